@@ -52,6 +52,7 @@
 	// Perform the actual pruning with logging.
 	prune.cleaner.Media().LogPruneUnused(ctx)
 	prune.cleaner.Media().LogUncacheRemote(ctx, t)
+	prune.cleaner.PreviewCard().LogUncacheRemote(ctx, t)
 
 	// Perform a cleanup of storage (for removed local dirs).
 	if err := prune.storage.Storage.Clean(ctx); err != nil {
@@ -21,18 +21,34 @@
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/cmd/gotosocial/action"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/db/bundb"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
+	gtsstorage "code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/internal/trans"
 )
 
-// Export exports info from the database into a file
+// Export exports info from the database and storage into a file
 var Export action.GTSAction = func(ctx context.Context) error {
 	var state state.State
 
+	path := config.GetAdminTransPath()
+	if path == "" {
+		return errors.New("no path set")
+	}
+
+	var since time.Time
+	if s := config.GetAdminTransSince(); s != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return fmt.Errorf("error parsing since timestamp: %w", err)
+		}
+	}
+
 	// Only set state DB connection.
 	// Don't need Actions or Workers for this.
 	dbConn, err := bundb.NewBunDBService(ctx, &state)
@@ -41,14 +57,21 @@
 	}
 	state.DB = dbConn
 
-	exporter := trans.NewExporter(dbConn)
-
-	path := config.GetAdminTransPath()
-	if path == "" {
-		return errors.New("no path set")
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
 	}
+	state.Storage = storage
 
-	if err := exporter.ExportMinimal(ctx, path); err != nil {
+	exporter := trans.NewExporter(dbConn, storage)
+
+	if config.GetAdminTransMinimal() {
+		err = exporter.ExportMinimal(ctx, path)
+	} else {
+		err = exporter.Export(ctx, path, since)
+	}
+	if err != nil {
 		return err
 	}
 
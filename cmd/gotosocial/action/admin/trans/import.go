@@ -26,13 +26,25 @@
 	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/db/bundb"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
+	gtsstorage "code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/internal/trans"
 )
 
-// Import imports info from a file into the database
+// Import imports info from a file into the database and storage
 var Import action.GTSAction = func(ctx context.Context) error {
 	var state state.State
 
+	path := config.GetAdminTransPath()
+	if path == "" {
+		return errors.New("no path set")
+	}
+
+	if config.GetAdminTransVerifyOnly() {
+		// Nothing is written when only
+		// verifying, so no DB or storage.
+		return trans.NewImporter(nil, nil).Verify(ctx, path)
+	}
+
 	// Only set state DB connection.
 	// Don't need Actions or Workers for this.
 	dbConn, err := bundb.NewBunDBService(ctx, &state)
@@ -41,12 +53,14 @@
 	}
 	state.DB = dbConn
 
-	importer := trans.NewImporter(dbConn)
-
-	path := config.GetAdminTransPath()
-	if path == "" {
-		return errors.New("no path set")
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
 	}
+	state.Storage = storage
+
+	importer := trans.NewImporter(dbConn, storage)
 
 	if err := importer.Import(ctx, path); err != nil {
 		return err
@@ -60,8 +60,10 @@
 	"code.superseriousbusiness.org/gotosocial/internal/state"
 	gtsstorage "code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/internal/subscriptions"
+	"code.superseriousbusiness.org/gotosocial/internal/suggestions"
 	"code.superseriousbusiness.org/gotosocial/internal/timeline"
 	"code.superseriousbusiness.org/gotosocial/internal/transport"
+	"code.superseriousbusiness.org/gotosocial/internal/trends"
 	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
 	"code.superseriousbusiness.org/gotosocial/internal/web"
 	"code.superseriousbusiness.org/gotosocial/internal/webpush"
@@ -397,10 +399,21 @@ func(context.Context, time.Time) {
 		return fmt.Errorf("error scheduling subscriptions jobs: %w", err)
 	}
 
+	// Schedule background trends calculation.
+	if err := trends.New(state).ScheduleJobs(); err != nil {
+		return fmt.Errorf("error scheduling trends jobs: %w", err)
+	}
+
+	// Schedule background suggestions calculation.
+	if err := suggestions.New(state).ScheduleJobs(); err != nil {
+		return fmt.Errorf("error scheduling suggestions jobs: %w", err)
+	}
+
 	// Initialize the specialized workers pools.
 	state.Workers.Client.Init(messages.ClientMsgIndices())
 	state.Workers.Federator.Init(messages.FederatorMsgIndices())
 	state.Workers.Delivery.Init(client)
+	state.Workers.Delivery.DB = state.DB
 	state.Workers.Client.Process = process.Workers().ProcessFromClientAPI
 	state.Workers.Federator.Process = process.Workers().ProcessFromFediAPI
 
@@ -412,6 +425,16 @@ func(context.Context, time.Time) {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule publication tasks for all existing scheduled statuses.
+	if err := process.Status().ScheduledStatusesScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling status publications: %w", err)
+	}
+
+	// Schedule stream tasks for when existing announcements start / end.
+	if err := process.Announcements().ScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling announcements: %w", err)
+	}
+
 	// Initialize metrics.
 	if err := observability.InitializeMetrics(state.DB); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
@@ -496,11 +519,16 @@ func(context.Context, time.Time) {
 	})
 
 	// build router modules
-	var idp oidc.IDP
+	var idps *oidc.Providers
 	if config.GetOIDCEnabled() {
-		idp, err = oidc.NewIDP(ctx)
+		idps, err = oidc.NewProviders(ctx, state)
 		if err != nil {
-			return fmt.Errorf("error creating oidc idp: %w", err)
+			return fmt.Errorf("error creating oidc providers: %w", err)
+		}
+
+		// Schedule background oidc groups syncing.
+		if err := idps.ScheduleJobs(); err != nil {
+			return fmt.Errorf("error scheduling oidc jobs: %w", err)
 		}
 	}
 
@@ -515,16 +543,16 @@ func(context.Context, time.Time) {
 	}
 
 	var (
-		authModule        = api.NewAuth(state, process, idp, routerSession, sessionName) // auth/oauth paths
-		clientModule      = api.NewClient(state, process)                                // api client endpoints
-		metricsModule     = api.NewMetrics()                                             // Metrics endpoints
-		healthModule      = api.NewHealth(dbService.Ready)                               // Health check endpoints
-		fileserverModule  = api.NewFileserver(process)                                   // fileserver endpoints
-		robotsModule      = api.NewRobots()                                              // robots.txt endpoint
-		wellKnownModule   = api.NewWellKnown(process)                                    // .well-known endpoints
-		nodeInfoModule    = api.NewNodeInfo(process)                                     // nodeinfo endpoint
-		activityPubModule = api.NewActivityPub(dbService, process)                       // ActivityPub endpoints
-		webModule         = web.New(dbService, process)                                  // web pages + user profiles + settings panels etc
+		authModule        = api.NewAuth(state, process, idps, routerSession, sessionName) // auth/oauth paths
+		clientModule      = api.NewClient(state, process)                                 // api client endpoints
+		metricsModule     = api.NewMetrics()                                              // Metrics endpoints
+		healthModule      = api.NewHealth(dbService.Ready)                                // Health check endpoints
+		fileserverModule  = api.NewFileserver(process)                                    // fileserver endpoints
+		robotsModule      = api.NewRobots()                                               // robots.txt endpoint
+		wellKnownModule   = api.NewWellKnown(process)                                     // .well-known endpoints
+		nodeInfoModule    = api.NewNodeInfo(process)                                      // nodeinfo endpoint
+		activityPubModule = api.NewActivityPub(dbService, process)                        // ActivityPub endpoints
+		webModule         = web.New(dbService, process)                                   // web pages + user profiles + settings panels etc
 	)
 
 	// Create per-route / per-grouping middlewares.
@@ -46,7 +46,9 @@
 	"code.superseriousbusiness.org/gotosocial/internal/state"
 	"code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/internal/subscriptions"
+	"code.superseriousbusiness.org/gotosocial/internal/suggestions"
 	"code.superseriousbusiness.org/gotosocial/internal/timeline"
+	"code.superseriousbusiness.org/gotosocial/internal/trends"
 	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
 	"code.superseriousbusiness.org/gotosocial/internal/web"
 	"code.superseriousbusiness.org/gotosocial/testrig"
@@ -264,11 +266,11 @@
 	})
 
 	// build router modules
-	var idp oidc.IDP
+	var idps *oidc.Providers
 	if config.GetOIDCEnabled() {
-		idp, err = oidc.NewIDP(ctx)
+		idps, err = oidc.NewProviders(ctx, state)
 		if err != nil {
-			return fmt.Errorf("error creating oidc idp: %w", err)
+			return fmt.Errorf("error creating oidc providers: %w", err)
 		}
 	}
 
@@ -283,16 +285,16 @@
 	}
 
 	var (
-		authModule        = api.NewAuth(state, processor, idp, routerSession, sessionName) // auth/oauth paths
-		clientModule      = api.NewClient(state, processor)                                // api client endpoints
-		metricsModule     = api.NewMetrics()                                               // Metrics endpoints
-		healthModule      = api.NewHealth(state.DB.Ready)                                  // Health check endpoints
-		fileserverModule  = api.NewFileserver(processor)                                   // fileserver endpoints
-		robotsModule      = api.NewRobots()                                                // robots.txt endpoint
-		wellKnownModule   = api.NewWellKnown(processor)                                    // .well-known endpoints
-		nodeInfoModule    = api.NewNodeInfo(processor)                                     // nodeinfo endpoint
-		activityPubModule = api.NewActivityPub(state.DB, processor)                        // ActivityPub endpoints
-		webModule         = web.New(state.DB, processor)                                   // web pages + user profiles + settings panels etc
+		authModule        = api.NewAuth(state, processor, idps, routerSession, sessionName) // auth/oauth paths
+		clientModule      = api.NewClient(state, processor)                                 // api client endpoints
+		metricsModule     = api.NewMetrics()                                                // Metrics endpoints
+		healthModule      = api.NewHealth(state.DB.Ready)                                   // Health check endpoints
+		fileserverModule  = api.NewFileserver(processor)                                    // fileserver endpoints
+		robotsModule      = api.NewRobots()                                                 // robots.txt endpoint
+		wellKnownModule   = api.NewWellKnown(processor)                                     // .well-known endpoints
+		nodeInfoModule    = api.NewNodeInfo(processor)                                      // nodeinfo endpoint
+		activityPubModule = api.NewActivityPub(state.DB, processor)                         // ActivityPub endpoints
+		webModule         = web.New(state.DB, processor)                                    // web pages + user profiles + settings panels etc
 	)
 
 	// these should be routed in order
@@ -329,6 +331,16 @@
 		return fmt.Errorf("error scheduling subscriptions jobs: %w", err)
 	}
 
+	// Schedule background trends calculation.
+	if err := trends.New(state).ScheduleJobs(); err != nil {
+		return fmt.Errorf("error scheduling trends jobs: %w", err)
+	}
+
+	// Schedule background suggestions calculation.
+	if err := suggestions.New(state).ScheduleJobs(); err != nil {
+		return fmt.Errorf("error scheduling suggestions jobs: %w", err)
+	}
+
 	// Finally start the main http server!
 	if err := route.Start(); err != nil {
 		return fmt.Errorf("error starting router: %w", err)
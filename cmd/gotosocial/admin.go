@@ -154,7 +154,7 @@ func adminCommands() *cobra.Command {
 
 	adminExportCmd := &cobra.Command{
 		Use:   "export",
-		Short: "export data from the database to file at the given path",
+		Short: "export data from the database and storage to file at the given path",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return preRun(preRunArgs{cmd: cmd})
 		},
@@ -163,11 +163,12 @@ func adminCommands() *cobra.Command {
 		},
 	}
 	config.AddAdminTrans(adminExportCmd)
+	config.AddAdminTransExport(adminExportCmd)
 	adminCmd.AddCommand(adminExportCmd)
 
 	adminImportCmd := &cobra.Command{
 		Use:   "import",
-		Short: "import data from a file into the database",
+		Short: "import data from a file into the database and storage",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return preRun(preRunArgs{cmd: cmd})
 		},
@@ -176,6 +177,7 @@ func adminCommands() *cobra.Command {
 		},
 	}
 	config.AddAdminTrans(adminImportCmd)
+	config.AddAdminTransImport(adminImportCmd)
 	adminCmd.AddCommand(adminImportCmd)
 
 	/*
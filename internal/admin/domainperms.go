@@ -204,12 +204,21 @@ func (a *Actions) DomainBlockF(
 //
 // It should be called asynchronously, since it can take a while when
 // there are many accounts present on the given domain.
+//
+// Blocks with limit severity have no side effects, as the limit
+// is applied on the fly through visibility and notification checks.
 func (a *Actions) domainBlockSideEffects(
 	ctx context.Context,
 	block *gtsmodel.DomainBlock,
 ) gtserror.MultiError {
 	var errs gtserror.MultiError
 
+	if block.IsLimit() {
+		// Limits leave the
+		// domain's data alone.
+		return errs
+	}
+
 	// If we have an instance entry for this domain,
 	// update it with the new block ID and clear all fields
 	instance, err := a.db.GetInstance(ctx, block.Domain)
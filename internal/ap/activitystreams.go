@@ -94,6 +94,12 @@ func PublicURI() *url.URL {
 	// and https://www.w3.org/TR/activitystreams-vocabulary/#dfn-tag
 	TagHashtag = "Hashtag"
 
+	// Link is in the AS spec as a type in its own right, but is
+	// used here to find FEP-e232 object links under the Tag property.
+	//
+	// See https://www.w3.org/TR/activitystreams-vocabulary/#dfn-link
+	TagLink = "Link"
+
 	// Not in the AS spec, just used internally to indicate
 	// that we don't *yet* know what type of Object something is.
 	ObjectUnknown = "Unknown"
@@ -106,6 +112,19 @@ func PublicURI() *url.URL {
 	ObjectReplyApproval    = "ReplyApproval"
 	ObjectAnnounceApproval = "AnnounceApproval"
 
+	/* FEP-044f stuff */
+
+	// Used internally to route accepts
+	// + rejects of quote interactions.
+	ActivityQuoteRequest = "QuoteRequest"
+
+	/* Misskey / Pleroma stuff */
+
+	// Emoji reaction to a status. Incoming EmojiReacts
+	// are normalized into Likes with content, see
+	// NormalizeIncomingEmojiReact.
+	ActivityEmojiReact = "EmojiReact"
+
 	/* Funkwhale stuff */
 
 	ObjectAlbum = "Album"
@@ -369,6 +369,7 @@ type CollectionPageBuilder interface {
 type ItemsPropertyBuilder interface {
 	AppendIRI(*url.URL)
 	AppendActivityStreamsCreate(vocab.ActivityStreamsCreate)
+	AppendActivityStreamsAnnounce(vocab.ActivityStreamsAnnounce)
 
 	// NOTE: add more of the items-property-like interface
 	// functions here as you require them for building pages.
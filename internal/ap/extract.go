@@ -246,6 +246,104 @@ func ExtractInReplyToURI(i WithInReplyTo) *url.URL {
 	return nil
 }
 
+// quoteProps are the (non-standard) properties that
+// different implementations use to indicate the URI
+// of a quoted status, in order of preference.
+var quoteProps = []string{
+	"quote",          // FEP-044f
+	"quoteUri",       // Fedibird
+	"quoteUrl",       // Akkoma / Pleroma / Misskey
+	"_misskey_quote", // Misskey
+}
+
+// ExtractQuoteURI extracts the URI of the status quoted
+// by the given Statusable. This is taken from the first
+// of the quote properties it can find, falling back to
+// a FEP-e232 object link in the tag property. Will return
+// nil if the Statusable doesn't quote anything.
+func ExtractQuoteURI(statusable Statusable) *url.URL {
+	if withUnknown, ok := statusable.(interface {
+		GetUnknownProperties() map[string]interface{}
+	}); ok {
+		unknown := withUnknown.GetUnknownProperties()
+		for _, prop := range quoteProps {
+			if iri := quoteIRI(unknown[prop]); iri != nil {
+				return iri
+			}
+		}
+	}
+
+	tagProp := statusable.GetActivityStreamsTag()
+	if tagProp == nil {
+		return nil
+	}
+
+	for iter := tagProp.Begin(); iter != tagProp.End(); iter = iter.Next() {
+		if !iter.IsActivityStreamsLink() {
+			continue
+		}
+
+		link := iter.GetActivityStreamsLink()
+		if link == nil {
+			continue
+		}
+
+		// Object links are identified
+		// by their ActivityStreams media type.
+		mediaTypeProp := link.GetActivityStreamsMediaType()
+		if mediaTypeProp == nil ||
+			!isASMediaType(mediaTypeProp.Get()) {
+			continue
+		}
+
+		hrefProp := link.GetActivityStreamsHref()
+		if hrefProp == nil || !hrefProp.IsIRI() {
+			continue
+		}
+
+		if iri := hrefProp.GetIRI(); isHTTPIRI(iri) {
+			return iri
+		}
+	}
+
+	return nil
+}
+
+// quoteIRI parses a quote property value, which
+// may be either a string IRI or an object with an id.
+func quoteIRI(v interface{}) *url.URL {
+	if m, ok := v.(map[string]interface{}); ok {
+		v = m["id"]
+	}
+
+	str, ok := v.(string)
+	if !ok || str == "" {
+		return nil
+	}
+
+	iri, err := url.Parse(str)
+	if err != nil || !isHTTPIRI(iri) {
+		return nil
+	}
+
+	return iri
+}
+
+// isHTTPIRI returns whether iri is an absolute http(s) IRI.
+func isHTTPIRI(iri *url.URL) bool {
+	return iri != nil && iri.Host != "" &&
+		(iri.Scheme == "http" || iri.Scheme == "https")
+}
+
+// isASMediaType returns whether the given media type
+// indicates an ActivityStreams object, as used by
+// FEP-e232 object links.
+func isASMediaType(mediaType string) bool {
+	mediaType = strings.ReplaceAll(mediaType, " ", "")
+	return mediaType == "application/activity+json" ||
+		mediaType == `application/ld+json;profile="https://www.w3.org/ns/activitystreams"`
+}
+
 // ExtractItemsURIs extracts each URI it can
 // find for an item from the provided WithItems.
 func ExtractItemsURIs(i WithItems) []*url.URL {
@@ -1028,6 +1126,53 @@ func ExtractObjectURIs(withObject WithObject) ([]*url.URL, error) {
 	return urls, nil
 }
 
+// ExtractAnnouncedIRIs extracts the IRIs of the objects
+// announced by the given Announce activity.
+//
+// FEP-1b12 groups (eg., Lemmy communities) announce whole
+// activities rather than just IRIs of objects. Announced
+// Creates are unwrapped to the IRIs of their objects,
+// while other announced activities (Like, Delete, etc)
+// are skipped, since we can't boost those.
+func ExtractAnnouncedIRIs(announce WithObject) []*url.URL {
+	objs := ExtractObjects(announce)
+	iris := make([]*url.URL, 0, len(objs))
+
+	for _, obj := range objs {
+		if obj.IsIRI() {
+			// Plain IRI, use as-is.
+			iris = append(iris, obj.GetIRI())
+			continue
+		}
+
+		t := obj.GetType()
+		if t == nil {
+			continue
+		}
+
+		activity, ok := ToActivityable(t)
+		if !ok {
+			// Not an activity, just
+			// use ID of the object.
+			if id := GetJSONLDId(t); id != nil {
+				iris = append(iris, id)
+			}
+			continue
+		}
+
+		if activity.GetTypeName() != ActivityCreate {
+			// Only announced
+			// Creates are usable.
+			continue
+		}
+
+		// Unwrap IRIs of created objects.
+		iris = append(iris, GetObjectIRIs(activity)...)
+	}
+
+	return iris
+}
+
 // ExtractVisibility extracts the gtsmodel.Visibility
 // of a given addressable with a To and CC property.
 //
@@ -1104,6 +1249,7 @@ func ExtractInteractionPolicy(
 		CanLike:     extractCanLike(policy.GetGoToSocialCanLike(), owner),
 		CanReply:    extractCanReply(policy.GetGoToSocialCanReply(), owner),
 		CanAnnounce: extractCanAnnounce(policy.GetGoToSocialCanAnnounce(), owner),
+		CanQuote:    extractCanQuote(policy.GetGoToSocialCanQuote(), owner),
 	}
 }
 
@@ -1179,6 +1325,30 @@ func extractCanAnnounce(
 	}
 }
 
+func extractCanQuote(
+	prop vocab.GoToSocialCanQuoteProperty,
+	owner *gtsmodel.Account,
+) gtsmodel.PolicyRules {
+	if prop == nil || prop.Len() != 1 {
+		return gtsmodel.PolicyRules{}
+	}
+
+	propIter := prop.At(0)
+	if !propIter.IsGoToSocialCanQuote() {
+		return gtsmodel.PolicyRules{}
+	}
+
+	withRules := propIter.Get()
+	if withRules == nil {
+		return gtsmodel.PolicyRules{}
+	}
+
+	return gtsmodel.PolicyRules{
+		Always:       extractPolicyValues(withRules.GetGoToSocialAlways(), owner),
+		WithApproval: extractPolicyValues(withRules.GetGoToSocialApprovalRequired(), owner),
+	}
+}
+
 func extractPolicyValues[T WithIRI](
 	prop Property[T],
 	owner *gtsmodel.Account,
@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExtractAnnounceTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractAnnounceTestSuite) announcedIRIs(rawJSON string) []string {
+	t, _ := suite.jsonToType(rawJSON)
+
+	announce, ok := t.(ap.Announceable)
+	if !ok {
+		suite.FailNow("type was not Announceable")
+	}
+
+	var iris []string
+	for _, iri := range ap.ExtractAnnouncedIRIs(announce) {
+		iris = append(iris, iri.String())
+	}
+
+	return iris
+}
+
+func (suite *ExtractAnnounceTestSuite) TestExtractAnnouncedIRI() {
+	iris := suite.announcedIRIs(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/01J8C6ZQ9F0J4YJ3W3WZ6T9H2E/activity",
+  "type": "Announce",
+  "actor": "https://example.org/users/someone",
+  "object": "https://example.org/users/someone_else/statuses/01J8C70F1ESC0J8A8X1CN9G4QR"
+}`)
+	suite.Equal([]string{
+		"https://example.org/users/someone_else/statuses/01J8C70F1ESC0J8A8X1CN9G4QR",
+	}, iris)
+}
+
+func (suite *ExtractAnnounceTestSuite) TestExtractAnnouncedCreate() {
+	// Lemmy communities announce the
+	// whole Create of a post in a thread.
+	iris := suite.announcedIRIs(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://lemmy.example.org/activities/announce/create/4cd9c8bb-2fb3-4fd4-a0c2-1e4a2d8f4ab5",
+  "type": "Announce",
+  "actor": "https://lemmy.example.org/c/knitting",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "cc": "https://lemmy.example.org/c/knitting/followers",
+  "object": {
+    "id": "https://lemmy.example.org/activities/create/1e5e4e2c-f2ba-4c06-8b0c-7a6f0a2df4d3",
+    "type": "Create",
+    "actor": "https://lemmy.example.org/u/someone",
+    "to": "https://www.w3.org/ns/activitystreams#Public",
+    "object": {
+      "id": "https://lemmy.example.org/post/1234",
+      "type": "Page",
+      "attributedTo": "https://lemmy.example.org/u/someone",
+      "name": "Look at my scarf"
+    }
+  }
+}`)
+	suite.Equal([]string{
+		"https://lemmy.example.org/post/1234",
+	}, iris)
+}
+
+func (suite *ExtractAnnounceTestSuite) TestExtractAnnouncedLike() {
+	// Announced Likes can't be boosted,
+	// so they shouldn't be extracted.
+	iris := suite.announcedIRIs(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://lemmy.example.org/activities/announce/like/5d0f9b2e-6e0b-4f7c-9d55-2f0d1d3c6a71",
+  "type": "Announce",
+  "actor": "https://lemmy.example.org/c/knitting",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "object": {
+    "id": "https://lemmy.example.org/activities/like/9d8e0c3a-6d43-46df-9cde-2a3b0f6c3a11",
+    "type": "Like",
+    "actor": "https://lemmy.example.org/u/someone",
+    "object": "https://lemmy.example.org/post/1234"
+  }
+}`)
+	suite.Empty(iris)
+}
+
+func TestExtractAnnounceTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractAnnounceTestSuite{})
+}
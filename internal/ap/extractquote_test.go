@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package ap_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExtractQuoteTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractQuoteTestSuite) extractQuoteURI(rawNote string) string {
+	statusable, err := ap.ResolveStatusable(
+		context.Background(),
+		io.NopCloser(
+			bytes.NewBufferString(rawNote),
+		),
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	if quoteURI == nil {
+		return ""
+	}
+
+	return quoteURI.String()
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteFEP044f() {
+	quoteURI := suite.extractQuoteURI(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/01JW0GHQ2A2Z1K4WBNAPJD3M0G",
+  "attributedTo": "https://example.org/users/someone",
+  "content": "look at this",
+  "quote": "https://example.org/users/someone_else/statuses/01JW0GJ5R0B9QWG5DGD57R3HKQ",
+  "type": "Note"
+}`)
+	suite.Equal("https://example.org/users/someone_else/statuses/01JW0GJ5R0B9QWG5DGD57R3HKQ", quoteURI)
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteMisskey() {
+	quoteURI := suite.extractQuoteURI(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://misskey.example.org/notes/a1b2c3d4e5",
+  "attributedTo": "https://misskey.example.org/users/a1b2c3d4e4",
+  "content": "look at this<br><br>RE: https://misskey.example.org/notes/a1b2c3d4e3",
+  "_misskey_quote": "https://misskey.example.org/notes/a1b2c3d4e3",
+  "quoteUrl": "https://misskey.example.org/notes/a1b2c3d4e3",
+  "type": "Note"
+}`)
+	suite.Equal("https://misskey.example.org/notes/a1b2c3d4e3", quoteURI)
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteLinkTag() {
+	quoteURI := suite.extractQuoteURI(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/01JW0GHQ2A2Z1K4WBNAPJD3M0G",
+  "attributedTo": "https://example.org/users/someone",
+  "content": "look at this",
+  "tag": [
+    {
+      "type": "Link",
+      "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+      "href": "https://example.org/users/someone_else/statuses/01JW0GJ5R0B9QWG5DGD57R3HKQ",
+      "name": "RE: https://example.org/users/someone_else/statuses/01JW0GJ5R0B9QWG5DGD57R3HKQ"
+    }
+  ],
+  "type": "Note"
+}`)
+	suite.Equal("https://example.org/users/someone_else/statuses/01JW0GJ5R0B9QWG5DGD57R3HKQ", quoteURI)
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteNone() {
+	quoteURI := suite.extractQuoteURI(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/users/someone/statuses/01JW0GHQ2A2Z1K4WBNAPJD3M0G",
+  "attributedTo": "https://example.org/users/someone",
+  "content": "nothing to see here",
+  "type": "Note"
+}`)
+	suite.Empty(quoteURI)
+}
+
+func TestExtractQuoteTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractQuoteTestSuite{})
+}
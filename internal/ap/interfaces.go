@@ -335,6 +335,16 @@ type Likeable interface {
 	WithObject
 }
 
+// EmojiReactable represents the minimum interface for an
+// emoji reaction, ie., a 'like' activity with content, and
+// (if the reaction is a custom emoji) the emoji in 'tag'.
+type EmojiReactable interface {
+	Likeable
+
+	WithContent
+	WithTag
+}
+
 // Blockable represents the minimum interface for an activitystreams 'block' activity.
 type Blockable interface {
 	WithJSONLDId
@@ -86,6 +86,45 @@ func NormalizeIncomingActivity(activity pub.Activity, rawJSON map[string]interfa
 	}
 }
 
+// NormalizeIncomingEmojiReact rewrites the type of an EmojiReact
+// in the given raw json object map, or in its 'object' (as for an
+// Undo), to Like, as go-fed has no EmojiReact type. Misskey-style
+// '_misskey_reaction' is copied to 'content' if the latter isn't
+// set, so that reactions can be told apart from plain Likes by
+// their content alone.
+//
+// This must be called *before* resolving the raw json into a type.
+func NormalizeIncomingEmojiReact(rawJSON map[string]interface{}) {
+	if !normalizeIncomingEmojiReact(rawJSON) {
+		// Not a reaction itself, check
+		// object for Undo of a reaction.
+		object, ok := rawJSON["object"].(map[string]interface{})
+		if ok {
+			normalizeIncomingEmojiReact(object)
+		}
+	}
+}
+
+// normalizeIncomingEmojiReact performs NormalizeIncomingEmojiReact
+// on the given raw map only, returning whether it was a Like type.
+func normalizeIncomingEmojiReact(rawJSON map[string]interface{}) bool {
+	switch rawJSON["type"] {
+	case ActivityEmojiReact:
+		rawJSON["type"] = ActivityLike
+	case ActivityLike:
+	default:
+		return false
+	}
+
+	if _, ok := rawJSON["content"]; !ok {
+		if reaction, ok := rawJSON["_misskey_reaction"].(string); ok {
+			rawJSON["content"] = reaction
+		}
+	}
+
+	return true
+}
+
 // normalizeContent normalizes the given content
 // string by sanitizing its HTML and minimizing it.
 //
@@ -638,9 +677,10 @@ func NormalizeOutgoingInteractionPolicyProp(item WithInteractionPolicy, rawJSON
 		"canLike",
 		"canReply",
 		"canAnnounce",
+		"canQuote",
 	} {
-		// Either "canAnnounce",
-		// "canLike", or "canApprove"
+		// Either "canAnnounce", "canLike",
+		// "canReply", or "canQuote".
 		rulesVal, ok := policyMap[rulesKey]
 		if !ok {
 			// Not set.
@@ -676,6 +716,80 @@ func NormalizeOutgoingInteractionPolicyProp(item WithInteractionPolicy, rawJSON
 	}
 }
 
+// NormalizeOutgoingQuoteProp copies the href of a FEP-e232
+// object link in the tag property of the given item to the
+// various quote properties used by other AP implementations,
+// as these don't (yet) all understand object links.
+//
+// Ie:
+//
+//	"tag": [
+//		{
+//			"type": "Link",
+//			"mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+//			"href": "https://example.org/users/someone/statuses/01J2736AWWJ3411CPR833F6D03",
+//			"name": "RE: https://example.org/users/someone/statuses/01J2736AWWJ3411CPR833F6D03"
+//		}
+//	]
+//
+// adds:
+//
+//	"quote": "https://example.org/users/someone/statuses/01J2736AWWJ3411CPR833F6D03",
+//	"quoteUri": "https://example.org/users/someone/statuses/01J2736AWWJ3411CPR833F6D03",
+//	"quoteUrl": "https://example.org/users/someone/statuses/01J2736AWWJ3411CPR833F6D03",
+//	"_misskey_quote": "https://example.org/users/someone/statuses/01J2736AWWJ3411CPR833F6D03"
+//
+// Noop for items without an object link.
+func NormalizeOutgoingQuoteProp(item WithTag, rawJSON map[string]interface{}) {
+	tags, ok := rawJSON["tag"].([]interface{})
+	if !ok {
+		// Single tag,
+		// or no tags.
+		tags = []interface{}{rawJSON["tag"]}
+	}
+
+	for _, tag := range tags {
+		tagMap, ok := tag.(map[string]interface{})
+		if !ok || tagMap["type"] != TagLink {
+			continue
+		}
+
+		mediaType, _ := tagMap["mediaType"].(string)
+		if !isASMediaType(mediaType) {
+			continue
+		}
+
+		href, ok := tagMap["href"].(string)
+		if !ok || href == "" {
+			continue
+		}
+
+		for _, prop := range quoteProps {
+			rawJSON[prop] = href
+		}
+
+		return
+	}
+}
+
+// NormalizeOutgoingEmojiReact sets the type of the given serialized
+// Like to EmojiReact if the Like has content, ie., if it's an emoji
+// reaction rather than a plain Like. '_misskey_reaction' is also set
+// to the content, for compatibility with Misskey and its forks.
+//
+// Noop if there's no content.
+func NormalizeOutgoingEmojiReact(item WithContent, rawJSON map[string]interface{}) {
+	content := ExtractContent(item).Content
+	if content == "" {
+		// Plain Like,
+		// nothing to do.
+		return
+	}
+
+	rawJSON["type"] = ActivityEmojiReact
+	rawJSON["_misskey_reaction"] = content
+}
+
 // NormalizeOutgoingObjectProp normalizes each Object entry in the rawJSON of the given
 // item by calling custom serialization / normalization functions on them in turn.
 //
@@ -747,6 +861,14 @@ func NormalizeOutgoingObjectProp(item WithObject, rawJSON map[string]interface{}
 		default:
 			// No custom serializer for this type; serialize as normal.
 			objectSer, err = objectType.Serialize()
+
+			// Undo of an emoji reaction
+			// should have EmojiReact type.
+			if err == nil && tn == ActivityLike {
+				if withContent, ok := objectType.(WithContent); ok {
+					NormalizeOutgoingEmojiReact(withContent, objectSer)
+				}
+			}
 		}
 
 		if err != nil {
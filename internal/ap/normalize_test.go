@@ -18,8 +18,11 @@
 package ap_test
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
+	"code.superseriousbusiness.org/activity/streams"
 	"code.superseriousbusiness.org/activity/streams/vocab"
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	"code.superseriousbusiness.org/gotosocial/testrig"
@@ -470,6 +473,112 @@ func (suite *NormalizeTestSuite) TestNormalizeStatusableName() {
 	suite.Equal(`WARNING: #WEIRD #nameEE ;;;;a;;a;asv    khop8273987(*^&^)`, ap.ExtractName(statusable))
 }
 
+func (suite *NormalizeTestSuite) emojiReactToType(rawJson string) vocab.Type {
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(rawJson), &raw); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ap.NormalizeIncomingEmojiReact(raw)
+
+	t, err := streams.ToType(context.Background(), raw)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return t
+}
+
+func (suite *NormalizeTestSuite) TestNormalizeIncomingEmojiReact() {
+	t := suite.emojiReactToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"actor": "https://example.org/users/someone",
+		"content": "🐸",
+		"id": "https://example.org/activities/01JWB3E5C2WJ8W3TZ6QJ1QWQ0M",
+		"object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+		"type": "EmojiReact"
+	}`)
+
+	like, ok := t.(vocab.ActivityStreamsLike)
+	if !ok {
+		suite.FailNowf("", "expected Like, got %T", t)
+	}
+
+	suite.Equal("🐸", ap.ExtractContent(like).Content)
+}
+
+func (suite *NormalizeTestSuite) TestNormalizeIncomingMisskeyReaction() {
+	t := suite.emojiReactToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"_misskey_reaction": ":blobcat:",
+		"actor": "https://example.org/users/someone",
+		"id": "https://example.org/likes/9x9dqsy7wd",
+		"object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+		"type": "Like"
+	}`)
+
+	like, ok := t.(vocab.ActivityStreamsLike)
+	if !ok {
+		suite.FailNowf("", "expected Like, got %T", t)
+	}
+
+	suite.Equal(":blobcat:", ap.ExtractContent(like).Content)
+}
+
+func (suite *NormalizeTestSuite) TestNormalizeIncomingUndoEmojiReact() {
+	t := suite.emojiReactToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"actor": "https://example.org/users/someone",
+		"id": "https://example.org/activities/01JWB3M1P4VJ5V9T0G3DWQ1XAW",
+		"object": {
+			"actor": "https://example.org/users/someone",
+			"content": "🐸",
+			"id": "https://example.org/activities/01JWB3E5C2WJ8W3TZ6QJ1QWQ0M",
+			"object": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+			"type": "EmojiReact"
+		},
+		"type": "Undo"
+	}`)
+
+	undo, ok := t.(vocab.ActivityStreamsUndo)
+	if !ok {
+		suite.FailNowf("", "expected Undo, got %T", t)
+	}
+
+	object := undo.GetActivityStreamsObject()
+	if object.Len() != 1 {
+		suite.FailNow("expected one object")
+	}
+
+	like := object.At(0).GetActivityStreamsLike()
+	if like == nil {
+		suite.FailNow("expected Like object")
+	}
+
+	suite.Equal("🐸", ap.ExtractContent(like).Content)
+}
+
+func (suite *NormalizeTestSuite) TestNormalizeOutgoingEmojiReact() {
+	like := suite.emojiReactToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"actor": "http://localhost:8080/users/the_mighty_zork",
+		"content": "🐸",
+		"id": "http://localhost:8080/users/the_mighty_zork/liked/01JWB3E5C2WJ8W3TZ6QJ1QWQ0M",
+		"object": "https://example.org/users/someone/statuses/01JWB3VDWXJ6F0A5A8C7S1X3S6",
+		"type": "Like"
+	}`)
+
+	suite.Equal(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "_misskey_reaction": "🐸",
+  "actor": "http://localhost:8080/users/the_mighty_zork",
+  "content": "🐸",
+  "id": "http://localhost:8080/users/the_mighty_zork/liked/01JWB3E5C2WJ8W3TZ6QJ1QWQ0M",
+  "object": "https://example.org/users/someone/statuses/01JWB3VDWXJ6F0A5A8C7S1X3S6",
+  "type": "EmojiReact"
+}`, suite.typeToJson(like))
+}
+
 func TestNormalizeTestSuite(t *testing.T) {
 	suite.Run(t, new(NormalizeTestSuite))
 }
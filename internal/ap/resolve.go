@@ -240,6 +240,10 @@ func decodeType(
 	// Done with body.
 	_ = body.Close()
 
+	// Rewrite any EmojiReact as a Like,
+	// so that go-fed can resolve it.
+	NormalizeIncomingEmojiReact(raw)
+
 	// Resolve an ActivityStreams type.
 	t, err := streams.ToType(ctx, raw)
 	if err != nil {
@@ -37,8 +37,9 @@
 //   - OrderedCollection:       'orderedItems' property will always be made into an array.
 //   - OrderedCollectionPage:   'orderedItems' property will always be made into an array.
 //   - Any Accountable type:    'attachment' property will always be made into an array.
-//   - Any Statusable type:     'attachment' property will always be made into an array; 'content', 'contentMap', and 'interactionPolicy' will be normalized.
+//   - Any Statusable type:     'attachment' property will always be made into an array; 'content', 'contentMap', and 'interactionPolicy' will be normalized; quote properties will be added for any quote link in 'tag'.
 //   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above.
+//   - Like with content:       will be serialized as an EmojiReact.
 func Serialize(t vocab.Type) (m map[string]interface{}, e error) {
 	switch tn := t.GetTypeName(); {
 	case tn == ObjectOrderedCollection ||
@@ -153,6 +154,7 @@ func serializeStatusable(t vocab.Type, includeContext bool) (map[string]interfac
 
 	NormalizeOutgoingAttachmentProp(statusable, data)
 	NormalizeOutgoingContentProp(statusable, data)
+	NormalizeOutgoingQuoteProp(statusable, data)
 	if ipa, ok := statusable.(InteractionPolicyAware); ok {
 		NormalizeOutgoingInteractionPolicyProp(ipa, data)
 	}
@@ -185,5 +187,11 @@ func serializeActivityable(t vocab.Type, includeContext bool) (map[string]interf
 		return nil, err
 	}
 
+	if activityable.GetTypeName() == ActivityLike {
+		if withContent, ok := activityable.(WithContent); ok {
+			NormalizeOutgoingEmojiReact(withContent, data)
+		}
+	}
+
 	return data, nil
 }
@@ -61,13 +61,13 @@ func (a *Auth) Route(r *router.Router, m ...gin.HandlerFunc) {
 func NewAuth(
 	state *state.State,
 	p *processing.Processor,
-	idp oidc.IDP,
+	idps *oidc.Providers,
 	routerSession *gtsmodel.RouterSession,
 	sessionName string,
 ) *Auth {
 	return &Auth{
 		routerSession: routerSession,
 		sessionName:   sessionName,
-		auth:          auth.New(state, p, idp),
+		auth:          auth.New(state, p, idps),
 	}
 }
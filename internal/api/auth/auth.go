@@ -31,22 +31,26 @@
 		paths prefixed with 'auth'
 	*/
 
-	AuthSignInPath          = "/sign_in"
-	Auth2FAPath             = "/2fa"
-	AuthCheckYourEmailPath  = "/check_your_email"
-	AuthWaitForApprovalPath = "/wait_for_approval"
-	AuthAccountDisabledPath = "/account_disabled"
-	AuthCallbackPath        = "/callback"
+	AuthSignInPath            = "/sign_in"
+	AuthSignInPasskeyPath     = AuthSignInPath + "/passkey"
+	Auth2FAPath               = "/2fa"
+	AuthCheckYourEmailPath    = "/check_your_email"
+	AuthWaitForApprovalPath   = "/wait_for_approval"
+	AuthAccountDisabledPath   = "/account_disabled"
+	AuthCallbackPath          = "/callback"
+	AuthSignOutPath           = "/sign_out"
+	AuthBackchannelLogoutPath = "/backchannel_logout"
 
 	/*
 		paths prefixed with 'oauth'
 	*/
 
-	OauthAuthorizePath = "/authorize"
-	OauthFinalizePath  = "/finalize"
-	OauthOOBTokenPath  = "/oob"   // #nosec G101 else we get a hardcoded credentials warning
-	OauthTokenPath     = "/token" // #nosec G101 else we get a hardcoded credentials warning
-	OauthRevokePath    = "/revoke"
+	OauthAuthorizePath  = "/authorize"
+	OauthFinalizePath   = "/finalize"
+	OauthOOBTokenPath   = "/oob"   // #nosec G101 else we get a hardcoded credentials warning
+	OauthTokenPath      = "/token" // #nosec G101 else we get a hardcoded credentials warning
+	OauthRevokePath     = "/revoke"
+	OauthIntrospectPath = "/introspect"
 
 	/*
 		params / session keys
@@ -54,6 +58,7 @@
 
 	callbackStateParam       = "state"
 	callbackCodeParam        = "code"
+	signInProviderParam      = "provider"
 	sessionUserID            = "userid"
 	sessionUserIDAwaiting2FA = "userid_awaiting_2fa"
 	sessionClientID          = "client_id"
@@ -64,28 +69,36 @@
 	sessionInternalState     = "internal_state"
 	sessionClientState       = "client_state"
 	sessionClaims            = "claims"
+	sessionOIDCProvider      = "oidc_provider"
 	sessionAppID             = "app_id"
+	sessionWebAuthnChallenge = "webauthn_challenge"
+
+	/*
+		assets
+	*/
+
+	jsWebAuthn = "/assets/dist/webauthn.js"
 )
 
 type Module struct {
 	state     *state.State
 	processor *processing.Processor
-	idp       oidc.IDP
+	idps      *oidc.Providers
 }
 
 // New returns an Auth module which provides
 // both 'oauth' and 'auth' endpoints.
 //
-// It is safe to pass a nil idp if oidc is disabled.
+// It is safe to pass nil idps if oidc is disabled.
 func New(
 	state *state.State,
 	processor *processing.Processor,
-	idp oidc.IDP,
+	idps *oidc.Providers,
 ) *Module {
 	return &Module{
 		state:     state,
 		processor: processor,
-		idp:       idp,
+		idps:      idps,
 	}
 }
 
@@ -93,15 +106,19 @@ func New(
 func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, AuthSignInPath, m.SignInGETHandler)
 	attachHandler(http.MethodPost, AuthSignInPath, m.SignInPOSTHandler)
+	attachHandler(http.MethodPost, AuthSignInPasskeyPath, m.SignInPasskeyPOSTHandler)
 	attachHandler(http.MethodGet, Auth2FAPath, m.TwoFactorCodeGETHandler)
 	attachHandler(http.MethodPost, Auth2FAPath, m.TwoFactorCodePOSTHandler)
 	attachHandler(http.MethodGet, AuthCallbackPath, m.CallbackGETHandler)
+	attachHandler(http.MethodPost, AuthSignOutPath, m.SignOutPOSTHandler)
+	attachHandler(http.MethodPost, AuthBackchannelLogoutPath, m.BackchannelLogoutPOSTHandler)
 }
 
 // RouteOAuth routes all paths that should have an 'oauth' prefix
 func (m *Module) RouteOAuth(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, OauthTokenPath, m.TokenPOSTHandler)
 	attachHandler(http.MethodPost, OauthRevokePath, m.TokenRevokePOSTHandler)
+	attachHandler(http.MethodPost, OauthIntrospectPath, m.TokenIntrospectPOSTHandler)
 	attachHandler(http.MethodGet, OauthAuthorizePath, m.AuthorizeGETHandler)
 	attachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
 	attachHandler(http.MethodPost, OauthFinalizePath, m.FinalizePOSTHandler)
@@ -51,7 +51,7 @@ type AuthStandardTestSuite struct {
 	federator    *federation.Federator
 	processor    *processing.Processor
 	emailSender  email.Sender
-	idp          oidc.IDP
+	idps         *oidc.Providers
 
 	// standard suite models
 	testTokens       map[string]*gtsmodel.Token
@@ -96,7 +96,7 @@ func (suite *AuthStandardTestSuite) SetupTest() {
 		testrig.NewNoopWebPushSender(),
 		suite.mediaManager,
 	)
-	suite.authModule = auth.New(&suite.state, suite.processor, suite.idp)
+	suite.authModule = auth.New(&suite.state, suite.processor, suite.idps)
 
 	testrig.StandardDBSetup(suite.db, suite.testAccounts)
 	testrig.StartNoopWorkers(&suite.state)
@@ -23,7 +23,6 @@
 	"fmt"
 	"net"
 	"net/http"
-	"slices"
 	"strings"
 
 	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
@@ -91,13 +90,29 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 
-	claims, errWithCode := m.idp.HandleCallback(c.Request.Context(), code)
+	idp := m.sessionIDP(s)
+	if idp == nil {
+		m.mustClearSession(s)
+		err := fmt.Errorf("key %s was not found in session", sessionOIDCProvider)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	claims, errWithCode := idp.HandleCallback(c.Request.Context(), code)
 	if errWithCode != nil {
 		m.mustClearSession(s)
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
+	// Check user permissions on login
+	if !idp.AllowedGroup(claims.Groups) {
+		m.mustClearSession(s)
+		err := fmt.Errorf("User groups %+v do not include an allowed group", claims.Groups)
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
 	// We can use the client_id on the session to retrieve
 	// info about the app associated with the client_id
 	clientID, ok := s.Get(sessionClientID).(string)
@@ -122,7 +137,7 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 
-	user, errWithCode := m.fetchUserForClaims(c.Request.Context(), claims, net.IP(c.ClientIP()), app.ID)
+	user, errWithCode := m.fetchUserForClaims(c.Request.Context(), idp, claims)
 	if errWithCode != nil {
 		m.mustClearSession(s)
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -164,10 +179,9 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 
-	// Check user permissions on login
-	if !allowedGroup(claims.Groups) {
-		err := fmt.Errorf("User groups %+v do not include an allowed group", claims.Groups)
-		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+	if err := m.idps.Login(c.Request.Context(), idp, user, claims); err != nil {
+		m.mustClearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
 		return
 	}
 
@@ -245,13 +259,28 @@ func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	// and the idp that returned them
+	idp := m.sessionIDP(s)
+	if idp == nil {
+		err := fmt.Errorf("key %s was not found in session", sessionOIDCProvider)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
 	// we're now ready to actually create the user
-	user, errWithCode := m.createUserFromOIDC(c.Request.Context(), claims, form, net.IP(c.ClientIP()), appID)
+	user, errWithCode := m.createUserFromOIDC(c.Request.Context(), idp, claims, form, net.IP(c.ClientIP()), appID)
 	if errWithCode != nil {
 		m.mustClearSession(s)
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
+
+	if err := m.idps.Login(c.Request.Context(), idp, user, claims); err != nil {
+		m.mustClearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+
 	s.Delete(sessionClaims)
 	s.Delete(sessionAppID)
 	s.Set(sessionUserID, user.ID)
@@ -263,23 +292,39 @@ func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
 }
 
-func (m *Module) fetchUserForClaims(ctx context.Context, claims *oidc.Claims, ip net.IP, appID string) (*gtsmodel.User, gtserror.WithCode) {
+// sessionIDP returns the idp the user chose to sign
+// in through, falling back to the only configured
+// idp, or nil if neither is available.
+func (m *Module) sessionIDP(s sessions.Session) oidc.IDP {
+	if providerID, ok := s.Get(sessionOIDCProvider).(string); ok {
+		return m.idps.Get(providerID)
+	}
+
+	if idps := m.idps.List(); len(idps) == 1 {
+		return idps[0]
+	}
+
+	return nil
+}
+
+func (m *Module) fetchUserForClaims(ctx context.Context, idp oidc.IDP, claims *oidc.Claims) (*gtsmodel.User, gtserror.WithCode) {
 	if claims.Sub == "" {
 		err := errors.New("no sub claim found - is your provider OIDC compliant?")
 		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
-	user, err := m.state.DB.GetUserByExternalID(ctx, claims.Sub)
-	if err == nil {
-		return user, nil
-	}
-	if err != db.ErrNoEntries {
-		err := fmt.Errorf("error checking database for externalID %s: %s", claims.Sub, err)
+	user, err := m.idps.GetUser(ctx, idp, claims)
+	if err != nil {
+		err := fmt.Errorf("error checking database for externalID %s: %w", claims.Sub, err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
+	if user != nil {
+		return user, nil
+	}
 	if !config.GetOIDCLinkExisting() {
 		return nil, nil
 	}
-	// fallback to email if we want to link existing users
+	// fallback to email if we want to link existing users;
+	// the identity itself is linked by Login once returned
 	user, err = m.state.DB.GetUserByEmailAddress(ctx, claims.Email)
 	if err == db.ErrNoEntries {
 		return nil, nil
@@ -287,18 +332,10 @@ func (m *Module) fetchUserForClaims(ctx context.Context, claims *oidc.Claims, ip
 		err := fmt.Errorf("error checking database for email %s: %s", claims.Email, err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
-	// at this point we have found a matching user but still need to link the newly received external ID
-
-	user.ExternalID = claims.Sub
-	err = m.state.DB.UpdateUser(ctx, user, "external_id")
-	if err != nil {
-		err := fmt.Errorf("error linking existing user %s: %s", claims.Email, err)
-		return nil, gtserror.NewErrorInternalError(err)
-	}
 	return user, nil
 }
 
-func (m *Module) createUserFromOIDC(ctx context.Context, claims *oidc.Claims, extraInfo *extraInfo, ip net.IP, appID string) (*gtsmodel.User, gtserror.WithCode) {
+func (m *Module) createUserFromOIDC(ctx context.Context, idp oidc.IDP, claims *oidc.Claims, extraInfo *extraInfo, ip net.IP, appID string) (*gtsmodel.User, gtserror.WithCode) {
 	// Check if the claimed email address is available for use.
 	emailAvailable, err := m.state.DB.IsEmailAvailable(ctx, claims.Email)
 	if err != nil {
@@ -312,7 +349,7 @@ func (m *Module) createUserFromOIDC(ctx context.Context, claims *oidc.Claims, ex
 		return nil, gtserror.NewErrorConflict(err, help)
 	}
 
-	if !allowedGroup(claims.Groups) {
+	if !idp.AllowedGroup(claims.Groups) {
 		err := fmt.Errorf("User groups %+v do not include an allowed group", claims.Groups)
 		return nil, gtserror.NewErrorUnauthorized(err, err.Error())
 	}
@@ -348,9 +385,10 @@ func (m *Module) createUserFromOIDC(ctx context.Context, claims *oidc.Claims, ex
 	)
 
 	// If one of the claimed groups corresponds to one of
-	// the configured admin OIDC groups, create this user
-	// as an admin.
-	admin := adminGroup(claims.Groups)
+	// the idp's admin OIDC groups, create this user as an
+	// admin. Other roles are synced once the user's external
+	// identity is linked to them.
+	admin := idp.AdminGroup(claims.Groups)
 
 	// Create the user! This will also create an account and
 	// store it in the database, so we don't need to do that.
@@ -360,7 +398,6 @@ func (m *Module) createUserFromOIDC(ctx context.Context, claims *oidc.Claims, ex
 		Password:      password,
 		SignUpIP:      ip,
 		AppID:         appID,
-		ExternalID:    claims.Sub,
 		PreApproved:   preApproved,
 		EmailVerified: emailVerified,
 		Admin:         admin,
@@ -372,41 +409,3 @@ func (m *Module) createUserFromOIDC(ctx context.Context, claims *oidc.Claims, ex
 
 	return user, nil
 }
-
-// adminGroup returns true if one of the given OIDC
-// groups is equal to at least one admin OIDC group.
-func adminGroup(groups []string) bool {
-	adminGroups := config.GetOIDCAdminGroups()
-	for _, claimedGroup := range groups {
-		if slices.ContainsFunc(adminGroups, func(allowedGroup string) bool {
-			return strings.EqualFold(claimedGroup, allowedGroup)
-		}) {
-			return true
-		}
-	}
-
-	// User is in no admin groups,
-	// ∴ user is not an admin.
-	return false
-}
-
-// allowedGroup returns true if one of the given OIDC
-// groups is equal to at least one allowed OIDC group.
-func allowedGroup(groups []string) bool {
-	allowedGroups := config.GetOIDCAllowedGroups()
-	if len(allowedGroups) == 0 {
-		// If no groups are configured, allow access (for backwards compatibility)
-		return true
-	}
-	for _, claimedGroup := range groups {
-		if slices.ContainsFunc(allowedGroups, func(allowedGroup string) bool {
-			return strings.EqualFold(claimedGroup, allowedGroup)
-		}) {
-			return true
-		}
-	}
-
-	// User is in no allowed groups,
-	// ∴ user is not allowed to log in
-	return false
-}
@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"net/http"
+
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	oautherr "code.superseriousbusiness.org/oauth2/v4/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// TokenIntrospectPOSTHandler swagger:operation POST /oauth/introspect oauthTokenIntrospect
+//
+// Introspect an access token to determine whether it's active, and to whom and with which scopes it was issued.
+//
+// See: https://www.rfc-editor.org/rfc/rfc7662.html
+//
+// The requesting client must authenticate itself, either with `client_id` and `client_secret`
+// form parameters, or with HTTP Basic authentication using the client ID and secret.
+//
+// Only tokens issued to the requesting client are reported as active; tokens belonging
+// to any other client are reported as `active: false`, unless the requesting client is
+// listed in the instance's `advanced-introspection-clients` setting.
+//
+//	---
+//	tags:
+//	- oauth
+//
+//	consumes:
+//	- application/x-www-form-urlencoded
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: client_id
+//		in: formData
+//		description: The client ID, obtained during app registration.
+//		type: string
+//	-
+//		name: client_secret
+//		in: formData
+//		description: The client secret, obtained during app registration.
+//		type: string
+//	-
+//		name: token
+//		in: formData
+//		description: The access token to introspect.
+//		type: string
+//		required: true
+//	-
+//		name: token_type_hint
+//		in: formData
+//		description: Hint about the type of the token. Only `access_token` is supported.
+//		type: string
+//
+//	responses:
+//		'200':
+//			description: >-
+//				Introspection result. If the token is not active, only `active: false` will be returned.
+//			schema:
+//				"$ref": "#/definitions/oauthTokenIntrospection"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TokenIntrospectPOSTHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Don't set `binding:"required"` on these
+	// fields as we want to validate them ourself.
+	form := &struct {
+		ClientID      string `form:"client_id"`
+		ClientSecret  string `form:"client_secret"`
+		Token         string `form:"token"`
+		TokenTypeHint string `form:"token_type_hint"`
+	}{}
+	if err := c.ShouldBind(form); err != nil {
+		errWithCode := gtserror.NewErrorBadRequest(err, err.Error())
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Client may authenticate using
+	// HTTP Basic auth instead of form.
+	if clientID, clientSecret, ok := c.Request.BasicAuth(); ok {
+		form.ClientID = clientID
+		form.ClientSecret = clientSecret
+	}
+
+	if form.Token == "" {
+		errWithCode := gtserror.NewErrorBadRequest(
+			oautherr.ErrInvalidRequest,
+			"token not set",
+		)
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	if form.ClientID == "" {
+		errWithCode := gtserror.NewErrorUnauthorized(
+			oautherr.ErrInvalidClient,
+			"client_id not set",
+		)
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	if form.ClientSecret == "" {
+		errWithCode := gtserror.NewErrorUnauthorized(
+			oautherr.ErrInvalidClient,
+			"client_secret not set",
+		)
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	introspection, errWithCode := m.processor.OAuthIntrospectToken(
+		c.Request.Context(),
+		form.ClientID,
+		form.ClientSecret,
+		form.Token,
+	)
+	if errWithCode != nil {
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, introspection)
+}
@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type IntrospectTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *IntrospectTestSuite) introspect(form map[string][]string) (int, string) {
+	// Prepare request form.
+	requestBody, w, err := testrig.CreateMultipartFormData(nil, form)
+	if err != nil {
+		panic(err)
+	}
+
+	// Prepare request ctx.
+	ctx, recorder := suite.newContext(
+		http.MethodPost,
+		"/oauth/introspect",
+		requestBody.Bytes(),
+		w.FormDataContentType(),
+	)
+
+	// Submit the introspect request.
+	suite.authModule.TokenIntrospectPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	// Read json bytes.
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Indent nicely.
+	dst := bytes.Buffer{}
+	if err := json.Indent(&dst, b, "", "  "); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, dst.String()
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectOK() {
+	var (
+		app   = suite.testApplications["application_1"]
+		token = suite.testTokens["local_account_1"]
+	)
+
+	code, body := suite.introspect(map[string][]string{
+		"token":         {token.Access},
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+	})
+
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{
+  "active": true,
+  "scope": "read write push",
+  "client_id": "01F8MGV8AC3NGSJW0FE8W1BV70",
+  "username": "the_mighty_zork",
+  "token_type": "Bearer",
+  "exp": 2524663328,
+  "iat": 1654874528,
+  "sub": "01F8MH1H7YV1Z7D2C8K2730QBF"
+}`, body)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectUnknownToken() {
+	app := suite.testApplications["application_1"]
+
+	code, body := suite.introspect(map[string][]string{
+		"token":         {"not a real token"},
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+	})
+
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{
+  "active": false
+}`, body)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectOtherClientToken() {
+	var (
+		app   = suite.testApplications["application_2"]
+		token = suite.testTokens["local_account_1"]
+	)
+
+	// Token belongs to application_1,
+	// so application_2 shouldn't see it.
+	code, body := suite.introspect(map[string][]string{
+		"token":         {token.Access},
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+	})
+
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{
+  "active": false
+}`, body)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectOtherClientTokenAllowed() {
+	var (
+		app   = suite.testApplications["application_2"]
+		token = suite.testTokens["local_account_1"]
+	)
+
+	// Token belongs to application_1, but
+	// application_2 is trusted to see it.
+	config.SetAdvancedIntrospectionClients([]string{app.ClientID})
+
+	code, body := suite.introspect(map[string][]string{
+		"token":         {token.Access},
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+	})
+
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{
+  "active": true,
+  "scope": "read write push",
+  "client_id": "01F8MGV8AC3NGSJW0FE8W1BV70",
+  "username": "the_mighty_zork",
+  "token_type": "Bearer",
+  "exp": 2524663328,
+  "iat": 1654874528,
+  "sub": "01F8MH1H7YV1Z7D2C8K2730QBF"
+}`, body)
+}
+
+func (suite *IntrospectTestSuite) TestIntrospectWrongSecret() {
+	var (
+		app   = suite.testApplications["application_1"]
+		token = suite.testTokens["local_account_1"]
+	)
+
+	code, body := suite.introspect(map[string][]string{
+		"token":         {token.Access},
+		"client_id":     {app.ClientID},
+		"client_secret": {"Not the right secret :( :( :("},
+	})
+
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.Equal(`{
+  "error": "invalid_client",
+  "error_description": "Unauthorized: You are not authorized to introspect tokens"
+}`, body)
+}
+
+func TestIntrospectTestSuite(t *testing.T) {
+	suite.Run(t, new(IntrospectTestSuite))
+}
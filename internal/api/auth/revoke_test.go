@@ -26,6 +26,7 @@
 	"testing"
 
 	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 	"code.superseriousbusiness.org/gotosocial/testrig"
 	"github.com/stretchr/testify/suite"
 )
@@ -72,7 +73,7 @@ func (suite *RevokeTestSuite) TestRevokeOK() {
 	// Ensure token now gone.
 	_, err = suite.state.DB.GetTokenByAccess(
 		context.Background(),
-		token.Access,
+		oauth.HashToken(token.Access),
 	)
 	suite.ErrorIs(err, db.ErrNoEntries)
 }
@@ -131,7 +132,7 @@ func (suite *RevokeTestSuite) TestRevokeWrongSecret() {
 	// Ensure token still there.
 	_, err = suite.state.DB.GetTokenByAccess(
 		context.Background(),
-		token.Access,
+		oauth.HashToken(token.Access),
 	)
 	suite.NoError(err)
 }
@@ -189,7 +190,7 @@ func (suite *RevokeTestSuite) TestRevokeNoClientID() {
 	// Ensure token still there.
 	_, err = suite.state.DB.GetTokenByAccess(
 		context.Background(),
-		token.Access,
+		oauth.HashToken(token.Access),
 	)
 	suite.NoError(err)
 }
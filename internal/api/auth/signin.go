@@ -30,6 +30,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/internal/oidc"
 	"codeberg.org/gruf/go-byteutil"
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
@@ -47,8 +48,13 @@
 // When submitted, the form will POST to the sign-
 // in page, which will be handled by SignInPOSTHandler.
 //
-// If an idp provider is set, then the user will
-// be redirected to that to do their sign in.
+// If oidc is enabled, then the user will be redirected
+// to the idp to do their sign in. If several idps are
+// configured, the user is first asked to choose one,
+// and the page is served again with ?provider={id}.
+//
+// Otherwise, the page also offers passwordless sign
+// in with a passkey, handled by SignInPasskeyPOSTHandler.
 func (m *Module) SignInGETHandler(c *gin.Context) {
 	if _, err := apiutil.NegotiateAccept(c, apiutil.HTMLAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
@@ -58,21 +64,7 @@ func (m *Module) SignInGETHandler(c *gin.Context) {
 	if config.GetOIDCEnabled() {
 		// IDP provider is in use, so redirect to it
 		// instead of serving our own sign in page.
-		//
-		// We need the internal state to know where
-		// to redirect to.
-		internalState := m.mustStringFromSession(
-			c,
-			sessions.Default(c),
-			sessionInternalState,
-		)
-		if internalState == "" {
-			// Error already
-			// written.
-			return
-		}
-
-		c.Redirect(http.StatusSeeOther, m.idp.AuthCodeURL(internalState))
+		m.signInOIDC(c)
 		return
 	}
 
@@ -84,12 +76,102 @@ func (m *Module) SignInGETHandler(c *gin.Context) {
 		return
 	}
 
+	// Prepare options for
+	// passwordless sign in.
+	s := sessions.Default(c)
+	webAuthnOptions, errWithCode := m.webAuthnOptions(c, s, nil)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+	m.mustSaveSession(s)
+
 	apiutil.TemplateWebPage(c, apiutil.WebPage{
 		Template: "sign-in.tmpl",
 		Instance: instance,
+		Javascript: []apiutil.JavascriptEntry{
+			{
+				Src:   jsWebAuthn,
+				Async: true,
+				Defer: true,
+			},
+		},
+		Extra: map[string]any{
+			"webauthnOptions": webAuthnOptions,
+		},
 	})
 }
 
+// signInOIDC redirects to the idp chosen by
+// the user (or the only one configured) to do
+// their sign in, or renders a page for the
+// user to choose one if they haven't yet.
+func (m *Module) signInOIDC(c *gin.Context) {
+	s := sessions.Default(c)
+
+	// We need the internal state to know where
+	// to redirect to.
+	internalState := m.mustStringFromSession(c, s, sessionInternalState)
+	if internalState == "" {
+		// Error already
+		// written.
+		return
+	}
+
+	idps := m.idps.List()
+
+	var idp oidc.IDP
+	if len(idps) == 1 {
+		idp = idps[0]
+	} else if providerID := c.Query(signInProviderParam); providerID != "" {
+		idp = m.idps.Get(providerID)
+		if idp == nil {
+			err := fmt.Errorf("unknown %s %s", signInProviderParam, providerID)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+	}
+
+	if idp == nil {
+		// User still needs
+		// to choose an idp.
+		instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		type provider struct {
+			ID   string
+			Name string
+		}
+
+		providers := make([]provider, 0, len(idps))
+		for _, idp := range idps {
+			providers = append(providers, provider{
+				ID:   idp.ID(),
+				Name: idp.Name(),
+			})
+		}
+
+		apiutil.TemplateWebPage(c, apiutil.WebPage{
+			Template: "sign-in.tmpl",
+			Instance: instance,
+			Extra: map[string]any{
+				"oidcProviders": providers,
+			},
+		})
+		return
+	}
+
+	// Remember the chosen idp so
+	// we can handle the callback.
+	s.Set(sessionOIDCProvider, idp.ID())
+	m.mustSaveSession(s)
+
+	c.Redirect(http.StatusSeeOther, idp.AuthCodeURL(internalState))
+}
+
 // SignInPOSTHandler should be served at
 // POST https://example.org/auth/sign_in.
 //
@@ -123,14 +205,26 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	// Check if user has any security keys
+	// registered, which also require 2fa.
+	securityKeys, err := m.state.DB.CountWebAuthnCredentialsByUserID(
+		c.Request.Context(),
+		user.ID,
+	)
+	if err != nil {
+		m.clearSessionWithInternalError(c, s, err, oauth.HelpfulAdvice)
+		return
+	}
+
 	// Whether or not 2fa is enabled, we want
 	// to save the session when we're done here.
 	defer m.mustSaveSession(s)
 
-	if user.TwoFactorEnabled() {
+	if user.TwoFactorEnabled() || securityKeys > 0 {
 		// If this user has 2FA enabled, redirect
 		// to the 2FA page and have them submit
-		// a code from their authenticator app.
+		// a code from their authenticator app,
+		// or use one of their security keys.
 		s.Set(sessionUserIDAwaiting2FA, user.ID)
 		c.Redirect(http.StatusFound, "/auth"+Auth2FAPath)
 		return
@@ -191,7 +285,8 @@ func incorrectPassword(err error) (*gtsmodel.User, gtserror.WithCode) {
 // GET https://example.org/auth/2fa.
 //
 // The 2fa template displays a simple form asking the
-// user to input a code from their authenticator app.
+// user to input a code from their authenticator app,
+// and / or to use one of their registered security keys.
 func (m *Module) TwoFactorCodeGETHandler(c *gin.Context) {
 	s := sessions.Default(c)
 
@@ -208,21 +303,54 @@ func (m *Module) TwoFactorCodeGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.TemplateWebPage(c, apiutil.WebPage{
+	page := apiutil.WebPage{
 		Template: "2fa.tmpl",
 		Instance: instance,
 		Extra: map[string]any{
 			"user": user.Account.Username,
+			"totp": user.TwoFactorEnabled(),
 		},
-	})
+	}
+
+	securityKeys, err := m.state.DB.CountWebAuthnCredentialsByUserID(
+		c.Request.Context(),
+		user.ID,
+	)
+	if err != nil {
+		m.clearSessionWithInternalError(c, s, err, oauth.HelpfulAdvice)
+		return
+	}
+
+	if securityKeys > 0 {
+		// Prepare options for using
+		// one of the user's security keys.
+		webAuthnOptions, errWithCode := m.webAuthnOptions(c, s, user)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+		m.mustSaveSession(s)
+
+		page.Extra["webauthnOptions"] = webAuthnOptions
+		page.Javascript = []apiutil.JavascriptEntry{
+			{
+				Src:   jsWebAuthn,
+				Async: true,
+				Defer: true,
+			},
+		}
+	}
+
+	apiutil.TemplateWebPage(c, page)
 }
 
 // TwoFactorCodePOSTHandler should be served at
 // POST https://example.org/auth/2fa.
 //
-// The idea is to handle a submitted 2fa code, validate it,
-// and if valid redirect to the /oauth/authorize page that
-// the user would get to if they didn't have 2fa enabled.
+// The idea is to handle a submitted 2fa code or security
+// key assertion, validate it, and if valid redirect to the
+// /oauth/authorize page that the user would get to if they
+// didn't have 2fa enabled.
 func (m *Module) TwoFactorCodePOSTHandler(c *gin.Context) {
 	s := sessions.Default(c)
 
@@ -233,15 +361,40 @@ func (m *Module) TwoFactorCodePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	// Parse 2fa code.
+	// Parse 2fa code or
+	// security key assertion.
 	form := &struct {
-		Code string `form:"code" binding:"required"`
+		Code       string `form:"code"`
+		Credential string `form:"credential"`
 	}{}
 	if err := c.ShouldBind(form); err != nil {
 		m.clearSessionWithBadRequest(c, s, err, oauth.HelpfulAdvice)
 		return
 	}
 
+	if form.Credential != "" {
+		// Security key was used, validate it.
+		if _, errWithCode := m.validateWebAuthn(
+			c, s, user, form.Credential,
+		); errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		// Security key looks good! Redirect
+		// to the OAuth authorize page.
+		s.Set(sessionUserID, user.ID)
+		m.mustSaveSession(s)
+		c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+		return
+	}
+
+	if form.Code == "" || !user.TwoFactorEnabled() {
+		const errText = "no 2fa code provided"
+		m.clearSessionWithBadRequest(c, s, errors.New(errText), oauth.HelpfulAdvice)
+		return
+	}
+
 	valid, err := m.validate2FACode(c, user, form.Code)
 	if err != nil {
 		m.clearSessionWithInternalError(c, s, err, oauth.HelpfulAdvice)
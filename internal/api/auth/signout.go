@@ -0,0 +1,165 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/internal/oidc"
+	oautherr "code.superseriousbusiness.org/oauth2/v4/errors"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// SignOutPOSTHandler should be served at
+// POST https://example.org/auth/sign_out.
+//
+// If the submitted form contains a valid access
+// token, all OAuth tokens of the user it belongs
+// to are revoked, so they're signed out of every
+// app they use with this instance.
+//
+// The user is then redirected to the end session
+// endpoint of the idp they last signed in through,
+// if it has one, so that they're signed out there
+// too. Otherwise they're redirected to the instance.
+func (m *Module) SignOutPOSTHandler(c *gin.Context) {
+	if !config.GetOIDCEnabled() {
+		err := errors.New("oidc is not enabled for this server")
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &struct {
+		Token string `form:"token"`
+	}{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Make sure nothing lingers
+	// in the sign in session.
+	m.mustClearSession(sessions.Default(c))
+
+	ctx := c.Request.Context()
+
+	var idp oidc.IDP
+	if form.Token != "" {
+		// Only ever look up by hash.
+		token, err := m.state.DB.GetTokenByAccess(ctx, oauth.HashToken(form.Token))
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting token: %w", err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+			return
+		}
+
+		if token != nil && token.UserID != "" {
+			idp, err = m.idps.LastIDP(ctx, token.UserID)
+			if err != nil {
+				apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+				return
+			}
+
+			if err := m.idps.Logout(ctx, token.UserID); err != nil {
+				apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+				return
+			}
+		}
+	}
+
+	if idps := m.idps.List(); idp == nil && len(idps) == 1 {
+		// Not sure who this is, but
+		// there's only one place they
+		// could have signed in anyway.
+		idp = idps[0]
+	}
+
+	returnTo := config.GetProtocol() + "://" + config.GetHost() + "/"
+	if idp != nil {
+		if endSessionURL := idp.EndSessionURL(returnTo); endSessionURL != "" {
+			c.Redirect(http.StatusSeeOther, endSessionURL)
+			return
+		}
+	}
+
+	c.Redirect(http.StatusSeeOther, returnTo)
+}
+
+// BackchannelLogoutPOSTHandler should be served at
+// POST https://example.org/auth/backchannel_logout.
+//
+// It's called directly by idps that support OpenID
+// Connect Back-Channel Logout with a logout token,
+// to sign the user it identifies out of GoToSocial
+// when they sign out of the idp, by revoking all of
+// their OAuth tokens.
+//
+// See https://openid.net/specs/openid-connect-backchannel-1_0.html
+func (m *Module) BackchannelLogoutPOSTHandler(c *gin.Context) {
+	if !config.GetOIDCEnabled() {
+		err := errors.New("oidc is not enabled for this server")
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotFound(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	// Responses to the idp
+	// must never be cached.
+	c.Header("Cache-Control", "no-store")
+
+	form := &struct {
+		LogoutToken string `form:"logout_token"`
+	}{}
+	if err := c.ShouldBind(form); err != nil || form.LogoutToken == "" {
+		errWithCode := gtserror.NewErrorBadRequest(
+			oautherr.ErrInvalidRequest,
+			"logout_token not set",
+		)
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	if errWithCode := m.idps.BackchannelLogout(
+		c.Request.Context(),
+		form.LogoutToken,
+	); errWithCode != nil {
+		if errWithCode.Code() != http.StatusBadRequest {
+			apiutil.OAuthErrorHandler(c, errWithCode)
+			return
+		}
+
+		// Don't tell the caller
+		// exactly what was wrong.
+		log.Debugf(c.Request.Context(), "invalid logout token: %v", errWithCode)
+		errWithCode = gtserror.NewErrorBadRequest(
+			oautherr.ErrInvalidRequest,
+			errWithCode.Safe(),
+		)
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -28,6 +28,7 @@
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 	"code.superseriousbusiness.org/gotosocial/testrig"
 	"github.com/stretchr/testify/suite"
 )
@@ -93,7 +94,7 @@ func (suite *TokenTestSuite) TestRetrieveClientCredentialsOK() {
 
 	// there should be a token in the database now too
 	dbToken := &gtsmodel.Token{}
-	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: t.AccessToken}}, dbToken)
+	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: oauth.HashToken(t.AccessToken)}}, dbToken)
 	suite.NoError(err)
 	suite.NotNil(dbToken)
 }
@@ -204,7 +205,7 @@ func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeOK() {
 	suite.WithinDuration(time.Now(), time.Unix(t.CreatedAt, 0), 1*time.Minute)
 
 	dbToken := &gtsmodel.Token{}
-	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: t.AccessToken}}, dbToken)
+	err = suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: oauth.HashToken(t.AccessToken)}}, dbToken)
 	suite.NoError(err)
 	suite.NotNil(dbToken)
 }
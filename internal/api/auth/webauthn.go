@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// SignInPasskeyPOSTHandler should be served at
+// POST https://example.org/auth/sign_in/passkey.
+//
+// The handler will check the submitted passkey assertion
+// against the challenge stored in the session when the sign
+// in page was served, then redirect straight to the authorize
+// page served at /oauth/authorize. Passkeys always verify the
+// user (by PIN, biometrics etc), so no further 2fa is required.
+func (m *Module) SignInPasskeyPOSTHandler(c *gin.Context) {
+	s := sessions.Default(c)
+
+	form := &struct {
+		Credential string `form:"credential" binding:"required"`
+	}{}
+	if err := c.ShouldBind(form); err != nil {
+		m.clearSessionWithBadRequest(c, s, err, oauth.HelpfulAdvice)
+		return
+	}
+
+	user, errWithCode := m.validateWebAuthn(c, s, nil, form.Credential)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Passkey looks good! Redirect
+	// to the OAuth authorize page.
+	s.Set(sessionUserID, user.ID)
+	m.mustSaveSession(s)
+	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+}
+
+// webAuthnOptions creates WebAuthn request options for
+// the given user, or for passwordless sign in if user is
+// nil, storing the challenge in the session. The options
+// are returned as JSON, ready to pass into a template.
+//
+// Callers must save the session after calling this.
+func (m *Module) webAuthnOptions(
+	c *gin.Context,
+	s sessions.Session,
+	user *gtsmodel.User,
+) (string, gtserror.WithCode) {
+	options, challenge, errWithCode := m.processor.User().WebAuthnLoginOptions(
+		c.Request.Context(),
+		user,
+	)
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	b, err := json.Marshal(options)
+	if err != nil {
+		err := gtserror.Newf("error marshaling webauthn options: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	s.Set(sessionWebAuthnChallenge, challenge)
+	return string(b), nil
+}
+
+// validateWebAuthn validates the given JSON encoded WebAuthn
+// assertion against the challenge stored in the session, which
+// is then removed so that it can't be used again. If user is set,
+// the assertion must be from one of their security keys; else it
+// must be from a passkey, and the user it belongs to is returned.
+func (m *Module) validateWebAuthn(
+	c *gin.Context,
+	s sessions.Session,
+	user *gtsmodel.User,
+	credential string,
+) (*gtsmodel.User, gtserror.WithCode) {
+	challenge, _ := s.Get(sessionWebAuthnChallenge).(string)
+	if challenge == "" {
+		const errText = "no security key challenge found in session, reload the page and try again"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	// Challenges are one-use only.
+	s.Delete(sessionWebAuthnChallenge)
+	m.mustSaveSession(s)
+
+	return m.processor.User().WebAuthnLogin(
+		c.Request.Context(),
+		user,
+		challenge,
+		credential,
+	)
+}
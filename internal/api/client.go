@@ -35,6 +35,7 @@
 	filtersV2 "code.superseriousbusiness.org/gotosocial/internal/api/client/filters/v2"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/followedtags"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/followrequests"
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/groups"
 	importdata "code.superseriousbusiness.org/gotosocial/internal/api/client/import"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/instance"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/interactionpolicies"
@@ -47,13 +48,17 @@
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/polls"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/preferences"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/push"
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/reactions"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/reports"
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/scheduledstatuses"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/search"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/statuses"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/streaming"
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/suggestions"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/tags"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/timelines"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/tokens"
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/trends"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/user"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/middleware"
@@ -82,6 +87,7 @@ type Client struct {
 	filtersV2           *filtersV2.Module           // api/v2/filters
 	followRequests      *followrequests.Module      // api/v1/follow_requests
 	followedTags        *followedtags.Module        // api/v1/followed_tags
+	groups              *groups.Module              // api/v1/groups
 	importData          *importdata.Module          // api/v1/import
 	instance            *instance.Module            // api/v1/instance
 	interactionPolicies *interactionpolicies.Module // api/v1/interaction_policies
@@ -94,12 +100,16 @@ type Client struct {
 	polls               *polls.Module               // api/v1/polls
 	preferences         *preferences.Module         // api/v1/preferences
 	push                *push.Module                // api/v1/push
+	reactions           *reactions.Module           // api/v1/pleroma/statuses/:id/reactions
 	reports             *reports.Module             // api/v1/reports
+	scheduledStatuses   *scheduledstatuses.Module   // api/v1/scheduled_statuses
 	search              *search.Module              // api/v1/search, api/v2/search
 	statuses            *statuses.Module            // api/v1/statuses
 	streaming           *streaming.Module           // api/v1/streaming
+	suggestions         *suggestions.Module         // api/v1/suggestions, api/v2/suggestions
 	tags                *tags.Module                // api/v1/tags
 	timelines           *timelines.Module           // api/v1/timelines
+	trends              *trends.Module              // api/v1/trends
 	tokens              *tokens.Module              // api/v1/tokens
 	user                *user.Module                // api/v1/user
 }
@@ -136,6 +146,7 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.filtersV2.Route(h)
 	c.followRequests.Route(h)
 	c.followedTags.Route(h)
+	c.groups.Route(h)
 	c.importData.Route(h)
 	c.instance.Route(h)
 	c.interactionPolicies.Route(h)
@@ -148,12 +159,16 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.polls.Route(h)
 	c.preferences.Route(h)
 	c.push.Route(h)
+	c.reactions.Route(h)
 	c.reports.Route(h)
+	c.scheduledStatuses.Route(h)
 	c.search.Route(h)
 	c.statuses.Route(h)
 	c.streaming.Route(h)
+	c.suggestions.Route(h)
 	c.tags.Route(h)
 	c.timelines.Route(h)
+	c.trends.Route(h)
 	c.tokens.Route(h)
 	c.user.Route(h)
 }
@@ -178,6 +193,7 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		filtersV2:           filtersV2.New(p),
 		followRequests:      followrequests.New(p),
 		followedTags:        followedtags.New(p),
+		groups:              groups.New(p),
 		importData:          importdata.New(p),
 		instance:            instance.New(p),
 		interactionPolicies: interactionpolicies.New(p),
@@ -190,12 +206,16 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		polls:               polls.New(p),
 		preferences:         preferences.New(p),
 		push:                push.New(p),
+		reactions:           reactions.New(p),
 		reports:             reports.New(p),
+		scheduledStatuses:   scheduledstatuses.New(p),
 		search:              search.New(p),
 		statuses:            statuses.New(p),
 		streaming:           streaming.New(p, time.Second*30, 4096),
+		suggestions:         suggestions.New(p),
 		tags:                tags.New(p),
 		timelines:           timelines.New(p),
+		trends:              trends.New(p),
 		tokens:              tokens.New(p),
 		user:                user.New(p),
 	}
@@ -69,7 +69,8 @@
 //			description: >-
 //				Unprocessable. Your account creation request cannot be processed
 //				because either too many accounts have been created on this instance
-//				in the last 24h, or the pending account backlog is full.
+//				in the last 24h, or the pending account backlog is full, or the given
+//				invite is not valid.
 //		'500':
 //			description: internal server error
 func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
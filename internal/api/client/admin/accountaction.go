@@ -52,7 +52,9 @@
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: >-
+//			Type of action to be taken. One of `none` (warning only), `sensitive`, `disable`, `silence`, or `suspend`.
+//			`disable` can only be performed on local accounts.
 //		type: string
 //		required: true
 //	-
@@ -60,6 +62,14 @@
 //		in: formData
 //		description: Optional text describing why this action was taken.
 //		type: string
+//	-
+//		name: send_email_notification
+//		in: formData
+//		description: >-
+//			Whether to email the user of the target account about the action,
+//			including the provided text. Only applies to local accounts.
+//		type: boolean
+//		default: true
 //
 //	security:
 //	- OAuth2 Bearer:
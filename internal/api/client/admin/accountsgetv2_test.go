@@ -79,6 +79,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -133,12 +134,13 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "id": "admin",
       "name": "admin",
       "color": "",
-      "permissions": "546033",
+      "permissions": "554225",
       "highlighted": true
     },
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -195,6 +197,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -242,6 +245,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -305,6 +309,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -357,6 +362,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -405,6 +411,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -452,6 +459,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -499,6 +507,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -547,6 +556,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -633,6 +643,7 @@ func (suite *AccountsGetTestSuite) TestAccountsMinID() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
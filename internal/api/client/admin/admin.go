@@ -48,6 +48,7 @@
 	DomainPermissionSubscriptionRemovePath   = DomainPermissionSubscriptionsPathWithID + "/remove"
 	DomainPermissionSubscriptionTestPath     = DomainPermissionSubscriptionsPathWithID + "/test"
 	DomainKeysExpirePath                     = BasePath + "/domain_keys_expire"
+	DeliveryQueuePath                        = BasePath + "/delivery_queue"
 	HeaderAllowsPath                         = BasePath + "/header_allows"
 	HeaderAllowsPathWithID                   = HeaderAllowsPath + "/:" + apiutil.IDKey
 	HeaderBlocksPath                         = BasePath + "/header_blocks"
@@ -58,6 +59,13 @@
 	AccountsActionPath                       = AccountsPathWithID + "/action"
 	AccountsApprovePath                      = AccountsPathWithID + "/approve"
 	AccountsRejectPath                       = AccountsPathWithID + "/reject"
+	AccountsEnablePath                       = AccountsPathWithID + "/enable"
+	AccountsUnsilencePath                    = AccountsPathWithID + "/unsilence"
+	AccountsUnsensitivePath                  = AccountsPathWithID + "/unsensitive"
+	AccountsUnsuspendPath                    = AccountsPathWithID + "/unsuspend"
+	AccountsExternalIdentitiesPath           = AccountsPathWithID + "/external_identities"
+	InvitesPath                              = BasePath + "/invites"
+	InvitesRevokePath                        = InvitesPath + "/:" + apiutil.IDKey + "/revoke"
 	MediaCleanupPath                         = BasePath + "/media_cleanup"
 	MediaRefetchPath                         = BasePath + "/media_refetch"
 	ReportsPath                              = BasePath + "/reports"
@@ -67,6 +75,24 @@
 	EmailTestPath                            = EmailPath + "/test"
 	InstanceRulesPath                        = BasePath + "/instance/rules"
 	InstanceRulesPathWithID                  = InstanceRulesPath + "/:" + apiutil.IDKey
+	AnnouncementsPath                        = BasePath + "/announcements"
+	AnnouncementsPathWithID                  = AnnouncementsPath + "/:" + apiutil.IDKey
+	RelaysPath                               = BasePath + "/relays"
+	RelaysPathWithID                         = RelaysPath + "/:" + apiutil.IDKey
+	RelaysEnablePath                         = RelaysPathWithID + "/enable"
+	RelaysDisablePath                        = RelaysPathWithID + "/disable"
+	StaffPicksPath                           = BasePath + "/staff_picks"
+	StaffPicksPathWithID                     = StaffPicksPath + "/:" + apiutil.IDKey
+	TrendsPath                               = BasePath + "/trends"
+	TrendsTagsPath                           = TrendsPath + "/tags"
+	TrendsTagsApprovePath                    = TrendsTagsPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsTagsRejectPath                     = TrendsTagsPath + "/:" + apiutil.IDKey + "/reject"
+	TrendsStatusesPath                       = TrendsPath + "/statuses"
+	TrendsStatusesApprovePath                = TrendsStatusesPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsStatusesRejectPath                 = TrendsStatusesPath + "/:" + apiutil.IDKey + "/reject"
+	TrendsLinksPath                          = TrendsPath + "/links"
+	TrendsLinksApprovePath                   = TrendsLinksPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsLinksRejectPath                    = TrendsLinksPath + "/:" + apiutil.IDKey + "/reject"
 	DebugPath                                = BasePath + "/debug"
 	DebugAPUrlPath                           = DebugPath + "/apurl"
 	DebugClearCachesPath                     = DebugPath + "/caches/clear"
@@ -146,6 +172,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// domain maintenance stuff
 	attachHandler(http.MethodPost, DomainKeysExpirePath, m.DomainKeysExpirePOSTHandler)
+	attachHandler(http.MethodGet, DeliveryQueuePath, m.DeliveryQueueGETHandler)
 
 	// accounts stuff
 	attachHandler(http.MethodGet, AccountsV1Path, m.AccountsGETV1Handler)
@@ -154,6 +181,15 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
 	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
 	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodPost, AccountsEnablePath, m.AccountEnablePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsilencePath, m.AccountUnsilencePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsensitivePath, m.AccountUnsensitivePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsuspendPath, m.AccountUnsuspendPOSTHandler)
+	attachHandler(http.MethodGet, AccountsExternalIdentitiesPath, m.AccountExternalIdentitiesGETHandler)
+
+	// invites stuff
+	attachHandler(http.MethodGet, InvitesPath, m.InvitesGETHandler)
+	attachHandler(http.MethodPost, InvitesRevokePath, m.InviteRevokePOSTHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
@@ -174,6 +210,37 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
 	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
 
+	// announcements stuff
+	attachHandler(http.MethodGet, AnnouncementsPath, m.AnnouncementsGETHandler)
+	attachHandler(http.MethodGet, AnnouncementsPathWithID, m.AnnouncementGETHandler)
+	attachHandler(http.MethodPost, AnnouncementsPath, m.AnnouncementPOSTHandler)
+	attachHandler(http.MethodPut, AnnouncementsPathWithID, m.AnnouncementPUTHandler)
+	attachHandler(http.MethodDelete, AnnouncementsPathWithID, m.AnnouncementDELETEHandler)
+
+	// relays stuff
+	attachHandler(http.MethodGet, RelaysPath, m.RelaysGETHandler)
+	attachHandler(http.MethodGet, RelaysPathWithID, m.RelayGETHandler)
+	attachHandler(http.MethodPost, RelaysPath, m.RelayPOSTHandler)
+	attachHandler(http.MethodPost, RelaysEnablePath, m.RelayEnablePOSTHandler)
+	attachHandler(http.MethodPost, RelaysDisablePath, m.RelayDisablePOSTHandler)
+	attachHandler(http.MethodDelete, RelaysPathWithID, m.RelayDELETEHandler)
+
+	// staff picks stuff
+	attachHandler(http.MethodGet, StaffPicksPath, m.StaffPicksGETHandler)
+	attachHandler(http.MethodPost, StaffPicksPath, m.StaffPickPOSTHandler)
+	attachHandler(http.MethodDelete, StaffPicksPathWithID, m.StaffPickDELETEHandler)
+
+	// trends stuff
+	attachHandler(http.MethodGet, TrendsTagsPath, m.TrendsTagsGETHandler)
+	attachHandler(http.MethodPost, TrendsTagsApprovePath, m.TrendsTagApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsTagsRejectPath, m.TrendsTagRejectPOSTHandler)
+	attachHandler(http.MethodGet, TrendsStatusesPath, m.TrendsStatusesGETHandler)
+	attachHandler(http.MethodPost, TrendsStatusesApprovePath, m.TrendsStatusApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsStatusesRejectPath, m.TrendsStatusRejectPOSTHandler)
+	attachHandler(http.MethodGet, TrendsLinksPath, m.TrendsLinksGETHandler)
+	attachHandler(http.MethodPost, TrendsLinksApprovePath, m.TrendsLinkApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsLinksRejectPath, m.TrendsLinkRejectPOSTHandler)
+
 	// debug stuff
 	if debug.DEBUG {
 		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
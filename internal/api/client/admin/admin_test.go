@@ -64,6 +64,8 @@ type AdminStandardTestSuite struct {
 	testEmojis          map[string]*gtsmodel.Emoji
 	testEmojiCategories map[string]*gtsmodel.EmojiCategory
 	testReports         map[string]*gtsmodel.Report
+	testAnnouncements   map[string]*gtsmodel.Announcement
+	testRelays          map[string]*gtsmodel.Relay
 
 	// module being tested
 	adminModule *admin.Module
@@ -79,6 +81,8 @@ func (suite *AdminStandardTestSuite) SetupSuite() {
 	suite.testEmojis = testrig.NewTestEmojis()
 	suite.testEmojiCategories = testrig.NewTestEmojiCategories()
 	suite.testReports = testrig.NewTestReports()
+	suite.testAnnouncements = testrig.NewTestAnnouncements()
+	suite.testRelays = testrig.NewTestRelays()
 }
 
 func (suite *AdminStandardTestSuite) SetupTest() {
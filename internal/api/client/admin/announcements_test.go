@@ -0,0 +1,346 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/admin"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/stream"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type AnnouncementsTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AnnouncementsTestSuite) SetupTest() {
+	suite.AdminStandardTestSuite.SetupTest()
+
+	// The previous test's teardown can race with
+	// the scheduler starting up again in setup, so
+	// make sure it's running before we schedule.
+	_ = suite.state.Workers.Scheduler.Start()
+}
+
+func (suite *AnnouncementsTestSuite) announcementReq(
+	method string,
+	handler gin.HandlerFunc,
+	id string,
+	body string,
+) (string, int) {
+	recorder := httptest.NewRecorder()
+
+	path := admin.AnnouncementsPath
+	if id != "" {
+		path += "/" + id
+	}
+
+	ctx := suite.newContext(recorder, method, []byte(body), path, "application/json")
+	ctx.Request.Method = method
+	if id != "" {
+		ctx.AddParam(apiutil.IDKey, id)
+	}
+
+	handler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dst := new(bytes.Buffer)
+	if err := json.Indent(dst, b, "", "  "); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return dst.String(), recorder.Code
+}
+
+// openStream opens a user stream for local_account_1,
+// returning a func to receive the next message from it.
+func (suite *AnnouncementsTestSuite) openStream() (func() (stream.Message, bool), func()) {
+	wssStream, errWithCode := suite.processor.Stream().Open(
+		context.Background(),
+		suite.testAccounts["local_account_1"],
+		stream.TimelineHome,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	recv := func() (stream.Message, bool) {
+		ctx, cncl := context.WithTimeout(context.Background(), time.Second)
+		defer cncl()
+		return wssStream.Recv(ctx)
+	}
+
+	return recv, wssStream.Close
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementsGet() {
+	out, code := suite.announcementReq(http.MethodGet, suite.adminModule.AnnouncementsGETHandler, "", "")
+	suite.Equal(http.StatusOK, code)
+
+	// All announcements should be
+	// returned, newest first.
+	var announcements []*apimodel.Announcement
+	if err := json.Unmarshal([]byte(out), &announcements); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ids := make([]string, len(announcements))
+	for i, a := range announcements {
+		ids[i] = a.ID
+	}
+
+	suite.Equal([]string{
+		"01JSV5H2R8M3Q6Z9T1X4C7B0PD",
+		"01JSV5B9WZ4F1N0T3M8Y7E2K6Q",
+		"01JSV4ZK3N6R9D2F5H8J1M4P7S",
+	}, ids)
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementCreate() {
+	recv, closeStream := suite.openStream()
+	defer closeStream()
+
+	out, code := suite.announcementReq(
+		http.MethodPost,
+		suite.adminModule.AnnouncementPOSTHandler,
+		"",
+		`{"text":"Happy birthday to us :rainbow: #Anniversary","starts_at":"2080-06-01T00:00:00Z","all_day":true}`,
+	)
+	suite.Equal(http.StatusOK, code)
+
+	announcement := new(apimodel.Announcement)
+	if err := json.Unmarshal([]byte(out), announcement); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(`<p>Happy birthday to us :rainbow: <a href="http://localhost:8080/tags/anniversary" class="mention hashtag" rel="tag nofollow noreferrer noopener" target="_blank">#<span>Anniversary</span></a></p>`, announcement.Content)
+	suite.Equal("2080-06-01T00:00:00.000Z", announcement.StartsAt)
+	suite.Empty(announcement.EndsAt)
+	suite.True(announcement.AllDay)
+	suite.True(announcement.Published)
+	suite.NotEmpty(announcement.PublishedAt)
+	suite.Len(announcement.Emojis, 1)
+	suite.Equal("rainbow", announcement.Emojis[0].Shortcode)
+	suite.Len(announcement.Tags, 1)
+	suite.Equal("anniversary", announcement.Tags[0].Name)
+
+	// Published announcement hasn't started
+	// yet, so it shouldn't have been streamed.
+	_, ok := recv()
+	suite.False(ok)
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementCreateScheduled() {
+	recv, closeStream := suite.openStream()
+	defer closeStream()
+
+	var (
+		now      = time.Now()
+		startsAt = now.Add(500 * time.Millisecond).UTC().Format(time.RFC3339Nano)
+		endsAt   = now.Add(1500 * time.Millisecond).UTC().Format(time.RFC3339Nano)
+	)
+
+	out, code := suite.announcementReq(
+		http.MethodPost,
+		suite.adminModule.AnnouncementPOSTHandler,
+		"",
+		`{"text":"brb","starts_at":"`+startsAt+`","ends_at":"`+endsAt+`"}`,
+	)
+	suite.Equal(http.StatusOK, code)
+
+	announcement := new(apimodel.Announcement)
+	if err := json.Unmarshal([]byte(out), announcement); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should be streamed once started.
+	msg, ok := recv()
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncement, msg.Event)
+	suite.Contains(msg.Payload, `"id":"`+announcement.ID+`"`)
+
+	// And streamed as a delete once ended.
+	msg, ok = recv()
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementDelete, msg.Event)
+	suite.Equal(announcement.ID, msg.Payload)
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementUpdateRescheduled() {
+	recv, closeStream := suite.openStream()
+	defer closeStream()
+
+	// Schedule an announcement to start soon.
+	startsAt := time.Now().Add(500 * time.Millisecond).UTC().Format(time.RFC3339Nano)
+	out, code := suite.announcementReq(
+		http.MethodPost,
+		suite.adminModule.AnnouncementPOSTHandler,
+		"",
+		`{"text":"soon","starts_at":"`+startsAt+`"}`,
+	)
+	suite.Equal(http.StatusOK, code)
+
+	announcement := new(apimodel.Announcement)
+	if err := json.Unmarshal([]byte(out), announcement); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Push its start back.
+	_, code = suite.announcementReq(
+		http.MethodPut,
+		suite.adminModule.AnnouncementPUTHandler,
+		announcement.ID,
+		`{"starts_at":"2080-06-01T00:00:00Z"}`,
+	)
+	suite.Equal(http.StatusOK, code)
+
+	// Nothing should be streamed at the old start.
+	_, ok := recv()
+	suite.False(ok)
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementCreateUnpublished() {
+	recv, closeStream := suite.openStream()
+	defer closeStream()
+
+	out, code := suite.announcementReq(
+		http.MethodPost,
+		suite.adminModule.AnnouncementPOSTHandler,
+		"",
+		`{"text":"not yet!","published":false}`,
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(out, `"published": false`)
+	suite.Contains(out, `"published_at": ""`)
+
+	// Nothing should have been streamed.
+	_, ok := recv()
+	suite.False(ok)
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementCreateInvalid() {
+	for body, expect := range map[string]string{
+		`{"text":""}`: `{
+  "error": "Bad Request: announcement text must be provided"
+}`,
+		`{"text":"hi","starts_at":"tomorrow"}`: `{
+  "error": "Bad Request: starts_at could not be parsed as ISO 8601 datetime"
+}`,
+		`{"text":"hi","starts_at":"2080-06-02T00:00:00Z","ends_at":"2080-06-01T00:00:00Z"}`: `{
+  "error": "Bad Request: ends_at must not be before starts_at"
+}`,
+	} {
+		out, code := suite.announcementReq(
+			http.MethodPost,
+			suite.adminModule.AnnouncementPOSTHandler,
+			"",
+			body,
+		)
+		suite.Equal(http.StatusBadRequest, code)
+		suite.Equal(expect, out)
+	}
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementUpdate() {
+	recv, closeStream := suite.openStream()
+	defer closeStream()
+
+	// Publish the draft announcement.
+	draft := suite.testAnnouncements["draft_announcement"]
+	out, code := suite.announcementReq(
+		http.MethodPut,
+		suite.adminModule.AnnouncementPUTHandler,
+		draft.ID,
+		`{"text":"Ready now!","published":true}`,
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(out, `"content": "<p>Ready now!</p>"`)
+	suite.Contains(out, `"published": true`)
+
+	msg, ok := recv()
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncement, msg.Event)
+
+	// Unpublish the active announcement.
+	active := suite.testAnnouncements["active_announcement"]
+	out, code = suite.announcementReq(
+		http.MethodPut,
+		suite.adminModule.AnnouncementPUTHandler,
+		active.ID,
+		`{"published":false}`,
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(out, `"published": false`)
+
+	// Should be streamed as a delete.
+	msg, ok = recv()
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementDelete, msg.Event)
+	suite.Equal(active.ID, msg.Payload)
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementDelete() {
+	recv, closeStream := suite.openStream()
+	defer closeStream()
+
+	active := suite.testAnnouncements["active_announcement"]
+	_, code := suite.announcementReq(
+		http.MethodDelete,
+		suite.adminModule.AnnouncementDELETEHandler,
+		active.ID,
+		"",
+	)
+	suite.Equal(http.StatusOK, code)
+
+	msg, ok := recv()
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementDelete, msg.Event)
+	suite.Equal(active.ID, msg.Payload)
+
+	_, err := suite.db.GetAnnouncementByID(context.Background(), active.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Deleting again should 404.
+	_, code = suite.announcementReq(
+		http.MethodDelete,
+		suite.adminModule.AnnouncementDELETEHandler,
+		active.ID,
+		"",
+	)
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestAnnouncementsTestSuite(t *testing.T) {
+	suite.Run(t, &AnnouncementsTestSuite{})
+}
@@ -90,6 +90,27 @@
 //			is a useful way of internally keeping track of why a certain domain ended up blocked.
 //			Used only if `import` is not `true`.
 //		type: string
+//	-
+//		name: severity
+//		in: formData
+//		description: >-
+//			Severity of the block: "suspend" or "limit". Defaults to "suspend" on creation.
+//			Limited domains are kept out of public and hashtag timelines, and their
+//			notifications only reach followers, but existing follows are preserved.
+//			Used only if `import` is not `true`.
+//		type: string
+//	-
+//		name: reject_media
+//		in: formData
+//		description: Strip media from posts and accounts of a limited domain.
+//			Used only if `import` is not `true`.
+//		type: boolean
+//	-
+//		name: sensitize_media
+//		in: formData
+//		description: Mark all media from a limited domain as sensitive.
+//			Used only if `import` is not `true`.
+//		type: boolean
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -64,6 +64,24 @@
 //			Private comment about this domain block. Will only be shown to other admins, so this
 //			is a useful way of internally keeping track of why a certain domain ended up blocked.
 //		type: string
+//	-
+//		name: severity
+//		in: formData
+//		description: >-
+//			Severity of the block: "suspend" or "limit". Defaults to "suspend" on creation.
+//			Limited domains are kept out of public and hashtag timelines, and their
+//			notifications only reach followers, but existing follows are preserved.
+//		type: string
+//	-
+//		name: reject_media
+//		in: formData
+//		description: Strip media from posts and accounts of a limited domain.
+//		type: boolean
+//	-
+//		name: sensitize_media
+//		in: formData
+//		description: Mark all media from a limited domain as sensitive.
+//		type: boolean
 //
 //	security:
 //	- OAuth2 Bearer:
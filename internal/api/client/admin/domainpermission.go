@@ -41,6 +41,9 @@
 	string, // publicComment
 	string, // privateComment
 	string, // subscriptionID
+	gtsmodel.DomainBlockSeverity, // severity
+	bool, // rejectMedia
+	bool, // sensitizeMedia
 ) (*apimodel.DomainPermission, string, gtserror.WithCode)
 
 type multiDomainPermCreate func(
@@ -122,6 +125,13 @@ func (m *Module) createDomainPermissions(
 	}
 
 	if !importing {
+		// Parse severity, if set.
+		severity, errWithCode := parseDomainBlockSeverity(form.Severity)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
 		// Single domain permission creation.
 		perm, _, errWithCode := single(
 			c.Request.Context(),
@@ -132,6 +142,9 @@ func (m *Module) createDomainPermissions(
 			util.PtrOrZero(form.PublicComment),
 			util.PtrOrZero(form.PrivateComment),
 			"", // No sub ID for single perm creation.
+			util.PtrOrZero(severity),
+			util.PtrOrZero(form.RejectMedia),
+			util.PtrOrZero(form.SensitizeMedia),
 		)
 
 		if errWithCode != nil {
@@ -230,21 +243,34 @@ func (m *Module) updateDomainPermission(
 
 	if form.Obfuscate == nil &&
 		form.PrivateComment == nil &&
-		form.PublicComment == nil {
+		form.PublicComment == nil &&
+		form.Severity == nil &&
+		form.RejectMedia == nil &&
+		form.SensitizeMedia == nil {
 		const errText = "empty form submitted"
 		errWithCode := gtserror.NewErrorBadRequest(errors.New(errText), errText)
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
+	severity, errWithCode := parseDomainBlockSeverity(form.Severity)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	perm, errWithCode := m.processor.Admin().DomainPermissionUpdate(
 		c.Request.Context(),
 		permType,
+		authed.Account,
 		permID,
 		form.Obfuscate,
 		form.PublicComment,
 		form.PrivateComment,
 		nil, // Can't update perm sub ID this way yet.
+		severity,
+		form.RejectMedia,
+		form.SensitizeMedia,
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -445,6 +471,26 @@ func parseDomainPermissionType(i string) (
 	return
 }
 
+// parseDomainBlockSeverity is a util function to parse i
+// to a DomainBlockSeverity, or return a suitable error.
+// Returns nil severity if i is nil or empty.
+func parseDomainBlockSeverity(i *string) (
+	*gtsmodel.DomainBlockSeverity,
+	gtserror.WithCode,
+) {
+	if i == nil || *i == "" {
+		return nil, nil
+	}
+
+	severity := gtsmodel.ParseDomainBlockSeverity(*i)
+	if severity == gtsmodel.DomainBlockSeverityUnknown {
+		var errText = fmt.Sprintf("severity %s not recognized, must be one of suspend or limit", *i)
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	return &severity, nil
+}
+
 // parseDomainPermSubContentType is a util function to parse i
 // to a DomainPermSubContentType, or return a suitable error.
 func parseDomainPermSubContentType(i string) (
@@ -76,6 +76,24 @@
 //			Private comment about this domain permission. Will only be shown to other admins, so this
 //			is a useful way of internally keeping track of why a certain domain ended up permissioned.
 //		type: string
+//	-
+//		name: severity
+//		in: formData
+//		description: >-
+//			Severity of a draft block: "suspend" (default) or "limit".
+//			Limited domains are kept out of public timelines, and their
+//			notifications only reach followers. Ignored for draft allows.
+//		type: string
+//	-
+//		name: reject_media
+//		in: formData
+//		description: Strip media from posts and accounts of a limited domain. Ignored for draft allows.
+//		type: boolean
+//	-
+//		name: sensitize_media
+//		in: formData
+//		description: Mark media from a limited domain as sensitive. Ignored for draft allows.
+//		type: boolean
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -144,6 +162,12 @@ func (m *Module) DomainPermissionDraftsPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	severity, errWithCode := parseDomainBlockSeverity(form.Severity)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	permDraft, errWithCode := m.processor.Admin().DomainPermissionDraftCreate(
 		c.Request.Context(),
 		authed.Account,
@@ -152,6 +176,9 @@ func (m *Module) DomainPermissionDraftsPOSTHandler(c *gin.Context) {
 		util.PtrOrZero(form.Obfuscate),
 		util.PtrOrZero(form.PublicComment),
 		util.PtrOrZero(form.PrivateComment),
+		util.PtrOrZero(severity),
+		util.PtrOrZero(form.RejectMedia),
+		util.PtrOrZero(form.SensitizeMedia),
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
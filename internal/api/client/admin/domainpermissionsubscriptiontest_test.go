@@ -99,19 +99,22 @@ func (suite *DomainPermissionSubscriptionTestTestSuite) TestDomainPermissionSubs
     "domain": "bumfaces.net",
     "public_comment": "big jerks",
     "obfuscate": false,
-    "private_comment": ""
+    "private_comment": "",
+    "severity": "suspend"
   },
   {
     "domain": "peepee.poopoo",
     "public_comment": "harassment",
     "obfuscate": false,
-    "private_comment": ""
+    "private_comment": "",
+    "severity": "suspend"
   },
   {
     "domain": "nothanks.com",
     "public_comment": "",
     "obfuscate": false,
-    "private_comment": ""
+    "private_comment": "",
+    "severity": "suspend"
   }
 ]`, dst.String())
 
@@ -187,19 +190,22 @@ func (suite *DomainPermissionSubscriptionTestTestSuite) TestDomainPermissionSubs
     "domain": "bumfaces.net",
     "public_comment": "",
     "obfuscate": false,
-    "private_comment": ""
+    "private_comment": "",
+    "severity": "suspend"
   },
   {
     "domain": "peepee.poopoo",
     "public_comment": "",
     "obfuscate": false,
-    "private_comment": ""
+    "private_comment": "",
+    "severity": "suspend"
   },
   {
     "domain": "nothanks.com",
     "public_comment": "",
     "obfuscate": false,
-    "private_comment": ""
+    "private_comment": "",
+    "severity": "suspend"
   }
 ]`, dst.String())
 
@@ -0,0 +1,225 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/admin"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type RelaysTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *RelaysTestSuite) relayReq(
+	handler gin.HandlerFunc,
+	id string,
+	body string,
+) (*apimodel.AdminRelay, int, string) {
+	recorder := httptest.NewRecorder()
+
+	path := admin.RelaysPath
+	if id != "" {
+		path += "/" + id
+	}
+
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(body), path, "application/json")
+	if id != "" {
+		ctx.AddParam(apiutil.IDKey, id)
+	}
+
+	handler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if recorder.Code != http.StatusOK {
+		return nil, recorder.Code, string(b)
+	}
+
+	relay := new(apimodel.AdminRelay)
+	if err := json.Unmarshal(b, relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return relay, recorder.Code, string(b)
+}
+
+// popDelivery pops the next delivery from the queue,
+// checking it's to the given inbox, and returning
+// the unmarshaled activity that it contains.
+func (suite *RelaysTestSuite) popDelivery(inbox string) map[string]any {
+	var activity map[string]any
+	if !testrig.WaitFor(func() bool {
+		dlv, ok := suite.state.Workers.Delivery.Queue.Pop()
+		if !ok {
+			return false
+		}
+
+		suite.Equal(inbox, dlv.Request.URL.String())
+
+		b, err := io.ReadAll(dlv.Request.Body)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if err := json.Unmarshal(b, &activity); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return true
+	}) {
+		suite.FailNow("timed out waiting for delivery")
+	}
+	return activity
+}
+
+func (suite *RelaysTestSuite) TestRelayCreateMastodon() {
+	relay, code, _ := suite.relayReq(
+		suite.adminModule.RelayPOSTHandler, "",
+		`{"url":"https://relay.example.net/inbox"}`,
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("mastodon", relay.Type)
+	suite.Equal("pending", relay.State)
+	suite.Equal("https://relay.example.net/inbox", relay.InboxURL)
+	suite.Empty(relay.ActorURL)
+
+	// A Follow of the public collection
+	// should be delivered to the inbox.
+	follow := suite.popDelivery("https://relay.example.net/inbox")
+	suite.Equal("Follow", follow["type"])
+	suite.Equal("http://localhost:8080/users/localhost:8080", follow["actor"])
+	suite.Equal("https://www.w3.org/ns/activitystreams#Public", follow["object"])
+
+	// Adding the same relay again should conflict.
+	_, code, body := suite.relayReq(
+		suite.adminModule.RelayPOSTHandler, "",
+		`{"url":"https://relay.example.net/inbox"}`,
+	)
+	suite.Equal(http.StatusConflict, code)
+	suite.Equal(`{"error":"Conflict: relay with inbox https://relay.example.net/inbox already exists"}`, body)
+}
+
+func (suite *RelaysTestSuite) TestRelayCreateLitePub() {
+	actor := suite.testAccounts["remote_account_1"]
+
+	relay, code, _ := suite.relayReq(
+		suite.adminModule.RelayPOSTHandler, "",
+		`{"url":"`+actor.URI+`"}`,
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("litepub", relay.Type)
+	suite.Equal("pending", relay.State)
+	suite.Equal(actor.URI, relay.ActorURL)
+
+	// Shared inbox should be preferred.
+	suite.Equal(*actor.SharedInboxURI, relay.InboxURL)
+
+	// A Follow of the relay actor
+	// should be delivered to the inbox.
+	follow := suite.popDelivery(*actor.SharedInboxURI)
+	suite.Equal("Follow", follow["type"])
+	suite.Equal(actor.URI, follow["object"])
+}
+
+func (suite *RelaysTestSuite) TestRelayCreateInvalid() {
+	_, code, body := suite.relayReq(
+		suite.adminModule.RelayPOSTHandler, "",
+		`{"url":"ftp://relay.example.net/inbox"}`,
+	)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"Bad Request: url must be a valid http(s) URL"}`, body)
+}
+
+func (suite *RelaysTestSuite) TestRelayDisableEnableDelete() {
+	pending := suite.testRelays["pending_relay"]
+
+	// Disable the relay, should send Undo.
+	relay, code, _ := suite.relayReq(suite.adminModule.RelayDisablePOSTHandler, pending.ID, "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("idle", relay.State)
+
+	undo := suite.popDelivery(pending.InboxURI)
+	suite.Equal("Undo", undo["type"])
+	suite.Equal(pending.FollowURI, undo["object"].(map[string]any)["id"])
+
+	// Enable it again, should
+	// send Follow with new URI.
+	relay, code, _ = suite.relayReq(suite.adminModule.RelayEnablePOSTHandler, pending.ID, "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("pending", relay.State)
+
+	follow := suite.popDelivery(pending.InboxURI)
+	suite.Equal("Follow", follow["type"])
+	suite.NotEqual(pending.FollowURI, follow["id"])
+
+	// Delete it, should send Undo of the new Follow.
+	_, code, _ = suite.relayReq(suite.adminModule.RelayDELETEHandler, pending.ID, "")
+	suite.Equal(http.StatusOK, code)
+
+	undo = suite.popDelivery(pending.InboxURI)
+	suite.Equal("Undo", undo["type"])
+	suite.Equal(follow["id"], undo["object"].(map[string]any)["id"])
+
+	// Should be gone now.
+	_, code, _ = suite.relayReq(suite.adminModule.RelayGETHandler, pending.ID, "")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func (suite *RelaysTestSuite) TestRelaysGet() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.RelaysPath, "")
+
+	suite.adminModule.RelaysGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	dst := new(bytes.Buffer)
+	if err := json.Indent(dst, recorder.Body.Bytes(), "", "  "); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(`[
+  {
+    "id": "01JSYB6Y3W2QK8N5R0T7V4X1ZC",
+    "created_at": "2025-04-28T15:30:45.000Z",
+    "updated_at": "2025-04-28T15:30:45.000Z",
+    "type": "mastodon",
+    "inbox_url": "https://relay.fedi.example.org/inbox",
+    "actor_url": "",
+    "state": "pending"
+  }
+]`, dst.String())
+}
+
+func TestRelaysTestSuite(t *testing.T) {
+	suite.Run(t, &RelaysTestSuite{})
+}
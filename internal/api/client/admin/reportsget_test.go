@@ -166,6 +166,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -213,6 +214,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -267,12 +269,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "id": "admin",
         "name": "admin",
         "color": "",
-        "permissions": "546033",
+        "permissions": "554225",
         "highlighted": true
       },
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -323,12 +326,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "id": "admin",
         "name": "admin",
         "color": "",
-        "permissions": "546033",
+        "permissions": "554225",
         "highlighted": true
       },
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -398,6 +402,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -458,6 +463,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -591,6 +597,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
           }
         }
       }
@@ -655,6 +668,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -715,6 +729,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -848,6 +863,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
           }
         }
       }
@@ -912,6 +934,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -972,6 +995,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -1105,6 +1129,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "always": [
+              "public",
+              "me"
+            ],
+            "with_approval": []
           }
         }
       }
@@ -0,0 +1,158 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/admin"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type StaffPicksTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// staffPicksReq calls the given staff picks handler
+// as the admin account, with the given account ID
+// path parameter (if any) and body, and decodes
+// the response into out if the response is OK.
+func (suite *StaffPicksTestSuite) staffPicksReq(
+	handler gin.HandlerFunc,
+	id string,
+	body string,
+	out any,
+) (int, string) {
+	recorder := httptest.NewRecorder()
+
+	path := admin.StaffPicksPath
+	if id != "" {
+		path += "/" + id
+	}
+
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(body), path, "application/json")
+	if id != "" {
+		ctx.AddParam(apiutil.IDKey, id)
+	}
+
+	handler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(b, out); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	return recorder.Code, string(b)
+}
+
+func (suite *StaffPicksTestSuite) TestStaffPicks() {
+	target := suite.testAccounts["local_account_1"]
+
+	// No staff picks to begin with.
+	var accounts []*apimodel.Account
+	code, _ := suite.staffPicksReq(suite.adminModule.StaffPicksGETHandler, "", "", &accounts)
+	suite.Equal(http.StatusOK, code)
+	suite.Empty(accounts)
+
+	// Pick an account.
+	var account apimodel.Account
+	code, _ = suite.staffPicksReq(
+		suite.adminModule.StaffPickPOSTHandler, "",
+		`{"account_id":"`+target.ID+`"}`,
+		&account,
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(target.ID, account.ID)
+
+	// Picking it again should conflict.
+	code, body := suite.staffPicksReq(
+		suite.adminModule.StaffPickPOSTHandler, "",
+		`{"account_id":"`+target.ID+`"}`,
+		&account,
+	)
+	suite.Equal(http.StatusConflict, code)
+	suite.Equal(`{"error":"Conflict: account `+target.ID+` is already a staff pick"}`, body)
+
+	code, _ = suite.staffPicksReq(suite.adminModule.StaffPicksGETHandler, "", "", &accounts)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(accounts, 1) {
+		suite.Equal(target.ID, accounts[0].ID)
+	}
+
+	// Unpick the account.
+	code, _ = suite.staffPicksReq(suite.adminModule.StaffPickDELETEHandler, target.ID, "", &account)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(target.ID, account.ID)
+
+	// Unpicking it again should 404.
+	code, _ = suite.staffPicksReq(suite.adminModule.StaffPickDELETEHandler, target.ID, "", &account)
+	suite.Equal(http.StatusNotFound, code)
+
+	code, _ = suite.staffPicksReq(suite.adminModule.StaffPicksGETHandler, "", "", &accounts)
+	suite.Equal(http.StatusOK, code)
+	suite.Empty(accounts)
+}
+
+func (suite *StaffPicksTestSuite) TestStaffPickCreateSuspended() {
+	target := suite.testAccounts["local_account_1"]
+	target.SuspendedAt = target.CreatedAt
+	if err := suite.db.UpdateAccount(context.Background(), target, "suspended_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var account apimodel.Account
+	code, body := suite.staffPicksReq(
+		suite.adminModule.StaffPickPOSTHandler, "",
+		`{"account_id":"`+target.ID+`"}`,
+		&account,
+	)
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal(`{"error":"Unprocessable Entity: account `+target.ID+` is suspended"}`, body)
+}
+
+func (suite *StaffPicksTestSuite) TestStaffPickCreateMissing() {
+	var account apimodel.Account
+	code, _ := suite.staffPicksReq(
+		suite.adminModule.StaffPickPOSTHandler, "",
+		`{"account_id":"01HXJ2Y6Z6ZQ6Q8XKX4T4Y2B5M"}`,
+		&account,
+	)
+	suite.Equal(http.StatusNotFound, code)
+
+	code, body := suite.staffPicksReq(suite.adminModule.StaffPickPOSTHandler, "", `{}`, &account)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"Bad Request: account_id must be provided"}`, body)
+}
+
+func TestStaffPicksTestSuite(t *testing.T) {
+	suite.Run(t, &StaffPicksTestSuite{})
+}
@@ -0,0 +1,210 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/admin"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrendsTestSuite struct {
+	AdminStandardTestSuite
+	testTags map[string]*gtsmodel.Tag
+}
+
+func (suite *TrendsTestSuite) SetupTest() {
+	suite.AdminStandardTestSuite.SetupTest()
+	suite.testTags = testrig.NewTestTags()
+
+	// Pretend the welcome tag,
+	// a status, and a link are
+	// currently trending.
+	suite.state.Caches.Trends.Set(gtsmodel.TrendTypeTag, []*cache.Trend{{
+		Type:   gtsmodel.TrendTypeTag,
+		Target: suite.testTags["welcome"].ID,
+		Score:  10,
+		History: []cache.TrendHistory{{
+			Day:      time.Now().UTC().Truncate(24 * time.Hour),
+			Uses:     5,
+			Accounts: 4,
+		}},
+	}})
+	suite.state.Caches.Trends.Set(gtsmodel.TrendTypeStatus, []*cache.Trend{{
+		Type:   gtsmodel.TrendTypeStatus,
+		Target: suite.testStatuses["local_account_1_status_1"].ID,
+		Score:  1,
+	}})
+	suite.state.Caches.Trends.Set(gtsmodel.TrendTypeLink, []*cache.Trend{{
+		Type:   gtsmodel.TrendTypeLink,
+		Target: "https://example.org/some/article",
+		Score:  5,
+	}})
+}
+
+func (suite *TrendsTestSuite) TearDownTest() {
+	suite.state.Caches.Trends.Clear()
+	suite.AdminStandardTestSuite.TearDownTest()
+}
+
+// trendsReq performs a request to the given admin trends
+// handler, decoding the response body into out if successful.
+func (suite *TrendsTestSuite) trendsReq(
+	handler gin.HandlerFunc,
+	method string,
+	path string,
+	id string,
+	out any,
+) int {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, method, nil, path, "")
+	if id != "" {
+		ctx.AddParam(apiutil.IDKey, id)
+	}
+
+	handler(ctx)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(b, out); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	return recorder.Code
+}
+
+func (suite *TrendsTestSuite) TestTagsGetAndApprove() {
+	tag := suite.testTags["welcome"]
+
+	// Tag is trending but not yet reviewed.
+	var tags []*apimodel.AdminTrendsTag
+	code := suite.trendsReq(suite.adminModule.TrendsTagsGETHandler, http.MethodGet, admin.TrendsTagsPath, "", &tags)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(tags, 1) {
+		suite.Equal(tag.ID, tags[0].ID)
+		suite.Equal("welcome", tags[0].Name)
+		suite.False(tags[0].Trendable)
+		suite.True(tags[0].Usable)
+		suite.True(tags[0].RequiresReview)
+		suite.Equal([]apimodel.History{{
+			Day:      tags[0].History[0].Day,
+			Uses:     "5",
+			Accounts: "4",
+		}}, tags[0].History)
+	}
+
+	// Approve it.
+	var approved apimodel.AdminTrendsTag
+	code = suite.trendsReq(suite.adminModule.TrendsTagApprovePOSTHandler, http.MethodPost, admin.TrendsTagsPath+"/"+tag.ID+"/approve", tag.ID, &approved)
+	suite.Equal(http.StatusOK, code)
+	suite.True(approved.Trendable)
+	suite.False(approved.RequiresReview)
+
+	review, err := suite.db.GetTrendReview(context.Background(), gtsmodel.TrendTypeTag, tag.ID)
+	if suite.NoError(err) {
+		suite.True(review.IsApproved())
+		suite.Equal(suite.testAccounts["admin_account"].ID, review.ReviewedByAccountID)
+	}
+
+	// Now reject it.
+	var rejected apimodel.AdminTrendsTag
+	code = suite.trendsReq(suite.adminModule.TrendsTagRejectPOSTHandler, http.MethodPost, admin.TrendsTagsPath+"/"+tag.ID+"/reject", tag.ID, &rejected)
+	suite.Equal(http.StatusOK, code)
+	suite.False(rejected.Trendable)
+	suite.False(rejected.RequiresReview)
+}
+
+func (suite *TrendsTestSuite) TestStatusApprove() {
+	status := suite.testStatuses["local_account_1_status_1"]
+
+	var approved apimodel.AdminTrendsStatus
+	code := suite.trendsReq(suite.adminModule.TrendsStatusApprovePOSTHandler, http.MethodPost, admin.TrendsStatusesPath+"/"+status.ID+"/approve", status.ID, &approved)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(status.ID, approved.ID)
+	suite.True(approved.Trendable)
+
+	var statuses []*apimodel.AdminTrendsStatus
+	code = suite.trendsReq(suite.adminModule.TrendsStatusesGETHandler, http.MethodGet, admin.TrendsStatusesPath, "", &statuses)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(statuses, 1) {
+		suite.Equal(status.ID, statuses[0].ID)
+		suite.True(statuses[0].Trendable)
+		suite.False(statuses[0].RequiresReview)
+	}
+}
+
+func (suite *TrendsTestSuite) TestLinksGetAndReject() {
+	link := "https://example.org/some/article"
+
+	// Links are only listed once queued for review.
+	var links []*apimodel.AdminTrendsLink
+	code := suite.trendsReq(suite.adminModule.TrendsLinksGETHandler, http.MethodGet, admin.TrendsLinksPath, "", &links)
+	suite.Equal(http.StatusOK, code)
+	suite.Empty(links)
+
+	review := &gtsmodel.TrendReview{
+		ID:     id.NewULID(),
+		Type:   gtsmodel.TrendTypeLink,
+		Target: link,
+		State:  gtsmodel.TrendReviewStatePending,
+	}
+	if err := suite.db.PutTrendReview(context.Background(), review); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	code = suite.trendsReq(suite.adminModule.TrendsLinksGETHandler, http.MethodGet, admin.TrendsLinksPath, "", &links)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(links, 1) {
+		suite.Equal(review.ID, links[0].ID)
+		suite.Equal(link, links[0].URL)
+		suite.True(links[0].RequiresReview)
+	}
+
+	var rejected apimodel.AdminTrendsLink
+	code = suite.trendsReq(suite.adminModule.TrendsLinkRejectPOSTHandler, http.MethodPost, admin.TrendsLinksPath+"/"+review.ID+"/reject", review.ID, &rejected)
+	suite.Equal(http.StatusOK, code)
+	suite.False(rejected.Trendable)
+	suite.False(rejected.RequiresReview)
+
+	// Unknown link review ID.
+	code = suite.trendsReq(suite.adminModule.TrendsLinkApprovePOSTHandler, http.MethodPost, admin.TrendsLinksPath+"/01HZZZZZZZZZZZZZZZZZZZZZZZ/approve", "01HZZZZZZZZZZZZZZZZZZZZZZZ", nil)
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestTrendsTestSuite(t *testing.T) {
+	suite.Run(t, &TrendsTestSuite{})
+}
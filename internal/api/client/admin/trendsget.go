@@ -0,0 +1,274 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"github.com/gin-gonic/gin"
+)
+
+// TrendsTagsGETHandler swagger:operation GET /api/v1/admin/trends/tags adminTrendsTagsGet
+//
+// View tags currently trending on this instance, most trending first.
+//
+// Unlike the public trends endpoint, this includes tags not yet approved,
+// and tags that have been rejected, so that they can be reviewed.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of tags to return.
+//		default: 10
+//		minimum: 1
+//		maximum: 20
+//		in: query
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n tags.
+//		default: 0
+//		minimum: 0
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:read
+//
+//	responses:
+//		'200':
+//			description: An array of trending tags.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminTrendsTag"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsTagsGETHandler(c *gin.Context) {
+	_, limit, offset, errWithCode := m.trendsGETParams(c, 10, 20)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Trends().AdminTagsGet(c.Request.Context(), limit, offset)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
+
+// TrendsStatusesGETHandler swagger:operation GET /api/v1/admin/trends/statuses adminTrendsStatusesGet
+//
+// View statuses currently trending on this instance, most trending first.
+//
+// Unlike the public trends endpoint, this includes statuses not yet approved,
+// and statuses that have been rejected, so that they can be reviewed.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of statuses to return.
+//		default: 20
+//		minimum: 1
+//		maximum: 40
+//		in: query
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n statuses.
+//		default: 0
+//		minimum: 0
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:read
+//
+//	responses:
+//		'200':
+//			description: An array of trending statuses.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminTrendsStatus"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsStatusesGETHandler(c *gin.Context) {
+	authed, limit, offset, errWithCode := m.trendsGETParams(c, 20, 40)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Trends().AdminStatusesGet(c.Request.Context(), authed.Account, limit, offset)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
+
+// TrendsLinksGETHandler swagger:operation GET /api/v1/admin/trends/links adminTrendsLinksGet
+//
+// View links currently trending on this instance, most trending first.
+//
+// Unlike the public trends endpoint, this includes links not yet approved,
+// and links that have been rejected, so that they can be reviewed.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of links to return.
+//		default: 10
+//		minimum: 1
+//		maximum: 20
+//		in: query
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n links.
+//		default: 0
+//		minimum: 0
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:read
+//
+//	responses:
+//		'200':
+//			description: An array of trending links.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminTrendsLink"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsLinksGETHandler(c *gin.Context) {
+	_, limit, offset, errWithCode := m.trendsGETParams(c, 10, 20)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Trends().AdminLinksGet(c.Request.Context(), limit, offset)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
+
+// trendsGETParams authenticates an admin request to view
+// trends, and parses the limit and offset query parameters.
+func (m *Module) trendsGETParams(
+	c *gin.Context,
+	defaultLimit int,
+	maxLimit int,
+) (*apiutil.Auth, int, int, gtserror.WithCode) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeAdminRead,
+	)
+	if errWithCode != nil {
+		return nil, 0, 0, errWithCode
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		return nil, 0, 0, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		return nil, 0, 0, gtserror.NewErrorNotAcceptable(err, err.Error())
+	}
+
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), defaultLimit, maxLimit, 1)
+	if errWithCode != nil {
+		return nil, 0, 0, errWithCode
+	}
+
+	offset, errWithCode := apiutil.ParseOffset(c.Query(apiutil.OffsetKey), 0, 100, 0)
+	if errWithCode != nil {
+		return nil, 0, 0, errWithCode
+	}
+
+	return authed, limit, offset, nil
+}
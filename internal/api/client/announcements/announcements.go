@@ -20,12 +20,21 @@
 import (
 	"net/http"
 
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
 	"code.superseriousbusiness.org/gotosocial/internal/processing"
 	"github.com/gin-gonic/gin"
 )
 
-// BasePath is the base path for this api module, excluding the api prefix
-const BasePath = "/v1/announcements"
+const (
+	// BasePath is the base path for this api module, excluding the api prefix
+	BasePath = "/v1/announcements"
+	// BasePathWithID is the base path with the ID key in it, for operations on one announcement.
+	BasePathWithID = BasePath + "/:" + apiutil.IDKey
+	// DismissPath is used for marking an announcement as read.
+	DismissPath = BasePathWithID + "/dismiss"
+	// ReactionPath is used for adding or removing a reaction to an announcement.
+	ReactionPath = BasePathWithID + "/reactions/:" + apiutil.AnnouncementReactionNameKey
+)
 
 type Module struct {
 	processor *processing.Processor
@@ -39,4 +48,7 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.AnnouncementsGETHandler)
+	attachHandler(http.MethodPost, DismissPath, m.AnnouncementDismissPOSTHandler)
+	attachHandler(http.MethodPut, ReactionPath, m.AnnouncementReactionPUTHandler)
+	attachHandler(http.MethodDelete, ReactionPath, m.AnnouncementReactionDELETEHandler)
 }
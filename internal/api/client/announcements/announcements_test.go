@@ -0,0 +1,271 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/announcements"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/internal/stream"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type AnnouncementsTestSuite struct {
+	suite.Suite
+
+	// standard suite models
+	testTokens        map[string]*gtsmodel.Token
+	testApplications  map[string]*gtsmodel.Application
+	testUsers         map[string]*gtsmodel.User
+	testAccounts      map[string]*gtsmodel.Account
+	testAnnouncements map[string]*gtsmodel.Announcement
+	testStructs       *testrig.TestStructs
+
+	// module being tested
+	announcements *announcements.Module
+}
+
+func (suite *AnnouncementsTestSuite) req(
+	httpMethod string,
+	requestPath string,
+	handler gin.HandlerFunc,
+	pathParams map[string]string,
+) (string, int) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// Prepare test context request.
+	request := httptest.NewRequest(httpMethod, requestPath, nil)
+	request.Header.Set("accept", "application/json")
+	ctx.Request = request
+
+	// Inject path parameters.
+	for k, v := range pathParams {
+		ctx.AddParam(k, v)
+	}
+
+	// Trigger the handler
+	handler(ctx)
+
+	// Read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Format as nice indented json.
+	dst := &bytes.Buffer{}
+	if err := json.Indent(dst, b, "", "  "); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return dst.String(), recorder.Code
+}
+
+func (suite *AnnouncementsTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testAnnouncements = testrig.NewTestAnnouncements()
+}
+
+func (suite *AnnouncementsTestSuite) SetupTest() {
+	suite.testStructs = testrig.SetupTestStructs(
+		"../../../../testrig/media",
+		"../../../../web/template",
+	)
+	suite.announcements = announcements.New(suite.testStructs.Processor)
+}
+
+func (suite *AnnouncementsTestSuite) TearDownTest() {
+	testrig.TearDownTestStructs(suite.testStructs)
+}
+
+func (suite *AnnouncementsTestSuite) getAnnouncements(withDismissed bool) string {
+	path := "/api" + announcements.BasePath
+	if withDismissed {
+		path += "?with_dismissed=true"
+	}
+
+	out, code := suite.req(
+		http.MethodGet,
+		path,
+		suite.announcements.AnnouncementsGETHandler,
+		nil,
+	)
+	suite.Equal(http.StatusOK, code)
+
+	return out
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementsGet() {
+	// Only the active announcement should be returned,
+	// not the unpublished or ended ones.
+	suite.Equal(`[
+  {
+    "id": "01JSV5B9WZ4F1N0T3M8Y7E2K6Q",
+    "content": "<p>Scheduled maintenance this weekend, expect some downtime!</p>",
+    "starts_at": "2025-04-26T10:00:00.000Z",
+    "ends_at": "",
+    "all_day": false,
+    "published_at": "2025-04-20T12:30:00.000Z",
+    "updated_at": "2025-04-20T12:30:00.000Z",
+    "published": true,
+    "read": false,
+    "mentions": [],
+    "statuses": [],
+    "tags": [],
+    "emoji": [],
+    "reactions": []
+  }
+]`, suite.getAnnouncements(false))
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementDismiss() {
+	announcement := suite.testAnnouncements["active_announcement"]
+
+	out, code := suite.req(
+		http.MethodPost,
+		"/api"+announcements.BasePath+"/"+announcement.ID+"/dismiss",
+		suite.announcements.AnnouncementDismissPOSTHandler,
+		map[string]string{"id": announcement.ID},
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("{}", out)
+
+	// Dismissed announcement should now be left out by default.
+	suite.Equal("[]", suite.getAnnouncements(false))
+
+	// ... but still be returned, as read, when asked for.
+	suite.Contains(suite.getAnnouncements(true), `"read": true`)
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementDismissUnpublished() {
+	announcement := suite.testAnnouncements["draft_announcement"]
+
+	out, code := suite.req(
+		http.MethodPost,
+		"/api"+announcements.BasePath+"/"+announcement.ID+"/dismiss",
+		suite.announcements.AnnouncementDismissPOSTHandler,
+		map[string]string{"id": announcement.ID},
+	)
+	suite.Equal(http.StatusNotFound, code)
+	suite.Equal(`{
+  "error": "Not Found: announcement not found"
+}`, out)
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementReactions() {
+	var (
+		ctx          = context.Background()
+		announcement = suite.testAnnouncements["active_announcement"]
+		path         = "/api" + announcements.BasePath + "/" + announcement.ID + "/reactions/"
+	)
+
+	// Open a user stream to watch for reaction events.
+	wssStream, errWithCode := suite.testStructs.Processor.Stream().Open(ctx,
+		suite.testAccounts["local_account_1"],
+		stream.TimelineHome,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer wssStream.Close()
+
+	react := func(method string, handler gin.HandlerFunc, name string) int {
+		_, code := suite.req(
+			method,
+			path+name,
+			handler,
+			map[string]string{"id": announcement.ID, "name": name},
+		)
+		return code
+	}
+
+	// React with a unicode emoji and a local custom emoji,
+	// and try reacting with the unicode one a second time.
+	suite.Equal(http.StatusOK, react(http.MethodPut, suite.announcements.AnnouncementReactionPUTHandler, "👍🏽"))
+	suite.Equal(http.StatusOK, react(http.MethodPut, suite.announcements.AnnouncementReactionPUTHandler, "rainbow"))
+	suite.Equal(http.StatusOK, react(http.MethodPut, suite.announcements.AnnouncementReactionPUTHandler, "👍🏽"))
+
+	// Reactions that aren't emojis should be rejected.
+	suite.Equal(http.StatusUnprocessableEntity, react(http.MethodPut, suite.announcements.AnnouncementReactionPUTHandler, "not_an_emoji"))
+	suite.Equal(http.StatusUnprocessableEntity, react(http.MethodPut, suite.announcements.AnnouncementReactionPUTHandler, "hello"))
+
+	// First reaction should have been streamed.
+	msgCtx, cncl := context.WithTimeout(ctx, time.Second)
+	defer cncl()
+	msg, ok := wssStream.Recv(msgCtx)
+	suite.True(ok)
+	suite.Equal(stream.EventTypeAnnouncementReaction, msg.Event)
+	suite.Equal(`{"name":"👍🏽","count":1,"announcement_id":"01JSV5B9WZ4F1N0T3M8Y7E2K6Q"}`, msg.Payload)
+
+	suite.Contains(suite.getAnnouncements(false), `"reactions": [
+      {
+        "name": "👍🏽",
+        "count": 1,
+        "me": true
+      },
+      {
+        "name": "rainbow",
+        "count": 1,
+        "me": true,
+        "url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png",
+        "static_url": "http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/static/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"
+      }
+    ]`)
+
+	// Remove the unicode reaction.
+	suite.Equal(http.StatusOK, react(http.MethodDelete, suite.announcements.AnnouncementReactionDELETEHandler, "👍🏽"))
+
+	reactions, err := suite.testStructs.State.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(reactions, 1)
+	suite.Equal("rainbow", reactions[0].Name)
+}
+
+func TestAnnouncementsTestSuite(t *testing.T) {
+	suite.Run(t, new(AnnouncementsTestSuite))
+}
@@ -27,9 +27,7 @@
 
 // AnnouncementsGETHandler swagger:operation GET /api/v1/announcements announcementsGet
 //
-// Get an array of currently active announcements.
-//
-// THIS ENDPOINT IS CURRENTLY NOT FULLY IMPLEMENTED: it will always return an empty array.
+// Get an array of currently active announcements, oldest first.
 //
 //	---
 //	tags:
@@ -38,16 +36,25 @@
 //	produces:
 //	- application/json
 //
+//	parameters:
+//	-
+//		name: with_dismissed
+//		type: boolean
+//		description: Include announcements that the requesting account has already dismissed.
+//		default: false
+//		in: query
+//
 //	security:
-//	- OAuth2 Bearer: []
+//	- OAuth2 Bearer:
+//		- read
 //
 //	responses:
 //		'200':
+//			description: Array of active announcements.
 //			schema:
 //				type: array
 //				items:
-//					type: object
-//				maxItems: 0
+//					"$ref": "#/definitions/announcement"
 //		'400':
 //			description: bad request
 //		'401':
@@ -57,8 +64,9 @@
 //		'500':
 //			description: internal server error
 func (m *Module) AnnouncementsGETHandler(c *gin.Context) {
-	_, errWithCode := apiutil.TokenAuth(c,
+	authed, errWithCode := apiutil.TokenAuth(c,
 		true, true, true, true,
+		apiutil.ScopeRead,
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
@@ -70,5 +78,24 @@ func (m *Module) AnnouncementsGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, apiutil.EmptyJSONArray)
+	withDismissed, errWithCode := apiutil.ParseAnnouncementsWithDismissed(
+		c.Query(apiutil.AnnouncementsWithDismissedKey),
+		false,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	announcements, errWithCode := m.processor.Announcements().GetActive(
+		c.Request.Context(),
+		authed.Account,
+		withDismissed,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, announcements)
 }
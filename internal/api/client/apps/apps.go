@@ -26,8 +26,9 @@
 )
 
 const (
-	BasePath       = "/v1/apps"
-	BasePathWithID = BasePath + "/:" + apiutil.IDKey
+	BasePath         = "/v1/apps"
+	BasePathWithID   = BasePath + "/:" + apiutil.IDKey
+	RotateSecretPath = BasePathWithID + "/rotate_secret"
 )
 
 type Module struct {
@@ -44,5 +45,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BasePath, m.AppsPOSTHandler)
 	attachHandler(http.MethodGet, BasePath, m.AppsGETHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.AppGETHandler)
+	attachHandler(http.MethodPatch, BasePathWithID, m.AppPATCHHandler)
 	attachHandler(http.MethodDelete, BasePathWithID, m.AppDELETEHandler)
+	attachHandler(http.MethodPost, RotateSecretPath, m.AppRotateSecretPOSTHandler)
 }
@@ -20,18 +20,22 @@
 import (
 	"net/http"
 
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
 	"code.superseriousbusiness.org/gotosocial/internal/processing"
 	"github.com/gin-gonic/gin"
 )
 
 const (
-	BasePath      = "/v1/exports"
-	StatsPath     = BasePath + "/stats"
-	FollowingPath = BasePath + "/following.csv"
-	FollowersPath = BasePath + "/followers.csv"
-	ListsPath     = BasePath + "/lists.csv"
-	BlocksPath    = BasePath + "/blocks.csv"
-	MutesPath     = BasePath + "/mutes.csv"
+	BasePath          = "/v1/exports"
+	StatsPath         = BasePath + "/stats"
+	FollowingPath     = BasePath + "/following.csv"
+	FollowersPath     = BasePath + "/followers.csv"
+	ListsPath         = BasePath + "/lists.csv"
+	BlocksPath        = BasePath + "/blocks.csv"
+	MutesPath         = BasePath + "/mutes.csv"
+	BookmarksPath     = BasePath + "/bookmarks.csv"
+	ArchivePath       = BasePath + "/archive"
+	ArchiveWithIDPath = ArchivePath + "/:" + apiutil.IDKey
 )
 
 type Module struct {
@@ -51,4 +55,8 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, ListsPath, m.ExportListsGETHandler)
 	attachHandler(http.MethodGet, BlocksPath, m.ExportBlocksGETHandler)
 	attachHandler(http.MethodGet, MutesPath, m.ExportMutesGETHandler)
+	attachHandler(http.MethodGet, BookmarksPath, m.ExportBookmarksGETHandler)
+	attachHandler(http.MethodPost, ArchivePath, m.ExportArchivePOSTHandler)
+	attachHandler(http.MethodGet, ArchivePath, m.ExportArchivesGETHandler)
+	attachHandler(http.MethodGet, ArchiveWithIDPath, m.ExportArchiveGETHandler)
 }
@@ -213,6 +213,18 @@ type testCase struct {
 			user:        suite.testUsers["local_account_2"],
 			account:     suite.testAccounts["local_account_2"],
 			expect: `foss_satan@fossbros-anonymous.io
+`,
+		},
+		// Export Bookmarks.
+		{
+			handler:     suite.exportsModule.ExportBookmarksGETHandler,
+			path:        exports.BookmarksPath,
+			contentType: apiutil.TextCSV,
+			application: suite.testApplications["application_1"],
+			token:       suite.testTokens["local_account_1"],
+			user:        suite.testUsers["local_account_1"],
+			account:     suite.testAccounts["local_account_1"],
+			expect: `http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R
 `,
 		},
 		// Export Stats.
@@ -231,7 +243,8 @@ type testCase struct {
   "statuses_count": 9,
   "lists_count": 1,
   "blocks_count": 0,
-  "mutes_count": 0
+  "mutes_count": 0,
+  "bookmarks_count": 1
 }`,
 		},
 	}
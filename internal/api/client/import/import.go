@@ -33,13 +33,18 @@
 )
 
 const (
-	BasePath = "/v1/import"
+	BasePath         = "/v1/import"
+	OutboxPath       = BasePath + "/outbox"
+	OutboxWithIDPath = OutboxPath + "/:" + apiutil.IDKey
 )
 
 var types = []string{
 	"following",
 	"blocks",
 	"mutes",
+	"lists",
+	"bookmarks",
+	"outbox",
 }
 
 var modes = []string{
@@ -59,17 +64,26 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, BasePath, m.ImportPOSTHandler)
+	attachHandler(http.MethodGet, OutboxPath, m.OutboxImportsGETHandler)
+	attachHandler(http.MethodGet, OutboxWithIDPath, m.OutboxImportGETHandler)
 }
 
 // ImportPOSTHandler swagger:operation POST /api/v1/import importData
 //
-// Upload some CSV-formatted data to your account.
+// Upload some CSV-formatted data, or an archive, to your account.
 //
 // This can be used to migrate data from a Mastodon-compatible CSV file to a GoToSocial account.
 //
 // Uploaded data will be processed asynchronously, and not all entries may be processed depending
 // on domain blocks, user-level blocks, network availability of referenced accounts and statuses, etc.
 //
+// With type `outbox`, the data file should instead be a Mastodon-compatible archive ZIP file.
+// Public and unlisted statuses in the archive's outbox are recreated, with their media, as
+// backdated statuses of your account, along with their mentions and hashtags. Boosts, polls,
+// and replies to statuses that can't be resolved are skipped. The progress of the import is
+// returned, and can be checked later at /api/v1/import/outbox/{id}. Only one outbox import
+// can be in progress at a time.
+//
 //	---
 //	tags:
 //	- import-export
@@ -84,7 +98,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //	-
 //		name: data
 //		in: formData
-//		description: The CSV data file to upload.
+//		description: The CSV data file, or archive ZIP file, to upload.
 //		type: file
 //		required: true
 //	-
@@ -96,6 +110,9 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //			- `following` - accounts to follow.
 //			- `blocks` - accounts to block.
 //			- `mutes` - accounts to mute.
+//			- `lists` - lists, and accounts in them. Accounts not yet followed are followed, and added to lists once the follow is accepted.
+//			- `bookmarks` - statuses to bookmark.
+//			- `outbox` - statuses in an archive.
 //
 //		type: string
 //		required: true
@@ -109,6 +126,15 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //			- `overwrite` to replace existing entries with entries in file.
 //		type: string
 //		default: merge
+//	-
+//		name: federate
+//		in: formData
+//		description: >-
+//			Federate statuses imported from an outbox to your followers.
+//			If false, imported statuses are only visible on this instance,
+//			or when fetched directly.
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -116,13 +142,19 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //
 //	responses:
 //		'202':
-//			description: Upload accepted.
+//			description: >-
+//				Upload accepted. For the `outbox` type, the body
+//				is the outboxImport model rather than a status.
 //		'400':
 //			description: bad request
 //		'401':
 //			description: unauthorized
+//		'403':
+//			description: backdating statuses has been disabled on this instance
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: an outbox import is already in progress
 //		'500':
 //			description: internal server error
 func (m *Module) ImportPOSTHandler(c *gin.Context) {
@@ -184,6 +216,24 @@ func (m *Module) ImportPOSTHandler(c *gin.Context) {
 	}
 	overwrite := form.Mode == "overwrite"
 
+	if form.Type == "outbox" {
+		// Outbox imports have their
+		// progress tracked separately.
+		outboxImport, errWithCode := m.processor.Account().ImportOutbox(
+			c.Request.Context(),
+			authed.Account,
+			form.Data,
+			form.Federate,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.JSON(c, http.StatusAccepted, outboxImport)
+		return
+	}
+
 	// Trigger the import.
 	errWithCode = m.processor.Account().ImportData(
 		c.Request.Context(),
@@ -46,6 +46,8 @@ type ImportTestSuite struct {
 	testApplications map[string]*gtsmodel.Application
 	testUsers        map[string]*gtsmodel.User
 	testAccounts     map[string]*gtsmodel.Account
+	testLists        map[string]*gtsmodel.List
+	testStatuses     map[string]*gtsmodel.Status
 
 	// module being tested
 	importModule *importdata.Module
@@ -56,6 +58,8 @@ func (suite *ImportTestSuite) SetupSuite() {
 	suite.testApplications = testrig.NewTestApplications()
 	suite.testUsers = testrig.NewTestUsers()
 	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testLists = testrig.NewTestLists()
+	suite.testStatuses = testrig.NewTestStatuses()
 }
 
 func (suite *ImportTestSuite) SetupTest() {
@@ -296,6 +300,186 @@ func (suite *ImportTestSuite) TestImportMutes() {
 
 }
 
+func (suite *ImportTestSuite) TestImportLists() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		admin       = suite.testAccounts["admin_account"]
+		turtle      = suite.testAccounts["local_account_2"]
+		coolList    = suite.testLists["local_account_1_list_1"]
+	)
+
+	// Have zork unfollow turtle, removing
+	// turtle from zork's existing list.
+	if err := suite.state.DB.DeleteFollow(ctx, testAccount.ID, turtle.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Put turtle back in the existing list,
+	// and admin in a new list. Turtle is locked,
+	// so they should only be added to the list
+	// when zork's follow request is accepted.
+	data := `Cool Ass Posters From This Instance,1happyturtle@localhost:8080
+Cool Ass Posters From This Instance,admin@localhost:8080
+New List,admin@localhost:8080
+`
+
+	// Trigger the import handler.
+	suite.TriggerHandler(data, "lists", "merge")
+
+	// Wait for new list
+	// to contain admin.
+	var newList *gtsmodel.List
+	if !testrig.WaitFor(func() bool {
+		lists, err := suite.state.DB.GetListsByAccountID(ctx, testAccount.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		for _, list := range lists {
+			if list.Title == "New List" {
+				newList = list
+			}
+		}
+		if newList == nil {
+			return false
+		}
+
+		in, err := suite.state.DB.IsAccountInList(ctx, newList.ID, admin.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return in
+	}) {
+		suite.FailNow("timed out waiting for admin to be added to new list")
+	}
+
+	// Wait for zork to be
+	// follow req'ing turtle.
+	if !testrig.WaitFor(func() bool {
+		f, err := suite.state.DB.IsFollowRequested(ctx, testAccount.ID, turtle.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return f
+	}) {
+		suite.FailNow("timed out waiting for zork to follow req turtle")
+	}
+
+	// Turtle isn't in
+	// the list yet.
+	in, err := suite.state.DB.IsAccountInList(ctx, coolList.ID, turtle.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(in)
+
+	// Turtle accepts the follow
+	// request, so should now be
+	// added to the existing list.
+	if _, err := suite.state.DB.AcceptFollowRequest(ctx, testAccount.ID, turtle.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	in, err = suite.state.DB.IsAccountInList(ctx, coolList.ID, turtle.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(in)
+
+	// Import again in overwrite mode:
+	//   - the existing list is removed
+	//   - turtle replaces admin in new list
+	data = `New List,1happyturtle@localhost:8080
+`
+
+	// Trigger the import handler.
+	suite.TriggerHandler(data, "lists", "overwrite")
+
+	// Wait for lists to be overwritten.
+	if !testrig.WaitFor(func() bool {
+		lists, err := suite.state.DB.GetListsByAccountID(ctx, testAccount.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if len(lists) != 1 || lists[0].ID != newList.ID {
+			return false
+		}
+
+		accountIDs, err := suite.state.DB.GetAccountIDsInList(ctx, newList.ID, nil)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return len(accountIDs) == 1 && accountIDs[0] == turtle.ID
+	}) {
+		suite.FailNow("timed out waiting for import to apply")
+	}
+}
+
+func (suite *ImportTestSuite) TestImportBookmarks() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		status1     = suite.testStatuses["admin_account_status_1"]
+		status2     = suite.testStatuses["admin_account_status_2"]
+	)
+
+	// Zork already bookmarked status 1, so
+	// have them bookmark admin's status 2,
+	// and a status that can't be found.
+	data := `http://localhost:8080/users/admin/statuses/01F8MHAAY43M6RJ473VQFCVH37
+https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839
+`
+
+	// Trigger the import handler.
+	suite.TriggerHandler(data, "bookmarks", "merge")
+
+	// Wait for status 2
+	// to be bookmarked.
+	if !testrig.WaitFor(func() bool {
+		b, err := suite.state.DB.IsStatusBookmarkedBy(ctx, testAccount.ID, status2.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return b
+	}) {
+		suite.FailNow("timed out waiting for zork to bookmark status 2")
+	}
+
+	// Import again in overwrite mode,
+	// removing the bookmark of status 1.
+	data = `http://localhost:8080/users/admin/statuses/01F8MHAAY43M6RJ473VQFCVH37
+`
+
+	// Trigger the import handler.
+	suite.TriggerHandler(data, "bookmarks", "overwrite")
+
+	// Wait for status 1 to
+	// no longer be bookmarked.
+	if !testrig.WaitFor(func() bool {
+		b, err := suite.state.DB.IsStatusBookmarkedBy(ctx, testAccount.ID, status1.ID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return !b
+	}) {
+		suite.FailNow("timed out waiting for import to apply")
+	}
+
+	bookmarks, err := suite.state.DB.GetStatusBookmarks(ctx, testAccount.ID, 0, "", "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(bookmarks, 1)
+	suite.Equal(status2.ID, bookmarks[0].StatusID)
+}
+
 func TestImportTestSuite(t *testing.T) {
 	suite.Run(t, new(ImportTestSuite))
 }
@@ -96,6 +96,16 @@
 //		in: formData
 //		description: Nth entry for public.can_reblog.with_approval.
 //		type: string
+//	-
+//		name: public[can_quote][always][0]
+//		in: formData
+//		description: Nth entry for public.can_quote.always.
+//		type: string
+//	-
+//		name: public[can_quote][with_approval][0]
+//		in: formData
+//		description: Nth entry for public.can_quote.with_approval.
+//		type: string
 //
 //	-
 //		name: unlisted[can_favourite][always][0]
@@ -127,6 +137,16 @@
 //		in: formData
 //		description: Nth entry for unlisted.can_reblog.with_approval.
 //		type: string
+//	-
+//		name: unlisted[can_quote][always][0]
+//		in: formData
+//		description: Nth entry for unlisted.can_quote.always.
+//		type: string
+//	-
+//		name: unlisted[can_quote][with_approval][0]
+//		in: formData
+//		description: Nth entry for unlisted.can_quote.with_approval.
+//		type: string
 //
 //	-
 //		name: private[can_favourite][always][0]
@@ -158,6 +178,16 @@
 //		in: formData
 //		description: Nth entry for private.can_reblog.with_approval.
 //		type: string
+//	-
+//		name: private[can_quote][always][0]
+//		in: formData
+//		description: Nth entry for private.can_quote.always.
+//		type: string
+//	-
+//		name: private[can_quote][with_approval][0]
+//		in: formData
+//		description: Nth entry for private.can_quote.with_approval.
+//		type: string
 //
 //	-
 //		name: direct[can_favourite][always][0]
@@ -189,6 +219,16 @@
 //		in: formData
 //		description: Nth entry for direct.can_reblog.with_approval.
 //		type: string
+//	-
+//		name: direct[can_quote][always][0]
+//		in: formData
+//		description: Nth entry for direct.can_quote.always.
+//		type: string
+//	-
+//		name: direct[can_quote][with_approval][0]
+//		in: formData
+//		description: Nth entry for direct.can_quote.with_approval.
+//		type: string
 //
 //	security:
 //	- OAuth2 Bearer:
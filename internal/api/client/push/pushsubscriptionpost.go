@@ -147,6 +147,24 @@
 //		default: false
 //		description: Receive a push notification when a boost is pending?
 //	-
+//		name: data[alerts][quote]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when a status you created has been quoted by someone else?
+//	-
+//		name: data[alerts][pending.quote]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when a quote is pending?
+//	-
+//		name: data[alerts][pleroma:emoji_reaction]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone else has reacted to a status you created with an emoji?
+//	-
 //		name: data[policy]
 //		in: formData
 //		type: string
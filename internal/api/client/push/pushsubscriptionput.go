@@ -123,6 +123,24 @@
 //		default: false
 //		description: Receive a push notification when a boost is pending?
 //	-
+//		name: data[alerts][quote]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when a status you created has been quoted by someone else?
+//	-
+//		name: data[alerts][pending.quote]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when a quote is pending?
+//	-
+//		name: data[alerts][pleroma:emoji_reaction]
+//		in: formData
+//		type: boolean
+//		default: false
+//		description: Receive a push notification when someone else has reacted to a status you created with an emoji?
+//	-
 //		name: data[policy]
 //		in: formData
 //		type: string
@@ -242,6 +260,15 @@ func validateNormalizeUpdate(request *apimodel.WebPushSubscriptionUpdateRequest)
 	if request.DataAlertsPendingReblog != nil {
 		request.Data.Alerts.Reblog = *request.DataAlertsPendingReblog
 	}
+	if request.DataAlertsQuote != nil {
+		request.Data.Alerts.Quote = *request.DataAlertsQuote
+	}
+	if request.DataAlertsPendingQuote != nil {
+		request.Data.Alerts.PendingQuote = *request.DataAlertsPendingQuote
+	}
+	if request.DataAlertsReaction != nil {
+		request.Data.Alerts.Reaction = *request.DataAlertsReaction
+	}
 
 	if request.DataPolicy != nil {
 		request.Data.Policy = request.DataPolicy
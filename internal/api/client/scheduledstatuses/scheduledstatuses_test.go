@@ -0,0 +1,254 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scheduledstatuses_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/scheduledstatuses"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScheduledStatusesTestSuite struct {
+	suite.Suite
+
+	// standard suite models
+	testTokens       map[string]*gtsmodel.Token
+	testApplications map[string]*gtsmodel.Application
+	testUsers        map[string]*gtsmodel.User
+	testAccounts     map[string]*gtsmodel.Account
+	testStructs      *testrig.TestStructs
+
+	// scheduled status for local_account_1
+	scheduledStatus *gtsmodel.ScheduledStatus
+
+	// module being tested
+	scheduledStatuses *scheduledstatuses.Module
+}
+
+func (suite *ScheduledStatusesTestSuite) req(
+	httpMethod string,
+	requestPath string,
+	handler gin.HandlerFunc,
+	pathParams map[string]string,
+	body string,
+) (string, int) {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+	)
+
+	// Prepare test context.
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// Prepare test context request.
+	request := httptest.NewRequest(httpMethod, requestPath, strings.NewReader(body))
+	request.Header.Set("accept", "application/json")
+	if body != "" {
+		request.Header.Set("content-type", "application/json")
+	}
+	ctx.Request = request
+
+	// Inject path parameters.
+	for k, v := range pathParams {
+		ctx.AddParam(k, v)
+	}
+
+	// Trigger the handler
+	handler(ctx)
+
+	// Read the response
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Format as nice indented json.
+	dst := &bytes.Buffer{}
+	if err := json.Indent(dst, b, "", "  "); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return dst.String(), recorder.Code
+}
+
+func (suite *ScheduledStatusesTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *ScheduledStatusesTestSuite) SetupTest() {
+	suite.testStructs = testrig.SetupTestStructs(
+		"../../../../testrig/media",
+		"../../../../web/template",
+	)
+	suite.scheduledStatuses = scheduledstatuses.New(suite.testStructs.Processor)
+
+	suite.scheduledStatus = &gtsmodel.ScheduledStatus{
+		ID:            "01JRVWBX0FVCCMT9ZB14PB1BD2",
+		AccountID:     suite.testAccounts["local_account_1"].ID,
+		ScheduledAt:   testrig.TimeMustParse("2080-10-04T15:32:02Z"),
+		Text:          "hello from the future!",
+		Sensitive:     util.Ptr(false),
+		Visibility:    gtsmodel.VisibilityPublic,
+		LocalOnly:     util.Ptr(false),
+		ContentType:   gtsmodel.StatusContentTypePlain,
+		Language:      "en",
+		ApplicationID: suite.testApplications["application_1"].ID,
+	}
+	if err := suite.testStructs.State.DB.PutScheduledStatus(
+		context.Background(),
+		suite.scheduledStatus,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *ScheduledStatusesTestSuite) TearDownTest() {
+	testrig.TearDownTestStructs(suite.testStructs)
+}
+
+func (suite *ScheduledStatusesTestSuite) TestScheduledStatusesGet() {
+	out, code := suite.req(
+		http.MethodGet,
+		"/api"+scheduledstatuses.BasePath,
+		suite.scheduledStatuses.ScheduledStatusesGETHandler,
+		nil,
+		"",
+	)
+
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`[
+  {
+    "id": "01JRVWBX0FVCCMT9ZB14PB1BD2",
+    "scheduled_at": "2080-10-04T15:32:02.000Z",
+    "params": {
+      "text": "hello from the future!",
+      "poll": null,
+      "media_ids": [],
+      "sensitive": false,
+      "spoiler_text": "",
+      "visibility": "public",
+      "in_reply_to_id": "",
+      "language": "en",
+      "application_id": "01F8MGY43H3N2C8EWPR2FPYEXG",
+      "local_only": false,
+      "content_type": "text/plain",
+      "interaction_policy": null,
+      "idempotency": null,
+      "scheduled_at": null,
+      "with_rate_limit": false
+    },
+    "media_attachments": []
+  }
+]`, out)
+}
+
+func (suite *ScheduledStatusesTestSuite) TestScheduledStatusUpdate() {
+	out, code := suite.req(
+		http.MethodPut,
+		"/api"+scheduledstatuses.BasePath+"/"+suite.scheduledStatus.ID,
+		suite.scheduledStatuses.ScheduledStatusPUTHandler,
+		map[string]string{"id": suite.scheduledStatus.ID},
+		`{"scheduled_at":"2081-01-01T12:00:00Z"}`,
+	)
+
+	suite.Equal(http.StatusOK, code)
+	suite.Contains(out, `"scheduled_at": "2081-01-01T12:00:00.000Z"`)
+
+	dbScheduledStatus, err := suite.testStructs.State.DB.GetScheduledStatusByID(
+		context.Background(),
+		suite.scheduledStatus.ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbScheduledStatus.ScheduledAt.Equal(time.Date(2081, 1, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func (suite *ScheduledStatusesTestSuite) TestScheduledStatusUpdateTooSoon() {
+	out, code := suite.req(
+		http.MethodPut,
+		"/api"+scheduledstatuses.BasePath+"/"+suite.scheduledStatus.ID,
+		suite.scheduledStatuses.ScheduledStatusPUTHandler,
+		map[string]string{"id": suite.scheduledStatus.ID},
+		`{"scheduled_at":"2020-01-01T12:00:00Z"}`,
+	)
+
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal(`{
+  "error": "Unprocessable Entity: scheduled_at must be at least 5 minutes in the future"
+}`, out)
+}
+
+func (suite *ScheduledStatusesTestSuite) TestScheduledStatusDelete() {
+	out, code := suite.req(
+		http.MethodDelete,
+		"/api"+scheduledstatuses.BasePath+"/"+suite.scheduledStatus.ID,
+		suite.scheduledStatuses.ScheduledStatusDELETEHandler,
+		map[string]string{"id": suite.scheduledStatus.ID},
+		"",
+	)
+
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{}`, out)
+
+	_, err := suite.testStructs.State.DB.GetScheduledStatusByID(
+		context.Background(),
+		suite.scheduledStatus.ID,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Deleting again should 404.
+	_, code = suite.req(
+		http.MethodDelete,
+		"/api"+scheduledstatuses.BasePath+"/"+suite.scheduledStatus.ID,
+		suite.scheduledStatuses.ScheduledStatusDELETEHandler,
+		map[string]string{"id": suite.scheduledStatus.ID},
+		"",
+	)
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestScheduledStatusesTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusesTestSuite))
+}
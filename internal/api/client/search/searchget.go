@@ -80,11 +80,10 @@
 //		name: offset
 //		type: integer
 //		description: >-
-//			Page number of results to return (starts at 0).
-//			This parameter is currently not used, page by selecting
-//			a specific query type and using maxID and minID instead.
+//			Number of results of each type to skip before returning results,
+//			for paging through results ordered by relevance.
 //		default: 0
-//		maximum: 10
+//		maximum: 1000
 //		minimum: 0
 //		in: query
 //		required: false
@@ -97,10 +96,19 @@
 //			- @[username]@[domain]` -- search for a remote account with exact username and domain. Will only ever return 1 result at most.
 //			- `https://example.org/some/arbitrary/url` -- search for an account OR a status with the given URL. Will only ever return 1 result at most.
 //			- `#[hashtag_name]` -- search for a hashtag with the given hashtag name, or starting with the given hashtag name. Case insensitive. Can return multiple results.
-//			- any arbitrary string -- search for accounts or statuses containing the given string. Can return multiple results.
+//			- any arbitrary string -- search for accounts or statuses containing all words in the given string, ordered by relevance. Can return multiple results.
 //
-//			Arbitrary string queries may include the following operators:
-//			- `from:localuser`, `from:remoteuser@instance.tld`: restrict results to statuses created by the specified account.
+//			Arbitrary string queries may contain "quoted phrases" that must match in order, and terms prefixed by `-` that must not match.
+//			They may also include the following operators, which restrict status results only:
+//			- `from:localuser`, `from:remoteuser@instance.tld`, `from:me`: statuses created by the specified account.
+//			- `has:media`, `has:poll`, `has:link`: statuses with media attachments, a poll, or a link.
+//			- `is:reply`, `is:sensitive`: statuses that are replies, or are marked as sensitive.
+//			- `in:library`: also search statuses you've faved, bookmarked, or been mentioned in.
+//			- `language:xx`: statuses in the given language.
+//			- `before:YYYY-MM-DD`, `after:YYYY-MM-DD`, `during:YYYY-MM-DD`: statuses created before, after, or on the given day (UTC).
+//
+//			Operators other than `in:`, `before:`, `after:` and `during:` may be negated by prefixing them with `-`, eg., `-has:media`.
+//			Using an unknown operator or an invalid operator value results in a 422 error.
 //		in: query
 //		required: true
 //	-
@@ -112,8 +120,8 @@
 //			- `accounts` -- return only account(s).
 //			- `statuses` -- return only status(es).
 //			- `hashtags` -- return only hashtag(s).
-//			If `type` is specified, paging can be performed using max_id and min_id parameters.
-//			If `type` is not specified, see the `offset` parameter for paging.
+//			Text search results are ordered by relevance, see the `offset` parameter for paging.
+//			If `type` is specified, max_id and min_id parameters can be used to narrow results.
 //		in: query
 //	-
 //		name: resolve
@@ -165,6 +173,8 @@
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: unknown search operator, or invalid operator value
 //		'500':
 //			description: internal server error
 func (m *Module) SearchGETHandler(c *gin.Context) {
@@ -209,7 +219,7 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		return
 	}
 
-	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 10, 0)
+	offset, errWithCode := apiutil.ParseSearchOffset(c.Query(apiutil.SearchOffsetKey), 0, 1000, 0)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
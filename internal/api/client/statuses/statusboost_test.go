@@ -115,6 +115,13 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -170,6 +177,13 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
         ],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "public",
@@ -300,6 +314,13 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -351,6 +372,13 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
         ],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "author",
+          "me"
+        ],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "author",
@@ -515,6 +543,13 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -562,6 +597,13 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
         ],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "public",
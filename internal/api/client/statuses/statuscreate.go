@@ -21,6 +21,7 @@
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
@@ -130,6 +131,12 @@
 //		type: string
 //		in: formData
 //	-
+//		name: quoted_status_id
+//		x-go-name: QuotedStatusID
+//		description: ID of the status being quoted, if status is a quote.
+//		type: string
+//		in: formData
+//	-
 //		name: sensitive
 //		x-go-name: Sensitive
 //		description: Status and attached media should be marked as sensitive.
@@ -181,7 +188,6 @@
 //
 //			Providing this parameter with a *future* time will cause ScheduledStatus to be returned instead of Status.
 //			Must be at least 5 minutes in the future.
-//			This feature isn't implemented yet.
 //
 //			Providing this parameter with a *past* time will cause the status to be backdated,
 //			and will not push it to the user's followers. This is intended for importing old statuses.
@@ -233,6 +239,16 @@
 //		in: formData
 //		description: Nth entry for interaction_policy.can_reblog.with_approval.
 //		type: string
+//	-
+//		name: interaction_policy[can_quote][always][0]
+//		in: formData
+//		description: Nth entry for interaction_policy.can_quote.always.
+//		type: string
+//	-
+//		name: interaction_policy[can_quote][with_approval][0]
+//		in: formData
+//		description: Nth entry for interaction_policy.can_quote.with_approval.
+//		type: string
 //
 //	produces:
 //	- application/json
@@ -243,7 +259,9 @@
 //
 //	responses:
 //		'200':
-//			description: "The newly created status."
+//			description: >-
+//				The newly created status. If scheduled_at was set to a
+//				future time, a scheduledStatus is returned instead.
 //			schema:
 //				"$ref": "#/definitions/status"
 //		'400':
@@ -256,10 +274,12 @@
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: >-
+//				scheduled_at was set to a future time, but was less than 5 minutes
+//				in the future, or the account's scheduled status limits were reached
 //		'500':
 //			description: internal server error
-//		'501':
-//			description: scheduled_at was set, but this feature is not yet implemented
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	authed, errWithCode := apiutil.TokenAuth(c,
 		true, true, true, true,
@@ -295,6 +315,24 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	// }
 	// form.Status += "\n\nsent from " + user + "'s iphone\n"
 
+	if form.ScheduledAt != nil && time.Now().Before(*form.ScheduledAt) {
+		// A future time was given, so this status
+		// should be scheduled instead of created.
+		apiScheduledStatus, errWithCode := m.processor.Status().ScheduledStatusesCreate(
+			c.Request.Context(),
+			authed.Account,
+			authed.Application,
+			form,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.JSON(c, http.StatusOK, apiScheduledStatus)
+		return
+	}
+
 	apiStatus, errWithCode := m.processor.Status().Create(
 		c.Request.Context(),
 		authed.Account,
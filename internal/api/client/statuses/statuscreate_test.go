@@ -30,6 +30,7 @@
 
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/statuses"
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 	"code.superseriousbusiness.org/gotosocial/testrig"
@@ -158,6 +159,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatus() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -243,6 +251,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicy() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -340,6 +355,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicyJSON() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -410,7 +432,7 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusMessedUpIntPolicy() {
 }
 
 func (suite *StatusCreateTestSuite) TestPostNewScheduledStatus() {
-	out, recorder := suite.postStatus(map[string][]string{
+	recorder := suite.postStatusCore(map[string][]string{
 		"status":       {"this is a brand new status! #helloworld"},
 		"spoiler_text": {"hello hello"},
 		"sensitive":    {"true"},
@@ -418,13 +440,55 @@ func (suite *StatusCreateTestSuite) TestPostNewScheduledStatus() {
 		"scheduled_at": {"2080-10-04T15:32:02.018Z"},
 	}, "")
 
-	// We should have 501 from
+	// We should have OK from
 	// our call to the function.
-	suite.Equal(http.StatusNotImplemented, recorder.Code)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	data, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// We should have a scheduled status
+	// back, rather than a regular status.
+	scheduledStatus := apimodel.ScheduledStatus{}
+	if err := json.Unmarshal(data, &scheduledStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEmpty(scheduledStatus.ID)
+	suite.Equal("2080-10-04T15:32:02.018Z", scheduledStatus.ScheduledAt)
+	suite.Equal("this is a brand new status! #helloworld", scheduledStatus.Params.Text)
+	suite.Equal("hello hello", scheduledStatus.Params.SpoilerText)
+	suite.True(scheduledStatus.Params.Sensitive)
+	suite.Equal(apimodel.VisibilityPrivate, scheduledStatus.Params.Visibility)
+	suite.Empty(scheduledStatus.MediaAttachments)
+
+	// It should be stored in the database.
+	dbScheduledStatus, err := suite.db.GetScheduledStatusByID(context.Background(), scheduledStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(suite.testAccounts["local_account_1"].ID, dbScheduledStatus.AccountID)
+	suite.Equal(gtsmodel.VisibilityMutualsOnly, dbScheduledStatus.Visibility)
+}
+
+func (suite *StatusCreateTestSuite) TestPostNewScheduledStatusTooSoon() {
+	out, recorder := suite.postStatus(map[string][]string{
+		"status":       {"this is a brand new status! #helloworld"},
+		"scheduled_at": {time.Now().Add(time.Minute).Format(time.RFC3339)},
+	}, "")
+
+	// We should have 422 from
+	// our call to the function.
+	suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
 
 	// We should have a helpful error message.
 	suite.Equal(`{
-  "error": "Not Implemented: scheduled statuses are not yet supported"
+  "error": "Unprocessable Entity: scheduled_at must be at least 5 minutes in the future"
 }`, out)
 }
 
@@ -555,6 +619,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusMarkdown() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -640,6 +711,13 @@ func (suite *StatusCreateTestSuite) TestMentionUnknownAccount() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -719,6 +797,13 @@ func (suite *StatusCreateTestSuite) TestPostStatusWithLinksAndTags() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -812,6 +897,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithEmoji() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -903,6 +995,13 @@ func (suite *StatusCreateTestSuite) TestReplyToLocalStatus() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -987,6 +1086,13 @@ func (suite *StatusCreateTestSuite) TestAttachNewMediaSuccess() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1093,6 +1199,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithNoncanonicalLanguageTag
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1169,6 +1282,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollForm() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1267,6 +1387,13 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
       ],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "public",
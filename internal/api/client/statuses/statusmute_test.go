@@ -171,6 +171,13 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, muted)
@@ -261,6 +268,13 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, unmuted)
@@ -0,0 +1,671 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package streaming
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	streampkg "code.superseriousbusiness.org/gotosocial/internal/stream"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sseWriteTimeout is the time allowed for
+// writing each chunk of an event stream.
+const sseWriteTimeout = 30 * time.Second
+
+var (
+	// sseOpen is written on opening a server-sent
+	// events stream, to let the client know that
+	// the stream is open before the first event.
+	sseOpen = []byte(":)\n\n")
+
+	// sseHeartbeat is written into a server-sent events
+	// stream when nothing else has been written for a
+	// while, to keep the connection (and proxies) alive.
+	sseHeartbeat = []byte(":thump\n\n")
+)
+
+// HealthGETHandler swagger:operation GET /api/v1/streaming/health streamHealthGet
+//
+// Check whether the streaming API is available.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/plain
+//
+//	responses:
+//		'200':
+//			description: OK
+func (m *Module) HealthGETHandler(c *gin.Context) {
+	apiutil.Data(c, http.StatusOK, apiutil.TextPlain, []byte("OK"))
+}
+
+// UserSSEGETHandler swagger:operation GET /api/v1/streaming/user streamUserSSEGet
+//
+// Open a server-sent events stream of updates for the account's home timeline, and notifications.
+//
+// Events are written with an `id`, an `event` type, and `data` containing the payload of the event,
+// as described for the websocket streaming endpoint. A heartbeat comment is written into the stream
+// every 30 seconds to keep the connection alive.
+//
+// When reconnecting, clients can provide the `id` of the last event they received in a `Last-Event-ID`
+// header, to have events they missed in the meantime replayed (for up to a few minutes after disconnecting).
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in the Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received by the client, to replay events from.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) UserSSEGETHandler(c *gin.Context) {
+	m.handleSSE(c, streampkg.TimelineHome)
+}
+
+// UserNotificationSSEGETHandler swagger:operation GET /api/v1/streaming/user/notification streamUserNotificationSSEGet
+//
+// Open a server-sent events stream of notifications for the account.
+//
+// See `/api/v1/streaming/user` for details of the stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in the Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received by the client, to replay events from.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) UserNotificationSSEGETHandler(c *gin.Context) {
+	m.handleSSE(c, streampkg.TimelineNotifications)
+}
+
+// PublicSSEGETHandler swagger:operation GET /api/v1/streaming/public streamPublicSSEGet
+//
+// Open a server-sent events stream of updates for the public timeline.
+//
+// See `/api/v1/streaming/user` for details of the stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: only_media
+//		type: boolean
+//		description: Only stream statuses with media attachments.
+//		default: false
+//		in: query
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in the Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received by the client, to replay events from.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) PublicSSEGETHandler(c *gin.Context) {
+	m.handlePublicSSE(c,
+		streampkg.TimelinePublic,
+		streampkg.TimelinePublicMedia,
+	)
+}
+
+// PublicLocalSSEGETHandler swagger:operation GET /api/v1/streaming/public/local streamPublicLocalSSEGet
+//
+// Open a server-sent events stream of updates for the local timeline.
+//
+// See `/api/v1/streaming/user` for details of the stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: only_media
+//		type: boolean
+//		description: Only stream statuses with media attachments.
+//		default: false
+//		in: query
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in the Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received by the client, to replay events from.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) PublicLocalSSEGETHandler(c *gin.Context) {
+	m.handlePublicSSE(c,
+		streampkg.TimelineLocal,
+		streampkg.TimelineLocalMedia,
+	)
+}
+
+// PublicRemoteSSEGETHandler swagger:operation GET /api/v1/streaming/public/remote streamPublicRemoteSSEGet
+//
+// Open a server-sent events stream of updates for the public timeline, from other servers only.
+//
+// See `/api/v1/streaming/user` for details of the stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: only_media
+//		type: boolean
+//		description: Only stream statuses with media attachments.
+//		default: false
+//		in: query
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in the Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received by the client, to replay events from.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) PublicRemoteSSEGETHandler(c *gin.Context) {
+	m.handlePublicSSE(c,
+		streampkg.TimelineRemote,
+		streampkg.TimelineRemoteMedia,
+	)
+}
+
+// HashtagSSEGETHandler swagger:operation GET /api/v1/streaming/hashtag streamHashtagSSEGet
+//
+// Open a server-sent events stream of updates for the given hashtag.
+//
+// See `/api/v1/streaming/user` for details of the stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: tag
+//		type: string
+//		description: Name of the tag to stream.
+//		in: query
+//		required: true
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in the Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received by the client, to replay events from.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) HashtagSSEGETHandler(c *gin.Context) {
+	m.handleParamSSE(c, streampkg.TimelineHashtag, StreamTagKey)
+}
+
+// HashtagLocalSSEGETHandler swagger:operation GET /api/v1/streaming/hashtag/local streamHashtagLocalSSEGet
+//
+// Open a server-sent events stream of updates for the given hashtag, from this server only.
+//
+// See `/api/v1/streaming/user` for details of the stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: tag
+//		type: string
+//		description: Name of the tag to stream.
+//		in: query
+//		required: true
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in the Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received by the client, to replay events from.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) HashtagLocalSSEGETHandler(c *gin.Context) {
+	m.handleParamSSE(c, streampkg.TimelineHashtagLocal, StreamTagKey)
+}
+
+// ListSSEGETHandler swagger:operation GET /api/v1/streaming/list streamListSSEGet
+//
+// Open a server-sent events stream of updates for the given list.
+//
+// See `/api/v1/streaming/user` for details of the stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: list
+//		type: string
+//		description: ID of the list to stream.
+//		in: query
+//		required: true
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in the Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received by the client, to replay events from.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) ListSSEGETHandler(c *gin.Context) {
+	m.handleParamSSE(c, streampkg.TimelineList, StreamListKey)
+}
+
+// DirectSSEGETHandler swagger:operation GET /api/v1/streaming/direct streamDirectSSEGet
+//
+// Open a server-sent events stream of updates for direct conversations.
+//
+// See `/api/v1/streaming/user` for details of the stream format.
+//
+//	---
+//	tags:
+//	- streaming
+//
+//	produces:
+//	- text/event-stream
+//
+//	parameters:
+//	-
+//		name: access_token
+//		type: string
+//		description: Access token for the requesting account, if not provided in the Authorization header.
+//		in: query
+//	-
+//		name: Last-Event-ID
+//		type: string
+//		description: ID of the last event received by the client, to replay events from.
+//		in: header
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:streaming
+//
+//	responses:
+//		'200':
+//			description: Stream of server-sent events.
+//		'401':
+//			description: unauthorized
+//		'400':
+//			description: bad request
+func (m *Module) DirectSSEGETHandler(c *gin.Context) {
+	m.handleSSE(c, streampkg.TimelineDirect)
+}
+
+// handlePublicSSE handles a server-sent events stream for one of the
+// public timelines, using the media-only stream type if requested.
+func (m *Module) handlePublicSSE(c *gin.Context, streamType string, mediaStreamType string) {
+	onlyMedia, errWithCode := apiutil.ParseStreamingOnlyMedia(
+		c.Query(apiutil.StreamingOnlyMediaKey),
+		false,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if onlyMedia {
+		streamType = mediaStreamType
+	}
+
+	m.handleSSE(c, streamType)
+}
+
+// handleParamSSE handles a server-sent events stream for a parameterized
+// stream type, taking the parameter from the given (required) query key.
+func (m *Module) handleParamSSE(c *gin.Context, streamType string, key string) {
+	param := c.Query(key)
+	if param == "" {
+		const text = "no " + StreamTagKey + " or " + StreamListKey + " given"
+		errWithCode := gtserror.NewErrorBadRequest(errors.New(text), text)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	m.handleSSE(c, streamType+":"+param)
+}
+
+// handleSSE opens a stream of the given type for the requesting account,
+// resuming from the Last-Event-ID header if given, and writes messages
+// from the stream into the response as server-sent events until either
+// the client goes away, or writing fails.
+func (m *Module) handleSSE(c *gin.Context, streamType string) {
+	// Authorize with query token if
+	// given, else regular oauth header.
+	token := c.Query(AccessTokenQueryKey)
+	account, ok := m.authorize(c, token)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	stream, errWithCode := m.processor.Stream().Resume(
+		ctx,
+		account,
+		streamType,
+		c.GetHeader(LastEventIDHeader),
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Ensure stream closed.
+	defer stream.Close()
+
+	l := log.WithContext(ctx).
+		WithField("username", account.Username).
+		WithField("streamType", streamType)
+
+	// Set event stream headers. Proxies
+	// (eg., nginx) may try to buffer the
+	// response, so ask them nicely not to.
+	header := c.Writer.Header()
+	header.Set("Content-Type", apiutil.TextEventStream+"; charset=utf-8")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	rc := http.NewResponseController(c.Writer)
+	if err := m.writeSSE(rc, c.Writer, sseOpen); err != nil {
+		l.Debugf("error opening event stream: %v", err)
+		return
+	}
+
+	l.Info("opened event stream")
+	m.writeToSSE(ctx, rc, c.Writer, stream, m.dTicker, &l)
+	l.Info("closed event stream")
+}
+
+// writeToSSE receives messages coming from the processor via the given
+// stream, and writes them as events into the given response writer. This
+// function also handles sending heartbeats to keep the connection alive
+// when no other activity occurs.
+//
+// This is a blocking function; will return only on write
+// error, if the stream is closed, or if ctx is canceled.
+func (m *Module) writeToSSE(
+	ctx context.Context,
+	rc *http.ResponseController,
+	w http.ResponseWriter,
+	stream *streampkg.Stream,
+	heartbeat time.Duration,
+	l *log.Entry,
+) {
+	for {
+		// Wrap context with timeout to send a heartbeat.
+		hbCtx, cncl := context.WithTimeout(ctx, heartbeat)
+
+		// Block and wait for
+		// one of the following:
+		//
+		// - receipt of msg
+		// - timeout of hbCtx
+		// - stream closed.
+		msg, haveMsg := stream.Recv(hbCtx)
+
+		// If heartbeat context has timed out
+		// (and not the parent), send heartbeat.
+		//
+		// In any case cancel hbCtx
+		// as we're done with it.
+		shouldBeat := (hbCtx.Err() != nil && ctx.Err() == nil)
+		cncl()
+
+		switch {
+		case haveMsg:
+			// We have a message to stream.
+			l.Tracef("writing event: %+v", msg)
+
+			if err := m.writeSSE(rc, w, formatSSE(msg)); err != nil {
+				// Client probably went away; they
+				// can reconnect (and resume) if needed.
+				l.Debugf("error writing event: %v", err)
+				return
+			}
+
+		case !shouldBeat:
+			// The client has gone away, or
+			// the stream has been closed,
+			// so nothing further to do here.
+			l.Trace("client gone or stream closed, returning...")
+			return
+
+		default:
+			// We have no message but we do
+			// need to send a heartbeat.
+			l.Trace("writing event stream heartbeat")
+
+			if err := m.writeSSE(rc, w, sseHeartbeat); err != nil {
+				l.Debugf("error writing event stream heartbeat: %v", err)
+				return
+			}
+		}
+	}
+}
+
+// writeSSE writes the given data into the event stream and flushes it,
+// extending the write deadline beforehand (until after the next heartbeat
+// is due), as the server's default write timeout would otherwise close the
+// stream after a short while.
+func (m *Module) writeSSE(rc *http.ResponseController, w http.ResponseWriter, b []byte) error {
+	deadline := time.Now().Add(m.dTicker + sseWriteTimeout)
+	if err := rc.SetWriteDeadline(deadline); err != nil &&
+		!errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	if _, err := w.Write(b); err != nil {
+		return err
+	}
+
+	return rc.Flush()
+}
+
+// formatSSE formats the given
+// message as a server-sent event.
+func formatSSE(msg streampkg.Message) []byte {
+	var buf bytes.Buffer
+
+	if msg.ID != "" {
+		buf.WriteString("id: " + msg.ID + "\n")
+	}
+
+	buf.WriteString("event: " + msg.Event + "\n")
+
+	// Data may not contain newlines, so
+	// split payload over multiple fields.
+	// Always include at least one field,
+	// as otherwise event won't dispatch.
+	for _, line := range strings.Split(msg.Payload, "\n") {
+		buf.WriteString("data: " + line + "\n")
+	}
+
+	buf.WriteString("\n")
+	return buf.Bytes()
+}
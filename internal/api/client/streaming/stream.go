@@ -29,6 +29,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	processingstream "code.superseriousbusiness.org/gotosocial/internal/processing/stream"
 	streampkg "code.superseriousbusiness.org/gotosocial/internal/stream"
 
 	"github.com/gin-gonic/gin"
@@ -79,6 +80,10 @@
 //			`user`: receive updates for the account's home timeline.
 //			`public`: receive updates for the public timeline.
 //			`public:local`: receive updates for the local timeline.
+//			`public:media`: receive updates for the public timeline, with media only.
+//			`public:local:media`: receive updates for the local timeline, with media only.
+//			`public:remote`: receive updates for the public timeline, from other servers only.
+//			`public:remote:media`: receive updates for the public timeline, from other servers, with media only.
 //			`hashtag`: receive updates for a given hashtag.
 //			`hashtag:local`: receive local updates for a given hashtag.
 //			`list`: receive updates for a certain list of accounts.
@@ -117,6 +122,10 @@
 //							- user
 //							- public
 //							- public:local
+//							- public:media
+//							- public:local:media
+//							- public:remote
+//							- public:remote:media
 //							- hashtag
 //							- hashtag:local
 //							- list
@@ -153,11 +162,6 @@
 //		'400':
 //			description: bad request
 func (m *Module) StreamGETHandler(c *gin.Context) {
-	var (
-		account     *gtsmodel.Account
-		errWithCode gtserror.WithCode
-	)
-
 	// Check both query parameter AND header "Sec-Websocket-Protocol"
 	// value for a token. The latter is hacky and not technically
 	// correct, but some client do it since Mastodon allows it, so
@@ -171,33 +175,8 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// Prefer query token else use header token.
 	token := cmp.Or(queryToken, headerToken)
 
-	if token != "" {
-
-		// Token was provided, use it to authorize stream.
-		account, errWithCode = m.processor.Stream().Authorize(c.Request.Context(), token)
-		if errWithCode != nil {
-			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-			return
-		}
-
-	} else {
-
-		// No explicit token was provided:
-		// try regular oauth as a last resort.
-		authed, errWithCode := apiutil.TokenAuth(c, true, true, true, true)
-		if errWithCode != nil {
-			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-			return
-		}
-
-		// Set the auth'ed account.
-		account = authed.Account
-	}
-
-	if account.IsMoving() {
-		// Moving accounts can't
-		// use streaming endpoints.
-		apiutil.NotFoundAfterMove(c)
+	account, ok := m.authorize(c, token)
+	if !ok {
 		return
 	}
 
@@ -263,6 +242,48 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	go m.handleWSConn(&l, wsConn, stream)
 }
 
+// authorize authorizes the streaming request using the given token
+// if set, else falling back to regular oauth. On failure, an error is
+// written to the response and false is returned.
+func (m *Module) authorize(c *gin.Context, token string) (*gtsmodel.Account, bool) {
+	var (
+		account     *gtsmodel.Account
+		errWithCode gtserror.WithCode
+	)
+
+	if token != "" {
+
+		// Token was provided, use it to authorize stream.
+		account, errWithCode = m.processor.Stream().Authorize(c.Request.Context(), token)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return nil, false
+		}
+
+	} else {
+
+		// No explicit token was provided:
+		// try regular oauth as a last resort.
+		authed, errWithCode := apiutil.TokenAuth(c, true, true, true, true)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return nil, false
+		}
+
+		// Set the auth'ed account.
+		account = authed.Account
+	}
+
+	if account.IsMoving() {
+		// Moving accounts can't
+		// use streaming endpoints.
+		apiutil.NotFoundAfterMove(c)
+		return nil, false
+	}
+
+	return account, true
+}
+
 // handleWSConn handles a two-way websocket streaming connection.
 // It will both read messages from the connection, and push messages
 // into the connection. If any errors are encountered while reading
@@ -322,6 +343,7 @@ func (m *Module) readFromWSConn(
 			Type   string `json:"type"`
 			Stream string `json:"stream"`
 			List   string `json:"list,omitempty"`
+			Tag    string `json:"tag,omitempty"`
 		}
 
 		// Read JSON objects from the client and act on them.
@@ -357,6 +379,15 @@ func (m *Module) readFromWSConn(
 			// the stream name as this is how we
 			// we track stream types internally.
 			msg.Stream += ":" + msg.List
+		} else if msg.Tag != "" {
+			// Same goes for tags, which
+			// also need normalizing.
+			var errWithCode gtserror.WithCode
+			msg.Stream, errWithCode = processingstream.NormalizeStreamType(msg.Stream + ":" + msg.Tag)
+			if errWithCode != nil {
+				l.Warnf("invalid 'tag' field: %v", msg)
+				continue
+			}
 		}
 
 		switch msg.Type {
@@ -28,11 +28,26 @@
 
 const (
 	BasePath            = "/v1/streaming"          // path for the streaming api, minus the 'api' prefix
+	HealthPath          = BasePath + "/health"     // path for the streaming api health check
 	StreamQueryKey      = "stream"                 // type of stream being requested
 	StreamListKey       = "list"                   // id of list being requested
 	StreamTagKey        = "tag"                    // name of tag being requested
 	AccessTokenQueryKey = "access_token"           // oauth access token
 	AccessTokenHeader   = "Sec-Websocket-Protocol" //nolint:gosec
+	LastEventIDHeader   = "Last-Event-ID"          // id of last server-sent event received by client
+)
+
+// Paths for server-sent events streams, minus the 'api' prefix.
+const (
+	UserPath             = BasePath + "/user"
+	UserNotificationPath = UserPath + "/notification"
+	PublicPath           = BasePath + "/public"
+	PublicLocalPath      = PublicPath + "/local"
+	PublicRemotePath     = PublicPath + "/remote"
+	HashtagPath          = BasePath + "/hashtag"
+	HashtagLocalPath     = HashtagPath + "/local"
+	ListPath             = BasePath + "/list"
+	DirectPath           = BasePath + "/direct"
 )
 
 type Module struct {
@@ -60,4 +75,16 @@ func New(processor *processing.Processor, dTicker time.Duration, wsBuf int) *Mod
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.StreamGETHandler)
+	attachHandler(http.MethodGet, HealthPath, m.HealthGETHandler)
+
+	// Server-sent events endpoints.
+	attachHandler(http.MethodGet, UserPath, m.UserSSEGETHandler)
+	attachHandler(http.MethodGet, UserNotificationPath, m.UserNotificationSSEGETHandler)
+	attachHandler(http.MethodGet, PublicPath, m.PublicSSEGETHandler)
+	attachHandler(http.MethodGet, PublicLocalPath, m.PublicLocalSSEGETHandler)
+	attachHandler(http.MethodGet, PublicRemotePath, m.PublicRemoteSSEGETHandler)
+	attachHandler(http.MethodGet, HashtagPath, m.HashtagSSEGETHandler)
+	attachHandler(http.MethodGet, HashtagLocalPath, m.HashtagLocalSSEGETHandler)
+	attachHandler(http.MethodGet, ListPath, m.ListSSEGETHandler)
+	attachHandler(http.MethodGet, DirectPath, m.DirectSSEGETHandler)
 }
@@ -19,6 +19,7 @@
 
 import (
 	"bufio"
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -26,6 +27,7 @@
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -251,6 +253,85 @@ func (suite *StreamingTestSuite) TestSecurityHeader() {
 	}
 }
 
+func (suite *StreamingTestSuite) TestSSEResume() {
+	var (
+		token   = suite.testTokens["local_account_1"]
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	engine := gin.New()
+	engine.GET("/api"+streaming.UserPath, suite.streamingModule.UserSSEGETHandler)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	// open opens an event stream with given
+	// Last-Event-ID, and waits until it's open.
+	open := func(lastEventID string) (*http.Response, *bufio.Reader) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/api"+streaming.UserPath+"?access_token="+token.Access, nil)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if lastEventID != "" {
+			req.Header.Set(streaming.LastEventIDHeader, lastEventID)
+		}
+
+		rsp, err := server.Client().Do(req)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(http.StatusOK, rsp.StatusCode)
+		suite.Equal("text/event-stream; charset=utf-8", rsp.Header.Get("Content-Type"))
+
+		r := bufio.NewReader(rsp.Body)
+		suite.Equal(":)\n", suite.readLine(r))
+		return rsp, r
+	}
+
+	// readEvent reads the next event from the
+	// stream, skipping heartbeats, returning ID.
+	readEvent := func(r *bufio.Reader) string {
+		for {
+			line := suite.readLine(r)
+			if line == "\n" || strings.HasPrefix(line, ":") {
+				continue
+			}
+
+			id := strings.TrimPrefix(line, "id: ")
+			suite.Equal("event: filters_changed\n", suite.readLine(r))
+			suite.Equal("data: \n", suite.readLine(r))
+			return strings.TrimSpace(id)
+		}
+	}
+
+	// Open stream and receive one event.
+	rsp, r := open("")
+	suite.processor.Stream().FiltersChanged(context.Background(), account)
+	lastEventID := readEvent(r)
+	suite.NotEmpty(lastEventID)
+	rsp.Body.Close()
+
+	// Post two more events while the client is away.
+	suite.processor.Stream().FiltersChanged(context.Background(), account)
+	suite.processor.Stream().FiltersChanged(context.Background(), account)
+
+	// Resume the stream, should get both missed events.
+	rsp, r = open(lastEventID)
+	defer rsp.Body.Close()
+
+	id1 := readEvent(r)
+	id2 := readEvent(r)
+	suite.NotEqual(lastEventID, id1)
+	suite.NotEqual(id1, id2)
+}
+
+func (suite *StreamingTestSuite) readLine(r *bufio.Reader) string {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return line
+}
+
 func TestStreamingTestSuite(t *testing.T) {
 	suite.Run(t, new(StreamingTestSuite))
 }
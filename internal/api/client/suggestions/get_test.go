@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions_test
+
+import (
+	"net/http"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/suggestions"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+)
+
+func (suite *SuggestionsTestSuite) TestGetV2() {
+	adminAccount := suite.testAccounts["admin_account"]
+	remoteAccount := suite.testAccounts["remote_account_2"]
+	suite.pick(adminAccount)
+
+	var resp []*apimodel.Suggestion
+	code := suite.suggestionsReq(
+		suite.suggestionsModule.SuggestionsGETHandlerV2,
+		http.MethodGet, "local_account_2",
+		suggestions.BasePathV2, "", &resp,
+	)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(resp, 2) {
+		// Staff pick comes first, and
+		// is also a friend of a friend.
+		suite.Equal(adminAccount.ID, resp[0].Account.ID)
+		suite.Equal("staff", resp[0].Source)
+		suite.Equal([]string{"featured", "friends_of_friends"}, resp[0].Sources)
+
+		suite.Equal(remoteAccount.ID, resp[1].Account.ID)
+		suite.Equal("global", resp[1].Source)
+		suite.Equal([]string{"friends_of_friends"}, resp[1].Sources)
+	}
+}
+
+func (suite *SuggestionsTestSuite) TestGetV1() {
+	var resp []*apimodel.Account
+	code := suite.suggestionsReq(
+		suite.suggestionsModule.SuggestionsGETHandlerV1,
+		http.MethodGet, "local_account_2",
+		suggestions.BasePathV1+"?limit=1", "", &resp,
+	)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(resp, 1) {
+		suite.Equal(suite.testAccounts["admin_account"].ID, resp[0].ID)
+	}
+}
+
+func (suite *SuggestionsTestSuite) TestGetExcludesSelfAndFollowed() {
+	// local_account_1 already follows admin_account,
+	// and can't be suggested to itself, so only the
+	// pick of remote_account_1 should be suggested.
+	suite.pick(suite.testAccounts["admin_account"])
+	suite.pick(suite.testAccounts["local_account_1"])
+	suite.pick(suite.testAccounts["remote_account_1"])
+
+	var resp []*apimodel.Suggestion
+	code := suite.suggestionsReq(
+		suite.suggestionsModule.SuggestionsGETHandlerV2,
+		http.MethodGet, "local_account_1",
+		suggestions.BasePathV2, "", &resp,
+	)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(resp, 1) {
+		suite.Equal(suite.testAccounts["remote_account_1"].ID, resp[0].Account.ID)
+	}
+}
+
+func (suite *SuggestionsTestSuite) TestDismiss() {
+	adminAccount := suite.testAccounts["admin_account"]
+	suite.pick(adminAccount)
+
+	var empty map[string]any
+	code := suite.suggestionsReq(
+		suite.suggestionsModule.SuggestionDELETEHandler,
+		http.MethodDelete, "local_account_2",
+		suggestions.BasePathV1+"/"+adminAccount.ID,
+		adminAccount.ID, &empty,
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.Empty(empty)
+
+	// Dismissed account shouldn't be suggested,
+	// even though it's picked by staff.
+	var resp []*apimodel.Suggestion
+	code = suite.suggestionsReq(
+		suite.suggestionsModule.SuggestionsGETHandlerV2,
+		http.MethodGet, "local_account_2",
+		suggestions.BasePathV2, "", &resp,
+	)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(resp, 1) {
+		suite.Equal(suite.testAccounts["remote_account_2"].ID, resp[0].Account.ID)
+	}
+
+	// Dismissing an unknown account 404s.
+	code = suite.suggestionsReq(
+		suite.suggestionsModule.SuggestionDELETEHandler,
+		http.MethodDelete, "local_account_2",
+		suggestions.BasePathV1+"/01HXJ2Y6Z6ZQ6Q8XKX4T4Y2B5M",
+		"01HXJ2Y6Z6ZQ6Q8XKX4T4Y2B5M", &empty,
+	)
+	suite.Equal(http.StatusNotFound, code)
+}
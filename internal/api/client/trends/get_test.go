@@ -0,0 +1,127 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"net/http"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/trends"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+func (suite *TrendsTestSuite) TestTagsGetUnreviewed() {
+	// Nothing is approved, so nothing trends.
+	var tags []*apimodel.Tag
+	code := suite.getTrends(suite.trendsModule.TrendsTagsGETHandler, "", trends.TagsPath, &tags)
+	suite.Equal(http.StatusOK, code)
+	suite.Empty(tags)
+}
+
+func (suite *TrendsTestSuite) TestTagsGetApproved() {
+	suite.approve(gtsmodel.TrendTypeTag, suite.testTags["Hashtag"].ID)
+
+	var tags []*apimodel.Tag
+	code := suite.getTrends(suite.trendsModule.TrendsTagsGETHandler, "", trends.TagsPath, &tags)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(tags, 1) {
+		suite.Equal("hashtag", tags[0].Name)
+		if suite.NotNil(tags[0].History) {
+			history := *tags[0].History
+			suite.Len(history, 1)
+			suite.Equal("3", history[0].Uses)
+			suite.Equal("3", history[0].Accounts)
+		}
+	}
+}
+
+func (suite *TrendsTestSuite) TestTagsGetPaging() {
+	suite.approve(gtsmodel.TrendTypeTag, suite.testTags["welcome"].ID)
+	suite.approve(gtsmodel.TrendTypeTag, suite.testTags["Hashtag"].ID)
+
+	var tags []*apimodel.Tag
+	code := suite.getTrends(suite.trendsModule.TrendsTagsGETHandler, "", trends.TagsPath+"?limit=1", &tags)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(tags, 1) {
+		suite.Equal("welcome", tags[0].Name)
+	}
+
+	code = suite.getTrends(suite.trendsModule.TrendsTagsGETHandler, "", trends.TagsPath+"?limit=1&offset=1", &tags)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(tags, 1) {
+		suite.Equal("hashtag", tags[0].Name)
+	}
+
+	code = suite.getTrends(suite.trendsModule.TrendsTagsGETHandler, "", trends.TagsPath+"?offset=2", &tags)
+	suite.Equal(http.StatusOK, code)
+	suite.Empty(tags)
+}
+
+func (suite *TrendsTestSuite) TestTagsGetDisabled() {
+	suite.approve(gtsmodel.TrendTypeTag, suite.testTags["welcome"].ID)
+	config.SetInstanceTrendsEnabled(false)
+
+	var tags []*apimodel.Tag
+	code := suite.getTrends(suite.trendsModule.TrendsTagsGETHandler, "", trends.TagsPath, &tags)
+	suite.Equal(http.StatusOK, code)
+	suite.Empty(tags)
+}
+
+func (suite *TrendsTestSuite) TestStatusesGet() {
+	suite.approve(gtsmodel.TrendTypeStatus, suite.testStatuses["admin_account_status_1"].ID)
+
+	var statuses []*apimodel.Status
+	code := suite.getTrends(suite.trendsModule.TrendsStatusesGETHandler, "local_account_1", trends.StatusesPath, &statuses)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[0].ID)
+	}
+}
+
+func (suite *TrendsTestSuite) TestStatusesGetUnauthenticated() {
+	suite.approve(gtsmodel.TrendTypeStatus, suite.testStatuses["admin_account_status_1"].ID)
+	suite.approve(gtsmodel.TrendTypeStatus, suite.testStatuses["local_account_1_status_5"].ID)
+
+	// Public timeline isn't exposed, so auth is required.
+	var statuses []*apimodel.Status
+	code := suite.getTrends(suite.trendsModule.TrendsStatusesGETHandler, "", trends.StatusesPath, &statuses)
+	suite.Equal(http.StatusUnauthorized, code)
+
+	// Followers-only status isn't
+	// visible without authentication.
+	config.SetInstanceExposePublicTimeline(true)
+	code = suite.getTrends(suite.trendsModule.TrendsStatusesGETHandler, "", trends.StatusesPath, &statuses)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["admin_account_status_1"].ID, statuses[0].ID)
+	}
+}
+
+func (suite *TrendsTestSuite) TestLinksGet() {
+	suite.approve(gtsmodel.TrendTypeLink, "https://example.org/some/article")
+
+	var links []*apimodel.TrendsLink
+	code := suite.getTrends(suite.trendsModule.TrendsLinksGETHandler, "", trends.LinksPath, &links)
+	suite.Equal(http.StatusOK, code)
+	if suite.Len(links, 1) {
+		suite.Equal("https://example.org/some/article", links[0].URL)
+		suite.Equal("link", links[0].Type)
+		suite.Len(links[0].History, 1)
+	}
+}
@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/admin"
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/trends"
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/email"
+	"code.superseriousbusiness.org/gotosocial/internal/federation"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/internal/processing"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrendsTestSuite struct {
+	suite.Suite
+	db           db.DB
+	storage      *storage.Driver
+	mediaManager *media.Manager
+	federator    *federation.Federator
+	processor    *processing.Processor
+	emailSender  email.Sender
+	sentEmails   map[string]string
+	state        state.State
+
+	// standard suite models
+	testTokens       map[string]*gtsmodel.Token
+	testApplications map[string]*gtsmodel.Application
+	testUsers        map[string]*gtsmodel.User
+	testAccounts     map[string]*gtsmodel.Account
+	testStatuses     map[string]*gtsmodel.Status
+	testTags         map[string]*gtsmodel.Tag
+
+	// module being tested
+	trendsModule *trends.Module
+}
+
+func (suite *TrendsTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testApplications = testrig.NewTestApplications()
+	suite.testUsers = testrig.NewTestUsers()
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+}
+
+func (suite *TrendsTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	testrig.InitTestConfig()
+	config.Config(func(cfg *config.Configuration) {
+		cfg.WebAssetBaseDir = "../../../../web/assets/"
+		cfg.WebTemplateBaseDir = "../../../../web/templates/"
+	})
+	testrig.InitTestLog()
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	suite.state.AdminActions = admin.New(suite.state.DB, &suite.state.Workers)
+	suite.storage = testrig.NewInMemoryStorage()
+	suite.state.Storage = suite.storage
+
+	suite.mediaManager = testrig.NewTestMediaManager(&suite.state)
+	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), suite.mediaManager)
+	suite.sentEmails = make(map[string]string)
+	suite.emailSender = testrig.NewEmailSender("../../../../web/template/", suite.sentEmails)
+	suite.processor = testrig.NewTestProcessor(
+		&suite.state,
+		suite.federator,
+		suite.emailSender,
+		testrig.NewNoopWebPushSender(),
+		suite.mediaManager,
+	)
+	suite.trendsModule = trends.New(suite.processor)
+
+	testrig.StandardDBSetup(suite.db, nil)
+	testrig.StandardStorageSetup(suite.storage, "../../../../testrig/media")
+
+	// Pretend two tags, two
+	// statuses, and a link
+	// are currently trending.
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	suite.state.Caches.Trends.Set(gtsmodel.TrendTypeTag, []*cache.Trend{
+		{
+			Type:    gtsmodel.TrendTypeTag,
+			Target:  suite.testTags["welcome"].ID,
+			Score:   10,
+			History: []cache.TrendHistory{{Day: day, Uses: 5, Accounts: 4}},
+		},
+		{
+			Type:    gtsmodel.TrendTypeTag,
+			Target:  suite.testTags["Hashtag"].ID,
+			Score:   5,
+			History: []cache.TrendHistory{{Day: day, Uses: 3, Accounts: 3}},
+		},
+	})
+	suite.state.Caches.Trends.Set(gtsmodel.TrendTypeStatus, []*cache.Trend{
+		{
+			Type:   gtsmodel.TrendTypeStatus,
+			Target: suite.testStatuses["admin_account_status_1"].ID,
+			Score:  2,
+		},
+		{
+			Type:   gtsmodel.TrendTypeStatus,
+			Target: suite.testStatuses["local_account_1_status_5"].ID,
+			Score:  1,
+		},
+	})
+	suite.state.Caches.Trends.Set(gtsmodel.TrendTypeLink, []*cache.Trend{{
+		Type:    gtsmodel.TrendTypeLink,
+		Target:  "https://example.org/some/article",
+		Score:   5,
+		History: []cache.TrendHistory{{Day: day, Uses: 2, Accounts: 2}},
+	}})
+}
+
+func (suite *TrendsTestSuite) TearDownTest() {
+	suite.state.Caches.Trends.Clear()
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
+	testrig.StopWorkers(&suite.state)
+}
+
+// approve approves the trend of given type and target.
+func (suite *TrendsTestSuite) approve(trendType gtsmodel.TrendType, target string) {
+	if err := suite.db.PutTrendReview(context.Background(), &gtsmodel.TrendReview{
+		ID:                  id.NewULID(),
+		Type:                trendType,
+		Target:              target,
+		State:               gtsmodel.TrendReviewStateApproved,
+		ReviewedByAccountID: suite.testAccounts["admin_account"].ID,
+		ReviewedAt:          time.Now(),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// getTrends performs a GET request to the given trends
+// handler as the given account (or unauthenticated if
+// empty), decoding the response body into out.
+func (suite *TrendsTestSuite) getTrends(
+	handler gin.HandlerFunc,
+	accountFixtureName string,
+	path string,
+	out any,
+) int {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	if accountFixtureName != "" {
+		ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountFixtureName])
+		ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountFixtureName]))
+		ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+		ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountFixtureName])
+	}
+
+	url := config.GetProtocol() + "://" + config.GetHost() + "/api/" + path
+	ctx.Request = httptest.NewRequest(http.MethodGet, url, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(b, out); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	return recorder.Code
+}
+
+func TestTrendsTestSuite(t *testing.T) {
+	suite.Run(t, new(TrendsTestSuite))
+}
@@ -20,6 +20,7 @@
 import (
 	"net/http"
 
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
 	"code.superseriousbusiness.org/gotosocial/internal/processing"
 	"github.com/gin-gonic/gin"
 )
@@ -33,6 +34,11 @@
 	TwoFactorQRCodeURIPath = TwoFactorPath + "/qruri"
 	TwoFactorEnablePath    = TwoFactorPath + "/enable"
 	TwoFactorDisablePath   = TwoFactorPath + "/disable"
+	WebAuthnPath           = BasePath + "/webauthn"
+	WebAuthnOptionsPath    = WebAuthnPath + "/options"
+	WebAuthnDeletePath     = WebAuthnPath + "/:" + apiutil.IDKey + "/delete"
+	InvitesPath            = BasePath + "/invites"
+	InviteRevokePath       = InvitesPath + "/:" + apiutil.IDKey + "/revoke"
 )
 
 type Module struct {
@@ -53,4 +59,11 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, TwoFactorQRCodeURIPath, m.TwoFactorQRCodeURIGETHandler)
 	attachHandler(http.MethodPost, TwoFactorEnablePath, m.TwoFactorEnablePOSTHandler)
 	attachHandler(http.MethodPost, TwoFactorDisablePath, m.TwoFactorDisablePOSTHandler)
+	attachHandler(http.MethodGet, WebAuthnPath, m.WebAuthnCredentialsGETHandler)
+	attachHandler(http.MethodPost, WebAuthnPath, m.WebAuthnCredentialPOSTHandler)
+	attachHandler(http.MethodPost, WebAuthnOptionsPath, m.WebAuthnOptionsPOSTHandler)
+	attachHandler(http.MethodPost, WebAuthnDeletePath, m.WebAuthnCredentialDeletePOSTHandler)
+	attachHandler(http.MethodGet, InvitesPath, m.InvitesGETHandler)
+	attachHandler(http.MethodPost, InvitesPath, m.InvitePOSTHandler)
+	attachHandler(http.MethodPost, InviteRevokePath, m.InviteRevokePOSTHandler)
 }
@@ -196,6 +196,11 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// Code of an invite to sign up with, which allows signing
+	// up even when registration is closed.
+	// swagger:parameters
+	// example: 2RCGMAF7Y0ND4F0P0F5RTW1KY3
+	InviteCode string `form:"invite_code" json:"invite_code" xml:"invite_code"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
@@ -420,7 +425,7 @@ func (a *AccountRolePermissions) UnmarshalJSON(b []byte) error {
 	AccountRolePermissionsManageInvites
 	// AccountRolePermissionsManageRules indicates that the user can edit instance rules.
 	AccountRolePermissionsManageRules
-	// AccountRolePermissionsManageAnnouncements is not used by GotoSocial.
+	// AccountRolePermissionsManageAnnouncements indicates that the user can manage instance announcements.
 	AccountRolePermissionsManageAnnouncements
 	// AccountRolePermissionsManageCustomEmojis indicates that the user can edit custom emoji.
 	AccountRolePermissionsManageCustomEmojis
@@ -446,6 +451,7 @@ func (a *AccountRolePermissions) UnmarshalJSON(b []byte) error {
 		AccountRolePermissionsManageBlocks |
 		AccountRolePermissionsManageUsers |
 		AccountRolePermissionsManageRules |
+		AccountRolePermissionsManageAnnouncements |
 		AccountRolePermissionsManageCustomEmojis |
 		AccountRolePermissionsDeleteUserData
 
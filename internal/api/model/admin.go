@@ -62,6 +62,9 @@ type AdminAccountInfo struct {
 	Approved bool `json:"approved"`
 	// Whether the account is currently disabled.
 	Disabled bool `json:"disabled"`
+	// Whether the account is currently sensitized,
+	// ie., all its media is forced to be sensitive.
+	Sensitized bool `json:"sensitized"`
 	// Whether the account is currently silenced
 	Silenced bool `json:"silenced"`
 	// Whether the account is currently suspended.
@@ -74,6 +77,39 @@ type AdminAccountInfo struct {
 	InvitedByAccountID string `json:"invited_by_account_id,omitempty"`
 }
 
+// AdminExternalIdentity models the admin view of an identity at
+// an OIDC provider, through which a local account can sign in.
+//
+// swagger:model adminExternalIdentity
+type AdminExternalIdentity struct {
+	// The ID of the identity in the database.
+	// example: 01GQ4PHNT622DQ9X95XQX4KKNR
+	ID string `json:"id"`
+	// The ID of the OIDC provider, as configured.
+	// Identities linked before multiple providers were
+	// supported belong to the provider "default".
+	// example: default
+	Provider string `json:"provider"`
+	// The subject ('sub' claim) of the identity at the provider.
+	// example: 248289761001
+	Subject string `json:"subject"`
+	// The email address last returned by the provider.
+	// example: someone@somewhere.com
+	Email string `json:"email"`
+	// The groups last returned by the provider.
+	// example: ["gotosocial-admins"]
+	Groups []string `json:"groups"`
+	// When the identity was first linked. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the identity was last used to sign in. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	LastLoginAt string `json:"last_login_at"`
+	// When the groups were last synced from the provider. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	LastSyncedAt string `json:"last_synced_at"`
+}
+
 // AdminReport models the admin view of a report.
 //
 // swagger:model adminReport
@@ -170,10 +206,16 @@ type AdminEmoji struct {
 type AdminActionRequest struct {
 	// Category of the target entity.
 	Category string `form:"-" json:"-" xml:"-"`
-	// Type of admin action to take. One of disable, silence, suspend.
+	// Type of admin action to take. One
+	// of none, sensitive, disable, silence,
+	// suspend, or one of their reversals.
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// Whether to email the target account's user
+	// about the action (local accounts only).
+	// Defaults to true if not set.
+	SendEmail *bool `form:"send_email_notification" json:"send_email_notification" xml:"send_email_notification"`
 	// ID of the target entity.
 	TargetID string `form:"-" json:"-" xml:"-"`
 }
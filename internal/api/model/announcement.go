@@ -19,7 +19,7 @@
 
 // Announcement models an admin announcement for the instance.
 //
-// TODO: swagger:model announcement once announcement API is supported
+// swagger:model announcement
 type Announcement struct {
 	// The ID of the announcement.
 	// example: 01FC30T7X4TNCZK0TH90QYF3M4
@@ -60,3 +60,52 @@ type Announcement struct {
 	// Reactions to this announcement.
 	Reactions []AnnouncementReaction `json:"reactions"`
 }
+
+// AnnouncementCreateRequest models a request to create an announcement.
+//
+// swagger:ignore
+type AnnouncementCreateRequest struct {
+	// Text of the announcement. Will be parsed as markdown.
+	Text string `form:"text" json:"text"`
+	// When the announced event starts (ISO 8601 Datetime), if at all.
+	StartsAt string `form:"starts_at" json:"starts_at"`
+	// When the announced event ends (ISO 8601 Datetime), if at all.
+	// The announcement is no longer shown to users after this time.
+	EndsAt string `form:"ends_at" json:"ends_at"`
+	// Starts and ends on whole days rather than at specific times.
+	AllDay bool `form:"all_day" json:"all_day"`
+	// Publish the announcement immediately. Defaults to true.
+	Published *bool `form:"published" json:"published"`
+}
+
+// AnnouncementUpdateRequest models a request to update an announcement.
+// Fields that are not set will be left unchanged.
+//
+// swagger:ignore
+type AnnouncementUpdateRequest struct {
+	// Text of the announcement. Will be parsed as markdown.
+	Text *string `form:"text" json:"text"`
+	// When the announced event starts (ISO 8601 Datetime).
+	// Empty string removes the start time.
+	StartsAt *string `form:"starts_at" json:"starts_at"`
+	// When the announced event ends (ISO 8601 Datetime).
+	// Empty string removes the end time.
+	EndsAt *string `form:"ends_at" json:"ends_at"`
+	// Starts and ends on whole days rather than at specific times.
+	AllDay *bool `form:"all_day" json:"all_day"`
+	// Publish or unpublish the announcement.
+	Published *bool `form:"published" json:"published"`
+}
+
+// AnnouncementReactionStreamEvent models the payload
+// of an "announcement.reaction" streaming event.
+//
+// swagger:ignore
+type AnnouncementReactionStreamEvent struct {
+	// The emoji used for the reaction.
+	Name string `json:"name"`
+	// The total number of users who have added this reaction.
+	Count int `json:"count"`
+	// ID of the announcement reacted to.
+	AnnouncementID string `json:"announcement_id"`
+}
@@ -77,3 +77,30 @@ type ApplicationCreateRequest struct {
 	// in: formData
 	Website string `form:"website" json:"website" xml:"website"`
 }
+
+// ApplicationUpdateRequest models app update parameters.
+//
+// swagger:parameters appUpdate
+type ApplicationUpdateRequest struct {
+	// The new name of the application.
+	//
+	// in: formData
+	ClientName *string `form:"client_name" json:"client_name" xml:"client_name"`
+	// Single redirect URI or newline-separated list of redirect URIs,
+	// replacing the current redirect URIs of the application.
+	//
+	// To display the authorization code to the user instead of redirecting to a web page, use `urn:ietf:wg:oauth:2.0:oob` in this parameter.
+	//
+	// in: formData
+	RedirectURIs *string `form:"redirect_uris" json:"redirect_uris" xml:"redirect_uris"`
+	// Space separated list of scopes, replacing the current scopes of the application.
+	//
+	// If any previously declared scopes are no longer included, all existing tokens of the application will be revoked.
+	//
+	// in: formData
+	Scopes *string `form:"scopes" json:"scopes" xml:"scopes"`
+	// A URL to the web page of the app.
+	//
+	// in: formData
+	Website *string `form:"website" json:"website" xml:"website"`
+}
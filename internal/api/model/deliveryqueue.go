@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminDeliveryQueueDomain represents the state of
+// outgoing federation deliveries to one domain.
+//
+// swagger:model adminDeliveryQueueDomain
+type AdminDeliveryQueueDomain struct {
+	// Domain (host) that deliveries are being sent to.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Number of deliveries queued for this domain, including
+	// those waiting on backoff before being re-attempted.
+	// example: 42
+	Queued int `json:"queued"`
+	// Number of deliveries to this domain currently in-flight.
+	// example: 1
+	InFlight int `json:"in_flight"`
+	// Number of consecutive failed deliveries to this domain.
+	// example: 7
+	Failures int `json:"failures"`
+	// If set, deliveries to this domain are paused until this time
+	// (ISO 8601 Datetime), as the domain appears to be unreachable.
+	// example: 2021-07-30T09:20:25+00:00
+	PausedUntil string `json:"paused_until,omitempty"`
+}
@@ -69,6 +69,18 @@ type DomainPermission struct {
 	// Permission type of this entry (block, allow).
 	// Only set for domain permission drafts.
 	PermissionType string `json:"permission_type,omitempty"`
+	// Severity of this domain block (suspend, limit).
+	// Only set for domain blocks and domain block drafts.
+	// example: limit
+	Severity string `json:"severity,omitempty"`
+	// Strip media attachments from posts and accounts on this domain.
+	// Only set for domain blocks and domain block drafts.
+	// example: false
+	RejectMedia *bool `json:"reject_media,omitempty"`
+	// Force media attachments from this domain to be marked as sensitive.
+	// Only set for domain blocks and domain block drafts.
+	// example: true
+	SensitizeMedia *bool `json:"sensitize_media,omitempty"`
 }
 
 // DomainPermissionRequest is the form submitted as a POST to create a new domain permission entry (allow/block).
@@ -95,6 +107,18 @@ type DomainPermissionRequest struct {
 	PublicComment *string `form:"public_comment" json:"public_comment"`
 	// Permission type to create (only applies to domain permission drafts, not explicit blocks and allows).
 	PermissionType string `form:"permission_type" json:"permission_type"`
+	// Severity of the domain block (suspend, limit). Defaults to suspend.
+	// Only applies to domain blocks and domain block drafts.
+	// example: limit
+	Severity *string `form:"severity" json:"severity"`
+	// Strip media attachments from posts and accounts on a limited domain.
+	// Only applies to domain blocks and domain block drafts.
+	// example: false
+	RejectMedia *bool `form:"reject_media" json:"reject_media"`
+	// Force media attachments from a limited domain to be marked as sensitive.
+	// Only applies to domain blocks and domain block drafts.
+	// example: true
+	SensitizeMedia *bool `form:"sensitize_media" json:"sensitize_media"`
 }
 
 // DomainKeysExpireRequest is the form submitted as a POST to /api/v1/admin/domain_keys_expire to expire a domain's public keys.
@@ -59,6 +59,101 @@ type AccountExportStats struct {
 	//
 	// example: 11
 	MutesCount int `json:"mutes_count"`
+
+	// Number of statuses bookmarked by this account.
+	//
+	// example: 24
+	BookmarksCount int `json:"bookmarks_count"`
+}
+
+// AccountArchive models an archive of an account's data,
+// requested by the account owner at /api/v1/exports/archive.
+//
+// swagger:model accountArchive
+type AccountArchive struct {
+	// The ID of the archive.
+	//
+	// example: 01FC30T7X4TNCZK0TH90QYF3M4
+	ID string `json:"id"`
+
+	// When the archive was requested (ISO 8601 Datetime).
+	//
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+
+	// State of the archive, one of:
+	//	- `pending` - the archive is still being prepared.
+	//	- `ready` - the archive can be downloaded from url.
+	//	- `failed` - the archive could not be prepared.
+	//
+	// example: ready
+	State string `json:"state"`
+
+	// Size of the archive in bytes, once ready.
+	//
+	// example: 12582912
+	Size int64 `json:"size,omitempty"`
+
+	// URL at which the archive can be downloaded
+	// as a ZIP file, once ready. Requires the same
+	// authorization as the rest of the API.
+	//
+	// example: https://example.org/api/v1/exports/archive/01FC30T7X4TNCZK0TH90QYF3M4
+	URL string `json:"url,omitempty"`
+
+	// When the archive will be removed (ISO 8601 Datetime).
+	//
+	// example: 2021-08-06T09:20:25+00:00
+	ExpiresAt string `json:"expires_at"`
+}
+
+// OutboxImport models an import of statuses
+// from an uploaded archive's outbox, and its progress.
+//
+// swagger:model outboxImport
+type OutboxImport struct {
+	// The ID of the import.
+	//
+	// example: 01FC30T7X4TNCZK0TH90QYF3M4
+	ID string `json:"id"`
+
+	// When the import was started (ISO 8601 Datetime).
+	//
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+
+	// When the import last made progress (ISO 8601 Datetime).
+	//
+	// example: 2021-07-30T09:25:25+00:00
+	UpdatedAt string `json:"updated_at"`
+
+	// State of the import, one of:
+	//	- `processing` - statuses are still being imported.
+	//	- `finished` - all statuses that could be imported have been.
+	//	- `failed` - the import stopped before it could finish.
+	//
+	// example: processing
+	State string `json:"state"`
+
+	// Whether imported statuses are federated to followers.
+	Federate bool `json:"federate"`
+
+	// Total number of items in the uploaded outbox.
+	//
+	// example: 1200
+	Total int `json:"total"`
+
+	// Number of items imported as statuses so far.
+	//
+	// example: 900
+	Imported int `json:"imported"`
+
+	// Number of items skipped so far, eg., boosts,
+	// non-public statuses, polls, and replies to
+	// statuses that couldn't be resolved.
+	//
+	// example: 100
+	Skipped int `json:"skipped"`
 }
 
 // AttachmentRequest models media attachment creation parameters.
@@ -74,9 +169,13 @@ type ImportRequest struct {
 	//	- `blocks` - accounts to block.
 	//	- `mutes` - accounts to mute.
 	//	- `bookmarks` - statuses to bookmark.
+	//	- `outbox` - statuses in an archive ZIP file.
 	Type string `form:"type" binding:"required"`
 	// Mode to use when creating entries from the data file:
 	//	- `merge` to merge entries in file with existing entries.
 	//	- `overwrite` to replace existing entries with entries in file.
 	Mode string `form:"mode"`
+	// Federate imported statuses to followers
+	// (only used for the `outbox` type).
+	Federate bool `form:"federate"`
 }
@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// GroupCreateRequest models group creation parameters.
+//
+// swagger:parameters groupCreate
+type GroupCreateRequest struct {
+	// Username of the new group.
+	// Sample: knitting
+	// in: formData
+	// required: true
+	Username string `form:"username" json:"username" xml:"username"`
+	// Display name of the new group.
+	// Sample: Knitting Circle
+	// in: formData
+	DisplayName string `form:"display_name" json:"display_name" xml:"display_name"`
+	// Plaintext description of the new group.
+	// in: formData
+	Note string `form:"note" json:"note" xml:"note"`
+}
+
+// GroupUpdateRequest models group update parameters.
+//
+// swagger:ignore
+type GroupUpdateRequest struct {
+	// Display name of the group.
+	// in: formData
+	DisplayName *string `form:"display_name" json:"display_name" xml:"display_name"`
+	// Plaintext description of the group.
+	// in: formData
+	Note *string `form:"note" json:"note" xml:"note"`
+}
@@ -0,0 +1,74 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Invite models an invite link, which lets
+// people sign up even when registration is closed.
+//
+// swagger:model invite
+type Invite struct {
+	// The ID of the invite.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Code of the invite, used in the invite link.
+	// example: 2RCGMAF7Y0ND4F0P0F5RTW1KY3
+	Code string `json:"code"`
+	// Link to the sign up page that uses this invite.
+	// example: https://example.org/signup?invite=2RCGMAF7Y0ND4F0P0F5RTW1KY3
+	URL string `json:"url"`
+	// When the invite was created. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// When the invite expires, if ever. (ISO 8601 Datetime)
+	// example: 2021-08-06T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+	// How many times the invite can be used to sign up, if limited.
+	// example: 5
+	MaxUses *int `json:"max_uses"`
+	// How many times the invite has been used to sign up.
+	// example: 1
+	Uses int `json:"uses"`
+	// People who sign up with the invite will follow its creator.
+	// example: true
+	Autofollow bool `json:"autofollow"`
+	// When the invite was revoked, if at all. (ISO 8601 Datetime)
+	// example: 2021-07-31T09:20:25+00:00
+	RevokedAt *string `json:"revoked_at"`
+	// The invite can still be used to sign up, ie.,
+	// it hasn't been revoked, expired, or used up.
+	// example: true
+	Valid bool `json:"valid"`
+	// The account that created the invite.
+	// Only included in admin views.
+	Account *Account `json:"account,omitempty"`
+}
+
+// InviteCreateRequest models a request to create an invite.
+//
+// swagger:ignore
+type InviteCreateRequest struct {
+	// How many times the invite can be used
+	// to sign up. 0 or unset for no limit.
+	MaxUses int `form:"max_uses" json:"max_uses"`
+	// Seconds from now until the invite
+	// expires. 0 or unset for never.
+	ExpiresIn int `form:"expires_in" json:"expires_in"`
+	// Have people who sign up with the
+	// invite follow the creating account.
+	Autofollow bool `form:"autofollow" json:"autofollow"`
+}
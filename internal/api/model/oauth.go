@@ -37,3 +37,94 @@ type OAuthAuthorize struct {
 	// See https://www.oauth.com/oauth2-servers/authorization/the-authorization-request/
 	State string `form:"state" json:"state"`
 }
+
+// OAuthServerMetadata represents an OAuth 2.0
+// authorization server metadata document.
+// See: https://www.rfc-editor.org/rfc/rfc8414.html#section-2
+//
+// swagger:model oauthServerMetadata
+type OAuthServerMetadata struct {
+	// Issuer identifier of the authorization server.
+	// example: https://example.org/
+	Issuer string `json:"issuer"`
+	// URL of the authorization endpoint.
+	// example: https://example.org/oauth/authorize
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	// URL of the token endpoint.
+	// example: https://example.org/oauth/token
+	TokenEndpoint string `json:"token_endpoint"`
+	// URL of the token revocation endpoint.
+	// example: https://example.org/oauth/revoke
+	RevocationEndpoint string `json:"revocation_endpoint"`
+	// URL of the token introspection endpoint.
+	// example: https://example.org/oauth/introspect
+	IntrospectionEndpoint string `json:"introspection_endpoint"`
+	// URL of the application registration endpoint.
+	// example: https://example.org/api/v1/apps
+	AppRegistrationEndpoint string `json:"app_registration_endpoint"`
+	// URL of human-readable documentation for developers.
+	// example: https://docs.gotosocial.org/en/latest/api/swagger/
+	ServiceDocumentation string `json:"service_documentation"`
+	// OAuth scopes supported by this server.
+	// example: ["read","write","push"]
+	ScopesSupported []string `json:"scopes_supported"`
+	// OAuth response types supported by this server.
+	// example: ["code"]
+	ResponseTypesSupported []string `json:"response_types_supported"`
+	// OAuth response modes supported by this server.
+	// example: ["query"]
+	ResponseModesSupported []string `json:"response_modes_supported"`
+	// OAuth grant types supported by this server.
+	// example: ["authorization_code","client_credentials"]
+	GrantTypesSupported []string `json:"grant_types_supported"`
+	// Client authentication methods supported by the token endpoint.
+	// example: ["client_secret_post"]
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	// Client authentication methods supported by the revocation endpoint.
+	// example: ["client_secret_post"]
+	RevocationEndpointAuthMethodsSupported []string `json:"revocation_endpoint_auth_methods_supported"`
+	// Client authentication methods supported by the introspection endpoint.
+	// example: ["client_secret_post"]
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported"`
+	// PKCE code challenge methods supported by this server.
+	// example: ["plain","S256"]
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+}
+
+// OAuthTokenIntrospection represents the
+// result of introspecting an OAuth token.
+// See: https://www.rfc-editor.org/rfc/rfc7662.html#section-2.2
+//
+// If the token is not active, only Active
+// will be set, and all other fields omitted.
+//
+// swagger:model oauthTokenIntrospection
+type OAuthTokenIntrospection struct {
+	// Whether the token is currently active.
+	// example: true
+	Active bool `json:"active"`
+	// OAuth scopes granted by the token, space-separated.
+	// example: read write
+	Scope string `json:"scope,omitempty"`
+	// Client ID of the application the token was issued to.
+	// example: 01JMW7QBAZYZ8T8H73PCEX12XG
+	ClientID string `json:"client_id,omitempty"`
+	// Username of the account that authorized the token.
+	// Omitted for application-level tokens.
+	// example: some_user
+	Username string `json:"username,omitempty"`
+	// OAuth token type. Will always be 'Bearer'.
+	// example: Bearer
+	TokenType string `json:"token_type,omitempty"`
+	// When the token will expire (UNIX timestamp seconds).
+	// Omitted if the token does not expire.
+	// example: 1627644520
+	Exp int64 `json:"exp,omitempty"`
+	// When the token was issued (UNIX timestamp seconds).
+	// example: 1627644520
+	Iat int64 `json:"iat,omitempty"`
+	// ID of the account that authorized the token.
+	// Omitted for application-level tokens.
+	// example: 01JMW7QBAZYZ8T8H73PCEX12XG
+	Sub string `json:"sub,omitempty"`
+}
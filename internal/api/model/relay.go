@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminRelay represents a fediverse relay
+// that this instance is (or was) subscribed to.
+//
+// swagger:model adminRelay
+type AdminRelay struct {
+	// The ID of the relay.
+	// example: 01JSYB6Y3W2QK8N5R0T7V4X1ZC
+	ID string `json:"id"`
+	// Time when the relay was added (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time when the relay was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+	// Flavour of relay, determining how it's followed.
+	// `mastodon` relays are followed by sending a Follow of the public collection to the relay's inbox.
+	// `litepub` relays are followed by sending a Follow of the relay actor.
+	// enum:
+	//   - mastodon
+	//   - litepub
+	// example: mastodon
+	Type string `json:"type"`
+	// Inbox URL of the relay, to which public posts are delivered.
+	// example: https://relay.example.org/inbox
+	InboxURL string `json:"inbox_url"`
+	// ActivityPub actor URL of the relay. Empty if not known yet.
+	// example: https://relay.example.org/actor
+	ActorURL string `json:"actor_url"`
+	// State of this instance's subscription to the relay.
+	// `idle`: disabled by an admin.
+	// `pending`: waiting for the relay to accept the subscription.
+	// `accepted`: subscribed, posts are being sent to and received from the relay.
+	// `rejected`: the relay rejected the subscription.
+	// enum:
+	//   - idle
+	//   - pending
+	//   - accepted
+	//   - rejected
+	// example: accepted
+	State string `json:"state"`
+}
+
+// AdminRelayCreateRequest is the form submitted
+// as a POST to /api/v1/admin/relays to add a relay.
+//
+// swagger:ignore
+type AdminRelayCreateRequest struct {
+	// URL of the relay. A URL ending in
+	// `/inbox` is treated as the inbox of a
+	// Mastodon-style relay, anything else is
+	// treated as the actor of a LitePub relay.
+	URL string `form:"url" json:"url"`
+}
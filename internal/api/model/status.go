@@ -88,6 +88,8 @@ type Status struct {
 	// The status that this status reblogs/boosts.
 	// nullable: true
 	Reblog *StatusReblogged `json:"reblog"`
+	// The status that this status quotes, if any.
+	Quote *Quote `json:"quote,omitempty"`
 	// The application used to post this status, if visible.
 	Application *Application `json:"application,omitempty"`
 	// The account that authored this status.
@@ -172,6 +174,16 @@ type WebStatus struct {
 	// Only set if status has been edited.
 	// Last entry is always creation time.
 	EditTimeline []string `json:"-"`
+
+	// Web version of the status quoted
+	// by this status, if quote is
+	// accepted and quoted status is
+	// visible to the web viewer.
+	QuotedStatus *WebStatus `json:"-"`
+
+	// Emoji reactions to this
+	// status, grouped by emoji.
+	Reactions []*StatusReaction `json:"-"`
 }
 
 /*
@@ -211,6 +223,25 @@ type StatusReblogged struct {
 	*Status
 }
 
+// Quote represents the quote of one status by another.
+//
+// swagger:model quote
+type Quote struct {
+	// State of the quote. One of:
+	//
+	//   - pending: quote is awaiting approval by the quoted status author.
+	//   - accepted: quote has been approved, or did not require approval.
+	//   - rejected: quote was rejected by the quoted status author.
+	//   - unauthorized: quoted status is not visible to the requesting account.
+	//   - deleted: quoted status has been deleted or could not be found.
+	//
+	// example: accepted
+	State string `json:"state"`
+	// The quoted status. Only set if state is accepted.
+	// nullable: true
+	QuotedStatus *Status `json:"quoted_status"`
+}
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:ignore
@@ -231,6 +262,9 @@ type StatusCreateRequest struct {
 	// ID of the status being replied to, if status is a reply.
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id"`
 
+	// ID of the status being quoted, if status is a quote.
+	QuotedStatusID string `form:"quoted_status_id" json:"quoted_status_id"`
+
 	// Status and attached media should be marked as sensitive.
 	Sensitive bool `form:"sensitive" json:"sensitive"`
 
@@ -252,7 +286,6 @@ type StatusCreateRequest struct {
 	//
 	// Providing this parameter with a *future* time will cause ScheduledStatus to be returned instead of Status.
 	// Must be at least 5 minutes in the future.
-	// This feature isn't implemented yet.
 	//
 	// Providing this parameter with a *past* time will cause the status to be backdated,
 	// and will not push it to the user's followers. This is intended for importing old statuses.
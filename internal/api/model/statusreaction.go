@@ -0,0 +1,43 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package model
+
+// StatusReaction models all emoji reactions to a status
+// with one emoji, in the format used by Pleroma and Akkoma.
+//
+// swagger:model statusReaction
+type StatusReaction struct {
+	// The emoji used for the reaction. Either a unicode emoji, or a custom emoji's
+	// shortcode. Shortcodes of remote custom emojis are suffixed with @ + their domain.
+	// example: blobcat_uwu
+	Name string `json:"name"`
+	// The total number of accounts that have reacted with this emoji.
+	// example: 5
+	Count int `json:"count"`
+	// The account viewing this has reacted with this emoji.
+	Me bool `json:"me"`
+	// Web link to the image of the custom emoji.
+	// Empty for unicode emojis.
+	// example: https://example.org/fileserver/01GCAMTQQJY3FA7N4R5YTQ6SK1/emoji/original/01GCBMGNZBKMEE1KTZ6PMJEW5D.png
+	URL string `json:"url,omitempty"`
+	// Web link to a non-animated image of the custom emoji.
+	// Empty for unicode emojis.
+	// example: https://example.org/fileserver/01GCAMTQQJY3FA7N4R5YTQ6SK1/emoji/static/01GCBMGNZBKMEE1KTZ6PMJEW5D.png
+	StaticURL string `json:"static_url,omitempty"`
+	// Accounts that have reacted with this emoji.
+	Accounts []*Account `json:"accounts"`
+}
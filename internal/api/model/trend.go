@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// TrendsLink represents a link that is trending on this instance.
+//
+// swagger:model trendsLink
+type TrendsLink struct {
+	// Location of the trending link.
+	// example: https://example.org/some/article
+	URL string `json:"url"`
+	// Title of the linked resource.
+	// Falls back to the link URL if not known.
+	// example: https://example.org/some/article
+	Title string `json:"title"`
+	// Description of the linked resource, if known.
+	Description string `json:"description"`
+	// The type of the preview card. Always "link".
+	// example: link
+	Type string `json:"type"`
+	// Daily usage history of the link, most recent day first.
+	History []History `json:"history"`
+}
+
+// AdminTrendsTag models the admin view of a trending tag.
+//
+// swagger:model adminTrendsTag
+type AdminTrendsTag struct {
+	// The ID of the tag in the database.
+	// example: 01GQ4PHNT622DQ9X95XQX4KKNR
+	ID string `json:"id"`
+	// The value of the hashtag after the # sign.
+	// example: helloworld
+	Name string `json:"name"`
+	// Web link to the hashtag.
+	// example: https://example.org/tags/helloworld
+	URL string `json:"url"`
+	// Daily usage history of the tag, most recent day first.
+	History []History `json:"history"`
+	// Whether the tag has been approved to be shown in trends.
+	Trendable bool `json:"trendable"`
+	// Whether the tag may be used in statuses.
+	Usable bool `json:"usable"`
+	// Whether the tag is awaiting review by an admin.
+	RequiresReview bool `json:"requires_review"`
+}
+
+// AdminTrendsStatus models the admin view of a trending status.
+//
+// swagger:model adminTrendsStatus
+type AdminTrendsStatus struct {
+	*Status
+	// Whether the status has been approved to be shown in trends.
+	Trendable bool `json:"trendable"`
+	// Whether the status is awaiting review by an admin.
+	RequiresReview bool `json:"requires_review"`
+}
+
+// AdminTrendsLink models the admin view of a trending link.
+//
+// swagger:model adminTrendsLink
+type AdminTrendsLink struct {
+	// The ID of the link's trend review, used to approve or reject it.
+	// example: 01GQ4PHNT622DQ9X95XQX4KKNR
+	ID string `json:"id"`
+	TrendsLink
+	// Whether the link has been approved to be shown in trends.
+	Trendable bool `json:"trendable"`
+	// Whether the link is awaiting review by an admin.
+	RequiresReview bool `json:"requires_review"`
+}
@@ -98,3 +98,52 @@ type EmailChangeRequest struct {
 	// required: true
 	NewEmail string `form:"new_email" json:"new_email" xml:"new_email" validation:"required"`
 }
+
+// WebAuthnCredential models a WebAuthn security
+// key or passkey registered by the authorized user.
+//
+// swagger:model webAuthnCredential
+type WebAuthnCredential struct {
+	// Database ID of this credential.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Name given to this credential by the user.
+	// example: Yubikey
+	Name string `json:"name"`
+	// Time this credential was registered. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time this credential was last used to sign in, if at all. (ISO 8601 Datetime)
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	// Credential can be used to sign in without a password (ie., it's a passkey).
+	// example: false
+	Passwordless bool `json:"passwordless"`
+	// Transports reported by the authenticator for this credential.
+	// example: ["usb","nfc"]
+	Transports []string `json:"transports"`
+}
+
+// WebAuthnOptionsRequest models WebAuthn
+// credential registration options parameters.
+//
+// swagger:ignore
+type WebAuthnOptionsRequest struct {
+	// Request a discoverable credential
+	// (passkey) usable for passwordless sign in.
+	Passwordless bool `form:"passwordless" json:"passwordless" xml:"passwordless"`
+}
+
+// WebAuthnRegisterRequest models WebAuthn
+// credential registration parameters.
+//
+// swagger:ignore
+type WebAuthnRegisterRequest struct {
+	// Name to give the new credential.
+	Name string `form:"name" json:"name" xml:"name" validation:"required"`
+	// Credential is usable for passwordless sign in.
+	Passwordless bool `form:"passwordless" json:"passwordless" xml:"passwordless"`
+	// JSON form of the PublicKeyCredential
+	// returned by navigator.credentials.create().
+	Credential string `form:"credential" json:"credential" xml:"credential" validation:"required"`
+}
@@ -83,6 +83,15 @@ type WebPushSubscriptionAlerts struct {
 
 	// Receive a push notification when a boost is pending?
 	PendingReblog bool `json:"pending.reblog"`
+
+	// Receive a push notification when a status you created has been quoted by someone else?
+	Quote bool `json:"quote"`
+
+	// Receive a push notification when a quote is pending?
+	PendingQuote bool `json:"pending.quote"`
+
+	// Receive a push notification when someone else has reacted to a status you created with an emoji?
+	Reaction bool `json:"pleroma:emoji_reaction"`
 }
 
 // WebPushSubscriptionCreateRequest captures params for creating or replacing a Web Push subscription.
@@ -138,6 +147,9 @@ type WebPushSubscriptionUpdateRequest struct {
 	DataAlertsPendingFavourite *bool `form:"data[alerts][pending.favourite]" json:"-"`
 	DataAlertsPendingReply     *bool `form:"data[alerts][pending.reply]" json:"-"`
 	DataAlertsPendingReblog    *bool `form:"data[alerts][pending.reblog]" json:"-"`
+	DataAlertsQuote            *bool `form:"data[alerts][quote]" json:"-"`
+	DataAlertsPendingQuote     *bool `form:"data[alerts][pending.quote]" json:"-"`
+	DataAlertsReaction         *bool `form:"data[alerts][pleroma:emoji_reaction]" json:"-"`
 
 	DataPolicy *WebPushNotificationPolicy `form:"data[policy]" json:"-"`
 }
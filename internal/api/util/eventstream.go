@@ -0,0 +1,35 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package util
+
+import (
+	"net/http"
+	"strings"
+)
+
+// EventStreamPath is the path prefix of the client API's
+// server-sent events streaming endpoints. Responses on these
+// endpoints are long-lived, so they should not be subject to
+// request timeouts, throttling, or compression.
+const EventStreamPath = "/api/v1/streaming/"
+
+// EventStreamRequest returns whether the given
+// request is for a server-sent events stream.
+func EventStreamRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, EventStreamPath)
+}
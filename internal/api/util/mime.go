@@ -37,6 +37,7 @@
 	TextCSS           = `text/css`
 	TextCSV           = `text/csv`
 	TextPlain         = `text/plain`
+	TextEventStream   = `text/event-stream`
 	UTF8              = `utf-8`
 )
 
@@ -84,6 +85,14 @@ func TextPlainContentType(ct string) bool {
 		p[0] == TextPlain
 }
 
+// TextHTMLContentType returns whether is text/html(;charset=utf-8)? content-type.
+func TextHTMLContentType(ct string) bool {
+	p := splitContentType(ct)
+	p, ok := isUTF8ContentType(p)
+	return ok && len(p) == 1 &&
+		p[0] == TextHTML
+}
+
 // ASContentType returns whether is valid ActivityStreams content-types:
 // - application/activity+json
 // - application/ld+json;profile=https://w3.org/ns/activitystreams
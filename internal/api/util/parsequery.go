@@ -36,6 +36,7 @@
 
 	IDKey              = "id"
 	LimitKey           = "limit"
+	OffsetKey          = "offset"
 	LocalKey           = "local"
 	MaxIDKey           = "max_id"
 	SinceIDKey         = "since_id"
@@ -101,6 +102,25 @@
 	InteractionFavouritesKey = "favourites"
 	InteractionRepliesKey    = "replies"
 	InteractionReblogsKey    = "reblogs"
+	InteractionQuotesKey     = "quotes"
+
+	/* Announcement keys */
+
+	AnnouncementsWithDismissedKey = "with_dismissed"
+	AnnouncementReactionNameKey   = "name"
+
+	/* Status reaction keys */
+
+	StatusReactionEmojiKey = "emoji"
+
+	/* Group keys */
+
+	GroupAccountIDKey = "account_id"
+	GroupStatusIDKey  = "status_id"
+
+	/* Streaming keys */
+
+	StreamingOnlyMediaKey = "only_media"
 )
 
 /*
@@ -140,6 +160,10 @@ func ParseLimit(value string, defaultValue int, max, min int) (int, gtserror.Wit
 	return i, nil
 }
 
+func ParseOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, OffsetKey)
+}
+
 func ParseLocal(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, LocalKey)
 }
@@ -176,6 +200,10 @@ func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.Wit
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
 
+func ParseStreamingOnlyMedia(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, StreamingOnlyMediaKey)
+}
+
 func ParseAdminRemote(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, AdminRemoteKey)
 }
@@ -216,6 +244,14 @@ func ParseInteractionReblogs(value string, defaultValue bool) (bool, gtserror.Wi
 	return parseBool(value, defaultValue, InteractionReblogsKey)
 }
 
+func ParseInteractionQuotes(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, InteractionQuotesKey)
+}
+
+func ParseAnnouncementsWithDismissed(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, AnnouncementsWithDismissedKey)
+}
+
 /*
 	Parse functions for *REQUIRED* parameters.
 */
@@ -290,6 +326,46 @@ func ParseUsername(value string) (string, gtserror.WithCode) {
 	return value, nil
 }
 
+func ParseAnnouncementReactionName(value string) (string, gtserror.WithCode) {
+	key := AnnouncementReactionNameKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
+func ParseStatusReactionEmoji(value string) (string, gtserror.WithCode) {
+	key := StatusReactionEmojiKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
+func ParseGroupAccountID(value string) (string, gtserror.WithCode) {
+	key := GroupAccountIDKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
+func ParseGroupStatusID(value string) (string, gtserror.WithCode) {
+	key := GroupStatusIDKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
 func ParseWebStatusID(value string) (string, gtserror.WithCode) {
 	key := WebStatusIDKey
 
@@ -92,6 +92,53 @@
 	ScopeAdminWriteDomainBlocks Scope = ScopeAdminWrite + ":" + scopeDomainBlocks
 )
 
+// Scopes contains every scope
+// understood by this instance,
+// top-level and granular.
+var Scopes = []Scope{
+	ScopeProfile,
+	ScopePush,
+	ScopeRead,
+	ScopeWrite,
+	ScopeAdmin,
+	ScopeAdminRead,
+	ScopeAdminWrite,
+	ScopeReadAccounts,
+	ScopeWriteAccounts,
+	ScopeReadApplications,
+	ScopeWriteApplications,
+	ScopeReadBlocks,
+	ScopeWriteBlocks,
+	ScopeReadBookmarks,
+	ScopeWriteBookmarks,
+	ScopeWriteConversations,
+	ScopeReadFavourites,
+	ScopeWriteFavourites,
+	ScopeReadFilters,
+	ScopeWriteFilters,
+	ScopeReadFollows,
+	ScopeWriteFollows,
+	ScopeReadLists,
+	ScopeWriteLists,
+	ScopeWriteMedia,
+	ScopeReadMutes,
+	ScopeWriteMutes,
+	ScopeReadNotifications,
+	ScopeWriteNotifications,
+	ScopeWriteReports,
+	ScopeReadSearch,
+	ScopeReadStatuses,
+	ScopeWriteStatuses,
+	ScopeAdminReadAccounts,
+	ScopeAdminWriteAccounts,
+	ScopeAdminReadReports,
+	ScopeAdminWriteReports,
+	ScopeAdminReadDomainAllows,
+	ScopeAdminWriteDomainAllows,
+	ScopeAdminReadDomainBlocks,
+	ScopeAdminWriteDomainBlocks,
+}
+
 // Permits returns true if the
 // scope permits the wanted scope.
 func (has Scope) Permits(wanted Scope) bool {
@@ -20,6 +20,7 @@
 import (
 	"code.superseriousbusiness.org/gotosocial/internal/api/wellknown/hostmeta"
 	"code.superseriousbusiness.org/gotosocial/internal/api/wellknown/nodeinfo"
+	"code.superseriousbusiness.org/gotosocial/internal/api/wellknown/oauthserver"
 	"code.superseriousbusiness.org/gotosocial/internal/api/wellknown/webfinger"
 	"code.superseriousbusiness.org/gotosocial/internal/middleware"
 	"code.superseriousbusiness.org/gotosocial/internal/processing"
@@ -28,9 +29,10 @@
 )
 
 type WellKnown struct {
-	nodeInfo  *nodeinfo.Module
-	webfinger *webfinger.Module
-	hostMeta  *hostmeta.Module
+	nodeInfo    *nodeinfo.Module
+	webfinger   *webfinger.Module
+	hostMeta    *hostmeta.Module
+	oauthServer *oauthserver.Module
 }
 
 func (w *WellKnown) Route(r *router.Router, m ...gin.HandlerFunc) {
@@ -50,12 +52,14 @@ func (w *WellKnown) Route(r *router.Router, m ...gin.HandlerFunc) {
 	w.nodeInfo.Route(wellKnownGroup.Handle)
 	w.webfinger.Route(wellKnownGroup.Handle)
 	w.hostMeta.Route(wellKnownGroup.Handle)
+	w.oauthServer.Route(wellKnownGroup.Handle)
 }
 
 func NewWellKnown(p *processing.Processor) *WellKnown {
 	return &WellKnown{
-		nodeInfo:  nodeinfo.New(p),
-		webfinger: webfinger.New(p),
-		hostMeta:  hostmeta.New(p),
+		nodeInfo:    nodeinfo.New(p),
+		webfinger:   webfinger.New(p),
+		hostMeta:    hostmeta.New(p),
+		oauthServer: oauthserver.New(p),
 	}
 }
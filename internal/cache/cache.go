@@ -46,10 +46,23 @@ type Caches struct {
 	// `[status.ID][status.UpdatedAt.Unix()]`
 	StatusesFilterableFields *ttl.Cache[string, []string]
 
+	// Suggestions provides access to the most recently
+	// calculated follow suggestions. (used by the
+	// suggestions processor).
+	Suggestions SuggestionsCache
+
+	// Trends provides access to the most recently
+	// calculated trends. (used by the trends processor).
+	Trends TrendsCache
+
 	// Visibility provides access to the item visibility
 	// cache. (used by the visibility filter).
 	Visibility VisibilityCache
 
+	// WebAuthnChallenges provides access to pending WebAuthn
+	// registration challenges, keyed by user ID.
+	WebAuthnChallenges *ttl.Cache[string, string] // TTL=5min, sweep=1min
+
 	// Webfinger provides access to the webfinger URL cache.
 	Webfinger *ttl.Cache[string, string] // TTL=24hr, sweep=5min
 
@@ -66,6 +79,7 @@ func (c *Caches) Init() {
 	c.initAccountNote()
 	c.initAccountSettings()
 	c.initAccountStats()
+	c.initAnnouncement()
 	c.initApplication()
 	c.initBlock()
 	c.initBlockIDs()
@@ -74,6 +88,7 @@ func (c *Caches) Init() {
 	c.initConversationLastStatusIDs()
 	c.initDomainAllow()
 	c.initDomainBlock()
+	c.initDomainLimit()
 	c.initDomainPermissionDraft()
 	c.initDomainPermissionSubscription()
 	c.initDomainPermissionExclude()
@@ -90,6 +105,7 @@ func (c *Caches) Init() {
 	c.initInReplyToIDs()
 	c.initInstance()
 	c.initInteractionRequest()
+	c.initInvite()
 	c.initList()
 	c.initListIDs()
 	c.initListedIDs()
@@ -101,7 +117,10 @@ func (c *Caches) Init() {
 	c.initPoll()
 	c.initPollVote()
 	c.initPollVoteIDs()
+	c.initPreviewCard()
+	c.initRelay()
 	c.initReport()
+	c.initScheduledStatus()
 	c.initSinBinStatus()
 	c.initStatus()
 	c.initStatusBookmark()
@@ -113,9 +132,11 @@ func (c *Caches) Init() {
 	c.initThreadMute()
 	c.initToken()
 	c.initTombstone()
+	c.initTrendReview()
 	c.initUser()
 	c.initUserMute()
 	c.initUserMuteIDs()
+	c.initWebAuthnChallenges()
 	c.initWebfinger()
 	c.initWebPushSubscription()
 	c.initWebPushSubscriptionIDs()
@@ -136,6 +157,10 @@ func (c *Caches) Start() error {
 		return gtserror.New("could not start statusesFilterableFields cache")
 	}
 
+	if !c.WebAuthnChallenges.Start(1 * time.Minute) {
+		return gtserror.New("could not start webAuthnChallenges cache")
+	}
+
 	return nil
 }
 
@@ -150,6 +175,9 @@ func (c *Caches) Stop() {
 	if c.StatusesFilterableFields != nil {
 		_ = c.StatusesFilterableFields.Stop()
 	}
+	if c.WebAuthnChallenges != nil {
+		_ = c.WebAuthnChallenges.Stop()
+	}
 }
 
 // Sweep will sweep all the available caches to ensure none
@@ -163,6 +191,7 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.AccountNote.Trim(threshold)
 	c.DB.AccountSettings.Trim(threshold)
 	c.DB.AccountStats.Trim(threshold)
+	c.DB.Announcement.Trim(threshold)
 	c.DB.Application.Trim(threshold)
 	c.DB.Block.Trim(threshold)
 	c.DB.BlockIDs.Trim(threshold)
@@ -182,6 +211,7 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.InReplyToIDs.Trim(threshold)
 	c.DB.Instance.Trim(threshold)
 	c.DB.InteractionRequest.Trim(threshold)
+	c.DB.Invite.Trim(threshold)
 	c.DB.List.Trim(threshold)
 	c.DB.ListIDs.Trim(threshold)
 	c.DB.ListedIDs.Trim(threshold)
@@ -193,7 +223,10 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.Poll.Trim(threshold)
 	c.DB.PollVote.Trim(threshold)
 	c.DB.PollVoteIDs.Trim(threshold)
+	c.DB.PreviewCard.Trim(threshold)
+	c.DB.Relay.Trim(threshold)
 	c.DB.Report.Trim(threshold)
+	c.DB.ScheduledStatus.Trim(threshold)
 	c.DB.SinBinStatus.Trim(threshold)
 	c.DB.Status.Trim(threshold)
 	c.DB.StatusBookmark.Trim(threshold)
@@ -204,6 +237,7 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.ThreadMute.Trim(threshold)
 	c.DB.Token.Trim(threshold)
 	c.DB.Tombstone.Trim(threshold)
+	c.DB.TrendReview.Trim(threshold)
 	c.DB.User.Trim(threshold)
 	c.DB.UserMute.Trim(threshold)
 	c.DB.UserMuteIDs.Trim(threshold)
@@ -219,6 +253,15 @@ func (c *Caches) initStatusesFilterableFields() {
 	)
 }
 
+func (c *Caches) initWebAuthnChallenges() {
+	c.WebAuthnChallenges = new(ttl.Cache[string, string])
+	c.WebAuthnChallenges.Init(
+		0,
+		512,
+		5*time.Minute,
+	)
+}
+
 func (c *Caches) initWebfinger() {
 	// Calculate maximum cache size.
 	cap := calculateCacheMax(
@@ -40,6 +40,9 @@ type DBCaches struct {
 	// AccountStats provides access to the gtsmodel AccountStats database cache.
 	AccountStats StructCache[*gtsmodel.AccountStats]
 
+	// Announcement provides access to the gtsmodel Announcement database cache.
+	Announcement StructCache[*gtsmodel.Announcement]
+
 	// Application provides access to the gtsmodel Application database cache.
 	Application StructCache[*gtsmodel.Application]
 
@@ -64,6 +67,10 @@ type DBCaches struct {
 	// DomainBlock provides access to the domain block database cache.
 	DomainBlock *domain.Cache
 
+	// DomainLimit provides access to the domain
+	// block (with limit severity) database cache.
+	DomainLimit *domain.Cache
+
 	// DomainPermissionDraft provides access to the domain permission draft database cache.
 	DomainPermissionDraft StructCache[*gtsmodel.DomainPermissionDraft]
 
@@ -155,6 +162,9 @@ type DBCaches struct {
 	// InteractionRequest provides access to the gtsmodel InteractionRequest database cache.
 	InteractionRequest StructCache[*gtsmodel.InteractionRequest]
 
+	// Invite provides access to the gtsmodel Invite database cache.
+	Invite StructCache[*gtsmodel.Invite]
+
 	// InReplyToIDs provides access to the status in reply to IDs list database cache.
 	InReplyToIDs SliceCache[string]
 
@@ -211,9 +221,18 @@ type DBCaches struct {
 	// PollVoteIDs provides access to the poll vote IDs list database cache.
 	PollVoteIDs SliceCache[string]
 
+	// PreviewCard provides access to the gtsmodel PreviewCard database cache.
+	PreviewCard StructCache[*gtsmodel.PreviewCard]
+
+	// Relay provides access to the gtsmodel Relay database cache.
+	Relay StructCache[*gtsmodel.Relay]
+
 	// Report provides access to the gtsmodel Report database cache.
 	Report StructCache[*gtsmodel.Report]
 
+	// ScheduledStatus provides access to the gtsmodel ScheduledStatus database cache.
+	ScheduledStatus StructCache[*gtsmodel.ScheduledStatus]
+
 	// SinBinStatus provides access to the gtsmodel SinBinStatus database cache.
 	SinBinStatus StructCache[*gtsmodel.SinBinStatus]
 
@@ -247,6 +266,9 @@ type DBCaches struct {
 	// Tombstone provides access to the gtsmodel Tombstone database cache.
 	Tombstone StructCache[*gtsmodel.Tombstone]
 
+	// TrendReview provides access to the gtsmodel TrendReview database cache.
+	TrendReview StructCache[*gtsmodel.TrendReview]
+
 	// User provides access to the gtsmodel User database cache.
 	User StructCache[*gtsmodel.User]
 
@@ -395,6 +417,38 @@ func (c *Caches) initAccountStats() {
 	})
 }
 
+func (c *Caches) initAnnouncement() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofAnnouncement(), // model in-mem size.
+		config.GetCacheAnnouncementMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(a1 *gtsmodel.Announcement) *gtsmodel.Announcement {
+		a2 := new(gtsmodel.Announcement)
+		*a2 = *a1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/announcement.go.
+		a2.Tags = nil
+		a2.Emojis = nil
+
+		return a2
+	}
+
+	c.DB.Announcement.Init(structr.CacheConfig[*gtsmodel.Announcement]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initApplication() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -535,6 +589,10 @@ func (c *Caches) initDomainBlock() {
 	c.DB.DomainBlock = new(domain.Cache)
 }
 
+func (c *Caches) initDomainLimit() {
+	c.DB.DomainLimit = new(domain.Cache)
+}
+
 func (c *Caches) initDomainPermissionDraft() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -958,6 +1016,39 @@ func (c *Caches) initInteractionRequest() {
 	})
 }
 
+func (c *Caches) initInvite() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofInvite(), // model in-mem size.
+		config.GetCacheInviteMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(i1 *gtsmodel.Invite) *gtsmodel.Invite {
+		i2 := new(gtsmodel.Invite)
+		*i2 = *i1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/invite.go.
+		i2.User = nil
+
+		return i2
+	}
+
+	c.DB.Invite.Init(structr.CacheConfig[*gtsmodel.Invite]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "Code"},
+			{Fields: "UserID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initList() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1247,6 +1338,60 @@ func (c *Caches) initPollVoteIDs() {
 	c.DB.PollVoteIDs.Init(0, cap)
 }
 
+func (c *Caches) initPreviewCard() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofPreviewCard(), // model in-mem size.
+		config.GetCachePreviewCardMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(p1 *gtsmodel.PreviewCard) *gtsmodel.PreviewCard {
+		p2 := new(gtsmodel.PreviewCard)
+		*p2 = *p1
+		return p2
+	}
+
+	c.DB.PreviewCard.Init(structr.CacheConfig[*gtsmodel.PreviewCard]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URL"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
+func (c *Caches) initRelay() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofRelay(), // model in-mem size.
+		config.GetCacheRelayMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(r1 *gtsmodel.Relay) *gtsmodel.Relay {
+		r2 := new(gtsmodel.Relay)
+		*r2 = *r1
+		return r2
+	}
+
+	c.DB.Relay.Init(structr.CacheConfig[*gtsmodel.Relay]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "InboxURI"},
+			{Fields: "ActorURI"},
+			{Fields: "FollowURI"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initReport() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1282,6 +1427,40 @@ func (c *Caches) initReport() {
 	})
 }
 
+func (c *Caches) initScheduledStatus() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofScheduledStatus(), // model in-mem size.
+		config.GetCacheScheduledStatusMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(s1 *gtsmodel.ScheduledStatus) *gtsmodel.ScheduledStatus {
+		s2 := new(gtsmodel.ScheduledStatus)
+		*s2 = *s1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/scheduledstatus.go.
+		s2.Account = nil
+		s2.Application = nil
+		s2.MediaAttachments = nil
+
+		return s2
+	}
+
+	c.DB.ScheduledStatus.Init(structr.CacheConfig[*gtsmodel.ScheduledStatus]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initSinBinStatus() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1329,7 +1508,10 @@ func (c *Caches) initStatus() {
 		s2.InReplyToAccount = nil
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
+		s2.QuoteOf = nil
+		s2.QuoteOfAccount = nil
 		s2.Poll = nil
+		s2.PreviewCard = nil
 		s2.Attachments = nil
 		s2.Tags = nil
 		s2.Mentions = nil
@@ -1593,6 +1775,32 @@ func (c *Caches) initTombstone() {
 	})
 }
 
+func (c *Caches) initTrendReview() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofTrendReview(), // model in-mem size.
+		config.GetCacheTrendReviewMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(r1 *gtsmodel.TrendReview) *gtsmodel.TrendReview {
+		r2 := new(gtsmodel.TrendReview)
+		*r2 = *r1
+		return r2
+	}
+
+	c.DB.TrendReview.Init(structr.CacheConfig[*gtsmodel.TrendReview]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "Type,Target"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initUser() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
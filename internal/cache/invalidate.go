@@ -208,6 +208,11 @@ func (c *Caches) OnInvalidateMedia(media *gtsmodel.MediaAttachment) {
 		// Invalidate cache of attaching status.
 		c.DB.Status.Invalidate("ID", media.StatusID)
 	}
+
+	if media.ScheduledStatusID != "" {
+		// Invalidate cache of attaching scheduled status.
+		c.DB.ScheduledStatus.Invalidate("ID", media.ScheduledStatusID)
+	}
 }
 
 func (c *Caches) OnInvalidatePoll(poll *gtsmodel.Poll) {
@@ -202,6 +202,7 @@ func totalOfRatios() float64 {
 		config.GetCacheInReplyToIDsMemRatio() +
 		config.GetCacheInstanceMemRatio() +
 		config.GetCacheInteractionRequestMemRatio() +
+		config.GetCacheInviteMemRatio() +
 		config.GetCacheListMemRatio() +
 		config.GetCacheListIDsMemRatio() +
 		config.GetCacheListedIDsMemRatio() +
@@ -213,6 +214,7 @@ func totalOfRatios() float64 {
 		config.GetCachePollMemRatio() +
 		config.GetCachePollVoteMemRatio() +
 		config.GetCachePollVoteIDsMemRatio() +
+		config.GetCachePreviewCardMemRatio() +
 		config.GetCacheReportMemRatio() +
 		config.GetCacheSinBinStatusMemRatio() +
 		config.GetCacheStatusMemRatio() +
@@ -224,6 +226,7 @@ func totalOfRatios() float64 {
 		config.GetCacheThreadMuteMemRatio() +
 		config.GetCacheTokenMemRatio() +
 		config.GetCacheTombstoneMemRatio() +
+		config.GetCacheTrendReviewMemRatio() +
 		config.GetCacheUserMemRatio() +
 		config.GetCacheUserMuteMemRatio() +
 		config.GetCacheUserMuteIDsMemRatio() +
@@ -299,6 +302,24 @@ func sizeofAccountStats() uintptr {
 	}))
 }
 
+func sizeofAnnouncement() uintptr {
+	return uintptr(size.Of(&gtsmodel.Announcement{
+		ID:          exampleID,
+		CreatedAt:   exampleTime,
+		UpdatedAt:   exampleTime,
+		AccountID:   exampleID,
+		Text:        exampleText,
+		Content:     exampleText,
+		StartsAt:    exampleTime,
+		EndsAt:      exampleTime,
+		AllDay:      util.Ptr(false),
+		Published:   util.Ptr(true),
+		PublishedAt: exampleTime,
+		TagIDs:      []string{exampleID, exampleID},
+		EmojiIDs:    []string{exampleID, exampleID},
+	}))
+}
+
 func sizeofApplication() uintptr {
 	return uintptr(size.Of(&gtsmodel.Application{
 		ID:              exampleID,
@@ -492,6 +513,21 @@ func sizeofInteractionRequest() uintptr {
 	}))
 }
 
+func sizeofInvite() uintptr {
+	return uintptr(size.Of(&gtsmodel.Invite{
+		ID:         exampleID,
+		CreatedAt:  exampleTime,
+		UpdatedAt:  exampleTime,
+		Code:       exampleID,
+		UserID:     exampleID,
+		MaxUses:    10,
+		Uses:       1,
+		ExpiresAt:  exampleTime,
+		Autofollow: util.Ptr(true),
+		RevokedAt:  exampleTime,
+	}))
+}
+
 func sizeofList() uintptr {
 	return uintptr(size.Of(&gtsmodel.List{
 		ID:            exampleID,
@@ -599,6 +635,46 @@ func sizeofPollVote() uintptr {
 	}))
 }
 
+func sizeofPreviewCard() uintptr {
+	return uintptr(size.Of(&gtsmodel.PreviewCard{
+		ID:               exampleID,
+		CreatedAt:        exampleTime,
+		UpdatedAt:        exampleTime,
+		FetchedAt:        exampleTime,
+		URL:              exampleURI,
+		Type:             gtsmodel.PreviewCardTypeLink,
+		Title:            exampleTextSmall,
+		Description:      exampleText,
+		AuthorName:       exampleUsername,
+		AuthorURL:        exampleURI,
+		ProviderName:     exampleUsername,
+		ProviderURL:      exampleURI,
+		Width:            640,
+		Height:           480,
+		ImageRemoteURL:   exampleURI,
+		ImageURL:         exampleURI,
+		ImagePath:        exampleURI,
+		ImageContentType: "image/jpeg",
+		ImageFileSize:    69420,
+		Blurhash:         exampleTextSmall,
+		Cached:           func() *bool { ok := true; return &ok }(),
+	}))
+}
+
+func sizeofRelay() uintptr {
+	return uintptr(size.Of(&gtsmodel.Relay{
+		ID:                 exampleID,
+		CreatedAt:          exampleTime,
+		UpdatedAt:          exampleTime,
+		CreatedByAccountID: exampleID,
+		Type:               gtsmodel.RelayTypeMastodon,
+		InboxURI:           exampleURI,
+		ActorURI:           exampleURI,
+		FollowURI:          exampleURI,
+		State:              gtsmodel.RelayStateAccepted,
+	}))
+}
+
 func sizeofReport() uintptr {
 	return uintptr(size.Of(&gtsmodel.Report{
 		ID:                     exampleID,
@@ -616,6 +692,28 @@ func sizeofReport() uintptr {
 	}))
 }
 
+func sizeofScheduledStatus() uintptr {
+	return uintptr(size.Of(&gtsmodel.ScheduledStatus{
+		ID:          exampleID,
+		AccountID:   exampleID,
+		ScheduledAt: exampleTime,
+		Text:        exampleText,
+		Poll: &gtsmodel.ScheduledStatusPoll{
+			Options:   []string{exampleTextSmall, exampleTextSmall, exampleTextSmall, exampleTextSmall},
+			ExpiresIn: 86400,
+		},
+		MediaIDs:      []string{exampleID, exampleID, exampleID},
+		Sensitive:     util.Ptr(false),
+		SpoilerText:   exampleTextSmall,
+		Visibility:    gtsmodel.VisibilityPublic,
+		InReplyToID:   exampleID,
+		Language:      "en",
+		ApplicationID: exampleID,
+		LocalOnly:     util.Ptr(false),
+		ContentType:   gtsmodel.StatusContentTypePlain,
+	}))
+}
+
 func sizeofSinBinStatus() uintptr {
 	return uintptr(size.Of(&gtsmodel.SinBinStatus{
 		ID:                  exampleID,
@@ -769,6 +867,19 @@ func sizeofTombstone() uintptr {
 	}))
 }
 
+func sizeofTrendReview() uintptr {
+	return uintptr(size.Of(&gtsmodel.TrendReview{
+		ID:                  exampleID,
+		CreatedAt:           exampleTime,
+		UpdatedAt:           exampleTime,
+		Type:                gtsmodel.TrendTypeLink,
+		Target:              exampleURI,
+		State:               gtsmodel.TrendReviewStateApproved,
+		ReviewedByAccountID: exampleID,
+		ReviewedAt:          exampleTime,
+	}))
+}
+
 func sizeofVisibility() uintptr {
 	return uintptr(size.Of(&CachedVisibility{
 		ItemID:      exampleID,
@@ -819,8 +930,9 @@ func sizeofUserMute() uintptr {
 
 func sizeofWebPushSubscription() uintptr {
 	return uintptr(size.Of(&gtsmodel.WebPushSubscription{
-		TokenID: exampleID,
-		Auth:    exampleWebPushAuth,
-		P256dh:  exampleWebPushP256dh,
+		TokenID:           exampleID,
+		SealedAccessToken: exampleTextSmall,
+		Auth:              exampleWebPushAuth,
+		P256dh:            exampleWebPushP256dh,
 	}))
 }
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import "sync"
+
+// SuggestionsCache holds the most recently calculated
+// friends-of-friends follow suggestions for each local
+// account, ordered by score. Suggestions are replaced
+// wholesale whenever they are recalculated.
+//
+// Cached suggestions may be out of date with regards to
+// follows, blocks, mutes and dismissals made since they
+// were calculated, so callers must check these again.
+type SuggestionsCache struct {
+	mu          sync.RWMutex
+	suggestions map[string][]*Suggestion
+}
+
+// Suggestion represents an account
+// suggested to another account.
+type Suggestion struct {
+	// ID of the suggested account.
+	TargetAccountID string
+
+	// Number of accounts followed by the
+	// account that follow the suggested one.
+	Mutuals int
+}
+
+// Get returns the cached suggestions for the account with
+// given ID, ordered by score. The returned slice must not
+// be modified by callers.
+func (c *SuggestionsCache) Get(accountID string) []*Suggestion {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.suggestions[accountID]
+}
+
+// Set replaces all cached suggestions with the given
+// suggestions, keyed by ID of the account to which they
+// are suggested, and each expected to be ordered by score.
+func (c *SuggestionsCache) Set(suggestions map[string][]*Suggestion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.suggestions = suggestions
+}
+
+// Clear drops all cached suggestions.
+func (c *SuggestionsCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.suggestions)
+}
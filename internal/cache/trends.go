@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// TrendsCache holds the most recently calculated scores of
+// trending tags, statuses and links, ordered by score. Each
+// list is replaced wholesale whenever trends are recalculated.
+//
+// Cached trends include those not yet approved by an admin,
+// so callers serving public trends must check for approval.
+type TrendsCache struct {
+	mu     sync.RWMutex
+	trends map[gtsmodel.TrendType][]*Trend
+}
+
+// Trend represents something
+// found to be trending.
+type Trend struct {
+	// Type of thing that's trending.
+	Type gtsmodel.TrendType
+
+	// ID of tag or status,
+	// or URL of link.
+	Target string
+
+	// Score calculated for the trend,
+	// higher is more trending.
+	Score float64
+
+	// Daily uses of the tag or link, most
+	// recent day first. Empty for statuses.
+	History []TrendHistory
+}
+
+// TrendHistory represents uses
+// of a tag or link on one day.
+type TrendHistory struct {
+	// Midnight (UTC) of the day.
+	Day time.Time
+
+	// Number of statuses using it.
+	Uses int
+
+	// Number of distinct accounts using it.
+	Accounts int
+}
+
+// Get returns the cached trends of given type, ordered by
+// score. The returned slice must not be modified by callers.
+func (c *TrendsCache) Get(trendType gtsmodel.TrendType) []*Trend {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.trends[trendType]
+}
+
+// Set replaces the cached trends of given type,
+// which are expected to already be ordered by score.
+func (c *TrendsCache) Set(trendType gtsmodel.TrendType, trends []*Trend) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.trends == nil {
+		c.trends = make(map[gtsmodel.TrendType][]*Trend)
+	}
+	c.trends[trendType] = trends
+}
+
+// Clear drops all cached trends.
+func (c *TrendsCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.trends)
+}
@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// AccountArchive encompasses a set of
+// account archive cleanup / admin utils.
+type AccountArchive struct{ *Cleaner }
+
+// All will execute all cleaner.AccountArchive utilities synchronously, including output logging.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (a *AccountArchive) All(ctx context.Context) {
+	a.LogPruneExpired(ctx)
+}
+
+// LogPruneExpired performs AccountArchive.PruneExpired(...), logging the start and outcome.
+func (a *AccountArchive) LogPruneExpired(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := a.PruneExpired(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
+// PruneExpired will delete all account archives, and their files,
+// that were requested longer ago than gtsmodel.AccountArchiveRetention.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (a *AccountArchive) PruneExpired(ctx context.Context) (int, error) {
+	olderThan := time.Now().Add(-gtsmodel.AccountArchiveRetention)
+
+	// There are only ever a handful of
+	// archives per account, so fetch all.
+	archives, err := a.state.DB.GetAccountArchivesOlderThan(
+		gtscontext.SetBarebones(ctx),
+		olderThan,
+		0,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting expired account archives: %w", err)
+	}
+
+	var total int
+
+	for _, archive := range archives {
+		// Remove the archive file, if any.
+		if _, err := a.removeFiles(ctx, archive.Path); err != nil {
+			return total, err
+		}
+
+		if !gtscontext.DryRun(ctx) {
+			// Delete the archive from the database.
+			err := a.state.DB.DeleteAccountArchiveByID(ctx, archive.ID)
+			if err != nil {
+				return total, gtserror.Newf("error deleting account archive: %w", err)
+			}
+		}
+
+		total++
+	}
+
+	return total, nil
+}
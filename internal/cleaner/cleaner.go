@@ -34,19 +34,28 @@
 )
 
 type Cleaner struct {
-	state *state.State
-	emoji Emoji
-	media Media
+	state   *state.State
+	archive AccountArchive
+	emoji   Emoji
+	media   Media
+	card    PreviewCard
 }
 
 func New(state *state.State) *Cleaner {
 	c := new(Cleaner)
 	c.state = state
+	c.archive.Cleaner = c
 	c.emoji.Cleaner = c
 	c.media.Cleaner = c
+	c.card.Cleaner = c
 	return c
 }
 
+// AccountArchive returns the account archive set of cleaner utilities.
+func (c *Cleaner) AccountArchive() *AccountArchive {
+	return &c.archive
+}
+
 // Emoji returns the emoji set of cleaner utilities.
 func (c *Cleaner) Emoji() *Emoji {
 	return &c.emoji
@@ -57,6 +66,11 @@ func (c *Cleaner) Media() *Media {
 	return &c.media
 }
 
+// PreviewCard returns the preview card set of cleaner utilities.
+func (c *Cleaner) PreviewCard() *PreviewCard {
+	return &c.card
+}
+
 // haveFiles returns whether all of the provided files exist within current storage.
 func (c *Cleaner) haveFiles(ctx context.Context, files ...string) (bool, error) {
 	for _, path := range files {
@@ -163,6 +177,8 @@ func (c *Cleaner) ScheduleJobs() error {
 		log.Info(ctx, "starting media clean")
 		c.Media().All(ctx, config.GetMediaRemoteCacheDays())
 		c.Emoji().All(ctx, config.GetMediaRemoteCacheDays())
+		c.PreviewCard().All(ctx, config.GetMediaRemoteCacheDays())
+		c.AccountArchive().All(ctx)
 		log.Infof(ctx, "finished media clean after %s", time.Since(start))
 	}
 
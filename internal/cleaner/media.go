@@ -20,6 +20,7 @@
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/db"
@@ -326,6 +327,21 @@ func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 			l.Debug("missing db entry for emoji")
 			return true, nil
 		}
+
+	case media.TypeCard:
+		// Look for preview card in database stored by ID.
+		card, err := m.state.DB.GetPreviewCardByID(
+			gtscontext.SetBarebones(ctx),
+			mediaID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return false, gtserror.Newf("error fetching preview card by id %s: %w", mediaID, err)
+		}
+
+		if card == nil || card.ImagePath != path {
+			l.Debug("missing db entry for preview card image")
+			return true, nil
+		}
 	}
 
 	return false, nil
@@ -355,6 +371,20 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	// Check whether we have a scheduled status for media.
+	scheduledStatus, err := m.getRelatedScheduledStatus(ctx, media)
+	if err != nil {
+		return false, err
+	}
+
+	if scheduledStatus != nil {
+		// Check whether still attached to scheduled status.
+		if slices.Contains(scheduledStatus.MediaIDs, media.ID) {
+			l.Debug("skipping as attached to scheduled status")
+			return false, nil
+		}
+	}
+
 	// Check whether we have the required status for media.
 	status, missing, err := m.getRelatedStatus(ctx, media)
 	if err != nil {
@@ -542,6 +572,24 @@ func (m *Media) getRelatedStatus(ctx context.Context, media *gtsmodel.MediaAttac
 	return status, false, nil
 }
 
+func (m *Media) getRelatedScheduledStatus(ctx context.Context, media *gtsmodel.MediaAttachment) (*gtsmodel.ScheduledStatus, error) {
+	if media.ScheduledStatusID == "" {
+		// no related scheduled status.
+		return nil, nil
+	}
+
+	// Load the scheduled status related to this media.
+	scheduledStatus, err := m.state.DB.GetScheduledStatusByID(
+		gtscontext.SetBarebones(ctx),
+		media.ScheduledStatusID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error fetching scheduled status by id %s: %w", media.ScheduledStatusID, err)
+	}
+
+	return scheduledStatus, nil
+}
+
 func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
@@ -0,0 +1,189 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// PreviewCard encompasses a set of
+// preview card cleanup / admin utils.
+type PreviewCard struct{ *Cleaner }
+
+// All will execute all cleaner.PreviewCard utilities synchronously, including output logging.
+// Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
+func (p *PreviewCard) All(ctx context.Context, maxRemoteDays int) {
+	t := time.Now().Add(-24 * time.Hour * time.Duration(maxRemoteDays))
+	p.LogUncacheRemote(ctx, t)
+	p.LogPruneUnused(ctx)
+	_ = p.state.Storage.Storage.Clean(ctx)
+}
+
+// LogUncacheRemote performs PreviewCard.UncacheRemote(...), logging the start and outcome.
+func (p *PreviewCard) LogUncacheRemote(ctx context.Context, olderThan time.Time) {
+	log.Infof(ctx, "start older than: %s", olderThan.Format(time.Stamp))
+	if n, err := p.UncacheRemote(ctx, olderThan); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "uncached: %d", n)
+	}
+}
+
+// LogPruneUnused performs PreviewCard.PruneUnused(...), logging the start and outcome.
+func (p *PreviewCard) LogPruneUnused(ctx context.Context) {
+	log.Info(ctx, "start")
+	if n, err := p.PruneUnused(ctx); err != nil {
+		log.Error(ctx, err)
+	} else {
+		log.Infof(ctx, "pruned: %d", n)
+	}
+}
+
+// UncacheRemote will uncache the images of all preview cards last fetched before
+// given input time. Preview cards are always generated from remote web pages,
+// so all preview card images are considered remote. Context will be checked
+// for `gtscontext.DryRun()` in order to actually perform the action.
+func (p *PreviewCard) UncacheRemote(ctx context.Context, olderThan time.Time) (int, error) {
+	var total int
+
+	for {
+		// Fetch the next batch of cached preview cards older than last-set time.
+		cards, err := p.state.DB.GetCachedPreviewCardsOlderThan(ctx, olderThan, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting cached preview cards: %w", err)
+		}
+
+		// If no cards / same group is
+		// returned, we reached the end.
+		if len(cards) == 0 ||
+			olderThan.Equal(cards[len(cards)-1].FetchedAt) {
+			break
+		}
+
+		// Use last fetchedAt as next 'olderThan' value.
+		olderThan = cards[len(cards)-1].FetchedAt
+
+		for _, card := range cards {
+			// Uncache each preview card image.
+			if err := p.uncache(ctx, card); err != nil {
+				return total, err
+			}
+
+			// Update
+			// count.
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// PruneUnused will delete all preview cards not used by any status from the database and
+// storage driver. Cards created in the last day are skipped, as they may yet be attached
+// to the status they were fetched for. Context will be checked for `gtscontext.DryRun()`
+// in order to actually perform the action.
+func (p *PreviewCard) PruneUnused(ctx context.Context) (int, error) {
+	var total int
+
+	// Only prune cards older than a day.
+	olderThan := time.Now().Add(-24 * time.Hour)
+
+	for {
+		// Fetch the next batch of unused preview cards older than last-set time.
+		cards, err := p.state.DB.GetUnusedPreviewCards(ctx, olderThan, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return total, gtserror.Newf("error getting unused preview cards: %w", err)
+		}
+
+		// If no cards are
+		// returned, we're done.
+		if len(cards) == 0 {
+			break
+		}
+
+		for _, card := range cards {
+			// Delete each unused preview card.
+			if err := p.delete(ctx, card); err != nil {
+				return total, err
+			}
+
+			// Update
+			// count.
+			total++
+		}
+
+		if gtscontext.DryRun(ctx) {
+			// Nothing was actually deleted,
+			// so we'd get the same batch.
+			break
+		}
+	}
+
+	return total, nil
+}
+
+func (p *PreviewCard) uncache(ctx context.Context, card *gtsmodel.PreviewCard) error {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return nil
+	}
+
+	// Remove card image thumbnail.
+	_, err := p.removeFiles(ctx, card.ImagePath)
+	if err != nil {
+		return gtserror.Newf("error removing preview card files: %w", err)
+	}
+
+	// Update card to reflect that we no longer have its image cached.
+	log.Debugf(ctx, "marking preview card as uncached: %s", card.ID)
+	card.Cached = func() *bool { i := false; return &i }()
+	if err := p.state.DB.UpdatePreviewCard(ctx, card, "cached"); err != nil {
+		return gtserror.Newf("error updating preview card: %w", err)
+	}
+
+	return nil
+}
+
+func (p *PreviewCard) delete(ctx context.Context, card *gtsmodel.PreviewCard) error {
+	if gtscontext.DryRun(ctx) {
+		// Dry run, do nothing.
+		return nil
+	}
+
+	// Remove card image thumbnail.
+	_, err := p.removeFiles(ctx, card.ImagePath)
+	if err != nil {
+		return gtserror.Newf("error removing preview card files: %w", err)
+	}
+
+	// Delete preview card entirely from the database.
+	log.Debugf(ctx, "deleting preview card: %s", card.ID)
+	if err := p.state.DB.DeletePreviewCardByID(ctx, card.ID); err != nil {
+		return gtserror.Newf("error deleting preview card: %w", err)
+	}
+
+	return nil
+}
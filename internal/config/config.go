@@ -91,11 +91,13 @@ type Configuration struct {
 	InstanceSubscriptionsProcessEvery time.Duration      `name:"instance-subscriptions-process-every" usage:"Period to elapse between instance subscriptions processing jobs, starting from instance-subscriptions-process-from."`
 	InstanceStatsMode                 string             `name:"instance-stats-mode" usage:"Allows you to customize the way stats are served to crawlers: one of '', 'serve', 'zero', 'baffle'. Home page stats remain unchanged."`
 	InstanceAllowBackdatingStatuses   bool               `name:"instance-allow-backdating-statuses" usage:"Allow local accounts to backdate statuses using the scheduled_at param to /api/v1/statuses"`
+	InstanceTrendsEnabled             bool               `name:"instance-trends-enabled" usage:"Calculate trending hashtags, statuses and links from public activity, and serve approved trends via /api/v1/trends"`
 
 	AccountsRegistrationOpen         bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
 	AccountsReasonRequired           bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
 	AccountsRegistrationDailyLimit   int  `name:"accounts-registration-daily-limit" usage:"Limit amount of approved account sign-ups allowed per 24hrs before registration is closed. 0 or less = no limit."`
 	AccountsRegistrationBacklogLimit int  `name:"accounts-registration-backlog-limit" usage:"Limit how big the 'accounts pending approval' queue can grow before registration is closed. 0 or less = no limit."`
+	AccountsAllowUserInvites         bool `name:"accounts-allow-user-invites" usage:"Allow all users, not just admins, to create invite links that let people sign up even when registration is closed."`
 	AccountsAllowCustomCSS           bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
 	AccountsCustomCSSLength          int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
 
@@ -127,6 +129,8 @@ type Configuration struct {
 	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
 	StatusesPollOptionMaxChars int `name:"statuses-poll-option-max-chars" usage:"Max amount of characters for a poll option"`
 	StatusesMediaMaxFiles      int `name:"statuses-media-max-files" usage:"Maximum number of media files/attachments per status"`
+	ScheduledStatusesMaxTotal  int `name:"scheduled-statuses-max-total" usage:"Maximum number of pending scheduled statuses permitted per account"`
+	ScheduledStatusesMaxDaily  int `name:"scheduled-statuses-max-daily" usage:"Maximum number of pending scheduled statuses permitted per account, per day"`
 
 	LetsEncryptEnabled      bool   `name:"letsencrypt-enabled" usage:"Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default)."`
 	LetsEncryptPort         int    `name:"letsencrypt-port" usage:"Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port."`
@@ -136,16 +140,18 @@ type Configuration struct {
 	TLSCertificateChain string `name:"tls-certificate-chain" usage:"Filesystem path to the certificate chain including any intermediate CAs and the TLS public key"`
 	TLSCertificateKey   string `name:"tls-certificate-key" usage:"Filesystem path to the TLS private key"`
 
-	OIDCEnabled          bool     `name:"oidc-enabled" usage:"Enabled OIDC authorization for this instance. If set to true, then the other OIDC flags must also be set."`
-	OIDCIdpName          string   `name:"oidc-idp-name" usage:"Name of the OIDC identity provider. Will be shown to the user when logging in."`
-	OIDCSkipVerification bool     `name:"oidc-skip-verification" usage:"Skip verification of tokens returned by the OIDC provider. Should only be set to 'true' for testing purposes, never in a production environment!"`
-	OIDCIssuer           string   `name:"oidc-issuer" usage:"Address of the OIDC issuer. Should be the web address, including protocol, at which the issuer can be reached. Eg., 'https://example.org/auth'"`
-	OIDCClientID         string   `name:"oidc-client-id" usage:"ClientID of GoToSocial, as registered with the OIDC provider."`
-	OIDCClientSecret     string   `name:"oidc-client-secret" usage:"ClientSecret of GoToSocial, as registered with the OIDC provider."`
-	OIDCScopes           []string `name:"oidc-scopes" usage:"OIDC scopes."`
-	OIDCLinkExisting     bool     `name:"oidc-link-existing" usage:"link existing user accounts to OIDC logins based on the stored email value"`
-	OIDCAllowedGroups    []string `name:"oidc-allowed-groups" usage:"Membership of one of the listed groups allows access to GtS. If this is empty, all groups are allowed."`
-	OIDCAdminGroups      []string `name:"oidc-admin-groups" usage:"Membership of one of the listed groups makes someone a GtS admin"`
+	OIDCEnabled          bool                        `name:"oidc-enabled" usage:"Enabled OIDC authorization for this instance. If set to true, then the other OIDC flags must also be set."`
+	OIDCIdpName          string                      `name:"oidc-idp-name" usage:"Name of the OIDC identity provider. Will be shown to the user when logging in."`
+	OIDCSkipVerification bool                        `name:"oidc-skip-verification" usage:"Skip verification of tokens returned by the OIDC provider. Should only be set to 'true' for testing purposes, never in a production environment!"`
+	OIDCIssuer           string                      `name:"oidc-issuer" usage:"Address of the OIDC issuer. Should be the web address, including protocol, at which the issuer can be reached. Eg., 'https://example.org/auth'"`
+	OIDCClientID         string                      `name:"oidc-client-id" usage:"ClientID of GoToSocial, as registered with the OIDC provider."`
+	OIDCClientSecret     string                      `name:"oidc-client-secret" usage:"ClientSecret of GoToSocial, as registered with the OIDC provider."`
+	OIDCScopes           []string                    `name:"oidc-scopes" usage:"OIDC scopes."`
+	OIDCLinkExisting     bool                        `name:"oidc-link-existing" usage:"link existing user accounts to OIDC logins based on the stored email value"`
+	OIDCAllowedGroups    []string                    `name:"oidc-allowed-groups" usage:"Membership of one of the listed groups allows access to GtS. If this is empty, all groups are allowed."`
+	OIDCAdminGroups      []string                    `name:"oidc-admin-groups" usage:"Membership of one of the listed groups makes someone a GtS admin"`
+	OIDCModeratorGroups  []string                    `name:"oidc-moderator-groups" usage:"Membership of one of the listed groups makes someone a GtS moderator"`
+	OIDCProviders        []OIDCProviderConfiguration `name:"oidc-providers" usage:"Named OIDC providers that users can choose between when signing in, alongside the provider set by oidc-issuer (if any). Can only be set in the config file."`
 
 	TracingEnabled           bool   `name:"tracing-enabled" usage:"Enable OTLP Tracing"`
 	TracingTransport         string `name:"tracing-transport" usage:"grpc or http"`
@@ -176,6 +182,8 @@ type Configuration struct {
 	AdvancedSenderMultiplier     int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
 	AdvancedCSPExtraURIs         []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
 	AdvancedHeaderFilterMode     string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedTokenHashSecret      string        `name:"advanced-token-hash-secret" usage:"Secret key used to hash OAuth tokens and authorization codes stored in the database. Changing this invalidates all existing tokens."`
+	AdvancedIntrospectionClients []string      `name:"advanced-introspection-clients" usage:"Client IDs of OAuth applications allowed to introspect tokens issued to any application, rather than only their own."`
 
 	// HTTPClient configuration vars.
 	HTTPClient HTTPClientConfiguration `name:"http-client"`
@@ -188,6 +196,9 @@ type Configuration struct {
 	AdminAccountEmail        string `name:"email" usage:"the email address of this account"`
 	AdminAccountPassword     string `name:"password" usage:"the password to set for this account"`
 	AdminTransPath           string `name:"path" usage:"the path of the file to import from/export to"`
+	AdminTransSince          string `name:"since" usage:"only export entries created or updated at or after this RFC3339 timestamp, for an incremental backup"`
+	AdminTransMinimal        bool   `name:"minimal" usage:"export only the bare minimum entries needed to keep federating, as newline separated JSON, without statuses or media"`
+	AdminTransVerifyOnly     bool   `name:"verify-only" usage:"only verify the completeness and checksums of the archive at the given path, without importing anything"`
 	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
 	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
 	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
@@ -206,12 +217,30 @@ type HTTPClientConfiguration struct {
 	TLSInsecureSkipVerify bool          `name:"tls-insecure-skip-verify"`
 }
 
+// OIDCProviderConfiguration configures one named
+// OIDC provider, as an entry of oidc-providers.
+//
+// Group lists left empty fall back to
+// the corresponding top-level oidc setting.
+type OIDCProviderConfiguration struct {
+	ID              string   `name:"id"`
+	Name            string   `name:"name"`
+	Issuer          string   `name:"issuer"`
+	ClientID        string   `name:"client-id"`
+	ClientSecret    string   `name:"client-secret"`
+	Scopes          []string `name:"scopes"`
+	AllowedGroups   []string `name:"allowed-groups"`
+	AdminGroups     []string `name:"admin-groups"`
+	ModeratorGroups []string `name:"moderator-groups"`
+}
+
 type CacheConfiguration struct {
 	MemoryTarget                          bytesize.Size `name:"memory-target"`
 	AccountMemRatio                       float64       `name:"account-mem-ratio"`
 	AccountNoteMemRatio                   float64       `name:"account-note-mem-ratio"`
 	AccountSettingsMemRatio               float64       `name:"account-settings-mem-ratio"`
 	AccountStatsMemRatio                  float64       `name:"account-stats-mem-ratio"`
+	AnnouncementMemRatio                  float64       `name:"announcement-mem-ratio"`
 	ApplicationMemRatio                   float64       `name:"application-mem-ratio"`
 	BlockMemRatio                         float64       `name:"block-mem-ratio"`
 	BlockIDsMemRatio                      float64       `name:"block-ids-mem-ratio"`
@@ -234,6 +263,7 @@ type CacheConfiguration struct {
 	InReplyToIDsMemRatio                  float64       `name:"in-reply-to-ids-mem-ratio"`
 	InstanceMemRatio                      float64       `name:"instance-mem-ratio"`
 	InteractionRequestMemRatio            float64       `name:"interaction-request-mem-ratio"`
+	InviteMemRatio                        float64       `name:"invite-mem-ratio"`
 	ListMemRatio                          float64       `name:"list-mem-ratio"`
 	ListIDsMemRatio                       float64       `name:"list-ids-mem-ratio"`
 	ListedIDsMemRatio                     float64       `name:"listed-ids-mem-ratio"`
@@ -245,7 +275,10 @@ type CacheConfiguration struct {
 	PollMemRatio                          float64       `name:"poll-mem-ratio"`
 	PollVoteMemRatio                      float64       `name:"poll-vote-mem-ratio"`
 	PollVoteIDsMemRatio                   float64       `name:"poll-vote-ids-mem-ratio"`
+	PreviewCardMemRatio                   float64       `name:"preview-card-mem-ratio"`
+	RelayMemRatio                         float64       `name:"relay-mem-ratio"`
 	ReportMemRatio                        float64       `name:"report-mem-ratio"`
+	ScheduledStatusMemRatio               float64       `name:"scheduled-status-mem-ratio"`
 	SinBinStatusMemRatio                  float64       `name:"sin-bin-status-mem-ratio"`
 	StatusMemRatio                        float64       `name:"status-mem-ratio"`
 	StatusBookmarkMemRatio                float64       `name:"status-bookmark-mem-ratio"`
@@ -257,6 +290,7 @@ type CacheConfiguration struct {
 	ThreadMuteMemRatio                    float64       `name:"thread-mute-mem-ratio"`
 	TokenMemRatio                         float64       `name:"token-mem-ratio"`
 	TombstoneMemRatio                     float64       `name:"tombstone-mem-ratio"`
+	TrendReviewMemRatio                   float64       `name:"trend-review-mem-ratio"`
 	UserMemRatio                          float64       `name:"user-mem-ratio"`
 	UserMuteMemRatio                      float64       `name:"user-mute-mem-ratio"`
 	UserMuteIDsMemRatio                   float64       `name:"user-mute-ids-mem-ratio"`
@@ -68,11 +68,13 @@
 	InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm,
 	InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
 	InstanceAllowBackdatingStatuses:   true,
+	InstanceTrendsEnabled:             true,
 
 	AccountsRegistrationOpen:         false,
 	AccountsReasonRequired:           true,
 	AccountsRegistrationDailyLimit:   10,
 	AccountsRegistrationBacklogLimit: 20,
+	AccountsAllowUserInvites:         false,
 	AccountsAllowCustomCSS:           false,
 	AccountsCustomCSSLength:          10000,
 
@@ -98,6 +100,8 @@
 	StatusesPollMaxOptions:     6,
 	StatusesPollOptionMaxChars: 50,
 	StatusesMediaMaxFiles:      6,
+	ScheduledStatusesMaxTotal:  300,
+	ScheduledStatusesMaxDaily:  25,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         80,
@@ -142,6 +146,7 @@
 	AdvancedThrottlingRetryAfter: time.Second * 30,
 	AdvancedSenderMultiplier:     2, // 2 senders per CPU
 	AdvancedCSPExtraURIs:         []string{},
+	AdvancedIntrospectionClients: []string{},
 	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
 
 	Cache: CacheConfiguration{
@@ -168,6 +173,7 @@
 		AccountNoteMemRatio:                   1,
 		AccountSettingsMemRatio:               0.1,
 		AccountStatsMemRatio:                  2,
+		AnnouncementMemRatio:                  0.1,
 		ApplicationMemRatio:                   0.1,
 		BlockMemRatio:                         2,
 		BlockIDsMemRatio:                      3,
@@ -190,6 +196,7 @@
 		InReplyToIDsMemRatio:                  3,
 		InstanceMemRatio:                      1,
 		InteractionRequestMemRatio:            1,
+		InviteMemRatio:                        0.5,
 		ListMemRatio:                          1,
 		ListIDsMemRatio:                       2,
 		ListedIDsMemRatio:                     2,
@@ -201,7 +208,10 @@
 		PollMemRatio:                          1,
 		PollVoteMemRatio:                      2,
 		PollVoteIDsMemRatio:                   2,
+		PreviewCardMemRatio:                   0.5,
+		RelayMemRatio:                         0.1,
 		ReportMemRatio:                        1,
+		ScheduledStatusMemRatio:               0.5,
 		SinBinStatusMemRatio:                  0.5,
 		StatusMemRatio:                        5,
 		StatusBookmarkMemRatio:                0.5,
@@ -213,6 +223,7 @@
 		ThreadMuteMemRatio:                    0.2,
 		TokenMemRatio:                         0.75,
 		TombstoneMemRatio:                     0.5,
+		TrendReviewMemRatio:                   0.5,
 		UserMemRatio:                          0.25,
 		UserMuteMemRatio:                      2,
 		UserMuteIDsMemRatio:                   3,
@@ -94,10 +94,12 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Duration(InstanceSubscriptionsProcessEveryFlag(), cfg.InstanceSubscriptionsProcessEvery, fieldtag("InstanceSubscriptionsProcessEvery", "usage"))
 		cmd.Flags().String(InstanceStatsModeFlag(), cfg.InstanceStatsMode, fieldtag("InstanceStatsMode", "usage"))
 		cmd.Flags().Bool(InstanceAllowBackdatingStatusesFlag(), cfg.InstanceAllowBackdatingStatuses, fieldtag("InstanceAllowBackdatingStatuses", "usage"))
+		cmd.Flags().Bool(InstanceTrendsEnabledFlag(), cfg.InstanceTrendsEnabled, fieldtag("InstanceTrendsEnabled", "usage"))
 
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
+		cmd.Flags().Bool(AccountsAllowUserInvitesFlag(), cfg.AccountsAllowUserInvites, fieldtag("AccountsAllowUserInvites", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
 
 		// Media
@@ -162,6 +164,8 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Int(AdvancedSenderMultiplierFlag(), cfg.AdvancedSenderMultiplier, fieldtag("AdvancedSenderMultiplier", "usage"))
 		cmd.Flags().StringSlice(AdvancedCSPExtraURIsFlag(), cfg.AdvancedCSPExtraURIs, fieldtag("AdvancedCSPExtraURIs", "usage"))
 		cmd.Flags().String(AdvancedHeaderFilterModeFlag(), cfg.AdvancedHeaderFilterMode, fieldtag("AdvancedHeaderFilterMode", "usage"))
+		cmd.Flags().String(AdvancedTokenHashSecretFlag(), cfg.AdvancedTokenHashSecret, fieldtag("AdvancedTokenHashSecret", "usage"))
+		cmd.Flags().StringSlice(AdvancedIntrospectionClientsFlag(), cfg.AdvancedIntrospectionClients, fieldtag("AdvancedIntrospectionClients", "usage"))
 
 		cmd.Flags().String(RequestIDHeaderFlag(), cfg.RequestIDHeader, fieldtag("RequestIDHeader", "usage"))
 	})
@@ -211,6 +215,24 @@ func AddAdminTrans(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminTransExport attaches flags pertaining to the export command.
+func AddAdminTransExport(cmd *cobra.Command) {
+	since := AdminTransSinceFlag()
+	sinceUsage := fieldtag("AdminTransSince", "usage")
+	cmd.Flags().String(since, "", sinceUsage)
+
+	minimal := AdminTransMinimalFlag()
+	minimalUsage := fieldtag("AdminTransMinimal", "usage")
+	cmd.Flags().Bool(minimal, false, minimalUsage)
+}
+
+// AddAdminTransImport attaches flags pertaining to the import command.
+func AddAdminTransImport(cmd *cobra.Command) {
+	name := AdminTransVerifyOnlyFlag()
+	usage := fieldtag("AdminTransVerifyOnly", "usage")
+	cmd.Flags().Bool(name, false, usage)
+}
+
 // AddAdminMediaList attaches flags pertaining to media list commands.
 func AddAdminMediaList(cmd *cobra.Command) {
 	localOnly := AdminMediaListLocalOnlyFlag()
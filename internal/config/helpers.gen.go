@@ -1106,6 +1106,31 @@ func GetInstanceAllowBackdatingStatuses() bool { return global.GetInstanceAllowB
 // SetInstanceAllowBackdatingStatuses safely sets the value for global configuration 'InstanceAllowBackdatingStatuses' field
 func SetInstanceAllowBackdatingStatuses(v bool) { global.SetInstanceAllowBackdatingStatuses(v) }
 
+// GetInstanceTrendsEnabled safely fetches the Configuration value for state's 'InstanceTrendsEnabled' field
+func (st *ConfigState) GetInstanceTrendsEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceTrendsEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceTrendsEnabled safely sets the Configuration value for state's 'InstanceTrendsEnabled' field
+func (st *ConfigState) SetInstanceTrendsEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceTrendsEnabled = v
+	st.reloadToViper()
+}
+
+// InstanceTrendsEnabledFlag returns the flag name for the 'InstanceTrendsEnabled' field
+func InstanceTrendsEnabledFlag() string { return "instance-trends-enabled" }
+
+// GetInstanceTrendsEnabled safely fetches the value for global configuration 'InstanceTrendsEnabled' field
+func GetInstanceTrendsEnabled() bool { return global.GetInstanceTrendsEnabled() }
+
+// SetInstanceTrendsEnabled safely sets the value for global configuration 'InstanceTrendsEnabled' field
+func SetInstanceTrendsEnabled(v bool) { global.SetInstanceTrendsEnabled(v) }
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1206,6 +1231,31 @@ func GetAccountsRegistrationBacklogLimit() int { return global.GetAccountsRegist
 // SetAccountsRegistrationBacklogLimit safely sets the value for global configuration 'AccountsRegistrationBacklogLimit' field
 func SetAccountsRegistrationBacklogLimit(v int) { global.SetAccountsRegistrationBacklogLimit(v) }
 
+// GetAccountsAllowUserInvites safely fetches the Configuration value for state's 'AccountsAllowUserInvites' field
+func (st *ConfigState) GetAccountsAllowUserInvites() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsAllowUserInvites
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsAllowUserInvites safely sets the Configuration value for state's 'AccountsAllowUserInvites' field
+func (st *ConfigState) SetAccountsAllowUserInvites(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsAllowUserInvites = v
+	st.reloadToViper()
+}
+
+// AccountsAllowUserInvitesFlag returns the flag name for the 'AccountsAllowUserInvites' field
+func AccountsAllowUserInvitesFlag() string { return "accounts-allow-user-invites" }
+
+// GetAccountsAllowUserInvites safely fetches the value for global configuration 'AccountsAllowUserInvites' field
+func GetAccountsAllowUserInvites() bool { return global.GetAccountsAllowUserInvites() }
+
+// SetAccountsAllowUserInvites safely sets the value for global configuration 'AccountsAllowUserInvites' field
+func SetAccountsAllowUserInvites(v bool) { global.SetAccountsAllowUserInvites(v) }
+
 // GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
 func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
 	st.mutex.RLock()
@@ -1906,6 +1956,56 @@ func GetStatusesMediaMaxFiles() int { return global.GetStatusesMediaMaxFiles() }
 // SetStatusesMediaMaxFiles safely sets the value for global configuration 'StatusesMediaMaxFiles' field
 func SetStatusesMediaMaxFiles(v int) { global.SetStatusesMediaMaxFiles(v) }
 
+// GetScheduledStatusesMaxTotal safely fetches the Configuration value for state's 'ScheduledStatusesMaxTotal' field
+func (st *ConfigState) GetScheduledStatusesMaxTotal() (v int) {
+	st.mutex.RLock()
+	v = st.config.ScheduledStatusesMaxTotal
+	st.mutex.RUnlock()
+	return
+}
+
+// SetScheduledStatusesMaxTotal safely sets the Configuration value for state's 'ScheduledStatusesMaxTotal' field
+func (st *ConfigState) SetScheduledStatusesMaxTotal(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.ScheduledStatusesMaxTotal = v
+	st.reloadToViper()
+}
+
+// ScheduledStatusesMaxTotalFlag returns the flag name for the 'ScheduledStatusesMaxTotal' field
+func ScheduledStatusesMaxTotalFlag() string { return "scheduled-statuses-max-total" }
+
+// GetScheduledStatusesMaxTotal safely fetches the value for global configuration 'ScheduledStatusesMaxTotal' field
+func GetScheduledStatusesMaxTotal() int { return global.GetScheduledStatusesMaxTotal() }
+
+// SetScheduledStatusesMaxTotal safely sets the value for global configuration 'ScheduledStatusesMaxTotal' field
+func SetScheduledStatusesMaxTotal(v int) { global.SetScheduledStatusesMaxTotal(v) }
+
+// GetScheduledStatusesMaxDaily safely fetches the Configuration value for state's 'ScheduledStatusesMaxDaily' field
+func (st *ConfigState) GetScheduledStatusesMaxDaily() (v int) {
+	st.mutex.RLock()
+	v = st.config.ScheduledStatusesMaxDaily
+	st.mutex.RUnlock()
+	return
+}
+
+// SetScheduledStatusesMaxDaily safely sets the Configuration value for state's 'ScheduledStatusesMaxDaily' field
+func (st *ConfigState) SetScheduledStatusesMaxDaily(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.ScheduledStatusesMaxDaily = v
+	st.reloadToViper()
+}
+
+// ScheduledStatusesMaxDailyFlag returns the flag name for the 'ScheduledStatusesMaxDaily' field
+func ScheduledStatusesMaxDailyFlag() string { return "scheduled-statuses-max-daily" }
+
+// GetScheduledStatusesMaxDaily safely fetches the value for global configuration 'ScheduledStatusesMaxDaily' field
+func GetScheduledStatusesMaxDaily() int { return global.GetScheduledStatusesMaxDaily() }
+
+// SetScheduledStatusesMaxDaily safely sets the value for global configuration 'ScheduledStatusesMaxDaily' field
+func SetScheduledStatusesMaxDaily(v int) { global.SetScheduledStatusesMaxDaily(v) }
+
 // GetLetsEncryptEnabled safely fetches the Configuration value for state's 'LetsEncryptEnabled' field
 func (st *ConfigState) GetLetsEncryptEnabled() (v bool) {
 	st.mutex.RLock()
@@ -2306,6 +2406,56 @@ func GetOIDCAdminGroups() []string { return global.GetOIDCAdminGroups() }
 // SetOIDCAdminGroups safely sets the value for global configuration 'OIDCAdminGroups' field
 func SetOIDCAdminGroups(v []string) { global.SetOIDCAdminGroups(v) }
 
+// GetOIDCModeratorGroups safely fetches the Configuration value for state's 'OIDCModeratorGroups' field
+func (st *ConfigState) GetOIDCModeratorGroups() (v []string) {
+	st.mutex.RLock()
+	v = st.config.OIDCModeratorGroups
+	st.mutex.RUnlock()
+	return
+}
+
+// SetOIDCModeratorGroups safely sets the Configuration value for state's 'OIDCModeratorGroups' field
+func (st *ConfigState) SetOIDCModeratorGroups(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OIDCModeratorGroups = v
+	st.reloadToViper()
+}
+
+// OIDCModeratorGroupsFlag returns the flag name for the 'OIDCModeratorGroups' field
+func OIDCModeratorGroupsFlag() string { return "oidc-moderator-groups" }
+
+// GetOIDCModeratorGroups safely fetches the value for global configuration 'OIDCModeratorGroups' field
+func GetOIDCModeratorGroups() []string { return global.GetOIDCModeratorGroups() }
+
+// SetOIDCModeratorGroups safely sets the value for global configuration 'OIDCModeratorGroups' field
+func SetOIDCModeratorGroups(v []string) { global.SetOIDCModeratorGroups(v) }
+
+// GetOIDCProviders safely fetches the Configuration value for state's 'OIDCProviders' field
+func (st *ConfigState) GetOIDCProviders() (v []OIDCProviderConfiguration) {
+	st.mutex.RLock()
+	v = st.config.OIDCProviders
+	st.mutex.RUnlock()
+	return
+}
+
+// SetOIDCProviders safely sets the Configuration value for state's 'OIDCProviders' field
+func (st *ConfigState) SetOIDCProviders(v []OIDCProviderConfiguration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OIDCProviders = v
+	st.reloadToViper()
+}
+
+// OIDCProvidersFlag returns the flag name for the 'OIDCProviders' field
+func OIDCProvidersFlag() string { return "oidc-providers" }
+
+// GetOIDCProviders safely fetches the value for global configuration 'OIDCProviders' field
+func GetOIDCProviders() []OIDCProviderConfiguration { return global.GetOIDCProviders() }
+
+// SetOIDCProviders safely sets the value for global configuration 'OIDCProviders' field
+func SetOIDCProviders(v []OIDCProviderConfiguration) { global.SetOIDCProviders(v) }
+
 // GetTracingEnabled safely fetches the Configuration value for state's 'TracingEnabled' field
 func (st *ConfigState) GetTracingEnabled() (v bool) {
 	st.mutex.RLock()
@@ -2931,6 +3081,56 @@ func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilte
 // SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
 func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
 
+// GetAdvancedTokenHashSecret safely fetches the Configuration value for state's 'AdvancedTokenHashSecret' field
+func (st *ConfigState) GetAdvancedTokenHashSecret() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedTokenHashSecret
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedTokenHashSecret safely sets the Configuration value for state's 'AdvancedTokenHashSecret' field
+func (st *ConfigState) SetAdvancedTokenHashSecret(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedTokenHashSecret = v
+	st.reloadToViper()
+}
+
+// AdvancedTokenHashSecretFlag returns the flag name for the 'AdvancedTokenHashSecret' field
+func AdvancedTokenHashSecretFlag() string { return "advanced-token-hash-secret" }
+
+// GetAdvancedTokenHashSecret safely fetches the value for global configuration 'AdvancedTokenHashSecret' field
+func GetAdvancedTokenHashSecret() string { return global.GetAdvancedTokenHashSecret() }
+
+// SetAdvancedTokenHashSecret safely sets the value for global configuration 'AdvancedTokenHashSecret' field
+func SetAdvancedTokenHashSecret(v string) { global.SetAdvancedTokenHashSecret(v) }
+
+// GetAdvancedIntrospectionClients safely fetches the Configuration value for state's 'AdvancedIntrospectionClients' field
+func (st *ConfigState) GetAdvancedIntrospectionClients() (v []string) {
+	st.mutex.RLock()
+	v = st.config.AdvancedIntrospectionClients
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedIntrospectionClients safely sets the Configuration value for state's 'AdvancedIntrospectionClients' field
+func (st *ConfigState) SetAdvancedIntrospectionClients(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedIntrospectionClients = v
+	st.reloadToViper()
+}
+
+// AdvancedIntrospectionClientsFlag returns the flag name for the 'AdvancedIntrospectionClients' field
+func AdvancedIntrospectionClientsFlag() string { return "advanced-introspection-clients" }
+
+// GetAdvancedIntrospectionClients safely fetches the value for global configuration 'AdvancedIntrospectionClients' field
+func GetAdvancedIntrospectionClients() []string { return global.GetAdvancedIntrospectionClients() }
+
+// SetAdvancedIntrospectionClients safely sets the value for global configuration 'AdvancedIntrospectionClients' field
+func SetAdvancedIntrospectionClients(v []string) { global.SetAdvancedIntrospectionClients(v) }
+
 // GetHTTPClientAllowIPs safely fetches the Configuration value for state's 'HTTPClient.AllowIPs' field
 func (st *ConfigState) GetHTTPClientAllowIPs() (v []string) {
 	st.mutex.RLock()
@@ -3156,6 +3356,31 @@ func GetCacheAccountStatsMemRatio() float64 { return global.GetCacheAccountStats
 // SetCacheAccountStatsMemRatio safely sets the value for global configuration 'Cache.AccountStatsMemRatio' field
 func SetCacheAccountStatsMemRatio(v float64) { global.SetCacheAccountStatsMemRatio(v) }
 
+// GetCacheAnnouncementMemRatio safely fetches the Configuration value for state's 'Cache.AnnouncementMemRatio' field
+func (st *ConfigState) GetCacheAnnouncementMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.AnnouncementMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheAnnouncementMemRatio safely sets the Configuration value for state's 'Cache.AnnouncementMemRatio' field
+func (st *ConfigState) SetCacheAnnouncementMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.AnnouncementMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheAnnouncementMemRatioFlag returns the flag name for the 'Cache.AnnouncementMemRatio' field
+func CacheAnnouncementMemRatioFlag() string { return "cache-announcement-mem-ratio" }
+
+// GetCacheAnnouncementMemRatio safely fetches the value for global configuration 'Cache.AnnouncementMemRatio' field
+func GetCacheAnnouncementMemRatio() float64 { return global.GetCacheAnnouncementMemRatio() }
+
+// SetCacheAnnouncementMemRatio safely sets the value for global configuration 'Cache.AnnouncementMemRatio' field
+func SetCacheAnnouncementMemRatio(v float64) { global.SetCacheAnnouncementMemRatio(v) }
+
 // GetCacheApplicationMemRatio safely fetches the Configuration value for state's 'Cache.ApplicationMemRatio' field
 func (st *ConfigState) GetCacheApplicationMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3724,6 +3949,31 @@ func GetCacheInteractionRequestMemRatio() float64 { return global.GetCacheIntera
 // SetCacheInteractionRequestMemRatio safely sets the value for global configuration 'Cache.InteractionRequestMemRatio' field
 func SetCacheInteractionRequestMemRatio(v float64) { global.SetCacheInteractionRequestMemRatio(v) }
 
+// GetCacheInviteMemRatio safely fetches the Configuration value for state's 'Cache.InviteMemRatio' field
+func (st *ConfigState) GetCacheInviteMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.InviteMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheInviteMemRatio safely sets the Configuration value for state's 'Cache.InviteMemRatio' field
+func (st *ConfigState) SetCacheInviteMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.InviteMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheInviteMemRatioFlag returns the flag name for the 'Cache.InviteMemRatio' field
+func CacheInviteMemRatioFlag() string { return "cache-invite-mem-ratio" }
+
+// GetCacheInviteMemRatio safely fetches the value for global configuration 'Cache.InviteMemRatio' field
+func GetCacheInviteMemRatio() float64 { return global.GetCacheInviteMemRatio() }
+
+// SetCacheInviteMemRatio safely sets the value for global configuration 'Cache.InviteMemRatio' field
+func SetCacheInviteMemRatio(v float64) { global.SetCacheInviteMemRatio(v) }
+
 // GetCacheListMemRatio safely fetches the Configuration value for state's 'Cache.ListMemRatio' field
 func (st *ConfigState) GetCacheListMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3999,6 +4249,56 @@ func GetCachePollVoteIDsMemRatio() float64 { return global.GetCachePollVoteIDsMe
 // SetCachePollVoteIDsMemRatio safely sets the value for global configuration 'Cache.PollVoteIDsMemRatio' field
 func SetCachePollVoteIDsMemRatio(v float64) { global.SetCachePollVoteIDsMemRatio(v) }
 
+// GetCachePreviewCardMemRatio safely fetches the Configuration value for state's 'Cache.PreviewCardMemRatio' field
+func (st *ConfigState) GetCachePreviewCardMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.PreviewCardMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCachePreviewCardMemRatio safely sets the Configuration value for state's 'Cache.PreviewCardMemRatio' field
+func (st *ConfigState) SetCachePreviewCardMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.PreviewCardMemRatio = v
+	st.reloadToViper()
+}
+
+// CachePreviewCardMemRatioFlag returns the flag name for the 'Cache.PreviewCardMemRatio' field
+func CachePreviewCardMemRatioFlag() string { return "cache-preview-card-mem-ratio" }
+
+// GetCachePreviewCardMemRatio safely fetches the value for global configuration 'Cache.PreviewCardMemRatio' field
+func GetCachePreviewCardMemRatio() float64 { return global.GetCachePreviewCardMemRatio() }
+
+// SetCachePreviewCardMemRatio safely sets the value for global configuration 'Cache.PreviewCardMemRatio' field
+func SetCachePreviewCardMemRatio(v float64) { global.SetCachePreviewCardMemRatio(v) }
+
+// GetCacheRelayMemRatio safely fetches the Configuration value for state's 'Cache.RelayMemRatio' field
+func (st *ConfigState) GetCacheRelayMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.RelayMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheRelayMemRatio safely sets the Configuration value for state's 'Cache.RelayMemRatio' field
+func (st *ConfigState) SetCacheRelayMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.RelayMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheRelayMemRatioFlag returns the flag name for the 'Cache.RelayMemRatio' field
+func CacheRelayMemRatioFlag() string { return "cache-relay-mem-ratio" }
+
+// GetCacheRelayMemRatio safely fetches the value for global configuration 'Cache.RelayMemRatio' field
+func GetCacheRelayMemRatio() float64 { return global.GetCacheRelayMemRatio() }
+
+// SetCacheRelayMemRatio safely sets the value for global configuration 'Cache.RelayMemRatio' field
+func SetCacheRelayMemRatio(v float64) { global.SetCacheRelayMemRatio(v) }
+
 // GetCacheReportMemRatio safely fetches the Configuration value for state's 'Cache.ReportMemRatio' field
 func (st *ConfigState) GetCacheReportMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4024,6 +4324,31 @@ func GetCacheReportMemRatio() float64 { return global.GetCacheReportMemRatio() }
 // SetCacheReportMemRatio safely sets the value for global configuration 'Cache.ReportMemRatio' field
 func SetCacheReportMemRatio(v float64) { global.SetCacheReportMemRatio(v) }
 
+// GetCacheScheduledStatusMemRatio safely fetches the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) GetCacheScheduledStatusMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.ScheduledStatusMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheScheduledStatusMemRatio safely sets the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) SetCacheScheduledStatusMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.ScheduledStatusMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheScheduledStatusMemRatioFlag returns the flag name for the 'Cache.ScheduledStatusMemRatio' field
+func CacheScheduledStatusMemRatioFlag() string { return "cache-scheduled-status-mem-ratio" }
+
+// GetCacheScheduledStatusMemRatio safely fetches the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func GetCacheScheduledStatusMemRatio() float64 { return global.GetCacheScheduledStatusMemRatio() }
+
+// SetCacheScheduledStatusMemRatio safely sets the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func SetCacheScheduledStatusMemRatio(v float64) { global.SetCacheScheduledStatusMemRatio(v) }
+
 // GetCacheSinBinStatusMemRatio safely fetches the Configuration value for state's 'Cache.SinBinStatusMemRatio' field
 func (st *ConfigState) GetCacheSinBinStatusMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4299,6 +4624,31 @@ func GetCacheTombstoneMemRatio() float64 { return global.GetCacheTombstoneMemRat
 // SetCacheTombstoneMemRatio safely sets the value for global configuration 'Cache.TombstoneMemRatio' field
 func SetCacheTombstoneMemRatio(v float64) { global.SetCacheTombstoneMemRatio(v) }
 
+// GetCacheTrendReviewMemRatio safely fetches the Configuration value for state's 'Cache.TrendReviewMemRatio' field
+func (st *ConfigState) GetCacheTrendReviewMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.TrendReviewMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheTrendReviewMemRatio safely sets the Configuration value for state's 'Cache.TrendReviewMemRatio' field
+func (st *ConfigState) SetCacheTrendReviewMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.TrendReviewMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheTrendReviewMemRatioFlag returns the flag name for the 'Cache.TrendReviewMemRatio' field
+func CacheTrendReviewMemRatioFlag() string { return "cache-trend-review-mem-ratio" }
+
+// GetCacheTrendReviewMemRatio safely fetches the value for global configuration 'Cache.TrendReviewMemRatio' field
+func GetCacheTrendReviewMemRatio() float64 { return global.GetCacheTrendReviewMemRatio() }
+
+// SetCacheTrendReviewMemRatio safely sets the value for global configuration 'Cache.TrendReviewMemRatio' field
+func SetCacheTrendReviewMemRatio(v float64) { global.SetCacheTrendReviewMemRatio(v) }
+
 // GetCacheUserMemRatio safely fetches the Configuration value for state's 'Cache.UserMemRatio' field
 func (st *ConfigState) GetCacheUserMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4582,6 +4932,81 @@ func GetAdminTransPath() string { return global.GetAdminTransPath() }
 // SetAdminTransPath safely sets the value for global configuration 'AdminTransPath' field
 func SetAdminTransPath(v string) { global.SetAdminTransPath(v) }
 
+// GetAdminTransSince safely fetches the Configuration value for state's 'AdminTransSince' field
+func (st *ConfigState) GetAdminTransSince() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminTransSince
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminTransSince safely sets the Configuration value for state's 'AdminTransSince' field
+func (st *ConfigState) SetAdminTransSince(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransSince = v
+	st.reloadToViper()
+}
+
+// AdminTransSinceFlag returns the flag name for the 'AdminTransSince' field
+func AdminTransSinceFlag() string { return "since" }
+
+// GetAdminTransSince safely fetches the value for global configuration 'AdminTransSince' field
+func GetAdminTransSince() string { return global.GetAdminTransSince() }
+
+// SetAdminTransSince safely sets the value for global configuration 'AdminTransSince' field
+func SetAdminTransSince(v string) { global.SetAdminTransSince(v) }
+
+// GetAdminTransMinimal safely fetches the Configuration value for state's 'AdminTransMinimal' field
+func (st *ConfigState) GetAdminTransMinimal() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdminTransMinimal
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminTransMinimal safely sets the Configuration value for state's 'AdminTransMinimal' field
+func (st *ConfigState) SetAdminTransMinimal(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransMinimal = v
+	st.reloadToViper()
+}
+
+// AdminTransMinimalFlag returns the flag name for the 'AdminTransMinimal' field
+func AdminTransMinimalFlag() string { return "minimal" }
+
+// GetAdminTransMinimal safely fetches the value for global configuration 'AdminTransMinimal' field
+func GetAdminTransMinimal() bool { return global.GetAdminTransMinimal() }
+
+// SetAdminTransMinimal safely sets the value for global configuration 'AdminTransMinimal' field
+func SetAdminTransMinimal(v bool) { global.SetAdminTransMinimal(v) }
+
+// GetAdminTransVerifyOnly safely fetches the Configuration value for state's 'AdminTransVerifyOnly' field
+func (st *ConfigState) GetAdminTransVerifyOnly() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdminTransVerifyOnly
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminTransVerifyOnly safely sets the Configuration value for state's 'AdminTransVerifyOnly' field
+func (st *ConfigState) SetAdminTransVerifyOnly(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransVerifyOnly = v
+	st.reloadToViper()
+}
+
+// AdminTransVerifyOnlyFlag returns the flag name for the 'AdminTransVerifyOnly' field
+func AdminTransVerifyOnlyFlag() string { return "verify-only" }
+
+// GetAdminTransVerifyOnly safely fetches the value for global configuration 'AdminTransVerifyOnly' field
+func GetAdminTransVerifyOnly() bool { return global.GetAdminTransVerifyOnly() }
+
+// SetAdminTransVerifyOnly safely sets the value for global configuration 'AdminTransVerifyOnly' field
+func SetAdminTransVerifyOnly(v bool) { global.SetAdminTransVerifyOnly(v) }
+
 // GetAdminMediaPruneDryRun safely fetches the Configuration value for state's 'AdminMediaPruneDryRun' field
 func (st *ConfigState) GetAdminMediaPruneDryRun() (v bool) {
 	st.mutex.RLock()
@@ -181,5 +181,41 @@ func Validate() error {
 		)
 	}
 
+	// OIDC refresh tokens, used to sync
+	// users' groups, are stored encrypted
+	// with a key derived from the token hash
+	// secret, so it must be set for group sync.
+	if GetOIDCEnabled() &&
+		GetAdvancedTokenHashSecret() == "" &&
+		oidcSyncsGroups() {
+		errf(
+			"%s must be set when %s, %s, %s, or the groups of an entry in %s are set",
+			AdvancedTokenHashSecretFlag(),
+			OIDCAllowedGroupsFlag(), OIDCAdminGroupsFlag(),
+			OIDCModeratorGroupsFlag(), OIDCProvidersFlag(),
+		)
+	}
+
 	return errs.Combine()
 }
+
+// oidcSyncsGroups returns whether any
+// OIDC provider has groups configured,
+// and so needs to sync users' groups.
+func oidcSyncsGroups() bool {
+	if len(GetOIDCAllowedGroups()) != 0 ||
+		len(GetOIDCAdminGroups()) != 0 ||
+		len(GetOIDCModeratorGroups()) != 0 {
+		return true
+	}
+
+	for _, cfg := range GetOIDCProviders() {
+		if len(cfg.AllowedGroups) != 0 ||
+			len(cfg.AdminGroups) != 0 ||
+			len(cfg.ModeratorGroups) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -140,6 +140,29 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set\nprotocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigOIDCGroupsNoTokenSecret() {
+	testrig.InitTestConfig()
+
+	config.SetOIDCEnabled(true)
+	config.SetAdvancedTokenHashSecret("")
+
+	err := config.Validate()
+	suite.EqualError(err, "advanced-token-hash-secret must be set when oidc-allowed-groups, oidc-admin-groups, oidc-moderator-groups, or the groups of an entry in oidc-providers are set")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigOIDCNoGroupsNoTokenSecret() {
+	testrig.InitTestConfig()
+
+	config.SetOIDCEnabled(true)
+	config.SetOIDCAllowedGroups(nil)
+	config.SetOIDCAdminGroups(nil)
+	config.SetOIDCModeratorGroups(nil)
+	config.SetAdvancedTokenHashSecret("")
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
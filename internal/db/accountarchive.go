@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type AccountArchive interface {
+	// GetAccountArchiveByID gets one account archive with the given ID.
+	GetAccountArchiveByID(ctx context.Context, id string) (*gtsmodel.AccountArchive, error)
+
+	// GetAccountArchivesByAccountID gets all archives
+	// of the given account ID, newest first.
+	GetAccountArchivesByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.AccountArchive, error)
+
+	// GetAccountArchivesOlderThan gets account archives
+	// created before the given time, oldest first.
+	GetAccountArchivesOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.AccountArchive, error)
+
+	// PutAccountArchive puts the given account archive in the database.
+	PutAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) error
+
+	// UpdateAccountArchive updates the given account archive by primary key.
+	// Updates values of given columns only, or all if none provided.
+	UpdateAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive, columns ...string) error
+
+	// DeleteAccountArchiveByID deletes the account archive with the given ID.
+	DeleteAccountArchiveByID(ctx context.Context, id string) error
+}
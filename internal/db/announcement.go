@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+type Announcement interface {
+	// GetAnnouncementByID gets one announcement with the given id.
+	GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error)
+
+	// GetAnnouncementsByIDs gets the announcements with the given ids.
+	GetAnnouncementsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Announcement, error)
+
+	// GetAnnouncements returns a page of all announcements, published or not.
+	GetAnnouncements(ctx context.Context, page *paging.Page) ([]*gtsmodel.Announcement, error)
+
+	// GetActiveAnnouncements returns all published announcements that have
+	// started and not ended at the given time, sorted oldest to newest.
+	GetActiveAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error)
+
+	// PopulateAnnouncement ensures that all sub-models of an announcement are populated (e.g. tags, emojis).
+	PopulateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// PutAnnouncement puts the given announcement in the database.
+	PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// UpdateAnnouncement updates the given announcement in the database, only on selected columns if provided (else, all).
+	UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error
+
+	// DeleteAnnouncementByID deletes one announcement, and any reads / reactions targeting it, from the database.
+	DeleteAnnouncementByID(ctx context.Context, id string) error
+
+	// IsAnnouncementRead returns whether the given account has read (dismissed) the given announcement.
+	IsAnnouncementRead(ctx context.Context, announcementID string, accountID string) (bool, error)
+
+	// PutAnnouncementRead puts the given announcement read in the database, doing nothing if it already exists.
+	PutAnnouncementRead(ctx context.Context, read *gtsmodel.AnnouncementRead) error
+
+	// GetAnnouncementReaction gets the reaction with given name by the given account to the given announcement.
+	GetAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) (*gtsmodel.AnnouncementReaction, error)
+
+	// GetAnnouncementReactions gets all reactions to the given announcement, oldest first.
+	GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error)
+
+	// PutAnnouncementReaction puts the given announcement reaction in the database.
+	PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error
+
+	// DeleteAnnouncementReactionByID deletes one announcement reaction from the database.
+	DeleteAnnouncementReactionByID(ctx context.Context, id string) error
+
+	// DeleteAnnouncementReadsAndReactionsByAccountID deletes all announcement
+	// reads and reactions by the given account from the database.
+	DeleteAnnouncementReadsAndReactionsByAccountID(ctx context.Context, accountID string) error
+}
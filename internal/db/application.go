@@ -37,6 +37,9 @@ type Application interface {
 	// PutApplication places the new application in the database, erroring on non-unique ID or client_id.
 	PutApplication(ctx context.Context, app *gtsmodel.Application) error
 
+	// UpdateApplication updates the given application. Update all columns if no specific columns given.
+	UpdateApplication(ctx context.Context, app *gtsmodel.Application, columns ...string) error
+
 	// DeleteApplicationByID deletes the application with corresponding id from the database.
 	DeleteApplicationByID(ctx context.Context, id string) error
 
@@ -79,4 +82,8 @@ type Application interface {
 	// DeleteTokensByClientID deletes all tokens
 	// with the given clientID from the database.
 	DeleteTokensByClientID(ctx context.Context, clientID string) error
+
+	// DeleteTokensByUserID deletes all tokens
+	// with the given userID from the database.
+	DeleteTokensByUserID(ctx context.Context, userID string) error
 }
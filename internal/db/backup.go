@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Backup contains functions for reading and writing whole
+// tables at a time, for backing up and restoring an instance.
+type Backup interface {
+	// StreamTable selects every entry in the table of the given model type
+	// (eg., &gtsmodel.Status{}) ordered by primary key, calling fn with each
+	// entry scanned into a newly allocated model of that same type.
+	//
+	// If since is not zero, only entries with any timestamp column (eg.,
+	// created_at, updated_at, edited_at) at or after since will be selected.
+	// Tables without timestamp columns will always be selected in full.
+	StreamTable(ctx context.Context, model interface{}, since time.Time, fn func(interface{}) error) error
+
+	// RestoreEntry inserts the given model into its table, overwriting any
+	// existing entry with the same primary key. For tables without a primary
+	// key, any existing entry with exactly the same values is replaced.
+	RestoreEntry(ctx context.Context, entry interface{}) error
+
+	// StreamTableKeys selects the key of every entry in the table of the given
+	// model type, ordered by primary key, calling fn with the key's values. An
+	// entry's key is its primary key, or all of its columns if the table has no
+	// primary key, so keys are unique to entries in either case.
+	StreamTableKeys(ctx context.Context, model interface{}, fn func(key []interface{}) error) error
+
+	// DeleteEntryByKey deletes the entry in the table of the
+	// given model type with the given key, as passed to fn by
+	// StreamTableKeys. No error is returned if there's no entry.
+	DeleteEntryByKey(ctx context.Context, model interface{}, key []interface{}) error
+}
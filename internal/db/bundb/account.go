@@ -483,7 +483,41 @@ func (a *accountDB) GetAccounts(
 		useAccountIDIn = true
 	}
 
-	// TODO: invitedBy
+	if invitedBy != "" {
+		// Get only accounts of users who signed
+		// up using an invite created by the user
+		// of the account with ID invitedBy.
+		if err := lazyLoadUsers(); err != nil {
+			return nil, err
+		}
+
+		var inviterID string
+		for _, user := range users {
+			if user.AccountID == invitedBy {
+				inviterID = user.ID
+				break
+			}
+		}
+
+		var inviteIDs []string
+		if inviterID != "" {
+			if err := a.db.
+				NewSelect().
+				Table("invites").
+				Column("id").
+				Where("? = ?", bun.Ident("user_id"), inviterID).
+				Scan(ctx, &inviteIDs); err != nil {
+				return nil, fmt.Errorf("error getting invites: %w", err)
+			}
+		}
+
+		for _, user := range users {
+			if user.InviteID != "" && slices.Contains(inviteIDs, user.InviteID) {
+				accountIDIn = append(accountIDIn, user.AccountID)
+			}
+		}
+		useAccountIDIn = true
+	}
 
 	if username != "" {
 		q = q.Where("? = ?", bun.Ident("account.username"), username)
@@ -0,0 +1,153 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type accountArchiveDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *accountArchiveDB) GetAccountArchiveByID(ctx context.Context, id string) (*gtsmodel.AccountArchive, error) {
+	archive := new(gtsmodel.AccountArchive)
+
+	if err := a.db.
+		NewSelect().
+		Model(archive).
+		Where("? = ?", bun.Ident("account_archive.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := a.populateAccountArchive(ctx, archive); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (a *accountArchiveDB) GetAccountArchivesByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.AccountArchive, error) {
+	var archives []*gtsmodel.AccountArchive
+
+	if err := a.db.
+		NewSelect().
+		Model(&archives).
+		Where("? = ?", bun.Ident("account_archive.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("account_archive.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return a.populateAccountArchives(ctx, archives)
+}
+
+func (a *accountArchiveDB) GetAccountArchivesOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.AccountArchive, error) {
+	var archives []*gtsmodel.AccountArchive
+
+	q := a.db.
+		NewSelect().
+		Model(&archives).
+		Where("? < ?", bun.Ident("account_archive.created_at"), olderThan).
+		OrderExpr("? ASC", bun.Ident("account_archive.id"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(archives) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	return a.populateAccountArchives(ctx, archives)
+}
+
+func (a *accountArchiveDB) populateAccountArchives(ctx context.Context, archives []*gtsmodel.AccountArchive) ([]*gtsmodel.AccountArchive, error) {
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return archives, nil
+	}
+
+	for _, archive := range archives {
+		if err := a.populateAccountArchive(ctx, archive); err != nil {
+			return nil, err
+		}
+	}
+
+	return archives, nil
+}
+
+func (a *accountArchiveDB) populateAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	var err error
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return nil
+	}
+
+	if archive.Account == nil {
+		// Archived account is not set, fetch from database.
+		archive.Account, err = a.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			archive.AccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating account archive account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (a *accountArchiveDB) PutAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	_, err := a.db.NewInsert().
+		Model(archive).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountArchiveDB) UpdateAccountArchive(ctx context.Context, archive *gtsmodel.AccountArchive, columns ...string) error {
+	_, err := a.db.NewUpdate().
+		Model(archive).
+		Column(columns...).
+		Where("? = ?", bun.Ident("account_archive.id"), archive.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *accountArchiveDB) DeleteAccountArchiveByID(ctx context.Context, id string) error {
+	_, err := a.db.NewDelete().
+		Table("account_archives").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
@@ -219,6 +219,7 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		UnconfirmedEmail:       newSignup.Email,
 		CreatedByApplicationID: newSignup.AppID,
 		ExternalID:             newSignup.ExternalID,
+		InviteID:               newSignup.InviteID,
 	}
 
 	if newSignup.EmailVerified {
@@ -455,6 +456,7 @@ func (a *adminDB) GetAdminAction(ctx context.Context, id string) (*gtsmodel.Admi
 	if err := a.db.
 		NewSelect().
 		Model(action).
+		Where("? = ?", bun.Ident("admin_action.id"), id).
 		Scan(ctx); err != nil {
 		return nil, err
 	}
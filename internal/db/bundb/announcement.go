@@ -0,0 +1,414 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/util/xslices"
+	"github.com/uptrace/bun"
+)
+
+type announcementDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *announcementDB) GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error) {
+	// Fetch announcement from database cache with loader callback.
+	announcement, err := a.state.Caches.DB.Announcement.LoadOne("ID", func() (*gtsmodel.Announcement, error) {
+		var announcement gtsmodel.Announcement
+
+		// Not cached! Perform database query.
+		if err := a.db.
+			NewSelect().
+			Model(&announcement).
+			Where("? = ?", bun.Ident("announcement.id"), id).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return &announcement, nil
+	}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return announcement, nil
+	}
+
+	// Further populate the announcement fields where applicable.
+	if err := a.PopulateAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+
+	return announcement, nil
+}
+
+func (a *announcementDB) GetAnnouncementsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Announcement, error) {
+	// Load all input announcement IDs via cache loader callback.
+	announcements, err := a.state.Caches.DB.Announcement.LoadIDs("ID",
+		ids,
+		func(uncached []string) ([]*gtsmodel.Announcement, error) {
+			// Preallocate expected length of uncached announcements.
+			announcements := make([]*gtsmodel.Announcement, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := a.db.NewSelect().
+				Model(&announcements).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return announcements, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the announcements by their
+	// IDs to ensure in correct order.
+	getID := func(a *gtsmodel.Announcement) string { return a.ID }
+	xslices.OrderBy(announcements, ids, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return announcements, nil
+	}
+
+	// Populate all loaded announcements, removing those we
+	// fail to populate (removes needing so many nil checks everywhere).
+	announcements = slices.DeleteFunc(announcements, func(announcement *gtsmodel.Announcement) bool {
+		if err := a.PopulateAnnouncement(ctx, announcement); err != nil {
+			log.Errorf(ctx, "error populating announcement %s: %v", announcement.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return announcements, nil
+}
+
+func (a *announcementDB) GetAnnouncements(ctx context.Context, page *paging.Page) ([]*gtsmodel.Announcement, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		announcementIDs = make([]string, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		Table("announcements").
+		Column("id")
+
+	// Return only items with id
+	// lower than provided maxID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("id"), maxID)
+	}
+
+	// Return only items with id
+	// greater than provided minID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("id"), minID)
+	}
+
+	if limit > 0 {
+		// Limit amount of
+		// items returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("id"))
+	}
+
+	if err := q.Scan(ctx, &announcementIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(announcementIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want items
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(announcementIDs)
+	}
+
+	return a.GetAnnouncementsByIDs(ctx, announcementIDs)
+}
+
+func (a *announcementDB) GetActiveAnnouncements(ctx context.Context, now time.Time) ([]*gtsmodel.Announcement, error) {
+	var announcementIDs []string
+
+	// Select IDs of all published announcements
+	// that have started and not yet ended.
+	if err := a.db.
+		NewSelect().
+		Table("announcements").
+		Column("id").
+		Where("? = ?", bun.Ident("published"), true).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("starts_at")).
+				WhereOr("? <= ?", bun.Ident("starts_at"), now)
+		}).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("ends_at")).
+				WhereOr("? > ?", bun.Ident("ends_at"), now)
+		}).
+		OrderExpr("? ASC", bun.Ident("id")).
+		Scan(ctx, &announcementIDs); err != nil {
+		return nil, err
+	}
+
+	return a.GetAnnouncementsByIDs(ctx, announcementIDs)
+}
+
+func (a *announcementDB) PopulateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(2)
+	)
+
+	if !announcement.TagsPopulated() {
+		// Announcement tags are out-of-date with IDs, repopulate.
+		announcement.Tags, err = a.state.DB.GetTags(ctx, announcement.TagIDs)
+		if err != nil {
+			errs.Appendf("error populating announcement tags: %w", err)
+		}
+	}
+
+	if !announcement.EmojisPopulated() {
+		// Announcement emojis are out-of-date with IDs, repopulate.
+		announcement.Emojis, err = a.state.DB.GetEmojisByIDs(
+			gtscontext.SetBarebones(ctx),
+			announcement.EmojiIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating announcement emojis: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (a *announcementDB) PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	return a.state.Caches.DB.Announcement.Store(announcement, func() error {
+		_, err := a.db.NewInsert().
+			Model(announcement).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (a *announcementDB) UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error {
+	announcement.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return a.state.Caches.DB.Announcement.Store(announcement, func() error {
+		_, err := a.db.NewUpdate().
+			Model(announcement).
+			Column(columns...).
+			Where("? = ?", bun.Ident("id"), announcement.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (a *announcementDB) DeleteAnnouncementByID(ctx context.Context, id string) error {
+	if err := a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete any reads + reactions
+		// targeting this announcement.
+		for _, table := range []string{
+			"announcement_reads",
+			"announcement_reactions",
+		} {
+			if _, err := tx.NewDelete().
+				Table(table).
+				Where("? = ?", bun.Ident("announcement_id"), id).
+				Exec(ctx); err != nil {
+				return gtserror.Newf("error deleting from %s: %w", table, err)
+			}
+		}
+
+		// Delete the announcement itself.
+		if _, err := tx.NewDelete().
+			Table("announcements").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx); err != nil &&
+			!errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Invalidate cached announcement by its ID.
+	a.state.Caches.DB.Announcement.Invalidate("ID", id)
+
+	return nil
+}
+
+func (a *announcementDB) IsAnnouncementRead(ctx context.Context, announcementID string, accountID string) (bool, error) {
+	q := a.db.
+		NewSelect().
+		Table("announcement_reads").
+		Column("id").
+		Where("? = ?", bun.Ident("announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	return exists(ctx, q)
+}
+
+func (a *announcementDB) PutAnnouncementRead(ctx context.Context, read *gtsmodel.AnnouncementRead) error {
+	_, err := a.db.NewInsert().
+		Model(read).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("announcement_id"), bun.Ident("account_id")).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) GetAnnouncementReaction(
+	ctx context.Context,
+	announcementID string,
+	accountID string,
+	name string,
+) (*gtsmodel.AnnouncementReaction, error) {
+	var reaction gtsmodel.AnnouncementReaction
+
+	if err := a.db.
+		NewSelect().
+		Model(&reaction).
+		Where("? = ?", bun.Ident("announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? = ?", bun.Ident("name"), name).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &reaction, nil
+}
+
+func (a *announcementDB) GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error) {
+	var reactions []*gtsmodel.AnnouncementReaction
+
+	if err := a.db.
+		NewSelect().
+		Model(&reactions).
+		Where("? = ?", bun.Ident("announcement_id"), announcementID).
+		OrderExpr("? ASC", bun.Ident("id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return reactions, nil
+	}
+
+	// Populate custom emojis of reactions,
+	// removing those we fail to populate.
+	reactions = slices.DeleteFunc(reactions, func(reaction *gtsmodel.AnnouncementReaction) bool {
+		if reaction.EmojiID == "" || reaction.Emoji != nil {
+			// Nothing to populate.
+			return false
+		}
+
+		var err error
+		reaction.Emoji, err = a.state.DB.GetEmojiByID(
+			gtscontext.SetBarebones(ctx),
+			reaction.EmojiID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error populating announcement reaction %s: %v", reaction.ID, err)
+			return true
+		}
+
+		return false
+	})
+
+	return reactions, nil
+}
+
+func (a *announcementDB) PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error {
+	_, err := a.db.NewInsert().
+		Model(reaction).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementReactionByID(ctx context.Context, id string) error {
+	_, err := a.db.NewDelete().
+		Table("announcement_reactions").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementReadsAndReactionsByAccountID(ctx context.Context, accountID string) error {
+	return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, table := range []string{
+			"announcement_reads",
+			"announcement_reactions",
+		} {
+			if _, err := tx.NewDelete().
+				Table(table).
+				Where("? = ?", bun.Ident("account_id"), accountID).
+				Exec(ctx); err != nil {
+				return gtserror.Newf("error deleting from %s: %w", table, err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,145 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"github.com/stretchr/testify/suite"
+)
+
+type AnnouncementTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *AnnouncementTestSuite) TestGetAnnouncements() {
+	announcements, err := suite.db.GetAnnouncements(
+		context.Background(),
+		&paging.Page{Limit: 10},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should get all, newest first.
+	suite.Len(announcements, 3)
+	suite.Equal(suite.testAnnouncements["draft_announcement"].ID, announcements[0].ID)
+	suite.Equal(suite.testAnnouncements["ended_announcement"].ID, announcements[2].ID)
+}
+
+func (suite *AnnouncementTestSuite) TestGetActiveAnnouncements() {
+	now, _ := time.Parse(time.RFC3339, "2025-04-27T10:00:00Z")
+
+	announcements, err := suite.db.GetActiveAnnouncements(context.Background(), now)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only the published, started, not-ended announcement.
+	suite.Len(announcements, 1)
+	suite.Equal(suite.testAnnouncements["active_announcement"].ID, announcements[0].ID)
+
+	// Nothing had started two days before.
+	announcements, err = suite.db.GetActiveAnnouncements(context.Background(), now.Add(-48*time.Hour))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(announcements)
+}
+
+func (suite *AnnouncementTestSuite) TestAnnouncementReadsAndReactions() {
+	var (
+		ctx          = context.Background()
+		announcement = suite.testAnnouncements["active_announcement"]
+		account      = suite.testAccounts["local_account_1"]
+		emoji        = suite.testEmojis["rainbow"]
+	)
+
+	read, err := suite.db.IsAnnouncementRead(ctx, announcement.ID, account.ID)
+	suite.NoError(err)
+	suite.False(read)
+
+	// Mark read twice, second time should no-op.
+	for range 2 {
+		if err := suite.db.PutAnnouncementRead(ctx, &gtsmodel.AnnouncementRead{
+			ID:             id.NewULID(),
+			AnnouncementID: announcement.ID,
+			AccountID:      account.ID,
+		}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	read, err = suite.db.IsAnnouncementRead(ctx, announcement.ID, account.ID)
+	suite.NoError(err)
+	suite.True(read)
+
+	if err := suite.db.PutAnnouncementReaction(ctx, &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      account.ID,
+		Name:           emoji.Shortcode,
+		EmojiID:        emoji.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	reactions, err := suite.db.GetAnnouncementReactions(ctx, announcement.ID)
+	suite.NoError(err)
+	suite.Len(reactions, 1)
+	suite.NotNil(reactions[0].Emoji)
+	suite.Equal(emoji.ID, reactions[0].Emoji.ID)
+
+	reaction, err := suite.db.GetAnnouncementReaction(ctx, announcement.ID, account.ID, emoji.Shortcode)
+	suite.NoError(err)
+	suite.Equal(reactions[0].ID, reaction.ID)
+
+	// Wipe everything for the account.
+	if err := suite.db.DeleteAnnouncementReadsAndReactionsByAccountID(ctx, account.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	read, err = suite.db.IsAnnouncementRead(ctx, announcement.ID, account.ID)
+	suite.NoError(err)
+	suite.False(read)
+
+	_, err = suite.db.GetAnnouncementReaction(ctx, announcement.ID, account.ID, emoji.Shortcode)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AnnouncementTestSuite) TestDeleteAnnouncementByID() {
+	ctx := context.Background()
+	announcement := suite.testAnnouncements["active_announcement"]
+
+	if err := suite.db.DeleteAnnouncementByID(ctx, announcement.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetAnnouncementByID(ctx, announcement.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestAnnouncementTestSuite(t *testing.T) {
+	suite.Run(t, new(AnnouncementTestSuite))
+}
@@ -176,6 +176,18 @@ func (a *applicationDB) PutApplication(ctx context.Context, app *gtsmodel.Applic
 	})
 }
 
+func (a *applicationDB) UpdateApplication(ctx context.Context, app *gtsmodel.Application, columns ...string) error {
+	return a.state.Caches.DB.Application.Store(app, func() error {
+		_, err := a.db.
+			NewUpdate().
+			Model(app).
+			Column(columns...).
+			Where("? = ?", bun.Ident("id"), app.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
 // DeleteApplicationByID deletes application with the given ID.
 //
 // The function does not delete tokens owned by the application
@@ -499,3 +511,25 @@ func (a *applicationDB) DeleteTokensByClientID(ctx context.Context, clientID str
 
 	return nil
 }
+
+func (a *applicationDB) DeleteTokensByUserID(ctx context.Context, userID string) error {
+	var tokens []*gtsmodel.Token
+
+	// Delete tokens owned by
+	// userID and gather token IDs.
+	if _, err := a.db.NewDelete().
+		Model(&tokens).
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Returning("?", bun.Ident("id")).
+		Exec(ctx); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate all deleted tokens.
+	for _, token := range tokens {
+		a.state.Caches.DB.Token.Invalidate("ID", token.ID)
+		a.state.Caches.OnInvalidateToken(token)
+	}
+
+	return nil
+}
@@ -116,6 +116,28 @@ func (suite *ApplicationTestSuite) TestDeleteApplicationBy() {
 	}
 }
 
+func (suite *ApplicationTestSuite) TestUpdateApplication() {
+	ctx := context.Background()
+
+	app, err := suite.db.GetApplicationByID(ctx, suite.testApplications["application_1"].ID)
+	suite.NoError(err)
+
+	app.Scopes = "read"
+	app.RedirectURIs = []string{"https://example.org/callback"}
+	err = suite.db.UpdateApplication(ctx, app, "scopes", "redirect_uris")
+	suite.NoError(err)
+
+	// Clear database caches
+	// to ensure we reload.
+	suite.state.Caches.Init()
+
+	updated, err := suite.db.GetApplicationByID(ctx, app.ID)
+	suite.NoError(err)
+	suite.Equal("read", updated.Scopes)
+	suite.Equal([]string{"https://example.org/callback"}, updated.RedirectURIs)
+	suite.Equal(app.ClientSecret, updated.ClientSecret)
+}
+
 func (suite *ApplicationTestSuite) TestGetAllTokens() {
 	tokens, err := suite.db.GetAllTokens(context.Background())
 	if err != nil {
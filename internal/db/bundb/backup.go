@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// timeType is the reflect.Type of time.Time,
+// for finding timestamp columns in a table.
+var timeType = reflect.TypeOf(time.Time{})
+
+type backupDB struct {
+	db *bun.DB
+}
+
+func (b *backupDB) StreamTable(ctx context.Context, model interface{}, since time.Time, fn func(interface{}) error) error {
+	typ := reflect.TypeOf(model).Elem()
+	table := b.db.Dialect().Tables().Get(typ)
+
+	q := b.db.
+		NewSelect().
+		Model(model)
+
+	if !since.IsZero() {
+		// Select only entries with any timestamp
+		// (created, updated, edited, etc) since.
+		var cols []bun.Ident
+		for _, f := range table.Fields {
+			if f.IndirectType == timeType {
+				cols = append(cols, bun.Ident(f.Name))
+			}
+		}
+
+		if len(cols) > 0 {
+			q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				for _, col := range cols {
+					q = q.WhereOr("? >= ?", col, since)
+				}
+				return q
+			})
+		}
+	}
+
+	// Order by primary key(s) so output
+	// is stable between repeat streams.
+	for _, pk := range table.PKs {
+		q = q.OrderExpr("? ASC", bun.Ident(pk.Name))
+	}
+
+	rows, err := q.Rows(ctx)
+	if err != nil {
+		return gtserror.Newf("error selecting %s: %w", table.Name, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		// Scan the next row into
+		// a new model of given type.
+		entry := reflect.New(typ).Interface()
+		if err := b.db.ScanRow(ctx, rows, entry); err != nil {
+			return gtserror.Newf("error scanning %s: %w", table.Name, err)
+		}
+
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (b *backupDB) RestoreEntry(ctx context.Context, entry interface{}) error {
+	typ := reflect.TypeOf(entry).Elem()
+	table := b.db.Dialect().Tables().Get(typ)
+
+	if len(table.PKs) == 0 {
+		// No primary key to detect conflicts on,
+		// so drop any identical entry beforehand.
+		return b.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			q := tx.NewDelete().Model(entry)
+			for _, f := range table.Fields {
+				q = q.Where("? = ?", bun.Ident(f.Name), f.Value(reflect.ValueOf(entry).Elem()).Interface())
+			}
+
+			if _, err := q.Exec(ctx); err != nil {
+				return err
+			}
+
+			_, err := tx.NewInsert().Model(entry).Exec(ctx)
+			return err
+		})
+	}
+
+	q := b.db.
+		NewInsert().
+		Model(entry)
+
+	if len(table.DataFields) == 0 {
+		// Nothing to update
+		// on primary key conflict.
+		q = q.Ignore()
+	} else {
+		// Update all data columns on conflict.
+		pks := make([]string, len(table.PKs))
+		for i, pk := range table.PKs {
+			pks[i] = string(pk.SQLName)
+		}
+		q = q.On("CONFLICT (" + strings.Join(pks, ", ") + ") DO UPDATE")
+	}
+
+	_, err := q.Exec(ctx)
+	return err
+}
+
+func (b *backupDB) StreamTableKeys(ctx context.Context, model interface{}, fn func(key []interface{}) error) error {
+	typ := reflect.TypeOf(model).Elem()
+	table := b.db.Dialect().Tables().Get(typ)
+	fields := tableKeyFields(table)
+
+	cols := make([]string, len(fields))
+	for i, f := range fields {
+		cols[i] = f.Name
+	}
+
+	q := b.db.
+		NewSelect().
+		Model(model).
+		Column(cols...)
+
+	// Order by primary key(s) so output
+	// is stable between repeat streams.
+	for _, pk := range table.PKs {
+		q = q.OrderExpr("? ASC", bun.Ident(pk.Name))
+	}
+
+	rows, err := q.Rows(ctx)
+	if err != nil {
+		return gtserror.Newf("error selecting %s: %w", table.Name, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		// Scan key columns of the next
+		// row into new model of given type.
+		entry := reflect.New(typ)
+		if err := b.db.ScanRow(ctx, rows, entry.Interface()); err != nil {
+			return gtserror.Newf("error scanning %s: %w", table.Name, err)
+		}
+
+		key := make([]interface{}, len(fields))
+		for i, f := range fields {
+			key[i] = f.Value(entry.Elem()).Interface()
+		}
+
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (b *backupDB) DeleteEntryByKey(ctx context.Context, model interface{}, key []interface{}) error {
+	typ := reflect.TypeOf(model).Elem()
+	table := b.db.Dialect().Tables().Get(typ)
+	fields := tableKeyFields(table)
+
+	if len(key) != len(fields) {
+		return gtserror.Newf("expected %d %s key values, got %d", len(fields), table.Name, len(key))
+	}
+
+	q := b.db.
+		NewDelete().
+		Model(model)
+
+	for i, f := range fields {
+		q = q.Where("? = ?", bun.Ident(f.Name), key[i])
+	}
+
+	_, err := q.Exec(ctx)
+	return err
+}
+
+// tableKeyFields returns the fields that uniquely identify
+// an entry in the given table: its primary key(s) if any,
+// else all of its fields, as for tables of join entries.
+func tableKeyFields(table *schema.Table) []*schema.Field {
+	if len(table.PKs) > 0 {
+		return table.PKs
+	}
+	return table.Fields
+}
@@ -54,16 +54,23 @@
 // DBService satisfies the DB interface
 type DBService struct {
 	db.Account
+	db.AccountArchive
 	db.Admin
 	db.AdvancedMigration
+	db.Announcement
 	db.Application
+	db.Backup
 	db.Basic
 	db.Conversation
+	db.Delivery
 	db.Domain
 	db.Emoji
+	db.ExternalIdentity
+	db.Group
 	db.HeaderFilter
 	db.Instance
 	db.Interaction
+	db.Invite
 	db.Filter
 	db.List
 	db.Marker
@@ -71,22 +78,30 @@ type DBService struct {
 	db.Mention
 	db.Move
 	db.Notification
+	db.OutboxImport
 	db.Poll
+	db.PreviewCard
 	db.Relationship
+	db.Relay
 	db.Report
 	db.Rule
 	db.Search
+	db.ScheduledStatus
 	db.Session
 	db.SinBinStatus
 	db.Status
 	db.StatusBookmark
 	db.StatusEdit
 	db.StatusFave
+	db.StatusReaction
+	db.Suggestion
 	db.Tag
 	db.Thread
 	db.Timeline
+	db.Trend
 	db.User
 	db.Tombstone
+	db.WebAuthn
 	db.WebPush
 	db.WorkerTask
 	db *bun.DB
@@ -171,6 +186,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		AccountArchive: &accountArchiveDB{
+			db:    db,
+			state: state,
+		},
 		Admin: &adminDB{
 			db:    db,
 			state: state,
@@ -179,10 +198,17 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Announcement: &announcementDB{
+			db:    db,
+			state: state,
+		},
 		Application: &applicationDB{
 			db:    db,
 			state: state,
 		},
+		Backup: &backupDB{
+			db: db,
+		},
 		Basic: &basicDB{
 			db: db,
 		},
@@ -190,6 +216,9 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Delivery: &deliveryDB{
+			db: db,
+		},
 		Domain: &domainDB{
 			db:    db,
 			state: state,
@@ -198,6 +227,14 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		ExternalIdentity: &externalIdentityDB{
+			db:    db,
+			state: state,
+		},
+		Group: &groupDB{
+			db:    db,
+			state: state,
+		},
 		HeaderFilter: &headerFilterDB{
 			db:    db,
 			state: state,
@@ -210,6 +247,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Invite: &inviteDB{
+			db:    db,
+			state: state,
+		},
 		Filter: &filterDB{
 			db:    db,
 			state: state,
@@ -238,14 +279,26 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		OutboxImport: &outboxImportDB{
+			db:    db,
+			state: state,
+		},
 		Poll: &pollDB{
 			db:    db,
 			state: state,
 		},
+		PreviewCard: &previewCardDB{
+			db:    db,
+			state: state,
+		},
 		Relationship: &relationshipDB{
 			db:    db,
 			state: state,
 		},
+		Relay: &relayDB{
+			db:    db,
+			state: state,
+		},
 		Report: &reportDB{
 			db:    db,
 			state: state,
@@ -257,6 +310,11 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Search: &searchDB{
 			db:    db,
 			state: state,
+			index: newSearchIndex(db),
+		},
+		ScheduledStatus: &scheduledStatusDB{
+			db:    db,
+			state: state,
 		},
 		Session: &sessionDB{
 			db: db,
@@ -281,6 +339,14 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StatusReaction: &statusReactionDB{
+			db:    db,
+			state: state,
+		},
+		Suggestion: &suggestionDB{
+			db:    db,
+			state: state,
+		},
 		Tag: &tagDB{
 			db:    db,
 			state: state,
@@ -293,6 +359,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Trend: &trendDB{
+			db:    db,
+			state: state,
+		},
 		User: &userDB{
 			db:    db,
 			state: state,
@@ -301,6 +371,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		WebAuthn: &webAuthnDB{
+			db:    db,
+			state: state,
+		},
 		WebPush: &webPushDB{
 			db:    db,
 			state: state,
@@ -57,6 +57,8 @@ type BunDBStandardTestSuite struct {
 	testPollVotes           map[string]*gtsmodel.PollVote
 	testInteractionRequests map[string]*gtsmodel.InteractionRequest
 	testStatusEdits         map[string]*gtsmodel.StatusEdit
+	testAnnouncements       map[string]*gtsmodel.Announcement
+	testRelays              map[string]*gtsmodel.Relay
 }
 
 func (suite *BunDBStandardTestSuite) SetupSuite() {
@@ -83,6 +85,8 @@ func (suite *BunDBStandardTestSuite) SetupSuite() {
 	suite.testPollVotes = testrig.NewTestPollVotes()
 	suite.testInteractionRequests = testrig.NewTestInteractionRequests()
 	suite.testStatusEdits = testrig.NewTestStatusEdits()
+	suite.testAnnouncements = testrig.NewTestAnnouncements()
+	suite.testRelays = testrig.NewTestRelays()
 }
 
 func (suite *BunDBStandardTestSuite) SetupTest() {
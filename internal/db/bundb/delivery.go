@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type deliveryDB struct{ db *bun.DB }
+
+func (d *deliveryDB) GetQueuedDeliveries(ctx context.Context, maxID string) ([]*gtsmodel.QueuedDelivery, error) {
+	var deliveries []*gtsmodel.QueuedDelivery
+	if err := d.db.NewSelect().
+		Model(&deliveries).
+		Where("? < ?", bun.Ident("id"), maxID).
+		OrderExpr("? ASC", bun.Ident("id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (d *deliveryDB) CountQueuedDeliveriesByDomain(ctx context.Context) ([]*gtsmodel.DeliveryQueueDomain, error) {
+	var counts []*gtsmodel.DeliveryQueueDomain
+	if err := d.db.NewSelect().
+		Table("queued_deliveries").
+		Column("domain").
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Group("domain").
+		OrderExpr("? DESC, ? ASC", bun.Ident("count"), bun.Ident("domain")).
+		Scan(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (d *deliveryDB) PutQueuedDeliveries(ctx context.Context, deliveries []*gtsmodel.QueuedDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	_, err := d.db.NewInsert().
+		Model(&deliveries).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) UpdateQueuedDelivery(ctx context.Context, delivery *gtsmodel.QueuedDelivery, columns ...string) error {
+	_, err := d.db.NewUpdate().
+		Model(delivery).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), delivery.ID).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) DeleteQueuedDeliveryByID(ctx context.Context, id string) error {
+	_, err := d.db.NewDelete().
+		Table("queued_deliveries").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) DeleteQueuedDeliveriesByIRI(ctx context.Context, iri string) error {
+	_, err := d.db.NewDelete().
+		Table("queued_deliveries").
+		WhereOr("? = ?", bun.Ident("actor_id"), iri).
+		WhereOr("? = ?", bun.Ident("object_id"), iri).
+		WhereOr("? = ?", bun.Ident("target_id"), iri).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeliveryTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DeliveryTestSuite) TestQueuedDeliveries() {
+	ctx := context.Background()
+
+	now := time.Now()
+	newDelivery := func(t time.Time, domain string, actorID string) *gtsmodel.QueuedDelivery {
+		return &gtsmodel.QueuedDelivery{
+			ID:      id.NewULIDFromTime(t),
+			Domain:  domain,
+			ActorID: actorID,
+			Data:    []byte(`{"method":"POST"}`),
+		}
+	}
+
+	deliveries := []*gtsmodel.QueuedDelivery{
+		newDelivery(now.Add(-3*time.Second), "example.org", "http://localhost:8080/users/the_mighty_zork"),
+		newDelivery(now.Add(-2*time.Second), "example.org", "http://localhost:8080/users/admin"),
+		newDelivery(now.Add(-1*time.Second), "fossbros-anonymous.io", "http://localhost:8080/users/the_mighty_zork"),
+	}
+
+	if err := suite.db.PutQueuedDeliveries(ctx, deliveries); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only deliveries before max ID should be returned.
+	maxID := id.NewULIDFromTime(now)
+	later := newDelivery(now.Add(time.Second), "example.org", "http://localhost:8080/users/admin")
+	if err := suite.db.PutQueuedDeliveries(ctx, []*gtsmodel.QueuedDelivery{later}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	queued, err := suite.db.GetQueuedDeliveries(ctx, maxID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(queued, 3)
+	for i, q := range queued {
+		suite.Equal(deliveries[i].ID, q.ID)
+	}
+
+	// Update attempt state of a delivery.
+	next := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := suite.db.UpdateQueuedDelivery(ctx, &gtsmodel.QueuedDelivery{
+		ID:            deliveries[0].ID,
+		Attempts:      3,
+		NextAttemptAt: next,
+	}, "attempts", "next_attempt_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	queued, err = suite.db.GetQueuedDeliveries(ctx, maxID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.EqualValues(3, queued[0].Attempts)
+	suite.True(next.Equal(queued[0].NextAttemptAt))
+	suite.Equal(deliveries[0].Data, queued[0].Data)
+
+	// Check counts by domain.
+	counts, err := suite.db.CountQueuedDeliveriesByDomain(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]*gtsmodel.DeliveryQueueDomain{
+		{Domain: "example.org", Count: 3},
+		{Domain: "fossbros-anonymous.io", Count: 1},
+	}, counts)
+
+	// Delete by ID, then by actor IRI.
+	if err := suite.db.DeleteQueuedDeliveryByID(ctx, later.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := suite.db.DeleteQueuedDeliveriesByIRI(ctx, "http://localhost:8080/users/the_mighty_zork"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	queued, err = suite.db.GetQueuedDeliveries(ctx, id.Highest)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(queued, 1)
+	suite.Equal(deliveries[1].ID, queued[0].ID)
+}
+
+func TestDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryTestSuite))
+}
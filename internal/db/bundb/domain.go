@@ -20,6 +20,7 @@
 import (
 	"context"
 	"net/url"
+	"strings"
 	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/config"
@@ -179,8 +180,9 @@ func (d *domainDB) PutDomainBlock(ctx context.Context, block *gtsmodel.DomainBlo
 		return err
 	}
 
-	// Clear the domain block cache (for later reload)
+	// Clear the domain block caches (for later reload)
 	d.state.Caches.DB.DomainBlock.Clear()
+	d.state.Caches.DB.DomainLimit.Clear()
 
 	return nil
 }
@@ -265,8 +267,9 @@ func (d *domainDB) UpdateDomainBlock(ctx context.Context, block *gtsmodel.Domain
 		return err
 	}
 
-	// Clear the domain block cache (for later reload)
+	// Clear the domain block caches (for later reload)
 	d.state.Caches.DB.DomainBlock.Clear()
+	d.state.Caches.DB.DomainLimit.Clear()
 
 	return nil
 }
@@ -286,8 +289,9 @@ func (d *domainDB) DeleteDomainBlock(ctx context.Context, domain string) error {
 		return err
 	}
 
-	// Clear the domain block cache (for later reload)
+	// Clear the domain block caches (for later reload)
 	d.state.Caches.DB.DomainBlock.Clear()
+	d.state.Caches.DB.DomainLimit.Clear()
 
 	return nil
 }
@@ -327,10 +331,12 @@ func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, er
 	explicitBlock, err := d.state.Caches.DB.DomainBlock.Matches(domain, func() ([]string, error) {
 		var domains []string
 
-		// Scan list of all blocked domains from DB
+		// Scan list of all blocked domains from DB,
+		// excluding those that are only limited.
 		q := d.db.NewSelect().
 			Table("domain_blocks").
-			Column("domain")
+			Column("domain").
+			Where("? = ?", bun.Ident("severity"), gtsmodel.DomainBlockSeveritySuspend)
 		if err := q.Scan(ctx, &domains); err != nil {
 			return nil, err
 		}
@@ -368,6 +374,126 @@ func (d *domainDB) IsDomainBlocked(ctx context.Context, domain string) (bool, er
 	}
 }
 
+func (d *domainDB) IsDomainLimited(ctx context.Context, domain string) (bool, error) {
+	// Normalize domain as punycode for lookup.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, gtserror.Newf("error punifying domain %s: %w", domain, err)
+	}
+
+	// Domain referencing *us* cannot be limited.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return false, nil
+	}
+
+	// Check the cache for a domain limit (hydrating the cache with callback if necessary)
+	explicitLimit, err := d.state.Caches.DB.DomainLimit.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all limited domains from DB
+		q := d.db.NewSelect().
+			Table("domain_blocks").
+			Column("domain").
+			Where("? = ?", bun.Ident("severity"), gtsmodel.DomainBlockSeverityLimit)
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if !explicitLimit {
+		// Nothing
+		// to do.
+		return false, nil
+	}
+
+	if config.GetInstanceFederationMode() == config.InstanceFederationModeAllowlist {
+		// Allowlist mode: explicit allows are
+		// needed to federate at all, so they
+		// can't take precedence over a limit.
+		return true, nil
+	}
+
+	// Blocklist mode: explicit allow
+	// takes precedence over explicit limit.
+	explicitAllow, err := d.state.Caches.DB.DomainAllow.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all explicitly allowed domains from DB
+		q := d.db.NewSelect().
+			Table("domain_allows").
+			Column("domain")
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return !explicitAllow, nil
+}
+
+func (d *domainDB) GetDomainLimit(ctx context.Context, domain string) (*gtsmodel.DomainBlock, error) {
+	limited, err := d.IsDomainLimited(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if !limited {
+		return nil, db.ErrNoEntries
+	}
+
+	// Normalize domain as punycode for lookup.
+	domain, err = util.Punify(domain)
+	if err != nil {
+		return nil, gtserror.Newf("error punifying domain %s: %w", domain, err)
+	}
+
+	// Gather the domain itself and each of its
+	// parents, as a limit on "example.org" also
+	// applies to eg., "social.example.org".
+	domains := []string{domain}
+	for i := strings.IndexByte(domain, '.'); i != -1; {
+		domain = domain[i+1:]
+		domains = append(domains, domain)
+		i = strings.IndexByte(domain, '.')
+	}
+
+	var blocks []*gtsmodel.DomainBlock
+
+	// Look for limits matching any of the domains.
+	if err := d.db.
+		NewSelect().
+		Model(&blocks).
+		Where("? IN (?)", bun.Ident("domain_block.domain"), bun.In(domains)).
+		Where("? = ?", bun.Ident("domain_block.severity"), gtsmodel.DomainBlockSeverityLimit).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(blocks) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Return the most specific (ie., longest) match.
+	block := blocks[0]
+	for _, b := range blocks[1:] {
+		if len(b.Domain) > len(block.Domain) {
+			block = b
+		}
+	}
+
+	return block, nil
+}
+
 func (d *domainDB) AreDomainsBlocked(ctx context.Context, domains []string) (bool, error) {
 	for _, domain := range domains {
 		if blocked, err := d.IsDomainBlocked(ctx, domain); err != nil {
@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type externalIdentityDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (e *externalIdentityDB) GetExternalIdentity(ctx context.Context, provider string, subject string) (*gtsmodel.ExternalIdentity, error) {
+	identity := new(gtsmodel.ExternalIdentity)
+
+	if err := e.db.
+		NewSelect().
+		Model(identity).
+		Where("? = ?", bun.Ident("external_identity.provider"), provider).
+		Where("? = ?", bun.Ident("external_identity.subject"), subject).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func (e *externalIdentityDB) GetExternalIdentitiesBySessionID(ctx context.Context, provider string, sessionID string) ([]*gtsmodel.ExternalIdentity, error) {
+	var identities []*gtsmodel.ExternalIdentity
+
+	if err := e.db.
+		NewSelect().
+		Model(&identities).
+		Where("? = ?", bun.Ident("external_identity.provider"), provider).
+		Where("? = ?", bun.Ident("external_identity.session_id"), sessionID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+func (e *externalIdentityDB) GetExternalIdentitiesByUserID(ctx context.Context, userID string) ([]*gtsmodel.ExternalIdentity, error) {
+	var identities []*gtsmodel.ExternalIdentity
+
+	if err := e.db.
+		NewSelect().
+		Model(&identities).
+		Where("? = ?", bun.Ident("external_identity.user_id"), userID).
+		OrderExpr("? ASC", bun.Ident("external_identity.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+func (e *externalIdentityDB) GetAllExternalIdentities(ctx context.Context) ([]*gtsmodel.ExternalIdentity, error) {
+	var identities []*gtsmodel.ExternalIdentity
+
+	if err := e.db.
+		NewSelect().
+		Model(&identities).
+		OrderExpr("? ASC", bun.Ident("external_identity.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
+
+func (e *externalIdentityDB) PutExternalIdentity(ctx context.Context, identity *gtsmodel.ExternalIdentity) error {
+	_, err := e.db.NewInsert().
+		Model(identity).
+		Exec(ctx)
+	return err
+}
+
+func (e *externalIdentityDB) UpdateExternalIdentity(ctx context.Context, identity *gtsmodel.ExternalIdentity, columns ...string) error {
+	identity.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := e.db.NewUpdate().
+		Model(identity).
+		Column(columns...).
+		Where("? = ?", bun.Ident("external_identity.id"), identity.ID).
+		Exec(ctx)
+	return err
+}
+
+func (e *externalIdentityDB) DeleteExternalIdentitiesByUserID(ctx context.Context, userID string) error {
+	_, err := e.db.NewDelete().
+		Table("external_identities").
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Exec(ctx)
+	return err
+}
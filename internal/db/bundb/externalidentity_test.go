@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExternalIdentityTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ExternalIdentityTestSuite) TestPutGetUpdateDelete() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	identity := &gtsmodel.ExternalIdentity{
+		ID:        "01JXVQ0DZ3RN0V0QKQ2M6YJ3TZ",
+		UserID:    user.ID,
+		Provider:  "staff",
+		Subject:   "248289761001",
+		Email:     user.Email,
+		Groups:    []string{"gts-admins"},
+		SessionID: "some-session-id",
+	}
+	if err := suite.db.PutExternalIdentity(ctx, identity); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Same subject at the same
+	// provider can't be linked twice.
+	dupe := *identity
+	dupe.ID = "01JXVQ0DZ3RN0V0QKQ2M6YJ3V0"
+	suite.Error(suite.db.PutExternalIdentity(ctx, &dupe))
+
+	got, err := suite.db.GetExternalIdentity(ctx, "staff", "248289761001")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(identity.ID, got.ID)
+	suite.Equal([]string{"gts-admins"}, got.Groups)
+
+	// Subject is scoped to provider.
+	_, err = suite.db.GetExternalIdentity(ctx, "community", "248289761001")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	bySession, err := suite.db.GetExternalIdentitiesBySessionID(ctx, "staff", "some-session-id")
+	suite.NoError(err)
+	suite.Len(bySession, 1)
+
+	got.Groups = nil
+	got.SessionID = ""
+	if err := suite.db.UpdateExternalIdentity(ctx, got, "groups", "session_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	byUser, err := suite.db.GetExternalIdentitiesByUserID(ctx, user.ID)
+	suite.NoError(err)
+	if suite.Len(byUser, 1) {
+		suite.Empty(byUser[0].Groups)
+		suite.Empty(byUser[0].SessionID)
+	}
+
+	if err := suite.db.DeleteExternalIdentitiesByUserID(ctx, user.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetExternalIdentity(ctx, "staff", "248289761001")
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestExternalIdentityTestSuite(t *testing.T) {
+	suite.Run(t, new(ExternalIdentityTestSuite))
+}
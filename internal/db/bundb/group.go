@@ -0,0 +1,241 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"slices"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type groupDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (g *groupDB) NewGroup(ctx context.Context, newGroup gtsmodel.NewGroup) (*gtsmodel.Account, error) {
+	uris := uris.GenerateURIsForAccount(newGroup.Username)
+
+	privKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		err := gtserror.Newf("error creating new rsa private key: %w", err)
+		return nil, err
+	}
+
+	displayName := newGroup.DisplayName
+	if displayName == "" {
+		displayName = newGroup.Username
+	}
+
+	accountID := id.NewULID()
+	account := &gtsmodel.Account{
+		ID:                    accountID,
+		Username:              newGroup.Username,
+		DisplayName:           displayName,
+		Note:                  newGroup.Note,
+		NoteRaw:               newGroup.NoteRaw,
+		URI:                   uris.UserURI,
+		URL:                   uris.UserURL,
+		InboxURI:              uris.InboxURI,
+		OutboxURI:             uris.OutboxURI,
+		FollowingURI:          uris.FollowingURI,
+		FollowersURI:          uris.FollowersURI,
+		FeaturedCollectionURI: uris.FeaturedCollectionURI,
+		ActorType:             gtsmodel.AccountActorTypeGroup,
+		PrivateKey:            privKey,
+		PublicKey:             &privKey.PublicKey,
+		PublicKeyURI:          uris.PublicKeyURI,
+
+		// Anyone may join a group
+		// by following it, and groups
+		// are there to be discovered.
+		Locked:       util.Ptr(false),
+		Discoverable: util.Ptr(true),
+	}
+
+	// Insert the new group account.
+	if err := g.state.DB.PutAccount(ctx, account); err != nil {
+		return nil, err
+	}
+
+	// Insert basic settings for new account.
+	account.Settings = &gtsmodel.AccountSettings{
+		AccountID: accountID,
+		Privacy:   gtsmodel.VisibilityPublic,
+	}
+	if err := g.state.DB.PutAccountSettings(ctx, account.Settings); err != nil {
+		return nil, err
+	}
+
+	// Stub empty stats for new account.
+	if err := g.state.DB.StubAccountStats(ctx, account); err != nil {
+		return nil, err
+	}
+
+	// Make the creator the first group admin.
+	if err := g.PutGroupAdmin(ctx, &gtsmodel.GroupAdmin{
+		ID:             id.NewULID(),
+		GroupAccountID: account.ID,
+		GroupAccount:   account,
+		AccountID:      newGroup.Admin.ID,
+		Account:        newGroup.Admin,
+	}); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (g *groupDB) GetGroupAdmin(ctx context.Context, groupAccountID string, accountID string) (*gtsmodel.GroupAdmin, error) {
+	var groupAdmin gtsmodel.GroupAdmin
+
+	if err := g.db.
+		NewSelect().
+		Model(&groupAdmin).
+		Where("? = ?", bun.Ident("group_account_id"), groupAccountID).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return &groupAdmin, nil
+	}
+
+	// Populate the group admin model.
+	if err := g.PopulateGroupAdmin(ctx, &groupAdmin); err != nil {
+		return nil, gtserror.Newf("error(s) populating group admin: %w", err)
+	}
+
+	return &groupAdmin, nil
+}
+
+func (g *groupDB) GetGroupAdmins(ctx context.Context, groupAccountID string) ([]*gtsmodel.GroupAdmin, error) {
+	return g.getGroupAdmins(ctx, "group_account_id", groupAccountID)
+}
+
+func (g *groupDB) GetGroupAdminsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.GroupAdmin, error) {
+	return g.getGroupAdmins(ctx, "account_id", accountID)
+}
+
+func (g *groupDB) getGroupAdmins(ctx context.Context, column string, value string) ([]*gtsmodel.GroupAdmin, error) {
+	var groupAdmins []*gtsmodel.GroupAdmin
+
+	if err := g.db.
+		NewSelect().
+		Model(&groupAdmins).
+		Where("? = ?", bun.Ident(column), value).
+		OrderExpr("? ASC", bun.Ident("id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return groupAdmins, nil
+	}
+
+	// Populate all loaded group admins, removing those we
+	// fail to populate (removes needing so many nil checks).
+	groupAdmins = slices.DeleteFunc(groupAdmins, func(groupAdmin *gtsmodel.GroupAdmin) bool {
+		if err := g.PopulateGroupAdmin(ctx, groupAdmin); err != nil {
+			log.Errorf(ctx, "error populating group admin %s: %v", groupAdmin.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return groupAdmins, nil
+}
+
+func (g *groupDB) IsGroupAdmin(ctx context.Context, groupAccountID string, accountID string) (bool, error) {
+	q := g.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("group_admins"), bun.Ident("group_admin")).
+		Column("group_admin.id").
+		Where("? = ?", bun.Ident("group_admin.group_account_id"), groupAccountID).
+		Where("? = ?", bun.Ident("group_admin.account_id"), accountID)
+	return exists(ctx, q)
+}
+
+func (g *groupDB) PopulateGroupAdmin(ctx context.Context, groupAdmin *gtsmodel.GroupAdmin) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(2)
+	)
+
+	if groupAdmin.GroupAccount == nil {
+		// Group account is not set, fetch from database.
+		groupAdmin.GroupAccount, err = g.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			groupAdmin.GroupAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating group account: %w", err)
+		}
+	}
+
+	if groupAdmin.Account == nil {
+		// Admin account is not set, fetch from database.
+		groupAdmin.Account, err = g.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			groupAdmin.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating group admin account: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (g *groupDB) PutGroupAdmin(ctx context.Context, groupAdmin *gtsmodel.GroupAdmin) error {
+	_, err := g.db.NewInsert().
+		Model(groupAdmin).
+		Exec(ctx)
+	return err
+}
+
+func (g *groupDB) DeleteGroupAdminByID(ctx context.Context, id string) error {
+	_, err := g.db.NewDelete().
+		Table("group_admins").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (g *groupDB) DeleteGroupAdminsByAccountID(ctx context.Context, accountID string) error {
+	_, err := g.db.NewDelete().
+		Table("group_admins").
+		WhereOr("? = ?", bun.Ident("group_account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,151 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"github.com/stretchr/testify/suite"
+)
+
+type GroupTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *GroupTestSuite) newGroup(username string, admin *gtsmodel.Account) *gtsmodel.Account {
+	group, err := suite.db.NewGroup(context.Background(), gtsmodel.NewGroup{
+		Username: username,
+		Admin:    admin,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return group
+}
+
+func (suite *GroupTestSuite) TestNewGroup() {
+	var (
+		ctx   = context.Background()
+		admin = suite.testAccounts["local_account_1"]
+	)
+
+	group := suite.newGroup("knitting", admin)
+	suite.True(group.IsGroup())
+	suite.True(group.IsLocal())
+	suite.Equal("knitting", group.DisplayName)
+	suite.Equal("http://localhost:8080/users/knitting", group.URI)
+	suite.NotNil(group.PrivateKey)
+
+	// Group should be gettable
+	// like any other account.
+	dbGroup, err := suite.db.GetAccountByUsernameDomain(ctx, "knitting", "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(group.ID, dbGroup.ID)
+	suite.Equal(gtsmodel.AccountActorTypeGroup, dbGroup.ActorType)
+	suite.NotNil(dbGroup.Settings)
+	suite.NotNil(dbGroup.Stats)
+
+	// Creator should be the group's first admin.
+	isAdmin, err := suite.db.IsGroupAdmin(ctx, group.ID, admin.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(isAdmin)
+
+	isAdmin, err = suite.db.IsGroupAdmin(ctx, group.ID, suite.testAccounts["admin_account"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(isAdmin)
+}
+
+func (suite *GroupTestSuite) TestPutGetDeleteGroupAdmins() {
+	var (
+		ctx    = context.Background()
+		admin1 = suite.testAccounts["local_account_1"]
+		admin2 = suite.testAccounts["local_account_2"]
+	)
+
+	group := suite.newGroup("knitting", admin1)
+	// Mint the second admin's ID ahead of the first
+	// so ordering by ID is deterministic in this test.
+	groupAdminID := id.NewULIDFromTime(time.Now().Add(time.Minute))
+	if err := suite.db.PutGroupAdmin(ctx, &gtsmodel.GroupAdmin{
+		ID:             groupAdminID,
+		GroupAccountID: group.ID,
+		AccountID:      admin2.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Get admins of the group in order.
+	groupAdmins, err := suite.db.GetGroupAdmins(ctx, group.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(groupAdmins, 2) {
+		suite.Equal(admin1.ID, groupAdmins[0].AccountID)
+		suite.Equal(admin2.ID, groupAdmins[1].AccountID)
+		for _, groupAdmin := range groupAdmins {
+			suite.NotNil(groupAdmin.Account)
+			suite.Equal(group.ID, groupAdmin.GroupAccount.ID)
+		}
+	}
+
+	// Get groups administered by admin2.
+	groupAdmins, err = suite.db.GetGroupAdminsByAccountID(ctx, admin2.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if suite.Len(groupAdmins, 1) {
+		suite.Equal(group.ID, groupAdmins[0].GroupAccountID)
+	}
+
+	// Remove admin2.
+	if err := suite.db.DeleteGroupAdminByID(ctx, groupAdminID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	isAdmin, err := suite.db.IsGroupAdmin(ctx, group.ID, admin2.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(isAdmin)
+
+	// Deleting by the group account
+	// should remove remaining admins.
+	if err := suite.db.DeleteGroupAdminsByAccountID(ctx, group.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	groupAdmins, err = suite.db.GetGroupAdmins(ctx, group.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(groupAdmins)
+}
+
+func TestGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(GroupTestSuite))
+}
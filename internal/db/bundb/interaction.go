@@ -229,6 +229,12 @@ func (i *interactionDB) PopulateInteractionRequest(ctx context.Context, req *gts
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			errs.Appendf("error populating interactionRequest Announce: %w", err)
 		}
+
+	case gtsmodel.InteractionQuote:
+		req.Quote, err = i.state.DB.GetStatusByURI(ctx, req.ObjectURI())
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating interactionRequest Quote: %w", err)
+		}
 	}
 
 	return errs.Combine()
@@ -275,10 +281,11 @@ func (i *interactionDB) GetInteractionsRequestsForAcct(
 	likes bool,
 	replies bool,
 	boosts bool,
+	quotes bool,
 	page *paging.Page,
 ) ([]*gtsmodel.InteractionRequest, error) {
-	if !likes && !replies && !boosts {
-		return nil, gtserror.New("at least one of likes, replies, or boosts must be true")
+	if !likes && !replies && !boosts && !quotes {
+		return nil, gtserror.New("at least one of likes, replies, boosts, or quotes must be true")
 	}
 
 	var (
@@ -318,7 +325,7 @@ func (i *interactionDB) GetInteractionsRequestsForAcct(
 
 	// Figure out which types of interaction are
 	// being sought, and add them to the query.
-	wantTypes := make([]gtsmodel.InteractionType, 0, 3)
+	wantTypes := make([]gtsmodel.InteractionType, 0, 4)
 	if likes {
 		wantTypes = append(wantTypes, gtsmodel.InteractionLike)
 	}
@@ -328,6 +335,9 @@ func (i *interactionDB) GetInteractionsRequestsForAcct(
 	if boosts {
 		wantTypes = append(wantTypes, gtsmodel.InteractionAnnounce)
 	}
+	if quotes {
+		wantTypes = append(wantTypes, gtsmodel.InteractionQuote)
+	}
 	q = q.Where("? IN (?)", bun.Ident("interaction_type"), bun.In(wantTypes))
 
 	// Add paging param max ID.
@@ -133,6 +133,7 @@ func (suite *InteractionTestSuite) TestGetPending() {
 		likes      = true
 		replies    = true
 		boosts     = true
+		quotes     = true
 		page       = &paging.Page{
 			Max:   paging.MaxID(id.Highest),
 			Limit: 20,
@@ -151,6 +152,7 @@ func (suite *InteractionTestSuite) TestGetPending() {
 		likes,
 		replies,
 		boosts,
+		quotes,
 		page,
 	)
 	suite.NoError(err)
@@ -181,6 +183,7 @@ func (suite *InteractionTestSuite) TestGetPendingRepliesOnly() {
 		likes      = false
 		replies    = true
 		boosts     = false
+		quotes     = false
 		page       = &paging.Page{
 			Max:   paging.MaxID(id.Highest),
 			Limit: 20,
@@ -199,6 +202,7 @@ func (suite *InteractionTestSuite) TestGetPendingRepliesOnly() {
 		likes,
 		replies,
 		boosts,
+		quotes,
 		page,
 	)
 	suite.NoError(err)
@@ -0,0 +1,342 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/util/xslices"
+	"github.com/uptrace/bun"
+)
+
+type inviteDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *inviteDB) GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error) {
+	return i.getInvite(
+		ctx,
+		"ID",
+		func(invite *gtsmodel.Invite) error {
+			return i.db.
+				NewSelect().
+				Model(invite).
+				Where("? = ?", bun.Ident("invite.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (i *inviteDB) GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error) {
+	return i.getInvite(
+		ctx,
+		"Code",
+		func(invite *gtsmodel.Invite) error {
+			return i.db.
+				NewSelect().
+				Model(invite).
+				Where("? = ?", bun.Ident("invite.code"), code).
+				Scan(ctx)
+		},
+		code,
+	)
+}
+
+func (i *inviteDB) getInvite(
+	ctx context.Context,
+	lookup string,
+	dbQuery func(*gtsmodel.Invite) error,
+	keyParts ...any,
+) (*gtsmodel.Invite, error) {
+	// Fetch invite from database cache with loader callback.
+	invite, err := i.state.Caches.DB.Invite.LoadOne(lookup, func() (*gtsmodel.Invite, error) {
+		var invite gtsmodel.Invite
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&invite); err != nil {
+			return nil, err
+		}
+
+		return &invite, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return invite, nil
+	}
+
+	if err := i.PopulateInvite(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+func (i *inviteDB) GetInvites(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Invite, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size.
+		inviteIDs = make([]string, 0, limit)
+	)
+
+	q := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("invites"), bun.Ident("invite")).
+		Column("invite.id")
+
+	if userID != "" {
+		q = q.Where("? = ?", bun.Ident("invite.user_id"), userID)
+	}
+
+	if maxID != "" {
+		// Return only invites LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("invite.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only invites HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("invite.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("invite.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("invite.id DESC")
+	}
+
+	if err := q.Scan(ctx, &inviteIDs); err != nil {
+		return nil, err
+	}
+
+	if len(inviteIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want invites
+	// to be sorted by ID desc (ie., newest to
+	// oldest), so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(inviteIDs)
+	}
+
+	return i.getInvitesByIDs(ctx, inviteIDs)
+}
+
+func (i *inviteDB) getInvitesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Invite, error) {
+	// Load all invite IDs via cache loader callbacks.
+	invites, err := i.state.Caches.DB.Invite.LoadIDs("ID",
+		ids,
+		func(uncached []string) ([]*gtsmodel.Invite, error) {
+			// Preallocate expected length of uncached invites.
+			invites := make([]*gtsmodel.Invite, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := i.db.NewSelect().
+				Model(&invites).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return invites, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the invites by their
+	// IDs to ensure in correct order.
+	getID := func(i *gtsmodel.Invite) string { return i.ID }
+	xslices.OrderBy(invites, ids, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return invites, nil
+	}
+
+	for _, invite := range invites {
+		if err := i.PopulateInvite(ctx, invite); err != nil {
+			return nil, err
+		}
+	}
+
+	return invites, nil
+}
+
+func (i *inviteDB) PopulateInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	var err error
+
+	if invite.User == nil {
+		// Creating user is not set, fetch from database.
+		invite.User, err = i.state.DB.GetUserByID(ctx, invite.UserID)
+		if err != nil {
+			return gtserror.Newf("error populating invite user: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (i *inviteDB) PutInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	return i.state.Caches.DB.Invite.Store(invite, func() error {
+		_, err := i.db.
+			NewInsert().
+			Model(invite).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (i *inviteDB) UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error {
+	invite.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return i.state.Caches.DB.Invite.Store(invite, func() error {
+		_, err := i.db.
+			NewUpdate().
+			Model(invite).
+			Column(columns...).
+			Where("? = ?", bun.Ident("invite.id"), invite.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (i *inviteDB) UseInvite(ctx context.Context, invite *gtsmodel.Invite) (bool, error) {
+	now := time.Now()
+
+	// Only increment uses if the invite is
+	// still valid at the time of the update.
+	res, err := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ? + 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("id"), invite.ID).
+		Where("? IS NULL", bun.Ident("revoked_at")).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("expires_at")).
+				WhereOr("? > ?", bun.Ident("expires_at"), now)
+		}).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("max_uses")).
+				WhereOr("? < ?", bun.Ident("uses"), bun.Ident("max_uses"))
+		}).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// Uses changed in the db, (or at least
+	// the invite is no longer valid), so
+	// drop any cached copy of the invite.
+	i.state.Caches.DB.Invite.Invalidate("ID", invite.ID)
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rows == 0 {
+		// Invite no
+		// longer valid.
+		return false, nil
+	}
+
+	invite.Uses++
+	invite.UpdatedAt = now
+	return true, nil
+}
+
+func (i *inviteDB) UnuseInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	now := time.Now()
+
+	// Decrement uses in the db, rather than
+	// setting from the model, in case of
+	// concurrent sign ups with this invite.
+	if _, err := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ? - 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("id"), invite.ID).
+		Where("? > 0", bun.Ident("uses")).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Uses changed in the db,
+	// drop any cached copy.
+	i.state.Caches.DB.Invite.Invalidate("ID", invite.ID)
+
+	if invite.Uses > 0 {
+		invite.Uses--
+	}
+	invite.UpdatedAt = now
+	return nil
+}
+
+func (i *inviteDB) RevokeInvitesByUserID(ctx context.Context, userID string) error {
+	now := time.Now()
+	_, err := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ?", bun.Ident("revoked_at"), now).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? IS NULL", bun.Ident("revoked_at")).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Drop any cached invites by this user.
+	i.state.Caches.DB.Invite.Invalidate("UserID", userID)
+	return nil
+}
@@ -0,0 +1,233 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type InviteTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InviteTestSuite) putInvite(id string, userID string, maxUses int, expiresAt time.Time) *gtsmodel.Invite {
+	invite := &gtsmodel.Invite{
+		ID:         id,
+		Code:       util.MustGenerateSecret(),
+		UserID:     userID,
+		MaxUses:    maxUses,
+		ExpiresAt:  expiresAt,
+		Autofollow: util.Ptr(false),
+	}
+	if err := suite.db.PutInvite(context.Background(), invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return invite
+}
+
+func (suite *InviteTestSuite) TestPutGetInvite() {
+	ctx := context.Background()
+	user := suite.testUsers["admin_account"]
+
+	invite := suite.putInvite("01JY3X0Q8M5D0D6B3G1ZQ6VW1A", user.ID, 5, time.Time{})
+
+	byCode, err := suite.db.GetInviteByCode(ctx, invite.Code)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(invite.ID, byCode.ID)
+	suite.Equal(user.ID, byCode.User.ID)
+	suite.Equal(5, byCode.MaxUses)
+	suite.Zero(byCode.Uses)
+	suite.True(byCode.IsValid())
+
+	_, err = suite.db.GetInviteByCode(ctx, "not a real code")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *InviteTestSuite) TestUseInvite() {
+	ctx := context.Background()
+	user := suite.testUsers["admin_account"]
+
+	invite := suite.putInvite("01JY3X0Q8M5D0D6B3G1ZQ6VW1B", user.ID, 2, time.Time{})
+
+	// Can be used twice...
+	for i := 0; i < 2; i++ {
+		ok, err := suite.db.UseInvite(ctx, invite)
+		suite.NoError(err)
+		suite.True(ok)
+	}
+	suite.Equal(2, invite.Uses)
+	suite.True(invite.IsUsedUp())
+
+	// ...but not three times, even if
+	// the passed model is out of date.
+	stale, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	stale.Uses = 0
+	ok, err := suite.db.UseInvite(ctx, stale)
+	suite.NoError(err)
+	suite.False(ok)
+
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, dbInvite.Uses)
+}
+
+func (suite *InviteTestSuite) TestUnuseInvite() {
+	ctx := context.Background()
+	user := suite.testUsers["admin_account"]
+
+	invite := suite.putInvite("01JY3X0Q8M5D0D6B3G1ZQ6VW1J", user.ID, 1, time.Time{})
+
+	ok, err := suite.db.UseInvite(ctx, invite)
+	suite.NoError(err)
+	suite.True(ok)
+	suite.True(invite.IsUsedUp())
+
+	// Giving back the use
+	// makes it usable again.
+	if err := suite.db.UnuseInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(0, invite.Uses)
+
+	ok, err = suite.db.UseInvite(ctx, invite)
+	suite.NoError(err)
+	suite.True(ok)
+
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, dbInvite.Uses)
+}
+
+func (suite *InviteTestSuite) TestUseInviteExpiredOrRevoked() {
+	ctx := context.Background()
+	user := suite.testUsers["admin_account"]
+
+	expired := suite.putInvite("01JY3X0Q8M5D0D6B3G1ZQ6VW1C", user.ID, 0, time.Now().Add(-time.Hour))
+	ok, err := suite.db.UseInvite(ctx, expired)
+	suite.NoError(err)
+	suite.False(ok)
+
+	unlimited := suite.putInvite("01JY3X0Q8M5D0D6B3G1ZQ6VW1D", user.ID, 0, time.Now().Add(time.Hour))
+	ok, err = suite.db.UseInvite(ctx, unlimited)
+	suite.NoError(err)
+	suite.True(ok)
+
+	if err := suite.db.RevokeInvitesByUserID(ctx, user.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ok, err = suite.db.UseInvite(ctx, unlimited)
+	suite.NoError(err)
+	suite.False(ok)
+
+	revoked, err := suite.db.GetInviteByID(ctx, unlimited.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(revoked.IsRevoked())
+	suite.Equal(1, revoked.Uses)
+}
+
+func (suite *InviteTestSuite) TestGetInvites() {
+	ctx := context.Background()
+	admin := suite.testUsers["admin_account"]
+	user := suite.testUsers["local_account_1"]
+
+	suite.putInvite("01JY3X0Q8M5D0D6B3G1ZQ6VW1E", admin.ID, 0, time.Time{})
+	suite.putInvite("01JY3X0Q8M5D0D6B3G1ZQ6VW1F", user.ID, 0, time.Time{})
+	suite.putInvite("01JY3X0Q8M5D0D6B3G1ZQ6VW1G", admin.ID, 0, time.Time{})
+
+	all, err := suite.db.GetInvites(ctx, "", &paging.Page{})
+	suite.NoError(err)
+	if suite.Len(all, 3) {
+		// Newest first.
+		suite.Equal("01JY3X0Q8M5D0D6B3G1ZQ6VW1G", all[0].ID)
+		suite.Equal("01JY3X0Q8M5D0D6B3G1ZQ6VW1E", all[2].ID)
+	}
+
+	byUser, err := suite.db.GetInvites(ctx, user.ID, &paging.Page{})
+	suite.NoError(err)
+	if suite.Len(byUser, 1) {
+		suite.Equal("01JY3X0Q8M5D0D6B3G1ZQ6VW1F", byUser[0].ID)
+	}
+
+	page, err := suite.db.GetInvites(ctx, admin.ID, &paging.Page{
+		Max:   paging.MaxID("01JY3X0Q8M5D0D6B3G1ZQ6VW1G"),
+		Limit: 1,
+	})
+	suite.NoError(err)
+	if suite.Len(page, 1) {
+		suite.Equal("01JY3X0Q8M5D0D6B3G1ZQ6VW1E", page[0].ID)
+	}
+}
+
+func (suite *InviteTestSuite) TestGetAccountsInvitedBy() {
+	ctx := context.Background()
+	admin := suite.testUsers["admin_account"]
+	user := suite.testUsers["local_account_1"]
+
+	invite := suite.putInvite("01JY3X0Q8M5D0D6B3G1ZQ6VW1H", admin.ID, 0, time.Time{})
+
+	user.InviteID = invite.ID
+	if err := suite.db.UpdateUser(ctx, user, "invite_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err := suite.db.GetAccounts(
+		ctx,
+		"",
+		"",
+		false,
+		admin.AccountID,
+		"",
+		"",
+		"",
+		"",
+		netip.Addr{},
+		nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(accounts, 1) {
+		suite.Equal(user.AccountID, accounts[0].ID)
+	}
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
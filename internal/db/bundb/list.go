@@ -28,6 +28,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/paging"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
@@ -192,7 +193,7 @@ func (l *listDB) DeleteListByID(ctx context.Context, id string) error {
 	// entries contained in list.
 	var followIDs []string
 
-	// Delete all list entries associated with list, and list itself in transaction.
+	// Delete all list entries (including pending) associated with list, and list itself in transaction.
 	if err := l.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
 		if _, err := tx.NewDelete().
 			Table("list_entries").
@@ -202,6 +203,13 @@ func (l *listDB) DeleteListByID(ctx context.Context, id string) error {
 			return err
 		}
 
+		if _, err := tx.NewDelete().
+			Table("pending_list_entries").
+			Where("? = ?", bun.Ident("list_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		_, err := tx.NewDelete().
 			Table("lists").
 			Where("? = ?", bun.Ident("id"), id).
@@ -449,6 +457,100 @@ func (l *listDB) DeleteAllListEntriesByFollows(ctx context.Context, followIDs ..
 	return nil
 }
 
+func (l *listDB) PutPendingListEntries(ctx context.Context, pendingEntries []*gtsmodel.PendingListEntry) error {
+	// Insert all pending entries into the database in a single transaction.
+	return l.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for _, pending := range pendingEntries {
+			if _, err := tx.
+				NewInsert().
+				Model(pending).
+				On("CONFLICT (?, ?) DO NOTHING", bun.Ident("list_id"), bun.Ident("follow_request_id")).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (l *listDB) AcceptPendingListEntries(ctx context.Context, followRequestID string) error {
+	var listIDs []string
+
+	// Delete all pending entries for the follow request, returning
+	// their list IDs, and add the accepted follow to those lists.
+	if err := l.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Table("pending_list_entries").
+			Where("? = ?", bun.Ident("follow_request_id"), followRequestID).
+			Returning("?", bun.Ident("list_id")).
+			Exec(ctx, &listIDs); err != nil &&
+			!errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		for _, listID := range listIDs {
+			// An accepted follow
+			// request keeps its ID.
+			entry := &gtsmodel.ListEntry{
+				ID:       id.NewULID(),
+				ListID:   listID,
+				FollowID: followRequestID,
+			}
+
+			if _, err := tx.
+				NewInsert().
+				Model(entry).
+				On("CONFLICT (?, ?) DO NOTHING", bun.Ident("list_id"), bun.Ident("follow_id")).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(listIDs) == 0 {
+		// Nothing
+		// changed.
+		return nil
+	}
+
+	// Invalidate all related list entry caches.
+	l.invalidateEntryCaches(ctx, listIDs,
+		[]string{followRequestID})
+
+	return nil
+}
+
+func (l *listDB) DeletePendingListEntriesByListID(ctx context.Context, listID string) error {
+	if _, err := l.db.NewDelete().
+		Table("pending_list_entries").
+		Where("? = ?", bun.Ident("list_id"), listID).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
+func (l *listDB) DeletePendingListEntriesByFollowRequests(ctx context.Context, followRequestIDs ...string) error {
+	// Check for empty list.
+	if len(followRequestIDs) == 0 {
+		return nil
+	}
+
+	if _, err := l.db.NewDelete().
+		Table("pending_list_entries").
+		Where("? IN (?)", bun.Ident("follow_request_id"), bun.In(followRequestIDs)).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
 // invalidateEntryCaches will invalidate all related ListEntry caches for given list IDs and follow IDs, including timelines.
 func (l *listDB) invalidateEntryCaches(ctx context.Context, listIDs, followIDs []string) {
 	var keys []string
@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type ScheduledStatus struct {
+	ID                string                        `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`
+	AccountID         string                        `bun:"type:CHAR(26),nullzero,notnull"`
+	ScheduledAt       time.Time                     `bun:"type:timestamptz,nullzero,notnull"`
+	Text              string                        `bun:""`
+	Poll              *gtsmodel.ScheduledStatusPoll `bun:""`
+	MediaIDs          []string                      `bun:"attachments,array"`
+	Sensitive         *bool                         `bun:",nullzero,notnull,default:false"`
+	SpoilerText       string                        `bun:""`
+	Visibility        int16                         `bun:",nullzero,notnull"`
+	InReplyToID       string                        `bun:"type:CHAR(26),nullzero"`
+	Language          string                        `bun:",nullzero"`
+	ApplicationID     string                        `bun:"type:CHAR(26),nullzero"`
+	LocalOnly         *bool                         `bun:",nullzero,notnull,default:false"`
+	ContentType       int16                         `bun:",nullzero"`
+	InteractionPolicy *gtsmodel.InteractionPolicy   `bun:""`
+}
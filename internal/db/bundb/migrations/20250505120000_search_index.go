@@ -0,0 +1,392 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		if err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			var stmts []string
+
+			switch d := tx.Dialect().Name(); d {
+
+			case dialect.SQLite:
+				// Use FTS5 virtual tables backed by
+				// external content tables keyed by ID,
+				// kept in sync with the index by triggers.
+				//
+				// See: https://sqlite.org/fts5.html#external_content_tables
+				stmts = []string{
+					`CREATE TABLE IF NOT EXISTS "status_search_content" (` +
+						`"id" INTEGER PRIMARY KEY, ` +
+						`"status_id" CHAR(26) NOT NULL UNIQUE, ` +
+						`"text" TEXT NOT NULL)`,
+					`CREATE VIRTUAL TABLE IF NOT EXISTS "status_search" USING fts5(` +
+						`"text", ` +
+						`content='status_search_content', ` +
+						`content_rowid='id', ` +
+						`tokenize='unicode61 remove_diacritics 2')`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_content_ai" AFTER INSERT ON "status_search_content" BEGIN ` +
+						`INSERT INTO "status_search" ("rowid", "text") VALUES (new."id", new."text"); ` +
+						`END`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_content_ad" AFTER DELETE ON "status_search_content" BEGIN ` +
+						`INSERT INTO "status_search" ("status_search", "rowid", "text") VALUES ('delete', old."id", old."text"); ` +
+						`END`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_content_au" AFTER UPDATE ON "status_search_content" BEGIN ` +
+						`INSERT INTO "status_search" ("status_search", "rowid", "text") VALUES ('delete', old."id", old."text"); ` +
+						`INSERT INTO "status_search" ("rowid", "text") VALUES (new."id", new."text"); ` +
+						`END`,
+					`CREATE TABLE IF NOT EXISTS "account_search_content" (` +
+						`"id" INTEGER PRIMARY KEY, ` +
+						`"account_id" CHAR(26) NOT NULL UNIQUE, ` +
+						`"name" TEXT NOT NULL, ` +
+						`"note" TEXT NOT NULL)`,
+					`CREATE VIRTUAL TABLE IF NOT EXISTS "account_search" USING fts5(` +
+						`"name", "note", ` +
+						`content='account_search_content', ` +
+						`content_rowid='id', ` +
+						`tokenize='unicode61 remove_diacritics 2')`,
+					`CREATE TRIGGER IF NOT EXISTS "account_search_content_ai" AFTER INSERT ON "account_search_content" BEGIN ` +
+						`INSERT INTO "account_search" ("rowid", "name", "note") VALUES (new."id", new."name", new."note"); ` +
+						`END`,
+					`CREATE TRIGGER IF NOT EXISTS "account_search_content_ad" AFTER DELETE ON "account_search_content" BEGIN ` +
+						`INSERT INTO "account_search" ("account_search", "rowid", "name", "note") VALUES ('delete', old."id", old."name", old."note"); ` +
+						`END`,
+					`CREATE TRIGGER IF NOT EXISTS "account_search_content_au" AFTER UPDATE ON "account_search_content" BEGIN ` +
+						`INSERT INTO "account_search" ("account_search", "rowid", "name", "note") VALUES ('delete', old."id", old."name", old."note"); ` +
+						`INSERT INTO "account_search" ("rowid", "name", "note") VALUES (new."id", new."name", new."note"); ` +
+						`END`,
+				}
+
+			case dialect.PG:
+				// Use tsvector columns with GIN
+				// indexes, keyed by ID. Postgres
+				// computes the tsvectors on insert.
+				stmts = []string{
+					`CREATE TABLE IF NOT EXISTS "status_search" (` +
+						`"status_id" CHAR(26) PRIMARY KEY, ` +
+						`"document" TSVECTOR NOT NULL)`,
+					`CREATE INDEX IF NOT EXISTS "status_search_document_idx" ON "status_search" USING GIN ("document")`,
+					`CREATE TABLE IF NOT EXISTS "account_search" (` +
+						`"account_id" CHAR(26) PRIMARY KEY, ` +
+						`"name" TSVECTOR NOT NULL, ` +
+						`"note" TSVECTOR NOT NULL)`,
+					`CREATE INDEX IF NOT EXISTS "account_search_name_idx" ON "account_search" USING GIN ("name")`,
+					`CREATE INDEX IF NOT EXISTS "account_search_note_idx" ON "account_search" USING GIN ("note")`,
+				}
+
+			default:
+				log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+			}
+
+			for _, stmt := range stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return fmt.Errorf("error creating search index: %w", err)
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Backfill the index outside of the above
+		// transaction, committing once per batch,
+		// so that there's no long-held write lock.
+		// If interrupted, the migration is rerun
+		// and picks up from the last indexed row.
+
+		log.Info(ctx, "indexing accounts for search; this may take a while, please don't interrupt!")
+		if err := indexAccountsForSearch(ctx, db); err != nil {
+			return err
+		}
+
+		log.Info(ctx, "indexing statuses for search; this may take a while, please don't interrupt!")
+		if err := indexStatusesForSearch(ctx, db); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			var stmts []string
+
+			switch d := tx.Dialect().Name(); d {
+
+			case dialect.SQLite:
+				stmts = []string{
+					`DROP TRIGGER IF EXISTS "status_search_content_ai"`,
+					`DROP TRIGGER IF EXISTS "status_search_content_ad"`,
+					`DROP TRIGGER IF EXISTS "status_search_content_au"`,
+					`DROP TABLE IF EXISTS "status_search"`,
+					`DROP TABLE IF EXISTS "status_search_content"`,
+					`DROP TRIGGER IF EXISTS "account_search_content_ai"`,
+					`DROP TRIGGER IF EXISTS "account_search_content_ad"`,
+					`DROP TRIGGER IF EXISTS "account_search_content_au"`,
+					`DROP TABLE IF EXISTS "account_search"`,
+					`DROP TABLE IF EXISTS "account_search_content"`,
+				}
+
+			case dialect.PG:
+				// Dropping the tables
+				// drops their indexes too.
+				stmts = []string{
+					`DROP TABLE IF EXISTS "status_search"`,
+					`DROP TABLE IF EXISTS "account_search"`,
+				}
+
+			default:
+				log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+			}
+
+			for _, stmt := range stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return fmt.Errorf("error dropping search index: %w", err)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// searchIndexBatchsz is the number of
+// accounts / statuses to select and
+// index per batch during migration.
+const searchIndexBatchsz = 1000
+
+// searchIndexResumeID returns the highest ID in the given
+// column of the given search index table, so that the
+// backfill can carry on from there if it was interrupted.
+func searchIndexResumeID(ctx context.Context, db *bun.DB, table string, column string) (string, error) {
+	var maxID sql.NullString
+	if err := db.NewSelect().
+		Table(table).
+		ColumnExpr("MAX(?)", bun.Ident(column)).
+		Scan(ctx, &maxID); err != nil {
+		return "", fmt.Errorf("error selecting max %s from %s: %w", column, table, err)
+	}
+	return maxID.String, nil
+}
+
+// insertSearchIndexBatch inserts the given rows into the given
+// search index table in one statement and one transaction. Each
+// row's values are formatted with the given placeholder tuple.
+func insertSearchIndexBatch(
+	ctx context.Context,
+	db *bun.DB,
+	table string,
+	columns []string,
+	tuple string,
+	rows [][]any,
+) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	cols := make([]any, len(columns))
+	for i, column := range columns {
+		cols[i] = bun.Ident(column)
+	}
+
+	tuples := make([]string, len(rows))
+	args := make([]any, 0, 1+len(cols)+len(rows)*len(columns))
+	args = append(args, bun.Ident(table))
+	args = append(args, cols...)
+	for i, row := range rows {
+		tuples[i] = tuple
+		args = append(args, row...)
+	}
+
+	query := "INSERT INTO ? (" +
+		strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ") +
+		") VALUES " + strings.Join(tuples, ", ") +
+		" ON CONFLICT DO NOTHING"
+
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewRaw(query, args...).Exec(ctx)
+		return err
+	})
+}
+
+// indexAccountsForSearch pages through all accounts
+// in the database, inserting their names and notes
+// into the newly-created account search index.
+func indexAccountsForSearch(ctx context.Context, db *bun.DB) error {
+	var (
+		table   = "account_search"
+		tuple   = "(?, to_tsvector('simple', ?), to_tsvector('simple', ?))"
+		indexed int
+	)
+
+	if db.Dialect().Name() == dialect.SQLite {
+		table = "account_search_content"
+		tuple = "(?, ?, ?)"
+	}
+
+	maxID, err := searchIndexResumeID(ctx, db, table, "account_id")
+	if err != nil {
+		return err
+	}
+
+	for {
+		var accounts []struct {
+			ID          string `bun:"id"`
+			Username    string `bun:"username"`
+			DisplayName string `bun:"display_name"`
+			Note        string `bun:"note"`
+		}
+
+		q := db.NewSelect().
+			Table("accounts").
+			Column("id", "username", "display_name", "note").
+			Order("id ASC").
+			Limit(searchIndexBatchsz)
+		if maxID != "" {
+			q = q.Where("? > ?", bun.Ident("id"), maxID)
+		}
+
+		if err := q.Scan(ctx, &accounts); err != nil {
+			return fmt.Errorf("error selecting accounts: %w", err)
+		}
+
+		if len(accounts) == 0 {
+			break
+		}
+
+		maxID = accounts[len(accounts)-1].ID
+
+		rows := make([][]any, 0, len(accounts))
+		for _, account := range accounts {
+			name := account.Username
+			if account.DisplayName != "" {
+				name += " " + account.DisplayName
+			}
+			note := text.ParseHTMLToPlain(account.Note)
+			rows = append(rows, []any{account.ID, name, note})
+		}
+
+		if err := insertSearchIndexBatch(ctx, db,
+			table,
+			[]string{"account_id", "name", "note"},
+			tuple,
+			rows,
+		); err != nil {
+			return fmt.Errorf("error indexing accounts: %w", err)
+		}
+
+		indexed += len(accounts)
+		log.Infof(ctx, "indexed %d accounts (next page will be from %s)", indexed, maxID)
+	}
+
+	return nil
+}
+
+// indexStatusesForSearch pages through all non-boost
+// statuses in the database, inserting their text into
+// the newly-created status search index.
+func indexStatusesForSearch(ctx context.Context, db *bun.DB) error {
+	var (
+		table   = "status_search"
+		column  = "document"
+		tuple   = "(?, to_tsvector('simple', ?))"
+		indexed int
+	)
+
+	if db.Dialect().Name() == dialect.SQLite {
+		table = "status_search_content"
+		column = "text"
+		tuple = "(?, ?)"
+	}
+
+	maxID, err := searchIndexResumeID(ctx, db, table, "status_id")
+	if err != nil {
+		return err
+	}
+
+	for {
+		var statuses []struct {
+			ID             string `bun:"id"`
+			Content        string `bun:"content"`
+			ContentWarning string `bun:"content_warning"`
+		}
+
+		q := db.NewSelect().
+			Table("statuses").
+			Column("id", "content", "content_warning").
+			Where("? IS NULL", bun.Ident("boost_of_id")).
+			Order("id ASC").
+			Limit(searchIndexBatchsz)
+		if maxID != "" {
+			q = q.Where("? > ?", bun.Ident("id"), maxID)
+		}
+
+		if err := q.Scan(ctx, &statuses); err != nil {
+			return fmt.Errorf("error selecting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+
+		rows := make([][]any, 0, len(statuses))
+		for _, status := range statuses {
+			statusText := strings.TrimSpace(
+				text.ParseHTMLToPlain(status.ContentWarning) + "\n" +
+					text.ParseHTMLToPlain(status.Content),
+			)
+			if statusText == "" {
+				continue
+			}
+			rows = append(rows, []any{status.ID, statusText})
+		}
+
+		if err := insertSearchIndexBatch(ctx, db,
+			table,
+			[]string{"status_id", column},
+			tuple,
+			rows,
+		); err != nil {
+			return fmt.Errorf("error indexing statuses: %w", err)
+		}
+
+		indexed += len(statuses)
+		log.Infof(ctx, "indexed %d statuses (next page will be from %s)", indexed, maxID)
+	}
+
+	return nil
+}
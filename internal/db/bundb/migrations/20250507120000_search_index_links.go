@@ -0,0 +1,195 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		table := statusSearchLinksTable(db)
+
+		if err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			exists, err := doesColumnExist(ctx, tx, table, "has_link")
+			if err != nil {
+				return err
+			}
+
+			if exists {
+				return nil
+			}
+
+			if _, err := tx.NewAddColumn().
+				Table(table).
+				ColumnExpr("? BOOLEAN NOT NULL DEFAULT false", bun.Ident("has_link")).
+				Exec(ctx); err != nil {
+				return fmt.Errorf("error adding has_link column: %w", err)
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Mark statuses outside of the above
+		// transaction, committing once per batch,
+		// so that there's no long-held write lock.
+		// Marking is idempotent, so if interrupted,
+		// the migration can just be rerun.
+		log.Info(ctx, "marking statuses with links in search index; this may take a while, please don't interrupt!")
+		return markStatusLinksForSearch(ctx, db, table)
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			table := statusSearchLinksTable(db)
+
+			exists, err := doesColumnExist(ctx, tx, table, "has_link")
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				return nil
+			}
+
+			var stmts []string
+			if tx.Dialect().Name() == dialect.SQLite {
+				// SQLite won't drop a column from a table
+				// with triggers on it, so drop the index
+				// triggers first, then recreate them as of
+				// 20250505120000_search_index afterwards.
+				stmts = []string{
+					`DROP TRIGGER IF EXISTS "status_search_content_ai"`,
+					`DROP TRIGGER IF EXISTS "status_search_content_ad"`,
+					`DROP TRIGGER IF EXISTS "status_search_content_au"`,
+					`ALTER TABLE "status_search_content" DROP COLUMN "has_link"`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_content_ai" AFTER INSERT ON "status_search_content" BEGIN ` +
+						`INSERT INTO "status_search" ("rowid", "text") VALUES (new."id", new."text"); ` +
+						`END`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_content_ad" AFTER DELETE ON "status_search_content" BEGIN ` +
+						`INSERT INTO "status_search" ("status_search", "rowid", "text") VALUES ('delete', old."id", old."text"); ` +
+						`END`,
+					`CREATE TRIGGER IF NOT EXISTS "status_search_content_au" AFTER UPDATE ON "status_search_content" BEGIN ` +
+						`INSERT INTO "status_search" ("status_search", "rowid", "text") VALUES ('delete', old."id", old."text"); ` +
+						`INSERT INTO "status_search" ("rowid", "text") VALUES (new."id", new."text"); ` +
+						`END`,
+				}
+			} else {
+				stmts = []string{
+					`ALTER TABLE "status_search" DROP COLUMN "has_link"`,
+				}
+			}
+
+			for _, stmt := range stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return fmt.Errorf("error dropping has_link column: %w", err)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// statusSearchLinksTable returns the table of the status
+// search index that has_link lives in: the external content
+// table on SQLite, and the tsvector table on Postgres; see
+// 20250505120000_search_index.
+func statusSearchLinksTable(db bun.IDB) string {
+	if db.Dialect().Name() == dialect.SQLite {
+		return "status_search_content"
+	}
+	return "status_search"
+}
+
+// markStatusLinksForSearch pages through all non-boost
+// statuses in the database that look like they may contain
+// a link, and sets has_link in the search index table for
+// those that actually contain one that isn't a mention,
+// in one statement and one transaction per batch.
+func markStatusLinksForSearch(ctx context.Context, db *bun.DB, table string) error {
+	var (
+		maxID  string
+		marked int
+	)
+
+	for {
+		var statuses []struct {
+			ID      string `bun:"id"`
+			Content string `bun:"content"`
+		}
+
+		q := db.NewSelect().
+			Table("statuses").
+			Column("id", "content").
+			Where("? IS NULL", bun.Ident("boost_of_id")).
+			Where("? LIKE ?", bun.Ident("content"), "%href%").
+			Order("id ASC").
+			Limit(searchIndexBatchsz)
+		if maxID != "" {
+			q = q.Where("? > ?", bun.Ident("id"), maxID)
+		}
+
+		if err := q.Scan(ctx, &statuses); err != nil {
+			return fmt.Errorf("error selecting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+
+		statusIDs := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			if len(text.FindLinks(status.Content)) != 0 {
+				statusIDs = append(statusIDs, status.ID)
+			}
+		}
+
+		if len(statusIDs) != 0 {
+			if err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+				_, err := tx.NewUpdate().
+					Table(table).
+					Set("? = ?", bun.Ident("has_link"), true).
+					Where("? IN (?)", bun.Ident("status_id"), bun.In(statusIDs)).
+					Exec(ctx)
+				return err
+			}); err != nil {
+				return fmt.Errorf("error marking statuses: %w", err)
+			}
+		}
+
+		marked += len(statusIDs)
+		log.Infof(ctx, "marked %d statuses with links (next page will be from %s)", marked, maxID)
+	}
+
+	return nil
+}
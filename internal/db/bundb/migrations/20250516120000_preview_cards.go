@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"reflect"
+
+	newmodel "code.superseriousbusiness.org/gotosocial/internal/db/bundb/migrations/20250516120000_preview_cards"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create new preview cards table.
+			if _, err := tx.
+				NewCreateTable().
+				Model((*newmodel.PreviewCard)(nil)).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Add preview_card_id column
+			// to statuses, if not done yet.
+			exists, err := doesColumnExist(ctx, tx,
+				"statuses", "preview_card_id",
+			)
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				columnDef, err := getBunColumnDef(tx,
+					reflect.TypeOf((*newmodel.Status)(nil)),
+					"PreviewCardID",
+				)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table("statuses").
+					ColumnExpr(columnDef).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Add index for looking up
+			// statuses by preview card,
+			// used when pruning cards.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_preview_card_id_idx").
+				Column("preview_card_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"reflect"
+
+	newmodel "code.superseriousbusiness.org/gotosocial/internal/db/bundb/migrations/20250524120000_status_quotes"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			statusType := reflect.TypeOf((*newmodel.Status)(nil))
+
+			// Add quote columns
+			// to statuses, if not done yet.
+			for column, field := range map[string]string{
+				"quote_of_id":            "QuoteOfID",
+				"quote_of_uri":           "QuoteOfURI",
+				"quote_of_account_id":    "QuoteOfAccountID",
+				"quote_pending_approval": "QuotePendingApproval",
+				"quote_approved_by_uri":  "QuoteApprovedByURI",
+			} {
+				exists, err := doesColumnExist(ctx, tx,
+					"statuses", column,
+				)
+				if err != nil {
+					return err
+				}
+
+				if exists {
+					continue
+				}
+
+				columnDef, err := getBunColumnDef(tx,
+					statusType,
+					field,
+				)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table("statuses").
+					ColumnExpr(columnDef).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Add quoted status ID column
+			// to scheduled statuses, if not done yet.
+			exists, err := doesColumnExist(ctx, tx,
+				"scheduled_statuses", "quoted_status_id",
+			)
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				columnDef, err := getBunColumnDef(tx,
+					reflect.TypeOf((*newmodel.ScheduledStatus)(nil)),
+					"QuotedStatusID",
+				)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table("scheduled_statuses").
+					ColumnExpr(columnDef).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Add index for looking up
+			// statuses by quoted status.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_quote_of_id_idx").
+				Column("quote_of_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
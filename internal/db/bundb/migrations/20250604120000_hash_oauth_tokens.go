@@ -0,0 +1,194 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"reflect"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	newmodel "code.superseriousbusiness.org/gotosocial/internal/db/bundb/migrations/20250604120000_hash_oauth_tokens"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Select the secrets of all tokens,
+			// which are currently in plaintext.
+			var tokens []struct {
+				ID      string `bun:"id"`
+				Code    string `bun:"code"`
+				Access  string `bun:"access"`
+				Refresh string `bun:"refresh"`
+			}
+			if err := tx.
+				NewSelect().
+				Table("tokens").
+				Column("id", "code", "access", "refresh").
+				Scan(ctx, &tokens); err != nil {
+				return err
+			}
+
+			// Keyed hash of token secret, as of
+			// this migration. Empty is left as-is.
+			secret := []byte(config.GetAdvancedTokenHashSecret())
+			hash := func(token string) string {
+				if token == "" {
+					return ""
+				}
+				mac := hmac.New(sha256.New, secret)
+				mac.Write([]byte(token))
+				return hex.EncodeToString(mac.Sum(nil))
+			}
+
+			// Web Push notifications include the subscription's
+			// access token, so before hashing, keep an encrypted
+			// copy of it on each subscription. Add the column
+			// for this first, if not done yet.
+			exists, err := doesColumnExist(ctx, tx,
+				"web_push_subscriptions", "sealed_access_token",
+			)
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				columnDef, err := getBunColumnDef(tx,
+					reflect.TypeOf((*newmodel.WebPushSubscription)(nil)),
+					"SealedAccessToken",
+				)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.
+					NewAddColumn().
+					Table("web_push_subscriptions").
+					ColumnExpr(columnDef).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Encryption of token secret, as of this migration,
+			// base64-encoded nonce + AES-256-GCM ciphertext.
+			mac := hmac.New(sha256.New, secret)
+			mac.Write([]byte("gotosocial token seal"))
+			block, err := aes.NewCipher(mac.Sum(nil))
+			if err != nil {
+				return err
+			}
+			gcm, err := cipher.NewGCM(block)
+			if err != nil {
+				return err
+			}
+			seal := func(token string) (string, error) {
+				nonce := make([]byte, gcm.NonceSize())
+				if _, err := rand.Read(nonce); err != nil {
+					return "", err
+				}
+				sealed := gcm.Seal(nonce, nonce, []byte(token), nil)
+				return base64.StdEncoding.EncodeToString(sealed), nil
+			}
+
+			var subscriptions []struct {
+				ID      string `bun:"id"`
+				TokenID string `bun:"token_id"`
+			}
+			if err := tx.
+				NewSelect().
+				Table("web_push_subscriptions").
+				Column("id", "token_id").
+				Scan(ctx, &subscriptions); err != nil {
+				return err
+			}
+
+			access := make(map[string]string, len(tokens))
+			for _, token := range tokens {
+				access[token.ID] = token.Access
+			}
+
+			for _, subscription := range subscriptions {
+				token := access[subscription.TokenID]
+				if token == "" || len(secret) == 0 {
+					// Nothing to seal, or no secret to
+					// seal it with, in which case the
+					// token is dropped from payloads.
+					continue
+				}
+
+				sealed, err := seal(token)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.
+					NewUpdate().
+					Table("web_push_subscriptions").
+					Set("? = ?", bun.Ident("sealed_access_token"), sealed).
+					Where("? = ?", bun.Ident("id"), subscription.ID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			log.Infof(ctx, "hashing %d oauth tokens, please wait...", len(tokens))
+
+			// Replace each token's
+			// secrets with hashes.
+			for _, token := range tokens {
+				if _, err := tx.
+					NewUpdate().
+					Table("tokens").
+					Set("? = ?", bun.Ident("code"), hash(token.Code)).
+					Set("? = ?", bun.Ident("access"), hash(token.Access)).
+					Set("? = ?", bun.Ident("refresh"), hash(token.Refresh)).
+					Where("? = ?", bun.Ident("id"), token.ID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	// This migration is irreversible: token secrets are
+	// replaced by one-way hashes, so there's no getting
+	// the plaintext back. After downgrading past it, no
+	// existing token or authorization code will work, and
+	// every user and application will have to log in again.
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+type WebAuthnCredential struct {
+	ID           string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`
+	CreatedAt    time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`
+	UserID       string    `bun:"type:CHAR(26),nullzero,notnull"`
+	Name         string    `bun:",nullzero,notnull"`
+	CredentialID string    `bun:",nullzero,notnull,unique"`
+	PublicKey    []byte    `bun:",nullzero,notnull"`
+	SignCount    uint32    `bun:",notnull,default:0"`
+	AAGUID       string    `bun:",nullzero"`
+	Transports   []string  `bun:",nullzero,array"`
+	Passwordless *bool     `bun:",nullzero,notnull,default:false"`
+	LastUsedAt   time.Time `bun:"type:timestamptz,nullzero"`
+}
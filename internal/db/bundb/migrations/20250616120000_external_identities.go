@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	newmodel "code.superseriousbusiness.org/gotosocial/internal/db/bundb/migrations/20250616120000_external_identities"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Create new external identities table.
+			if _, err := tx.
+				NewCreateTable().
+				Model((*newmodel.ExternalIdentity)(nil)).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Add index for looking
+			// up identities by user.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("external_identities").
+				Index("external_identities_user_id_idx").
+				Column("user_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Select users previously linked to
+			// the single configured OIDC provider.
+			var users []struct {
+				ID         string
+				CreatedAt  time.Time
+				Email      string
+				ExternalID string
+			}
+			if err := tx.
+				NewSelect().
+				Table("users").
+				Column("id", "created_at", "email", "external_id").
+				Where("? IS NOT NULL", bun.Ident("external_id")).
+				Scan(ctx, &users); err != nil {
+				return err
+			}
+
+			// Link each of these users to the same
+			// subject at the "default" provider, which
+			// is the one configured by oidc-issuer.
+			for _, user := range users {
+				identity := &newmodel.ExternalIdentity{
+					ID:        id.NewULIDFromTime(user.CreatedAt),
+					CreatedAt: user.CreatedAt,
+					UpdatedAt: time.Now(),
+					UserID:    user.ID,
+					Provider:  "default",
+					Subject:   user.ExternalID,
+					Email:     user.Email,
+				}
+
+				if _, err := tx.
+					NewInsert().
+					Model(identity).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
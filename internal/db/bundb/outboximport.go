@@ -0,0 +1,126 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type outboxImportDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (o *outboxImportDB) GetOutboxImportByID(ctx context.Context, id string) (*gtsmodel.OutboxImport, error) {
+	outboxImport := new(gtsmodel.OutboxImport)
+
+	if err := o.db.
+		NewSelect().
+		Model(outboxImport).
+		Where("? = ?", bun.Ident("outbox_import.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := o.populateOutboxImport(ctx, outboxImport); err != nil {
+		return nil, err
+	}
+
+	return outboxImport, nil
+}
+
+func (o *outboxImportDB) GetOutboxImportsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.OutboxImport, error) {
+	var outboxImports []*gtsmodel.OutboxImport
+
+	if err := o.db.
+		NewSelect().
+		Model(&outboxImports).
+		Where("? = ?", bun.Ident("outbox_import.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("outbox_import.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, outboxImport := range outboxImports {
+		if err := o.populateOutboxImport(ctx, outboxImport); err != nil {
+			return nil, err
+		}
+	}
+
+	return outboxImports, nil
+}
+
+func (o *outboxImportDB) populateOutboxImport(ctx context.Context, outboxImport *gtsmodel.OutboxImport) error {
+	var err error
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return nil
+	}
+
+	if outboxImport.Account == nil {
+		// Import account is not set, fetch from database.
+		outboxImport.Account, err = o.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			outboxImport.AccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating outbox import account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (o *outboxImportDB) PutOutboxImport(ctx context.Context, outboxImport *gtsmodel.OutboxImport) error {
+	_, err := o.db.NewInsert().
+		Model(outboxImport).
+		Exec(ctx)
+	return err
+}
+
+func (o *outboxImportDB) UpdateOutboxImport(ctx context.Context, outboxImport *gtsmodel.OutboxImport, columns ...string) error {
+	outboxImport.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := o.db.NewUpdate().
+		Model(outboxImport).
+		Column(columns...).
+		Where("? = ?", bun.Ident("outbox_import.id"), outboxImport.ID).
+		Exec(ctx)
+	return err
+}
+
+func (o *outboxImportDB) DeleteOutboxImportsByAccountID(ctx context.Context, accountID string) error {
+	_, err := o.db.NewDelete().
+		Table("outbox_imports").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,226 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/util/xslices"
+	"github.com/uptrace/bun"
+)
+
+type previewCardDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (p *previewCardDB) GetPreviewCardByID(ctx context.Context, id string) (*gtsmodel.PreviewCard, error) {
+	return p.getPreviewCard(
+		"ID",
+		func(card *gtsmodel.PreviewCard) error {
+			return p.db.
+				NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("preview_card.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (p *previewCardDB) GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, error) {
+	return p.getPreviewCard(
+		"URL",
+		func(card *gtsmodel.PreviewCard) error {
+			return p.db.
+				NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("preview_card.url"), url).
+				Scan(ctx)
+		},
+		url,
+	)
+}
+
+func (p *previewCardDB) getPreviewCard(
+	lookup string,
+	dbQuery func(*gtsmodel.PreviewCard) error,
+	keyParts ...any,
+) (*gtsmodel.PreviewCard, error) {
+	// Fetch preview card from database cache with loader callback.
+	return p.state.Caches.DB.PreviewCard.LoadOne(lookup, func() (*gtsmodel.PreviewCard, error) {
+		var card gtsmodel.PreviewCard
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&card); err != nil {
+			return nil, err
+		}
+
+		return &card, nil
+	}, keyParts...)
+}
+
+func (p *previewCardDB) GetPreviewCardsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.PreviewCard, error) {
+	if len(ids) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Load all preview card IDs via cache loader callbacks.
+	cards, err := p.state.Caches.DB.PreviewCard.LoadIDs("ID",
+		ids,
+		func(uncached []string) ([]*gtsmodel.PreviewCard, error) {
+			// Preallocate expected length of uncached cards.
+			cards := make([]*gtsmodel.PreviewCard, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := p.db.NewSelect().
+				Model(&cards).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return cards, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the cards by their
+	// IDs to ensure in correct order.
+	getID := func(c *gtsmodel.PreviewCard) string { return c.ID }
+	xslices.OrderBy(cards, ids, getID)
+
+	return cards, nil
+}
+
+func (p *previewCardDB) GetCachedPreviewCardsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.PreviewCard, error) {
+	var cardIDs []string
+
+	q := p.db.NewSelect().
+		Table("preview_cards").
+		Column("id").
+		Where("? = ?", bun.Ident("cached"), true).
+		Where("? < ?", bun.Ident("fetched_at"), olderThan).
+		Order("fetched_at DESC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &cardIDs); err != nil {
+		return nil, err
+	}
+
+	return p.GetPreviewCardsByIDs(ctx, cardIDs)
+}
+
+func (p *previewCardDB) GetUnusedPreviewCards(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.PreviewCard, error) {
+	var cardIDs []string
+
+	// Select cards that no
+	// status points to.
+	used := p.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("1").
+		Where("? = ?", bun.Ident("status.preview_card_id"), bun.Ident("preview_card.id"))
+
+	q := p.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("preview_cards"), bun.Ident("preview_card")).
+		Column("preview_card.id").
+		Where("? < ?", bun.Ident("preview_card.created_at"), olderThan).
+		Where("NOT EXISTS (?)", used).
+		Order("preview_card.created_at ASC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &cardIDs); err != nil {
+		return nil, err
+	}
+
+	return p.GetPreviewCardsByIDs(ctx, cardIDs)
+}
+
+func (p *previewCardDB) PutPreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error {
+	return p.state.Caches.DB.PreviewCard.Store(card, func() error {
+		_, err := p.db.NewInsert().
+			Model(card).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (p *previewCardDB) UpdatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard, columns ...string) error {
+	card.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return p.state.Caches.DB.PreviewCard.Store(card, func() error {
+		_, err := p.db.NewUpdate().
+			Model(card).
+			Column(columns...).
+			Where("? = ?", bun.Ident("preview_card.id"), card.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (p *previewCardDB) DeletePreviewCardByID(ctx context.Context, id string) error {
+	var statusIDs []string
+
+	// Unset the card on any statuses using it, and delete
+	// the card from the database in a singular transaction.
+	if err := p.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewUpdate().
+			Table("statuses").
+			Set("? = NULL", bun.Ident("preview_card_id")).
+			Where("? = ?", bun.Ident("preview_card_id"), id).
+			Returning("id").
+			Exec(ctx, &statusIDs); err != nil &&
+			!errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		_, err := tx.NewDelete().
+			Table("preview_cards").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx)
+		return err
+	}); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate card, and any effected statuses.
+	p.state.Caches.DB.PreviewCard.Invalidate("ID", id)
+	p.state.Caches.DB.Status.InvalidateIDs("ID", statusIDs)
+
+	return nil
+}
@@ -271,6 +271,12 @@ func (r *relationshipDB) AcceptFollowRequest(ctx context.Context, sourceAccountI
 		return nil, err
 	}
 
+	// Add the new follow to any lists it
+	// was pending in as a follow request.
+	if err := r.state.DB.AcceptPendingListEntries(ctx, follow.ID); err != nil {
+		return nil, gtserror.Newf("error accepting pending list entries: %w", err)
+	}
+
 	return follow, nil
 }
 
@@ -317,6 +323,11 @@ func (r *relationshipDB) DeleteFollowRequest(
 		sourceAccountID, targetAccountID)
 	r.state.Caches.OnInvalidateFollowRequest(&deleted)
 
+	// Delete every pending list entry that was created targetting this follow request ID.
+	if err := r.state.DB.DeletePendingListEntriesByFollowRequests(ctx, deleted.ID); err != nil {
+		return gtserror.Newf("error deleting pending list entries: %w", err)
+	}
+
 	return nil
 }
 
@@ -345,6 +356,11 @@ func (r *relationshipDB) DeleteFollowRequestByID(ctx context.Context, id string)
 	r.state.Caches.DB.FollowRequest.Invalidate("ID", id)
 	r.state.Caches.OnInvalidateFollowRequest(&deleted)
 
+	// Delete every pending list entry that was created targetting this follow request ID.
+	if err := r.state.DB.DeletePendingListEntriesByFollowRequests(ctx, id); err != nil {
+		return gtserror.Newf("error deleting pending list entries: %w", err)
+	}
+
 	return nil
 }
 
@@ -373,6 +389,11 @@ func (r *relationshipDB) DeleteFollowRequestByURI(ctx context.Context, uri strin
 	r.state.Caches.DB.FollowRequest.Invalidate("URI", uri)
 	r.state.Caches.OnInvalidateFollowRequest(&deleted)
 
+	// Delete every pending list entry that was created targetting this follow request ID.
+	if err := r.state.DB.DeletePendingListEntriesByFollowRequests(ctx, deleted.ID); err != nil {
+		return gtserror.Newf("error deleting pending list entries: %w", err)
+	}
+
 	return nil
 }
 
@@ -402,6 +423,16 @@ func (r *relationshipDB) DeleteAccountFollowRequests(ctx context.Context, accoun
 		return err
 	}
 
+	// Gather the follow request IDs that were deleted for removing related pending list entries.
+	followReqIDs := xslices.Gather(nil, deleted, func(followReq *gtsmodel.FollowRequest) string {
+		return followReq.ID
+	})
+
+	// Delete every pending list entry that was created targetting any of these follow request IDs.
+	if err := r.state.DB.DeletePendingListEntriesByFollowRequests(ctx, followReqIDs...); err != nil {
+		return gtserror.Newf("error deleting pending list entries: %w", err)
+	}
+
 	// Invalidate all account's incoming / outoing follows requests.
 	r.state.Caches.DB.FollowRequest.Invalidate("AccountID", accountID)
 	r.state.Caches.DB.FollowRequest.Invalidate("TargetAccountID", accountID)
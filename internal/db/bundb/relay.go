@@ -0,0 +1,212 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/util/xslices"
+	"github.com/uptrace/bun"
+)
+
+type relayDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (r *relayDB) GetRelayByID(ctx context.Context, id string) (*gtsmodel.Relay, error) {
+	return r.getRelay(
+		"ID",
+		func(relay *gtsmodel.Relay) error {
+			return r.db.
+				NewSelect().
+				Model(relay).
+				Where("? = ?", bun.Ident("relay.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (r *relayDB) GetRelayByInboxURI(ctx context.Context, inboxURI string) (*gtsmodel.Relay, error) {
+	return r.getRelay(
+		"InboxURI",
+		func(relay *gtsmodel.Relay) error {
+			return r.db.
+				NewSelect().
+				Model(relay).
+				Where("? = ?", bun.Ident("relay.inbox_uri"), inboxURI).
+				Scan(ctx)
+		},
+		inboxURI,
+	)
+}
+
+func (r *relayDB) GetRelayByActorURI(ctx context.Context, actorURI string) (*gtsmodel.Relay, error) {
+	return r.getRelay(
+		"ActorURI",
+		func(relay *gtsmodel.Relay) error {
+			return r.db.
+				NewSelect().
+				Model(relay).
+				Where("? = ?", bun.Ident("relay.actor_uri"), actorURI).
+				Scan(ctx)
+		},
+		actorURI,
+	)
+}
+
+func (r *relayDB) GetRelayByFollowURI(ctx context.Context, followURI string) (*gtsmodel.Relay, error) {
+	return r.getRelay(
+		"FollowURI",
+		func(relay *gtsmodel.Relay) error {
+			return r.db.
+				NewSelect().
+				Model(relay).
+				Where("? = ?", bun.Ident("relay.follow_uri"), followURI).
+				Scan(ctx)
+		},
+		followURI,
+	)
+}
+
+func (r *relayDB) getRelay(
+	lookup string,
+	dbQuery func(*gtsmodel.Relay) error,
+	keyParts ...any,
+) (*gtsmodel.Relay, error) {
+	// Fetch relay from database cache with loader callback.
+	return r.state.Caches.DB.Relay.LoadOne(lookup, func() (*gtsmodel.Relay, error) {
+		var relay gtsmodel.Relay
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&relay); err != nil {
+			return nil, err
+		}
+
+		return &relay, nil
+	}, keyParts...)
+}
+
+func (r *relayDB) GetRelays(ctx context.Context) ([]*gtsmodel.Relay, error) {
+	return r.getRelays(ctx, r.db.
+		NewSelect().
+		Table("relays").
+		Column("id").
+		OrderExpr("? ASC", bun.Ident("id")),
+	)
+}
+
+func (r *relayDB) GetAcceptedRelays(ctx context.Context) ([]*gtsmodel.Relay, error) {
+	return r.getRelays(ctx, r.db.
+		NewSelect().
+		Table("relays").
+		Column("id").
+		Where("? = ?", bun.Ident("state"), gtsmodel.RelayStateAccepted).
+		OrderExpr("? ASC", bun.Ident("id")),
+	)
+}
+
+func (r *relayDB) getRelays(ctx context.Context, q *bun.SelectQuery) ([]*gtsmodel.Relay, error) {
+	var ids []string
+
+	// Select IDs of relays
+	// using the given query.
+	if err := q.Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	// Load all relay IDs via cache loader callback.
+	relays, err := r.state.Caches.DB.Relay.LoadIDs("ID",
+		ids,
+		func(uncached []string) ([]*gtsmodel.Relay, error) {
+			// Preallocate expected length of uncached relays.
+			relays := make([]*gtsmodel.Relay, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := r.db.NewSelect().
+				Model(&relays).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return relays, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the relays by their
+	// IDs to ensure in correct order.
+	getID := func(r *gtsmodel.Relay) string { return r.ID }
+	xslices.OrderBy(relays, ids, getID)
+
+	return relays, nil
+}
+
+func (r *relayDB) PutRelay(ctx context.Context, relay *gtsmodel.Relay) error {
+	return r.state.Caches.DB.Relay.Store(relay, func() error {
+		_, err := r.db.NewInsert().
+			Model(relay).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *relayDB) UpdateRelay(ctx context.Context, relay *gtsmodel.Relay, columns ...string) error {
+	relay.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return r.state.Caches.DB.Relay.Store(relay, func() error {
+		_, err := r.db.NewUpdate().
+			Model(relay).
+			Column(columns...).
+			Where("? = ?", bun.Ident("relay.id"), relay.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (r *relayDB) DeleteRelayByID(ctx context.Context, id string) error {
+	// Delete the relay with given ID.
+	if _, err := r.db.NewDelete().
+		Table("relays").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate cached relay by its ID,
+	// this also invalidates all other keys.
+	r.state.Caches.DB.Relay.Invalidate("ID", id)
+
+	return nil
+}
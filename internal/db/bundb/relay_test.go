@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"github.com/stretchr/testify/suite"
+)
+
+type RelayTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *RelayTestSuite) TestGetRelay() {
+	ctx := context.Background()
+	testRelay := suite.testRelays["pending_relay"]
+
+	for _, get := range []func() (*gtsmodel.Relay, error){
+		func() (*gtsmodel.Relay, error) { return suite.db.GetRelayByID(ctx, testRelay.ID) },
+		func() (*gtsmodel.Relay, error) { return suite.db.GetRelayByInboxURI(ctx, testRelay.InboxURI) },
+		func() (*gtsmodel.Relay, error) { return suite.db.GetRelayByFollowURI(ctx, testRelay.FollowURI) },
+	} {
+		relay, err := get()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(testRelay.ID, relay.ID)
+		suite.Equal(gtsmodel.RelayStatePending, relay.State)
+	}
+}
+
+func (suite *RelayTestSuite) TestGetAcceptedRelays() {
+	ctx := context.Background()
+	relay := new(gtsmodel.Relay)
+	*relay = *suite.testRelays["pending_relay"]
+
+	// Pending relay is not accepted yet.
+	relays, err := suite.db.GetAcceptedRelays(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(relays)
+
+	relay.State = gtsmodel.RelayStateAccepted
+	relay.ActorURI = "https://relay.fedi.example.org/actor"
+	if err := suite.db.UpdateRelay(ctx, relay, "state", "actor_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	relays, err = suite.db.GetAcceptedRelays(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(relays, 1)
+	suite.Equal(relay.ID, relays[0].ID)
+
+	// Should now be gettable by actor URI.
+	byActor, err := suite.db.GetRelayByActorURI(ctx, relay.ActorURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(relay.ID, byActor.ID)
+}
+
+func (suite *RelayTestSuite) TestDeleteRelay() {
+	ctx := context.Background()
+	testRelay := suite.testRelays["pending_relay"]
+
+	if err := suite.db.DeleteRelayByID(ctx, testRelay.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetRelayByID(ctx, testRelay.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	relays, err := suite.db.GetRelays(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(relays)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
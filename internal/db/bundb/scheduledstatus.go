@@ -0,0 +1,461 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/util/xslices"
+	"github.com/uptrace/bun"
+)
+
+type scheduledStatusDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *scheduledStatusDB) GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error) {
+	var statusIDs []string
+
+	// Select ALL scheduled status IDs.
+	if err := s.db.NewSelect().
+		Table("scheduled_statuses").
+		Column("id").
+		Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetScheduledStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error) {
+	return s.getScheduledStatus(
+		ctx,
+		"ID",
+		func(status *gtsmodel.ScheduledStatus) error {
+			return s.db.
+				NewSelect().
+				Model(status).
+				Where("? = ?", bun.Ident("scheduled_status.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (s *scheduledStatusDB) getScheduledStatus(
+	ctx context.Context,
+	lookup string,
+	dbQuery func(*gtsmodel.ScheduledStatus) error,
+	keyParts ...any,
+) (*gtsmodel.ScheduledStatus, error) {
+	// Fetch scheduled status from database cache with loader callback.
+	status, err := s.state.Caches.DB.ScheduledStatus.LoadOne(lookup, func() (*gtsmodel.ScheduledStatus, error) {
+		var status gtsmodel.ScheduledStatus
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&status); err != nil {
+			return nil, err
+		}
+
+		return &status, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return status, nil
+	}
+
+	// Further populate the scheduled status fields where applicable.
+	if err := s.PopulateScheduledStatus(ctx, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.ScheduledStatus, error) {
+	// Load all input scheduled status IDs via cache loader callback.
+	statuses, err := s.state.Caches.DB.ScheduledStatus.LoadIDs("ID",
+		ids,
+		func(uncached []string) ([]*gtsmodel.ScheduledStatus, error) {
+			// Preallocate expected length of uncached scheduled statuses.
+			statuses := make([]*gtsmodel.ScheduledStatus, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := s.db.NewSelect().
+				Model(&statuses).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return statuses, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the scheduled statuses by their
+	// IDs to ensure in correct order.
+	getID := func(s *gtsmodel.ScheduledStatus) string { return s.ID }
+	xslices.OrderBy(statuses, ids, getID)
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return statuses, nil
+	}
+
+	// Populate all loaded scheduled statuses, removing those we
+	// fail to populate (removes needing so many nil checks everywhere).
+	statuses = slices.DeleteFunc(statuses, func(status *gtsmodel.ScheduledStatus) bool {
+		if err := s.PopulateScheduledStatus(ctx, status); err != nil {
+			log.Errorf(ctx, "error populating scheduled status %s: %v", status.ID, err)
+			return true
+		}
+		return false
+	})
+
+	return statuses, nil
+}
+
+func (s *scheduledStatusDB) GetScheduledStatusesForAcct(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) ([]*gtsmodel.ScheduledStatus, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		statusIDs = make([]string, 0, limit)
+	)
+
+	q := s.db.
+		NewSelect().
+		TableExpr(
+			"? AS ?",
+			bun.Ident("scheduled_statuses"),
+			bun.Ident("scheduled_status"),
+		).
+		// Select only IDs from table
+		Column("scheduled_status.id").
+		Where("? = ?", bun.Ident("scheduled_status.account_id"), accountID)
+
+	// Return only items with id
+	// lower than provided maxID.
+	if maxID != "" {
+		q = q.Where(
+			"? < ?",
+			bun.Ident("scheduled_status.id"),
+			maxID,
+		)
+	}
+
+	// Return only items with id
+	// greater than provided minID.
+	if minID != "" {
+		q = q.Where(
+			"? > ?",
+			bun.Ident("scheduled_status.id"),
+			minID,
+		)
+	}
+
+	if limit > 0 {
+		// Limit amount of
+		// items returned.
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr(
+			"? ASC",
+			bun.Ident("scheduled_status.id"),
+		)
+	} else {
+		// Page down.
+		q = q.OrderExpr(
+			"? DESC",
+			bun.Ident("scheduled_status.id"),
+		)
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(statusIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want items
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(statusIDs)
+	}
+
+	return s.GetScheduledStatusesByIDs(ctx, statusIDs)
+}
+
+func (s *scheduledStatusDB) CountScheduledStatusesForAcct(
+	ctx context.Context,
+	accountID string,
+	scheduledAt time.Time,
+) (int, error) {
+	q := s.db.
+		NewSelect().
+		Table("scheduled_statuses").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	if !scheduledAt.IsZero() {
+		// Only count statuses scheduled
+		// within the same (UTC) day.
+		start := scheduledAt.UTC().Truncate(24 * time.Hour)
+		end := start.Add(24 * time.Hour)
+
+		q = q.
+			Where("? >= ?", bun.Ident("scheduled_at"), start).
+			Where("? < ?", bun.Ident("scheduled_at"), end)
+	}
+
+	return q.Count(ctx)
+}
+
+func (s *scheduledStatusDB) PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(3)
+	)
+
+	if status.Account == nil {
+		// Status author is not set, fetch from database.
+		status.Account, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status author: %w", err)
+		}
+	}
+
+	if status.Application == nil && status.ApplicationID != "" {
+		// Status application is not set, fetch from database.
+		status.Application, err = s.state.DB.GetApplicationByID(
+			gtscontext.SetBarebones(ctx),
+			status.ApplicationID,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status application: %w", err)
+		}
+	}
+
+	if !status.AttachmentsPopulated() {
+		// Status attachments are out-of-date with IDs, repopulate.
+		status.MediaAttachments, err = s.state.DB.GetAttachmentsByIDs(
+			gtscontext.SetBarebones(ctx),
+			status.MediaIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating scheduled status attachments: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (s *scheduledStatusDB) PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error {
+	if err := s.state.Caches.DB.ScheduledStatus.Store(status, func() error {
+		return s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if _, err := tx.NewInsert().
+				Model(status).
+				Exec(ctx); err != nil {
+				return gtserror.Newf("error inserting scheduled status: %w", err)
+			}
+
+			// If any media attachments are included,
+			// set their scheduled status ID so they're
+			// not treated as unused by the cleaner.
+			return updateScheduledStatusMedia(ctx, tx,
+				status.ID,
+				status.MediaIDs,
+			)
+		})
+	}); err != nil {
+		return err
+	}
+
+	// Invalidate the now updated media attachments.
+	s.state.Caches.DB.Media.InvalidateIDs("ID", status.MediaIDs)
+
+	return nil
+}
+
+func (s *scheduledStatusDB) UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error {
+	return s.state.Caches.DB.ScheduledStatus.Store(status, func() error {
+		_, err := s.db.NewUpdate().
+			Model(status).
+			Column(columns...).
+			Where("? = ?", bun.Ident("id"), status.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (s *scheduledStatusDB) UpdateScheduledStatusMedia(ctx context.Context, scheduledStatusID string, mediaIDs []string) error {
+	if err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return updateScheduledStatusMedia(ctx, tx,
+			scheduledStatusID,
+			mediaIDs,
+		)
+	}); err != nil {
+		return err
+	}
+
+	// Invalidate the now updated media attachments.
+	s.state.Caches.DB.Media.InvalidateIDs("ID", mediaIDs)
+
+	return nil
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusByID(ctx context.Context, id string) error {
+	var deleted gtsmodel.ScheduledStatus
+
+	// Delete scheduled status
+	// from database by its ID.
+	if err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Model(&deleted).
+			Returning("?, ?", bun.Ident("id"), bun.Ident("attachments")).
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx); err != nil &&
+			!errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		// Unset scheduled status ID on any attachments,
+		// they're either about to be used by the published
+		// status, or will be treated as unused by cleaner.
+		return updateScheduledStatusMedia(ctx, tx,
+			"",
+			deleted.MediaIDs,
+		)
+	}); err != nil {
+		return err
+	}
+
+	// Invalidate cached scheduled status by its ID.
+	s.state.Caches.DB.ScheduledStatus.Invalidate("ID", id)
+
+	// Invalidate the now updated media attachments.
+	s.state.Caches.DB.Media.InvalidateIDs("ID", deleted.MediaIDs)
+
+	return nil
+}
+
+func (s *scheduledStatusDB) DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error {
+	// Deleted partial models for cache invalidation.
+	var deleted []*gtsmodel.ScheduledStatus
+
+	// Delete scheduled statuses, returning subset of columns.
+	if err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Model(&deleted).
+			Returning("?, ?", bun.Ident("id"), bun.Ident("attachments")).
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Exec(ctx); err != nil &&
+			!errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		for _, status := range deleted {
+			// Unset scheduled status ID on any attachments.
+			if err := updateScheduledStatusMedia(ctx, tx,
+				"",
+				status.MediaIDs,
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Invalidate cached scheduled statuses by account ID.
+	s.state.Caches.DB.ScheduledStatus.Invalidate("AccountID", accountID)
+
+	// Invalidate the now updated media attachments.
+	for _, status := range deleted {
+		s.state.Caches.DB.Media.InvalidateIDs("ID", status.MediaIDs)
+	}
+
+	return nil
+}
+
+// updateScheduledStatusMedia sets the given scheduledStatusID
+// on all media attachments with given IDs, in transaction.
+func updateScheduledStatusMedia(
+	ctx context.Context,
+	tx bun.Tx,
+	scheduledStatusID string,
+	mediaIDs []string,
+) error {
+	if len(mediaIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Empty ID should be stored as NULL.
+	var value any = scheduledStatusID
+	if scheduledStatusID == "" {
+		value = nil
+	}
+
+	if _, err := tx.NewUpdate().
+		Table("media_attachments").
+		Set("? = ?", bun.Ident("scheduled_status_id"), value).
+		Where("? IN (?)", bun.Ident("id"), bun.In(mediaIDs)).
+		Exec(ctx); err != nil {
+		return gtserror.Newf("error updating scheduled status media: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScheduledStatusTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) newScheduledStatus(
+	account *gtsmodel.Account,
+	scheduledAt time.Time,
+	mediaIDs ...string,
+) *gtsmodel.ScheduledStatus {
+	return &gtsmodel.ScheduledStatus{
+		ID:            id.NewULID(),
+		AccountID:     account.ID,
+		ScheduledAt:   scheduledAt,
+		Text:          "hello world, from the future!",
+		MediaIDs:      mediaIDs,
+		Sensitive:     util.Ptr(false),
+		Visibility:    gtsmodel.VisibilityPublic,
+		LocalOnly:     util.Ptr(false),
+		ContentType:   gtsmodel.StatusContentTypePlain,
+		ApplicationID: suite.testApplications["application_1"].ID,
+		Poll: &gtsmodel.ScheduledStatusPoll{
+			Options:   []string{"yes", "no"},
+			ExpiresIn: 3600,
+		},
+	}
+}
+
+func (suite *ScheduledStatusTestSuite) TestPutGetScheduledStatus() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	media := suite.testAttachments["local_account_1_unattached_1"]
+	scheduledAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	scheduledStatus := suite.newScheduledStatus(account, scheduledAt, media.ID)
+	if err := suite.db.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Clear caches to ensure we go to the db.
+	suite.state.Caches.Init()
+
+	dbScheduledStatus, err := suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(scheduledStatus.Text, dbScheduledStatus.Text)
+	suite.True(scheduledStatus.ScheduledAt.Equal(dbScheduledStatus.ScheduledAt))
+	suite.Equal(scheduledStatus.Poll, dbScheduledStatus.Poll)
+	suite.Equal([]string{media.ID}, dbScheduledStatus.MediaIDs)
+	suite.Equal(account.ID, dbScheduledStatus.Account.ID)
+	suite.Equal(scheduledStatus.ApplicationID, dbScheduledStatus.Application.ID)
+	suite.Len(dbScheduledStatus.MediaAttachments, 1)
+
+	// Media should now be marked as belonging to the scheduled status.
+	dbMedia, err := suite.db.GetAttachmentByID(ctx, media.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(scheduledStatus.ID, dbMedia.ScheduledStatusID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestCountAndPageScheduledStatuses() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	tomorrow := time.Now().Add(24 * time.Hour)
+	nextWeek := time.Now().Add(7 * 24 * time.Hour)
+
+	for _, scheduledAt := range []time.Time{tomorrow, tomorrow, nextWeek} {
+		scheduledStatus := suite.newScheduledStatus(account, scheduledAt)
+		if err := suite.db.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	total, err := suite.db.CountScheduledStatusesForAcct(ctx, account.ID, time.Time{})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(3, total)
+
+	daily, err := suite.db.CountScheduledStatusesForAcct(ctx, account.ID, tomorrow)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, daily)
+
+	scheduledStatuses, err := suite.db.GetScheduledStatusesForAcct(ctx,
+		account.ID,
+		&paging.Page{Limit: 2},
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(scheduledStatuses, 2)
+	suite.Greater(scheduledStatuses[0].ID, scheduledStatuses[1].ID)
+
+	// Other accounts should have none.
+	_, err = suite.db.GetScheduledStatusesForAcct(ctx,
+		suite.testAccounts["local_account_2"].ID,
+		&paging.Page{Limit: 2},
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ScheduledStatusTestSuite) TestDeleteScheduledStatus() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	media := suite.testAttachments["local_account_1_unattached_1"]
+
+	scheduledStatus := suite.newScheduledStatus(account, time.Now().Add(time.Hour), media.ID)
+	if err := suite.db.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.DeleteScheduledStatusByID(ctx, scheduledStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Media should no longer be marked as belonging to the scheduled status.
+	dbMedia, err := suite.db.GetAttachmentByID(ctx, media.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbMedia.ScheduledStatusID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestDeleteScheduledStatusesByAccountID() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	for i := 0; i < 3; i++ {
+		scheduledStatus := suite.newScheduledStatus(account, time.Now().Add(time.Hour))
+		if err := suite.db.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	if err := suite.db.DeleteScheduledStatusesByAccountID(ctx, account.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	total, err := suite.db.CountScheduledStatusesForAcct(ctx, account.ID, time.Time{})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(total)
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
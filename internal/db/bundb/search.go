@@ -21,54 +21,40 @@
 	"context"
 	"strings"
 
+	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
 	"github.com/uptrace/bun"
-	"github.com/uptrace/bun/dialect"
 )
 
-// todo: currently we pass an 'offset' parameter into functions owned by this struct,
-// which is ignored.
+// searchDB implements full-text search of accounts and
+// statuses using a database-specific searchIndex, and
+// prefix search of tags and usernames using LIKE / ILIKE.
 //
-// The idea of 'offset' is to allow callers to page through results without supplying
-// maxID or minID params; they simply use the offset as more or less a 'page number'.
-// This works fine when you're dealing with something like Elasticsearch, but for
-// SQLite or Postgres 'LIKE' queries it doesn't really, because for each higher offset
-// you have to calculate the value of all the previous offsets as well *within the
-// execution time of the query*. It's MUCH more efficient to page using maxID and
-// minID for queries like this. For now, then, we just ignore the offset and hope that
-// the caller will page using maxID and minID instead.
-//
-// In future, however, it would be good to support offset in a way that doesn't totally
-// destroy database queries. One option would be to cache previous offsets when paging
-// down (which is the most common use case).
-//
-// For example, say a caller makes a call with offset 0: we run the query as normal,
-// and in a 10 minute cache or something, store the next maxID value as it would be for
-// offset 1, for the supplied query, limit, following, etc. Then when they call for
-// offset 1, instead of supplying 'offset' in the query and causing slowdown, we check
-// the cache to see if we have the next maxID value stored for that query, and use that
-// instead. If a caller out of the blue requests offset 4 or something, on an empty cache,
-// we could run the previous 4 queries and store the offsets for those before making the
-// 5th call for page 4.
-//
-// This isn't ideal, of course, but at least we could cover the most common use case of
-// a caller paging down through results.
+// Full-text search results are ordered by relevance, with
+// ties broken by ID (newest first). As relevance ordering
+// isn't compatible with paging via maxID and minID, callers
+// should use offset to page through full-text results, while
+// maxID and minID can be used to narrow the range of results.
 type searchDB struct {
 	db    *bun.DB
 	state *state.State
+	index searchIndex
 }
 
 // Query example (SQLite):
 //
 //	SELECT "account"."id" FROM "accounts" AS "account"
+//	JOIN "account_search_content" ON "account_search_content"."account_id" = "account"."id"
+//	JOIN "account_search" ON "account_search"."rowid" = "account_search_content"."id"
 //	WHERE (("account"."domain" IS NULL) OR ("account"."domain" != "account"."username"))
 //	AND ("account"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ("account"."id" IN (SELECT "target_account_id" FROM "follows" WHERE ("account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
-//	AND ((SELECT "account"."username" || COALESCE("account"."display_name", '') || COALESCE("account"."note", '') AS "account_text") LIKE '%turtle%' ESCAPE '\')
-//	ORDER BY "account"."id" DESC LIMIT 10
+//	AND ("account"."id" IN (SELECT "follow"."target_account_id" FROM "follows" AS "follow" WHERE ("follow"."account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
+//	AND ("account_search" MATCH '"turtle"*')
+//	ORDER BY bm25("account_search", 10.0, 1.0) ASC, "account"."id" DESC LIMIT 10
 func (s *searchDB) SearchForAccounts(
 	ctx context.Context,
 	accountID string,
@@ -83,12 +69,12 @@ func (s *searchDB) SearchForAccounts(
 	if limit < 0 {
 		limit = 0
 	}
+	if offset < 0 {
+		offset = 0
+	}
 
 	// Make educated guess for slice size
-	var (
-		accountIDs  = make([]string, 0, limit)
-		frontToBack = true
-	)
+	accountIDs := make([]string, 0, limit)
 
 	q := s.db.
 		NewSelect().
@@ -114,9 +100,6 @@ func (s *searchDB) SearchForAccounts(
 	if minID != "" {
 		// Return only items with a HIGHER id than minID.
 		q = q.Where("? > ?", bun.Ident("account.id"), minID)
-
-		// page up
-		frontToBack = false
 	}
 
 	if following {
@@ -136,23 +119,27 @@ func (s *searchDB) SearchForAccounts(
 		q = whereStartsLike(q, bun.Ident("account.username"), query)
 	} else {
 		// Query looks like arbitrary string.
-		// Search using LIKE for matches of query
-		// string within accountText subquery.
-		subQ := s.accountText(following)
-		q = whereLike(q, subQ, query)
+		// Search the index for accounts with
+		// names (and notes, if following)
+		// matching each term in the query.
+		terms := searchTerms(query)
+		if len(terms) == 0 {
+			return nil, nil
+		}
+		q = s.index.matchAccounts(q, terms, following)
 	}
 
+	// Break any ties with newest first.
+	q = q.Order("account.id DESC")
+
 	if limit > 0 {
 		// Limit amount of accounts returned.
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
-		// Page down.
-		q = q.Order("account.id DESC")
-	} else {
-		// Page up.
-		q = q.Order("account.id ASC")
+	if offset > 0 {
+		// Skip earlier pages of results.
+		q = q.Offset(offset)
 	}
 
 	if err := q.Scan(ctx, &accountIDs); err != nil {
@@ -163,15 +150,6 @@ func (s *searchDB) SearchForAccounts(
 		return nil, nil
 	}
 
-	// If we're paging up, we still want accounts
-	// to be sorted by ID desc, so reverse ids slice.
-	// https://zchee.github.io/golang-wiki/SliceTricks/#reversing
-	if !frontToBack {
-		for l, r := 0, len(accountIDs)-1; l < r; l, r = l+1, r-1 {
-			accountIDs[l], accountIDs[r] = accountIDs[r], accountIDs[l]
-		}
-	}
-
 	accounts := make([]*gtsmodel.Account, 0, len(accountIDs))
 	for _, id := range accountIDs {
 		// Fetch account from db for ID
@@ -198,77 +176,23 @@ func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 		Where("? = ?", bun.Ident("follow.account_id"), accountID)
 }
 
-// accountText returns a subquery that selects a concatenation
-// of account username and display name as "account_text". If
-// `following` is true, then account note will also be included
-// in the concatenation.
-func (s *searchDB) accountText(following bool) *bun.SelectQuery {
-	var (
-		accountText = s.db.NewSelect()
-		query       string
-		args        []interface{}
-	)
-
-	if following {
-		// If querying for accounts we follow,
-		// include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account.note"), "",
-			bun.Ident("account_text"),
-		}
-	} else {
-		// If querying for accounts we're not following,
-		// don't include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account_text"),
-		}
-	}
-
-	// SQLite and Postgres use different syntaxes for
-	// concatenation, and we also need to use a
-	// different number of placeholders depending on
-	// following/not following. COALESCE calls ensure
-	// that we're not trying to concatenate null values.
-
-	switch d := s.db.Dialect().Name(); {
-
-	case d == dialect.SQLite && following:
-		query = "? || COALESCE(?, ?) || COALESCE(?, ?) AS ?"
-
-	case d == dialect.SQLite && !following:
-		query = "? || COALESCE(?, ?) AS ?"
-
-	case d == dialect.PG && following:
-		query = "CONCAT(?, COALESCE(?, ?), COALESCE(?, ?)) AS ?"
-
-	case d == dialect.PG && !following:
-		query = "CONCAT(?, COALESCE(?, ?)) AS ?"
-
-	default:
-		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
-	}
-
-	return accountText.ColumnExpr(query, args...)
-}
-
 // Query example (SQLite):
 //
 //	SELECT "status"."id"
 //	FROM "statuses" AS "status"
+//	JOIN "status_search_content" ON "status_search_content"."status_id" = "status"."id"
+//	JOIN "status_search" ON "status_search"."rowid" = "status_search_content"."id"
 //	WHERE ("status"."boost_of_id" IS NULL)
 //	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
 //	AND ("status"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ((SELECT "status"."content" || COALESCE("status"."content_warning", '') AS "status_text") LIKE '%hello%' ESCAPE '\')
-//	ORDER BY "status"."id" DESC LIMIT 10
+//	AND ("status"."poll_id" IS NOT NULL)
+//	AND ("status"."id" NOT IN (SELECT "status_search_content"."status_id" FROM "status_search_content" JOIN "status_search" ON "status_search"."rowid" = "status_search_content"."id" WHERE ("status_search" MATCH '"goodbye"')))
+//	AND ("status_search" MATCH '"hello world"')
+//	ORDER BY "status_search"."rank" ASC, "status"."id" DESC LIMIT 10
 func (s *searchDB) SearchForStatuses(
 	ctx context.Context,
 	requestingAccountID string,
-	query string,
-	fromAccountID string,
+	params *db.StatusSearchParams,
 	maxID string,
 	minID string,
 	limit int,
@@ -278,12 +202,25 @@ func (s *searchDB) SearchForStatuses(
 	if limit < 0 {
 		limit = 0
 	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	if params.Empty() {
+		// Nothing to search for.
+		return nil, nil
+	}
+
+	// Split terms into phrases of searchable words.
+	phrases := searchPhrases(params.Terms)
+	if len(params.Terms) > 0 && len(phrases) == 0 {
+		// Terms were given but none
+		// of them can match anything.
+		return nil, nil
+	}
 
 	// Make educated guess for slice size
-	var (
-		statusIDs   = make([]string, 0, limit)
-		frontToBack = true
-	)
+	statusIDs := make([]string, 0, limit)
 
 	q := s.db.
 		NewSelect().
@@ -291,16 +228,28 @@ func (s *searchDB) SearchForStatuses(
 		// Select only IDs from table
 		Column("status.id").
 		// Ignore boosts.
-		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? IS NULL", bun.Ident("status.boost_of_id"))
+
+	if params.InLibrary {
+		// Select statuses created by accountID, replying to
+		// accountID, faved or bookmarked by accountID, or
+		// mentioning accountID.
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("status.account_id"), requestingAccountID).
+				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), requestingAccountID).
+				WhereOr("? IN (?)", bun.Ident("status.id"), s.libraryStatuses("status_faves", "account_id", requestingAccountID)).
+				WhereOr("? IN (?)", bun.Ident("status.id"), s.libraryStatuses("status_bookmarks", "account_id", requestingAccountID)).
+				WhereOr("? IN (?)", bun.Ident("status.id"), s.libraryStatuses("mentions", "target_account_id", requestingAccountID))
+		})
+	} else {
 		// Select only statuses created by
 		// accountID or replying to accountID.
-		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
 			return q.
 				Where("? = ?", bun.Ident("status.account_id"), requestingAccountID).
 				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), requestingAccountID)
 		})
-	if fromAccountID != "" {
-		q = q.Where("? = ?", bun.Ident("status.account_id"), fromAccountID)
 	}
 
 	// Return only items with a LOWER id than maxID.
@@ -312,29 +261,36 @@ func (s *searchDB) SearchForStatuses(
 	if minID != "" {
 		// return only statuses HIGHER (ie., newer) than minID
 		q = q.Where("? > ?", bun.Ident("status.id"), minID)
+	}
 
-		// page up
-		frontToBack = false
+	// Apply any operator filters.
+	q = s.filterStatuses(q, params)
+
+	if len(params.ExcludeTerms) > 0 {
+		// Drop statuses matching any
+		// of the terms we don't want.
+		if excl := searchPhrases(params.ExcludeTerms); len(excl) > 0 {
+			q = s.index.excludeStatuses(q, excl)
+		}
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
+	if len(phrases) > 0 {
+		// Search the index for statuses
+		// matching each phrase in the query.
+		q = s.index.matchStatuses(q, phrases)
+	}
 
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
+	// Break any ties with newest first.
+	q = q.Order("status.id DESC")
 
 	if limit > 0 {
 		// Limit amount of statuses returned.
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
-		// Page down.
-		q = q.Order("status.id DESC")
-	} else {
-		// Page up.
-		q = q.Order("status.id ASC")
+	if offset > 0 {
+		// Skip earlier pages of results.
+		q = q.Offset(offset)
 	}
 
 	if err := q.Scan(ctx, &statusIDs); err != nil {
@@ -345,15 +301,6 @@ func (s *searchDB) SearchForStatuses(
 		return nil, nil
 	}
 
-	// If we're paging up, we still want statuses
-	// to be sorted by ID desc, so reverse ids slice.
-	// https://zchee.github.io/golang-wiki/SliceTricks/#reversing
-	if !frontToBack {
-		for l, r := 0, len(statusIDs)-1; l < r; l, r = l+1, r-1 {
-			statusIDs[l], statusIDs[r] = statusIDs[r], statusIDs[l]
-		}
-	}
-
 	statuses := make([]*gtsmodel.Status, 0, len(statusIDs))
 	for _, id := range statusIDs {
 		// Fetch status from db for ID
@@ -370,32 +317,133 @@ func (s *searchDB) SearchForStatuses(
 	return statuses, nil
 }
 
-// statusText returns a subquery that selects a concatenation
-// of status content and content warning as "status_text".
-func (s *searchDB) statusText() *bun.SelectQuery {
-	statusText := s.db.NewSelect()
+// libraryStatuses returns a subquery selecting the status IDs
+// from table where column is equal to accountID, used to find
+// statuses faved, bookmarked, or mentioned in by an account.
+func (s *searchDB) libraryStatuses(table string, column string, accountID string) *bun.SelectQuery {
+	return s.db.
+		NewSelect().
+		Table(table).
+		Column("status_id").
+		Where("? = ?", bun.Ident(column), accountID)
+}
+
+// filterStatuses applies the non-text
+// criteria of params to the given query.
+func (s *searchDB) filterStatuses(q *bun.SelectQuery, params *db.StatusSearchParams) *bun.SelectQuery {
+	if len(params.AccountIDs) > 0 {
+		q = q.Where("? IN (?)", bun.Ident("status.account_id"), bun.In(params.AccountIDs))
+	}
+
+	if len(params.ExcludeAccountIDs) > 0 {
+		q = q.Where("? NOT IN (?)", bun.Ident("status.account_id"), bun.In(params.ExcludeAccountIDs))
+	}
+
+	if len(params.Languages) > 0 {
+		q = q.Where("? IN (?)", bun.Ident("status.language"), bun.In(params.Languages))
+	}
+
+	if len(params.ExcludeLanguages) > 0 {
+		// Statuses with no language
+		// aren't in any excluded one.
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("status.language")).
+				WhereOr("? NOT IN (?)", bun.Ident("status.language"), bun.In(params.ExcludeLanguages))
+		})
+	}
+
+	if params.HasMedia != nil {
+		if *params.HasMedia {
+			q = qMediaOnly(q)
+		} else {
+			q = q.WhereGroup(" AND NOT ", qMediaOnly)
+		}
+	}
+
+	if params.HasPoll != nil {
+		if *params.HasPoll {
+			q = q.Where("? IS NOT NULL", bun.Ident("status.poll_id"))
+		} else {
+			q = q.Where("? IS NULL", bun.Ident("status.poll_id"))
+		}
+	}
+
+	if params.HasLink != nil {
+		q = s.index.whereStatusHasLink(q, *params.HasLink)
+	}
+
+	if params.IsReply != nil {
+		if *params.IsReply {
+			q = q.Where("? IS NOT NULL", bun.Ident("status.in_reply_to_id"))
+		} else {
+			q = q.Where("? IS NULL", bun.Ident("status.in_reply_to_id"))
+		}
+	}
+
+	if params.IsSensitive != nil {
+		q = q.Where("? = ?", bun.Ident("status.sensitive"), *params.IsSensitive)
+	}
+
+	if !params.Before.IsZero() {
+		q = q.Where("? < ?", bun.Ident("status.created_at"), params.Before)
+	}
+
+	if !params.After.IsZero() {
+		q = q.Where("? >= ?", bun.Ident("status.created_at"), params.After)
+	}
+
+	return q
+}
+
+func (s *searchDB) IndexAccount(ctx context.Context, account *gtsmodel.Account) error {
+	// Index username and display
+	// name together as the name.
+	name := account.Username
+	if account.DisplayName != "" {
+		name += " " + account.DisplayName
+	}
+
+	// Note is stored as HTML,
+	// so index plaintext only.
+	note := text.ParseHTMLToPlain(account.Note)
 
-	// SQLite and Postgres use different
-	// syntaxes for concatenation.
-	switch d := s.db.Dialect().Name(); d {
+	return s.index.indexAccount(ctx, account.ID, name, note)
+}
+
+func (s *searchDB) DeindexAccount(ctx context.Context, accountID string) error {
+	return s.index.deindexAccount(ctx, accountID)
+}
 
-	case dialect.SQLite:
-		statusText = statusText.ColumnExpr(
-			"? || COALESCE(?, ?) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+func (s *searchDB) IndexStatus(ctx context.Context, status *gtsmodel.Status) error {
+	if status.BoostOfID != "" {
+		// Boosts have no text
+		// of their own to index.
+		return nil
+	}
 
-	case dialect.PG:
-		statusText = statusText.ColumnExpr(
-			"CONCAT(?, COALESCE(?, ?)) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+	// Index content warning and
+	// content as plaintext only.
+	statusText := strings.TrimSpace(
+		text.ParseHTMLToPlain(status.ContentWarning) + "\n" +
+			text.ParseHTMLToPlain(status.Content),
+	)
 
-	default:
-		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+	if statusText == "" {
+		// Nothing searchable, ensure
+		// no stale text left behind.
+		return s.index.deindexStatus(ctx, status.ID)
 	}
 
-	return statusText
+	// Note whether status links anywhere,
+	// ignoring mentions and hashtags.
+	hasLink := len(text.FindLinks(status.Content)) > 0
+
+	return s.index.indexStatus(ctx, status.ID, statusText, hasLink)
+}
+
+func (s *searchDB) DeindexStatus(ctx context.Context, statusID string) error {
+	return s.index.deindexStatus(ctx, statusID)
 }
 
 // Query example (SQLite):
@@ -455,6 +503,11 @@ func (s *searchDB) SearchForTags(
 		q = q.Limit(limit)
 	}
 
+	if offset > 0 {
+		// Skip earlier pages of results.
+		q = q.Offset(offset)
+	}
+
 	if frontToBack {
 		// Page down.
 		q = q.Order("tag.id DESC")
@@ -20,8 +20,13 @@
 import (
 	"context"
 	"testing"
+	"time"
 
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -107,7 +112,7 @@ func (suite *SearchTestSuite) TestSearchAccountsFossAny() {
 func (suite *SearchTestSuite) TestSearchStatuses() {
 	testAccount := suite.testAccounts["local_account_1"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchParams{Terms: []string{"hello"}}, "", "", 10, 0)
 	suite.NoError(err)
 	suite.Len(statuses, 1)
 }
@@ -116,13 +121,274 @@ func (suite *SearchTestSuite) TestSearchStatusesFromAccount() {
 	testAccount := suite.testAccounts["local_account_1"]
 	fromAccount := suite.testAccounts["local_account_2"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", fromAccount.ID, "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchParams{Terms: []string{"hi"}, AccountIDs: []string{fromAccount.ID}}, "", "", 10, 0)
 	suite.NoError(err)
 	if suite.Len(statuses, 1) {
 		suite.Equal(fromAccount.ID, statuses[0].AccountID)
 	}
 }
 
+func (suite *SearchTestSuite) TestSearchStatusesFromAccountNoText() {
+	testAccount := suite.testAccounts["local_account_1"]
+	fromAccount := suite.testAccounts["local_account_2"]
+
+	// Query with only a from: operator
+	// should return all statuses from
+	// the account, rather than none.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchParams{AccountIDs: []string{fromAccount.ID}}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+	for _, status := range statuses {
+		suite.Equal(fromAccount.ID, status.AccountID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesIgnoresMarkup() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Mentions of zork are wrapped in <span> and <a href>,
+	// but only the text content of statuses is indexed.
+	for _, query := range []string{"href", "nofollow", "h-card"} {
+		statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchParams{Terms: []string{query}}, "", "", 10, 0)
+		suite.NoError(err)
+		suite.Empty(statuses, query)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesRelevance() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Older status that mentions
+	// snails a lot, and newer
+	// status that only mentions
+	// snails in passing.
+	snails := suite.putStatus(testAccount, "<p>snails snails snails!</p>")
+	turtles := suite.putStatus(testAccount, "<p>i like Snails, but not as much as i like turtles</p>")
+
+	// Most relevant
+	// status first.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"snails"}}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 2) {
+		suite.Equal(snails.ID, statuses[0].ID)
+		suite.Equal(turtles.ID, statuses[1].ID)
+	}
+
+	// Page through using offset.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"snails"}}, "", "", 1, 1)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(turtles.ID, statuses[0].ID)
+	}
+
+	// All terms must match.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"snails", "turtles"}}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(turtles.ID, statuses[0].ID)
+	}
+
+	// Deindexed statuses
+	// shouldn't be found.
+	suite.NoError(suite.db.DeindexStatus(ctx, snails.ID))
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"snails"}}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(turtles.ID, statuses[0].ID)
+	}
+
+	// Edited statuses should
+	// be found by new text.
+	turtles.Content = "<p>i've changed my mind about snails</p>"
+	suite.NoError(suite.db.IndexStatus(ctx, turtles))
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"turtles"}}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesPhrases() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	likesTurtles := suite.putStatus(testAccount, "<p>snails like turtles</p>")
+	likesSnails := suite.putStatus(testAccount, "<p>turtles like snails</p>")
+
+	// Words match in any order.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"like", "turtles"}}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	// Phrases must match in order.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"like turtles"}}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(likesTurtles.ID, statuses[0].ID)
+	}
+
+	// Excluded phrases drop matches.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"snails"}, ExcludeTerms: []string{"like turtles"}}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(likesSnails.ID, statuses[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesHasLink() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	link := suite.putStatus(testAccount, `<p>snails <a href="https://example.org/snails" rel="nofollow noreferrer noopener" target="_blank">here</a></p>`)
+	mention := suite.putStatus(testAccount, `<p><span class="h-card"><a href="http://localhost:8080/@1happyturtle" class="u-url mention" rel="nofollow noreferrer noopener" target="_blank">@<span>1happyturtle</span></a></span> snails</p>`)
+
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"snails"}, HasLink: util.Ptr(true)}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(link.ID, statuses[0].ID)
+	}
+
+	// Mentions aren't links.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{Terms: []string{"snails"}, HasLink: util.Ptr(false)}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(mention.ID, statuses[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesFilters() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		from        = []string{testAccount.ID}
+	)
+
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{AccountIDs: from, HasMedia: util.Ptr(true)}, "", "", 0, 0)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+	for _, status := range statuses {
+		suite.NotEmpty(status.AttachmentIDs)
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{AccountIDs: from, HasMedia: util.Ptr(false)}, "", "", 0, 0)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+	for _, status := range statuses {
+		suite.Empty(status.AttachmentIDs)
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{AccountIDs: from, IsReply: util.Ptr(true)}, "", "", 0, 0)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+	for _, status := range statuses {
+		suite.NotEmpty(status.InReplyToID)
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{AccountIDs: from, IsSensitive: util.Ptr(true)}, "", "", 0, 0)
+	suite.NoError(err)
+	for _, status := range statuses {
+		suite.True(*status.Sensitive)
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{AccountIDs: from, Languages: []string{"en"}}, "", "", 0, 0)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+	for _, status := range statuses {
+		suite.Equal("en", status.Language)
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{AccountIDs: from, Before: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}, "", "", 0, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{AccountIDs: from, After: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}, "", "", 0, 0)
+	suite.NoError(err)
+	suite.NotEmpty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesInLibrary() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		faved       = suite.testStatuses["admin_account_status_1"]
+	)
+
+	// Admin status faved by zork isn't
+	// normally in the scope of search.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{AccountIDs: []string{faved.AccountID}}, "", "", 0, 0)
+	suite.NoError(err)
+	suite.NotContains(statusIDs(statuses), faved.ID)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchParams{AccountIDs: []string{faved.AccountID}, InLibrary: true}, "", "", 0, 0)
+	suite.NoError(err)
+	suite.Contains(statusIDs(statuses), faved.ID)
+}
+
+func (suite *SearchTestSuite) TestSearchAccountsUpdated() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		account     = new(gtsmodel.Account)
+	)
+
+	*account = *suite.testAccounts["local_account_2"]
+	account.DisplayName = "slow snail"
+	suite.NoError(suite.db.IndexAccount(ctx, account))
+
+	// Old display name is no longer searchable.
+	accounts, err := suite.db.SearchForAccounts(ctx, testAccount.ID, "turtle", "", "", 10, false, 0)
+	suite.NoError(err)
+	suite.Empty(accounts)
+
+	// New display name matches by prefix.
+	accounts, err = suite.db.SearchForAccounts(ctx, testAccount.ID, "sna", "", "", 10, false, 0)
+	suite.NoError(err)
+	if suite.Len(accounts, 1) {
+		suite.Equal(account.ID, accounts[0].ID)
+	}
+
+	// Deindexed accounts
+	// shouldn't be found.
+	suite.NoError(suite.db.DeindexAccount(ctx, account.ID))
+	accounts, err = suite.db.SearchForAccounts(ctx, testAccount.ID, "snail", "", "", 10, false, 0)
+	suite.NoError(err)
+	suite.Empty(accounts)
+}
+
+// putStatus stores and indexes a new
+// status with the given content by account.
+func (suite *SearchTestSuite) putStatus(account *gtsmodel.Account, content string) *gtsmodel.Status {
+	statusID := id.NewULID()
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 account.URI + "/statuses/" + statusID,
+		URL:                 account.URL + "/statuses/" + statusID,
+		Content:             content,
+		ContentType:         gtsmodel.StatusContentTypePlain,
+		Local:               util.Ptr(true),
+		AccountURI:          account.URI,
+		AccountID:           account.ID,
+		Visibility:          gtsmodel.VisibilityPublic,
+		Federated:           util.Ptr(true),
+		ActivityStreamsType: ap.ObjectNote,
+	}
+
+	if err := suite.db.PutStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.IndexStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
 func (suite *SearchTestSuite) TestSearchTags() {
 	// Search with full tag string.
 	tags, err := suite.db.SearchForTags(context.Background(), "welcome", "", "", 10, 0)
@@ -143,3 +409,12 @@ func (suite *SearchTestSuite) TestSearchTags() {
 func TestSearchTestSuite(t *testing.T) {
 	suite.Run(t, new(SearchTestSuite))
 }
+
+// statusIDs returns the IDs of the given statuses.
+func statusIDs(statuses []*gtsmodel.Status) []string {
+	ids := make([]string, len(statuses))
+	for i, status := range statuses {
+		ids[i] = status.ID
+	}
+	return ids
+}
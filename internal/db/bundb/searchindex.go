@@ -0,0 +1,411 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// searchIndex abstracts over the different full-text search
+// index implementations available to searchDB, allowing each
+// database backend to use its own native full-text tooling.
+//
+// Index contents are keyed by the ID of the indexed account
+// or status, and contain only the plaintext extracted from it.
+// Callers are responsible for joining matches back to the
+// accounts or statuses tables to apply any further filtering.
+type searchIndex interface {
+	// indexAccount inserts or updates the
+	// indexed name and note of account with ID.
+	indexAccount(ctx context.Context, accountID string, name string, note string) error
+
+	// deindexAccount removes account with ID from the index.
+	deindexAccount(ctx context.Context, accountID string) error
+
+	// indexStatus inserts or updates the indexed text
+	// of status with ID, and whether it contains links.
+	indexStatus(ctx context.Context, statusID string, text string, hasLink bool) error
+
+	// deindexStatus removes status with ID from the index.
+	deindexStatus(ctx context.Context, statusID string) error
+
+	// matchAccounts joins the index onto given query (which
+	// must select from "accounts" AS "account"), restricting
+	// it to accounts that match all of the given terms as
+	// prefixes, ordered by relevance. If includeNote is true,
+	// account notes will be matched as well as names.
+	matchAccounts(q *bun.SelectQuery, terms []string, includeNote bool) *bun.SelectQuery
+
+	// matchStatuses joins the index onto given query (which
+	// must select from "statuses" AS "status"), restricting
+	// it to statuses that match all of the given phrases,
+	// ordered by relevance. Each phrase is a slice of terms
+	// that must appear consecutively in the status text.
+	matchStatuses(q *bun.SelectQuery, phrases [][]string) *bun.SelectQuery
+
+	// excludeStatuses restricts the given query (which must
+	// select from "statuses" AS "status") to statuses that
+	// match none of the given phrases.
+	excludeStatuses(q *bun.SelectQuery, phrases [][]string) *bun.SelectQuery
+
+	// whereStatusHasLink restricts the given query (which must
+	// select from "statuses" AS "status") to statuses that do
+	// or don't contain links, depending on hasLink.
+	whereStatusHasLink(q *bun.SelectQuery, hasLink bool) *bun.SelectQuery
+}
+
+// newSearchIndex returns the appropriate
+// searchIndex implementation for db's dialect.
+func newSearchIndex(db *bun.DB) searchIndex {
+	switch d := db.Dialect().Name(); d {
+	case dialect.SQLite:
+		return &sqliteSearchIndex{db: db}
+	case dialect.PG:
+		return &postgresSearchIndex{db: db}
+	default:
+		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
+
+// searchTerms splits the given query into lowercase
+// search terms, on any character that isn't a letter
+// or a number. This matches what both the FTS5 unicode61
+// tokenizer and Postgres' simple text search config
+// consider to be word boundaries, and ensures that no
+// query syntax from the caller makes it to the index.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(
+		strings.ToLower(query),
+		func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		},
+	)
+}
+
+// searchPhrases splits each of the given
+// query terms into a phrase with searchTerms,
+// dropping any that contain no searchable words.
+func searchPhrases(terms []string) [][]string {
+	phrases := make([][]string, 0, len(terms))
+	for _, term := range terms {
+		if phrase := searchTerms(term); len(phrase) > 0 {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases
+}
+
+// singleWords wraps each of the given
+// terms as a phrase containing one word.
+func singleWords(terms []string) [][]string {
+	phrases := make([][]string, len(terms))
+	for i, term := range terms {
+		phrases[i] = []string{term}
+	}
+	return phrases
+}
+
+// whereStatusIn restricts the given query to statuses
+// with an ID in (or not in, if not is true) the given
+// subquery, which must select only status IDs.
+func whereStatusIn(q *bun.SelectQuery, subq *bun.SelectQuery, not bool) *bun.SelectQuery {
+	if not {
+		return q.Where("? NOT IN (?)", bun.Ident("status.id"), subq)
+	}
+	return q.Where("? IN (?)", bun.Ident("status.id"), subq)
+}
+
+// sqliteSearchIndex implements searchIndex using SQLite FTS5
+// virtual tables. Each virtual table is backed by an external
+// content table keyed by account / status ID, which is kept in
+// sync with the virtual table by triggers. See the migration
+// 20250505120000_search_index for table and trigger definitions.
+type sqliteSearchIndex struct{ db *bun.DB }
+
+func (i *sqliteSearchIndex) indexAccount(ctx context.Context, accountID string, name string, note string) error {
+	_, err := i.db.NewRaw(
+		"INSERT INTO ? (?, ?, ?) VALUES (?, ?, ?) "+
+			"ON CONFLICT (?) DO UPDATE SET ? = ?, ? = ?",
+		bun.Ident("account_search_content"),
+		bun.Ident("account_id"), bun.Ident("name"), bun.Ident("note"),
+		accountID, name, note,
+		bun.Ident("account_id"),
+		bun.Ident("name"), bun.Ident("excluded.name"),
+		bun.Ident("note"), bun.Ident("excluded.note"),
+	).Exec(ctx)
+	return err
+}
+
+func (i *sqliteSearchIndex) deindexAccount(ctx context.Context, accountID string) error {
+	_, err := i.db.NewDelete().
+		Table("account_search_content").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (i *sqliteSearchIndex) indexStatus(ctx context.Context, statusID string, text string, hasLink bool) error {
+	_, err := i.db.NewRaw(
+		"INSERT INTO ? (?, ?, ?) VALUES (?, ?, ?) "+
+			"ON CONFLICT (?) DO UPDATE SET ? = ?, ? = ?",
+		bun.Ident("status_search_content"),
+		bun.Ident("status_id"), bun.Ident("text"), bun.Ident("has_link"),
+		statusID, text, hasLink,
+		bun.Ident("status_id"),
+		bun.Ident("text"), bun.Ident("excluded.text"),
+		bun.Ident("has_link"), bun.Ident("excluded.has_link"),
+	).Exec(ctx)
+	return err
+}
+
+func (i *sqliteSearchIndex) deindexStatus(ctx context.Context, statusID string) error {
+	_, err := i.db.NewDelete().
+		Table("status_search_content").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+func (i *sqliteSearchIndex) matchAccounts(q *bun.SelectQuery, terms []string, includeNote bool) *bun.SelectQuery {
+	match := sqliteMatchExpr(singleWords(terms), " ", true)
+	if !includeNote {
+		// Restrict match to the name column only.
+		match = "name : (" + match + ")"
+	}
+
+	return q.
+		Join(
+			"JOIN ? ON ? = ?",
+			bun.Ident("account_search_content"),
+			bun.Ident("account_search_content.account_id"),
+			bun.Ident("account.id"),
+		).
+		Join(
+			"JOIN ? ON ? = ?",
+			bun.Ident("account_search"),
+			bun.Ident("account_search.rowid"),
+			bun.Ident("account_search_content.id"),
+		).
+		Where("? MATCH ?", bun.Ident("account_search"), match).
+		// Weight name matches above note matches.
+		OrderExpr("bm25(?, 10.0, 1.0) ASC", bun.Ident("account_search"))
+}
+
+func (i *sqliteSearchIndex) matchStatuses(q *bun.SelectQuery, phrases [][]string) *bun.SelectQuery {
+	return q.
+		Join(
+			"JOIN ? ON ? = ?",
+			bun.Ident("status_search_content"),
+			bun.Ident("status_search_content.status_id"),
+			bun.Ident("status.id"),
+		).
+		Join(
+			"JOIN ? ON ? = ?",
+			bun.Ident("status_search"),
+			bun.Ident("status_search.rowid"),
+			bun.Ident("status_search_content.id"),
+		).
+		Where("? MATCH ?", bun.Ident("status_search"), sqliteMatchExpr(phrases, " ", false)).
+		OrderExpr("? ASC", bun.Ident("status_search.rank"))
+}
+
+func (i *sqliteSearchIndex) excludeStatuses(q *bun.SelectQuery, phrases [][]string) *bun.SelectQuery {
+	subq := i.db.NewSelect().
+		Table("status_search_content").
+		Column("status_search_content.status_id").
+		Join(
+			"JOIN ? ON ? = ?",
+			bun.Ident("status_search"),
+			bun.Ident("status_search.rowid"),
+			bun.Ident("status_search_content.id"),
+		).
+		Where("? MATCH ?", bun.Ident("status_search"), sqliteMatchExpr(phrases, " OR ", false))
+	return whereStatusIn(q, subq, true)
+}
+
+func (i *sqliteSearchIndex) whereStatusHasLink(q *bun.SelectQuery, hasLink bool) *bun.SelectQuery {
+	subq := i.db.NewSelect().
+		Table("status_search_content").
+		Column("status_search_content.status_id").
+		Where("? = ?", bun.Ident("status_search_content.has_link"), true)
+	return whereStatusIn(q, subq, !hasLink)
+}
+
+// sqliteMatchExpr builds an FTS5 MATCH expression from the
+// given phrases joined by sep, which should be either " "
+// (all phrases) or " OR " (any phrase). Phrases are quoted
+// as strings so they're not interpreted as FTS5 syntax.
+// If prefix is true, phrases will match as prefixes.
+func sqliteMatchExpr(phrases [][]string, sep string, prefix bool) string {
+	var b strings.Builder
+	for i, phrase := range phrases {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteByte('"')
+		b.WriteString(strings.ReplaceAll(strings.Join(phrase, " "), `"`, `""`))
+		b.WriteByte('"')
+		if prefix {
+			b.WriteByte('*')
+		}
+	}
+	return b.String()
+}
+
+// postgresSearchIndex implements searchIndex using Postgres
+// tsvector columns with GIN indexes, using the language-agnostic
+// 'simple' text search configuration. See the migration
+// 20250505120000_search_index for table and index definitions.
+type postgresSearchIndex struct{ db *bun.DB }
+
+func (i *postgresSearchIndex) indexAccount(ctx context.Context, accountID string, name string, note string) error {
+	_, err := i.db.NewRaw(
+		"INSERT INTO ? (?, ?, ?) VALUES (?, to_tsvector('simple', ?), to_tsvector('simple', ?)) "+
+			"ON CONFLICT (?) DO UPDATE SET ? = ?, ? = ?",
+		bun.Ident("account_search"),
+		bun.Ident("account_id"), bun.Ident("name"), bun.Ident("note"),
+		accountID, name, note,
+		bun.Ident("account_id"),
+		bun.Ident("name"), bun.Ident("excluded.name"),
+		bun.Ident("note"), bun.Ident("excluded.note"),
+	).Exec(ctx)
+	return err
+}
+
+func (i *postgresSearchIndex) deindexAccount(ctx context.Context, accountID string) error {
+	_, err := i.db.NewDelete().
+		Table("account_search").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (i *postgresSearchIndex) indexStatus(ctx context.Context, statusID string, text string, hasLink bool) error {
+	_, err := i.db.NewRaw(
+		"INSERT INTO ? (?, ?, ?) VALUES (?, to_tsvector('simple', ?), ?) "+
+			"ON CONFLICT (?) DO UPDATE SET ? = ?, ? = ?",
+		bun.Ident("status_search"),
+		bun.Ident("status_id"), bun.Ident("document"), bun.Ident("has_link"),
+		statusID, text, hasLink,
+		bun.Ident("status_id"),
+		bun.Ident("document"), bun.Ident("excluded.document"),
+		bun.Ident("has_link"), bun.Ident("excluded.has_link"),
+	).Exec(ctx)
+	return err
+}
+
+func (i *postgresSearchIndex) deindexStatus(ctx context.Context, statusID string) error {
+	_, err := i.db.NewDelete().
+		Table("status_search").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+func (i *postgresSearchIndex) matchAccounts(q *bun.SelectQuery, terms []string, includeNote bool) *bun.SelectQuery {
+	tsquery := i.db.NewRaw("to_tsquery('simple', ?)", postgresQueryExpr(singleWords(terms), " & ", true))
+
+	q = q.Join(
+		"JOIN ? ON ? = ?",
+		bun.Ident("account_search"),
+		bun.Ident("account_search.account_id"),
+		bun.Ident("account.id"),
+	)
+
+	if includeNote {
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? @@ ?", bun.Ident("account_search.name"), tsquery).
+				WhereOr("? @@ ?", bun.Ident("account_search.note"), tsquery)
+		})
+	} else {
+		q = q.Where("? @@ ?", bun.Ident("account_search.name"), tsquery)
+	}
+
+	// Rank name matches above note matches.
+	return q.
+		OrderExpr("ts_rank(?, ?) DESC", bun.Ident("account_search.name"), tsquery).
+		OrderExpr("ts_rank(?, ?) DESC", bun.Ident("account_search.note"), tsquery)
+}
+
+func (i *postgresSearchIndex) matchStatuses(q *bun.SelectQuery, phrases [][]string) *bun.SelectQuery {
+	tsquery := i.db.NewRaw("to_tsquery('simple', ?)", postgresQueryExpr(phrases, " & ", false))
+
+	return q.
+		Join(
+			"JOIN ? ON ? = ?",
+			bun.Ident("status_search"),
+			bun.Ident("status_search.status_id"),
+			bun.Ident("status.id"),
+		).
+		Where("? @@ ?", bun.Ident("status_search.document"), tsquery).
+		OrderExpr("ts_rank(?, ?) DESC", bun.Ident("status_search.document"), tsquery)
+}
+
+func (i *postgresSearchIndex) excludeStatuses(q *bun.SelectQuery, phrases [][]string) *bun.SelectQuery {
+	subq := i.db.NewSelect().
+		Table("status_search").
+		Column("status_search.status_id").
+		Where("? @@ to_tsquery('simple', ?)",
+			bun.Ident("status_search.document"),
+			postgresQueryExpr(phrases, " | ", false),
+		)
+	return whereStatusIn(q, subq, true)
+}
+
+func (i *postgresSearchIndex) whereStatusHasLink(q *bun.SelectQuery, hasLink bool) *bun.SelectQuery {
+	subq := i.db.NewSelect().
+		Table("status_search").
+		Column("status_search.status_id").
+		Where("? = ?", bun.Ident("status_search.has_link"), true)
+	return whereStatusIn(q, subq, !hasLink)
+}
+
+// postgresQueryExpr builds a to_tsquery() expression from
+// the given phrases joined by sep, which should be either
+// " & " (all phrases) or " | " (any phrase). Terms are
+// expected to already be split on non-word characters
+// by searchTerms, so they can't contain any tsquery
+// operators. If prefix is true, terms will match as prefixes.
+func postgresQueryExpr(phrases [][]string, sep string, prefix bool) string {
+	var b strings.Builder
+	for i, phrase := range phrases {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteByte('(')
+		for j, term := range phrase {
+			if j > 0 {
+				b.WriteString(" <-> ")
+			}
+			b.WriteString(term)
+			if prefix {
+				b.WriteString(":*")
+			}
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
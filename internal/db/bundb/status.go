@@ -242,6 +242,30 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteOfID != "" {
+		if status.QuoteOf == nil {
+			// Quoted status is not set, fetch from database.
+			status.QuoteOf, err = s.GetStatusByID(
+				gtscontext.SetBarebones(ctx),
+				status.QuoteOfID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error populating quoted status: %w", err)
+			}
+		}
+
+		if status.QuoteOfAccount == nil {
+			// Quoted status author is not set, fetch from database.
+			status.QuoteOfAccount, err = s.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				status.QuoteOfAccountID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error populating quoted status author: %w", err)
+			}
+		}
+	}
+
 	if status.PollID != "" && status.Poll == nil {
 		// Status poll is not set, fetch from database.
 		status.Poll, err = s.state.DB.GetPollByID(
@@ -253,6 +277,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.PreviewCardID != "" && status.PreviewCard == nil {
+		// Status preview card is not set, fetch from database.
+		status.PreviewCard, err = s.state.DB.GetPreviewCardByID(
+			gtscontext.SetBarebones(ctx),
+			status.PreviewCardID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status preview card: %w", err)
+		}
+	}
+
 	if !status.AttachmentsPopulated() {
 		// Status attachments are out-of-date with IDs, repopulate.
 		status.Attachments, err = s.state.DB.GetAttachmentsByIDs(
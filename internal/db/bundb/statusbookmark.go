@@ -230,6 +230,14 @@ func (s *statusBookmarkDB) GetStatusBookmarks(ctx context.Context, accountID str
 	return s.GetStatusBookmarksByIDs(ctx, ids)
 }
 
+func (s *statusBookmarkDB) CountStatusBookmarks(ctx context.Context, accountID string) (int, error) {
+	return s.db.
+		NewSelect().
+		Table("status_bookmarks").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Count(ctx)
+}
+
 func (s *statusBookmarkDB) getStatusBookmarkIDs(ctx context.Context, statusID string) ([]string, error) {
 	return s.state.Caches.DB.StatusBookmarkIDs.Load(statusID, func() ([]string, error) {
 		var bookmarkIDs []string
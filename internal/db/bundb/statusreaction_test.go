@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusReactionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *StatusReactionTestSuite) putReaction(
+	reactionID string,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+	name string,
+	emoji *gtsmodel.Emoji,
+) *gtsmodel.StatusReaction {
+	reaction := &gtsmodel.StatusReaction{
+		ID:              reactionID,
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		Name:            name,
+		URI:             "http://localhost:8080/users/" + account.Username + "/liked/" + reactionID,
+	}
+	if emoji != nil {
+		reaction.EmojiID = emoji.ID
+	}
+
+	if err := suite.db.PutStatusReaction(context.Background(), reaction); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return reaction
+}
+
+func (suite *StatusReactionTestSuite) TestPutGetStatusReactions() {
+	var (
+		ctx     = context.Background()
+		status  = suite.testStatuses["admin_account_status_1"]
+		account = suite.testAccounts["local_account_1"]
+		emoji   = suite.testEmojis["rainbow"]
+	)
+
+	unicode := suite.putReaction("01JWB1ZQ7DT4TBBXV4T5Y1GE4M", account, status, "🐸", nil)
+	custom := suite.putReaction("01JWB20YJ0D1FKQY9BG8QTF6HJ", account, status, emoji.Shortcode, emoji)
+
+	// Get reactions in order.
+	reactions, err := suite.db.GetStatusReactions(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(reactions, 2) {
+		suite.Equal(unicode.ID, reactions[0].ID)
+		suite.Equal(custom.ID, reactions[1].ID)
+	}
+
+	for _, reaction := range reactions {
+		suite.NotNil(reaction.Account)
+		suite.NotNil(reaction.TargetAccount)
+		suite.NotNil(reaction.Status)
+	}
+
+	// Custom emoji should be populated.
+	suite.Nil(reactions[0].Emoji)
+	suite.False(reactions[0].IsCustom())
+	suite.Equal("🐸", reactions[0].Content())
+	suite.NotNil(reactions[1].Emoji)
+	suite.True(reactions[1].IsCustom())
+	suite.Equal(":rainbow:", reactions[1].Content())
+
+	// Get reaction by account + status + name.
+	reaction, err := suite.db.GetStatusReaction(ctx, account.ID, status.ID, "🐸")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(unicode.ID, reaction.ID)
+
+	// Get reaction by URI.
+	reaction, err = suite.db.GetStatusReactionByURI(ctx, custom.URI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(custom.ID, reaction.ID)
+
+	// Same reaction again should fail.
+	err = suite.db.PutStatusReaction(ctx, &gtsmodel.StatusReaction{
+		ID:              "01JWB2N2Y7X8R2H2PY2AFP2C0K",
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		Name:            "🐸",
+		URI:             "http://localhost:8080/users/the_mighty_zork/liked/01JWB2N2Y7X8R2H2PY2AFP2C0K",
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+}
+
+func (suite *StatusReactionTestSuite) TestGetStatusReactionsNone() {
+	testStatus := suite.testStatuses["admin_account_status_4"]
+
+	reactions, err := suite.db.GetStatusReactions(context.Background(), testStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(reactions)
+}
+
+func (suite *StatusReactionTestSuite) TestDeleteStatusReactions() {
+	var (
+		ctx      = context.Background()
+		status1  = suite.testStatuses["admin_account_status_1"]
+		status2  = suite.testStatuses["admin_account_status_2"]
+		account1 = suite.testAccounts["local_account_1"]
+		account2 = suite.testAccounts["local_account_2"]
+	)
+
+	reaction1 := suite.putReaction("01JWB2A8B1CW3N3QT0SFNH6C58", account1, status1, "🐸", nil)
+	reaction2 := suite.putReaction("01JWB2AH8HC3ZPHF0F6TM9K2FS", account2, status1, "🐸", nil)
+	reaction3 := suite.putReaction("01JWB2ARQ1PSFZ2ZXH2S5PKQ7X", account1, status2, "🐸", nil)
+
+	// Delete one reaction by ID.
+	if err := suite.db.DeleteStatusReactionByID(ctx, reaction1.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetStatusReactionByID(ctx, reaction1.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Delete all reactions to status1.
+	if err := suite.db.DeleteStatusReactionsForStatus(ctx, status1.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetStatusReactionByID(ctx, reaction2.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Delete all reactions by account1.
+	if err := suite.db.DeleteStatusReactions(ctx, "", account1.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetStatusReactionByID(ctx, reaction3.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestStatusReactionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactionTestSuite))
+}
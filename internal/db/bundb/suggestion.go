@@ -0,0 +1,187 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type suggestionDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *suggestionDB) GetStaffPicks(ctx context.Context) ([]*gtsmodel.StaffPick, error) {
+	var picks []*gtsmodel.StaffPick
+
+	if err := s.db.
+		NewSelect().
+		Model(&picks).
+		OrderExpr("? ASC", bun.Ident("staff_pick.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return picks, nil
+	}
+
+	for _, pick := range picks {
+		if err := s.PopulateStaffPick(ctx, pick); err != nil {
+			return nil, err
+		}
+	}
+
+	return picks, nil
+}
+
+func (s *suggestionDB) GetStaffPickByAccountID(ctx context.Context, accountID string) (*gtsmodel.StaffPick, error) {
+	pick := new(gtsmodel.StaffPick)
+
+	if err := s.db.
+		NewSelect().
+		Model(pick).
+		Where("? = ?", bun.Ident("staff_pick.account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return pick, nil
+	}
+
+	if err := s.PopulateStaffPick(ctx, pick); err != nil {
+		return nil, err
+	}
+
+	return pick, nil
+}
+
+func (s *suggestionDB) PopulateStaffPick(ctx context.Context, pick *gtsmodel.StaffPick) error {
+	var err error
+
+	if pick.Account == nil {
+		// Picked account is not set, fetch from database.
+		pick.Account, err = s.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			pick.AccountID,
+		)
+		if err != nil {
+			return gtserror.Newf("error populating staff pick account: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *suggestionDB) PutStaffPick(ctx context.Context, pick *gtsmodel.StaffPick) error {
+	_, err := s.db.NewInsert().
+		Model(pick).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) DeleteStaffPickByAccountID(ctx context.Context, accountID string) error {
+	if _, err := s.db.NewDelete().
+		Table("staff_picks").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
+func (s *suggestionDB) GetSuggestionDismissedIDs(ctx context.Context, accountID string) ([]string, error) {
+	var ids []string
+
+	if err := s.db.
+		NewSelect().
+		Table("suggestion_dismissals").
+		Column("target_account_id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (s *suggestionDB) PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) error {
+	_, err := s.db.NewInsert().
+		Model(dismissal).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("account_id"), bun.Ident("target_account_id")).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) DeleteSuggestionDismissalsByAccountID(ctx context.Context, accountID string) error {
+	_, err := s.db.NewDelete().
+		Table("suggestion_dismissals").
+		WhereOr("? = ?", bun.Ident("account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("target_account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) GetFriendsOfFriends(ctx context.Context) ([]*db.FriendOfFriend, error) {
+	var fofs []*db.FriendOfFriend
+
+	// Join follows by local accounts ("follow") to
+	// follows by the accounts they follow ("fof"),
+	// excluding anyone the local account already
+	// has a relationship with, then group by pair.
+	if err := s.db.NewRaw(
+		`SELECT "follow"."account_id", "fof"."target_account_id", COUNT(*) AS "mutuals" `+
+			`FROM "follows" AS "follow" `+
+			`JOIN "accounts" AS "account" ON "account"."id" = "follow"."account_id" `+
+			`JOIN "follows" AS "fof" ON "fof"."account_id" = "follow"."target_account_id" `+
+			`JOIN "accounts" AS "target" ON "target"."id" = "fof"."target_account_id" `+
+			`WHERE "account"."domain" IS NULL `+
+			`AND "fof"."target_account_id" != "follow"."account_id" `+
+			`AND "target"."discoverable" = ? `+
+			`AND "target"."suspended_at" IS NULL `+
+			`AND NOT EXISTS (SELECT 1 FROM "follows" AS "f" `+
+			`WHERE "f"."account_id" = "follow"."account_id" AND "f"."target_account_id" = "fof"."target_account_id") `+
+			`AND NOT EXISTS (SELECT 1 FROM "follow_requests" AS "fr" `+
+			`WHERE "fr"."account_id" = "follow"."account_id" AND "fr"."target_account_id" = "fof"."target_account_id") `+
+			`AND NOT EXISTS (SELECT 1 FROM "blocks" AS "b" `+
+			`WHERE ("b"."account_id" = "follow"."account_id" AND "b"."target_account_id" = "fof"."target_account_id") `+
+			`OR ("b"."account_id" = "fof"."target_account_id" AND "b"."target_account_id" = "follow"."account_id")) `+
+			`AND NOT EXISTS (SELECT 1 FROM "user_mutes" AS "m" `+
+			`WHERE "m"."account_id" = "follow"."account_id" AND "m"."target_account_id" = "fof"."target_account_id") `+
+			`AND NOT EXISTS (SELECT 1 FROM "suggestion_dismissals" AS "d" `+
+			`WHERE "d"."account_id" = "follow"."account_id" AND "d"."target_account_id" = "fof"."target_account_id") `+
+			`GROUP BY "follow"."account_id", "fof"."target_account_id"`,
+		true,
+	).Scan(ctx, &fofs); err != nil {
+		return nil, err
+	}
+
+	return fofs, nil
+}
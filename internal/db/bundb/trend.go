@@ -0,0 +1,290 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+)
+
+// trendLinksBatchsz is the number of
+// statuses to select per batch when
+// counting links in CountLinkUses.
+const trendLinksBatchsz = 200
+
+type trendDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (t *trendDB) GetTrendReviewByID(ctx context.Context, id string) (*gtsmodel.TrendReview, error) {
+	return t.getTrendReview(
+		"ID",
+		func(review *gtsmodel.TrendReview) error {
+			return t.db.
+				NewSelect().
+				Model(review).
+				Where("? = ?", bun.Ident("trend_review.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (t *trendDB) GetTrendReview(ctx context.Context, trendType gtsmodel.TrendType, target string) (*gtsmodel.TrendReview, error) {
+	return t.getTrendReview(
+		"Type,Target",
+		func(review *gtsmodel.TrendReview) error {
+			return t.db.
+				NewSelect().
+				Model(review).
+				Where("? = ?", bun.Ident("trend_review.type"), trendType).
+				Where("? = ?", bun.Ident("trend_review.target"), target).
+				Scan(ctx)
+		},
+		trendType,
+		target,
+	)
+}
+
+func (t *trendDB) getTrendReview(
+	lookup string,
+	dbQuery func(*gtsmodel.TrendReview) error,
+	keyParts ...any,
+) (*gtsmodel.TrendReview, error) {
+	// Fetch trend review from database cache with loader callback.
+	return t.state.Caches.DB.TrendReview.LoadOne(lookup, func() (*gtsmodel.TrendReview, error) {
+		var review gtsmodel.TrendReview
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&review); err != nil {
+			return nil, err
+		}
+
+		return &review, nil
+	}, keyParts...)
+}
+
+func (t *trendDB) PutTrendReview(ctx context.Context, review *gtsmodel.TrendReview) error {
+	return t.state.Caches.DB.TrendReview.Store(review, func() error {
+		_, err := t.db.NewInsert().
+			Model(review).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (t *trendDB) UpdateTrendReview(ctx context.Context, review *gtsmodel.TrendReview, columns ...string) error {
+	review.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return t.state.Caches.DB.TrendReview.Store(review, func() error {
+		_, err := t.db.NewUpdate().
+			Model(review).
+			Column(columns...).
+			Where("? = ?", bun.Ident("trend_review.id"), review.ID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (t *trendDB) CountTagUses(ctx context.Context, since time.Time, until time.Time) ([]*db.TrendUses, error) {
+	var uses []*db.TrendUses
+
+	q := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("target")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("uses")).
+		ColumnExpr("COUNT(DISTINCT ?) AS ?", bun.Ident("status.account_id"), bun.Ident("accounts")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Group("status_to_tag.tag_id")
+
+	if err := whereTrendable(q, since, until).Scan(ctx, &uses); err != nil {
+		return nil, err
+	}
+
+	return uses, nil
+}
+
+func (t *trendDB) CountLinkUses(ctx context.Context, since time.Time, until time.Time) ([]*db.TrendUses, error) {
+	var (
+		// Count uses and distinct
+		// accounts for each link.
+		uses     = make(map[string]*db.TrendUses)
+		accounts = make(map[string]map[string]struct{})
+
+		// Page through
+		// by status ID.
+		maxID string
+	)
+
+	for {
+		var statuses []struct {
+			ID        string `bun:"id"`
+			AccountID string `bun:"account_id"`
+			Content   string `bun:"content"`
+		}
+
+		q := t.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+			Column("status.id", "status.account_id", "status.content").
+			// Only bother parsing
+			// statuses likely to
+			// contain a link.
+			Where("? LIKE ?", bun.Ident("status.content"), "%href%").
+			Order("status.id ASC").
+			Limit(trendLinksBatchsz)
+		if maxID != "" {
+			q = q.Where("? > ?", bun.Ident("status.id"), maxID)
+		}
+
+		if err := whereTrendable(q, since, until).Scan(ctx, &statuses); err != nil {
+			return nil, err
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			// Only count each link
+			// once per status.
+			links := text.FindLinks(status.Content)
+			slices.Sort(links)
+			links = slices.Compact(links)
+
+			for _, link := range links {
+				u, ok := uses[link]
+				if !ok {
+					u = &db.TrendUses{Target: link}
+					uses[link] = u
+					accounts[link] = make(map[string]struct{})
+				}
+
+				u.Uses++
+				accounts[link][status.AccountID] = struct{}{}
+				u.Accounts = len(accounts[link])
+			}
+		}
+	}
+
+	out := make([]*db.TrendUses, 0, len(uses))
+	for _, u := range uses {
+		out = append(out, u)
+	}
+
+	return out, nil
+}
+
+func (t *trendDB) CountStatusInteractions(ctx context.Context, since time.Time) ([]*db.TrendUses, error) {
+	var uses []*db.TrendUses
+
+	// Union faves, boosts and replies
+	// together as (target, account_id)
+	// pairs, then group by target.
+	if err := t.db.NewRaw(
+		`SELECT "target", COUNT(*) AS "uses", COUNT(DISTINCT "account_id") AS "accounts" FROM (`+
+			`SELECT "status_id" AS "target", "account_id" FROM "status_faves" WHERE "created_at" >= ? `+
+			`UNION ALL `+
+			`SELECT "boost_of_id" AS "target", "account_id" FROM "statuses" WHERE "boost_of_id" IS NOT NULL AND "created_at" >= ? `+
+			`UNION ALL `+
+			`SELECT "in_reply_to_id" AS "target", "account_id" FROM "statuses" WHERE "in_reply_to_id" IS NOT NULL AND "created_at" >= ?`+
+			`) AS "interaction" GROUP BY "target"`,
+		since, since, since,
+	).Scan(ctx, &uses); err != nil {
+		return nil, err
+	}
+
+	return uses, nil
+}
+
+// whereTrendable restricts the given query (which must select
+// from "statuses" AS "status") to public, non-boost statuses
+// created between since and until, by accounts that have opted
+// in to being discoverable, aren't suspended or silenced, and
+// aren't from a limited domain.
+func whereTrendable(q *bun.SelectQuery, since time.Time, until time.Time) *bun.SelectQuery {
+	return q.
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("status.account_id"),
+		).
+		Where("? >= ?", bun.Ident("status.created_at"), since).
+		Where("? < ?", bun.Ident("status.created_at"), until).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? = ?", bun.Ident("account.discoverable"), true).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Where("? IS NULL", bun.Ident("account.silenced_at")).
+		Where("NOT EXISTS (?)", domainLimitedQuery(q))
+}
+
+// domainLimitedQuery returns a subquery selecting any domain
+// limit matching "account"."domain" (or a parent domain of it),
+// as per IsDomainLimited. Local accounts never match.
+func domainLimitedQuery(q *bun.SelectQuery) *bun.SelectQuery {
+	limits := q.NewSelect().
+		TableExpr("? AS ?", bun.Ident("domain_blocks"), bun.Ident("domain_block")).
+		ColumnExpr("1").
+		Where("? = ?", bun.Ident("domain_block.severity"), gtsmodel.DomainBlockSeverityLimit).
+		Where(
+			"(? = ? OR ? LIKE '%.' || ?)",
+			bun.Ident("account.domain"), bun.Ident("domain_block.domain"),
+			bun.Ident("account.domain"), bun.Ident("domain_block.domain"),
+		)
+
+	if config.GetInstanceFederationMode() == config.InstanceFederationModeAllowlist {
+		// Allowlist mode: explicit allows
+		// can't take precedence over a limit.
+		return limits
+	}
+
+	// Blocklist mode: explicit allow
+	// takes precedence over explicit limit.
+	allows := q.NewSelect().
+		TableExpr("? AS ?", bun.Ident("domain_allows"), bun.Ident("domain_allow")).
+		ColumnExpr("1").
+		Where(
+			"(? = ? OR ? LIKE '%.' || ?)",
+			bun.Ident("account.domain"), bun.Ident("domain_allow.domain"),
+			bun.Ident("account.domain"), bun.Ident("domain_allow.domain"),
+		)
+
+	return limits.Where("NOT EXISTS (?)", allows)
+}
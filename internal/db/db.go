@@ -25,16 +25,23 @@
 // DB provides methods for interacting with an underlying database or other storage mechanism.
 type DB interface {
 	Account
+	AccountArchive
 	Admin
 	AdvancedMigration
+	Announcement
 	Application
+	Backup
 	Basic
 	Conversation
+	Delivery
 	Domain
 	Emoji
+	ExternalIdentity
+	Group
 	HeaderFilter
 	Instance
 	Interaction
+	Invite
 	Filter
 	List
 	Marker
@@ -42,22 +49,30 @@ type DB interface {
 	Mention
 	Move
 	Notification
+	OutboxImport
 	Poll
+	PreviewCard
 	Relationship
+	Relay
 	Report
 	Rule
 	Search
+	ScheduledStatus
 	Session
 	SinBinStatus
 	Status
 	StatusBookmark
 	StatusEdit
 	StatusFave
+	StatusReaction
+	Suggestion
 	Tag
 	Thread
 	Timeline
+	Trend
 	User
 	Tombstone
+	WebAuthn
 	WebPush
 	WorkerTask
 }
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type Delivery interface {
+	// GetQueuedDeliveries fetches all persisted queued deliveries with
+	// IDs lower than given maxID from the database, oldest first.
+	GetQueuedDeliveries(ctx context.Context, maxID string) ([]*gtsmodel.QueuedDelivery, error)
+
+	// CountQueuedDeliveriesByDomain returns
+	// counts of queued deliveries per domain,
+	// ordered by count descending.
+	CountQueuedDeliveriesByDomain(ctx context.Context) ([]*gtsmodel.DeliveryQueueDomain, error)
+
+	// PutQueuedDeliveries persists the given queued deliveries.
+	PutQueuedDeliveries(ctx context.Context, deliveries []*gtsmodel.QueuedDelivery) error
+
+	// UpdateQueuedDelivery updates the given queued delivery, only
+	// updating the given columns (or all if none are given).
+	UpdateQueuedDelivery(ctx context.Context, delivery *gtsmodel.QueuedDelivery, columns ...string) error
+
+	// DeleteQueuedDeliveryByID deletes queued delivery with given ID.
+	DeleteQueuedDeliveryByID(ctx context.Context, id string) error
+
+	// DeleteQueuedDeliveriesByIRI deletes all queued deliveries
+	// with actor, object or target ID IRI matching given IRI.
+	DeleteQueuedDeliveriesByIRI(ctx context.Context, iri string) error
+}
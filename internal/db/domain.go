@@ -86,6 +86,14 @@ type Domain interface {
 	// Will return true if even one of the given URIs is blocked.
 	AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, error)
 
+	// IsDomainLimited checks if domain is limited by a domain block with limit severity.
+	// In blocklist mode, an explicit allow for the domain takes precedence over the limit.
+	IsDomainLimited(ctx context.Context, domain string) (bool, error)
+
+	// GetDomainLimit returns the most specific domain block with limit severity
+	// applying to the given domain (or a parent of it), if the domain is limited.
+	GetDomainLimit(ctx context.Context, domain string) (*gtsmodel.DomainBlock, error)
+
 	/*
 		Domain permission draft stuff.
 	*/
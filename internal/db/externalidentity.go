@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// ExternalIdentity contains functions related to
+// users' identities at external OIDC providers.
+type ExternalIdentity interface {
+	// GetExternalIdentity gets the external identity
+	// with the given subject at the given provider.
+	GetExternalIdentity(ctx context.Context, provider string, subject string) (*gtsmodel.ExternalIdentity, error)
+
+	// GetExternalIdentitiesBySessionID gets all external
+	// identities whose latest session at the given provider
+	// has the given session ID ('sid' claim).
+	GetExternalIdentitiesBySessionID(ctx context.Context, provider string, sessionID string) ([]*gtsmodel.ExternalIdentity, error)
+
+	// GetExternalIdentitiesByUserID gets all external
+	// identities linked to the given user ID, oldest first.
+	GetExternalIdentitiesByUserID(ctx context.Context, userID string) ([]*gtsmodel.ExternalIdentity, error)
+
+	// GetAllExternalIdentities gets all external identities, oldest first.
+	GetAllExternalIdentities(ctx context.Context) ([]*gtsmodel.ExternalIdentity, error)
+
+	// PutExternalIdentity puts the given external identity in the database.
+	PutExternalIdentity(ctx context.Context, identity *gtsmodel.ExternalIdentity) error
+
+	// UpdateExternalIdentity updates the given external identity by primary key.
+	// Updates values of given columns only, or all if none provided.
+	UpdateExternalIdentity(ctx context.Context, identity *gtsmodel.ExternalIdentity, columns ...string) error
+
+	// DeleteExternalIdentitiesByUserID deletes all
+	// external identities linked to the given user ID.
+	DeleteExternalIdentitiesByUserID(ctx context.Context, userID string) error
+}
@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type Group interface {
+	// NewGroup creates a new local Group actor account with the given
+	// parameters, and makes newGroup.Admin the first admin of the group.
+	NewGroup(ctx context.Context, newGroup gtsmodel.NewGroup) (*gtsmodel.Account, error)
+
+	// GetGroupAdmin gets the group admin entry for the given group and admin account.
+	GetGroupAdmin(ctx context.Context, groupAccountID string, accountID string) (*gtsmodel.GroupAdmin, error)
+
+	// GetGroupAdmins gets all admins of the group with
+	// the given account ID, in ascending order of creation.
+	GetGroupAdmins(ctx context.Context, groupAccountID string) ([]*gtsmodel.GroupAdmin, error)
+
+	// GetGroupAdminsByAccountID gets all group admin entries for the
+	// given admin account ID, ie., all groups that account administers.
+	GetGroupAdminsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.GroupAdmin, error)
+
+	// IsGroupAdmin returns true if the account with
+	// accountID is an admin of the given group account.
+	IsGroupAdmin(ctx context.Context, groupAccountID string, accountID string) (bool, error)
+
+	// PopulateGroupAdmin ensures that the group and admin accounts of the given group admin are populated.
+	PopulateGroupAdmin(ctx context.Context, groupAdmin *gtsmodel.GroupAdmin) error
+
+	// PutGroupAdmin puts the given group admin entry in the database.
+	PutGroupAdmin(ctx context.Context, groupAdmin *gtsmodel.GroupAdmin) error
+
+	// DeleteGroupAdminByID deletes one group admin entry with the given ID.
+	DeleteGroupAdminByID(ctx context.Context, id string) error
+
+	// DeleteGroupAdminsByAccountID deletes all group admin entries
+	// in which the given account ID is either the group or the admin.
+	DeleteGroupAdminsByAccountID(ctx context.Context, accountID string) error
+}
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// Invite contains functions related to invite links.
+type Invite interface {
+	// GetInviteByID gets the invite with the given ID.
+	GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error)
+
+	// GetInviteByCode gets the invite with the given code.
+	GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error)
+
+	// GetInvites gets a page of invites, newest first,
+	// optionally only those created by the given user ID.
+	GetInvites(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Invite, error)
+
+	// PopulateInvite populates the struct pointers on the given invite.
+	PopulateInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// PutInvite puts the given invite in the database.
+	PutInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// UpdateInvite updates the given invite by primary key.
+	// Updates values of given columns only, or all if none provided.
+	UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error
+
+	// UseInvite increments the uses of the given invite, provided
+	// that it is still valid, and returns whether it was. Uses are
+	// incremented atomically, so an invite can't be used more times
+	// than it allows by concurrent sign ups.
+	UseInvite(ctx context.Context, invite *gtsmodel.Invite) (bool, error)
+
+	// UnuseInvite gives back a use of the given invite previously
+	// taken with UseInvite, eg., because the sign up then failed.
+	UnuseInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// RevokeInvitesByUserID revokes all
+	// invites created by the given user ID.
+	RevokeInvitesByUserID(ctx context.Context, userID string) error
+}
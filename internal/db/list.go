@@ -77,4 +77,18 @@ type List interface {
 
 	// DeleteAllListEntryByFollow deletes all list entries with the given followIDs.
 	DeleteAllListEntriesByFollows(ctx context.Context, followIDs ...string) error
+
+	// PutPendingListEntries inserts a slice of pending list entries into the
+	// database, ignoring any which already exist for the list and follow request.
+	PutPendingListEntries(ctx context.Context, pendingEntries []*gtsmodel.PendingListEntry) error
+
+	// AcceptPendingListEntries replaces all pending list entries for the given
+	// follow request ID with list entries for the follow it was accepted as.
+	AcceptPendingListEntries(ctx context.Context, followRequestID string) error
+
+	// DeletePendingListEntriesByListID deletes all pending list entries in the list with given ID.
+	DeletePendingListEntriesByListID(ctx context.Context, listID string) error
+
+	// DeletePendingListEntriesByFollowRequests deletes all pending list entries with the given followRequestIDs.
+	DeletePendingListEntriesByFollowRequests(ctx context.Context, followRequestIDs ...string) error
 }
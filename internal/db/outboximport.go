@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type OutboxImport interface {
+	// GetOutboxImportByID gets one outbox import with the given ID.
+	GetOutboxImportByID(ctx context.Context, id string) (*gtsmodel.OutboxImport, error)
+
+	// GetOutboxImportsByAccountID gets all outbox imports
+	// into the given account ID, newest first.
+	GetOutboxImportsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.OutboxImport, error)
+
+	// PutOutboxImport puts the given outbox import in the database.
+	PutOutboxImport(ctx context.Context, outboxImport *gtsmodel.OutboxImport) error
+
+	// UpdateOutboxImport updates the given outbox import by primary key.
+	// Updates values of given columns only, or all if none provided.
+	UpdateOutboxImport(ctx context.Context, outboxImport *gtsmodel.OutboxImport, columns ...string) error
+
+	// DeleteOutboxImportsByAccountID deletes all
+	// outbox imports into the given account ID.
+	DeleteOutboxImportsByAccountID(ctx context.Context, accountID string) error
+}
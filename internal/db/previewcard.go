@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type PreviewCard interface {
+	// GetPreviewCardByID gets one preview card with the given ID.
+	GetPreviewCardByID(ctx context.Context, id string) (*gtsmodel.PreviewCard, error)
+
+	// GetPreviewCardByURL gets one preview card for the given linked page URL.
+	GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, error)
+
+	// GetPreviewCardsByIDs gets all preview cards with the given IDs.
+	GetPreviewCardsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.PreviewCard, error)
+
+	// GetCachedPreviewCardsOlderThan gets preview cards with a locally cached
+	// image, that were last fetched before the given time, newest first.
+	GetCachedPreviewCardsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.PreviewCard, error)
+
+	// GetUnusedPreviewCards gets preview cards created before the given
+	// time that aren't used by any status, oldest first.
+	GetUnusedPreviewCards(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.PreviewCard, error)
+
+	// PutPreviewCard puts the given preview card in the database.
+	PutPreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error
+
+	// UpdatePreviewCard updates the given preview card by primary key.
+	// Updates values of given columns only, or all if none provided.
+	UpdatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard, columns ...string) error
+
+	// DeletePreviewCardByID deletes the preview card with the given
+	// ID, and unsets it as the preview card of any statuses using it.
+	DeletePreviewCardByID(ctx context.Context, id string) error
+}
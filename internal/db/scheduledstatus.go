@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+type ScheduledStatus interface {
+	// GetAllScheduledStatuses returns all pending scheduled statuses.
+	GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusByID gets one scheduled status with the given id.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusesByIDs gets the scheduled statuses with the given ids.
+	GetScheduledStatusesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusesForAcct returns a page of scheduled statuses owned by the given account.
+	GetScheduledStatusesForAcct(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error)
+
+	// CountScheduledStatusesForAcct counts the scheduled statuses owned by the given account.
+	// If scheduledAt is non-zero, only statuses scheduled on the same (UTC) day are counted.
+	CountScheduledStatusesForAcct(ctx context.Context, accountID string, scheduledAt time.Time) (int, error)
+
+	// PopulateScheduledStatus ensures that all sub-models of a scheduled status are populated (e.g. account, attachments).
+	PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// PutScheduledStatus puts the given scheduled status in the database.
+	PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// UpdateScheduledStatus updates the given scheduled status in the database, only on selected columns if provided (else, all).
+	UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error
+
+	// UpdateScheduledStatusMedia sets the scheduled status ID on the given media
+	// attachments, or unsets it if scheduledStatusID is empty, without otherwise
+	// touching the scheduled status. Used to hand media over when publishing.
+	UpdateScheduledStatusMedia(ctx context.Context, scheduledStatusID string, mediaIDs []string) error
+
+	// DeleteScheduledStatusByID deletes one scheduled status from the database.
+	DeleteScheduledStatusByID(ctx context.Context, id string) error
+
+	// DeleteScheduledStatusesByAccountID deletes all scheduled statuses owned by the given account from the database.
+	DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error
+}
@@ -19,18 +19,105 @@
 
 import (
 	"context"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 )
 
 type Search interface {
 	// SearchForAccounts uses the given query text to search for accounts that accountID follows.
+	// Results are returned in order of relevance, and may be paged through using offset.
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
-	// SearchForStatuses uses the given query text to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
-	// If fromAccountID is used, the results are restricted to statuses created by fromAccountID.
-	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+	// SearchForStatuses uses the given params to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
+	// If params.InLibrary is set, statuses faved or bookmarked by requestingAccountID, or mentioning requestingAccountID, are searched too.
+	// Results are returned in order of relevance, and may be paged through using offset.
+	SearchForStatuses(ctx context.Context, requestingAccountID string, params *StatusSearchParams, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
 
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
+
+	// IndexAccount inserts or updates the searchable text of the given account in the full-text search index.
+	IndexAccount(ctx context.Context, account *gtsmodel.Account) error
+
+	// DeindexAccount removes the account with the given ID from the full-text search index.
+	DeindexAccount(ctx context.Context, accountID string) error
+
+	// IndexStatus inserts or updates the searchable text of the given status in the full-text search index.
+	// Boosts are not indexed, as they have no text of their own.
+	IndexStatus(ctx context.Context, status *gtsmodel.Status) error
+
+	// DeindexStatus removes the status with the given ID from the full-text search index.
+	DeindexStatus(ctx context.Context, statusID string) error
+}
+
+// StatusSearchParams contains the parsed
+// criteria of a status search. All of the
+// given criteria must be met for a status
+// to be included in the search results.
+type StatusSearchParams struct {
+	// Terms that must all be present in the
+	// status text. A term containing several
+	// words will be matched as a phrase.
+	Terms []string
+
+	// Terms that must not be present
+	// in the status text. A term containing
+	// several words will be matched as a phrase.
+	ExcludeTerms []string
+
+	// If set, only statuses created by
+	// one of these accounts will be returned.
+	AccountIDs []string
+
+	// Statuses created by any of these
+	// accounts will not be returned.
+	ExcludeAccountIDs []string
+
+	// If set, only statuses in one of
+	// these languages will be returned.
+	Languages []string
+
+	// Statuses in any of these
+	// languages will not be returned.
+	ExcludeLanguages []string
+
+	// If set, only return statuses
+	// with (true) or without (false)
+	// media attachments, polls, links,
+	// a parent status, or a sensitive flag.
+	HasMedia    *bool
+	HasPoll     *bool
+	HasLink     *bool
+	IsReply     *bool
+	IsSensitive *bool
+
+	// Search statuses faved, bookmarked,
+	// or mentioned in by the requester too.
+	InLibrary bool
+
+	// If set, only return statuses
+	// created before / at or after
+	// the given times respectively.
+	Before time.Time
+	After  time.Time
+}
+
+// Empty returns true if params
+// contain no criteria to search by.
+func (p *StatusSearchParams) Empty() bool {
+	return len(p.Terms) == 0 &&
+		len(p.ExcludeTerms) == 0 &&
+		len(p.AccountIDs) == 0 &&
+		len(p.ExcludeAccountIDs) == 0 &&
+		len(p.Languages) == 0 &&
+		len(p.ExcludeLanguages) == 0 &&
+		p.HasMedia == nil &&
+		p.HasPoll == nil &&
+		p.HasLink == nil &&
+		p.IsReply == nil &&
+		p.IsSensitive == nil &&
+		!p.InLibrary &&
+		p.Before.IsZero() &&
+		p.After.IsZero()
 }
@@ -43,6 +43,9 @@ type StatusBookmark interface {
 	// timeline view.
 	GetStatusBookmarks(ctx context.Context, accountID string, limit int, maxID string, minID string) ([]*gtsmodel.StatusBookmark, error)
 
+	// CountStatusBookmarks counts the status bookmarks created by the given accountID.
+	CountStatusBookmarks(ctx context.Context, accountID string) (int, error)
+
 	// PutStatusBookmark inserts the given statusBookmark into the database.
 	PutStatusBookmark(ctx context.Context, statusBookmark *gtsmodel.StatusBookmark) error
 
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type Suggestion interface {
+	// GetStaffPicks gets all staff picks, in ascending order of creation.
+	GetStaffPicks(ctx context.Context) ([]*gtsmodel.StaffPick, error)
+
+	// GetStaffPickByAccountID gets the staff pick of the given account.
+	GetStaffPickByAccountID(ctx context.Context, accountID string) (*gtsmodel.StaffPick, error)
+
+	// PopulateStaffPick populates the struct pointers on the given staff pick.
+	PopulateStaffPick(ctx context.Context, pick *gtsmodel.StaffPick) error
+
+	// PutStaffPick puts the given staff pick in the database.
+	PutStaffPick(ctx context.Context, pick *gtsmodel.StaffPick) error
+
+	// DeleteStaffPickByAccountID deletes the staff pick of the given account, if any.
+	DeleteStaffPickByAccountID(ctx context.Context, accountID string) error
+
+	// GetSuggestionDismissedIDs gets the IDs of all accounts
+	// whose suggestion was dismissed by the given account.
+	GetSuggestionDismissedIDs(ctx context.Context, accountID string) ([]string, error)
+
+	// PutSuggestionDismissal puts the given suggestion dismissal in the
+	// database, doing nothing if the suggestion was already dismissed.
+	PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) error
+
+	// DeleteSuggestionDismissalsByAccountID deletes all suggestion
+	// dismissals made by, or targeting, the given account.
+	DeleteSuggestionDismissalsByAccountID(ctx context.Context, accountID string) error
+
+	// GetFriendsOfFriends gets, for each local account, the accounts followed by accounts
+	// it follows, along with how many of the accounts it follows follow each of them.
+	//
+	// Only discoverable accounts that aren't suspended are included. Accounts that the
+	// local account already follows or has requested to follow, accounts it has blocked
+	// or muted or been blocked by, and accounts it has dismissed, are excluded.
+	GetFriendsOfFriends(ctx context.Context) ([]*FriendOfFriend, error)
+}
+
+// FriendOfFriend contains an account followed
+// by accounts that the given account follows.
+type FriendOfFriend struct {
+	// ID of the (local) account.
+	AccountID string `bun:"account_id"`
+
+	// ID of the friend of a friend.
+	TargetAccountID string `bun:"target_account_id"`
+
+	// Number of accounts followed by
+	// the account which follow the target.
+	Mutuals int `bun:"mutuals"`
+}
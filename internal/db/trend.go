@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type Trend interface {
+	// GetTrendReviewByID gets one trend review with the given ID.
+	GetTrendReviewByID(ctx context.Context, id string) (*gtsmodel.TrendReview, error)
+
+	// GetTrendReview gets the trend review for the given type and target.
+	GetTrendReview(ctx context.Context, trendType gtsmodel.TrendType, target string) (*gtsmodel.TrendReview, error)
+
+	// PutTrendReview puts the given trend review in the database.
+	PutTrendReview(ctx context.Context, review *gtsmodel.TrendReview) error
+
+	// UpdateTrendReview updates the given trend review by primary key.
+	// Updates values of given columns only, or all if none provided.
+	UpdateTrendReview(ctx context.Context, review *gtsmodel.TrendReview, columns ...string) error
+
+	// CountTagUses counts uses of each tag by public statuses created between since (inclusive)
+	// and until (exclusive), by discoverable accounts that aren't suspended, silenced, or from
+	// a limited domain. Boosts aren't counted.
+	CountTagUses(ctx context.Context, since time.Time, until time.Time) ([]*TrendUses, error)
+
+	// CountLinkUses counts uses of each link URL by public statuses created between since (inclusive)
+	// and until (exclusive), by discoverable accounts that aren't suspended, silenced, or from
+	// a limited domain. Boosts aren't counted.
+	CountLinkUses(ctx context.Context, since time.Time, until time.Time) ([]*TrendUses, error)
+
+	// CountStatusInteractions counts faves, boosts and replies
+	// targeting each status that were created since the given time.
+	CountStatusInteractions(ctx context.Context, since time.Time) ([]*TrendUses, error)
+}
+
+// TrendUses contains the number of times something
+// was used or interacted with within a period of time,
+// and by how many different accounts.
+type TrendUses struct {
+	// ID of tag or status, or URL of link.
+	Target string `bun:"target"`
+
+	// Number of uses / interactions.
+	Uses int `bun:"uses"`
+
+	// Number of distinct accounts.
+	Accounts int `bun:"accounts"`
+}
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// WebAuthn contains functions related to
+// WebAuthn security keys and passkeys.
+type WebAuthn interface {
+	// GetWebAuthnCredentialByID gets one WebAuthn credential with the given ID.
+	GetWebAuthnCredentialByID(ctx context.Context, id string) (*gtsmodel.WebAuthnCredential, error)
+
+	// GetWebAuthnCredentialByCredentialID gets one WebAuthn credential
+	// with the given base64url encoded (authenticator) credential ID.
+	GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID string) (*gtsmodel.WebAuthnCredential, error)
+
+	// GetWebAuthnCredentialsByUserID gets all WebAuthn
+	// credentials registered by the given user ID, oldest first.
+	GetWebAuthnCredentialsByUserID(ctx context.Context, userID string) ([]*gtsmodel.WebAuthnCredential, error)
+
+	// CountWebAuthnCredentialsByUserID counts WebAuthn
+	// credentials registered by the given user ID.
+	CountWebAuthnCredentialsByUserID(ctx context.Context, userID string) (int, error)
+
+	// PutWebAuthnCredential puts the given WebAuthn credential in the database.
+	PutWebAuthnCredential(ctx context.Context, credential *gtsmodel.WebAuthnCredential) error
+
+	// UpdateWebAuthnCredential updates the given WebAuthn credential by primary key.
+	// Updates values of given columns only, or all if none provided.
+	UpdateWebAuthnCredential(ctx context.Context, credential *gtsmodel.WebAuthnCredential, columns ...string) error
+
+	// DeleteWebAuthnCredentialByID deletes one WebAuthn credential with the given ID.
+	DeleteWebAuthnCredentialByID(ctx context.Context, id string) error
+
+	// DeleteWebAuthnCredentialsByUserID deletes all
+	// WebAuthn credentials registered by the given user ID.
+	DeleteWebAuthnCredentialsByUserID(ctx context.Context, userID string) error
+}
@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+const (
+	accountArchiveTemplate = "email_account_archive.tmpl"
+	accountArchiveSubject  = "GoToSocial Account Archive Ready"
+)
+
+type AccountArchiveData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// URL of the settings page to download the archive from.
+	ArchiveURL string
+	// Size of the archive, human-readable, eg., "12MiB".
+	Size string
+	// Date after which the archive will be removed, eg., "Jan 2, 2006".
+	ExpiresAt string
+}
+
+func (s *sender) SendAccountArchiveEmail(toAddress string, data AccountArchiveData) error {
+	return s.sendTemplate(accountArchiveTemplate, accountArchiveSubject, data, toAddress)
+}
@@ -220,6 +220,57 @@ func (suite *EmailTestSuite) TestTemplateReportClosedLocalAccountNoComment() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello !\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateAccountActionSilence() {
+	accountActionData := email.AccountActionData{
+		Username:     "the_mighty_zork",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ActionType:   "silence",
+		Text:         "Please stop spamming the local timeline.",
+	}
+
+	if err := suite.sender.SendAccountActionEmail("user@example.org", accountActionData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Account Moderation\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello the_mighty_zork!\r\n\r\nYou are receiving this mail because a moderator of Test Instance (https://example.org) has taken action on your account.\r\n\r\nYour account has been silenced. You can still use your account, but only people who already follow you will see your posts on this instance, and your account will be hidden from public timelines and search results.\r\n\r\nThe moderator included the following message regarding this action: \"Please stop spamming the local timeline.\"\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateAccountActionWarningNoText() {
+	accountActionData := email.AccountActionData{
+		Username:     "the_mighty_zork",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ActionType:   "none",
+	}
+
+	if err := suite.sender.SendAccountActionEmail("user@example.org", accountActionData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Account Moderation\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello the_mighty_zork!\r\n\r\nYou are receiving this mail because a moderator of Test Instance (https://example.org) has taken action on your account.\r\n\r\nThis is a warning from the moderators of Test Instance regarding your account.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateAccountArchive() {
+	accountArchiveData := email.AccountArchiveData{
+		Username:     "the_mighty_zork",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ArchiveURL:   "https://example.org/settings/user/export-import",
+		Size:         "12MiB",
+		ExpiresAt:    "Jan 9, 2025",
+	}
+
+	if err := suite.sender.SendAccountArchiveEmail("user@example.org", accountArchiveData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Account Archive Ready\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello the_mighty_zork!\r\n\r\nYou are receiving this mail because you requested an archive of your account on Test Instance (https://example.org).\r\n\r\nYour archive (12MiB) is now ready. You can download it from the export & import section of your settings: https://example.org/settings/user/export-import\r\n\r\nThe archive will be available until Jan 9, 2025, after which it will be removed.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
 func TestEmailTestSuite(t *testing.T) {
 	suite.Run(t, new(EmailTestSuite))
 }
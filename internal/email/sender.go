@@ -61,6 +61,15 @@ type Sender interface {
 	// SendSignupRejectedEmail sends an email to the given address
 	// that their sign-up request has been rejected by a moderator.
 	SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error
+
+	// SendAccountActionEmail sends an email to the given address letting
+	// them know that a moderator has taken action on their account, eg.,
+	// silencing or disabling it, or just sending them a warning.
+	SendAccountActionEmail(toAddress string, data AccountActionData) error
+
+	// SendAccountArchiveEmail sends an email to the given address letting
+	// them know that the archive of their account they requested is ready.
+	SendAccountArchiveEmail(toAddress string, data AccountArchiveData) error
 }
 
 // NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
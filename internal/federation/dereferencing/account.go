@@ -806,6 +806,12 @@ func (d *Dereferencer) enrichAccount(
 	latestAcc.FetchedAt = now
 	latestAcc.UpdatedAt = now
 
+	// Drop avatar + header if
+	// the account's domain is limited.
+	if err := d.applyAccountDomainLimit(ctx, latestAcc); err != nil {
+		log.Errorf(ctx, "error applying domain limit for account %s: %v", uri, err)
+	}
+
 	// Ensure the account's avatar media is populated, passing in existing to check for chages.
 	if err := d.fetchAccountAvatar(ctx, requestUser, account, latestAcc); err != nil {
 		log.Errorf(ctx, "error fetching remote avatar for account %s: %v", uri, err)
@@ -846,6 +852,13 @@ func (d *Dereferencer) enrichAccount(
 		}
 	}
 
+	// Update the account's searchable text. Remote accounts
+	// are mostly discovered through dereferencing rather than
+	// via the federator worker, so we index them here instead.
+	if err := d.state.DB.IndexAccount(ctx, latestAcc); err != nil {
+		log.Errorf(ctx, "error indexing account %s: %v", uri, err)
+	}
+
 	return latestAcc, apubAcc, nil
 }
 
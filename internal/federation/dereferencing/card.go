@@ -0,0 +1,425 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+const (
+	// previewCardFreshness is the window in which
+	// a preview card fetched for a link is reused
+	// as-is, without fetching the linked page again.
+	previewCardFreshness = 7 * 24 * time.Hour
+
+	// previewCardHostInterval is the minimum
+	// interval between fetches of pages for
+	// preview cards from one host.
+	previewCardHostInterval = 2 * time.Second
+
+	// previewCardHostMaxWait is the maximum time
+	// we'll keep rescheduling a fetch of a page from
+	// a busy host, after which the fetch is skipped.
+	previewCardHostMaxWait = time.Minute
+)
+
+// previewCardHostBusyError is returned when it's not
+// yet our turn to fetch a page for a preview card from
+// a host, with the time at which it next will be.
+type previewCardHostBusyError struct {
+	host string
+	next time.Time
+}
+
+func (err *previewCardHostBusyError) Error() string {
+	return "waiting to fetch from " + err.host
+}
+
+// RefreshStatusPreviewCardAsync enqueues a worker function
+// to call RefreshStatusPreviewCard for the given status.
+// It returns early if there's nothing for it to do.
+func (d *Dereferencer) RefreshStatusPreviewCardAsync(ctx context.Context, status *gtsmodel.Status) {
+	if status.PreviewCardID == "" && previewCardLink(status) == nil {
+		// No card to set
+		// or unset, ignore.
+		return
+	}
+
+	d.queueRefreshStatusPreviewCard(status.ID, time.Now())
+}
+
+// queueRefreshStatusPreviewCard enqueues a worker function to
+// call RefreshStatusPreviewCard for the status with given ID.
+//
+// If the linked host is busy, rather than wait on the worker
+// (which would hold up other dereferencing), the function is
+// rescheduled for when it's our turn, for up to a total of
+// previewCardHostMaxWait since queued, then it's skipped.
+func (d *Dereferencer) queueRefreshStatusPreviewCard(statusID string, queuedAt time.Time) {
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		// Reload the status, in case
+		// it's changed since enqueued.
+		status, err := d.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error getting status: %v", err)
+			return
+		}
+
+		var busy *previewCardHostBusyError
+		err = d.RefreshStatusPreviewCard(ctx, status)
+		switch {
+		case errors.As(err, &busy):
+			if busy.next.Sub(queuedAt) > previewCardHostMaxWait {
+				log.Debugf(ctx, "skipping status preview card: %v", err)
+				return
+			}
+
+			taskID := "@previewcard:" + statusID
+			if !d.state.Workers.Scheduler.AddOnce(
+				taskID,
+				busy.next,
+				func(context.Context, time.Time) {
+					_ = d.state.Workers.Scheduler.Cancel(taskID)
+					d.queueRefreshStatusPreviewCard(statusID, queuedAt)
+				},
+			) {
+				log.Debugf(ctx, "status preview card refresh already scheduled for %s", statusID)
+			}
+
+		case err != nil:
+			log.Errorf(ctx, "error refreshing status preview card: %v", err)
+		}
+	})
+}
+
+// RefreshStatusPreviewCard ensures that the given status
+// has a preview card for the first link in its content,
+// if there is one, fetching (or refreshing) the card from
+// the linked page as necessary. If there's no suitable
+// link in the status, any existing preview card is unset.
+func (d *Dereferencer) RefreshStatusPreviewCard(ctx context.Context, status *gtsmodel.Status) error {
+	var card *gtsmodel.PreviewCard
+
+	if link := previewCardLink(status); link != nil {
+		var err error
+
+		// Get the preview card for this link. On failure we
+		// just unset the status card below, as if it has
+		// changed link then any existing card is outdated.
+		card, err = d.getPreviewCard(ctx, link)
+		if err != nil {
+			var busy *previewCardHostBusyError
+			if errors.As(err, &busy) {
+				// Leave status as-is,
+				// caller can retry.
+				return err
+			}
+			log.Debugf(ctx, "couldn't get preview card for %s: %v", link, err)
+		}
+	}
+
+	var cardID string
+	if card != nil {
+		cardID = card.ID
+	}
+
+	if status.PreviewCardID == cardID {
+		// Nothing
+		// changed.
+		return nil
+	}
+
+	// Update the status preview card.
+	status.PreviewCardID = cardID
+	status.PreviewCard = card
+	if err := d.state.DB.UpdateStatus(ctx,
+		status,
+		"preview_card_id",
+	); err != nil {
+		return gtserror.Newf("error updating status: %w", err)
+	}
+
+	return nil
+}
+
+// getPreviewCard returns the preview card for given link,
+// fetching it from the linked page if we don't have it yet,
+// or if the one we have is no longer fresh. If refreshing
+// a card fails, the stale version is returned instead.
+func (d *Dereferencer) getPreviewCard(ctx context.Context, link *url.URL) (*gtsmodel.PreviewCard, error) {
+	// Look for an existing card for this link.
+	card, err := d.state.DB.GetPreviewCardByURL(ctx, link.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting preview card: %w", err)
+	}
+
+	if card != nil && time.Since(card.FetchedAt) < previewCardFreshness {
+		// Fresh enough,
+		// use as-is.
+		return card, nil
+	}
+
+	latest, err := d.fetchPreviewCard(ctx, link, card)
+	if err != nil {
+		var busy *previewCardHostBusyError
+		if card != nil && !errors.As(err, &busy) {
+			log.Debugf(ctx, "couldn't refresh preview card for %s: %v", link, err)
+			return card, nil
+		}
+		return nil, err
+	}
+
+	return latest, nil
+}
+
+// fetchPreviewCard fetches a preview card from the
+// page at given link, along with its preview image,
+// storing it in the database. If an existing card is
+// given, it will be updated rather than a new one put.
+func (d *Dereferencer) fetchPreviewCard(
+	ctx context.Context,
+	link *url.URL,
+	existing *gtsmodel.PreviewCard,
+) (*gtsmodel.PreviewCard, error) {
+	blocked, err := d.state.DB.IsDomainBlocked(ctx, link.Host)
+	if err != nil {
+		return nil, gtserror.Newf("db error checking domain block: %w", err)
+	} else if blocked {
+		return nil, gtserror.Newf("domain %s is blocked", link.Host)
+	}
+
+	// Check it's our turn to fetch from this host.
+	if err := d.bookPreviewCardHost(link.Host); err != nil {
+		return nil, err
+	}
+
+	// Fetch transport for the instance account.
+	tsport, err := d.transportController.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("failed getting transport: %w", err)
+	}
+
+	card, err := tsport.DereferencePreviewCard(ctx, link)
+	if err != nil {
+		return nil, gtserror.Newf("error dereferencing preview card: %w", err)
+	}
+
+	now := time.Now()
+	card.FetchedAt = now
+	card.UpdatedAt = now
+	card.Cached = util.Ptr(false)
+
+	if existing != nil {
+		// Keep existing card ID
+		// and image details, so
+		// any image is replaced.
+		card.ID = existing.ID
+		card.CreatedAt = existing.CreatedAt
+		card.ImageURL = existing.ImageURL
+		card.ImagePath = existing.ImagePath
+		card.ImageContentType = existing.ImageContentType
+		card.ImageFileSize = existing.ImageFileSize
+		card.Blurhash = existing.Blurhash
+		card.Cached = existing.Cached
+	} else {
+		card.ID = id.NewULID()
+		card.CreatedAt = now
+	}
+
+	if card.ImageRemoteURL != "" {
+		// Fetch and cache the preview image.
+		if err := d.cachePreviewCardImage(ctx,
+			card,
+			tsport.DereferenceMedia,
+		); err != nil {
+			log.Debugf(ctx, "couldn't cache preview card image %s: %v", card.ImageRemoteURL, err)
+		}
+	} else if card.ImagePath != "" {
+		// Card no longer has an
+		// image, drop the old one.
+		d.uncachePreviewCardImage(ctx, card)
+	}
+
+	if existing != nil {
+		if err := d.state.DB.UpdatePreviewCard(ctx, card); err != nil {
+			return nil, gtserror.Newf("db error updating preview card: %w", err)
+		}
+		return card, nil
+	}
+
+	if err := d.state.DB.PutPreviewCard(ctx, card); err != nil {
+		if !errors.Is(err, db.ErrAlreadyExists) {
+			return nil, gtserror.Newf("db error putting preview card: %w", err)
+		}
+
+		// Card was put while we were fetching
+		// (e.g., for another status linking the
+		// same page). Drop ours and use that one.
+		d.uncachePreviewCardImage(ctx, card)
+		return d.state.DB.GetPreviewCardByURL(ctx, card.URL)
+	}
+
+	return card, nil
+}
+
+// cachePreviewCardImage fetches the preview image of given card
+// using the given dereference function, and caches it in storage.
+func (d *Dereferencer) cachePreviewCardImage(
+	ctx context.Context,
+	card *gtsmodel.PreviewCard,
+	deref func(context.Context, *url.URL, int64) (io.ReadCloser, error),
+) error {
+	imageURL, err := url.Parse(card.ImageRemoteURL)
+	if err != nil {
+		return gtserror.Newf("invalid image url: %w", err)
+	}
+
+	blocked, err := d.state.DB.IsDomainBlocked(ctx, imageURL.Host)
+	if err != nil {
+		return gtserror.Newf("db error checking domain block: %w", err)
+	} else if blocked {
+		return gtserror.Newf("domain %s is blocked", imageURL.Host)
+	}
+
+	// Cached card images are
+	// owned by instance account.
+	instanceAcc, err := d.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("db error getting instance account: %w", err)
+	}
+
+	// Get maximum supported remote media size.
+	maxsz := int64(config.GetMediaRemoteMaxSize()) // #nosec G115 -- Already validated.
+
+	return d.mediaManager.CachePreviewCardImage(ctx,
+		card,
+		instanceAcc.ID,
+		func(ctx context.Context) (io.ReadCloser, error) {
+			return deref(ctx, imageURL, maxsz)
+		},
+	)
+}
+
+// uncachePreviewCardImage removes the cached
+// image of given card from storage, if any,
+// and unsets the card's image details.
+func (d *Dereferencer) uncachePreviewCardImage(ctx context.Context, card *gtsmodel.PreviewCard) {
+	if card.ImagePath != "" {
+		err := d.state.Storage.Delete(ctx, card.ImagePath)
+		if err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error deleting %s: %v", card.ImagePath, err)
+		}
+	}
+
+	card.ImageURL = ""
+	card.ImagePath = ""
+	card.ImageContentType = ""
+	card.ImageFileSize = 0
+	card.Blurhash = ""
+	card.Cached = util.Ptr(false)
+}
+
+// bookPreviewCardHost books a fetch of a page for a preview
+// card from given host, ensuring that fetches from any one
+// host are spaced out by previewCardHostInterval. If it's not
+// our turn yet, *previewCardHostBusyError is returned.
+func (d *Dereferencer) bookPreviewCardHost(host string) error {
+	now := time.Now()
+
+	d.derefCardHostsMu.Lock()
+	defer d.derefCardHostsMu.Unlock()
+
+	// Get next time we're
+	// allowed to fetch.
+	next := d.derefCardHosts[host]
+	if next.After(now) {
+		return &previewCardHostBusyError{
+			host: host,
+			next: next,
+		}
+	}
+
+	// Book our slot for this host.
+	d.derefCardHosts[host] = now.Add(previewCardHostInterval)
+
+	// Drop expired host entries,
+	// to keep the map from growing.
+	for h, t := range d.derefCardHosts {
+		if t.Before(now) {
+			delete(d.derefCardHosts, h)
+		}
+	}
+
+	return nil
+}
+
+// previewCardLink returns the link that should be
+// used to generate a preview card for given status,
+// ie., the first link in its content that's not to
+// this instance, or nil if it shouldn't have one.
+func previewCardLink(status *gtsmodel.Status) *url.URL {
+	if status.BoostOfID != "" ||
+		status.Visibility == gtsmodel.VisibilityDirect ||
+		status.ContentWarning != "" ||
+		len(status.AttachmentIDs) > 0 {
+		// Boosts have their own card,
+		// and we don't want to leak
+		// direct message links, or show
+		// cards for hidden content or
+		// statuses that have media.
+		return nil
+	}
+
+	for _, link := range text.FindLinks(status.Content) {
+		u, err := url.Parse(link)
+		if err != nil || u.Host == "" {
+			continue
+		}
+
+		host := strings.ToLower(u.Hostname())
+		if host == config.GetHost() ||
+			host == config.GetAccountDomain() {
+			// Link to
+			// ourselves.
+			continue
+		}
+
+		return u
+	}
+
+	return nil
+}
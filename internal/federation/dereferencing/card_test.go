@@ -0,0 +1,224 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/federation/dereferencing"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type CardTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+func (suite *CardTestSuite) SetupTest() {
+	suite.DereferencerStandardTestSuite.SetupTest()
+
+	image, err := os.ReadFile("../../../testrig/media/thoughtsofdog-original.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Serve a web page with a card,
+	// its oEmbed, and its preview image.
+	pages := map[string]struct {
+		contentType string
+		body        []byte
+	}{
+		"https://example.org/robots.txt": {
+			"text/plain",
+			[]byte("User-agent: *\nDisallow: /private"),
+		},
+		"https://example.org/article": {
+			"text/html; charset=utf-8",
+			[]byte(`<!DOCTYPE html>
+<html>
+<head>
+<title>Page title</title>
+<meta property="og:title" content="Dogs: A Retrospective">
+<meta name="twitter:title" content="Twitter title">
+<meta name="description" content="Everything you ever wanted to know about dogs.">
+<meta property="og:image" content="/image.jpg">
+<link rel="alternate" type="application/json+oembed" href="https://example.org/oembed?url=https%3A%2F%2Fexample.org%2Farticle">
+</head>
+<body><meta property="og:title" content="Not a head tag"></body>
+</html>`),
+		},
+		"https://example.org/oembed?url=https%3A%2F%2Fexample.org%2Farticle": {
+			"application/json",
+			[]byte(`{
+  "type": "video",
+  "provider_name": "Example Videos",
+  "provider_url": "https://example.org",
+  "html": "<iframe src=\"https://example.org/embed/1\" onload=\"evil()\"></iframe><script>evil()</script>",
+  "width": 640,
+  "height": "360"
+}`),
+		},
+		"https://example.org/private/article": {
+			"text/html",
+			[]byte(`<html><head><title>Secret</title></head></html>`),
+		},
+		"https://example.org/image.jpg": {
+			"image/jpeg",
+			image,
+		},
+	}
+
+	suite.client = testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		page, ok := pages[req.URL.String()]
+		if !ok {
+			return &http.Response{
+				Status:     http.StatusText(http.StatusNotFound),
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{},
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+				Request:    req,
+			}, nil
+		}
+		return &http.Response{
+			Status:        http.StatusText(http.StatusOK),
+			StatusCode:    http.StatusOK,
+			ContentLength: int64(len(page.body)),
+			Header:        http.Header{"Content-Type": {page.contentType}},
+			Body:          io.NopCloser(bytes.NewReader(page.body)),
+			Request:       req,
+		}, nil
+	}, "")
+
+	suite.dereferencer = dereferencing.NewDereferencer(
+		&suite.state,
+		suite.converter,
+		testrig.NewTestTransportController(&suite.state, suite.client),
+		suite.visFilter,
+		suite.intFilter,
+		suite.media,
+	)
+}
+
+// statusWithContent updates a test status
+// to have the given content, returning it.
+func (suite *CardTestSuite) statusWithContent(content string) *gtsmodel.Status {
+	status, err := suite.db.GetStatusByID(context.Background(), "01F8MHAYFKS4KMXF8K5Y1C0KRN")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status.Content = content
+	if err := suite.db.UpdateStatus(context.Background(), status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+func (suite *CardTestSuite) TestRefreshStatusPreviewCard() {
+	ctx := context.Background()
+
+	status := suite.statusWithContent(`<p>hello <a href="https://localhost:8080/tags/dogs" class="mention hashtag">#<span>dogs</span></a>, read <a href="https://example.org/article">this</a></p>`)
+
+	err := suite.dereferencer.RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.NotEmpty(status.PreviewCardID)
+
+	// Card should have been stored.
+	card, err := suite.db.GetPreviewCardByURL(ctx, "https://example.org/article")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(status.PreviewCardID, card.ID)
+	suite.Equal(gtsmodel.PreviewCardTypeVideo, card.Type)
+	suite.Equal("Dogs: A Retrospective", card.Title)
+	suite.Equal("Everything you ever wanted to know about dogs.", card.Description)
+	suite.Equal("Example Videos", card.ProviderName)
+	suite.Equal("https://example.org", card.ProviderURL)
+	suite.Equal(`<iframe src="https://example.org/embed/1" width="640" height="360" frameborder="0" allowfullscreen="true"></iframe>`, card.HTML)
+	suite.Equal(640, card.Width)
+	suite.Equal(360, card.Height)
+	suite.Equal("https://example.org/image.jpg", card.ImageRemoteURL)
+
+	// Image thumbnail should be cached.
+	suite.True(*card.Cached)
+	suite.NotEmpty(card.Blurhash)
+	suite.Equal("image/jpeg", card.ImageContentType)
+	have, err := suite.storage.Has(ctx, card.ImagePath)
+	suite.NoError(err)
+	suite.True(have)
+
+	// Card should be populated on the status.
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(card.ID, dbStatus.PreviewCardID)
+	suite.NotNil(dbStatus.PreviewCard)
+
+	// Removing the link should unset the card.
+	status = suite.statusWithContent("<p>nothing to see here</p>")
+	err = suite.dereferencer.RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.Empty(status.PreviewCardID)
+}
+
+func (suite *CardTestSuite) TestRefreshStatusPreviewCardRobotsDisallowed() {
+	ctx := context.Background()
+
+	status := suite.statusWithContent(`<p><a href="https://example.org/private/article">secrets</a></p>`)
+
+	err := suite.dereferencer.RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.Empty(status.PreviewCardID)
+
+	// No card should have been stored.
+	card, err := suite.db.GetPreviewCardByURL(ctx, "https://example.org/private/article")
+	suite.Nil(card)
+	suite.Error(err)
+}
+
+func (suite *CardTestSuite) TestRefreshStatusPreviewCardHostBusy() {
+	ctx := context.Background()
+
+	status := suite.statusWithContent(`<p><a href="https://example.org/article">this</a></p>`)
+
+	err := suite.dereferencer.RefreshStatusPreviewCard(ctx, status)
+	suite.NoError(err)
+	suite.NotEmpty(status.PreviewCardID)
+	cardID := status.PreviewCardID
+
+	// Fetching another page from the same host
+	// straight away should be refused, without
+	// waiting, and leave the status card as-is.
+	status = suite.statusWithContent(`<p><a href="https://example.org/other">that</a></p>`)
+	err = suite.dereferencer.RefreshStatusPreviewCard(ctx, status)
+	suite.ErrorContains(err, "waiting to fetch from example.org")
+	suite.Equal(cardID, status.PreviewCardID)
+}
+
+func TestCardTestSuite(t *testing.T) {
+	suite.Run(t, new(CardTestSuite))
+}
@@ -93,6 +93,11 @@ type Dereferencer struct {
 	derefEmojis   map[string]*media.ProcessingEmoji
 	derefEmojisMu sync.Mutex
 
+	// next allowed times of preview card
+	// page fetches, keyed by remote host.
+	derefCardHosts   map[string]time.Time
+	derefCardHostsMu sync.Mutex
+
 	// handshakes marks current in-progress handshakes
 	// occurring, useful to prevent a deadlock between
 	// gotosocial instances attempting to dereference
@@ -123,6 +128,7 @@ func NewDereferencer(
 		intFilter:           intFilter,
 		derefMedia:          make(map[string]*media.ProcessingMedia),
 		derefEmojis:         make(map[string]*media.ProcessingEmoji),
+		derefCardHosts:      make(map[string]time.Time),
 		handshakes:          make(map[string][]*url.URL),
 	}
 }
@@ -0,0 +1,90 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dereferencing
+
+import (
+	"context"
+	"errors"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// getDomainLimit returns the domain block with limit
+// severity applying to given domain, or nil if none.
+func (d *Dereferencer) getDomainLimit(ctx context.Context, domain string) (*gtsmodel.DomainBlock, error) {
+	limit, err := d.state.DB.GetDomainLimit(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting domain limit %s: %w", domain, err)
+	}
+	return limit, nil
+}
+
+// applyStatusDomainLimit applies the media policy of any
+// domain limit in place for the status author's domain,
+// either stripping attachments or marking them sensitive.
+// Must be called before attachments are fetched.
+func (d *Dereferencer) applyStatusDomainLimit(ctx context.Context, status *gtsmodel.Status) error {
+	limit, err := d.getDomainLimit(ctx, status.Account.Domain)
+	if err != nil {
+		return err
+	}
+
+	if limit == nil || len(status.Attachments) == 0 {
+		// Nothing
+		// to do.
+		return nil
+	}
+
+	if util.PtrOrZero(limit.RejectMedia) {
+		// Drop attachments
+		// before they're fetched.
+		status.Attachments = nil
+		status.AttachmentIDs = nil
+		return nil
+	}
+
+	if util.PtrOrZero(limit.SensitizeMedia) {
+		status.Sensitive = util.Ptr(true)
+	}
+
+	return nil
+}
+
+// applyAccountDomainLimit applies the media policy of any domain
+// limit in place for the account's domain, clearing avatar and
+// header remote URLs so that they won't be fetched if rejected.
+// Must be called before avatar and header are fetched.
+func (d *Dereferencer) applyAccountDomainLimit(ctx context.Context, account *gtsmodel.Account) error {
+	limit, err := d.getDomainLimit(ctx, account.Domain)
+	if err != nil {
+		return err
+	}
+
+	if limit == nil || !util.PtrOrZero(limit.RejectMedia) {
+		// Nothing
+		// to do.
+		return nil
+	}
+
+	account.AvatarRemoteURL = ""
+	account.HeaderRemoteURL = ""
+	return nil
+}
@@ -97,6 +97,12 @@ func (d *Dereferencer) GetStatusByURI(ctx context.Context, requestUser string, u
 
 	} else if statusable != nil {
 
+		// Deref quoted status.
+		d.dereferenceQuote(ctx,
+			requestUser,
+			status,
+		)
+
 		// Deref parents + children.
 		d.dereferenceThread(ctx,
 			requestUser,
@@ -206,6 +212,12 @@ func (d *Dereferencer) RefreshStatus(
 	)
 
 	if statusable != nil {
+		// Deref quoted status.
+		d.dereferenceQuote(ctx,
+			requestUser,
+			latest,
+		)
+
 		// Deref parents + children.
 		d.dereferenceThread(ctx,
 			requestUser,
@@ -255,6 +267,7 @@ func (d *Dereferencer) RefreshStatusAsync(
 			return
 		}
 		if statusable != nil {
+			d.dereferenceQuote(ctx, requestUser, latest)
 			if err := d.DereferenceStatusAncestors(ctx, requestUser, latest); err != nil {
 				log.Error(ctx, err)
 			}
@@ -536,6 +549,13 @@ func (d *Dereferencer) enrichStatus(
 		latestStatus.ApprovedByURI = status.ApprovedByURI
 	}
 
+	// Same as above, but for the approval of a quote,
+	// provided the status still quotes the same thing.
+	if latestStatus.QuoteApprovedByURI == "" &&
+		latestStatus.QuoteOfURI == status.QuoteOfURI {
+		latestStatus.QuoteApprovedByURI = status.QuoteApprovedByURI
+	}
+
 	// Check if this is a permitted status we should accept.
 	// Function also sets "PendingApproval" bool as necessary,
 	// and handles removal of existing statuses no longer permitted.
@@ -550,6 +570,13 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.SetNotPermitted(err)
 	}
 
+	// Check if status is permitted to quote the status it
+	// quotes, if any. Function sets "QuotePendingApproval"
+	// as necessary, and detaches the quote if not permitted.
+	if err := d.isPermittedQuote(ctx, requestUser, latestStatus); err != nil {
+		return nil, nil, gtserror.Newf("error checking quote permissivity for status %s: %w", uri, err)
+	}
+
 	// Insert / update any attached status poll.
 	pollChanged, err := d.handleStatusPoll(ctx,
 		status,
@@ -590,6 +617,12 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.Newf("error populating tags for status %s: %w", uri, err)
 	}
 
+	// Strip or sensitize media attachments
+	// if the author's domain is limited.
+	if err := d.applyStatusDomainLimit(ctx, latestStatus); err != nil {
+		return nil, nil, gtserror.Newf("error applying domain limit for status %s: %w", uri, err)
+	}
+
 	// Populate media attachments associated with status,
 	// passing in existing status to reuse old where possible
 	// (especially important here to reduce need to dereference).
@@ -1148,7 +1181,7 @@ func (d *Dereferencer) handleStatusEdit(
 	status.Edits = existing.Edits
 
 	// Preallocate max slice length.
-	cols = make([]string, 1, 13)
+	cols = make([]string, 1, 18)
 
 	// Always update `fetched_at`.
 	cols[0] = "fetched_at"
@@ -1199,6 +1232,37 @@ func (d *Dereferencer) handleStatusEdit(
 		// actually be included in a thread.
 	}
 
+	if existing.QuoteOfURI != status.QuoteOfURI {
+		// Quoted status changed.
+		cols = append(cols,
+			"quote_of_id",
+			"quote_of_uri",
+			"quote_of_account_id",
+		)
+		edited = true
+	} else if existing.QuoteOfID != status.QuoteOfID {
+		cols = append(cols,
+			"quote_of_id",
+			"quote_of_account_id",
+		)
+
+		// Quote ID changed doesn't necessarily
+		// indicate an edit, it may just not have
+		// been previously dereferenced yet.
+	}
+
+	if util.PtrOrValue(existing.QuotePendingApproval, false) != *status.QuotePendingApproval ||
+		existing.QuoteApprovedByURI != status.QuoteApprovedByURI {
+		cols = append(cols,
+			"quote_pending_approval",
+			"quote_approved_by_uri",
+		)
+
+		// Quote approval changing doesn't
+		// indicate an edit, it may just
+		// have been Accepted in the meantime.
+	}
+
 	if tagsChanged {
 		cols = append(cols, "tags") // i.e. TagIDs
 
@@ -1292,6 +1356,70 @@ func (d *Dereferencer) handleStatusEdit(
 	return cols, nil
 }
 
+// dereferenceQuote dereferences the status quoted by the
+// given status, if it wasn't yet known to us at the time
+// the status was enriched, and updates the given status
+// with the quoted status and its quote approval state.
+//
+// The quoted status is fetched without also dereferencing
+// the status that *it* quotes, preventing any recursion.
+func (d *Dereferencer) dereferenceQuote(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) {
+	if status.QuoteOfURI == "" || status.QuoteOfID != "" {
+		// Not a quote, or
+		// quote already set.
+		return
+	}
+
+	uri, err := url.Parse(status.QuoteOfURI)
+	if err != nil {
+		log.Errorf(ctx, "invalid quote uri %q: %v", status.QuoteOfURI, err)
+		return
+	}
+
+	// Fetch the quoted status. We only care
+	// about errors if no status was returned.
+	quoteOf, _, _, err := d.getStatusByURI(ctx,
+		requestUser,
+		uri,
+	)
+	if err != nil && quoteOf == nil {
+		log.Debugf(ctx, "error dereferencing quote %s: %v", uri, err)
+		return
+	}
+
+	// Lock on the quoting status
+	// URI as we're updating it.
+	unlock := d.state.FedLocks.Lock(status.URI)
+	defer unlock()
+
+	// Set the now-known quoted status.
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+	status.QuoteOfAccountID = quoteOf.AccountID
+	status.QuoteOfAccount = quoteOf.Account
+
+	// Check quote permissivity now
+	// that we know the quoted status.
+	if err := d.isPermittedQuote(ctx, requestUser, status); err != nil {
+		log.Errorf(ctx, "error checking quote permissivity for status %s: %v", status.URI, err)
+		return
+	}
+
+	if err := d.state.DB.UpdateStatus(ctx, status,
+		"quote_of_id",
+		"quote_of_uri",
+		"quote_of_account_id",
+		"quote_pending_approval",
+		"quote_approved_by_uri",
+	); err != nil {
+		log.Errorf(ctx, "error updating quote for status %s: %v", status.URI, err)
+	}
+}
+
 // newOrExistingMention tries to populate the given
 // mention with the correct TargetAccount and (if not
 // yet set) TargetAccountURI, returning the populated
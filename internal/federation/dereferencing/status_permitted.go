@@ -637,6 +637,152 @@ func (d *Dereferencer) isPermittedBoost(
 	return true, nil
 }
 
+// isPermittedQuote checks whether the given status is
+// permitted to quote the status that it quotes (if any),
+// setting "QuotePendingApproval" on status as necessary.
+//
+// Unlike an unpermitted reply or boost, an unpermitted
+// quote doesn't cause the status to be dropped; the status
+// is just detached from the status it tries to quote.
+//
+// If the quoted status is not yet known to us, then
+// nothing is checked; see dereferenceQuote().
+func (d *Dereferencer) isPermittedQuote(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) error {
+	quoteOf := status.QuoteOf
+	if quoteOf == nil {
+		// Not a quote, or
+		// quote not known yet.
+		return nil
+	}
+
+	if quoteOf.BoostOfID != "" {
+		// We do not permit quotes of
+		// boost wrapper statuses.
+		detachQuote(status)
+		return nil
+	}
+
+	// Check if this quote was already Rejected.
+	req, err := d.state.DB.GetInteractionRequestByInteractionURI(ctx,
+		status.QuoteRequestURI(),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting interaction request: %w", err)
+	}
+
+	if req != nil && req.IsRejected() {
+		// Quote was already rejected.
+		detachQuote(status)
+		return nil
+	}
+
+	// Check visibility of local
+	// quoteOf to quoting account.
+	if quoteOf.IsLocal() {
+		visible, err := d.visFilter.StatusVisible(ctx,
+			status.Account,
+			quoteOf,
+		)
+		if err != nil {
+			return gtserror.Newf("error checking quoteOf visibility: %w", err)
+		}
+
+		if !visible {
+			detachQuote(status)
+			return nil
+		}
+	}
+
+	// Check interaction policy of quoteOf.
+	quotable, err := d.intFilter.StatusQuotable(ctx,
+		status.Account,
+		quoteOf,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status quotability: %w", err)
+	}
+
+	if quotable.Forbidden() {
+		// Quoter is not permitted
+		// to do this interaction.
+		detachQuote(status)
+		return nil
+	}
+
+	if quotable.Permitted() &&
+		!quotable.MatchedOnCollection() {
+		// Quoter is permitted to do this
+		// interaction, and didn't match on
+		// a collection so we don't need to
+		// do further checking.
+		status.QuotePendingApproval = util.Ptr(false)
+		return nil
+	}
+
+	if status.QuoteApprovedByURI == "" {
+		// Quote doesn't claim to be approved.
+		//
+		// For quotes of local statuses we can mark
+		// it as pending approval, or pre-approved if
+		// permission matched a followers / following
+		// collection so an Accept is sent immediately.
+		//
+		// For quotes of remote statuses we just
+		// leave it pending approval by the remote.
+		status.QuotePendingApproval = util.Ptr(true)
+		status.QuotePreApproved = quoteOf.IsLocal() &&
+			quotable.MatchedOnCollection()
+		return nil
+	}
+
+	// Quote claims to be approved, check
+	// this by dereferencing the approvedBy
+	// and inspecting the return value.
+	permitted, err := d.isValidApprovedByIRI(
+		ctx,
+		gtsmodel.InteractionQuote,
+		requestUser,
+		status.QuoteApprovedByURI, // approval uri
+		quoteOf.AccountURI,        // actor
+		status.URI,                // object
+		status.QuoteOfURI,         // target
+	)
+	if err != nil {
+		// Error dereferencing means we couldn't get
+		// the approval right now, but don't drop the
+		// status for this, just leave quote pending.
+		log.Warnf(ctx, "undereferencable QuoteApprovedByURI: %v", err)
+		permitted = false
+	}
+
+	if !permitted {
+		status.QuotePendingApproval = util.Ptr(true)
+		status.QuoteApprovedByURI = ""
+		return nil
+	}
+
+	// Quote has been approved.
+	status.QuotePendingApproval = util.Ptr(false)
+	return nil
+}
+
+// detachQuote clears the quote fields on given status,
+// leaving the rest of the status itself untouched.
+func detachQuote(status *gtsmodel.Status) {
+	status.QuoteOfID = ""
+	status.QuoteOfURI = ""
+	status.QuoteOfAccountID = ""
+	status.QuoteOf = nil
+	status.QuoteOfAccount = nil
+	status.QuotePendingApproval = util.Ptr(false)
+	status.QuotePreApproved = false
+	status.QuoteApprovedByURI = ""
+}
+
 // isValidApprovedByIRI dereferences the activitystreams Accept or approval
 // at the specified IRI, and checks the Accept or approval for validity
 // against the provided expectedActor, expectedObject, and expectedTarget.
@@ -93,6 +93,23 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 
 			// ACCEPT FOLLOW
 			case name == ap.ActivityFollow:
+				// Check first whether this is the
+				// Follow of a relay by our instance.
+				isRelay, err := f.updateRelayFollow(
+					ctx,
+					ap.GetJSONLDId(asType),
+					receivingAcct,
+					requestingAcct,
+					gtsmodel.RelayStateAccepted,
+				)
+				if err != nil {
+					return err
+				}
+
+				if isRelay {
+					continue
+				}
+
 				if err := f.acceptFollowType(
 					ctx,
 					asType,
@@ -154,7 +171,28 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 		} else if object.IsIRI() {
 			// Check and handle any
 			// IRI type objects.
-			switch objIRI := object.GetIRI(); {
+			objIRI := object.GetIRI()
+
+			// Check first whether this is the Follow of a relay
+			// by our instance. This is done outside the switch
+			// as the instance actor's username (the host) may
+			// not match the usual Follow path pattern.
+			isRelay, err := f.updateRelayFollow(
+				ctx,
+				objIRI,
+				receivingAcct,
+				requestingAcct,
+				gtsmodel.RelayStateAccepted,
+			)
+			if err != nil {
+				return err
+			}
+
+			if isRelay {
+				continue
+			}
+
+			switch {
 
 			// ACCEPT FOLLOW
 			case uris.IsFollowPath(objIRI):
@@ -432,6 +470,23 @@ func (f *federatingDB) acceptStoredStatus(
 	unlock := f.state.FedLocks.Lock(status.URI)
 	defer unlock()
 
+	// Check if we're dealing with the Accept of a
+	// pending quote, ie., the requester is the author
+	// of the quoted status, and not the author of a
+	// replied-to status (in which case reply takes precedence).
+	if util.PtrOrValue(status.QuotePendingApproval, false) &&
+		status.QuoteOfAccountID == requestingAcct.ID &&
+		status.InReplyToAccountID != requestingAcct.ID {
+		return f.acceptStoredQuote(
+			ctx,
+			acceptID,
+			accept,
+			status,
+			receivingAcct,
+			requestingAcct,
+		)
+	}
+
 	pendingApproval := util.PtrOrValue(status.PendingApproval, false)
 	if !pendingApproval {
 		// Status doesn't need approval or it's
@@ -489,6 +544,46 @@ func (f *federatingDB) acceptStoredStatus(
 	return nil
 }
 
+func (f *federatingDB) acceptStoredQuote(
+	ctx context.Context,
+	acceptID *url.URL,
+	accept vocab.ActivityStreamsAccept,
+	status *gtsmodel.Status,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) error {
+	// Extract appropriate approvedByURI from the Accept.
+	approvedByURI, err := approvedByURI(acceptID, accept)
+	if err != nil {
+		return gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	// Mark the quote as approved by this URI.
+	status.QuotePendingApproval = util.Ptr(false)
+	status.QuoteApprovedByURI = approvedByURI.String()
+	if err := f.state.DB.UpdateStatus(
+		ctx,
+		status,
+		"quote_pending_approval",
+		"quote_approved_by_uri",
+	); err != nil {
+		err := gtserror.Newf("db error accepting quote: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Send the now-approved quote through to the
+	// fedi worker again to process side effects.
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActivityQuoteRequest,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       status,
+		Receiving:      receivingAcct,
+		Requesting:     requestingAcct,
+	})
+
+	return nil
+}
+
 func (f *federatingDB) acceptLikeIRI(
 	ctx context.Context,
 	acceptID *url.URL,
@@ -60,6 +60,41 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		)
 	}
 
+	// Get IRIs of the announced objects. Groups
+	// following FEP-1b12 also announce activities
+	// like Likes and Deletes, which we can't boost.
+	objectIRIs := ap.ExtractAnnouncedIRIs(announce)
+	if len(objectIRIs) == 0 && len(ap.ExtractObjects(announce)) != 0 {
+		log.Debugf(ctx, "ignoring announce of unsupported activity from %s", requestingAcct.URI)
+		return nil
+	}
+
+	// Check whether the Announce comes
+	// from a relay we're subscribed to.
+	relay, err := f.acceptedRelay(ctx, requestingAcct)
+	if err != nil {
+		return err
+	}
+
+	if relay != nil {
+		// Relays Announce public statuses from
+		// elsewhere in the fediverse. Rather than
+		// creating a boost by the relay actor (and
+		// thereby a home timeline entry for anyone
+		// following it), just ingest the statuses.
+		var errs gtserror.MultiError
+		for _, objectIRI := range objectIRIs {
+			if err := f.relayStatus(ctx,
+				objectIRI,
+				receivingAcct,
+				requestingAcct,
+			); err != nil {
+				errs.Append(err)
+			}
+		}
+		return errs.Combine()
+	}
+
 	boost, isNew, err := f.converter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
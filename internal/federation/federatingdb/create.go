@@ -281,6 +281,25 @@ func (f *federatingDB) createStatusable(
 	statusable ap.Statusable,
 	forwarded bool,
 ) error {
+	if forwarded {
+		// Check whether this status was
+		// forwarded by a relay we're subscribed
+		// to, in which case it's relevant by
+		// definition and we can just deref it.
+		relay, err := f.acceptedRelay(ctx, requester)
+		if err != nil {
+			return err
+		}
+
+		if relay != nil {
+			return f.relayStatus(ctx,
+				ap.GetJSONLDId(statusable),
+				receiver,
+				requester,
+			)
+		}
+	}
+
 	// Check whether this status is both
 	// relevant, and doesn't look like spam.
 	err := f.spamFilter.StatusableOK(ctx,
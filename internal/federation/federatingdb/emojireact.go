@@ -0,0 +1,165 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+)
+
+// emojiReact handles an emoji reaction, ie., an incoming
+// EmojiReact, or a Like with content (as sent by Misskey).
+func (f *federatingDB) emojiReact(
+	ctx context.Context,
+	reactable ap.EmojiReactable,
+	requesting *gtsmodel.Account,
+	receiving *gtsmodel.Account,
+) error {
+	// Convert received AS reaction to internal reaction model.
+	reaction, err := f.converter.ASEmojiReactToStatusReaction(ctx, reactable)
+	if err != nil {
+		err := gtserror.Newf("error converting from AS type: %w", err)
+		return gtserror.WrapWithCode(http.StatusBadRequest, err)
+	}
+
+	// Ensure reaction enacted by correct account.
+	if reaction.AccountID != requesting.ID {
+		return gtserror.NewfWithCode(http.StatusForbidden, "requester %s is not expected actor %s",
+			requesting.URI, reaction.Account.URI)
+	}
+
+	// Ensure reaction received by correct account.
+	if reaction.TargetAccountID != receiving.ID {
+		return gtserror.NewfWithCode(http.StatusForbidden, "receiver %s is not expected object %s",
+			receiving.URI, reaction.TargetAccount.URI)
+	}
+
+	if !*reaction.Status.Local {
+		// Only process reactions to local statuses.
+		return nil
+	}
+
+	// Reactions are gated by the
+	// same policy rules as Likes.
+	policyResult, err := f.intFilter.StatusLikeable(ctx,
+		requesting,
+		reaction.Status,
+	)
+	if err != nil {
+		return gtserror.Newf("error seeing if status %s is likeable: %w", reaction.Status.URI, err)
+	}
+
+	if policyResult.Forbidden() {
+		return gtserror.NewWithCode(http.StatusForbidden, "requester does not have permission to react to status")
+	}
+
+	if policyResult.WithApproval() {
+		// Reactions can't be approved
+		// later like Likes can, so just
+		// drop ones that require approval.
+		log.Debugf(ctx, "dropping reaction %s requiring approval", reaction.URI)
+		return nil
+	}
+
+	// Check whether we already have
+	// this reaction from this account.
+	existing, err := f.state.DB.GetStatusReaction(
+		gtscontext.SetBarebones(ctx),
+		reaction.AccountID,
+		reaction.StatusID,
+		reaction.Name,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error checking existing reaction: %w", err)
+	}
+
+	if existing != nil {
+		// Already handled.
+		return nil
+	}
+
+	// Pass to the processor to fetch any custom
+	// emoji for the reaction, and store it there.
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Receiving:      receiving,
+		Requesting:     requesting,
+	})
+
+	return nil
+}
+
+// undoEmojiReact handles an Undo of an emoji reaction.
+func (f *federatingDB) undoEmojiReact(
+	ctx context.Context,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+	reactable ap.EmojiReactable,
+) error {
+	uri := ap.GetJSONLDId(reactable)
+	if uri == nil {
+		err := gtserror.New("unusable iri property")
+		return gtserror.SetMalformed(err)
+	}
+
+	// Fetch the reaction from the DB by its URI.
+	reaction, err := f.state.DB.GetStatusReactionByURI(
+		gtscontext.SetBarebones(ctx),
+		uri.String(),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting reaction %s: %w", uri, err)
+	}
+
+	if reaction == nil {
+		// We didn't have this reaction
+		// stored anyway, so we can't
+		// Undo it, just ignore.
+		return nil
+	}
+
+	// Ensure addressee is reaction target.
+	if reaction.TargetAccountID != receivingAcct.ID {
+		const text = "receivingAcct was not reaction target"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Ensure requester is reaction origin.
+	if reaction.AccountID != requestingAcct.ID {
+		const text = "requestingAcct was not reaction origin"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Delete the reaction.
+	if err := f.state.DB.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		return gtserror.Newf("db error deleting reaction %s: %w", reaction.ID, err)
+	}
+
+	log.Debug(ctx, "EmojiReact undone")
+	return nil
+}
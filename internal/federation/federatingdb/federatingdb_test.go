@@ -48,6 +48,7 @@ type FederatingDBTestSuite struct {
 	testAttachments  map[string]*gtsmodel.MediaAttachment
 	testStatuses     map[string]*gtsmodel.Status
 	testBlocks       map[string]*gtsmodel.Block
+	testRelays       map[string]*gtsmodel.Relay
 	testActivities   map[string]testrig.ActivityWithSignature
 }
 
@@ -66,6 +67,7 @@ func (suite *FederatingDBTestSuite) SetupSuite() {
 	suite.testAttachments = testrig.NewTestAttachments()
 	suite.testStatuses = testrig.NewTestStatuses()
 	suite.testBlocks = testrig.NewTestBlocks()
+	suite.testRelays = testrig.NewTestRelays()
 }
 
 func (suite *FederatingDBTestSuite) SetupTest() {
@@ -52,6 +52,16 @@ func (f *federatingDB) Like(ctx context.Context, likeable vocab.ActivityStreamsL
 		return nil
 	}
 
+	if ap.ExtractContent(likeable).Content != "" {
+		// A Like with content is an emoji
+		// reaction, handle it separately.
+		return f.emojiReact(ctx,
+			likeable,
+			requesting,
+			receiving,
+		)
+	}
+
 	// Convert received AS like type to internal fave model.
 	fave, err := f.converter.ASLikeToFave(ctx, likeable)
 	if err != nil {
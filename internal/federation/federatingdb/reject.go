@@ -30,6 +30,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/messages"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
 	"code.superseriousbusiness.org/gotosocial/internal/uris"
 )
 
@@ -58,6 +59,23 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 
 			// REJECT FOLLOW
 			case ap.ActivityFollow:
+				// Check first whether this is the
+				// Follow of a relay by our instance.
+				isRelay, err := f.updateRelayFollow(
+					ctx,
+					ap.GetJSONLDId(asType),
+					receivingAcct,
+					requestingAcct,
+					gtsmodel.RelayStateRejected,
+				)
+				if err != nil {
+					return err
+				}
+
+				if isRelay {
+					continue
+				}
+
 				if err := f.rejectFollowType(
 					ctx,
 					asType,
@@ -75,7 +93,28 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 		} else if object.IsIRI() {
 			// Check and handle any
 			// IRI type objects.
-			switch objIRI := object.GetIRI(); {
+			objIRI := object.GetIRI()
+
+			// Check first whether this is the Follow of a relay
+			// by our instance. This is done outside the switch
+			// as the instance actor's username (the host) may
+			// not match the usual Follow path pattern.
+			isRelay, err := f.updateRelayFollow(
+				ctx,
+				objIRI,
+				receivingAcct,
+				requestingAcct,
+				gtsmodel.RelayStateRejected,
+			)
+			if err != nil {
+				return err
+			}
+
+			if isRelay {
+				continue
+			}
+
+			switch {
 
 			// REJECT FOLLOW
 			case uris.IsFollowPath(objIRI):
@@ -271,6 +310,22 @@ func (f *federatingDB) rejectStatusIRI(
 		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 	}
 
+	// Check if we're dealing with the Reject of a
+	// quote, ie., the requester is the author of the
+	// quoted status, and not the author of a replied-to
+	// status (in which case the reply takes precedence).
+	if status.IsQuote() &&
+		status.QuoteOfAccountID == requestingAcct.ID &&
+		status.InReplyToAccountID != requestingAcct.ID {
+		return f.rejectStatusQuote(
+			ctx,
+			activityID,
+			status,
+			receivingAcct,
+			requestingAcct,
+		)
+	}
+
 	// Check if we're dealing with a reply
 	// or an announce, and make sure the
 	// requester is permitted to Reject.
@@ -497,3 +552,71 @@ func (f *federatingDB) rejectLikeIRI(
 
 	return nil
 }
+
+func (f *federatingDB) rejectStatusQuote(
+	ctx context.Context,
+	activityID string,
+	status *gtsmodel.Status,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) error {
+	// Get the interaction request for the quote,
+	// if it exists. Quote requests are keyed on
+	// a suffixed version of the status URI.
+	req, err := f.state.DB.GetInteractionRequestByInteractionURI(ctx, status.QuoteRequestURI())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting interaction request: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	switch {
+	case req == nil:
+		// No interaction request existed yet for this
+		// quote, create a pre-rejected request for it.
+		req = typeutils.StatusQuoteToInteractionRequest(status)
+		req.TargetAccount = requestingAcct
+		req.URI = activityID
+		req.RejectedAt = time.Now()
+		if err := f.state.DB.PutInteractionRequest(ctx, req); err != nil {
+			err := gtserror.Newf("db error inserting interaction request: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+	case req.IsRejected():
+		// Interaction has already been rejected. Just
+		// update to this Reject URI and then return early.
+		req.URI = activityID
+		if err := f.state.DB.UpdateInteractionRequest(ctx, req, "uri"); err != nil {
+			err := gtserror.Newf("db error updating interaction request: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+		return nil
+
+	default:
+		// Mark existing interaction request as
+		// Rejected, even if previously Accepted.
+		req.AcceptedAt = time.Time{}
+		req.RejectedAt = time.Now()
+		req.URI = activityID
+		if err := f.state.DB.UpdateInteractionRequest(ctx, req,
+			"accepted_at",
+			"rejected_at",
+			"uri",
+		); err != nil {
+			err := gtserror.Newf("db error updating interaction request: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Send the rejected request through to
+	// the fedi worker to process side effects.
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActivityQuoteRequest,
+		APActivityType: ap.ActivityReject,
+		GTSModel:       req,
+		Receiving:      receivingAcct,
+		Requesting:     requestingAcct,
+	})
+
+	return nil
+}
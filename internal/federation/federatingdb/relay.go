@@ -0,0 +1,160 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+)
+
+// updateRelayFollow checks whether the given Follow IRI
+// is that of a relay subscription sent by our instance
+// actor, and if so updates the relay to the given state
+// in response to an Accept or Reject of the Follow.
+//
+// Returns true if the Follow IRI belonged to a relay,
+// in which case no further processing is required.
+func (f *federatingDB) updateRelayFollow(
+	ctx context.Context,
+	followIRI *url.URL,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+	state gtsmodel.RelayState,
+) (bool, error) {
+	if followIRI == nil || !receivingAcct.IsInstance() {
+		// Relays are only ever
+		// followed by instance actor.
+		return false, nil
+	}
+
+	// Lock on the Follow URI
+	// as we may be updating it.
+	unlock := f.state.FedLocks.Lock(followIRI.String())
+	defer unlock()
+
+	relay, err := f.state.DB.GetRelayByFollowURI(ctx, followIRI.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay: %w", err)
+		return false, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay == nil {
+		// Not a relay Follow.
+		return false, nil
+	}
+
+	// If we already know the actor of the relay, make
+	// sure the relay is the one making the request.
+	if relay.ActorURI != "" && relay.ActorURI != requestingAcct.URI {
+		const text = "relay actor and requesting account were not the same"
+		return true, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if relay.State != gtsmodel.RelayStatePending {
+		// Only pending Follows can be accepted or
+		// rejected, this may be a late response to
+		// a Follow that an admin has since undone.
+		log.Debugf(ctx, "relay %s not pending, ignoring response", relay.InboxURI)
+		return true, nil
+	}
+
+	// Update the relay state, storing the
+	// actor URI for Mastodon-style relays
+	// which we only know from their response.
+	relay.ActorURI = requestingAcct.URI
+	relay.State = state
+	if err := f.state.DB.UpdateRelay(ctx,
+		relay,
+		"actor_uri",
+		"state",
+	); err != nil {
+		err := gtserror.Newf("db error updating relay: %w", err)
+		return true, gtserror.NewErrorInternalError(err)
+	}
+
+	return true, nil
+}
+
+// acceptedRelay returns the accepted relay
+// with the given account as its actor, if any.
+func (f *federatingDB) acceptedRelay(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*gtsmodel.Relay, error) {
+	relay, err := f.state.DB.GetRelayByActorURI(ctx, account.URI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting relay: %w", err)
+	}
+
+	if relay == nil || !relay.IsAccepted() {
+		return nil, nil
+	}
+
+	return relay, nil
+}
+
+// relayStatus enqueues the status with the given IRI,
+// received via a relay, to be dereferenced from its
+// origin server by the fedi API worker. This ensures we
+// don't have to trust the relay's copy of the status,
+// nor create any boost wrapper authored by the relay.
+func (f *federatingDB) relayStatus(
+	ctx context.Context,
+	statusIRI *url.URL,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) error {
+	if statusIRI == nil {
+		// We need an ID.
+		return gtserror.New("relayed status had no id")
+	}
+
+	// Check if we already have this status stored,
+	// if so there's nothing to do here: we'll get
+	// any changes to it via the normal routes.
+	_, err := f.state.DB.GetStatusByURI(
+		gtscontext.SetBarebones(ctx),
+		statusIRI.String(),
+	)
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting status: %w", err)
+	}
+
+	// Pass the status IRI into the processor
+	// worker to dereference it asynchronously.
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		APIRI:          statusIRI,
+		Receiving:      receivingAcct,
+		Requesting:     requestingAcct,
+	})
+
+	return nil
+}
@@ -0,0 +1,158 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/activity/streams"
+	"code.superseriousbusiness.org/activity/streams/vocab"
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type RelayTestSuite struct {
+	FederatingDBTestSuite
+}
+
+// relayResponse returns an Accept or Reject
+// of the given relay's Follow, by actor.
+func relayResponse[T interface {
+	vocab.Type
+	SetActivityStreamsActor(vocab.ActivityStreamsActorProperty)
+	SetActivityStreamsObject(vocab.ActivityStreamsObjectProperty)
+}](
+	activity T,
+	actor *gtsmodel.Account,
+	relay *gtsmodel.Relay,
+) T {
+	ap.SetJSONLDIdStr(activity, actor.URI+"/"+id.NewULID())
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(actor.URI))
+	activity.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(testrig.URLMustParse(relay.FollowURI))
+	activity.SetActivityStreamsObject(objectProp)
+
+	return activity
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollow() {
+	var (
+		ctx        = context.Background()
+		relay      = suite.testRelays["pending_relay"]
+		instance   = suite.testAccounts["instance_account"]
+		relayActor = suite.testAccounts["remote_account_1"]
+	)
+
+	accept := relayResponse(streams.NewActivityStreamsAccept(), relayActor, relay)
+	err := suite.federatingDB.Accept(createTestContext(instance, relayActor), accept)
+	suite.NoError(err)
+
+	// Relay should now be accepted,
+	// with actor URI set from response.
+	relay, err = suite.db.GetRelayByID(ctx, relay.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.RelayStateAccepted, relay.State)
+	suite.Equal(relayActor.URI, relay.ActorURI)
+
+	// No follow processing should have happened.
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollowWrongInbox() {
+	var (
+		ctx        = context.Background()
+		relay      = suite.testRelays["pending_relay"]
+		receiving  = suite.testAccounts["local_account_1"]
+		relayActor = suite.testAccounts["remote_account_1"]
+	)
+
+	// Accept sent to someone other than
+	// instance actor shouldn't touch relay.
+	accept := relayResponse(streams.NewActivityStreamsAccept(), relayActor, relay)
+	err := suite.federatingDB.Accept(createTestContext(receiving, relayActor), accept)
+	suite.NoError(err)
+
+	relay, err = suite.db.GetRelayByID(ctx, relay.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.RelayStatePending, relay.State)
+	suite.Empty(relay.ActorURI)
+}
+
+func (suite *RelayTestSuite) TestRejectRelayFollow() {
+	var (
+		ctx        = context.Background()
+		relay      = suite.testRelays["pending_relay"]
+		instance   = suite.testAccounts["instance_account"]
+		relayActor = suite.testAccounts["remote_account_1"]
+	)
+
+	reject := relayResponse(streams.NewActivityStreamsReject(), relayActor, relay)
+	err := suite.federatingDB.Reject(createTestContext(instance, relayActor), reject)
+	suite.NoError(err)
+
+	relay, err = suite.db.GetRelayByID(ctx, relay.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.RelayStateRejected, relay.State)
+}
+
+func (suite *RelayTestSuite) TestAnnounceFromRelay() {
+	var (
+		ctx        = context.Background()
+		relay      = suite.testRelays["pending_relay"]
+		instance   = suite.testAccounts["instance_account"]
+		relayActor = suite.testAccounts["remote_account_1"]
+	)
+
+	// Mark relay as accepted, with
+	// remote_account_1 as the relay actor.
+	relay.ActorURI = relayActor.URI
+	relay.State = gtsmodel.RelayStateAccepted
+	if err := suite.db.UpdateRelay(ctx, relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	announce := suite.testActivities["announce_forwarded_1_zork"]
+	err := suite.federatingDB.Announce(
+		createTestContext(instance, relayActor),
+		announce.Activity.(vocab.ActivityStreamsAnnounce),
+	)
+	suite.NoError(err)
+
+	// Should be a message to dereference the announced
+	// status directly, rather than to create a boost.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	suite.True(ok)
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Nil(msg.GTSModel)
+	suite.Equal("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1", msg.APIRI.String())
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, &RelayTestSuite{})
+}
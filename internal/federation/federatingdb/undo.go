@@ -205,6 +205,16 @@ func (f *federatingDB) undoLike(
 		return nil
 	}
 
+	if ap.ExtractContent(asLike).Content != "" {
+		// A Like with content is an emoji
+		// reaction, handle it separately.
+		return f.undoEmojiReact(ctx,
+			receivingAcct,
+			requestingAcct,
+			asLike,
+		)
+	}
+
 	// Convert AS Like to barebones *gtsmodel.StatusFave,
 	// retrieving liking acct and target status from the DB.
 	fave, err := f.converter.ASLikeToFave(
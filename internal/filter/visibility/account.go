@@ -22,6 +22,7 @@
 
 	"code.superseriousbusiness.org/gotosocial/internal/cache"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
@@ -118,6 +119,13 @@ func (f *Filter) isAccountVisible(ctx context.Context, account *gtsmodel.Account
 			return true, nil
 		}
 
+		if account.IsGroup() {
+			// Local group accounts have
+			// no user, so just check that
+			// the group isn't suspended.
+			return account.SuspendedAt.IsZero(), nil
+		}
+
 		// Fetch the local user model for this account.
 		user, err := f.state.DB.GetUserByAccountID(ctx, account.ID)
 		if err != nil {
@@ -152,3 +160,67 @@ func (f *Filter) isAccountVisible(ctx context.Context, account *gtsmodel.Account
 
 	return true, nil
 }
+
+// AccountSilenced checks whether given account is silenced from the
+// perspective of requester, ie., the account has been silenced by an
+// admin (or is on a domain limited by a domain block), and requester
+// neither is that account nor follows it. Silenced accounts should be
+// hidden from public timelines, hashtag timelines and search results.
+func (f *Filter) AccountSilenced(ctx context.Context, requester *gtsmodel.Account, account *gtsmodel.Account) (bool, error) {
+	silenced := account.IsSilenced()
+
+	if !silenced && !account.IsLocal() {
+		// Check whether the account's
+		// domain is limited instead.
+		var err error
+		silenced, err = f.state.DB.IsDomainLimited(ctx, account.Domain)
+		if err != nil {
+			return false, gtserror.Newf("error checking domain limit: %w", err)
+		}
+	}
+
+	if !silenced {
+		// Not silenced at all.
+		return false, nil
+	}
+
+	if requester == nil {
+		// Silenced, and requester
+		// can't possibly be a follower.
+		return true, nil
+	}
+
+	if requester.ID == account.ID {
+		// Silenced accounts can
+		// always see themselves.
+		return false, nil
+	}
+
+	// Followers can see silenced accounts.
+	follows, err := f.state.DB.IsFollowing(ctx,
+		requester.ID,
+		account.ID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking follow: %w", err)
+	}
+
+	return !follows, nil
+}
+
+// StatusAuthorSilenced is a small wrapper around AccountSilenced()
+// that ensures the author of the given status is loaded first.
+func (f *Filter) StatusAuthorSilenced(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if status.Account == nil {
+		var err error
+		status.Account, err = f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error getting status author: %w", err)
+		}
+	}
+
+	return f.AccountSilenced(ctx, requester, status.Account)
+}
@@ -65,7 +65,19 @@ func (f *Filter) StatusPublicTimelineable(ctx context.Context, requester *gtsmod
 		return false, err
 	}
 
-	return visibility.Value, nil
+	if !visibility.Value {
+		return false, nil
+	}
+
+	// Silencing is checked outside of the visibility cache, as
+	// an account being (un)silenced doesn't invalidate cached
+	// visibility entries for each of that account's statuses.
+	silenced, err := f.StatusAuthorSilenced(ctx, requester, status)
+	if err != nil {
+		return false, err
+	}
+
+	return !silenced, nil
 }
 
 func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusPublicTimelineableTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusPublicTimelineableTestSuite) TestPublicTimelineable() {
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+	ctx := context.Background()
+
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, nil, testStatus)
+	suite.NoError(err)
+
+	suite.True(timelineable)
+}
+
+func (suite *StatusPublicTimelineableTestSuite) TestSilencedPublicTimelineable() {
+	ctx := context.Background()
+
+	// Silence the author of the status.
+	author := new(gtsmodel.Account)
+	*author = *suite.testAccounts["local_account_1"]
+	author.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, author, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testStatus, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_1_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, test := range []struct {
+		requester    *gtsmodel.Account
+		timelineable bool
+	}{
+		// Unauthed requester, not a follower.
+		{nil, false},
+		// Not a follower.
+		{suite.testAccounts["remote_account_1"], false},
+		// Follower.
+		{suite.testAccounts["admin_account"], true},
+		// The silenced account itself.
+		{author, true},
+	} {
+		timelineable, err := suite.filter.StatusPublicTimelineable(ctx, test.requester, testStatus)
+		suite.NoError(err)
+		suite.Equal(test.timelineable, timelineable)
+	}
+}
+
+func TestStatusPublicTimelineableTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusPublicTimelineableTestSuite))
+}
@@ -55,6 +55,17 @@ func (f *Filter) StatusTagTimelineable(
 		return false, nil
 	}
 
+	// Check whether status author is silenced to requester.
+	silenced, err := f.StatusAuthorSilenced(ctx, requester, status)
+	if err != nil {
+		return false, err
+	}
+
+	if silenced {
+		log.Trace(ctx, "status author silenced to timeline requester")
+		return false, nil
+	}
+
 	// Looks good!
 	return true, nil
 }
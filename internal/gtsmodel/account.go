@@ -315,6 +315,14 @@ func (a *Account) IsInstance() bool {
 		a.Username == "instance.actor" // <- misskey
 }
 
+// IsGroup returns whether account is a Group actor,
+// ie., a community that members can follow and post to.
+//
+// Local Group accounts have no associated user.
+func (a *Account) IsGroup() bool {
+	return a.ActorType == AccountActorTypeGroup
+}
+
 // EmojisPopulated returns whether emojis are
 // populated according to current EmojiIDs.
 func (a *Account) EmojisPopulated() bool {
@@ -382,6 +390,18 @@ func (a *Account) IsSuspended() bool {
 	return !a.SuspendedAt.IsZero()
 }
 
+// IsSilenced returns true if account
+// has been silenced on this instance.
+func (a *Account) IsSilenced() bool {
+	return !a.SilencedAt.IsZero()
+}
+
+// IsSensitized returns true if account has been
+// set to have all its media marked as sensitive.
+func (a *Account) IsSensitized() bool {
+	return !a.SensitizedAt.IsZero()
+}
+
 // IsMoving returns true if
 // account is Moving or has Moved.
 func (a *Account) IsMoving() bool {
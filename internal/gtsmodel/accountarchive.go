@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AccountArchive represents an archive of a local
+// account's data, requested by the account owner,
+// which is stored temporarily for them to download.
+type AccountArchive struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID   string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account is this an archive of?
+	Account     *Account  `bun:"-"`                                                           // account corresponding to accountID
+	Path        string    `bun:",nullzero"`                                                   // storage path of the archive file, once processed
+	Size        int64     `bun:",nullzero"`                                                   // size of the archive file in bytes, once processed
+	ProcessedAt time.Time `bun:"type:timestamptz,nullzero"`                                   // when was the archive finished processing (successfully or not)?
+	Failed      *bool     `bun:",nullzero,notnull,default:false"`                             // did processing of the archive fail?
+}
+
+// AccountArchiveRetention is how long account
+// archives are kept around after they're requested.
+const AccountArchiveRetention = 7 * 24 * time.Hour
+
+// Pending returns true if the
+// archive is still being processed.
+func (a *AccountArchive) Pending() bool {
+	return a.ProcessedAt.IsZero()
+}
+
+// Ready returns true if the archive was
+// processed successfully and can be downloaded.
+func (a *AccountArchive) Ready() bool {
+	return !a.Pending() && !*a.Failed && a.Path != ""
+}
+
+// ExpiresAt returns the time after
+// which the archive will be removed.
+func (a *AccountArchive) ExpiresAt() time.Time {
+	return a.CreatedAt.Add(AccountArchiveRetention)
+}
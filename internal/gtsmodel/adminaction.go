@@ -74,6 +74,9 @@ func ParseAdminActionCategory(in string) AdminActionCategory {
 	AdminActionSuspend
 	AdminActionUnsuspend
 	AdminActionExpireKeys
+	AdminActionSensitize
+	AdminActionUnsensitize
+	AdminActionNone
 )
 
 func (t AdminActionType) String() string {
@@ -92,6 +95,12 @@ func (t AdminActionType) String() string {
 		return "unsuspend"
 	case AdminActionExpireKeys:
 		return "expire-keys"
+	case AdminActionSensitize:
+		return "sensitive"
+	case AdminActionUnsensitize:
+		return "unsensitive"
+	case AdminActionNone:
+		return "none"
 	default:
 		return "unknown"
 	}
@@ -113,6 +122,12 @@ func ParseAdminActionType(in string) AdminActionType {
 		return AdminActionUnsuspend
 	case "expire-keys":
 		return AdminActionExpireKeys
+	case "sensitive":
+		return AdminActionSensitize
+	case "unsensitive":
+		return AdminActionUnsensitize
+	case "none":
+		return AdminActionNone
 	default:
 		return AdminActionUnknown
 	}
@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Announcement models an announcement set by an instance
+// admin, to be shown to all local users of the instance.
+type Announcement struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID   string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which admin account created this announcement?
+	Text        string    `bun:""`                                                            // raw (markdown) text of the announcement, as submitted by the admin
+	Content     string    `bun:""`                                                            // html-formatted content of the announcement, derived from text
+	StartsAt    time.Time `bun:"type:timestamptz,nullzero"`                                   // when the announced event starts, if set; the announcement isn't shown before this time
+	EndsAt      time.Time `bun:"type:timestamptz,nullzero"`                                   // when the announced event ends, if set; the announcement is no longer shown after this time
+	AllDay      *bool     `bun:",nullzero,notnull,default:false"`                             // starts and ends on whole days rather than at specific times
+	Published   *bool     `bun:",nullzero,notnull,default:false"`                             // is this announcement visible to non-admin users?
+	PublishedAt time.Time `bun:"type:timestamptz,nullzero"`                                   // when was this announcement (most recently) published
+	TagIDs      []string  `bun:"tags,array"`                                                  // database IDs of any tags used in this announcement
+	Tags        []*Tag    `bun:"-"`                                                           // tags corresponding to tagIDs
+	EmojiIDs    []string  `bun:"emojis,array"`                                                // database IDs of any emojis used in this announcement
+	Emojis      []*Emoji  `bun:"-"`                                                           // emojis corresponding to emojiIDs
+}
+
+// IsActive returns whether the announcement is published,
+// has started, and has not yet ended at given time.
+func (a *Announcement) IsActive(now time.Time) bool {
+	return *a.Published &&
+		!a.StartsAt.After(now) &&
+		(a.EndsAt.IsZero() || a.EndsAt.After(now))
+}
+
+// TagsPopulated returns whether tags are
+// populated according to current TagIDs.
+func (a *Announcement) TagsPopulated() bool {
+	if len(a.TagIDs) != len(a.Tags) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range a.TagIDs {
+		if a.Tags[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
+// EmojisPopulated returns whether emojis are
+// populated according to current EmojiIDs.
+func (a *Announcement) EmojisPopulated() bool {
+	if len(a.EmojiIDs) != len(a.Emojis) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range a.EmojiIDs {
+		if a.Emojis[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
+// AnnouncementRead marks an announcement as having
+// been read (dismissed) by the given local account.
+type AnnouncementRead struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                 // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                              // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reads_announcement_id_account_id_uniq"` // which announcement was read?
+	AccountID      string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reads_announcement_id_account_id_uniq"` // which account read the announcement?
+}
+
+// AnnouncementReaction represents an emoji
+// reaction by a local account to an announcement.
+type AnnouncementReaction struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                          // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                       // when was item created
+	AnnouncementID string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reactions_announcement_id_account_id_name_uniq"` // which announcement was reacted to?
+	AccountID      string    `bun:"type:CHAR(26),nullzero,notnull,unique:announcement_reactions_announcement_id_account_id_name_uniq"` // which account reacted?
+	Name           string    `bun:",nullzero,notnull,unique:announcement_reactions_announcement_id_account_id_name_uniq"`              // unicode emoji, or local custom emoji shortcode
+	EmojiID        string    `bun:"type:CHAR(26),nullzero"`                                                                            // id of the local custom emoji, if name is a shortcode
+	Emoji          *Emoji    `bun:"-"`                                                                                                 // emoji corresponding to emojiID
+}
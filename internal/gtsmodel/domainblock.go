@@ -17,20 +17,26 @@
 
 package gtsmodel
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // DomainBlock represents a federation block against a particular domain
 type DomainBlock struct {
-	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	Domain             string    `bun:",nullzero,notnull"`                                           // domain to block. Eg. 'whatever.com'
-	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block
-	CreatedByAccount   *Account  `bun:"-"`                                                           // Account corresponding to createdByAccountID
-	PrivateComment     string    `bun:""`                                                            // Private comment on this block, viewable to admins
-	PublicComment      string    `bun:""`                                                            // Public comment on this block, viewable (optionally) by everyone
-	Obfuscate          *bool     `bun:",nullzero,notnull,default:false"`                             // whether the domain name should appear obfuscated when displaying it publicly
-	SubscriptionID     string    `bun:"type:CHAR(26),nullzero"`                                      // if this block was created through a subscription, what's the subscription ID?
+	ID                 string              `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time           `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain             string              `bun:",nullzero,notnull"`                                           // domain to block. Eg. 'whatever.com'
+	CreatedByAccountID string              `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block
+	CreatedByAccount   *Account            `bun:"-"`                                                           // Account corresponding to createdByAccountID
+	PrivateComment     string              `bun:""`                                                            // Private comment on this block, viewable to admins
+	PublicComment      string              `bun:""`                                                            // Public comment on this block, viewable (optionally) by everyone
+	Obfuscate          *bool               `bun:",nullzero,notnull,default:false"`                             // whether the domain name should appear obfuscated when displaying it publicly
+	SubscriptionID     string              `bun:"type:CHAR(26),nullzero"`                                      // if this block was created through a subscription, what's the subscription ID?
+	Severity           DomainBlockSeverity `bun:",nullzero,notnull,default:1"`                                 // severity of this block; suspend (default) or limit
+	RejectMedia        *bool               `bun:",nullzero,notnull,default:false"`                             // strip media from statuses + accounts of a limited domain
+	SensitizeMedia     *bool               `bun:",nullzero,notnull,default:false"`                             // force media from a limited domain to be marked as sensitive
 }
 
 func (d *DomainBlock) GetID() string {
@@ -108,3 +114,47 @@ func (d *DomainBlock) GetType() DomainPermissionType {
 func (d *DomainBlock) IsOrphan() bool {
 	return d.SubscriptionID == ""
 }
+
+// IsLimit returns true if this block only limits
+// the domain, rather than suspending it entirely.
+func (d *DomainBlock) IsLimit() bool {
+	return d.Severity == DomainBlockSeverityLimit
+}
+
+// DomainBlockSeverity denotes how
+// harshly a domain block is applied.
+type DomainBlockSeverity enumType
+
+const (
+	DomainBlockSeverityUnknown DomainBlockSeverity = 0 // ???
+	DomainBlockSeveritySuspend DomainBlockSeverity = 1 // full federation suspension
+	DomainBlockSeverityLimit   DomainBlockSeverity = 2 // kept out of public view, follows preserved
+)
+
+// String returns a stringified,
+// frontend API compatible form
+// of DomainBlockSeverity.
+func (s DomainBlockSeverity) String() string {
+	switch s {
+	case DomainBlockSeveritySuspend:
+		return "suspend"
+	case DomainBlockSeverityLimit:
+		return "limit"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseDomainBlockSeverity parses the given
+// string as a DomainBlockSeverity. Mastodon's
+// "silence" is accepted as an alias for limit.
+func ParseDomainBlockSeverity(in string) DomainBlockSeverity {
+	switch strings.ToLower(in) {
+	case "suspend":
+		return DomainBlockSeveritySuspend
+	case "limit", "silence":
+		return DomainBlockSeverityLimit
+	default:
+		return DomainBlockSeverityUnknown
+	}
+}
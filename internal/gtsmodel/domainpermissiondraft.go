@@ -31,6 +31,9 @@ type DomainPermissionDraft struct {
 	PublicComment      string               `bun:",nullzero"`                                                                                     // Public comment on this perm, viewable (optionally) by everyone.
 	Obfuscate          *bool                `bun:",nullzero,notnull,default:false"`                                                               // Obfuscate domain name when displaying it publicly.
 	SubscriptionID     string               `bun:"type:CHAR(26),unique:domain_permission_drafts_permission_type_domain_subscription_id_uniq"`     // ID of the subscription that created this draft, if any.
+	Severity           DomainBlockSeverity  `bun:",nullzero,notnull,default:1"`                                                                   // Severity of the block, if this is a block draft.
+	RejectMedia        *bool                `bun:",nullzero,notnull,default:false"`                                                               // Strip media from a limited domain, if this is a block draft.
+	SensitizeMedia     *bool                `bun:",nullzero,notnull,default:false"`                                                               // Mark media from a limited domain as sensitive, if this is a block draft.
 }
 
 func (d *DomainPermissionDraft) GetID() string {
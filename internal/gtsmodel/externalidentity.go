@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ExternalIdentity links a local user to their identity
+// (subject) at one of the instance's OIDC providers.
+//
+// A user may have several external identities,
+// for example when they sign in through more than
+// one provider, and the roles granted to the user
+// are the union of those granted by each identity.
+type ExternalIdentity struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                           // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`        // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`        // when was item last updated
+	UserID             string    `bun:"type:CHAR(26),nullzero,notnull"`                                     // user this identity is linked to
+	Provider           string    `bun:",nullzero,notnull,unique:external_identities_provider_subject_uniq"` // ID of the OIDC provider this identity belongs to
+	Subject            string    `bun:",nullzero,notnull,unique:external_identities_provider_subject_uniq"` // 'sub' claim identifying the user at the provider
+	Email              string    `bun:",nullzero"`                                                          // 'email' claim last returned by the provider
+	Groups             []string  `bun:",nullzero,array"`                                                    // 'groups' claim last returned by the provider
+	SessionID          string    `bun:",nullzero"`                                                          // 'sid' claim of the latest session at the provider, if given
+	SealedRefreshToken string    `bun:",nullzero"`                                                          // refresh token issued by the provider, if any, used to sync groups; encrypted with oauth.SealToken
+	LastLoginAt        time.Time `bun:"type:timestamptz,nullzero"`                                          // when did the user last sign in with this identity
+	LastSyncedAt       time.Time `bun:"type:timestamptz,nullzero"`                                          // when were groups last synced from the provider
+}
@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// GroupAdmin marks a local account as an admin of a
+// local Group actor account. Group admins can moderate
+// the group by removing posts and banning members.
+type GroupAdmin struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                            // id of this item in the database
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                         // when was item created
+	GroupAccountID string    `bun:"type:CHAR(26),nullzero,notnull,unique:group_admins_group_account_id_account_id_uniq"` // id of the Group actor account
+	GroupAccount   *Account  `bun:"-"`                                                                                   // Group actor account
+	AccountID      string    `bun:"type:CHAR(26),nullzero,notnull,unique:group_admins_group_account_id_account_id_uniq"` // id of the local account that administers the group
+	Account        *Account  `bun:"-"`                                                                                   // local account that administers the group
+}
+
+// NewGroup models parameters for creating a new local Group actor account.
+//
+// This struct is not stored in the database,
+// it's just for passing around parameters.
+type NewGroup struct {
+	Username string   // Username of the new group account (required).
+	Admin    *Account // Local account creating the group, becomes its first admin (required).
+
+	DisplayName string // Display name of the group (optional).
+	Note        string // Formatted HTML description of the group (optional).
+	NoteRaw     string // Raw description of the group, before formatting (optional).
+}
@@ -17,9 +17,12 @@
 
 package gtsmodel
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
-// Like / Reply / Announce
+// Like / Reply / Announce / Quote
 type InteractionType int
 
 const (
@@ -32,6 +35,7 @@
 	InteractionLike InteractionType = iota
 	InteractionReply
 	InteractionAnnounce
+	InteractionQuote
 )
 
 // Stringifies this InteractionType in a
@@ -47,6 +51,9 @@ func (i InteractionType) String() string {
 	case InteractionAnnounce:
 		const text = "reblog"
 		return text
+	case InteractionQuote:
+		const text = "quote"
+		return text
 	default:
 		panic("undefined InteractionType")
 	}
@@ -65,10 +72,11 @@ type InteractionRequest struct {
 	InteractingAccountID string          `bun:"type:CHAR(26),nullzero,notnull"`                              // id of the account requesting the interaction.
 	InteractingAccount   *Account        `bun:"-"`                                                           // Not stored in DB. Account corresponding to targetAccountID
 	InteractionURI       string          `bun:",nullzero,notnull,unique"`                                    // URI of the interacting like, reply, or announce. Unique (only one interaction request allowed per interaction URI).
-	InteractionType      InteractionType `bun:",notnull"`                                                    // One of Like, Reply, or Announce.
+	InteractionType      InteractionType `bun:",notnull"`                                                    // One of Like, Reply, Announce, or Quote.
 	Like                 *StatusFave     `bun:"-"`                                                           // Not stored in DB. Only set if InteractionType = InteractionLike.
 	Reply                *Status         `bun:"-"`                                                           // Not stored in DB. Only set if InteractionType = InteractionReply.
 	Announce             *Status         `bun:"-"`                                                           // Not stored in DB. Only set if InteractionType = InteractionAnnounce.
+	Quote                *Status         `bun:"-"`                                                           // Not stored in DB. Only set if InteractionType = InteractionQuote.
 	AcceptedAt           time.Time       `bun:"type:timestamptz,nullzero"`                                   // If interaction request was accepted, time at which this occurred.
 	RejectedAt           time.Time       `bun:"type:timestamptz,nullzero"`                                   // If interaction request was rejected, time at which this occurred.
 
@@ -95,3 +103,15 @@ func (ir *InteractionRequest) IsAccepted() bool {
 func (ir *InteractionRequest) IsRejected() bool {
 	return !ir.RejectedAt.IsZero()
 }
+
+// ObjectURI returns the URI of the object that was
+// used to interact with the target status. For most
+// interaction types this is the InteractionURI, but
+// quote requests are keyed on a suffixed status URI,
+// so the suffix is trimmed off to get the quote URI.
+func (ir *InteractionRequest) ObjectURI() string {
+	if ir.InteractionType == InteractionQuote {
+		return strings.TrimSuffix(ir.InteractionURI, QuoteRequestURISuffix)
+	}
+	return ir.InteractionURI
+}
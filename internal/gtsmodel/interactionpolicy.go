@@ -195,6 +195,14 @@ type InteractionPolicy struct {
 	// interaction will be accepted
 	// for an item with this policy.
 	CanAnnounce PolicyRules
+	// Conditions in which a Quote
+	// interaction will be accepted
+	// for an item with this policy.
+	//
+	// Policies stored before quotes were
+	// supported will have this unset; see
+	// PolicyRules.IsEmpty().
+	CanQuote PolicyRules
 }
 
 // PolicyRules represents the rules according
@@ -211,6 +219,14 @@ type PolicyRules struct {
 	WithApproval PolicyValues
 }
 
+// IsEmpty returns true if neither Always nor
+// WithApproval contain any PolicyValues, ie.,
+// the rules were never set. Rules set via the
+// API or by us always contain at least "author".
+func (pr *PolicyRules) IsEmpty() bool {
+	return len(pr.Always) == 0 && len(pr.WithApproval) == 0
+}
+
 // Returns the default interaction policy
 // for the given visibility level.
 func DefaultInteractionPolicyFor(v Visibility) *InteractionPolicy {
@@ -250,6 +266,13 @@ func DefaultInteractionPolicyFor(v Visibility) *InteractionPolicy {
 		},
 		WithApproval: make(PolicyValues, 0),
 	},
+	CanQuote: PolicyRules{
+		// Anyone can quote.
+		Always: PolicyValues{
+			PolicyValuePublic,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
 }
 
 // Returns the default interaction policy
@@ -293,6 +316,13 @@ func DefaultInteractionPolicyUnlocked() *InteractionPolicy {
 		},
 		WithApproval: make(PolicyValues, 0),
 	},
+	CanQuote: PolicyRules{
+		// Only self can quote.
+		Always: PolicyValues{
+			PolicyValueAuthor,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
 }
 
 // Returns the default interaction policy for
@@ -327,6 +357,13 @@ func DefaultInteractionPolicyFollowersOnly() *InteractionPolicy {
 		},
 		WithApproval: make(PolicyValues, 0),
 	},
+	CanQuote: PolicyRules{
+		// Only self can quote.
+		Always: PolicyValues{
+			PolicyValueAuthor,
+		},
+		WithApproval: make(PolicyValues, 0),
+	},
 }
 
 // Returns the default interaction policy
@@ -0,0 +1,60 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Invite represents an invite link created by a local
+// user, which lets people sign up to the instance even
+// when registration is closed.
+type Invite struct {
+	ID         string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt  time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt  time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Code       string    `bun:",nullzero,notnull,unique"`                                    // random code used in the invite link
+	UserID     string    `bun:"type:CHAR(26),nullzero,notnull"`                              // user who created this invite
+	User       *User     `bun:"-"`                                                           // user corresponding to UserID
+	MaxUses    int       `bun:",nullzero"`                                                   // how many sign ups this invite allows, 0 for unlimited
+	Uses       int       `bun:",notnull,default:0"`                                          // how many sign ups this invite has been used for
+	ExpiresAt  time.Time `bun:"type:timestamptz,nullzero"`                                   // when does this invite expire, zero for never
+	Autofollow *bool     `bun:",nullzero,notnull,default:false"`                             // have people who sign up with this invite follow its creator
+	RevokedAt  time.Time `bun:"type:timestamptz,nullzero"`                                   // when was this invite revoked, if at all
+}
+
+// IsRevoked returns whether the invite has been revoked.
+func (i *Invite) IsRevoked() bool {
+	return !i.RevokedAt.IsZero()
+}
+
+// IsExpired returns whether the invite has expired.
+func (i *Invite) IsExpired() bool {
+	return !i.ExpiresAt.IsZero() && !time.Now().Before(i.ExpiresAt)
+}
+
+// IsUsedUp returns whether the invite has
+// been used as many times as it allows.
+func (i *Invite) IsUsedUp() bool {
+	return i.MaxUses != 0 && i.Uses >= i.MaxUses
+}
+
+// IsValid returns whether the invite can
+// still be used to sign up, ie., it's not
+// revoked, expired, or used up.
+func (i *Invite) IsValid() bool {
+	return !i.IsRevoked() && !i.IsExpired() && !i.IsUsedUp()
+}
@@ -39,6 +39,17 @@ type ListEntry struct {
 	ListID    string    `bun:"type:CHAR(26),notnull,nullzero,unique:listentrylistfollow"`   // ID of the list that this entry belongs to.
 	FollowID  string    `bun:"type:CHAR(26),notnull,nullzero,unique:listentrylistfollow"`   // Follow that the account owning this entry wants to see posts of in the timeline.
 	Follow    *Follow   `bun:"-"`                                                           // Follow corresponding to followID.
+	List      *List     `bun:"-"`                                                           // List corresponding to listID.
+}
+
+// PendingListEntry refers to an entry in a list for a follow request
+// that hasn't been accepted yet. When the follow request is accepted,
+// the pending entry is replaced with a ListEntry for the new follow.
+type PendingListEntry struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                            // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`         // when was item created
+	ListID          string    `bun:"type:CHAR(26),notnull,nullzero,unique:pendinglistentrylistfollowreq"` // ID of the list that this entry belongs to.
+	FollowRequestID string    `bun:"type:CHAR(26),notnull,nullzero,unique:pendinglistentrylistfollowreq"` // Follow request that will be added to the list once accepted.
 }
 
 // RepliesPolicy denotes which replies should be shown in the list.
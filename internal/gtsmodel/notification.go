@@ -57,7 +57,10 @@ type Notification struct {
 	NotificationPendingReblog NotificationType = 11 // NotificationPendingReblog -- Someone has boosted a status of yours, which requires approval by you.
 	NotificationAdminReport   NotificationType = 12 // NotificationAdminReport -- someone has submitted a new report to the instance.
 	NotificationUpdate        NotificationType = 13 // NotificationUpdate -- someone has edited their status.
-	NotificationTypeNumValues NotificationType = 14 // NotificationTypeNumValues -- 1 + number of max notification type
+	NotificationQuote         NotificationType = 14 // NotificationQuote -- someone quoted one of your statuses
+	NotificationPendingQuote  NotificationType = 15 // NotificationPendingQuote -- Someone has quoted a status of yours, which requires approval by you.
+	NotificationReaction      NotificationType = 16 // NotificationReaction -- someone reacted to one of your statuses with an emoji
+	NotificationTypeNumValues NotificationType = 17 // NotificationTypeNumValues -- 1 + number of max notification type
 )
 
 // String returns a stringified, frontend API compatible form of NotificationType.
@@ -89,6 +92,12 @@ func (t NotificationType) String() string {
 		return "admin.report"
 	case NotificationUpdate:
 		return "update"
+	case NotificationQuote:
+		return "quote"
+	case NotificationPendingQuote:
+		return "pending.quote"
+	case NotificationReaction:
+		return "pleroma:emoji_reaction"
 	default:
 		panic("invalid notification type")
 	}
@@ -123,6 +132,12 @@ func ParseNotificationType(in string) NotificationType {
 		return NotificationAdminReport
 	case "update":
 		return NotificationUpdate
+	case "quote":
+		return NotificationQuote
+	case "pending.quote":
+		return NotificationPendingQuote
+	case "pleroma:emoji_reaction":
+		return NotificationReaction
 	default:
 		return NotificationUnknown
 	}
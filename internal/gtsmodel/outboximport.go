@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// OutboxImport represents an import of statuses
+// into a local account from an uploaded archive's
+// outbox, and keeps track of the import's progress.
+type OutboxImport struct {
+	ID         string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt  time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt  time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item (ie., progress) last updated
+	AccountID  string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account are statuses being imported into?
+	Account    *Account  `bun:"-"`                                                           // account corresponding to accountID
+	Federate   *bool     `bun:",nullzero,notnull,default:false"`                             // should imported statuses be federated to followers?
+	Total      int       `bun:",notnull,default:0"`                                          // total number of items in the outbox
+	Imported   int       `bun:",notnull,default:0"`                                          // number of items imported as statuses so far
+	Skipped    int       `bun:",notnull,default:0"`                                          // number of items skipped so far (boosts, non-public, unresolvable replies, etc)
+	FinishedAt time.Time `bun:"type:timestamptz,nullzero"`                                   // when did the import finish (successfully or not)?
+	Failed     *bool     `bun:",nullzero,notnull,default:false"`                             // did the import fail?
+}
+
+// OutboxImportStaleAfter is how long an unfinished outbox
+// import can go without progress before it's considered
+// stale, eg., because the instance was restarted during it.
+const OutboxImportStaleAfter = time.Hour
+
+// Finished returns true if the
+// import has finished, or failed.
+func (o *OutboxImport) Finished() bool {
+	return !o.FinishedAt.IsZero()
+}
+
+// Stale returns true if the import is unfinished,
+// but hasn't made progress for a while, and so
+// will likely never finish.
+func (o *OutboxImport) Stale() bool {
+	return !o.Finished() &&
+		time.Since(o.UpdatedAt) > OutboxImportStaleAfter
+}
@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// PreviewCard represents a rich preview of a web page linked to from
+// the content of a status, generated from OpenGraph, Twitter card,
+// and oEmbed metadata found at that page. Cards are shared between
+// all statuses that link to the same URL.
+type PreviewCard struct {
+	ID               string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt        time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt        time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	FetchedAt        time.Time       `bun:"type:timestamptz,nullzero"`                                   // when was the linked page last fetched?
+	URL              string          `bun:",nullzero,notnull,unique"`                                    // URL of the linked page, as linked to from statuses
+	Type             PreviewCardType `bun:",nullzero,notnull"`                                           // type of the preview card
+	Title            string          `bun:""`                                                            // title of the linked page
+	Description      string          `bun:""`                                                            // description of the linked page
+	AuthorName       string          `bun:""`                                                            // name of the author of the linked page
+	AuthorURL        string          `bun:",nullzero"`                                                   // URL of the author of the linked page
+	ProviderName     string          `bun:""`                                                            // name of the site providing the linked page
+	ProviderURL      string          `bun:",nullzero"`                                                   // URL of the site providing the linked page
+	HTML             string          `bun:""`                                                            // sanitized oEmbed HTML (an iframe) for embedding the linked page
+	Width            int             `bun:",nullzero"`                                                   // width of the embed or image, in pixels
+	Height           int             `bun:",nullzero"`                                                   // height of the embed or image, in pixels
+	EmbedURL         string          `bun:",nullzero"`                                                   // URL of a photo embed
+	ImageRemoteURL   string          `bun:",nullzero"`                                                   // URL of the preview image on the remote site
+	ImageURL         string          `bun:",nullzero"`                                                   // URL of the cached preview image thumbnail on this instance
+	ImagePath        string          `bun:",nullzero"`                                                   // path of the cached preview image thumbnail in storage
+	ImageContentType string          `bun:",nullzero"`                                                   // MIME content type of the cached preview image thumbnail
+	ImageFileSize    int             `bun:",nullzero"`                                                   // size of the cached preview image thumbnail in bytes
+	Blurhash         string          `bun:",nullzero"`                                                   // blurhash of the preview image
+	Cached           *bool           `bun:",nullzero,notnull,default:false"`                             // whether the preview image is cached locally in storage
+}
+
+// PreviewCardType denotes the
+// type of a preview card, as
+// given by Mastodon's API.
+type PreviewCardType enumType
+
+const (
+	PreviewCardTypeUnknown PreviewCardType = 0 // ???
+	PreviewCardTypeLink    PreviewCardType = 1 // Link or article
+	PreviewCardTypePhoto   PreviewCardType = 2 // Photo embed
+	PreviewCardTypeVideo   PreviewCardType = 3 // Video embed
+	PreviewCardTypeRich    PreviewCardType = 4 // Rich oEmbed embed
+)
+
+// String returns a stringified
+// form of PreviewCardType.
+func (t PreviewCardType) String() string {
+	switch t {
+	case PreviewCardTypeLink:
+		return "link"
+	case PreviewCardTypePhoto:
+		return "photo"
+	case PreviewCardTypeVideo:
+		return "video"
+	case PreviewCardTypeRich:
+		return "rich"
+	default:
+		panic("invalid preview card type")
+	}
+}
+
+// ParsePreviewCardType returns a preview card type
+// from the given stringified form, defaulting to
+// PreviewCardTypeLink for any unrecognized value.
+func ParsePreviewCardType(in string) PreviewCardType {
+	switch in {
+	case "photo":
+		return PreviewCardTypePhoto
+	case "video":
+		return PreviewCardTypeVideo
+	case "rich":
+		return PreviewCardTypeRich
+	default:
+		return PreviewCardTypeLink
+	}
+}
@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// QueuedDelivery represents an outgoing ActivityPub delivery
+// that is queued for sending (or waiting on retry backoff).
+// Deliveries are persisted *before* being queued in memory,
+// and only removed once they've been delivered (or dropped),
+// so that pending deliveries survive crashes and restarts.
+type QueuedDelivery struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	Domain        string    `bun:",nullzero,notnull"`                                           // host of the delivery target inbox
+	ActorID       string    `bun:",nullzero"`                                                   // ActivityPub ID IRI of the actor of the delivered activity, if any
+	ObjectID      string    `bun:",nullzero"`                                                   // ActivityPub ID IRI of the object of the delivered activity, if any
+	TargetID      string    `bun:",nullzero"`                                                   // ActivityPub ID IRI of the target of the delivered activity, if any
+	Data          []byte    `bun:",nullzero,notnull"`                                           // serialized delivery request data
+	Attempts      uint      `bun:",notnull,default:0"`                                          // number of delivery attempts made so far
+	NextAttemptAt time.Time `bun:"type:timestamptz,nullzero"`                                   // when should delivery next be attempted, if backing off
+}
+
+// DeliveryQueueDomain contains
+// statistics about the queued
+// deliveries to one domain.
+type DeliveryQueueDomain struct {
+	Domain string // host of the delivery target inboxes
+	Count  int    // number of queued deliveries
+}
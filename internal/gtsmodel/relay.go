@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Relay represents a subscription by this instance to
+// a fediverse relay. The instance actor Follows the
+// relay, public local statuses are delivered to the
+// relay's inbox, and Announces coming from the relay
+// actor are ingested into the federated timeline.
+type Relay struct {
+	ID                 string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	CreatedByAccountID string     `bun:"type:CHAR(26),nullzero,notnull"`                              // which admin account added this relay?
+	Type               RelayType  `bun:",nullzero,notnull"`                                           // flavour of relay, determining how we Follow it
+	InboxURI           string     `bun:",nullzero,notnull,unique"`                                    // inbox of the relay, to which we deliver activities
+	ActorURI           string     `bun:",nullzero"`                                                   // actor URI of the relay; known from the start for LitePub relays, otherwise learned from their Accept
+	FollowURI          string     `bun:",nullzero,notnull,unique"`                                    // URI of the most recent Follow activity sent to the relay
+	State              RelayState `bun:",nullzero,notnull"`                                           // state of our subscription to the relay
+}
+
+// IsAccepted returns whether the relay has accepted
+// our subscription, ie., whether we should be sending
+// activities to it and ingesting activities from it.
+func (r *Relay) IsAccepted() bool {
+	return r.State == RelayStateAccepted
+}
+
+// RelayType denotes the flavour
+// of relay, and therefore what the
+// object of our Follow should be.
+type RelayType enumType
+
+const (
+	RelayTypeUnknown  RelayType = 0 // ???
+	RelayTypeMastodon RelayType = 1 // Follow object is the public collection
+	RelayTypeLitePub  RelayType = 2 // Follow object is the relay actor
+)
+
+// String returns a stringified,
+// frontend API compatible form
+// of RelayType.
+func (t RelayType) String() string {
+	switch t {
+	case RelayTypeMastodon:
+		return "mastodon"
+	case RelayTypeLitePub:
+		return "litepub"
+	default:
+		panic("invalid relay type")
+	}
+}
+
+// RelayState denotes the
+// state of a relay subscription.
+type RelayState enumType
+
+const (
+	RelayStateUnknown  RelayState = 0 // ???
+	RelayStateIdle     RelayState = 1 // disabled by an admin, not following
+	RelayStatePending  RelayState = 2 // Follow sent, awaiting Accept
+	RelayStateAccepted RelayState = 3 // Follow accepted by relay
+	RelayStateRejected RelayState = 4 // Follow rejected by relay
+)
+
+// String returns a stringified,
+// frontend API compatible form
+// of RelayState.
+func (s RelayState) String() string {
+	switch s {
+	case RelayStateIdle:
+		return "idle"
+	case RelayStatePending:
+		return "pending"
+	case RelayStateAccepted:
+		return "accepted"
+	case RelayStateRejected:
+		return "rejected"
+	default:
+		panic("invalid relay state")
+	}
+}
@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"time"
+)
+
+// ScheduledStatus represents a status created by a local account
+// that is to be published at some point in the future. It stores
+// the (validated) parameters that were submitted with the status
+// creation request, so that the status itself can be created
+// using those same parameters when it comes due.
+type ScheduledStatus struct {
+	ID                string               `bun:"type:CHAR(26),pk,nullzero,notnull,unique"` // id of this item in the database
+	AccountID         string               `bun:"type:CHAR(26),nullzero,notnull"`           // which account scheduled this status?
+	Account           *Account             `bun:"-"`                                        // account corresponding to accountID
+	ScheduledAt       time.Time            `bun:"type:timestamptz,nullzero,notnull"`        // time at which the status should be published
+	Text              string               `bun:""`                                         // raw text of the status, to be processed when published
+	Poll              *ScheduledStatusPoll `bun:""`                                         // poll parameters for the status, if any
+	MediaIDs          []string             `bun:"attachments,array"`                        // database IDs of any media attachments for the status
+	MediaAttachments  []*MediaAttachment   `bun:"-"`                                        // attachments corresponding to mediaIDs
+	Sensitive         *bool                `bun:",nullzero,notnull,default:false"`          // mark the status as sensitive?
+	SpoilerText       string               `bun:""`                                         // raw text of the content warning, to be processed when published
+	Visibility        Visibility           `bun:",nullzero,notnull"`                        // visibility entry for the status
+	InReplyToID       string               `bun:"type:CHAR(26),nullzero"`                   // id of the status the status replies to
+	QuotedStatusID    string               `bun:"type:CHAR(26),nullzero"`                   // id of the status the status quotes
+	Language          string               `bun:",nullzero"`                                // what language is the status written in?
+	ApplicationID     string               `bun:"type:CHAR(26),nullzero"`                   // which application was used to schedule the status?
+	Application       *Application         `bun:"-"`                                        // application corresponding to applicationID
+	LocalOnly         *bool                `bun:",nullzero,notnull,default:false"`          // should the status be "local only" (unfederated)?
+	ContentType       StatusContentType    `bun:",nullzero"`                                // content type used to process the text of the status
+	InteractionPolicy *InteractionPolicy   `bun:""`                                         // interaction policy for the status, nil means use default for visibility
+	PublishAttempts   int                  `bun:",notnull,default:0"`                       // number of times publishing the status has failed so far
+	PublishFailedAt   time.Time            `bun:"type:timestamptz,nullzero"`                // time at which publishing the status was given up on, if it was
+}
+
+// PublishFailed returns whether publishing
+// this scheduled status has been given up on.
+func (s *ScheduledStatus) PublishFailed() bool {
+	return !s.PublishFailedAt.IsZero()
+}
+
+// ScheduledStatusPoll contains the parameters
+// for a poll to be attached to a scheduled status.
+type ScheduledStatusPoll struct {
+	Options    []string `json:"options"`     // the available options for the poll
+	ExpiresIn  int      `json:"expires_in"`  // duration the poll should be open once published, in seconds
+	Multiple   bool     `json:"multiple"`    // is this a multiple choice poll?
+	HideTotals bool     `json:"hide_totals"` // hide vote counts until the poll ends?
+}
+
+// AttachmentsPopulated returns whether media attachments
+// are populated according to current MediaIDs.
+func (s *ScheduledStatus) AttachmentsPopulated() bool {
+	if len(s.MediaIDs) != len(s.MediaAttachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.MediaIDs {
+		if s.MediaAttachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
@@ -62,6 +62,8 @@ type Status struct {
 	Edits                    []*StatusEdit      `bun:"-"`                                                           //
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                      //
 	Poll                     *Poll              `bun:"-"`                                                           //
+	PreviewCardID            string             `bun:"type:CHAR(26),nullzero"`                                      // id of the preview card for the first link in this status, if any
+	PreviewCard              *PreviewCard       `bun:"-"`                                                           // preview card corresponding to previewCardID
 	ContentWarning           string             `bun:",nullzero"`                                                   // Content warning HTML for this status.
 	ContentWarningText       string             `bun:""`                                                            // Original text of the content warning without formatting
 	Visibility               Visibility         `bun:",nullzero,notnull"`                                           // visibility entry for this status
@@ -77,6 +79,14 @@ type Status struct {
 	PendingApproval          *bool              `bun:",nullzero,notnull,default:false"`                             // If true then status is a reply or boost wrapper that must be Approved by the reply-ee or boost-ee before being fully distributed.
 	PreApproved              bool               `bun:"-"`                                                           // If true, then status is a reply to or boost wrapper of a status on our instance, has permission to do the interaction, and an Accept should be sent out for it immediately. Field not stored in the DB.
 	ApprovedByURI            string             `bun:",nullzero"`                                                   // URI of an Accept Activity that approves the Announce or Create Activity that this status was/will be attached to.
+	QuoteOfID                string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status quotes
+	QuoteOfURI               string             `bun:",nullzero"`                                                   // activitypub uri of the status this status quotes
+	QuoteOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that owns the quoted status
+	QuoteOf                  *Status            `bun:"-"`                                                           // status corresponding to quoteOfID
+	QuoteOfAccount           *Account           `bun:"-"`                                                           // account corresponding to quoteOfAccountID
+	QuotePendingApproval     *bool              `bun:",nullzero,default:false"`                                     // If true then status is a quote that must be Approved by the quoted account before the quote is shown.
+	QuoteApprovedByURI       string             `bun:",nullzero"`                                                   // URI of an Accept Activity that approves this status quoting the status at QuoteOfURI.
+	QuotePreApproved         bool               `bun:"-"`                                                           // If true, then status is a quote of a status on our instance, has permission to quote it, and an Accept should be sent out for it immediately. Field not stored in the DB.
 }
 
 // GetID implements timeline.Timelineable{}.
@@ -283,6 +293,35 @@ func (s *Status) IsLocalOnly() bool {
 	return s.Federated == nil || !*s.Federated
 }
 
+// IsQuote returns true if this
+// status quotes another status.
+func (s *Status) IsQuote() bool {
+	return s.QuoteOfURI != ""
+}
+
+// QuoteRequestURISuffix is appended to the URI of
+// a quoting status to derive its QuoteRequestURI.
+const QuoteRequestURISuffix = "#quote"
+
+// QuoteRequestURI returns the URI used to key the
+// interaction request for this status quoting another.
+//
+// This differs from the status URI, since a status may
+// be both a reply and a quote, which require separate
+// interaction requests as each is approved separately.
+func (s *Status) QuoteRequestURI() string {
+	return s.URI + QuoteRequestURISuffix
+}
+
+// QuoteApproved returns true if this status is a quote,
+// the quoted status is known to us, and the quote is not
+// pending approval by the quoted account, ie., whether
+// the quoted status may be shown alongside this one.
+func (s *Status) QuoteApproved() bool {
+	return s.QuoteOfID != "" &&
+		(s.QuotePendingApproval == nil || !*s.QuotePendingApproval)
+}
+
 // AllAttachmentIDs gathers ALL media attachment IDs from both
 // the receiving Status{}, and any historical Status{}.Edits.
 func (s *Status) AllAttachmentIDs() []string {
@@ -414,4 +453,9 @@ type Content struct {
 // BackfillStatus is a wrapper for creating a status without pushing notifications to followers.
 type BackfillStatus struct {
 	*Status
+
+	// Federate the backfilled status to
+	// followers anyway, eg., when it's been
+	// imported and the account owner opted in.
+	Federate bool
 }
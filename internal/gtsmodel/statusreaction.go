@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package gtsmodel
+
+import "time"
+
+// StatusReaction represents an emoji reaction to
+// a status, as sent by eg., Misskey, Pleroma, Akkoma.
+type StatusReaction struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                              // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                           // when was item created
+	UpdatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                           // when was item last updated
+	AccountID       string    `bun:"type:CHAR(26),nullzero,notnull,unique:status_reactions_status_id_account_id_name_uniq"` // id of the account that created ('did') the reaction
+	Account         *Account  `bun:"-"`                                                                                     // account that created the reaction
+	TargetAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                                                        // id the account owning the reacted-to status
+	TargetAccount   *Account  `bun:"-"`                                                                                     // account owning the reacted-to status
+	StatusID        string    `bun:"type:CHAR(26),nullzero,notnull,unique:status_reactions_status_id_account_id_name_uniq"` // database id of the status that has been reacted to
+	Status          *Status   `bun:"-"`                                                                                     // the reacted-to status
+	Name            string    `bun:",nullzero,notnull,unique:status_reactions_status_id_account_id_name_uniq"`              // unicode emoji, or custom emoji shortcode (with @domain suffix for remote emojis)
+	EmojiID         string    `bun:"type:CHAR(26),nullzero"`                                                                // id of the custom emoji, if name is a shortcode
+	Emoji           *Emoji    `bun:"-"`                                                                                     // emoji corresponding to emojiID
+	URI             string    `bun:",nullzero,notnull,unique"`                                                              // ActivityPub URI of this reaction
+}
+
+// IsCustom returns true if this is
+// a reaction with a custom emoji,
+// rather than a unicode emoji.
+func (r *StatusReaction) IsCustom() bool {
+	return r.EmojiID != ""
+}
+
+// Content returns the reaction in the
+// form used for the 'content' property
+// of an outgoing reaction, ie., the
+// unicode emoji, or the custom emoji
+// shortcode wrapped in colons.
+func (r *StatusReaction) Content() string {
+	if r.Emoji != nil {
+		return ":" + r.Emoji.Shortcode + ":"
+	}
+	return r.Name
+}
@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StaffPick represents an account that an admin has
+// picked to be suggested to local accounts looking
+// for people to follow, regardless of who they follow.
+type StaffPick struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID          string    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // which account was picked?
+	Account            *Account  `bun:"-"`                                                           // account corresponding to accountID
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which admin account picked this account?
+}
+
+// SuggestionDismissal represents a local account
+// dismissing the suggestion to follow another account,
+// so that it won't be suggested to them again.
+type SuggestionDismissal struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                      // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                   // when was item created
+	AccountID       string    `bun:"type:CHAR(26),nullzero,notnull,unique:suggestion_dismissals_account_id_target_account_id_uniq"` // which account dismissed the suggestion?
+	TargetAccountID string    `bun:"type:CHAR(26),nullzero,notnull,unique:suggestion_dismissals_account_id_target_account_id_uniq"` // which account was no longer to be suggested?
+}
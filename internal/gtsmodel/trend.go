@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// TrendReview represents an admin's review of a hashtag,
+// status, or link that has been found to be trending on
+// this instance. Nothing is shown in public trends until
+// it has a review that has been approved by an admin.
+//
+// Pending reviews are created by the trends calculation
+// when something starts trending for the first time,
+// and make up the admin trends review queue.
+type TrendReview struct {
+	ID                  string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt           time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt           time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Type                TrendType        `bun:",nullzero,notnull,unique:trend_reviews_type_target_uniq"`     // type of thing that's trending
+	Target              string           `bun:",nullzero,notnull,unique:trend_reviews_type_target_uniq"`     // ID of trending tag or status, or URL of trending link
+	State               TrendReviewState `bun:",nullzero,notnull"`                                           // state of this review
+	ReviewedByAccountID string           `bun:"type:CHAR(26),nullzero"`                                      // which admin account approved or rejected the trend?
+	ReviewedAt          time.Time        `bun:"type:timestamptz,nullzero"`                                   // when was the trend approved or rejected?
+}
+
+// IsApproved returns whether an admin
+// has approved the trend to be shown.
+func (r *TrendReview) IsApproved() bool {
+	return r.State == TrendReviewStateApproved
+}
+
+// IsPending returns whether the trend
+// has yet to be reviewed by an admin.
+func (r *TrendReview) IsPending() bool {
+	return r.State == TrendReviewStatePending
+}
+
+// TrendType denotes the type
+// of thing that is trending.
+type TrendType enumType
+
+const (
+	TrendTypeUnknown TrendType = 0 // ???
+	TrendTypeTag     TrendType = 1 // Target is a tag ID
+	TrendTypeStatus  TrendType = 2 // Target is a status ID
+	TrendTypeLink    TrendType = 3 // Target is a link URL
+)
+
+// String returns a stringified
+// form of TrendType.
+func (t TrendType) String() string {
+	switch t {
+	case TrendTypeTag:
+		return "tag"
+	case TrendTypeStatus:
+		return "status"
+	case TrendTypeLink:
+		return "link"
+	default:
+		panic("invalid trend type")
+	}
+}
+
+// TrendReviewState denotes
+// the state of a trend review.
+type TrendReviewState enumType
+
+const (
+	TrendReviewStateUnknown  TrendReviewState = 0 // ???
+	TrendReviewStatePending  TrendReviewState = 1 // not yet reviewed by an admin
+	TrendReviewStateApproved TrendReviewState = 2 // approved, may be shown in trends
+	TrendReviewStateRejected TrendReviewState = 3 // rejected, never shown in trends
+)
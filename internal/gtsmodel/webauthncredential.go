@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebAuthnCredential represents a WebAuthn public key
+// credential (security key or passkey) registered by
+// a local user, usable as a second factor on sign in,
+// or for passwordless sign in if Passwordless is set.
+type WebAuthnCredential struct {
+	ID           string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt    time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UserID       string    `bun:"type:CHAR(26),nullzero,notnull"`                              // user that registered this credential
+	Name         string    `bun:",nullzero,notnull"`                                           // user-given name of this credential, eg., "yubikey"
+	CredentialID string    `bun:",nullzero,notnull,unique"`                                    // base64url encoded credential ID, as given by the authenticator
+	PublicKey    []byte    `bun:",nullzero,notnull"`                                           // CBOR encoded COSE_Key public key of this credential
+	SignCount    uint32    `bun:",notnull,default:0"`                                          // last seen signature counter value
+	AAGUID       string    `bun:",nullzero"`                                                   // base64url encoded AAGUID of the authenticator model, if known
+	Transports   []string  `bun:",nullzero,array"`                                             // transports reported by the authenticator, eg., "usb", "internal"
+	Passwordless *bool     `bun:",nullzero,notnull,default:false"`                             // can this credential be used to sign in without a password?
+	LastUsedAt   time.Time `bun:"type:timestamptz,nullzero"`                                   // when was this credential last used to sign in
+}
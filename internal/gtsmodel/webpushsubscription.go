@@ -30,6 +30,11 @@ type WebPushSubscription struct {
 	// There can be at most one subscription for any given access token,
 	TokenID string `bun:"type:CHAR(26),nullzero,notnull,unique"`
 
+	// SealedAccessToken is the associated access token, encrypted
+	// with oauth.SealToken, as tokens themselves are only stored
+	// hashed. It's sent back in Web Push notification payloads.
+	SealedAccessToken string `bun:",nullzero"`
+
 	// Endpoint is the URL receiving Web Push notifications for this subscription.
 	Endpoint string `bun:",nullzero,notnull"`
 
@@ -75,6 +75,19 @@ func (r *Request) BackOff() time.Duration {
 	return r.backoff
 }
 
+// Attempts returns the number of
+// delivery attempts made so far.
+func (r *Request) Attempts() uint {
+	return r.attempts
+}
+
+// SetAttempts sets the number of delivery
+// attempts made so far, eg., when restoring
+// a previously persisted request for retry.
+func (r *Request) SetAttempts(n uint) {
+	r.attempts = n
+}
+
 type uintPtr struct{ u *uint }
 
 func (f uintPtr) String() string {
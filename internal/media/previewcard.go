@@ -0,0 +1,179 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"os"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// CachePreviewCardImage loads the preview image of given
+// card using data function, and stores a thumbnail of it
+// in storage under the given (instance) account ID. On
+// success, the image fields of card are updated to point
+// to the newly stored thumbnail, any previously stored
+// thumbnail is removed, and card is marked as cached.
+//
+// Note the card is NOT updated in the database.
+func (m *Manager) CachePreviewCardImage(
+	ctx context.Context,
+	card *gtsmodel.PreviewCard,
+	accountID string,
+	data DataFunc,
+) error {
+	// Load image from data func.
+	rc, err := data(ctx)
+	if err != nil {
+		return gtserror.Newf("error executing data function: %w", err)
+	}
+
+	var (
+		// predfine temporary media
+		// file path variables so we
+		// can remove them on error.
+		temppath  string
+		thumbpath string
+	)
+
+	defer func() {
+		if err := remove(temppath, thumbpath); err != nil {
+			log.Errorf(ctx, "error(s) cleaning up files: %v", err)
+		}
+	}()
+
+	// Drain reader to tmp file
+	// (this reader handles close).
+	temppath, err = drainToTmp(rc)
+	if err != nil {
+		return gtserror.Newf("error draining data to tmp: %w", err)
+	}
+
+	// Pass input file through ffprobe to
+	// parse further metadata information.
+	result, err := probe(ctx, temppath)
+	if err != nil && !isUnsupportedTypeErr(err) {
+		return gtserror.Newf("ffprobe error: %w", err)
+	} else if result == nil {
+		return gtserror.Newf("unsupported data type by ffprobe: %w", err)
+	}
+
+	// Only images make
+	// sense for a card.
+	fileType, _, ext := result.GetFileType()
+	if fileType != gtsmodel.FileTypeImage {
+		return gtserror.Newf("unsupported preview card image format: %s", result.format)
+	}
+
+	width, height, _ := result.ImageMeta()
+	if width <= 0 || height <= 0 {
+		return gtserror.Newf("invalid preview card image dimensions %dx%d", width, height)
+	}
+
+	// Add file extension to path.
+	newpath := temppath + "." + ext
+
+	// Before ffmpeg processing, rename to set file ext.
+	if err := os.Rename(temppath, newpath); err != nil {
+		return gtserror.Newf("error renaming to %s - >%s: %w", temppath, newpath, err)
+	}
+
+	// Update path var
+	// AFTER successful.
+	temppath = newpath
+
+	// Determine thumbnail dimens to use.
+	thumbWidth, thumbHeight := thumbSize(
+		width,
+		height,
+		util.Div(float32(width), float32(height)),
+	)
+
+	// Generate thumbnail and blurhash from temp media.
+	// Only the thumbnail is kept, the original is never
+	// served, which also drops any original metadata.
+	thumbpath, mimeType, blurhash, err := generateThumb(ctx, temppath,
+		thumbWidth,
+		thumbHeight,
+		result.orientation,
+		result.PixFmt(),
+		true,
+	)
+	if err != nil {
+		return gtserror.Newf("error generating image thumb: %w", err)
+	}
+
+	// Determine final thumbnail ext.
+	thumbExt := getExtension(thumbpath)
+
+	// Calculate final preview card thumbnail path.
+	path := uris.StoragePathForAttachment(
+		accountID,
+		string(TypeCard),
+		string(SizeSmall),
+		card.ID,
+		thumbExt,
+	)
+
+	// Copy thumbnail file into storage at path.
+	thumbsz, err := m.state.Storage.PutFile(ctx,
+		path,
+		thumbpath,
+		mimeType,
+	)
+	if err != nil {
+		return gtserror.Newf("error writing thumb to storage: %w", err)
+	}
+
+	if card.ImagePath != "" && card.ImagePath != path {
+		// Remove the previously cached thumbnail, now replaced.
+		err := m.state.Storage.Delete(ctx, card.ImagePath)
+		if err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error deleting %s: %v", card.ImagePath, err)
+		}
+	}
+
+	// Set final determined image details.
+	card.ImagePath = path
+	card.ImageContentType = mimeType
+	card.ImageFileSize = int(thumbsz)
+	card.ImageURL = uris.URIForAttachment(
+		accountID,
+		string(TypeCard),
+		string(SizeSmall),
+		card.ID,
+		thumbExt,
+	)
+	card.Blurhash = blurhash
+	card.Cached = util.Ptr(true)
+
+	// Use image dimensions when
+	// no embed dimensions known.
+	if card.Width == 0 || card.Height == 0 {
+		card.Width = thumbWidth
+		card.Height = thumbHeight
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@
 package middleware
 
 import (
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
 )
@@ -31,5 +32,13 @@ func Gzip() gin.HandlerFunc {
 		return func(ctx *gin.Context) {}
 	}
 
-	return gzip.Gzip(gzip.DefaultCompression)
+	return gzip.Gzip(
+		gzip.DefaultCompression,
+
+		// Compressing server-sent events
+		// would buffer them up, so skip.
+		gzip.WithExcludedPaths([]string{
+			apiutil.EventStreamPath,
+		}),
+	)
 }
@@ -100,6 +100,13 @@ func Throttle(cpuMultiplier int, retryAfter time.Duration) gin.HandlerFunc {
 	}
 
 	return func(c *gin.Context) {
+		if apiutil.EventStreamRequest(c.Request) {
+			// Server-sent events streams stay open
+			// indefinitely, so would hog a token for
+			// as long as they're open. Don't throttle.
+			return
+		}
+
 		// Always decrement request counter.
 		defer func() { requestCount.Add(-1) }()
 
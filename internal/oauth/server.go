@@ -19,11 +19,14 @@
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
+	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
@@ -64,6 +67,31 @@
 	HelpfulAdviceGrant = "If you arrived at this error during a sign in/oauth flow, your client is trying to use an unsupported OAuth grant type. Supported grant types are: authorization_code, client_credentials; please reach out to developer of your client"
 )
 
+var (
+	// AllowedResponseTypes are the response types
+	// supported by the server, ie., only the
+	// non-implicit flow.
+	AllowedResponseTypes = []oauth2.ResponseType{
+		oauth2.Code,
+	}
+
+	// AllowedGrantTypes are the grant types supported by the server:
+	// - Authorization Code (for first & third parties)
+	// - Client Credentials (for applications)
+	AllowedGrantTypes = []oauth2.GrantType{
+		oauth2.AuthorizationCode,
+		oauth2.ClientCredentials,
+	}
+
+	// AllowedCodeChallengeMethods are the
+	// PKCE code challenge methods supported
+	// by the server.
+	AllowedCodeChallengeMethods = []oauth2.CodeChallengeMethod{
+		oauth2.CodeChallengePlain,
+		oauth2.CodeChallengeS256,
+	}
+)
+
 // Server wraps some oauth2 server functions
 // in an interface, exposing only what is needed.
 type Server interface {
@@ -73,6 +101,7 @@ type Server interface {
 	GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, clientSecret string, userID string) (accessToken oauth2.TokenInfo, err error)
 	LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error)
 	RevokeAccessToken(ctx context.Context, clientID string, clientSecret string, access string) gtserror.WithCode
+	IntrospectAccessToken(ctx context.Context, clientID string, clientSecret string, access string) (oauth2.TokenInfo, gtserror.WithCode)
 }
 
 // s fulfils the Server interface
@@ -115,20 +144,10 @@ func New(
 		&server.Config{
 			TokenType: "Bearer",
 			// Must follow the spec.
-			AllowGetAccessRequest: false,
-			// Support only the non-implicit flow.
-			AllowedResponseTypes: []oauth2.ResponseType{oauth2.Code},
-			// Allow:
-			// - Authorization Code (for first & third parties)
-			// - Client Credentials (for applications)
-			AllowedGrantTypes: []oauth2.GrantType{
-				oauth2.AuthorizationCode,
-				oauth2.ClientCredentials,
-			},
-			AllowedCodeChallengeMethods: []oauth2.CodeChallengeMethod{
-				oauth2.CodeChallengePlain,
-				oauth2.CodeChallengeS256,
-			},
+			AllowGetAccessRequest:       false,
+			AllowedResponseTypes:        AllowedResponseTypes,
+			AllowedGrantTypes:           AllowedGrantTypes,
+			AllowedCodeChallengeMethods: AllowedCodeChallengeMethods,
 		},
 		manager,
 	)
@@ -412,3 +431,90 @@ func (s *s) RevokeAccessToken(
 
 	return nil
 }
+
+// IntrospectAccessToken loads the given access token on behalf of
+// the client with the given client ID and secret, for RFC 7662 token
+// introspection. If the token doesn't exist, is no longer active, or
+// was issued to a different client (and the client isn't configured
+// in advanced-introspection-clients), then a nil token info and nil
+// error are returned.
+func (s *s) IntrospectAccessToken(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	access string,
+) (oauth2.TokenInfo, gtserror.WithCode) {
+	// Get client from the db using provided client ID.
+	client, err := s.server.Manager.GetClient(ctx, clientID)
+	switch {
+	case err == nil:
+		// Got the client, can
+		// proceed to authenticate.
+
+	case errorsv2.IsV2(
+		err,
+		db.ErrNoEntries,
+		oautherr.ErrInvalidClient,
+	):
+		log.Debug(ctx, "no client found with provided client_id")
+		return nil, gtserror.NewErrorUnauthorized(
+			oautherr.ErrInvalidClient,
+			"You are not authorized to introspect tokens",
+		)
+
+	default:
+		// Real error.
+		log.Errorf(ctx, "db error loading client: %v", err)
+		return nil, gtserror.NewErrorInternalError(
+			oautherr.ErrServerError,
+			"db error loading client, check logs",
+		)
+	}
+
+	// Ensure requester knows the client secret,
+	// which authenticates them as the client.
+	if subtle.ConstantTimeCompare(
+		[]byte(client.GetSecret()),
+		[]byte(clientSecret),
+	) != 1 {
+		log.Debug(ctx, "secret of client does not match provided client_secret")
+		return nil, gtserror.NewErrorUnauthorized(
+			oautherr.ErrInvalidClient,
+			"You are not authorized to introspect tokens",
+		)
+	}
+
+	token, err := s.server.Manager.LoadAccessToken(ctx, access)
+	switch {
+	case err == nil:
+		// Got the token, but only report it as
+		// active to the client it was issued to,
+		// so apps can't probe each other's tokens,
+		// unless the client is trusted to see all.
+		if token.GetClientID() != clientID &&
+			!slices.Contains(config.GetAdvancedIntrospectionClients(), clientID) {
+			log.Debug(ctx, "client id of token does not match provided client_id")
+			return nil, nil
+		}
+		return token, nil
+
+	case errorsv2.IsV2(
+		err,
+		db.ErrNoEntries,
+		oautherr.ErrInvalidAccessToken,
+		oautherr.ErrExpiredAccessToken,
+		oautherr.ErrExpiredRefreshToken,
+	):
+		// Token deleted, expired, or doesn't
+		// exist, so it's simply not active.
+		return nil, nil
+
+	default:
+		// Real error.
+		log.Errorf(ctx, "db error loading access token: %v", err)
+		return nil, gtserror.NewErrorInternalError(
+			oautherr.ErrServerError,
+			"db error loading access token, check logs",
+		)
+	}
+}
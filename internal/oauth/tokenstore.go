@@ -19,9 +19,17 @@
 
 import (
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"time"
 
+	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
@@ -121,7 +129,14 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 		return errors.New("info param was not a models.Token")
 	}
 
+	// Only ever store hashes of the token
+	// secrets. Note this converts to a new
+	// model, so the plaintext values in 't'
+	// are still returned (once) to the caller.
 	dbt := TokenToDBToken(t)
+	dbt.Code = HashToken(dbt.Code)
+	dbt.Access = HashToken(dbt.Access)
+	dbt.Refresh = HashToken(dbt.Refresh)
 	if dbt.ID == "" {
 		dbt.ID = id.NewULID()
 	}
@@ -131,17 +146,17 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 
 // RemoveByCode deletes a token from the DB based on the Code field
 func (ts *tokenStore) RemoveByCode(ctx context.Context, code string) error {
-	return ts.state.DB.DeleteTokenByCode(ctx, code)
+	return ts.state.DB.DeleteTokenByCode(ctx, HashToken(code))
 }
 
 // RemoveByAccess deletes a token from the DB based on the Access field
 func (ts *tokenStore) RemoveByAccess(ctx context.Context, access string) error {
-	return ts.state.DB.DeleteTokenByAccess(ctx, access)
+	return ts.state.DB.DeleteTokenByAccess(ctx, HashToken(access))
 }
 
 // RemoveByRefresh deletes a token from the DB based on the Refresh field
 func (ts *tokenStore) RemoveByRefresh(ctx context.Context, refresh string) error {
-	return ts.state.DB.DeleteTokenByRefresh(ctx, refresh)
+	return ts.state.DB.DeleteTokenByRefresh(ctx, HashToken(refresh))
 }
 
 // GetByCode selects a token from
@@ -150,11 +165,16 @@ func (ts *tokenStore) GetByCode(
 	ctx context.Context,
 	code string,
 ) (oauth2.TokenInfo, error) {
-	return ts.getUpdateToken(
+	token, err := ts.getUpdateToken(
 		ctx,
 		ts.state.DB.GetTokenByCode,
 		code,
 	)
+	if err != nil {
+		return nil, err
+	}
+	token.Code = code
+	return token, nil
 }
 
 // GetByAccess selects a token from
@@ -163,11 +183,16 @@ func (ts *tokenStore) GetByAccess(
 	ctx context.Context,
 	access string,
 ) (oauth2.TokenInfo, error) {
-	return ts.getUpdateToken(
+	token, err := ts.getUpdateToken(
 		ctx,
 		ts.state.DB.GetTokenByAccess,
 		access,
 	)
+	if err != nil {
+		return nil, err
+	}
+	token.Access = access
+	return token, nil
 }
 
 // GetByRefresh selects a token from
@@ -176,20 +201,33 @@ func (ts *tokenStore) GetByRefresh(
 	ctx context.Context,
 	refresh string,
 ) (oauth2.TokenInfo, error) {
-	return ts.getUpdateToken(
+	token, err := ts.getUpdateToken(
 		ctx,
 		ts.state.DB.GetTokenByRefresh,
 		refresh,
 	)
+	if err != nil {
+		return nil, err
+	}
+	token.Refresh = refresh
+	return token, nil
 }
 
 // package-internal function for getting a token
 // and potentially updating its last_used value.
+//
+// The key is hashed before lookup, and as such
+// the returned token contains only the hashes
+// of its secrets; callers should set the value
+// of the looked-up field back to the plaintext.
 func (ts *tokenStore) getUpdateToken(
 	ctx context.Context,
 	getBy func(context.Context, string) (*gtsmodel.Token, error),
 	key string,
-) (oauth2.TokenInfo, error) {
+) (*models.Token, error) {
+	// Only ever look up by hash.
+	key = HashToken(key)
+
 	// Hold a lock to get the token based on
 	// whatever func + key we've been given.
 	unlock := ts.lastUsedLocks.Lock(key)
@@ -219,6 +257,100 @@ func (ts *tokenStore) getUpdateToken(
 	return DBTokenToToken(token), nil
 }
 
+// HashToken returns the keyed hash (HMAC-SHA256, using the configured
+// token hash secret) of the given OAuth token secret, i.e. an access or
+// refresh token, or an authorization code. Only these hashes are stored
+// in the database, so tokens can't be recovered from a database leak.
+func HashToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	secret := config.GetAdvancedTokenHashSecret()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ErrNoTokenSecret is returned by SealToken and OpenToken when
+// advanced-token-hash-secret is not set, as the key would then
+// be derived from nothing but a constant in the source code,
+// which is no better than storing tokens in plaintext.
+var ErrNoTokenSecret = errors.New("advanced-token-hash-secret not set")
+
+// SealToken encrypts the given token secret (AES-256-GCM, with a key
+// derived from the configured token hash secret), for the few places
+// where a token must be recoverable later on, unlike with HashToken.
+// The result is base64-encoded nonce + ciphertext. Empty is left as-is.
+//
+// Callers must handle ErrNoTokenSecret by not storing the token at all.
+func SealToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	gcm, err := tokenSealer()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", gtserror.Newf("error generating nonce: %w", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(token), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenToken decrypts a token secret previously encrypted with SealToken.
+func OpenToken(sealed string) (string, error) {
+	if sealed == "" {
+		return "", nil
+	}
+
+	gcm, err := tokenSealer()
+	if err != nil {
+		return "", err
+	}
+
+	b, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", gtserror.Newf("error decoding sealed token: %w", err)
+	}
+
+	if len(b) < gcm.NonceSize() {
+		return "", gtserror.New("sealed token too short")
+	}
+
+	nonce, ciphertext := b[:gcm.NonceSize()], b[gcm.NonceSize():]
+	token, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", gtserror.Newf("error opening sealed token: %w", err)
+	}
+
+	return string(token), nil
+}
+
+// tokenSealer returns the AEAD used by SealToken and OpenToken.
+// Its key is derived from the token hash secret, rather than
+// using it directly, so hashes and ciphertexts never share a key.
+func tokenSealer() (cipher.AEAD, error) {
+	secret := config.GetAdvancedTokenHashSecret()
+	if secret == "" {
+		return nil, ErrNoTokenSecret
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("gotosocial token seal"))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, gtserror.Newf("error creating cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
 /*
 	The following models are basically helpers for the token store implementation, they should only be used internally.
 */
@@ -17,16 +17,33 @@
 
 package oidc
 
-import "encoding/gob"
+import (
+	"encoding/gob"
+	"encoding/json"
+)
 
-// Claims represents claims as found in an id_token returned from an OIDC flow.
 type Claims struct {
 	Sub               string   `json:"sub"`
+	Sid               string   `json:"sid"`
 	Email             string   `json:"email"`
 	EmailVerified     bool     `json:"email_verified"`
 	Groups            []string `json:"groups"`
 	Name              string   `json:"name"`
 	PreferredUsername string   `json:"preferred_username"`
+
+	// RefreshToken is not a claim, but the refresh
+	// token (if any) issued alongside the id token.
+	RefreshToken string `json:"-"`
+}
+
+// LogoutClaims are the claims of a verified
+// OIDC back-channel logout token. At least one
+// of Sub or Sid will be set.
+type LogoutClaims struct {
+	Sub    string                     `json:"sub"`
+	Sid    string                     `json:"sid"`
+	Nonce  string                     `json:"nonce"`
+	Events map[string]json.RawMessage `json:"events"`
 }
 
 func init() {
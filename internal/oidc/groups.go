@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oidc
+
+import (
+	"slices"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+)
+
+// groups determines whether users are allowed access,
+// and which roles they are granted, based on the
+// 'groups' claim returned by an OIDC provider.
+type groups struct {
+	allowed   []string
+	admin     []string
+	moderator []string
+}
+
+// configGroups returns groups using
+// the top-level oidc group settings.
+func configGroups() groups {
+	return groups{
+		allowed:   config.GetOIDCAllowedGroups(),
+		admin:     config.GetOIDCAdminGroups(),
+		moderator: config.GetOIDCModeratorGroups(),
+	}
+}
+
+// newGroups returns groups using the given lists,
+// falling back to the corresponding top-level oidc
+// setting for each list that is empty.
+func newGroups(allowed, admin, moderator []string, fallback groups) groups {
+	if len(allowed) == 0 {
+		allowed = fallback.allowed
+	}
+	if len(admin) == 0 {
+		admin = fallback.admin
+	}
+	if len(moderator) == 0 {
+		moderator = fallback.moderator
+	}
+	return groups{
+		allowed:   allowed,
+		admin:     admin,
+		moderator: moderator,
+	}
+}
+
+// AllowedGroup returns true if one of the given OIDC
+// groups is equal to at least one allowed OIDC group,
+// or if no allowed groups are configured.
+func (g groups) AllowedGroup(claimed []string) bool {
+	if len(g.allowed) == 0 {
+		// If no groups are configured, allow
+		// access (for backwards compatibility).
+		return true
+	}
+	return inGroup(claimed, g.allowed)
+}
+
+// AdminGroup returns true if one of the given OIDC
+// groups is equal to at least one admin OIDC group.
+func (g groups) AdminGroup(claimed []string) bool {
+	return inGroup(claimed, g.admin)
+}
+
+// ModeratorGroup returns true if one of the given OIDC
+// groups is equal to at least one moderator OIDC group.
+func (g groups) ModeratorGroup(claimed []string) bool {
+	return inGroup(claimed, g.moderator)
+}
+
+// ManagesRoles returns true if any admin or moderator
+// groups are configured, in which case users' roles
+// are kept in sync with their group memberships.
+func (g groups) ManagesRoles() bool {
+	return len(g.admin) != 0 || len(g.moderator) != 0
+}
+
+// inGroup returns true if one of the claimed groups
+// is equal (case-insensitive) to one of the configured.
+func inGroup(claimed []string, configured []string) bool {
+	for _, claimedGroup := range claimed {
+		if slices.ContainsFunc(configured, func(group string) bool {
+			return strings.EqualFold(claimedGroup, group)
+		}) {
+			return true
+		}
+	}
+	return false
+}
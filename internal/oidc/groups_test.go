@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oidc
+
+import (
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/testrig"
+)
+
+func TestAdminGroup(t *testing.T) {
+	testrig.InitTestConfig()
+	g := newGroups(nil, nil, nil, configGroups())
+	for _, test := range []struct {
+		name     string
+		groups   []string
+		expected bool
+	}{
+		{name: "not in admin group", groups: []string{"group1", "group2", "allowedRole"}, expected: false},
+		{name: "in admin group", groups: []string{"group1", "group2", "adminRole"}, expected: true},
+		{name: "in admin group different case", groups: []string{"group1", "group2", "ADMINROLE"}, expected: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := g.AdminGroup(test.groups); got != test.expected {
+				t.Fatalf("got: %t, wanted: %t", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestAllowedGroup(t *testing.T) {
+	testrig.InitTestConfig()
+	g := newGroups(nil, nil, nil, configGroups())
+	for _, test := range []struct {
+		name     string
+		groups   []string
+		expected bool
+	}{
+		{name: "not in allowed group", groups: []string{"group1", "group2", "adminRole"}, expected: false},
+		{name: "in allowed group", groups: []string{"group1", "group2", "allowedRole"}, expected: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := g.AllowedGroup(test.groups); got != test.expected {
+				t.Fatalf("got: %t, wanted: %t", got, test.expected)
+			}
+		})
+	}
+}
+
+func TestProviderGroups(t *testing.T) {
+	testrig.InitTestConfig()
+
+	// Provider's own groups take precedence
+	// over top-level config, unset ones fall
+	// back to it.
+	g := newGroups([]string{"members"}, nil, []string{"mods"}, configGroups())
+
+	if g.AllowedGroup([]string{"allowedRole"}) {
+		t.Fatal("expected top-level allowed group to be overridden")
+	}
+	if !g.AllowedGroup([]string{"members"}) {
+		t.Fatal("expected provider allowed group to be allowed")
+	}
+	if !g.AdminGroup([]string{"adminRole"}) {
+		t.Fatal("expected admin groups to fall back to top-level config")
+	}
+	if !g.ModeratorGroup([]string{"Mods"}) {
+		t.Fatal("expected provider moderator group to grant moderator")
+	}
+	if !g.ManagesRoles() {
+		t.Fatal("expected roles to be managed")
+	}
+
+	if newGroups(nil, nil, nil, groups{}).ManagesRoles() {
+		t.Fatal("expected roles not to be managed without admin or moderator groups")
+	}
+}
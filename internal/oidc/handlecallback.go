@@ -47,6 +47,21 @@ func (i *idp) HandleCallback(ctx context.Context, code string) (*Claims, gtserro
 	}
 	log.Debugf(ctx, "raw id token: %s", rawIDToken)
 
+	claims, errWithCode := i.verifyIDToken(ctx, rawIDToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Keep the refresh token, if any,
+	// for syncing groups later on.
+	claims.RefreshToken = oauth2Token.RefreshToken
+
+	return claims, nil
+}
+
+// verifyIDToken verifies the given raw
+// id_token, and extracts claims from it.
+func (i *idp) verifyIDToken(ctx context.Context, rawIDToken string) (*Claims, gtserror.WithCode) {
 	// Parse and verify ID Token payload.
 	log.Debug(ctx, "verifying id_token")
 	idTokenVerifier := i.provider.Verifier(i.oidcConf)
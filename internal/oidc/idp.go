@@ -20,6 +20,7 @@
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
@@ -30,10 +31,18 @@
 const (
 	// CallbackPath is the API path for receiving callback tokens from external OIDC providers
 	CallbackPath = "/auth/callback"
+
+	// DefaultProviderID is the ID of the OIDC provider
+	// configured by the top-level oidc-issuer etc settings.
+	DefaultProviderID = "default"
 )
 
-// IDP contains logic for parsing an OIDC access code into a set of claims by calling an external OIDC provider.
 type IDP interface {
+	// ID returns the ID of this IDP, which is used
+	// to link users to their identities at the IDP.
+	ID() string
+	// Name returns the name of this IDP, to show to users.
+	Name() string
 	// HandleCallback accepts a context (pass the context from the http.Request), and an oauth2 code as returned from a successful
 	// login through an OIDC provider. It uses the code to request a token from the OIDC provider, which should contain an id_token
 	// with a set of claims.
@@ -42,15 +51,39 @@ type IDP interface {
 	HandleCallback(ctx context.Context, code string) (*Claims, gtserror.WithCode)
 	// AuthCodeURL returns the proper redirect URL for this IDP, for redirecting requesters to the correct OIDC endpoint.
 	AuthCodeURL(state string) string
+	// Refresh uses the given refresh token to fetch up-to-date claims for a user from the IDP, taken
+	// from a refreshed id_token, or from the userinfo endpoint if the IDP doesn't issue a new id_token.
+	//
+	// If the IDP rejects the refresh token, eg., because the user was removed or logged out, the
+	// returned error will wrap ErrRefreshRejected.
+	Refresh(ctx context.Context, refreshToken string) (*Claims, error)
+	// VerifyLogoutToken verifies the given back-channel logout token, and returns its claims.
+	VerifyLogoutToken(ctx context.Context, rawToken string) (*LogoutClaims, error)
+	// EndSessionURL returns the URL to redirect users to for RP-initiated logout at this IDP,
+	// or an empty string if the IDP doesn't advertise an end_session_endpoint.
+	EndSessionURL(postLogoutRedirectURI string) string
+	// AllowedGroup returns true if the given groups allow access to GtS.
+	AllowedGroup(groups []string) bool
+	// AdminGroup returns true if the given groups grant the admin role.
+	AdminGroup(groups []string) bool
+	// ModeratorGroup returns true if the given groups grant the moderator role.
+	ModeratorGroup(groups []string) bool
+	// ManagesRoles returns true if users' roles should be synced from groups at this IDP.
+	ManagesRoles() bool
 }
 
 type idp struct {
-	oauth2Config oauth2.Config
-	provider     *oidc.Provider
-	oidcConf     *oidc.Config
+	groups
+	id            string
+	name          string
+	oauth2Config  oauth2.Config
+	provider      *oidc.Provider
+	oidcConf      *oidc.Config
+	endSessionURL string
 }
 
-// NewIDP returns a new IDP configured with the given config.
+// NewIDP returns the IDP configured
+// by the top-level oidc-* settings.
 func NewIDP(ctx context.Context) (IDP, error) {
 	// validate config fields
 	idpName := config.GetOIDCIdpName()
@@ -63,26 +96,52 @@ func NewIDP(ctx context.Context) (IDP, error) {
 		return nil, fmt.Errorf("not set: Issuer")
 	}
 
-	clientID := config.GetOIDCClientID()
+	return newIDP(ctx, config.OIDCProviderConfiguration{
+		ID:           DefaultProviderID,
+		Name:         idpName,
+		Issuer:       issuer,
+		ClientID:     config.GetOIDCClientID(),
+		ClientSecret: config.GetOIDCClientSecret(),
+		Scopes:       config.GetOIDCScopes(),
+	})
+}
+
+// newIDP returns an IDP for the given provider
+// configuration, using top-level oidc-* settings
+// for scopes and groups that aren't set.
+func newIDP(ctx context.Context, cfg config.OIDCProviderConfiguration) (IDP, error) {
+	clientID := cfg.ClientID
 	if clientID == "" {
 		return nil, fmt.Errorf("not set: ClientID")
 	}
 
-	clientSecret := config.GetOIDCClientSecret()
+	clientSecret := cfg.ClientSecret
 	if clientSecret == "" {
 		return nil, fmt.Errorf("not set: ClientSecret")
 	}
 
-	scopes := config.GetOIDCScopes()
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = config.GetOIDCScopes()
+	}
 	if len(scopes) == 0 {
 		return nil, fmt.Errorf("not set: Scopes")
 	}
 
-	provider, err := oidc.NewProvider(ctx, issuer)
+	provider, err := oidc.NewProvider(ctx, cfg.Issuer)
 	if err != nil {
 		return nil, err
 	}
 
+	// Get the end session endpoint, if the
+	// provider supports RP-initiated logout.
+	var discovery struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	if err := provider.Claims(&discovery); err != nil {
+		return nil, err
+	}
+
 	protocol := config.GetProtocol()
 	host := config.GetHost()
 
@@ -115,8 +174,42 @@ func NewIDP(ctx context.Context) (IDP, error) {
 	}
 
 	return &idp{
-		oauth2Config: oauth2Config,
-		oidcConf:     oidcConf,
-		provider:     provider,
+		groups: newGroups(
+			cfg.AllowedGroups,
+			cfg.AdminGroups,
+			cfg.ModeratorGroups,
+			configGroups(),
+		),
+		id:            cfg.ID,
+		name:          cfg.Name,
+		oauth2Config:  oauth2Config,
+		oidcConf:      oidcConf,
+		provider:      provider,
+		endSessionURL: discovery.EndSessionEndpoint,
 	}, nil
 }
+
+func (i *idp) ID() string {
+	return i.id
+}
+
+func (i *idp) Name() string {
+	return i.name
+}
+
+func (i *idp) EndSessionURL(postLogoutRedirectURI string) string {
+	if i.endSessionURL == "" {
+		return ""
+	}
+
+	u, err := url.Parse(i.endSessionURL)
+	if err != nil {
+		return ""
+	}
+
+	q := u.Query()
+	q.Set("client_id", i.oauth2Config.ClientID)
+	q.Set("post_logout_redirect_uri", postLogoutRedirectURI)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
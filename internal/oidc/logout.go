@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+)
+
+// backchannelLogoutEvent is the event member required
+// in back-channel logout tokens, as described in:
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+const backchannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+func (i *idp) VerifyLogoutToken(ctx context.Context, rawToken string) (*LogoutClaims, error) {
+	// Logout tokens are signed just like id tokens,
+	// and must have the same issuer and audience.
+	verifier := i.provider.Verifier(i.oidcConf)
+	token, err := verifier.Verify(ctx, rawToken)
+	if err != nil {
+		return nil, gtserror.Newf("could not verify logout token: %w", err)
+	}
+
+	claims := &LogoutClaims{}
+	if err := token.Claims(claims); err != nil {
+		return nil, gtserror.Newf("could not parse claims from logout token: %w", err)
+	}
+
+	if _, ok := claims.Events[backchannelLogoutEvent]; !ok {
+		return nil, errors.New("logout token does not contain back-channel logout event")
+	}
+
+	if claims.Nonce != "" {
+		// Prevents an id token
+		// being used as logout token.
+		return nil, errors.New("logout token must not contain nonce")
+	}
+
+	if claims.Sub == "" && claims.Sid == "" {
+		return nil, errors.New("logout token contains neither sub nor sid")
+	}
+
+	return claims, nil
+}
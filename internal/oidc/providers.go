@@ -0,0 +1,504 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// How often users' groups are
+// synced from their providers.
+const syncEvery = time.Hour
+
+// Providers wraps the OIDC providers configured for
+// this instance, and keeps users' external identities,
+// and the roles granted by them, up to date.
+type Providers struct {
+	state *state.State
+	idps  []IDP
+}
+
+// NewProviders returns Providers for the provider configured by
+// the top-level oidc-issuer etc settings (if set), followed by
+// each provider configured in oidc-providers.
+func NewProviders(ctx context.Context, state *state.State) (*Providers, error) {
+	var idps []IDP
+
+	if config.GetOIDCIssuer() != "" {
+		idp, err := NewIDP(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error creating idp %s: %w", DefaultProviderID, err)
+		}
+		idps = append(idps, idp)
+	}
+
+	for _, cfg := range config.GetOIDCProviders() {
+		if cfg.ID == "" {
+			return nil, errors.New("not set: ID of entry in oidc-providers")
+		}
+
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("not set: Name of idp %s", cfg.ID)
+		}
+
+		if cfg.Issuer == "" {
+			return nil, fmt.Errorf("not set: Issuer of idp %s", cfg.ID)
+		}
+
+		for _, idp := range idps {
+			if idp.ID() == cfg.ID {
+				return nil, fmt.Errorf("duplicate idp ID %s", cfg.ID)
+			}
+		}
+
+		idp, err := newIDP(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error creating idp %s: %w", cfg.ID, err)
+		}
+		idps = append(idps, idp)
+	}
+
+	if len(idps) == 0 {
+		return nil, errors.New("neither oidc-issuer nor oidc-providers are set")
+	}
+
+	return &Providers{
+		state: state,
+		idps:  idps,
+	}, nil
+}
+
+// List returns all configured IDPs, in config order.
+func (p *Providers) List() []IDP {
+	return p.idps
+}
+
+// Get returns the IDP with the given ID, or nil.
+func (p *Providers) Get(id string) IDP {
+	for _, idp := range p.idps {
+		if idp.ID() == id {
+			return idp
+		}
+	}
+	return nil
+}
+
+// GetUser returns the user linked to the subject of the given
+// claims at the given IDP, or nil if no user is linked yet.
+func (p *Providers) GetUser(ctx context.Context, idp IDP, claims *Claims) (*gtsmodel.User, error) {
+	identity, err := p.state.DB.GetExternalIdentity(ctx, idp.ID(), claims.Sub)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil, nil
+		}
+		return nil, gtserror.Newf("db error getting external identity: %w", err)
+	}
+
+	user, err := p.state.DB.GetUserByID(ctx, identity.UserID)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting user %s: %w", identity.UserID, err)
+	}
+
+	return user, nil
+}
+
+// Login records that the given user signed in through the
+// given IDP with the given claims: the user's external
+// identity at the IDP is created or updated with the
+// claims, and the user's roles are synced.
+func (p *Providers) Login(ctx context.Context, idp IDP, user *gtsmodel.User, claims *Claims) error {
+	now := time.Now()
+
+	// Refresh token is long-lived, so only ever
+	// store it encrypted, or not at all if there's
+	// no secret to encrypt it with (config validation
+	// ensures there is one if groups are synced).
+	sealedRefreshToken, err := oauth.SealToken(claims.RefreshToken)
+	if err != nil && !errors.Is(err, oauth.ErrNoTokenSecret) {
+		return gtserror.Newf("error sealing refresh token: %w", err)
+	}
+
+	identity, err := p.state.DB.GetExternalIdentity(ctx, idp.ID(), claims.Sub)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting external identity: %w", err)
+	}
+
+	if identity == nil {
+		// First sign in with this
+		// identity, link it to user.
+		identity = &gtsmodel.ExternalIdentity{
+			ID:                 id.NewULID(),
+			UserID:             user.ID,
+			Provider:           idp.ID(),
+			Subject:            claims.Sub,
+			Email:              claims.Email,
+			Groups:             claims.Groups,
+			SessionID:          claims.Sid,
+			SealedRefreshToken: sealedRefreshToken,
+			LastLoginAt:        now,
+			LastSyncedAt:       now,
+		}
+
+		if err := p.state.DB.PutExternalIdentity(ctx, identity); err != nil {
+			return gtserror.Newf("db error putting external identity: %w", err)
+		}
+	} else {
+		if identity.UserID != user.ID {
+			return gtserror.Newf(
+				"external identity %s is linked to user %s, not %s",
+				identity.ID, identity.UserID, user.ID,
+			)
+		}
+
+		identity.Email = claims.Email
+		identity.Groups = claims.Groups
+		identity.SessionID = claims.Sid
+		identity.SealedRefreshToken = sealedRefreshToken
+		identity.LastLoginAt = now
+		identity.LastSyncedAt = now
+
+		if err := p.state.DB.UpdateExternalIdentity(ctx, identity,
+			"email",
+			"groups",
+			"session_id",
+			"sealed_refresh_token",
+			"last_login_at",
+			"last_synced_at",
+		); err != nil {
+			return gtserror.Newf("db error updating external identity: %w", err)
+		}
+	}
+
+	if _, err := p.syncRoles(ctx, user); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// syncRoles sets the admin and moderator roles of the given user
+// from the groups of each of their external identities, if any of
+// those identities' IDPs manage roles. A role is granted if any one
+// identity grants it.
+//
+// Returns whether any identity still allows the user access.
+func (p *Providers) syncRoles(ctx context.Context, user *gtsmodel.User) (bool, error) {
+	identities, err := p.state.DB.GetExternalIdentitiesByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting external identities: %w", err)
+	}
+
+	var managed, allowed, admin, moderator bool
+	for _, identity := range identities {
+		idp := p.Get(identity.Provider)
+		if idp == nil {
+			// IDP no longer
+			// configured, skip.
+			continue
+		}
+
+		allowed = allowed || idp.AllowedGroup(identity.Groups)
+
+		if !idp.ManagesRoles() {
+			continue
+		}
+
+		managed = true
+		admin = admin || idp.AdminGroup(identity.Groups)
+		moderator = moderator || idp.ModeratorGroup(identity.Groups)
+	}
+
+	// Admins are
+	// also moderators.
+	moderator = moderator || admin
+
+	if !managed ||
+		(util.PtrOrZero(user.Admin) == admin &&
+			util.PtrOrZero(user.Moderator) == moderator) {
+		// Nothing to change.
+		return allowed, nil
+	}
+
+	log.Infof(ctx,
+		"syncing roles of user %s from oidc groups: admin=%t moderator=%t",
+		user.ID, admin, moderator,
+	)
+
+	user.Admin = util.Ptr(admin)
+	user.Moderator = util.Ptr(moderator)
+	if err := p.state.DB.UpdateUser(ctx, user, "admin", "moderator"); err != nil {
+		return false, gtserror.Newf("db error updating user roles: %w", err)
+	}
+
+	return allowed, nil
+}
+
+// ScheduleJobs schedules users' groups to be synced
+// regularly from their IDPs, starting in syncEvery.
+func (p *Providers) ScheduleJobs() error {
+	fn := func(ctx context.Context, start time.Time) {
+		log.Debug(ctx, "starting oidc groups sync")
+		if err := p.Sync(ctx); err != nil {
+			log.Errorf(ctx, "error syncing oidc groups: %v", err)
+			return
+		}
+		log.Debugf(ctx, "finished oidc groups sync after %s", time.Since(start))
+	}
+
+	log.Infof(nil, "scheduling oidc groups sync to run every %s", syncEvery)
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@oidcsync",
+		time.Now().Add(syncEvery),
+		syncEvery,
+		fn,
+	) {
+		panic("failed to schedule @oidcsync")
+	}
+
+	return nil
+}
+
+// Sync refreshes the groups of every external identity with a
+// refresh token from its IDP, and syncs the roles of the users
+// the identities are linked to.
+//
+// If an IDP rejects an identity's refresh token, the groups
+// of that identity are cleared, so that any roles granted by
+// them are removed until the user signs in again.
+//
+// Users whose identities no longer allow them access have
+// all of their OAuth tokens revoked.
+func (p *Providers) Sync(ctx context.Context) error {
+	identities, err := p.state.DB.GetAllExternalIdentities(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting external identities: %w", err)
+	}
+
+	userIDs := make(map[string]struct{})
+	for _, identity := range identities {
+		if identity.SealedRefreshToken == "" {
+			// Can't sync
+			// this identity.
+			continue
+		}
+
+		idp := p.Get(identity.Provider)
+		if idp == nil {
+			continue
+		}
+
+		refreshToken, err := oauth.OpenToken(identity.SealedRefreshToken)
+		if err != nil {
+			log.Warnf(ctx,
+				"error opening refresh token for identity %s: %v",
+				identity.ID, err,
+			)
+			continue
+		}
+
+		claims, err := idp.Refresh(ctx, refreshToken)
+		switch {
+		case errors.Is(err, ErrRefreshRejected):
+			log.Infof(ctx,
+				"idp %s rejected refresh token for identity %s, clearing groups",
+				idp.ID(), identity.ID,
+			)
+			identity.Groups = nil
+			identity.SealedRefreshToken = ""
+
+		case err != nil:
+			log.Warnf(ctx,
+				"error refreshing identity %s at idp %s: %v",
+				identity.ID, idp.ID(), err,
+			)
+			continue
+
+		case claims.Sub != identity.Subject:
+			log.Warnf(ctx,
+				"idp %s returned sub %s for identity %s with sub %s",
+				idp.ID(), claims.Sub, identity.ID, identity.Subject,
+			)
+			continue
+
+		default:
+			sealed, err := oauth.SealToken(claims.RefreshToken)
+			if err != nil {
+				log.Errorf(ctx, "error sealing refresh token for identity %s: %v", identity.ID, err)
+				continue
+			}
+			identity.Groups = claims.Groups
+			identity.SealedRefreshToken = sealed
+		}
+
+		identity.LastSyncedAt = time.Now()
+		if err := p.state.DB.UpdateExternalIdentity(ctx, identity,
+			"groups",
+			"sealed_refresh_token",
+			"last_synced_at",
+		); err != nil {
+			log.Errorf(ctx, "db error updating external identity %s: %v", identity.ID, err)
+			continue
+		}
+
+		userIDs[identity.UserID] = struct{}{}
+	}
+
+	for userID := range userIDs {
+		user, err := p.state.DB.GetUserByID(ctx, userID)
+		if err != nil {
+			log.Errorf(ctx, "db error getting user %s: %v", userID, err)
+			continue
+		}
+
+		allowed, err := p.syncRoles(ctx, user)
+		if err != nil {
+			log.Errorf(ctx, "error syncing roles of user %s: %v", userID, err)
+			continue
+		}
+
+		if !allowed {
+			log.Infof(ctx, "user %s no longer in allowed oidc groups, revoking tokens", userID)
+			if err := p.state.DB.DeleteTokensByUserID(ctx, userID); err != nil {
+				log.Errorf(ctx, "db error revoking tokens of user %s: %v", userID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// BackchannelLogout verifies the given back-channel
+// logout token against each configured IDP, and
+// revokes the OAuth tokens of each user whose
+// identity at the issuing IDP it applies to.
+func (p *Providers) BackchannelLogout(ctx context.Context, rawToken string) gtserror.WithCode {
+	var (
+		idp    IDP
+		claims *LogoutClaims
+		errs   gtserror.MultiError
+	)
+
+	for _, i := range p.idps {
+		var err error
+		claims, err = i.VerifyLogoutToken(ctx, rawToken)
+		if err == nil {
+			idp = i
+			break
+		}
+		errs.Appendf("idp %s: %w", i.ID(), err)
+	}
+
+	if idp == nil {
+		err := errs.Combine()
+		return gtserror.NewErrorBadRequest(err, "invalid logout token")
+	}
+
+	var identities []*gtsmodel.ExternalIdentity
+	if claims.Sub != "" {
+		identity, err := p.state.DB.GetExternalIdentity(ctx, idp.ID(), claims.Sub)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting external identity: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+		if identity != nil {
+			identities = append(identities, identity)
+		}
+	} else {
+		var err error
+		identities, err = p.state.DB.GetExternalIdentitiesBySessionID(ctx, idp.ID(), claims.Sid)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting external identities: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	for _, identity := range identities {
+		log.Infof(ctx,
+			"back-channel logout from idp %s, revoking tokens of user %s",
+			idp.ID(), identity.UserID,
+		)
+		if err := p.Logout(ctx, identity.UserID); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// Logout revokes all OAuth tokens of the given user,
+// and forgets their sessions at IDPs, so that a
+// later back-channel logout doesn't apply to them.
+func (p *Providers) Logout(ctx context.Context, userID string) error {
+	if err := p.state.DB.DeleteTokensByUserID(ctx, userID); err != nil {
+		return gtserror.Newf("db error revoking tokens: %w", err)
+	}
+
+	identities, err := p.state.DB.GetExternalIdentitiesByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting external identities: %w", err)
+	}
+
+	for _, identity := range identities {
+		if identity.SessionID == "" {
+			continue
+		}
+
+		identity.SessionID = ""
+		if err := p.state.DB.UpdateExternalIdentity(ctx, identity, "session_id"); err != nil {
+			return gtserror.Newf("db error updating external identity: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LastIDP returns the IDP the given user most
+// recently signed in through, or nil if none.
+func (p *Providers) LastIDP(ctx context.Context, userID string) (IDP, error) {
+	identities, err := p.state.DB.GetExternalIdentitiesByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting external identities: %w", err)
+	}
+
+	var last *gtsmodel.ExternalIdentity
+	for _, identity := range identities {
+		if last == nil || identity.LastLoginAt.After(last.LastLoginAt) {
+			last = identity
+		}
+	}
+
+	if last == nil {
+		return nil, nil
+	}
+
+	return p.Get(last.Provider), nil
+}
@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"golang.org/x/oauth2"
+)
+
+// ErrRefreshRejected is returned from IDP.Refresh
+// when the IDP rejects the given refresh token.
+var ErrRefreshRejected = errors.New("refresh token rejected by idp")
+
+func (i *idp) Refresh(ctx context.Context, refreshToken string) (*Claims, error) {
+	src := i.oauth2Config.TokenSource(ctx, &oauth2.Token{
+		RefreshToken: refreshToken,
+	})
+
+	oauth2Token, err := src.Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant" {
+			return nil, fmt.Errorf("%w: %w", ErrRefreshRejected, err)
+		}
+		return nil, gtserror.Newf("error refreshing token: %w", err)
+	}
+
+	var claims *Claims
+	if rawIDToken, ok := oauth2Token.Extra("id_token").(string); ok {
+		// IDP issued a new id_token,
+		// so take claims from that.
+		var errWithCode gtserror.WithCode
+		claims, errWithCode = i.verifyIDToken(ctx, rawIDToken)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	} else {
+		// No new id_token, fall back
+		// to the userinfo endpoint.
+		userInfo, err := i.provider.UserInfo(ctx, oauth2.StaticTokenSource(oauth2Token))
+		if err != nil {
+			return nil, gtserror.Newf("error getting userinfo: %w", err)
+		}
+
+		claims = &Claims{}
+		if err := userInfo.Claims(claims); err != nil {
+			return nil, gtserror.Newf("error parsing userinfo claims: %w", err)
+		}
+	}
+
+	// The IDP may or may
+	// not rotate the token.
+	claims.RefreshToken = oauth2Token.RefreshToken
+	if claims.RefreshToken == "" {
+		claims.RefreshToken = refreshToken
+	}
+
+	return claims, nil
+}
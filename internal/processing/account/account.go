@@ -18,7 +18,9 @@
 package account
 
 import (
+	"code.superseriousbusiness.org/gotosocial/internal/email"
 	"code.superseriousbusiness.org/gotosocial/internal/federation"
+	"code.superseriousbusiness.org/gotosocial/internal/filter/interaction"
 	"code.superseriousbusiness.org/gotosocial/internal/filter/visibility"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/media"
@@ -39,8 +41,10 @@ type Processor struct {
 	converter    *typeutils.Converter
 	mediaManager *media.Manager
 	visFilter    *visibility.Filter
+	intFilter    *interaction.Filter
 	formatter    *text.Formatter
 	federator    *federation.Federator
+	emailSender  email.Sender
 	parseMention gtsmodel.ParseMentionFunc
 	themes       *Themes
 }
@@ -54,6 +58,7 @@ func New(
 	federator *federation.Federator,
 	visFilter *visibility.Filter,
 	parseMention gtsmodel.ParseMentionFunc,
+	emailSender email.Sender,
 ) Processor {
 	return Processor{
 		c:            common,
@@ -61,8 +66,10 @@ func New(
 		converter:    converter,
 		mediaManager: mediaManager,
 		visFilter:    visFilter,
+		intFilter:    interaction.NewFilter(state),
 		formatter:    text.NewFormatter(state.DB),
 		federator:    federator,
+		emailSender:  emailSender,
 		parseMention: parseMention,
 		themes:       PopulateThemes(),
 	}
@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/email"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"codeberg.org/gruf/go-bytesize"
+)
+
+// ArchiveRequest creates a new archive of the requester's
+// data, and queues it to be prepared in the background.
+//
+// Only one archive can be requested at a time; a new one can be
+// requested once the previous one has failed or been removed.
+func (p *Processor) ArchiveRequest(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.AccountArchive, gtserror.WithCode) {
+	archives, err := p.state.DB.GetAccountArchivesByAccountID(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+	for _, archive := range archives {
+		if !*archive.Failed && now.Before(archive.ExpiresAt()) {
+			const text = "an archive was already requested recently, please wait until it has been removed"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	archive := &gtsmodel.AccountArchive{
+		ID:        id.NewULID(),
+		CreatedAt: now,
+		AccountID: requester.ID,
+		Account:   requester,
+		Failed:    util.Ptr(false),
+	}
+
+	if err := p.state.DB.PutAccountArchive(ctx, archive); err != nil {
+		err = gtserror.Newf("db error putting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Do the actual archiving asynchronously.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.processArchive(ctx, archive)
+	})
+
+	return p.converter.AccountArchiveToAPIAccountArchive(archive), nil
+}
+
+// ArchivesGet returns the requester's archives, newest first.
+func (p *Processor) ArchivesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.AccountArchive, gtserror.WithCode) {
+	archives, err := p.state.DB.GetAccountArchivesByAccountID(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiArchives := make([]*apimodel.AccountArchive, 0, len(archives))
+	for _, archive := range archives {
+		apiArchives = append(apiArchives, p.converter.AccountArchiveToAPIAccountArchive(archive))
+	}
+
+	return apiArchives, nil
+}
+
+// ArchiveGetFile returns the ZIP file content of
+// the requester's archive with the given ID.
+func (p *Processor) ArchiveGetFile(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	archiveID string,
+) (*apimodel.Content, gtserror.WithCode) {
+	archive, err := p.state.DB.GetAccountArchiveByID(
+		gtscontext.SetBarebones(ctx),
+		archiveID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if archive == nil || archive.AccountID != requester.ID {
+		const text = "archive not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if !archive.Ready() {
+		const text = "archive not ready"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	content := &apimodel.Content{
+		ContentType:   "application/zip",
+		ContentLength: archive.Size,
+	}
+
+	// If running on S3 with proxying
+	// disabled, just return a signed URL.
+	if url := p.state.Storage.URL(ctx, archive.Path); url != nil {
+		content.URL = url
+		return content, nil
+	}
+
+	content.Content, err = p.state.Storage.GetStream(ctx, archive.Path)
+	if err != nil {
+		err = gtserror.Newf("error getting archive %s from storage: %w", archive.Path, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return content, nil
+}
+
+// processArchive prepares the given pending archive,
+// updating it in the database once done, and emails
+// the requester to let them know it's ready.
+func (p *Processor) processArchive(ctx context.Context, archive *gtsmodel.AccountArchive) {
+	path, size, err := p.writeArchive(ctx, archive)
+	if err != nil {
+		log.Errorf(ctx, "error writing archive %s: %v", archive.ID, err)
+		archive.Failed = util.Ptr(true)
+	} else {
+		archive.Path = path
+		archive.Size = size
+	}
+
+	archive.ProcessedAt = time.Now()
+	if err := p.state.DB.UpdateAccountArchive(ctx, archive,
+		"path",
+		"size",
+		"processed_at",
+		"failed",
+	); err != nil {
+		log.Errorf(ctx, "db error updating archive %s: %v", archive.ID, err)
+		return
+	}
+
+	if !archive.Ready() {
+		return
+	}
+
+	if err := p.emailArchiveReady(ctx, archive); err != nil {
+		log.Errorf(ctx, "error emailing archive %s: %v", archive.ID, err)
+	}
+}
+
+// emailArchiveReady emails the owner of the given
+// archive to let them know it's ready to download,
+// if they have a confirmed email address.
+func (p *Processor) emailArchiveReady(ctx context.Context, archive *gtsmodel.AccountArchive) error {
+	user, err := p.state.DB.GetUserByAccountID(ctx, archive.AccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if user.ConfirmedAt.IsZero() || user.Email == "" {
+		// Only email users who
+		// have a confirmed email.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	if err := p.emailSender.SendAccountArchiveEmail(
+		user.Email,
+		email.AccountArchiveData{
+			Username:     archive.Account.Username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			ArchiveURL:   instance.URI + "/settings/user/export-import",
+			Size:         bytesize.Size(archive.Size).StringIEC(),
+			ExpiresAt:    archive.ExpiresAt().Format("Jan 2, 2006"),
+		},
+	); err != nil {
+		return gtserror.Newf("error sending email: %w", err)
+	}
+
+	return nil
+}
+
+// deleteArchives deletes all archives
+// of the given account, and their files.
+func (p *Processor) deleteArchives(ctx context.Context, accountID string) error {
+	archives, err := p.state.DB.GetAccountArchivesByAccountID(
+		gtscontext.SetBarebones(ctx),
+		accountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting archives: %w", err)
+	}
+
+	for _, archive := range archives {
+		if archive.Path != "" {
+			err := p.state.Storage.Delete(ctx, archive.Path)
+			if err != nil && !storage.IsNotFound(err) {
+				return gtserror.Newf("error deleting archive %s from storage: %w", archive.Path, err)
+			}
+		}
+
+		if err := p.state.DB.DeleteAccountArchiveByID(ctx, archive.ID); err != nil {
+			return gtserror.Newf("db error deleting archive: %w", err)
+		}
+	}
+
+	return nil
+}
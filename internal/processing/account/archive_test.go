@@ -0,0 +1,199 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"github.com/stretchr/testify/suite"
+)
+
+type ArchiveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// processArchive runs the queued archive
+// processing function, if there is one.
+func (suite *ArchiveTestSuite) processArchive() {
+	fn, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !suite.True(ok) {
+		suite.FailNow("no archive processing queued")
+	}
+	fn(context.Background())
+}
+
+func (suite *ArchiveTestSuite) TestArchiveRequest() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+		user      = suite.testUsers["local_account_1"]
+	)
+
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveRequest(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("pending", apiArchive.State)
+	suite.Empty(apiArchive.URL)
+
+	// Only one archive at a time.
+	_, errWithCode = suite.accountProcessor.ArchiveRequest(ctx, requester)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Can't download it before it's ready.
+	_, errWithCode = suite.accountProcessor.ArchiveGetFile(ctx, requester, apiArchive.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	suite.processArchive()
+
+	archive, err := suite.db.GetAccountArchiveByID(ctx, apiArchive.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(archive.Ready())
+	suite.NotZero(archive.Size)
+
+	// Owner should have been told it's ready.
+	suite.Contains(suite.sentEmails[user.Email], "is now ready")
+
+	// Someone else can't download it.
+	_, errWithCode = suite.accountProcessor.ArchiveGetFile(ctx, suite.testAccounts["local_account_2"], archive.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	content, errWithCode := suite.accountProcessor.ArchiveGetFile(ctx, requester, archive.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer content.Content.Close()
+
+	b, err := io.ReadAll(content.Content)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	// Actor should point at files in the archive.
+	actor := suite.readArchiveJSON(files, "actor.json")
+	suite.Equal(requester.URI, actor["id"])
+	suite.Equal("outbox.json", actor["outbox"])
+	suite.Equal("likes.json", actor["likes"])
+	suite.Equal("bookmarks.json", actor["bookmarks"])
+
+	avatarURL := actor["icon"].(map[string]interface{})["url"].(string)
+	suite.Contains(files, avatarURL)
+
+	// Outbox should contain all the requester's statuses.
+	outbox := suite.readArchiveJSON(files, "outbox.json")
+	items := outbox["orderedItems"].([]interface{})
+	suite.Len(items, int(outbox["totalItems"].(float64)))
+
+	statuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(items, len(statuses))
+
+	// Each attachment of the requester's statuses should be included.
+	for _, status := range statuses {
+		for _, attachmentID := range status.AttachmentIDs {
+			attachment := suite.testAttachments[attachmentID]
+			if attachment == nil {
+				continue
+			}
+			suite.Contains(files, "media_attachments/"+attachment.File.Path)
+		}
+	}
+
+	for _, name := range []string{"likes.json", "bookmarks.json"} {
+		collection := suite.readArchiveJSON(files, name)
+		suite.Equal("OrderedCollection", collection["type"])
+		suite.Len(collection["orderedItems"], int(collection["totalItems"].(float64)))
+	}
+}
+
+func (suite *ArchiveTestSuite) TestArchiveDeletedWithAccount() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveRequest(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.processArchive()
+
+	archive, err := suite.db.GetAccountArchiveByID(ctx, apiArchive.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *requester
+
+	if errWithCode := suite.accountProcessor.Delete(ctx, testAccount, requester.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	archives, err := suite.db.GetAccountArchivesByAccountID(ctx, requester.ID)
+	suite.NoError(err)
+	suite.Empty(archives)
+
+	_, err = suite.storage.Get(ctx, archive.Path)
+	suite.Error(err)
+}
+
+func (suite *ArchiveTestSuite) readArchiveJSON(files map[string]*zip.File, name string) map[string]interface{} {
+	f, ok := files[name]
+	if !ok {
+		suite.FailNow("missing " + name)
+	}
+
+	r, err := f.Open()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer r.Close()
+
+	var m map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return m
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
@@ -0,0 +1,414 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+)
+
+// Account archives use the same layout as Mastodon
+// archives, so that they can be imported elsewhere.
+const (
+	archiveActor     = "actor.json"
+	archiveOutbox    = "outbox.json"
+	archiveLikes     = "likes.json"
+	archiveBookmarks = "bookmarks.json"
+	archiveAvatar    = "avatar"
+	archiveHeader    = "header"
+	archiveMedia     = "media_attachments/"
+	archivePageSize  = 100
+	archiveContext   = "https://www.w3.org/ns/activitystreams"
+)
+
+// writeArchive writes out the ZIP file of the given
+// archive to a temporary file, then moves it to storage,
+// returning the storage path and size of the archive.
+func (p *Processor) writeArchive(ctx context.Context, archive *gtsmodel.AccountArchive) (string, int64, error) {
+	// Refetch the account to archive, so
+	// that it's up to date and populated.
+	account, err := p.state.DB.GetAccountByID(ctx, archive.AccountID)
+	if err != nil {
+		return "", 0, gtserror.Newf("db error getting account: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(os.TempDir(), "gotosocial-archive-*.zip")
+	if err != nil {
+		return "", 0, gtserror.Newf("error creating temp file: %w", err)
+	}
+
+	defer func() {
+		// Done with the temp file
+		// by the time we return.
+		_ = tmp.Close()
+		if err := os.Remove(tmp.Name()); err != nil {
+			log.Errorf(ctx, "error removing temp file %s: %v", tmp.Name(), err)
+		}
+	}()
+
+	zw := zip.NewWriter(tmp)
+
+	for _, write := range []func(context.Context, *zip.Writer, *gtsmodel.Account) error{
+		p.writeArchiveActor,
+		p.writeArchiveOutbox,
+		p.writeArchiveLikes,
+		p.writeArchiveBookmarks,
+	} {
+		if err := write(ctx, zw, account); err != nil {
+			return "", 0, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return "", 0, gtserror.Newf("error closing zip: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return "", 0, gtserror.Newf("error closing temp file: %w", err)
+	}
+
+	// Archives are stored alongside the account's
+	// media, but not in a path served by the fileserver.
+	//
+	// Eg., 01F8MH1H7YV1Z7D2C8K2730QBF/archive/01J5QVXCCEATJYSXM9H6MZT4JR.zip
+	archivePath := account.ID + "/archive/" + archive.ID + ".zip"
+
+	size, err := p.state.Storage.PutFile(ctx, archivePath, tmp.Name(), "application/zip")
+	if err != nil {
+		return "", 0, gtserror.Newf("error storing archive: %w", err)
+	}
+
+	return archivePath, size, nil
+}
+
+// writeArchiveActor writes the account's actor
+// to the archive, along with its avatar and header.
+func (p *Processor) writeArchiveActor(ctx context.Context, zw *zip.Writer, account *gtsmodel.Account) error {
+	accountable, err := p.converter.AccountToAS(ctx, account)
+	if err != nil {
+		return gtserror.Newf("error converting account: %w", err)
+	}
+
+	actor, err := ap.Serialize(accountable)
+	if err != nil {
+		return gtserror.Newf("error serializing account: %w", err)
+	}
+
+	// Point the actor's avatar + header at the
+	// files in the archive, rather than on this
+	// instance, and write them to the archive.
+	for _, image := range []struct {
+		key        string
+		name       string
+		attachment *gtsmodel.MediaAttachment
+	}{
+		{"icon", archiveAvatar, account.AvatarMediaAttachment},
+		{"image", archiveHeader, account.HeaderMediaAttachment},
+	} {
+		if image.attachment == nil {
+			continue
+		}
+
+		obj, ok := actor[image.key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name := image.name + path.Ext(image.attachment.File.Path)
+		obj["url"] = name
+
+		if err := p.writeArchiveFile(ctx, zw, name, image.attachment.File.Path); err != nil {
+			return err
+		}
+	}
+
+	actor["outbox"] = archiveOutbox
+	actor["likes"] = archiveLikes
+	actor["bookmarks"] = archiveBookmarks
+
+	return writeArchiveJSON(zw, archiveActor, actor)
+}
+
+// writeArchiveOutbox writes all of the account's statuses
+// and boosts to the archive's outbox, as Create and Announce
+// activities respectively, along with their media attachments.
+func (p *Processor) writeArchiveOutbox(ctx context.Context, zw *zip.Writer, account *gtsmodel.Account) error {
+	w, err := zw.Create(archiveOutbox)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", archiveOutbox, err)
+	}
+
+	// The outbox can get very large, so stream
+	// each item, and put the total at the end.
+	if _, err := io.WriteString(w, `{"@context":"`+archiveContext+`","id":"`+archiveOutbox+`","type":"OrderedCollection","orderedItems":[`); err != nil {
+		return gtserror.Newf("error writing %s: %w", archiveOutbox, err)
+	}
+
+	var (
+		total int
+		maxID string
+
+		// Media files to write to the archive
+		// once we're done writing the outbox.
+		files []string
+	)
+
+	for {
+		statuses, err := p.state.DB.GetAccountStatuses(ctx,
+			account.ID,
+			archivePageSize,
+			false, // exclude replies
+			false, // exclude reblogs
+			maxID,
+			"",    // min ID
+			false, // media only
+			false, // public only
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			item, statusFiles, err := p.archiveOutboxItem(ctx, status)
+			if err != nil {
+				log.Warnf(ctx, "skipping status %s: %v", status.URI, err)
+				continue
+			}
+
+			b, err := json.Marshal(item)
+			if err != nil {
+				return gtserror.Newf("error marshaling status %s: %w", status.URI, err)
+			}
+
+			if total > 0 {
+				b = append([]byte{','}, b...)
+			}
+
+			if _, err := w.Write(b); err != nil {
+				return gtserror.Newf("error writing %s: %w", archiveOutbox, err)
+			}
+
+			files = append(files, statusFiles...)
+			total++
+		}
+	}
+
+	b, err := json.Marshal(total)
+	if err != nil {
+		return gtserror.Newf("error marshaling total: %w", err)
+	}
+
+	if _, err := io.WriteString(w, `],"totalItems":`+string(b)+`}`); err != nil {
+		return gtserror.Newf("error writing %s: %w", archiveOutbox, err)
+	}
+
+	for _, file := range files {
+		if err := p.writeArchiveFile(ctx, zw, archiveMedia+file, file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// archiveOutboxItem converts the given status to a serialized
+// Create (or Announce, for boosts) activity for the archive's
+// outbox, returning the storage paths of any media it uses.
+func (p *Processor) archiveOutboxItem(ctx context.Context, status *gtsmodel.Status) (map[string]interface{}, []string, error) {
+	if status.BoostOfID != "" {
+		announce, err := p.converter.BoostToAS(ctx, status, status.Account, status.BoostOfAccount)
+		if err != nil {
+			return nil, nil, gtserror.Newf("error converting boost: %w", err)
+		}
+
+		item, err := ap.Serialize(announce)
+		return item, nil, err
+	}
+
+	// Point attachments at the files in the archive
+	// rather than on this instance, taking copies to
+	// avoid modifying the (possibly cached) status.
+	var files []string
+	if len(status.Attachments) > 0 {
+		status2 := new(gtsmodel.Status)
+		*status2 = *status
+		status2.Attachments = make([]*gtsmodel.MediaAttachment, 0, len(status.Attachments))
+
+		for _, attachment := range status.Attachments {
+			attachment2 := new(gtsmodel.MediaAttachment)
+			*attachment2 = *attachment
+
+			if attachment.File.Path != "" {
+				attachment2.URL = archiveMedia + attachment.File.Path
+				files = append(files, attachment.File.Path)
+			}
+
+			status2.Attachments = append(status2.Attachments, attachment2)
+		}
+
+		status = status2
+	}
+
+	statusable, err := p.converter.StatusToAS(ctx, status)
+	if err != nil {
+		return nil, nil, gtserror.Newf("error converting status: %w", err)
+	}
+
+	create := typeutils.WrapStatusableInCreate(statusable, false)
+	item, err := ap.Serialize(create)
+	return item, files, err
+}
+
+// writeArchiveLikes writes the URIs of
+// statuses faved by the account to the archive.
+func (p *Processor) writeArchiveLikes(ctx context.Context, zw *zip.Writer, account *gtsmodel.Account) error {
+	faves, err := p.state.DB.GetAccountFaves(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting faves: %w", err)
+	}
+
+	uris := make([]string, 0, len(faves))
+	for _, fave := range faves {
+		status, err := p.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			fave.StatusID,
+		)
+		if err != nil {
+			log.Warnf(ctx, "skipping fave %s: %v", fave.ID, err)
+			continue
+		}
+
+		uris = append(uris, status.URI)
+	}
+
+	return writeArchiveJSON(zw, archiveLikes, archiveCollection(archiveLikes, uris))
+}
+
+// writeArchiveBookmarks writes the URIs of
+// statuses bookmarked by the account to the archive.
+func (p *Processor) writeArchiveBookmarks(ctx context.Context, zw *zip.Writer, account *gtsmodel.Account) error {
+	var (
+		uris  []string
+		maxID string
+	)
+
+	for {
+		bookmarks, err := p.state.DB.GetStatusBookmarks(ctx,
+			account.ID,
+			archivePageSize,
+			maxID,
+			"", // min ID
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting bookmarks: %w", err)
+		}
+
+		if len(bookmarks) == 0 {
+			break
+		}
+
+		maxID = bookmarks[len(bookmarks)-1].ID
+
+		for _, bookmark := range bookmarks {
+			uris = append(uris, bookmark.Status.URI)
+		}
+	}
+
+	return writeArchiveJSON(zw, archiveBookmarks, archiveCollection(archiveBookmarks, uris))
+}
+
+// writeArchiveFile copies the file at the given
+// storage path to the archive, under given name.
+func (p *Processor) writeArchiveFile(ctx context.Context, zw *zip.Writer, name string, storagePath string) error {
+	r, err := p.state.Storage.GetStream(ctx, storagePath)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			log.Warnf(ctx, "skipping missing file %s", storagePath)
+			return nil
+		}
+		return gtserror.Newf("error getting %s from storage: %w", storagePath, err)
+	}
+	defer r.Close()
+
+	// Media is already compressed,
+	// so store it in the archive as-is.
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:   name,
+		Method: zip.Store,
+	})
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(w, r); err != nil {
+		return gtserror.Newf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// archiveCollection returns an ordered
+// collection with the given ID and items.
+func archiveCollection(id string, items []string) map[string]interface{} {
+	if items == nil {
+		items = []string{}
+	}
+
+	return map[string]interface{}{
+		"@context":     archiveContext,
+		"id":           id,
+		"type":         "OrderedCollection",
+		"totalItems":   len(items),
+		"orderedItems": items,
+	}
+}
+
+// writeArchiveJSON writes given
+// value to the archive as JSON.
+func writeArchiveJSON(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", name, err)
+	}
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		return gtserror.Newf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -73,7 +73,7 @@ func (p *Processor) Delete(
 		l.Errorf("continuing after error during account delete: %v", err)
 	}
 
-	if account.IsLocal() {
+	if account.IsLocal() && !account.IsGroup() {
 		// We delete tokens, applications and clients for
 		// account as one of the last stages during deletion,
 		// as other database models rely on these.
@@ -96,7 +96,8 @@ func (p *Processor) Delete(
 }
 
 // deleteUserAndTokensForAccount deletes the gtsmodel.User and
-// any OAuth tokens, applications, and Web Push subscriptions for the given account.
+// any OAuth tokens, applications, Web Push subscriptions and
+// WebAuthn credentials for the given account.
 //
 // Callers to this function should already have checked that
 // this is a local account, or else it won't have a user associated
@@ -142,6 +143,20 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 		return gtserror.Newf("db error deleting Web Push subscriptions: %w", err)
 	}
 
+	if err := p.state.DB.DeleteWebAuthnCredentialsByUserID(ctx, user.ID); err != nil {
+		return gtserror.Newf("db error deleting WebAuthn credentials: %w", err)
+	}
+
+	if err := p.state.DB.DeleteExternalIdentitiesByUserID(ctx, user.ID); err != nil {
+		return gtserror.Newf("db error deleting external identities: %w", err)
+	}
+
+	// Revoke rather than delete invites, so
+	// admins can still see who invited whom.
+	if err := p.state.DB.RevokeInvitesByUserID(ctx, user.ID); err != nil {
+		return gtserror.Newf("db error revoking invites: %w", err)
+	}
+
 	columns, err := stubbifyUser(user)
 	if err != nil {
 		return gtserror.Newf("error stubbifying user: %w", err)
@@ -471,6 +486,25 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting faves targeting account: %w", err)
 	}
 
+	// Delete all reactions targeting given account.
+	if err := p.state.DB.DeleteStatusReactions(ctx, account.ID, ""); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting reactions targeting account: %w", err)
+	}
+
+	// Delete all reactions by given account.
+	if err := p.state.DB.DeleteStatusReactions(ctx, "", account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting reactions by account: %w", err)
+	}
+
+	// Delete all group admin entries for given account,
+	// either as the group or as an admin of a group.
+	if err := p.state.DB.DeleteGroupAdminsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting group admins for account: %w", err)
+	}
+
 	// TODO: add status mutes here when they're implemented.
 
 	// Delete all conversations owned by given account.
@@ -493,6 +527,39 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting followed tags by account: %w", err)
 	}
 
+	// Delete all scheduled statuses owned by given account.
+	if err := p.state.DB.DeleteScheduledStatusesByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting scheduled statuses by account: %w", err)
+	}
+
+	// Delete all announcement reads + reactions by given account.
+	if err := p.state.DB.DeleteAnnouncementReadsAndReactionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting announcement reads and reactions by account: %w", err)
+	}
+
+	// Delete all archives of given account, and their files.
+	if err := p.deleteArchives(ctx, account.ID); err != nil {
+		return err
+	}
+
+	// Delete all outbox imports into given account.
+	if err := p.state.DB.DeleteOutboxImportsByAccountID(ctx, account.ID); err != nil {
+		return gtserror.Newf("error deleting outbox imports of account: %w", err)
+	}
+
+	// Delete staff pick of given account, if any.
+	if err := p.state.DB.DeleteStaffPickByAccountID(ctx, account.ID); err != nil {
+		return gtserror.Newf("error deleting staff pick of account: %w", err)
+	}
+
+	// Delete all suggestion dismissals by or targeting given account.
+	if err := p.state.DB.DeleteSuggestionDismissalsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting suggestion dismissals by account: %w", err)
+	}
+
 	// Delete account stats model.
 	if err := p.state.DB.DeleteAccountStats(ctx, account.ID); err != nil {
 		return gtserror.Newf("error deleting stats for account: %w", err)
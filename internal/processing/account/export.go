@@ -157,3 +157,27 @@ func (p *Processor) ExportMutes(
 
 	return records, nil
 }
+
+// ExportBookmarks returns a CSV file of
+// statuses bookmarked by the requester.
+func (p *Processor) ExportBookmarks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([][]string, gtserror.WithCode) {
+	// Fetch all bookmarks by requester,
+	// using no limit to get everything.
+	bookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requester.ID, 0, "", "")
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting bookmarks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Convert bookmarks to CSV-compatible records.
+	records, err := p.converter.BookmarksToCSV(ctx, bookmarks)
+	if err != nil {
+		err = gtserror.Newf("error converting bookmarks to records: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return records, nil
+}
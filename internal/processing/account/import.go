@@ -23,11 +23,16 @@
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"net/url"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 )
 
 func (p *Processor) ImportData(
@@ -63,6 +68,22 @@ func (p *Processor) ImportData(
 			overwrite,
 		)
 
+	case "lists":
+		return p.importLists(
+			ctx,
+			requester,
+			data,
+			overwrite,
+		)
+
+	case "bookmarks":
+		return p.importBookmarks(
+			ctx,
+			requester,
+			data,
+			overwrite,
+		)
+
 	default:
 		const text = "import type not yet supported"
 		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
@@ -532,3 +553,479 @@ func importMutesAsyncF(
 		}
 	}
 }
+
+func (p *Processor) importLists(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	listsData *multipart.FileHeader,
+	overwrite bool,
+) gtserror.WithCode {
+	file, err := listsData.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening lists data file: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse records out of the file.
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		err := fmt.Errorf("error reading lists data file: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Convert the records into a slice of barebones list entries.
+	//
+	// Only List.Title, Follow.TargetAccount.Username, and
+	// Follow.TargetAccount.Domain will be set on each entry.
+	entries, err := p.converter.CSVToListEntries(ctx, records)
+	if err != nil {
+		err := fmt.Errorf("error converting records to list entries: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Do remaining processing of this import asynchronously.
+	f := importListsAsyncF(p, requester, entries, overwrite)
+	p.state.Workers.Processing.Queue.Push(f)
+
+	return nil
+}
+
+func importListsAsyncF(
+	p *Processor,
+	requester *gtsmodel.Account,
+	entries []*gtsmodel.ListEntry,
+	overwrite bool,
+) func(context.Context) {
+	return func(ctx context.Context) {
+		// Get lists currently owned by requester,
+		// so we can add entries to existing lists.
+		prevLists, err := p.state.DB.GetListsByAccountID(ctx, requester.ID)
+		if err != nil {
+			log.Errorf(ctx, "db error getting lists: %v", err)
+			return
+		}
+
+		// Key existing lists by their title.
+		lists := make(map[string]*gtsmodel.List, len(prevLists))
+		for _, list := range prevLists {
+			lists[list.Title] = list
+		}
+
+		// Group entries parsed from the CSV
+		// file by list title, keeping order.
+		var titles []string
+		wanted := make(map[string][]*gtsmodel.ListEntry)
+		for _, entry := range entries {
+			title := entry.List.Title
+			if _, ok := wanted[title]; !ok {
+				titles = append(titles, title)
+			}
+			wanted[title] = append(wanted[title], entry)
+		}
+
+		if overwrite {
+			// If we're overwriting, remove
+			// any lists not in the CSV file.
+			for title, list := range lists {
+				if _, ok := wanted[title]; ok {
+					// Leave this
+					// one alone.
+					continue
+				}
+
+				if err := p.state.DB.DeleteListByID(ctx, list.ID); err != nil {
+					log.Errorf(ctx, "could not delete list: %v", err)
+				}
+			}
+		}
+
+		// Go through the lists parsed from
+		// CSV file, and create / update each.
+		for _, title := range titles {
+			list, ok := lists[title]
+			if !ok {
+				// No list with this title
+				// yet, so create a new one.
+				list = &gtsmodel.List{
+					ID:            id.NewULID(),
+					Title:         title,
+					AccountID:     requester.ID,
+					RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+					Exclusive:     util.Ptr(false),
+				}
+
+				if err := p.state.DB.PutList(ctx, list); err != nil {
+					log.Errorf(ctx, "could not create list: %v", err)
+					continue
+				}
+			}
+
+			importListEntries(ctx, p, requester, list, wanted[title], overwrite)
+		}
+	}
+}
+
+// importListEntries adds accounts in the given entries to
+// the given list. Accounts that aren't yet followed by the
+// requester are followed, and added to the list once the
+// follow is accepted. If overwrite is true, follows not in
+// the given entries are removed from the list.
+func importListEntries(
+	ctx context.Context,
+	p *Processor,
+	requester *gtsmodel.Account,
+	list *gtsmodel.List,
+	entries []*gtsmodel.ListEntry,
+	overwrite bool,
+) {
+	// Get all follows that are entries in list.
+	prevFollows, err := p.state.DB.GetFollowsInList(ctx, list.ID, nil)
+	if err != nil {
+		log.Errorf(ctx, "db error getting list follows: %v", err)
+		return
+	}
+
+	// Map used to store wanted
+	// entry targets (if overwriting).
+	var wantedEntries map[string]struct{}
+
+	if overwrite {
+		// Pending entries will be recreated
+		// below for wanted follow requests.
+		if err := p.state.DB.DeletePendingListEntriesByListID(ctx, list.ID); err != nil {
+			log.Errorf(ctx, "db error deleting pending list entries: %v", err)
+			return
+		}
+
+		// Initialize new entries map.
+		wantedEntries = make(map[string]struct{}, len(entries))
+
+		// Once we've created (or tried to create)
+		// the required entries, go through previous
+		// entries and remove unwanted ones.
+		defer func() {
+			for _, prev := range prevFollows {
+				username := prev.TargetAccount.Username
+				domain := prev.TargetAccount.Domain
+
+				_, wanted := wantedEntries[username+"@"+domain]
+				if wanted {
+					// Leave this
+					// one alone.
+					continue
+				}
+
+				if err := p.state.DB.DeleteListEntry(ctx, list.ID, prev.ID); err != nil {
+					log.Errorf(ctx, "could not remove list entry: %v", err)
+					continue
+				}
+			}
+		}()
+	}
+
+	// Convert the previous follows to a hash set of follow IDs.
+	inList := util.ToSetFunc(prevFollows, func(follow *gtsmodel.Follow) string {
+		return follow.ID
+	})
+
+	for _, entry := range entries {
+		var (
+			// Username of the target.
+			username = entry.Follow.TargetAccount.Username
+
+			// Domain of the target.
+			// Empty for our domain.
+			domain = entry.Follow.TargetAccount.Domain
+		)
+
+		if overwrite {
+			// We'll be overwriting, so store
+			// this new entry in our handy map.
+			wantedEntries[username+"@"+domain] = struct{}{}
+		}
+
+		// Get the target account, dereferencing it if necessary.
+		targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
+			ctx,
+			requester.Username,
+			username,
+			domain,
+		)
+		if err != nil {
+			log.Errorf(ctx, "could not retrieve account: %v", err)
+			continue
+		}
+
+		// Get the existing follow of target, if any.
+		follow, err := p.state.DB.GetFollow(
+			gtscontext.SetBarebones(ctx),
+			requester.ID,
+			targetAcct.ID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting follow: %v", err)
+			continue
+		}
+
+		if follow == nil {
+			// Not followed yet, so use the processor's
+			// FollowCreate function to request a follow,
+			// and add the account to the list once the
+			// follow request has been accepted.
+			importPendingListEntry(ctx, p, requester, list, targetAcct)
+			continue
+		}
+
+		if inList.Has(follow.ID) {
+			// Already in list.
+			continue
+		}
+
+		if err := p.state.DB.PutListEntries(ctx, []*gtsmodel.ListEntry{{
+			ID:       id.NewULID(),
+			ListID:   list.ID,
+			FollowID: follow.ID,
+		}}); err != nil {
+			log.Errorf(ctx, "could not add list entry: %v", err)
+			continue
+		}
+
+		inList[follow.ID] = struct{}{}
+	}
+}
+
+// importPendingListEntry follows the target account if
+// it's not already requested, and adds a pending entry
+// for the follow request to the given list.
+func importPendingListEntry(
+	ctx context.Context,
+	p *Processor,
+	requester *gtsmodel.Account,
+	list *gtsmodel.List,
+	targetAcct *gtsmodel.Account,
+) {
+	if _, errWithCode := p.FollowCreate(
+		ctx,
+		requester,
+		&apimodel.AccountFollowRequest{ID: targetAcct.ID},
+	); errWithCode != nil {
+		log.Errorf(ctx, "could not follow account: %v", errWithCode.Unwrap())
+		return
+	}
+
+	followReq, err := p.state.DB.GetFollowRequest(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+		targetAcct.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting follow request: %v", err)
+		return
+	}
+
+	// The follow may already have been
+	// accepted, in which case this uses
+	// the ID of the accepted follow.
+	followID := ""
+	if followReq != nil {
+		followID = followReq.ID
+	} else {
+		follow, err := p.state.DB.GetFollow(
+			gtscontext.SetBarebones(ctx),
+			requester.ID,
+			targetAcct.ID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "db error getting follow: %v", err)
+			return
+		}
+		followID = follow.ID
+	}
+
+	if err := p.state.DB.PutPendingListEntries(ctx, []*gtsmodel.PendingListEntry{{
+		ID:              id.NewULID(),
+		ListID:          list.ID,
+		FollowRequestID: followID,
+	}}); err != nil {
+		log.Errorf(ctx, "could not add pending list entry: %v", err)
+		return
+	}
+
+	// If the follow request was accepted before
+	// the pending entry was stored, accept the
+	// pending entry now. This is a no-op if the
+	// follow request is still pending.
+	if followed, err := p.state.DB.IsFollowing(ctx,
+		requester.ID,
+		targetAcct.ID,
+	); err != nil {
+		log.Errorf(ctx, "db error checking follow: %v", err)
+	} else if followed {
+		if err := p.state.DB.AcceptPendingListEntries(ctx, followID); err != nil {
+			log.Errorf(ctx, "could not accept pending list entry: %v", err)
+		}
+	}
+}
+
+func (p *Processor) importBookmarks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	bookmarksData *multipart.FileHeader,
+	overwrite bool,
+) gtserror.WithCode {
+	file, err := bookmarksData.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening bookmarks data file: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse records out of the file.
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		err := fmt.Errorf("error reading bookmarks data file: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Convert the records into a slice of barebones bookmarks.
+	//
+	// Only Status.URI will be set on each bookmark.
+	bookmarks, err := p.converter.CSVToBookmarks(ctx, records)
+	if err != nil {
+		err := fmt.Errorf("error converting records to bookmarks: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Do remaining processing of this import asynchronously.
+	f := importBookmarksAsyncF(p, requester, bookmarks, overwrite)
+	p.state.Workers.Processing.Queue.Push(f)
+
+	return nil
+}
+
+func importBookmarksAsyncF(
+	p *Processor,
+	requester *gtsmodel.Account,
+	bookmarks []*gtsmodel.StatusBookmark,
+	overwrite bool,
+) func(context.Context) {
+	return func(ctx context.Context) {
+		// Map used to store wanted
+		// bookmark targets (if overwriting).
+		var wantedBookmarks map[string]struct{}
+
+		if overwrite {
+			// If we're overwriting, we need to get current
+			// bookmarks owned by requester *before* making
+			// any changes, so that we can remove unwanted
+			// bookmarks after we've created new ones.
+			prevBookmarks, err := p.state.DB.GetStatusBookmarks(ctx, requester.ID, 0, "", "")
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting bookmarks: %v", err)
+				return
+			}
+
+			// Initialize new bookmarks map.
+			wantedBookmarks = make(map[string]struct{}, len(bookmarks))
+
+			// Once we've created (or tried to create)
+			// the required bookmarks, go through previous
+			// bookmarks and remove unwanted ones.
+			defer func() {
+				for _, prev := range prevBookmarks {
+					_, wanted := wantedBookmarks[prev.Status.URI]
+					if wanted {
+						// Leave this
+						// one alone.
+						continue
+					}
+
+					if err := p.state.DB.DeleteStatusBookmarkByID(ctx, prev.ID); err != nil {
+						log.Errorf(ctx, "could not remove bookmark: %v", err)
+						continue
+					}
+
+					if err := p.c.InvalidateTimelinedStatus(ctx, requester.ID, prev.StatusID); err != nil {
+						log.Errorf(ctx, "error invalidating status from timelines: %v", err)
+					}
+				}
+			}()
+		}
+
+		// Go through the bookmarks parsed from CSV
+		// file, and create each one if necessary.
+		for _, bookmark := range bookmarks {
+			// URI of the bookmarked status.
+			uriStr := bookmark.Status.URI
+
+			if overwrite {
+				// We'll be overwriting, so store
+				// this new bookmark in our handy map.
+				wantedBookmarks[uriStr] = struct{}{}
+			}
+
+			uri, err := url.Parse(uriStr)
+			if err != nil {
+				log.Errorf(ctx, "could not parse status uri: %v", err)
+				continue
+			}
+
+			// Get the target status, dereferencing it if necessary.
+			status, _, err := p.federator.Dereferencer.GetStatusByURI(
+				ctx,
+				requester.Username,
+				uri,
+			)
+			if err != nil {
+				log.Errorf(ctx, "could not retrieve status: %v", err)
+				continue
+			}
+
+			// Check the status is visible to requester.
+			visible, err := p.visFilter.StatusVisible(ctx, requester, status)
+			if err != nil {
+				log.Errorf(ctx, "error checking status visibility: %v", err)
+				continue
+			}
+
+			if !visible {
+				log.Debugf(ctx, "status %s not visible to requester", uriStr)
+				continue
+			}
+
+			// Check for an existing bookmark of status.
+			bookmarked, err := p.state.DB.IsStatusBookmarkedBy(ctx,
+				requester.ID,
+				status.ID,
+			)
+			if err != nil {
+				log.Errorf(ctx, "db error checking bookmark: %v", err)
+				continue
+			}
+
+			if bookmarked {
+				// Already
+				// bookmarked.
+				continue
+			}
+
+			if err := p.state.DB.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+				ID:              id.NewULID(),
+				AccountID:       requester.ID,
+				Account:         requester,
+				TargetAccountID: status.AccountID,
+				TargetAccount:   status.Account,
+				StatusID:        status.ID,
+				Status:          status,
+			}); err != nil {
+				log.Errorf(ctx, "could not create bookmark: %v", err)
+				continue
+			}
+
+			if err := p.c.InvalidateTimelinedStatus(ctx, requester.ID, status.ID); err != nil {
+				log.Errorf(ctx, "error invalidating status from timelines: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,833 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// outboxImportProgressEvery is how many outbox items
+// are processed between each write of an import's
+// progress to the database.
+const outboxImportProgressEvery = 20
+
+// outboxItem is a status from an
+// archive's outbox, ready for import.
+type outboxItem struct {
+	statusable  ap.Statusable
+	published   time.Time
+	attachments []*outboxAttachment
+}
+
+// outboxAttachment is a media attachment of
+// a status in an archive's outbox. These are
+// parsed separately from the status, as their
+// URLs are paths relative to the archive root.
+type outboxAttachment struct {
+	path        string
+	description string
+	blurhash    string
+	focusX      float32
+	focusY      float32
+}
+
+// ImportOutbox starts importing public statuses from the
+// outbox of the given Mastodon-compatible archive ZIP file,
+// as backdated statuses of the requester. The import is
+// done asynchronously; its progress is returned, and can
+// be checked later using OutboxImportGet.
+//
+// Imported statuses are only federated to the requester's
+// followers if federate is true.
+func (p *Processor) ImportOutbox(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	data *multipart.FileHeader,
+	federate bool,
+) (*apimodel.OutboxImport, gtserror.WithCode) {
+	if !config.GetInstanceAllowBackdatingStatuses() {
+		const text = "backdating statuses has been disabled on this instance"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	outboxImports, err := p.state.DB.GetOutboxImportsByAccountID(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting outbox imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, outboxImport := range outboxImports {
+		if !outboxImport.Finished() && !outboxImport.Stale() {
+			const text = "an outbox import is already in progress, please wait until it has finished"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	// The uploaded file is removed at the end
+	// of the request, so copy it somewhere that
+	// we can import it from in the background.
+	archivePath, errWithCode := copyOutboxArchive(data)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Check early that this looks
+	// like an archive we can import.
+	if errWithCode := checkOutboxArchive(archivePath); errWithCode != nil {
+		removeOutboxArchive(ctx, archivePath)
+		return nil, errWithCode
+	}
+
+	now := time.Now()
+	outboxImport := &gtsmodel.OutboxImport{
+		ID:        id.NewULID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		AccountID: requester.ID,
+		Account:   requester,
+		Federate:  &federate,
+		Failed:    util.Ptr(false),
+	}
+
+	if err := p.state.DB.PutOutboxImport(ctx, outboxImport); err != nil {
+		removeOutboxArchive(ctx, archivePath)
+		err = gtserror.Newf("db error putting outbox import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Do the actual importing asynchronously.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.processOutboxImport(ctx, outboxImport, archivePath)
+	})
+
+	return p.converter.OutboxImportToAPIOutboxImport(outboxImport), nil
+}
+
+// OutboxImportsGet returns the requester's outbox imports, newest first.
+func (p *Processor) OutboxImportsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.OutboxImport, gtserror.WithCode) {
+	outboxImports, err := p.state.DB.GetOutboxImportsByAccountID(
+		gtscontext.SetBarebones(ctx),
+		requester.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting outbox imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiImports := make([]*apimodel.OutboxImport, 0, len(outboxImports))
+	for _, outboxImport := range outboxImports {
+		apiImports = append(apiImports, p.converter.OutboxImportToAPIOutboxImport(outboxImport))
+	}
+
+	return apiImports, nil
+}
+
+// OutboxImportGet returns the requester's
+// outbox import with the given ID.
+func (p *Processor) OutboxImportGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.OutboxImport, gtserror.WithCode) {
+	outboxImport, err := p.state.DB.GetOutboxImportByID(
+		gtscontext.SetBarebones(ctx),
+		id,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting outbox import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if outboxImport == nil || outboxImport.AccountID != requester.ID {
+		const text = "outbox import not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return p.converter.OutboxImportToAPIOutboxImport(outboxImport), nil
+}
+
+// copyOutboxArchive copies the uploaded
+// archive to a new temporary file, and
+// returns the path of the temporary file.
+func copyOutboxArchive(data *multipart.FileHeader) (string, gtserror.WithCode) {
+	file, err := data.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening archive file: %w", err)
+		return "", gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	tmp, err := os.CreateTemp(os.TempDir(), "gotosocial-import-*.zip")
+	if err != nil {
+		err = gtserror.Newf("error creating temp file: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	if _, err := io.Copy(tmp, file); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		err = gtserror.Newf("error copying archive to temp file: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		err = gtserror.Newf("error closing temp file: %w", err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// checkOutboxArchive checks that the archive
+// at the given path is a ZIP file with an outbox.
+func checkOutboxArchive(archivePath string) gtserror.WithCode {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		err := fmt.Errorf("error reading archive file: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer zr.Close()
+
+	if _, err := zr.Open(archiveOutbox); err != nil {
+		text := "archive file does not contain " + archiveOutbox
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return nil
+}
+
+// removeOutboxArchive removes the temporary
+// archive file at the given path, logging
+// rather than returning any error.
+func removeOutboxArchive(ctx context.Context, archivePath string) {
+	if err := os.Remove(archivePath); err != nil {
+		log.Errorf(ctx, "error removing temp file %s: %v", archivePath, err)
+	}
+}
+
+// processOutboxImport imports the archive at the given
+// path, updating the import's progress as it goes,
+// and removing the archive once it's finished with.
+func (p *Processor) processOutboxImport(
+	ctx context.Context,
+	outboxImport *gtsmodel.OutboxImport,
+	archivePath string,
+) {
+	defer removeOutboxArchive(ctx, archivePath)
+
+	if err := p.importOutbox(ctx, outboxImport, archivePath); err != nil {
+		log.Errorf(ctx, "error importing outbox %s: %v", outboxImport.ID, err)
+		outboxImport.Failed = util.Ptr(true)
+	}
+
+	outboxImport.FinishedAt = time.Now()
+	if err := p.state.DB.UpdateOutboxImport(ctx,
+		outboxImport,
+		"total",
+		"imported",
+		"skipped",
+		"finished_at",
+		"failed",
+	); err != nil {
+		log.Errorf(ctx, "db error updating outbox import %s: %v", outboxImport.ID, err)
+	}
+}
+
+// importOutbox imports each status in the outbox of
+// the archive at the given path, oldest first, so
+// that replies can be threaded with their parents.
+func (p *Processor) importOutbox(
+	ctx context.Context,
+	outboxImport *gtsmodel.OutboxImport,
+	archivePath string,
+) error {
+	// Refetch the account to import into,
+	// so that it's up to date and populated.
+	account, err := p.state.DB.GetAccountByID(ctx, outboxImport.AccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return gtserror.Newf("error opening archive: %w", err)
+	}
+	defer zr.Close()
+
+	items, total, err := readOutboxItems(ctx, &zr.Reader)
+	if err != nil {
+		return err
+	}
+
+	// Items we couldn't read at
+	// all count as skipped already.
+	outboxImport.Total = total
+	outboxImport.Skipped = total - len(items)
+	if err := p.state.DB.UpdateOutboxImport(ctx,
+		outboxImport,
+		"total",
+		"skipped",
+	); err != nil {
+		return gtserror.Newf("db error updating outbox import: %w", err)
+	}
+
+	// Statuses imported so far, keyed
+	// by their URI in the archive, to
+	// thread self-replies in the archive.
+	imported := make(map[string]*gtsmodel.Status, len(items))
+
+	for i, item := range items {
+		status, err := p.importOutboxItem(ctx,
+			account,
+			&zr.Reader,
+			item,
+			imported,
+			*outboxImport.Federate,
+		)
+		switch {
+		case err != nil:
+			log.Warnf(ctx, "skipping outbox item: %v", err)
+			outboxImport.Skipped++
+
+		case status == nil:
+			outboxImport.Skipped++
+
+		default:
+			uri := ap.GetJSONLDId(item.statusable)
+			imported[uri.String()] = status
+			outboxImport.Imported++
+		}
+
+		if (i+1)%outboxImportProgressEvery == 0 {
+			if err := p.state.DB.UpdateOutboxImport(ctx,
+				outboxImport,
+				"imported",
+				"skipped",
+			); err != nil {
+				log.Errorf(ctx, "db error updating outbox import %s: %v", outboxImport.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// readOutboxItems reads the outbox of the given
+// archive, returning the importable statuses in it,
+// oldest first, and the total number of outbox items.
+//
+// Only Create activities with an embedded status
+// that isn't a poll are importable; boosts can't be
+// imported, and polls can't be backdated.
+func readOutboxItems(ctx context.Context, zr *zip.Reader) ([]*outboxItem, int, error) {
+	f, err := zr.Open(archiveOutbox)
+	if err != nil {
+		return nil, 0, gtserror.Newf("error opening %s: %w", archiveOutbox, err)
+	}
+	defer f.Close()
+
+	var outbox struct {
+		Context      any               `json:"@context"`
+		OrderedItems []json.RawMessage `json:"orderedItems"`
+	}
+
+	if err := json.NewDecoder(f).Decode(&outbox); err != nil {
+		return nil, 0, gtserror.Newf("error decoding %s: %w", archiveOutbox, err)
+	}
+
+	items := make([]*outboxItem, 0, len(outbox.OrderedItems))
+	for _, raw := range outbox.OrderedItems {
+		var activity struct {
+			Context any             `json:"@context"`
+			Type    string          `json:"type"`
+			Object  json.RawMessage `json:"object"`
+		}
+
+		if err := json.Unmarshal(raw, &activity); err != nil ||
+			activity.Type != ap.ActivityCreate {
+			continue
+		}
+
+		// Object must be embedded
+		// rather than just a URI.
+		var object map[string]any
+		if err := json.Unmarshal(activity.Object, &object); err != nil {
+			continue
+		}
+
+		// Take attachments out of the object before
+		// resolving it, as their relative URLs aren't
+		// valid as far as ActivityStreams is concerned.
+		attachments := parseOutboxAttachments(object["attachment"])
+		delete(object, "attachment")
+
+		// Embedded objects usually rely on the
+		// JSON-LD context of their container.
+		if _, ok := object["@context"]; !ok {
+			switch {
+			case activity.Context != nil:
+				object["@context"] = activity.Context
+			case outbox.Context != nil:
+				object["@context"] = outbox.Context
+			default:
+				object["@context"] = archiveContext
+			}
+		}
+
+		b, err := json.Marshal(object)
+		if err != nil {
+			continue
+		}
+
+		statusable, err := ap.ResolveStatusable(ctx, io.NopCloser(bytes.NewReader(b)))
+		if err != nil {
+			log.Debugf(ctx, "skipping unresolvable outbox item: %v", err)
+			continue
+		}
+
+		if _, ok := ap.ToPollable(statusable); ok {
+			continue
+		}
+
+		if ap.GetJSONLDId(statusable) == nil {
+			continue
+		}
+
+		published := ap.GetPublished(statusable)
+		if published.Compare(time.UnixMilli(0)) <= 0 {
+			// Can't generate an ID for
+			// statuses at or before epoch.
+			continue
+		}
+
+		items = append(items, &outboxItem{
+			statusable:  statusable,
+			published:   published,
+			attachments: attachments,
+		})
+	}
+
+	slices.SortStableFunc(items, func(a, b *outboxItem) int {
+		return a.published.Compare(b.published)
+	})
+
+	return items, len(outbox.OrderedItems), nil
+}
+
+// parseOutboxAttachments parses the given raw JSON
+// "attachment" property of a status in an outbox.
+func parseOutboxAttachments(raw any) []*outboxAttachment {
+	var objects []any
+	switch raw := raw.(type) {
+	case []any:
+		objects = raw
+	case map[string]any:
+		objects = []any{raw}
+	default:
+		return nil
+	}
+
+	attachments := make([]*outboxAttachment, 0, len(objects))
+	for _, object := range objects {
+		object, ok := object.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		rawURL, _ := object["url"].(string)
+		if rawURL == "" {
+			continue
+		}
+
+		attachment := new(outboxAttachment)
+
+		// Mastodon archives use paths relative to
+		// the domain root, eg., "/media_attachments/...",
+		// whereas ours are relative, eg., "media_attachments/...".
+		// Either way they're relative to the archive root.
+		if u, err := url.Parse(rawURL); err == nil {
+			attachment.path = strings.TrimPrefix(u.Path, "/")
+		}
+
+		if attachment.path == "" {
+			continue
+		}
+
+		attachment.description, _ = object["name"].(string)
+		attachment.blurhash, _ = object["blurhash"].(string)
+
+		if focus, ok := object["focalPoint"].([]any); ok && len(focus) == 2 {
+			x, _ := focus[0].(float64)
+			y, _ := focus[1].(float64)
+			attachment.focusX = float32(x)
+			attachment.focusY = float32(y)
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments
+}
+
+// importOutboxItem imports the given outbox item as a
+// backdated status of the given account, returning
+// nil status if the item should be skipped.
+func (p *Processor) importOutboxItem(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	zr *zip.Reader,
+	item *outboxItem,
+	imported map[string]*gtsmodel.Status,
+	federate bool,
+) (*gtsmodel.Status, error) {
+	statusable := item.statusable
+	uri := ap.GetJSONLDId(statusable).String()
+
+	// Only import public and unlisted statuses; we can't
+	// know who else the original statuses were visible to.
+	visibility, err := ap.ExtractVisibility(statusable, "")
+	if err != nil ||
+		(visibility != gtsmodel.VisibilityPublic &&
+			visibility != gtsmodel.VisibilityUnlocked) {
+		return nil, nil
+	}
+
+	// Resolve the replied-to status first (if
+	// any), as replies are skipped if we can't.
+	var inReplyTo *gtsmodel.Status
+	if inReplyToURIs := ap.GetInReplyTo(statusable); len(inReplyToURIs) > 0 {
+		inReplyTo, err = p.importOutboxInReplyTo(ctx,
+			account,
+			inReplyToURIs[0],
+			imported,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error resolving reply of %s: %w", uri, err)
+		}
+	}
+
+	statusID, err := p.c.BackfilledStatusID(ctx, item.published)
+	if err != nil {
+		return nil, err
+	}
+
+	// Imported statuses were originally written
+	// elsewhere, so we only have their HTML; keep
+	// a plain text version as the status source.
+	content, language := typeutils.ContentToContentLanguage(ctx, ap.ExtractContent(statusable))
+	accountURIs := uris.GenerateURIsForAccount(account.Username)
+
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 accountURIs.StatusesURI + "/" + statusID,
+		URL:                 accountURIs.StatusesURL + "/" + statusID,
+		CreatedAt:           item.published,
+		Local:               util.Ptr(true),
+		Account:             account,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		ActivityStreamsType: statusable.GetTypeName(),
+		Sensitive:           util.Ptr(ap.ExtractSensitive(statusable)),
+		Language:            language,
+		Content:             content,
+		ContentWarning:      ap.ExtractSummary(statusable),
+		Text:                text.ParseHTMLToPlain(content),
+		ContentType:         gtsmodel.StatusContentTypePlain,
+		Visibility:          visibility,
+		Federated:           util.Ptr(true),
+		PendingApproval:     util.Ptr(false),
+	}
+
+	switch visibility {
+	case gtsmodel.VisibilityPublic:
+		status.InteractionPolicy = account.Settings.InteractionPolicyPublic
+	case gtsmodel.VisibilityUnlocked:
+		status.InteractionPolicy = account.Settings.InteractionPolicyUnlocked
+	}
+
+	if inReplyTo != nil {
+		status.InReplyToID = inReplyTo.ID
+		status.InReplyTo = inReplyTo
+		status.InReplyToURI = inReplyTo.URI
+		status.InReplyToAccountID = inReplyTo.AccountID
+		status.InReplyToAccount = inReplyTo.Account
+		status.ThreadID = inReplyTo.ThreadID
+	}
+
+	if status.ThreadID == "" {
+		// Start a new thread from here.
+		thread := &gtsmodel.Thread{ID: id.NewULID()}
+		if err := p.state.DB.PutThread(ctx, thread); err != nil {
+			return nil, gtserror.Newf("db error putting thread: %w", err)
+		}
+		status.ThreadID = thread.ID
+	}
+
+	if err := p.importOutboxMentions(ctx, account, status, statusable); err != nil {
+		return nil, err
+	}
+
+	if err := p.importOutboxTags(ctx, status, statusable); err != nil {
+		return nil, err
+	}
+
+	p.importOutboxMedia(ctx, account, status, zr, item.attachments)
+
+	if len(status.AttachmentIDs) > 0 &&
+		(status.ContentWarning != "" || account.IsSensitized()) {
+		// As with new statuses, statuses with
+		// media and a content-warning (or from
+		// sensitized accounts) are always sensitive.
+		status.Sensitive = util.Ptr(true)
+	}
+
+	if err := p.state.DB.PutStatus(ctx, status); err != nil {
+		return nil, gtserror.Newf("db error putting status: %w", err)
+	}
+
+	// Send to the client API worker for async
+	// side-effects, as with any backfilled status.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel: &gtsmodel.BackfillStatus{
+			Status:   status,
+			Federate: federate,
+		},
+		Origin: account,
+	})
+
+	return status, nil
+}
+
+// importOutboxInReplyTo resolves the status that an imported
+// status replies to, either from statuses imported already,
+// or by dereferencing it, returning an error if it can't be
+// resolved or can't be replied to by the importing account.
+func (p *Processor) importOutboxInReplyTo(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	inReplyToURI *url.URL,
+	imported map[string]*gtsmodel.Status,
+) (*gtsmodel.Status, error) {
+	// Self-replies are the common case.
+	if status, ok := imported[inReplyToURI.String()]; ok {
+		return status, nil
+	}
+
+	// Replied-to statuses may well be long
+	// gone, so don't hang around trying.
+	status, _, err := p.federator.GetStatusByURI(
+		gtscontext.SetFastFail(ctx),
+		account.Username,
+		inReplyToURI,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := p.visFilter.StatusVisible(ctx, account, status)
+	if err != nil {
+		return nil, gtserror.Newf("error checking visibility: %w", err)
+	}
+
+	if !visible {
+		return nil, gtserror.New("replied-to status not visible")
+	}
+
+	// Only import replies that are permitted outright,
+	// as any approval process would be long over.
+	policyResult, err := p.intFilter.StatusReplyable(ctx, account, status)
+	if err != nil {
+		return nil, gtserror.Newf("error checking replyability: %w", err)
+	}
+
+	if !policyResult.Permitted() || policyResult.MatchedOnCollection() {
+		return nil, gtserror.New("replied-to status not replyable without approval")
+	}
+
+	return status, nil
+}
+
+// importOutboxMentions resolves and stores
+// the mentions of an imported status.
+// Mentions that can't be resolved are left
+// in the status content, but not stored.
+func (p *Processor) importOutboxMentions(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+	statusable ap.Statusable,
+) error {
+	mentions, err := ap.ExtractMentions(statusable)
+	if err != nil {
+		log.Debugf(ctx, "error extracting mentions: %v", err)
+	}
+
+	for _, m := range mentions {
+		namestring := m.NameString
+
+		// Some implementations use just "@someone" as
+		// the name of remote mentions; take the domain
+		// from the target URI, if so.
+		if strings.Count(namestring, "@") == 1 && m.TargetAccountURI != "" {
+			if u, err := url.Parse(m.TargetAccountURI); err == nil && u.Host != "" {
+				namestring += "@" + u.Host
+			}
+		}
+
+		if namestring == "" {
+			continue
+		}
+
+		mention, err := p.parseMention(ctx, namestring, account.ID, status.ID)
+		if err != nil {
+			log.Debugf(ctx, "skipping mention %s: %v", namestring, err)
+			continue
+		}
+
+		if err := p.state.DB.PutMention(ctx, mention); err != nil {
+			return gtserror.Newf("db error putting mention: %w", err)
+		}
+
+		status.Mentions = append(status.Mentions, mention)
+		status.MentionIDs = append(status.MentionIDs, mention.ID)
+	}
+
+	return nil
+}
+
+// importOutboxTags gets or creates
+// the hashtags of an imported status.
+func (p *Processor) importOutboxTags(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	statusable ap.Statusable,
+) error {
+	tags, err := ap.ExtractHashtags(statusable)
+	if err != nil {
+		log.Debugf(ctx, "error extracting hashtags: %v", err)
+	}
+
+	for _, t := range tags {
+		tag, err := p.state.DB.GetTagByName(ctx, t.Name)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting tag %s: %w", t.Name, err)
+		}
+
+		if tag == nil {
+			tag = &gtsmodel.Tag{
+				ID:   id.NewULID(),
+				Name: t.Name,
+			}
+
+			if err := p.state.DB.PutTag(ctx, tag); err != nil {
+				return gtserror.Newf("db error putting tag %s: %w", t.Name, err)
+			}
+		}
+
+		status.Tags = append(status.Tags, tag)
+		status.TagIDs = append(status.TagIDs, tag.ID)
+	}
+
+	return nil
+}
+
+// importOutboxMedia stores the media attachments of
+// an imported status from the archive. Attachments
+// missing from the archive, too big, or that can't
+// be processed are skipped, rather than the status.
+func (p *Processor) importOutboxMedia(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+	zr *zip.Reader,
+	attachments []*outboxAttachment,
+) {
+	maxsz := config.GetMediaLocalMaxSize()
+
+	for _, a := range attachments {
+		f, err := zr.Open(a.path)
+		if err != nil {
+			log.Debugf(ctx, "skipping attachment %s: %v", a.path, err)
+			continue
+		}
+
+		if info, err := f.Stat(); err != nil || info.Size() > int64(maxsz) { // #nosec G115 -- Already validated.
+			log.Debugf(ctx, "skipping attachment %s: too big or unreadable", a.path)
+			_ = f.Close()
+			continue
+		}
+
+		attachment, errWithCode := p.c.StoreLocalMedia(ctx,
+			account.ID,
+			func(context.Context) (io.ReadCloser, error) {
+				return f, nil
+			},
+			media.AdditionalMediaInfo{
+				StatusID:    &status.ID,
+				Description: &a.description,
+				Blurhash:    &a.blurhash,
+				FocusX:      &a.focusX,
+				FocusY:      &a.focusY,
+			},
+		)
+		if errWithCode != nil {
+			log.Debugf(ctx, "skipping attachment %s: %v", a.path, errWithCode)
+			continue
+		}
+
+		status.Attachments = append(status.Attachments, attachment)
+		status.AttachmentIDs = append(status.AttachmentIDs, attachment.ID)
+	}
+}
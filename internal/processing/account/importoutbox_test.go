@@ -0,0 +1,260 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportOutboxTestSuite struct {
+	AccountStandardTestSuite
+}
+
+const testOutbox = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "outbox.json",
+  "type": "OrderedCollection",
+  "totalItems": 5,
+  "orderedItems": [
+    {
+      "id": "https://old.example.org/users/turtle/statuses/2/activity",
+      "type": "Create",
+      "actor": "https://old.example.org/users/turtle",
+      "published": "2020-01-02T12:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "object": {
+        "id": "https://old.example.org/users/turtle/statuses/2",
+        "type": "Note",
+        "attributedTo": "https://old.example.org/users/turtle",
+        "published": "2020-01-02T12:00:00Z",
+        "inReplyTo": "https://old.example.org/users/turtle/statuses/1",
+        "to": ["https://old.example.org/users/turtle/followers"],
+        "cc": ["https://www.w3.org/ns/activitystreams#Public"],
+        "content": "<p>and a self-reply</p>"
+      }
+    },
+    {
+      "id": "https://old.example.org/users/turtle/statuses/1/activity",
+      "type": "Create",
+      "actor": "https://old.example.org/users/turtle",
+      "published": "2020-01-01T12:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "object": {
+        "id": "https://old.example.org/users/turtle/statuses/1",
+        "type": "Note",
+        "attributedTo": "https://old.example.org/users/turtle",
+        "published": "2020-01-01T12:00:00Z",
+        "to": ["https://www.w3.org/ns/activitystreams#Public"],
+        "cc": ["https://old.example.org/users/turtle/followers"],
+        "content": "<p>hello <span class=\"h-card\"><a href=\"http://localhost:8080/@admin\" class=\"u-url mention\">@<span>admin</span></a></span>, <a href=\"https://old.example.org/tags/welcome\" class=\"mention hashtag\" rel=\"tag\">#<span>welcome</span></a></p>",
+        "tag": [
+          {"type": "Mention", "href": "http://localhost:8080/users/admin", "name": "@admin@localhost:8080"},
+          {"type": "Hashtag", "href": "https://old.example.org/tags/welcome", "name": "#welcome"}
+        ],
+        "attachment": [
+          {"type": "Document", "mediaType": "image/jpeg", "url": "/media_attachments/files/beeplushie.jpg", "name": "a bee plushie", "focalPoint": [0.5, -0.5]}
+        ]
+      }
+    },
+    {
+      "id": "https://old.example.org/users/turtle/statuses/3/activity",
+      "type": "Create",
+      "actor": "https://old.example.org/users/turtle",
+      "published": "2020-01-03T12:00:00Z",
+      "to": ["https://old.example.org/users/turtle/followers"],
+      "object": {
+        "id": "https://old.example.org/users/turtle/statuses/3",
+        "type": "Note",
+        "attributedTo": "https://old.example.org/users/turtle",
+        "published": "2020-01-03T12:00:00Z",
+        "to": ["https://old.example.org/users/turtle/followers"],
+        "content": "<p>followers only</p>"
+      }
+    },
+    {
+      "id": "https://old.example.org/users/turtle/statuses/4/activity",
+      "type": "Create",
+      "actor": "https://old.example.org/users/turtle",
+      "published": "2020-01-04T12:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "object": {
+        "id": "https://old.example.org/users/turtle/statuses/4",
+        "type": "Note",
+        "attributedTo": "https://old.example.org/users/turtle",
+        "published": "2020-01-04T12:00:00Z",
+        "inReplyTo": "https://unknown-instance.com/users/someone/statuses/1",
+        "to": ["https://www.w3.org/ns/activitystreams#Public"],
+        "content": "<p>reply to something gone</p>"
+      }
+    },
+    {
+      "id": "https://old.example.org/users/turtle/statuses/5/activity",
+      "type": "Announce",
+      "actor": "https://old.example.org/users/turtle",
+      "published": "2020-01-05T12:00:00Z",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "object": "https://unknown-instance.com/users/someone/statuses/2"
+    }
+  ]
+}`
+
+func (suite *ImportOutboxTestSuite) archive() *multipart.FileHeader {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	w, err := zw.Create("outbox.json")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := w.Write([]byte(testOutbox)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	image, err := os.ReadFile("../../../testrig/media/beeplushie.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	w, err = zw.Create("media_attachments/files/beeplushie.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := w.Write(image); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b, mw, err := testrig.CreateMultipartFormData(
+		testrig.StringToDataF("data", "archive.zip", buf.String()),
+		nil,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(&b, mw.Boundary()).ReadForm(32 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return form.File["data"][0]
+}
+
+func (suite *ImportOutboxTestSuite) TestImportOutbox() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_2"]
+	)
+
+	apiImport, errWithCode := suite.accountProcessor.ImportOutbox(ctx, requester, suite.archive(), false)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("processing", apiImport.State)
+
+	// Only one import at a time.
+	_, errWithCode = suite.accountProcessor.ImportOutbox(ctx, requester, suite.archive(), false)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Run the queued import.
+	fn, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("no import processing queued")
+	}
+	fn(ctx)
+
+	apiImport, errWithCode = suite.accountProcessor.OutboxImportGet(ctx, requester, apiImport.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("finished", apiImport.State)
+	suite.Equal(5, apiImport.Total)
+	suite.Equal(2, apiImport.Imported)
+	suite.Equal(3, apiImport.Skipped)
+
+	// Someone else can't see the import.
+	_, errWithCode = suite.accountProcessor.OutboxImportGet(ctx, suite.testAccounts["local_account_1"], apiImport.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	statuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var first, reply *gtsmodel.Status
+	for _, status := range statuses {
+		switch {
+		case status.CreatedAt.Equal(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)):
+			first = status
+		case status.CreatedAt.Equal(time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC)):
+			reply = status
+		}
+	}
+
+	if first == nil || reply == nil {
+		suite.FailNow("imported statuses not found")
+	}
+
+	// First status should have been imported with
+	// its mention, hashtag and media.
+	suite.Equal(gtsmodel.VisibilityPublic, first.Visibility)
+	suite.True(*first.Local)
+	suite.Contains(first.Content, "hello")
+	suite.Len(first.Mentions, 1)
+	suite.Equal(suite.testAccounts["admin_account"].ID, first.Mentions[0].TargetAccountID)
+	suite.Len(first.Tags, 1)
+	suite.Equal("welcome", first.Tags[0].Name)
+	if suite.Len(first.Attachments, 1) {
+		suite.Equal("a bee plushie", first.Attachments[0].Description)
+		suite.Equal(float32(0.5), first.Attachments[0].FileMeta.Focus.X)
+	}
+
+	// Unlisted self-reply should be threaded with it.
+	suite.Equal(gtsmodel.VisibilityUnlocked, reply.Visibility)
+	suite.Equal(first.ID, reply.InReplyToID)
+	suite.Equal(first.ThreadID, reply.ThreadID)
+}
+
+func (suite *ImportOutboxTestSuite) TestImportOutboxBackdatingDisabled() {
+	ctx := context.Background()
+
+	config.SetInstanceAllowBackdatingStatuses(false)
+	defer config.SetInstanceAllowBackdatingStatuses(true)
+
+	_, errWithCode := suite.accountProcessor.ImportOutbox(ctx, suite.testAccounts["local_account_2"], suite.archive(), false)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func TestImportOutboxTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportOutboxTestSuite))
+}
@@ -38,8 +38,11 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 
 	// Take set "direct" policy
 	// or global default.
-	direct := cmp.Or(
-		requester.Settings.InteractionPolicyDirect,
+	direct := withDefaultCanQuote(
+		cmp.Or(
+			requester.Settings.InteractionPolicyDirect,
+			gtsmodel.DefaultInteractionPolicyDirect(),
+		),
 		gtsmodel.DefaultInteractionPolicyDirect(),
 	)
 
@@ -51,8 +54,11 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 
 	// Take set "private" policy
 	// or global default.
-	private := cmp.Or(
-		requester.Settings.InteractionPolicyFollowersOnly,
+	private := withDefaultCanQuote(
+		cmp.Or(
+			requester.Settings.InteractionPolicyFollowersOnly,
+			gtsmodel.DefaultInteractionPolicyFollowersOnly(),
+		),
 		gtsmodel.DefaultInteractionPolicyFollowersOnly(),
 	)
 
@@ -64,8 +70,11 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 
 	// Take set "unlisted" policy
 	// or global default.
-	unlisted := cmp.Or(
-		requester.Settings.InteractionPolicyUnlocked,
+	unlisted := withDefaultCanQuote(
+		cmp.Or(
+			requester.Settings.InteractionPolicyUnlocked,
+			gtsmodel.DefaultInteractionPolicyUnlocked(),
+		),
 		gtsmodel.DefaultInteractionPolicyUnlocked(),
 	)
 
@@ -77,8 +86,11 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 
 	// Take set "public" policy
 	// or global default.
-	public := cmp.Or(
-		requester.Settings.InteractionPolicyPublic,
+	public := withDefaultCanQuote(
+		cmp.Or(
+			requester.Settings.InteractionPolicyPublic,
+			gtsmodel.DefaultInteractionPolicyPublic(),
+		),
 		gtsmodel.DefaultInteractionPolicyPublic(),
 	)
 
@@ -182,6 +194,22 @@ func (p *Processor) DefaultInteractionPoliciesUpdate(
 	return p.DefaultInteractionPoliciesGet(ctx, requester)
 }
 
+// withDefaultCanQuote returns the given policy, or a
+// copy of it with quote rules taken from def if policy
+// was stored before quote rules were introduced.
+func withDefaultCanQuote(
+	policy *gtsmodel.InteractionPolicy,
+	def *gtsmodel.InteractionPolicy,
+) *gtsmodel.InteractionPolicy {
+	if !policy.CanQuote.IsEmpty() {
+		return policy
+	}
+
+	withQuote := *policy
+	withQuote.CanQuote = def.CanQuote
+	return &withQuote
+}
+
 // populateAccountSettings just ensures that
 // Settings is populated on the given account.
 func (p *Processor) populateAccountSettings(
@@ -23,6 +23,7 @@
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"code.superseriousbusiness.org/gotosocial/testrig"
 	"github.com/stretchr/testify/suite"
 )
@@ -77,6 +78,199 @@ func (suite *AccountTestSuite) TestAccountActionSuspend() {
 	suite.NotZero(targetAcct.SuspendedAt)
 }
 
+// runAccountAction runs an admin action of the given type
+// on the given target account, waits for it to complete,
+// and returns the target account as it is after the action.
+func (suite *AccountTestSuite) runAccountAction(
+	actionType gtsmodel.AdminActionType,
+	text string,
+	sendEmail *bool,
+	targetAcct *gtsmodel.Account,
+) (*gtsmodel.AdminAction, *gtsmodel.Account) {
+	ctx := context.Background()
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		suite.testAccounts["admin_account"],
+		&apimodel.AdminActionRequest{
+			Category:  gtsmodel.AdminActionCategoryAccount.String(),
+			Type:      actionType.String(),
+			Text:      text,
+			SendEmail: sendEmail,
+			TargetID:  targetAcct.ID,
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.state.AdminActions.TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+
+	targetAcct, err = suite.db.GetAccountByID(ctx, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return adminAction, targetAcct
+}
+
+func (suite *AccountTestSuite) TestAccountActionSilence() {
+	targetAcct := suite.testAccounts["local_account_1"]
+
+	adminAction, targetAcct := suite.runAccountAction(
+		gtsmodel.AdminActionSilence,
+		"please stop spamming the local timeline",
+		nil,
+		targetAcct,
+	)
+	suite.True(*adminAction.SendEmail)
+	suite.True(targetAcct.IsSilenced())
+
+	// User should have been
+	// warned by email by default.
+	suite.Len(suite.sentEmails, 1)
+	suite.Contains(suite.sentEmails["zork@example.org"], "Your account has been silenced.")
+	suite.Contains(suite.sentEmails["zork@example.org"], "please stop spamming the local timeline")
+
+	// Reverse the action, this
+	// shouldn't send another email.
+	adminAction, targetAcct = suite.runAccountAction(
+		gtsmodel.AdminActionUnsilence,
+		"",
+		nil,
+		targetAcct,
+	)
+	suite.False(*adminAction.SendEmail)
+	suite.False(targetAcct.IsSilenced())
+	suite.Len(suite.sentEmails, 1)
+}
+
+func (suite *AccountTestSuite) TestAccountActionSensitizeNoEmail() {
+	targetAcct := suite.testAccounts["remote_account_1"]
+
+	_, targetAcct = suite.runAccountAction(
+		gtsmodel.AdminActionSensitize,
+		"",
+		util.Ptr(false),
+		targetAcct,
+	)
+	suite.True(targetAcct.IsSensitized())
+	suite.Empty(suite.sentEmails)
+
+	_, targetAcct = suite.runAccountAction(
+		gtsmodel.AdminActionUnsensitize,
+		"",
+		nil,
+		targetAcct,
+	)
+	suite.False(targetAcct.IsSensitized())
+}
+
+func (suite *AccountTestSuite) TestAccountActionDisable() {
+	var (
+		ctx        = context.Background()
+		targetAcct = suite.testAccounts["local_account_1"]
+	)
+
+	suite.runAccountAction(gtsmodel.AdminActionDisable, "", nil, targetAcct)
+
+	user, err := suite.db.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*user.Disabled)
+	suite.Contains(suite.sentEmails["zork@example.org"], "Your account has been disabled.")
+
+	suite.runAccountAction(gtsmodel.AdminActionReenable, "", nil, targetAcct)
+
+	user, err = suite.db.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*user.Disabled)
+}
+
+func (suite *AccountTestSuite) TestAccountActionDisableRemote() {
+	_, errWithCode := suite.adminProcessor.AccountAction(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		&apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionDisable.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		},
+	)
+	suite.EqualError(errWithCode, "admin action type disable can only be performed on local accounts")
+}
+
+func (suite *AccountTestSuite) TestAccountActionWarning() {
+	targetAcct := suite.testAccounts["local_account_1"]
+
+	adminAction, _ := suite.runAccountAction(
+		gtsmodel.AdminActionNone,
+		"this is your first and last warning",
+		nil,
+		targetAcct,
+	)
+	suite.Equal(gtsmodel.AdminActionNone, adminAction.Type)
+	suite.Contains(suite.sentEmails["zork@example.org"], "this is your first and last warning")
+}
+
+func (suite *AccountTestSuite) TestAccountActionUnsuspend() {
+	targetAcct := suite.testAccounts["remote_account_1"]
+
+	// Account not suspended yet.
+	_, errWithCode := suite.adminProcessor.AccountAction(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		&apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionUnsuspend.String(),
+			TargetID: targetAcct.ID,
+		},
+	)
+	suite.EqualError(errWithCode, "account is not suspended")
+
+	_, targetAcct = suite.runAccountAction(gtsmodel.AdminActionSuspend, "", nil, targetAcct)
+	suite.True(targetAcct.IsSuspended())
+
+	_, targetAcct = suite.runAccountAction(gtsmodel.AdminActionUnsuspend, "", nil, targetAcct)
+	suite.False(targetAcct.IsSuspended())
+	suite.Empty(targetAcct.SuspensionOrigin)
+}
+
+func (suite *AccountTestSuite) TestAccountActionUnsuspendLocal() {
+	targetAcct := suite.testAccounts["local_account_1"]
+
+	_, targetAcct = suite.runAccountAction(gtsmodel.AdminActionSuspend, "", nil, targetAcct)
+	suite.True(targetAcct.IsSuspended())
+
+	// Suspension deleted the account,
+	// so it can't be unsuspended.
+	_, errWithCode := suite.adminProcessor.AccountAction(
+		context.Background(),
+		suite.testAccounts["admin_account"],
+		&apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     gtsmodel.AdminActionUnsuspend.String(),
+			TargetID: targetAcct.ID,
+		},
+	)
+	suite.EqualError(errWithCode, "local accounts are deleted when suspended, and can't be unsuspended")
+}
+
 func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 	var (
 		ctx       = context.Background()
@@ -94,7 +288,7 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 		adminAcct,
 		request,
 	)
-	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\"]")
+	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"none\" \"sensitive\" \"unsensitive\" \"disable\" \"reenable\" \"silence\" \"unsilence\" \"suspend\" \"unsuspend\"]")
 	suite.Empty(actionID)
 }
 
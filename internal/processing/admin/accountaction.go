@@ -19,36 +19,108 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/email"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/messages"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 )
 
+// accountActionF is the signature of a function that
+// applies one type of admin action to the target account.
+type accountActionF func(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error
+
 func (p *Processor) AccountAction(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
 	request *apimodel.AdminActionRequest,
 ) (string, gtserror.WithCode) {
 	targetAcct, err := p.state.DB.GetAccountByID(ctx, request.TargetID)
-	if err != nil {
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err := gtserror.Newf("db error getting target account: %w", err)
 		return "", gtserror.NewErrorInternalError(err)
 	}
 
-	switch gtsmodel.ParseAdminActionType(request.Type) {
+	if targetAcct == nil {
+		err := gtserror.Newf("target account %s not found", request.TargetID)
+		return "", gtserror.NewErrorNotFound(err)
+	}
+
+	if targetAcct.IsInstance() {
+		const text = "admin actions cannot be performed on the instance account"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	var (
+		actionType = gtsmodel.ParseAdminActionType(request.Type)
+		action     accountActionF
+	)
+
+	switch actionType {
+	case gtsmodel.AdminActionNone:
+		// Warning only, nothing to do
+		// beyond recording the action
+		// and emailing the user.
+		action = nil
+
+	case gtsmodel.AdminActionSensitize:
+		action = p.accountActionSensitize
+
+	case gtsmodel.AdminActionUnsensitize:
+		action = p.accountActionUnsensitize
+
+	case gtsmodel.AdminActionDisable, gtsmodel.AdminActionReenable:
+		if !targetAcct.IsLocal() {
+			err := fmt.Errorf("admin action type %s can only be performed on local accounts", actionType)
+			return "", gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if actionType == gtsmodel.AdminActionDisable {
+			action = p.accountActionDisable
+		} else {
+			action = p.accountActionReenable
+		}
+
+	case gtsmodel.AdminActionSilence:
+		action = p.accountActionSilence
+
+	case gtsmodel.AdminActionUnsilence:
+		action = p.accountActionUnsilence
+
 	case gtsmodel.AdminActionSuspend:
-		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
+		action = p.accountActionSuspend
+
+	case gtsmodel.AdminActionUnsuspend:
+		if errWithCode := p.checkUnsuspend(ctx, targetAcct); errWithCode != nil {
+			return "", errWithCode
+		}
+
+		action = p.accountActionUnsuspend
 
 	default:
-		// TODO: add more types to this slice when adding
-		//       more types to the switch statement above.
 		supportedTypes := []string{
+			gtsmodel.AdminActionNone.String(),
+			gtsmodel.AdminActionSensitize.String(),
+			gtsmodel.AdminActionUnsensitize.String(),
+			gtsmodel.AdminActionDisable.String(),
+			gtsmodel.AdminActionReenable.String(),
+			gtsmodel.AdminActionSilence.String(),
+			gtsmodel.AdminActionUnsilence.String(),
 			gtsmodel.AdminActionSuspend.String(),
+			gtsmodel.AdminActionUnsuspend.String(),
 		}
 
 		err := fmt.Errorf(
@@ -58,45 +130,256 @@ func (p *Processor) AccountAction(
 
 		return "", gtserror.NewErrorBadRequest(err, err.Error())
 	}
+
+	// Email by default, unless caller specifies otherwise.
+	// Reversals of actions are never emailed to the user.
+	sendEmail := util.PtrOrValue(request.SendEmail, true)
+	switch actionType {
+	case gtsmodel.AdminActionUnsensitize,
+		gtsmodel.AdminActionReenable,
+		gtsmodel.AdminActionUnsilence,
+		gtsmodel.AdminActionUnsuspend:
+		sendEmail = false
+	}
+
+	adminAction := &gtsmodel.AdminAction{
+		ID:             id.NewULID(),
+		TargetCategory: gtsmodel.AdminActionCategoryAccount,
+		TargetID:       targetAcct.ID,
+		Target:         targetAcct,
+		Type:           actionType,
+		AccountID:      adminAcct.ID,
+		Text:           request.Text,
+		SendEmail:      &sendEmail,
+	}
+
+	errWithCode := p.state.AdminActions.Run(
+		ctx,
+		adminAction,
+		func(ctx context.Context) gtserror.MultiError {
+			var errs gtserror.MultiError
+
+			if action != nil {
+				if err := action(ctx, adminAcct, targetAcct); err != nil {
+					// Don't email the user
+					// about a failed action.
+					errs.Append(err)
+					return errs
+				}
+			}
+
+			if sendEmail {
+				if err := p.emailAccountAction(ctx, adminAction, targetAcct); err != nil {
+					errs.Append(err)
+				}
+			}
+
+			return errs
+		},
+	)
+
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	return adminAction.ID, nil
+}
+
+func (p *Processor) accountActionSensitize(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	targetAcct.SensitizedAt = time.Now()
+	return p.state.DB.UpdateAccount(ctx, targetAcct, "sensitized_at")
+}
+
+func (p *Processor) accountActionUnsensitize(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	targetAcct.SensitizedAt = time.Time{}
+	return p.state.DB.UpdateAccount(ctx, targetAcct, "sensitized_at")
+}
+
+func (p *Processor) accountActionSilence(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	targetAcct.SilencedAt = time.Now()
+	return p.state.DB.UpdateAccount(ctx, targetAcct, "silenced_at")
+}
+
+func (p *Processor) accountActionUnsilence(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	targetAcct.SilencedAt = time.Time{}
+	return p.state.DB.UpdateAccount(ctx, targetAcct, "silenced_at")
+}
+
+func (p *Processor) accountActionDisable(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	return p.setUserDisabled(ctx, targetAcct, true)
+}
+
+func (p *Processor) accountActionReenable(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	return p.setUserDisabled(ctx, targetAcct, false)
+}
+
+// setUserDisabled sets the disabled flag on the
+// user corresponding to the given local account.
+func (p *Processor) setUserDisabled(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+	disabled bool,
+) error {
+	if targetAcct.IsGroup() {
+		// Local groups have no
+		// user to be disabled.
+		return nil
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	user.Disabled = &disabled
+	if err := p.state.DB.UpdateUser(ctx, user, "disabled"); err != nil {
+		return gtserror.Newf("db error updating user: %w", err)
+	}
+
+	return nil
 }
 
 func (p *Processor) accountActionSuspend(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
 	targetAcct *gtsmodel.Account,
-	text string,
-) (string, gtserror.WithCode) {
-	actionID := id.NewULID()
-
-	errWithCode := p.state.AdminActions.Run(
+) error {
+	return p.state.Workers.Client.Process(
 		ctx,
-		&gtsmodel.AdminAction{
-			ID:             actionID,
-			TargetCategory: gtsmodel.AdminActionCategoryAccount,
-			TargetID:       targetAcct.ID,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ActorPerson,
+			APActivityType: ap.ActivityDelete,
+			Origin:         adminAcct,
 			Target:         targetAcct,
-			Type:           gtsmodel.AdminActionSuspend,
-			AccountID:      adminAcct.ID,
-			Text:           text,
 		},
-		func(ctx context.Context) gtserror.MultiError {
-			if err := p.state.Workers.Client.Process(
-				ctx,
-				&messages.FromClientAPI{
-					APObjectType:   ap.ActorPerson,
-					APActivityType: ap.ActivityDelete,
-					Origin:         adminAcct,
-					Target:         targetAcct,
-				},
-			); err != nil {
-				errs := gtserror.NewMultiError(1)
-				errs.Append(err)
-				return errs
-			}
+	)
+}
 
-			return nil
-		},
+// checkUnsuspend checks whether the given
+// account is in a state to be unsuspended.
+func (p *Processor) checkUnsuspend(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+) gtserror.WithCode {
+	if !targetAcct.IsSuspended() {
+		const text = "account is not suspended"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if targetAcct.IsLocal() {
+		// Suspending a local account deletes it: its content
+		// is removed, its followers are dropped, and a Delete
+		// of the actor is federated, after which other servers
+		// won't accept it again. Since none of this can be
+		// undone, lifting the suspension would only leave a
+		// broken account behind, so refuse to do so.
+		const text = "local accounts are deleted when suspended, and can't be unsuspended"
+		return gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	// Remote accounts suspended because of a domain block
+	// can't be unsuspended while the domain block remains,
+	// as they'd just get suspended again on next deref.
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, targetAcct.Domain)
+	if err != nil {
+		err := gtserror.Newf("db error checking domain block: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		const text = "account's domain is blocked, remove the domain block to unsuspend it"
+		return gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	return nil
+}
+
+func (p *Processor) accountActionUnsuspend(
+	ctx context.Context,
+	_ *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+) error {
+	// Account content was removed on
+	// suspension, so all we can do here
+	// is lift the suspension itself. Only
+	// remote accounts get this far (see
+	// checkUnsuspend), and they'll be
+	// refreshed on next dereference.
+	targetAcct.SuspendedAt = time.Time{}
+	targetAcct.SuspensionOrigin = ""
+	return p.state.DB.UpdateAccount(ctx,
+		targetAcct,
+		"suspended_at",
+		"suspension_origin",
 	)
+}
+
+// emailAccountAction emails the user of the
+// target account to inform them of the action
+// taken, if they're a local user with an email.
+func (p *Processor) emailAccountAction(
+	ctx context.Context,
+	adminAction *gtsmodel.AdminAction,
+	targetAcct *gtsmodel.Account,
+) error {
+	if !targetAcct.IsLocal() || targetAcct.IsGroup() {
+		// Can only email our own
+		// users; groups have none.
+		return nil
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if user.ConfirmedAt.IsZero() || user.Email == "" {
+		// Only email users who
+		// have a confirmed email.
+		return nil
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	if err := p.email.SendAccountActionEmail(
+		user.Email,
+		email.AccountActionData{
+			Username:     targetAcct.Username,
+			InstanceURL:  instance.URI,
+			InstanceName: instance.Title,
+			ActionType:   adminAction.Type.String(),
+			Text:         adminAction.Text,
+		},
+	); err != nil {
+		return gtserror.Newf("error sending email: %w", err)
+	}
 
-	return actionID, errWithCode
+	return nil
 }
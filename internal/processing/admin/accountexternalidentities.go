@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+)
+
+// AccountExternalIdentitiesGet returns the identities at
+// OIDC providers through which the given local account
+// can sign in, oldest first.
+func (p *Processor) AccountExternalIdentitiesGet(
+	ctx context.Context,
+	accountID string,
+) ([]*apimodel.AdminExternalIdentity, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil || !account.IsLocal() {
+		err := fmt.Errorf("local account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil {
+		err := gtserror.Newf("db error getting user for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	identities, err := p.state.DB.GetExternalIdentitiesByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting external identities for user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiIdentities := make([]*apimodel.AdminExternalIdentity, 0, len(identities))
+	for _, identity := range identities {
+		apiIdentities = append(apiIdentities, p.converter.ExternalIdentityToAdminAPIExternalIdentity(identity))
+	}
+
+	return apiIdentities, nil
+}
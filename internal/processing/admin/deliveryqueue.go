@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// DeliveryQueueGet returns the state of outgoing deliveries
+// per target domain, i.e. the number of deliveries queued
+// and whether deliveries to the domain are currently paused.
+// Domains are returned in descending order of queue depth.
+func (p *Processor) DeliveryQueueGet(ctx context.Context) ([]*apimodel.AdminDeliveryQueueDomain, gtserror.WithCode) {
+	var domains []*apimodel.AdminDeliveryQueueDomain
+	byDomain := make(map[string]*apimodel.AdminDeliveryQueueDomain)
+
+	if p.state.Workers.Delivery.DB != nil {
+		// Get queue depths of persisted deliveries.
+		counts, err := p.state.DB.CountQueuedDeliveriesByDomain(ctx)
+		if err != nil {
+			err := gtserror.Newf("db error counting queued deliveries: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		for _, count := range counts {
+			domain := &apimodel.AdminDeliveryQueueDomain{
+				Domain: count.Domain,
+				Queued: count.Count,
+			}
+			byDomain[domain.Domain] = domain
+			domains = append(domains, domain)
+		}
+	}
+
+	now := time.Now()
+
+	// Add current delivery states of target hosts.
+	for _, host := range p.state.Workers.Delivery.Hosts() {
+		domain, ok := byDomain[host.Host]
+		if !ok {
+			domain = &apimodel.AdminDeliveryQueueDomain{Domain: host.Host}
+			domains = append(domains, domain)
+		}
+
+		domain.InFlight = host.InFlight
+		domain.Failures = host.Failures
+		if host.OpenUntil.After(now) {
+			domain.PausedUntil = util.FormatISO8601(host.OpenUntil)
+		}
+	}
+
+	// Sort by queue depth, then domain.
+	slices.SortFunc(domains, func(a, b *apimodel.AdminDeliveryQueueDomain) int {
+		if c := cmp.Compare(b.Queued, a.Queued); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Domain, b.Domain)
+	})
+
+	return domains, nil
+}
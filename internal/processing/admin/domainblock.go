@@ -38,6 +38,9 @@ func (p *Processor) createDomainBlock(
 	publicComment string,
 	privateComment string,
 	subscriptionID string,
+	severity gtsmodel.DomainBlockSeverity,
+	rejectMedia bool,
+	sensitizeMedia bool,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
 	// Check if a block already exists for this domain.
 	domainBlock, err := p.state.DB.GetDomainBlock(ctx, domain)
@@ -48,6 +51,11 @@ func (p *Processor) createDomainBlock(
 	}
 
 	if domainBlock == nil {
+		if severity == gtsmodel.DomainBlockSeverityUnknown {
+			// Default to full suspension.
+			severity = gtsmodel.DomainBlockSeveritySuspend
+		}
+
 		// No block exists yet, create it.
 		domainBlock = &gtsmodel.DomainBlock{
 			ID:                 id.NewULID(),
@@ -57,6 +65,9 @@ func (p *Processor) createDomainBlock(
 			PublicComment:      text.StripHTMLFromText(publicComment),
 			Obfuscate:          &obfuscate,
 			SubscriptionID:     subscriptionID,
+			Severity:           severity,
+			RejectMedia:        &rejectMedia,
+			SensitizeMedia:     &sensitizeMedia,
 		}
 
 		// Insert the new block into the database.
@@ -77,6 +88,12 @@ func (p *Processor) createDomainBlock(
 		Text:           domainBlock.PrivateComment,
 	}
 
+	if domainBlock.IsLimit() {
+		// Limits are a softer
+		// action than suspension.
+		action.Type = gtsmodel.AdminActionSilence
+	}
+
 	if errWithCode := p.state.AdminActions.Run(
 		ctx,
 		action,
@@ -95,11 +112,15 @@ func (p *Processor) createDomainBlock(
 
 func (p *Processor) updateDomainBlock(
 	ctx context.Context,
+	adminAcct *gtsmodel.Account,
 	domainBlockID string,
 	obfuscate *bool,
 	publicComment *string,
 	privateComment *string,
 	subscriptionID *string,
+	severity *gtsmodel.DomainBlockSeverity,
+	rejectMedia *bool,
+	sensitizeMedia *bool,
 ) (*apimodel.DomainPermission, gtserror.WithCode) {
 	domainBlock, err := p.state.DB.GetDomainBlockByID(ctx, domainBlockID)
 	if err != nil {
@@ -131,6 +152,22 @@ func (p *Processor) updateDomainBlock(
 		domainBlock.SubscriptionID = *subscriptionID
 		columns = append(columns, "subscription_id")
 	}
+	if rejectMedia != nil {
+		domainBlock.RejectMedia = rejectMedia
+		columns = append(columns, "reject_media")
+	}
+	if sensitizeMedia != nil {
+		domainBlock.SensitizeMedia = sensitizeMedia
+		columns = append(columns, "sensitize_media")
+	}
+
+	// Check whether severity is changing, as
+	// that requires side effects to be processed.
+	severityChanged := severity != nil && *severity != domainBlock.Severity
+	if severityChanged {
+		domainBlock.Severity = *severity
+		columns = append(columns, "severity")
+	}
 
 	// Update the domain block.
 	if err := p.state.DB.UpdateDomainBlock(ctx, domainBlock, columns...); err != nil {
@@ -138,9 +175,54 @@ func (p *Processor) updateDomainBlock(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if severityChanged {
+		// Process side effects of the new severity.
+		if _, errWithCode := p.domainBlockSeverityChanged(ctx, adminAcct, domainBlock); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
 	return p.apiDomainPerm(ctx, domainBlock, false)
 }
 
+// domainBlockSeverityChanged runs an admin action to
+// process side effects of the given domain block's
+// severity having been changed, returning action ID.
+func (p *Processor) domainBlockSeverityChanged(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domainBlock *gtsmodel.DomainBlock,
+) (string, gtserror.WithCode) {
+	action := &gtsmodel.AdminAction{
+		ID:             id.NewULID(),
+		TargetCategory: gtsmodel.AdminActionCategoryDomain,
+		TargetID:       domainBlock.Domain,
+		AccountID:      adminAcct.ID,
+		Text:           domainBlock.PrivateComment,
+	}
+
+	var actionF func(context.Context) gtserror.MultiError
+	if domainBlock.IsLimit() {
+		// Downgraded from suspend to limit,
+		// process this as though the domain
+		// was unblocked, so that accounts
+		// suspended by the block are restored.
+		action.Type = gtsmodel.AdminActionUnsuspend
+		actionF = p.state.AdminActions.DomainUnblockF(action.ID, domainBlock)
+	} else {
+		// Upgraded from limit to suspend,
+		// process side effects of the block.
+		action.Type = gtsmodel.AdminActionSuspend
+		actionF = p.state.AdminActions.DomainBlockF(action.ID, domainBlock)
+	}
+
+	if errWithCode := p.state.AdminActions.Run(ctx, action, actionF); errWithCode != nil {
+		return action.ID, errWithCode
+	}
+
+	return action.ID, nil
+}
+
 func (p *Processor) deleteDomainBlock(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
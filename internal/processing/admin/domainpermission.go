@@ -40,6 +40,8 @@
 // If the same permission type already exists for the domain,
 // side effects will be retried.
 //
+// Severity, rejectMedia and sensitizeMedia only apply to blocks.
+//
 // Return values for this function are the new or existing
 // domain permission, the ID of the admin action resulting
 // from this call, and/or an error if something goes wrong.
@@ -52,6 +54,9 @@ func (p *Processor) DomainPermissionCreate(
 	publicComment string,
 	privateComment string,
 	subscriptionID string,
+	severity gtsmodel.DomainBlockSeverity,
+	rejectMedia bool,
+	sensitizeMedia bool,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
 	switch permissionType {
 
@@ -65,6 +70,9 @@ func (p *Processor) DomainPermissionCreate(
 			publicComment,
 			privateComment,
 			subscriptionID,
+			severity,
+			rejectMedia,
+			sensitizeMedia,
 		)
 
 	// Explicitly allow a domain.
@@ -88,14 +96,22 @@ func (p *Processor) DomainPermissionCreate(
 
 // DomainPermissionUpdate updates a domain permission
 // of the given permissionType, with the given ID.
+//
+// Severity, rejectMedia and sensitizeMedia only apply
+// to blocks. Changing the severity of a block processes
+// the side effects of suspending or unsuspending the domain.
 func (p *Processor) DomainPermissionUpdate(
 	ctx context.Context,
 	permissionType gtsmodel.DomainPermissionType,
+	adminAcct *gtsmodel.Account,
 	permID string,
 	obfuscate *bool,
 	publicComment *string,
 	privateComment *string,
 	subscriptionID *string,
+	severity *gtsmodel.DomainBlockSeverity,
+	rejectMedia *bool,
+	sensitizeMedia *bool,
 ) (*apimodel.DomainPermission, gtserror.WithCode) {
 	switch permissionType {
 
@@ -103,11 +119,15 @@ func (p *Processor) DomainPermissionUpdate(
 	case gtsmodel.DomainPermissionBlock:
 		return p.updateDomainBlock(
 			ctx,
+			adminAcct,
 			permID,
 			obfuscate,
 			publicComment,
 			privateComment,
 			subscriptionID,
+			severity,
+			rejectMedia,
+			sensitizeMedia,
 		)
 
 	// Explicitly allow a domain.
@@ -243,8 +263,25 @@ func (p *Processor) importOrUpdateDomainPerm(
 		publicComment  = cmp.Or(apiDomainPerm.PublicComment, apiDomainPerm.Comment)
 		privateComment = apiDomainPerm.PrivateComment
 		subscriptionID = "" // No sub ID for imports.
+		rejectMedia    = apiDomainPerm.RejectMedia
+		sensitizeMedia = apiDomainPerm.SensitizeMedia
+		severity       *gtsmodel.DomainBlockSeverity
 	)
 
+	// Parse severity if set. Only
+	// applicable to domain blocks.
+	if apiDomainPerm.Severity != "" && permType == gtsmodel.DomainPermissionBlock {
+		s := gtsmodel.ParseDomainBlockSeverity(apiDomainPerm.Severity)
+		if s == gtsmodel.DomainBlockSeverityUnknown {
+			return apimodel.MultiStatusEntry{
+				Resource: domain,
+				Message:  "unrecognized domain block severity " + apiDomainPerm.Severity,
+				Status:   http.StatusBadRequest,
+			}
+		}
+		severity = &s
+	}
+
 	// Check if this domain
 	// perm already exists.
 	var (
@@ -272,11 +309,15 @@ func (p *Processor) importOrUpdateDomainPerm(
 		apiDomainPerm, errWithCode = p.DomainPermissionUpdate(
 			ctx,
 			permType,
+			account,
 			domainPerm.GetID(),
 			obfuscate,
 			publicComment,
 			privateComment,
 			nil,
+			severity,
+			rejectMedia,
+			sensitizeMedia,
 		)
 	} else {
 		// Permission didn't exist yet, create it.
@@ -289,6 +330,9 @@ func (p *Processor) importOrUpdateDomainPerm(
 			util.PtrOrZero(publicComment),
 			util.PtrOrZero(privateComment),
 			subscriptionID,
+			util.PtrOrZero(severity),
+			util.PtrOrZero(rejectMedia),
+			util.PtrOrZero(sensitizeMedia),
 		)
 	}
 
@@ -24,9 +24,11 @@
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/filter/visibility"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"code.superseriousbusiness.org/gotosocial/testrig"
 	"github.com/stretchr/testify/suite"
 )
@@ -134,6 +136,9 @@ func (suite *DomainBlockTestSuite) createDomainPerm(
 		"",
 		"",
 		"",
+		gtsmodel.DomainBlockSeveritySuspend,
+		false,
+		false,
 	)
 	suite.NoError(errWithCode)
 	suite.NotNil(apiPerm)
@@ -526,6 +531,134 @@ func (suite *DomainBlockTestSuite) TestAllowAndBlockDomain() {
 	})
 }
 
+func (suite *DomainBlockTestSuite) TestLimitDomain() {
+	var (
+		ctx       = context.Background()
+		domain    = "fossbros-anonymous.io"
+		adminAcct = suite.testAccounts["admin_account"]
+		requester = suite.testAccounts["local_account_1"]
+		limited   = suite.testAccounts["remote_account_1"]
+	)
+
+	config.SetInstanceFederationMode(config.InstanceFederationModeBlocklist)
+
+	// Limit the domain.
+	apiPerm, actionID, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		adminAcct,
+		domain,
+		false,
+		"",
+		"",
+		"",
+		gtsmodel.DomainBlockSeverityLimit,
+		false,
+		true,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("limit", apiPerm.Severity)
+	suite.True(*apiPerm.SensitizeMedia)
+	suite.False(*apiPerm.RejectMedia)
+	suite.awaitAction(actionID)
+
+	// Domain is limited, but not blocked.
+	blocked, err := suite.db.IsDomainBlocked(ctx, domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	limitedDomain, err := suite.db.IsDomainLimited(ctx, "sub."+domain)
+	suite.NoError(err)
+	suite.True(limitedDomain)
+
+	domainLimit, err := suite.db.GetDomainLimit(ctx, "sub."+domain)
+	suite.NoError(err)
+	suite.Equal(apiPerm.ID, domainLimit.ID)
+
+	// Accounts on the domain should not be suspended.
+	accounts, err := suite.db.GetInstanceAccounts(ctx, domain, "", 0)
+	suite.NoError(err)
+	for _, account := range accounts {
+		suite.Zero(account.SuspendedAt)
+	}
+
+	// But they should be silenced to non-followers.
+	visFilter := visibility.NewFilter(&suite.state)
+	silenced, err := visFilter.AccountSilenced(ctx, requester, limited)
+	suite.NoError(err)
+	suite.True(silenced)
+
+	silenced, err = visFilter.AccountSilenced(ctx, nil, limited)
+	suite.NoError(err)
+	suite.True(silenced)
+
+	// Upgrade limit to suspend.
+	apiPerm, errWithCode = suite.adminProcessor.DomainPermissionUpdate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		adminAcct,
+		apiPerm.ID,
+		nil, nil, nil, nil,
+		util.Ptr(gtsmodel.DomainBlockSeveritySuspend),
+		nil, nil,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("suspend", apiPerm.Severity)
+
+	if !testrig.WaitFor(func() bool {
+		return suite.state.AdminActions.TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Domain is now blocked and not limited.
+	blocked, err = suite.db.IsDomainBlocked(ctx, domain)
+	suite.NoError(err)
+	suite.True(blocked)
+
+	limitedDomain, err = suite.db.IsDomainLimited(ctx, domain)
+	suite.NoError(err)
+	suite.False(limitedDomain)
+
+	// Accounts on the domain should now be suspended.
+	accounts, err = suite.db.GetInstanceAccounts(ctx, domain, "", 0)
+	suite.NoError(err)
+	for _, account := range accounts {
+		suite.NotZero(account.SuspendedAt)
+	}
+
+	// Downgrade suspend to limit again.
+	_, errWithCode = suite.adminProcessor.DomainPermissionUpdate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		adminAcct,
+		apiPerm.ID,
+		nil, nil, nil, nil,
+		util.Ptr(gtsmodel.DomainBlockSeverityLimit),
+		nil, nil,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		return suite.state.AdminActions.TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Accounts on the domain should be unsuspended.
+	accounts, err = suite.db.GetInstanceAccounts(ctx, domain, "", 0)
+	suite.NoError(err)
+	for _, account := range accounts {
+		suite.Zero(account.SuspendedAt)
+	}
+}
+
 func TestDomainBlockTestSuite(t *testing.T) {
 	suite.Run(t, new(DomainBlockTestSuite))
 }
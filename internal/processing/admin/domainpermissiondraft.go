@@ -125,7 +125,15 @@ func (p *Processor) DomainPermissionDraftCreate(
 	obfuscate bool,
 	publicComment string,
 	privateComment string,
+	severity gtsmodel.DomainBlockSeverity,
+	rejectMedia bool,
+	sensitizeMedia bool,
 ) (*apimodel.DomainPermission, gtserror.WithCode) {
+	if severity == gtsmodel.DomainBlockSeverityUnknown {
+		// Default to full suspension.
+		severity = gtsmodel.DomainBlockSeveritySuspend
+	}
+
 	permDraft := &gtsmodel.DomainPermissionDraft{
 		ID:                 id.NewULID(),
 		PermissionType:     permType,
@@ -135,6 +143,9 @@ func (p *Processor) DomainPermissionDraftCreate(
 		PrivateComment:     privateComment,
 		PublicComment:      publicComment,
 		Obfuscate:          &obfuscate,
+		Severity:           severity,
+		RejectMedia:        &rejectMedia,
+		SensitizeMedia:     &sensitizeMedia,
 	}
 
 	if err := p.state.DB.PutDomainPermissionDraft(ctx, permDraft); err != nil {
@@ -229,6 +240,9 @@ func (p *Processor) DomainPermissionDraftAccept(
 				permDraft.PublicComment,
 				permDraft.PrivateComment,
 				permDraft.SubscriptionID,
+				permDraft.Severity,
+				util.PtrOrZero(permDraft.RejectMedia),
+				util.PtrOrZero(permDraft.SensitizeMedia),
 			)
 		}
 
@@ -253,7 +267,8 @@ func (p *Processor) DomainPermissionDraftAccept(
 
 	// Domain permission exists but we should overwrite
 	// it by just updating the existing domain permission.
-	// Domain can't change, so no need to re-run side effects.
+	// Domain can't change, so no need to re-run side effects,
+	// unless the severity of an existing block changes.
 	existing.SetCreatedByAccountID(permDraft.CreatedByAccountID)
 	existing.SetCreatedByAccount(permDraft.CreatedByAccount)
 	existing.SetPrivateComment(permDraft.PrivateComment)
@@ -261,8 +276,13 @@ func (p *Processor) DomainPermissionDraftAccept(
 	existing.SetObfuscate(permDraft.Obfuscate)
 	existing.SetSubscriptionID(permDraft.SubscriptionID)
 
+	var severityChanged bool
 	switch dp := existing.(type) {
 	case *gtsmodel.DomainBlock:
+		severityChanged = dp.Severity != permDraft.Severity
+		dp.Severity = permDraft.Severity
+		dp.RejectMedia = permDraft.RejectMedia
+		dp.SensitizeMedia = permDraft.SensitizeMedia
 		err = p.state.DB.UpdateDomainBlock(ctx, dp)
 
 	case *gtsmodel.DomainAllow:
@@ -278,8 +298,22 @@ func (p *Processor) DomainPermissionDraftAccept(
 	// before returning.
 	deleteDraft()
 
+	var (
+		actionID    string
+		errWithCode gtserror.WithCode
+	)
+
+	if severityChanged {
+		// Process side effects of the new severity.
+		block := existing.(*gtsmodel.DomainBlock)
+		actionID, errWithCode = p.domainBlockSeverityChanged(ctx, acct, block)
+		if errWithCode != nil {
+			return nil, actionID, errWithCode
+		}
+	}
+
 	apiPerm, errWithCode := p.apiDomainPerm(ctx, existing, false)
-	return apiPerm, "", errWithCode
+	return apiPerm, actionID, errWithCode
 }
 
 func (p *Processor) DomainPermissionDraftRemove(
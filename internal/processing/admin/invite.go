@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// InvitesGet returns a page of invites, newest first,
+// optionally only those created by the given local account.
+func (p *Processor) InvitesGet(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	var userID string
+	if accountID != "" {
+		user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting user for account %s: %w", accountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if user == nil {
+			err := fmt.Errorf("local account %s not found", accountID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		userID = user.ID
+	}
+
+	invites, err := p.state.DB.GetInvites(ctx, userID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(invites)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := invites[count-1].ID
+	hi := invites[0].ID
+
+	// Convert each invite to API model.
+	items := make([]any, len(invites))
+	for i, invite := range invites {
+		apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite, true)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		items[i] = apiInvite
+	}
+
+	// Assemble next/prev page queries.
+	query := make(url.Values, 1)
+	if accountID != "" {
+		query.Set(apiutil.AccountIDKey, accountID)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/invites",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// InviteRevoke revokes the invite with the given ID,
+// so that it can no longer be used to sign up.
+func (p *Processor) InviteRevoke(
+	ctx context.Context,
+	id string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		err := fmt.Errorf("invite %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if !invite.IsRevoked() {
+		invite.RevokedAt = time.Now()
+		if err := p.state.DB.UpdateInvite(ctx, invite, "revoked_at"); err != nil {
+			err := gtserror.Newf("db error updating invite %s: %w", id, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite, true)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
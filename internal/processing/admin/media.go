@@ -61,6 +61,7 @@ func (p *Processor) MediaPrune(ctx context.Context, mediaRemoteCacheDays int) gt
 		ctx := context.Background()
 		p.cleaner.Media().All(ctx, mediaRemoteCacheDays)
 		p.cleaner.Emoji().All(ctx, mediaRemoteCacheDays)
+		p.cleaner.PreviewCard().All(ctx, mediaRemoteCacheDays)
 	}()
 
 	return nil
@@ -0,0 +1,318 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"code.superseriousbusiness.org/activity/streams/vocab"
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+)
+
+// RelaysGet returns all relays known to this instance.
+func (p *Processor) RelaysGet(ctx context.Context) ([]*apimodel.AdminRelay, gtserror.WithCode) {
+	relays, err := p.state.DB.GetRelays(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relays: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRelays := make([]*apimodel.AdminRelay, len(relays))
+	for i, relay := range relays {
+		apiRelays[i] = typeutils.RelayToAdminAPIRelay(relay)
+	}
+
+	return apiRelays, nil
+}
+
+// RelayGet returns one relay with the given ID.
+func (p *Processor) RelayGet(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return typeutils.RelayToAdminAPIRelay(relay), nil
+}
+
+// RelayCreate adds a new relay with the given URL,
+// and sends a Follow to it from the instance actor.
+//
+// A URL ending in "/inbox" is taken to be the inbox of
+// a Mastodon-style relay. Any other URL is taken to be
+// the actor of a LitePub relay, and dereferenced.
+func (p *Processor) RelayCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	relayURL string,
+) (*apimodel.AdminRelay, gtserror.WithCode) {
+	u, err := url.Parse(relayURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		const text = "url must be a valid http(s) URL"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	relay := &gtsmodel.Relay{
+		ID:                 id.NewULID(),
+		CreatedByAccountID: adminAcct.ID,
+		State:              gtsmodel.RelayStatePending,
+	}
+
+	if strings.HasSuffix(u.Path, "/inbox") {
+		// Mastodon-style relay, we
+		// already know where to deliver.
+		relay.Type = gtsmodel.RelayTypeMastodon
+		relay.InboxURI = u.String()
+	} else {
+		// LitePub relay, dereference
+		// the actor to find its inbox.
+		relay.Type = gtsmodel.RelayTypeLitePub
+
+		instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+		if err != nil {
+			err := gtserror.Newf("db error getting instance account: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		actor, _, err := p.federator.GetAccountByURI(ctx,
+			instanceAcct.Username,
+			u,
+			false,
+		)
+		if err != nil {
+			err := gtserror.Newf("error dereferencing relay actor %s: %w", u, err)
+			const text = "url could not be dereferenced as a relay actor"
+			return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+		}
+
+		relay.ActorURI = actor.URI
+		relay.InboxURI = actor.InboxURI
+		if actor.SharedInboxURI != nil && *actor.SharedInboxURI != "" {
+			relay.InboxURI = *actor.SharedInboxURI
+		}
+	}
+
+	// Ensure we don't already have this relay.
+	existing, err := p.state.DB.GetRelayByInboxURI(ctx, relay.InboxURI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		err := fmt.Errorf("relay with inbox %s already exists", relay.InboxURI)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	if errWithCode := p.relayFollow(ctx, relay, true); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return typeutils.RelayToAdminAPIRelay(relay), nil
+}
+
+// RelayEnable (re)sends a Follow to the relay with the
+// given ID, if it's currently idle or has been rejected.
+func (p *Processor) RelayEnable(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	switch relay.State {
+	case gtsmodel.RelayStatePending, gtsmodel.RelayStateAccepted:
+		// Already enabled.
+
+	default:
+		relay.State = gtsmodel.RelayStatePending
+		if errWithCode := p.relayFollow(ctx, relay, false); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	return typeutils.RelayToAdminAPIRelay(relay), nil
+}
+
+// RelayDisable sends an Undo of the Follow of
+// the relay with the given ID, and marks it idle.
+func (p *Processor) RelayDisable(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if relay.State == gtsmodel.RelayStateIdle {
+		// Already disabled.
+		return typeutils.RelayToAdminAPIRelay(relay), nil
+	}
+
+	if errWithCode := p.relayUnfollow(ctx, relay); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	relay.State = gtsmodel.RelayStateIdle
+	if err := p.state.DB.UpdateRelay(ctx, relay, "state"); err != nil {
+		err := gtserror.Newf("db error updating relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return typeutils.RelayToAdminAPIRelay(relay), nil
+}
+
+// RelayDelete sends an Undo of the Follow of the
+// relay with the given ID, and then deletes it.
+func (p *Processor) RelayDelete(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if relay.State != gtsmodel.RelayStateIdle {
+		if errWithCode := p.relayUnfollow(ctx, relay); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if err := p.state.DB.DeleteRelayByID(ctx, relay.ID); err != nil {
+		err := gtserror.Newf("db error deleting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return typeutils.RelayToAdminAPIRelay(relay), nil
+}
+
+func (p *Processor) getRelay(ctx context.Context, id string) (*gtsmodel.Relay, gtserror.WithCode) {
+	relay, err := p.state.DB.GetRelayByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay == nil {
+		err := fmt.Errorf("relay %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, "relay not found")
+	}
+
+	return relay, nil
+}
+
+// relayFollow generates a new Follow URI for the given
+// relay, stores it (inserting the relay if isNew), then
+// delivers the Follow to the relay from the instance actor.
+func (p *Processor) relayFollow(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	isNew bool,
+) gtserror.WithCode {
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Each Follow needs a fresh URI, so
+	// we can tell responses to it apart.
+	relay.FollowURI = uris.GenerateURIForFollow(
+		instanceAcct.Username,
+		id.NewULID(),
+	)
+
+	if isNew {
+		err = p.state.DB.PutRelay(ctx, relay)
+	} else {
+		err = p.state.DB.UpdateRelay(ctx, relay, "follow_uri", "state")
+	}
+	if err != nil {
+		err := gtserror.Newf("db error storing relay: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	follow, err := p.converter.RelayToASFollow(ctx, relay)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to Follow: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return p.relayDeliver(ctx, instanceAcct, relay, follow)
+}
+
+// relayUnfollow delivers an Undo of the current
+// Follow of the relay from the instance actor.
+func (p *Processor) relayUnfollow(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+) gtserror.WithCode {
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	undo, err := p.converter.RelayToASUndo(ctx, relay)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to Undo: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return p.relayDeliver(ctx, instanceAcct, relay, undo)
+}
+
+// relayDeliver queues the given activity for
+// delivery to the relay's inbox, signed by
+// the instance actor.
+func (p *Processor) relayDeliver(
+	ctx context.Context,
+	instanceAcct *gtsmodel.Account,
+	relay *gtsmodel.Relay,
+	t vocab.Type,
+) gtserror.WithCode {
+	inbox, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		err := gtserror.Newf("error parsing relay inbox: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	tsport, err := p.transport.NewTransportForUsername(ctx, instanceAcct.Username)
+	if err != nil {
+		err := gtserror.Newf("error getting instance transport: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	m, err := ap.Serialize(t)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := tsport.Deliver(ctx, m, inbox); err != nil {
+		err := gtserror.Newf("error delivering %T to relay: %w", t, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
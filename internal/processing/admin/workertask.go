@@ -133,6 +133,65 @@ func (p *Processor) FillWorkerQueues(ctx context.Context) error {
 		WithField("errors", errors).
 		Info("recovered queued tasks")
 
+	// Recover any write-ahead persisted deliveries
+	// left over from a previous run, e.g. from crash.
+	if err := p.fillDeliveries(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// fillDeliveries recovers all persisted queued deliveries from the database
+// that were persisted before the delivery worker pool was initialized (i.e.
+// by a previous run), and pushes them to the delivery queue. Those persisted
+// by this run will already be queued, so are not fetched.
+func (p *Processor) fillDeliveries(ctx context.Context) error {
+	if p.state.Workers.Delivery.DB == nil {
+		// Persistence
+		// not enabled.
+		return nil
+	}
+
+	// Get all queued deliveries persisted before this run.
+	maxID := p.state.Workers.Delivery.InitID()
+	queued, err := p.state.DB.GetQueuedDeliveries(ctx, maxID)
+	if err != nil {
+		return gtserror.Newf("error fetching queued deliveries from db: %w", err)
+	}
+
+	var recovered, errors int
+
+	for _, q := range queued {
+		dlv := new(delivery.Delivery)
+
+		// Restore delivery from its persisted model.
+		if err := dlv.Restore(q); err != nil {
+			log.Errorf(ctx, "error restoring delivery %s: %v", q.ID, err)
+		} else if err := p.signDelivery(ctx, dlv); err != nil {
+			log.Errorf(ctx, "error signing delivery %s: %v", q.ID, err)
+		} else {
+			// Push recovered delivery to queue, this
+			// remains persisted until delivered / dropped.
+			p.state.Workers.Delivery.Queue.Push(dlv)
+			recovered++
+			continue
+		}
+
+		// Drop unrecoverable delivery.
+		if err := p.state.DB.DeleteQueuedDeliveryByID(ctx, q.ID); err != nil {
+			log.Errorf(ctx, "error deleting delivery from db: %v", err)
+		}
+
+		errors++
+	}
+
+	// Log recovered deliveries.
+	log.WithContext(ctx).
+		WithField("recovered", recovered).
+		WithField("errors", errors).
+		Info("recovered queued deliveries")
+
 	return nil
 }
 
@@ -239,6 +298,20 @@ func (p *Processor) pushDelivery(ctx context.Context, task *gtsmodel.WorkerTask)
 		return gtserror.Newf("error deserializing delivery: %w", err)
 	}
 
+	// Re-sign the deserialized delivery.
+	if err := p.signDelivery(ctx, dlv); err != nil {
+		return err
+	}
+
+	// Push deserialized task to delivery queue.
+	p.state.Workers.Delivery.Queue.Push(dlv)
+
+	return nil
+}
+
+// signDelivery adds the signature of the delivery's actor (or
+// the instance account, if none) to the given delivery request.
+func (p *Processor) signDelivery(ctx context.Context, dlv *delivery.Delivery) error {
 	var tsport transport.Transport
 
 	if uri := dlv.ActorID; uri != "" {
@@ -268,19 +341,29 @@ func (p *Processor) pushDelivery(ctx context.Context, task *gtsmodel.WorkerTask)
 		return gtserror.Newf("error signing delivery: %w", err)
 	}
 
-	// Push deserialized task to delivery queue.
-	p.state.Workers.Delivery.Queue.Push(dlv)
-
 	return nil
 }
 
 // popDelivery pops delivery.Delivery{} from queue and serializes as valid task data.
 func (p *Processor) popDelivery() (*gtsmodel.WorkerTask, error) {
+	var delivery *delivery.Delivery
 
-	// Pop waiting delivery from the delivery worker.
-	delivery, ok := p.state.Workers.Delivery.Queue.Pop()
-	if !ok {
-		return nil, nil
+	for {
+		var ok bool
+
+		// Pop waiting delivery from the delivery worker.
+		delivery, ok = p.state.Workers.Delivery.Queue.Pop()
+		if !ok {
+			return nil, nil
+		}
+
+		if delivery.ID == "" {
+			break
+		}
+
+		// Deliveries with an ID are already
+		// persisted in the queued deliveries
+		// table, and will be recovered on start.
 	}
 
 	// Serialize the delivery task data.
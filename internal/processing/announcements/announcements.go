@@ -0,0 +1,127 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/stream"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	state            *state.State
+	converter        *typeutils.Converter
+	formatter        *text.Formatter
+	parseMentionFunc gtsmodel.ParseMentionFunc
+	stream           *stream.Processor
+}
+
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+	stream *stream.Processor,
+	parseMentionFunc gtsmodel.ParseMentionFunc,
+) Processor {
+	return Processor{
+		state:            state,
+		converter:        converter,
+		formatter:        text.NewFormatter(state.DB),
+		parseMentionFunc: parseMentionFunc,
+		stream:           stream,
+	}
+}
+
+// getAnnouncement fetches the announcement with given ID,
+// returning 404 if it doesn't exist or, when onlyActive
+// is set, if it isn't currently shown to users.
+func (p *Processor) getAnnouncement(
+	ctx context.Context,
+	id string,
+	onlyActive bool,
+) (*gtsmodel.Announcement, gtserror.WithCode) {
+	announcement, err := p.state.DB.GetAnnouncementByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement == nil ||
+		(onlyActive && !announcement.IsActive(time.Now())) {
+		const text = "announcement not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return announcement, nil
+}
+
+// apiAnnouncement converts the given announcement
+// to its API model representation for requester.
+func (p *Processor) apiAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	requester *gtsmodel.Account,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx,
+		announcement,
+		requester,
+	)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiAnnouncement, nil
+}
+
+// streamAnnouncement streams the current state of the given
+// announcement to all users, sending a delete if it was
+// previously shown to users but no longer is.
+func (p *Processor) streamAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	wasActive bool,
+) {
+	if !announcement.IsActive(time.Now()) {
+		if wasActive {
+			p.stream.AnnouncementDelete(ctx, announcement.ID)
+		}
+		return
+	}
+
+	// Convert without a requester, as
+	// this is going to *all* accounts.
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(ctx,
+		announcement,
+		nil,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement: %v", err)
+		return
+	}
+
+	p.stream.Announcement(ctx, apiAnnouncement)
+}
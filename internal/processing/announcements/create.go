@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/internal/util/xslices"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+// Create creates a new announcement authored by the requester
+// (an admin), streaming it to all users if it's published.
+func (p *Processor) Create(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.AnnouncementCreateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	if err := validate.AnnouncementText(form.Text); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	startsAt, errWithCode := parseTime("starts_at", form.StartsAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	endsAt, errWithCode := parseTime("ends_at", form.EndsAt)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	now := time.Now()
+	announcement := &gtsmodel.Announcement{
+		ID:        id.NewULID(),
+		CreatedAt: now,
+		UpdatedAt: now,
+		AccountID: requester.ID,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		AllDay:    &form.AllDay,
+		Published: util.Ptr(util.PtrOrValue(form.Published, true)),
+	}
+
+	if errWithCode := validateTimes(announcement); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if *announcement.Published {
+		announcement.PublishedAt = now
+	}
+
+	// Parse text into content.
+	p.setText(ctx, announcement, form.Text)
+
+	if err := p.state.DB.PutAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error inserting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Stream to users if now visible,
+	// and schedule when it starts / ends.
+	p.streamAnnouncement(ctx, announcement, false)
+	p.schedule(ctx, announcement)
+
+	return p.apiAnnouncement(ctx, announcement, requester)
+}
+
+// setText sets the text of the given announcement,
+// (re)formatting its content, tags and emojis from it.
+func (p *Processor) setText(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	text string,
+) {
+	res := p.formatter.FromMarkdown(ctx,
+		p.parseMentionFunc,
+		announcement.AccountID,
+		"",
+		text,
+	)
+
+	announcement.Text = text
+	announcement.Content = res.HTML
+	announcement.Tags = res.Tags
+	announcement.TagIDs = xslices.Gather(nil, res.Tags, func(t *gtsmodel.Tag) string { return t.ID })
+	announcement.Emojis = res.Emojis
+	announcement.EmojiIDs = xslices.Gather(nil, res.Emojis, func(e *gtsmodel.Emoji) string { return e.ID })
+}
+
+// parseTime parses the given ISO 8601 form value
+// for key, returning zero time if value is empty.
+func parseTime(key string, value string) (time.Time, gtserror.WithCode) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	// RFC3339 parsing also accepts our own
+	// ISO8601 format with fractional seconds.
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		text := fmt.Sprintf("%s could not be parsed as ISO 8601 datetime", key)
+		return time.Time{}, gtserror.NewErrorBadRequest(err, text)
+	}
+
+	return t, nil
+}
+
+// validateTimes ensures that the announcement's
+// end time, if set, isn't before its start time.
+func validateTimes(announcement *gtsmodel.Announcement) gtserror.WithCode {
+	if !announcement.StartsAt.IsZero() &&
+		!announcement.EndsAt.IsZero() &&
+		announcement.EndsAt.Before(announcement.StartsAt) {
+		const text = "ends_at must not be before starts_at"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+// ReactionAdd adds a reaction with the given name (either a unicode
+// emoji or the shortcode of a local custom emoji) by the requester
+// to the active announcement with given ID. Adding a reaction that
+// already exists is a no-op.
+func (p *Processor) ReactionAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	if _, errWithCode := p.getAnnouncement(ctx, announcementID, true); errWithCode != nil {
+		return errWithCode
+	}
+
+	reaction, err := p.state.DB.GetAnnouncementReaction(ctx,
+		announcementID,
+		requester.ID,
+		name,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if reaction != nil {
+		// Already reacted,
+		// nothing to do.
+		return nil
+	}
+
+	reaction = &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcementID,
+		AccountID:      requester.ID,
+		Name:           name,
+	}
+
+	if validate.EmojiShortcode(name) == nil {
+		// Name looks like a shortcode,
+		// ensure it's a usable local emoji.
+		emoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, name, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if emoji == nil || *emoji.Disabled {
+			const text = "name is not a recognized emoji"
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		reaction.EmojiID = emoji.ID
+	} else if err := validate.UnicodeEmoji(name); err != nil {
+		const text = "name is not a recognized emoji"
+		return gtserror.NewErrorUnprocessableEntity(err, text)
+	}
+
+	if err := p.state.DB.PutAnnouncementReaction(ctx, reaction); err != nil {
+		err := gtserror.Newf("db error inserting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamReaction(ctx, announcementID, name)
+	return nil
+}
+
+// ReactionRemove removes the reaction with the given name by the
+// requester from the active announcement with given ID. Removing
+// a reaction that doesn't exist is a no-op.
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	if _, errWithCode := p.getAnnouncement(ctx, announcementID, true); errWithCode != nil {
+		return errWithCode
+	}
+
+	reaction, err := p.state.DB.GetAnnouncementReaction(ctx,
+		announcementID,
+		requester.ID,
+		name,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if reaction == nil {
+		// Not reacted,
+		// nothing to do.
+		return nil
+	}
+
+	if err := p.state.DB.DeleteAnnouncementReactionByID(ctx, reaction.ID); err != nil {
+		err := gtserror.Newf("db error deleting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamReaction(ctx, announcementID, name)
+	return nil
+}
+
+// streamReaction streams the updated count of reactions
+// with the given name on announcement to all users.
+func (p *Processor) streamReaction(
+	ctx context.Context,
+	announcementID string,
+	name string,
+) {
+	reactions, err := p.state.DB.GetAnnouncementReactions(
+		gtscontext.SetBarebones(ctx),
+		announcementID,
+	)
+	if err != nil {
+		log.Errorf(ctx, "db error getting announcement reactions: %v", err)
+		return
+	}
+
+	var count int
+	for _, reaction := range reactions {
+		if reaction.Name == name {
+			count++
+		}
+	}
+
+	p.stream.AnnouncementReaction(ctx, &apimodel.AnnouncementReactionStreamEvent{
+		Name:           name,
+		Count:          count,
+		AnnouncementID: announcementID,
+	})
+}
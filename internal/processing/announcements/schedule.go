@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// ScheduleAll schedules streaming of all published
+// announcements in the database to users when they
+// start, and their removal when they end. This should
+// be called once on startup, after the scheduler has started.
+func (p *Processor) ScheduleAll(ctx context.Context) error {
+	announcements, err := p.state.DB.GetAnnouncements(ctx, nil)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting announcements from db: %w", err)
+	}
+
+	for _, announcement := range announcements {
+		p.schedule(ctx, announcement)
+	}
+
+	return nil
+}
+
+// schedule (re)schedules streaming of the given announcement
+// to users at its start time, and its removal at its end time,
+// for whichever of these are still to come. Any previously
+// scheduled tasks for the announcement are cancelled first.
+func (p *Processor) schedule(ctx context.Context, announcement *gtsmodel.Announcement) {
+	p.unschedule(announcement.ID)
+
+	if !*announcement.Published {
+		// Not shown to
+		// users at all.
+		return
+	}
+
+	now := time.Now()
+
+	if announcement.StartsAt.After(now) {
+		p.scheduleTask(ctx,
+			startTaskID(announcement.ID),
+			announcement.StartsAt,
+			announcement.ID,
+			false,
+		)
+	}
+
+	if announcement.EndsAt.After(now) {
+		p.scheduleTask(ctx,
+			endTaskID(announcement.ID),
+			announcement.EndsAt,
+			announcement.ID,
+			true,
+		)
+	}
+}
+
+// scheduleTask schedules a task under taskID to stream the current
+// state of announcement with ID to users at the given time; see
+// streamAnnouncement for the meaning of wasActive.
+func (p *Processor) scheduleTask(
+	ctx context.Context,
+	taskID string,
+	at time.Time,
+	id string,
+	wasActive bool,
+) {
+	if !p.state.Workers.Scheduler.AddOnce(
+		taskID,
+		at,
+		func(ctx context.Context, _ time.Time) {
+			// Once tasks stay registered
+			// until they're cancelled.
+			_ = p.state.Workers.Scheduler.Cancel(taskID)
+
+			// Get the latest version of announcement from database.
+			announcement, err := p.state.DB.GetAnnouncementByID(ctx, id)
+			if err != nil {
+				if !errors.Is(err, db.ErrNoEntries) {
+					log.Errorf(ctx, "error getting announcement %s from db: %v", id, err)
+				}
+				return
+			}
+
+			p.streamAnnouncement(ctx, announcement, wasActive)
+		},
+	) {
+		// Either the scheduler is
+		// starting / stopping, or
+		// a task already exists.
+		log.Errorf(ctx, "failed adding task %s to scheduler", taskID)
+		return
+	}
+
+	atStr := at.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled task %s at '%s'", taskID, atStr)
+}
+
+// unschedule cancels any scheduled tasks
+// for the announcement with given ID.
+func (p *Processor) unschedule(id string) {
+	_ = p.state.Workers.Scheduler.Cancel(startTaskID(id))
+	_ = p.state.Workers.Scheduler.Cancel(endTaskID(id))
+}
+
+// startTaskID returns the scheduler task ID used
+// for the start of announcement with given ID.
+func startTaskID(id string) string {
+	return "@announcementstart:" + id
+}
+
+// endTaskID returns the scheduler task ID used
+// for the end of announcement with given ID.
+func endTaskID(id string) string {
+	return "@announcementend:" + id
+}
@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+// Update updates the given fields of the announcement with ID,
+// streaming the change to all users where appropriate.
+func (p *Processor) Update(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+	form *apimodel.AnnouncementUpdateRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var (
+		now          = time.Now()
+		wasActive    = announcement.IsActive(now)
+		wasPublished = *announcement.Published
+	)
+
+	if form.StartsAt != nil {
+		announcement.StartsAt, errWithCode = parseTime("starts_at", *form.StartsAt)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if form.EndsAt != nil {
+		announcement.EndsAt, errWithCode = parseTime("ends_at", *form.EndsAt)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if errWithCode := validateTimes(announcement); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.AllDay != nil {
+		announcement.AllDay = form.AllDay
+	}
+
+	if form.Published != nil {
+		announcement.Published = form.Published
+		if *announcement.Published && !wasPublished {
+			// Newly (re)published.
+			announcement.PublishedAt = now
+		}
+	}
+
+	if form.Text != nil && *form.Text != announcement.Text {
+		if err := validate.AnnouncementText(*form.Text); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		// Reparse text into content.
+		p.setText(ctx, announcement, *form.Text)
+	}
+
+	if err := p.state.DB.UpdateAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error updating announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Stream updated state to users,
+	// and reschedule when it starts / ends.
+	p.streamAnnouncement(ctx, announcement, wasActive)
+	p.schedule(ctx, announcement)
+
+	return p.apiAnnouncement(ctx, announcement, requester)
+}
@@ -47,42 +47,9 @@ func (p *Processor) Create(
 	}
 
 	// Normalize + parse requested redirect URIs.
-	form.RedirectURIs = strings.TrimSpace(form.RedirectURIs)
-	var redirectURIs []string
-	if form.RedirectURIs != "" {
-		// Redirect URIs can be just one value, or can be passed
-		// as a newline-separated list of strings. Ensure each URI
-		// is parseable + normalize it by reconstructing from *url.URL.
-		// Also ensure we don't add multiple copies of the same URI.
-		redirectStrs := strings.Split(form.RedirectURIs, "\n")
-		added := make(map[string]struct{}, len(redirectStrs))
-
-		for _, redirectStr := range redirectStrs {
-			redirectStr = strings.TrimSpace(redirectStr)
-			if redirectStr == "" {
-				continue
-			}
-
-			redirectURI, err := url.Parse(redirectStr)
-			if err != nil {
-				errText := fmt.Sprintf("error parsing redirect URI: %v", err)
-				return nil, gtserror.NewErrorBadRequest(err, errText)
-			}
-
-			redirectURIStr := redirectURI.String()
-			if _, alreadyAdded := added[redirectURIStr]; !alreadyAdded {
-				redirectURIs = append(redirectURIs, redirectURIStr)
-				added[redirectURIStr] = struct{}{}
-			}
-		}
-
-		if len(redirectURIs) == 0 {
-			errText := "no redirect URIs left after trimming space"
-			return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
-		}
-	} else {
-		// No redirect URI(s) provided, just set default oob.
-		redirectURIs = append(redirectURIs, oauth.OOBURI)
+	redirectURIs, errWithCode := parseRedirectURIs(form.RedirectURIs)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
 	// Generate random client ID.
@@ -114,3 +81,48 @@ func (p *Processor) Create(
 
 	return apiApp, nil
 }
+
+// parseRedirectURIs parses and normalizes the given
+// newline-separated list of redirect URIs, defaulting
+// to the out-of-band URI if no redirect URIs are given.
+func parseRedirectURIs(redirectURIsStr string) ([]string, gtserror.WithCode) {
+	redirectURIsStr = strings.TrimSpace(redirectURIsStr)
+	if redirectURIsStr == "" {
+		// No redirect URI(s) provided, just set default oob.
+		return []string{oauth.OOBURI}, nil
+	}
+
+	// Redirect URIs can be just one value, or can be passed
+	// as a newline-separated list of strings. Ensure each URI
+	// is parseable + normalize it by reconstructing from *url.URL.
+	// Also ensure we don't add multiple copies of the same URI.
+	redirectStrs := strings.Split(redirectURIsStr, "\n")
+	redirectURIs := make([]string, 0, len(redirectStrs))
+	added := make(map[string]struct{}, len(redirectStrs))
+
+	for _, redirectStr := range redirectStrs {
+		redirectStr = strings.TrimSpace(redirectStr)
+		if redirectStr == "" {
+			continue
+		}
+
+		redirectURI, err := url.Parse(redirectStr)
+		if err != nil {
+			errText := fmt.Sprintf("error parsing redirect URI: %v", err)
+			return nil, gtserror.NewErrorBadRequest(err, errText)
+		}
+
+		redirectURIStr := redirectURI.String()
+		if _, alreadyAdded := added[redirectURIStr]; !alreadyAdded {
+			redirectURIs = append(redirectURIs, redirectURIStr)
+			added[redirectURIStr] = struct{}{}
+		}
+	}
+
+	if len(redirectURIs) == 0 {
+		errText := "no redirect URIs left after trimming space"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	return redirectURIs, nil
+}
@@ -0,0 +1,187 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"github.com/google/uuid"
+)
+
+// Update updates the name, website, redirect URIs
+// and / or scopes of the given application managed
+// by the given user, returning the updated app.
+//
+// If any scopes are removed from the application,
+// all of the application's existing tokens are
+// revoked, as they may have been granted scopes
+// that the application no longer declares.
+func (p *Processor) Update(
+	ctx context.Context,
+	userID string,
+	appID string,
+	form *apimodel.ApplicationUpdateRequest,
+) (*apimodel.Application, gtserror.WithCode) {
+	app, errWithCode := p.getManagedApp(ctx, userID, appID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var (
+		columns      []string
+		revokeTokens bool
+	)
+
+	if form.ClientName != nil {
+		name := strings.TrimSpace(*form.ClientName)
+		if name == "" {
+			const errText = "client_name cannot be empty"
+			return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+		}
+
+		app.Name = name
+		columns = append(columns, "name")
+	}
+
+	if form.Website != nil {
+		app.Website = strings.TrimSpace(*form.Website)
+		columns = append(columns, "website")
+	}
+
+	if form.RedirectURIs != nil {
+		redirectURIs, errWithCode := parseRedirectURIs(*form.RedirectURIs)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		app.RedirectURIs = redirectURIs
+		columns = append(columns, "redirect_uris")
+	}
+
+	if form.Scopes != nil {
+		scopes := strings.Join(strings.Fields(*form.Scopes), " ")
+		if scopes == "" {
+			const errText = "scopes cannot be empty"
+			return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+		}
+
+		// Check whether every previously declared
+		// scope is still permitted by the new scopes.
+		newScopes := strings.Split(scopes, " ")
+		for _, oldScope := range strings.Split(app.Scopes, " ") {
+			if !slices.ContainsFunc(newScopes, func(newScope string) bool {
+				return apiutil.Scope(newScope).Permits(apiutil.Scope(oldScope))
+			}) {
+				revokeTokens = true
+				break
+			}
+		}
+
+		app.Scopes = scopes
+		columns = append(columns, "scopes")
+	}
+
+	if len(columns) == 0 {
+		const errText = "no application fields to update"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	if err := p.state.DB.UpdateApplication(ctx, app, columns...); err != nil {
+		err := gtserror.Newf("db error updating app %s: %w", appID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if revokeTokens {
+		// Scopes were narrowed, delete all tokens owned by app.
+		if err := p.state.DB.DeleteTokensByClientID(ctx, app.ClientID); err != nil {
+			err := gtserror.Newf("db error deleting tokens for app %s: %w", appID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiApp, err := p.converter.AppToAPIAppSensitive(ctx, app)
+	if err != nil {
+		err := gtserror.Newf("error converting app to api app: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiApp, nil
+}
+
+// RotateSecret generates a new client secret for the
+// given application managed by the given user, returning
+// the updated app. The previous client secret stops
+// working immediately, though existing tokens are kept.
+func (p *Processor) RotateSecret(
+	ctx context.Context,
+	userID string,
+	appID string,
+) (*apimodel.Application, gtserror.WithCode) {
+	app, errWithCode := p.getManagedApp(ctx, userID, appID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	app.ClientSecret = uuid.NewString()
+	if err := p.state.DB.UpdateApplication(ctx, app, "client_secret"); err != nil {
+		err := gtserror.Newf("db error updating app %s: %w", appID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiApp, err := p.converter.AppToAPIAppSensitive(ctx, app)
+	if err != nil {
+		err := gtserror.Newf("error converting app to api app: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiApp, nil
+}
+
+// getManagedApp gets the application with the given ID,
+// returning not found if it's not managed by the given user.
+func (p *Processor) getManagedApp(
+	ctx context.Context,
+	userID string,
+	appID string,
+) (*gtsmodel.Application, gtserror.WithCode) {
+	app, err := p.state.DB.GetApplicationByID(ctx, appID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting app %s: %w", appID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if app == nil {
+		err := gtserror.Newf("app %s not found in the db", appID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if app.ManagedByUserID != userID {
+		err := gtserror.Newf("app %s not managed by user %s", appID, userID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return app, nil
+}
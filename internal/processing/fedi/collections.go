@@ -103,16 +103,19 @@ func (p *Processor) OutboxGet(
 	default:
 		// Paging enabled.
 		// Get page of full public statuses.
+		//
+		// Group outboxes also contain the Announces
+		// the group has made of posts by its members.
 		statuses, err := p.state.DB.GetAccountStatuses(
 			ctx,
 			receivingAcct.ID,
-			page.GetLimit(), // limit
-			true,            // excludeReplies
-			true,            // excludeReblogs
-			page.GetMax(),   // maxID
-			page.GetMin(),   // minID
-			false,           // mediaOnly
-			true,            // publicOnly
+			page.GetLimit(),          // limit
+			true,                     // excludeReplies
+			!receivingAcct.IsGroup(), // excludeReblogs
+			page.GetMax(),            // maxID
+			page.GetMin(),            // minID
+			false,                    // mediaOnly
+			true,                     // publicOnly
 		)
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			err := gtserror.Newf("error getting statuses: %w", err)
@@ -159,6 +162,23 @@ func (p *Processor) OutboxGet(
 			// Get status at index.
 			status := statuses[i]
 
+			if status.BoostOfID != "" {
+				// Derive announce from boost.
+				announce, err := p.converter.BoostToAS(ctx,
+					status,
+					receivingAcct,
+					status.BoostOfAccount,
+				)
+				if err != nil {
+					log.Errorf(ctx, "error converting %s to announce: %v", status.URI, err)
+					return
+				}
+
+				// Add to item property.
+				itemsProp.AppendActivityStreamsAnnounce(announce)
+				return
+			}
+
 			// Derive statusable from status.
 			statusable, err := p.converter.StatusToAS(ctx, status)
 			if err != nil {
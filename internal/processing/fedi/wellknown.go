@@ -22,8 +22,10 @@
 	"fmt"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 )
 
 const (
@@ -41,6 +43,12 @@
 	webfingerSelf                   = "self"
 	webFingerSelfContentType        = "application/activity+json"
 	webfingerAccount                = "acct"
+	oauthAuthorizePath              = "oauth/authorize"
+	oauthTokenPath                  = "oauth/token" // #nosec G101 else we get a hardcoded credentials warning
+	oauthRevokePath                 = "oauth/revoke"
+	oauthIntrospectPath             = "oauth/introspect"
+	oauthAppRegistrationPath        = "api/v1/apps"
+	oauthServiceDocumentation       = "https://docs.gotosocial.org/en/latest/api/swagger/"
 )
 
 var (
@@ -155,6 +163,52 @@ func (p *Processor) HostMetaGet() *apimodel.HostMeta {
 	}
 }
 
+// OAuthServerMetadataGet returns an OAuth 2.0
+// authorization server metadata document.
+//
+// See: https://www.rfc-editor.org/rfc/rfc8414.html
+func (p *Processor) OAuthServerMetadataGet() *apimodel.OAuthServerMetadata {
+	baseURL := config.GetProtocol() + "://" + config.GetHost() + "/"
+
+	scopes := make([]string, len(apiutil.Scopes))
+	for i, scope := range apiutil.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	responseTypes := make([]string, len(oauth.AllowedResponseTypes))
+	for i, responseType := range oauth.AllowedResponseTypes {
+		responseTypes[i] = responseType.String()
+	}
+
+	grantTypes := make([]string, len(oauth.AllowedGrantTypes))
+	for i, grantType := range oauth.AllowedGrantTypes {
+		grantTypes[i] = grantType.String()
+	}
+
+	codeChallengeMethods := make([]string, len(oauth.AllowedCodeChallengeMethods))
+	for i, method := range oauth.AllowedCodeChallengeMethods {
+		codeChallengeMethods[i] = method.String()
+	}
+
+	return &apimodel.OAuthServerMetadata{
+		Issuer:                                    baseURL,
+		AuthorizationEndpoint:                     baseURL + oauthAuthorizePath,
+		TokenEndpoint:                             baseURL + oauthTokenPath,
+		RevocationEndpoint:                        baseURL + oauthRevokePath,
+		IntrospectionEndpoint:                     baseURL + oauthIntrospectPath,
+		AppRegistrationEndpoint:                   baseURL + oauthAppRegistrationPath,
+		ServiceDocumentation:                      oauthServiceDocumentation,
+		ScopesSupported:                           scopes,
+		ResponseTypesSupported:                    responseTypes,
+		ResponseModesSupported:                    []string{"query"},
+		GrantTypesSupported:                       grantTypes,
+		TokenEndpointAuthMethodsSupported:         []string{"client_secret_post"},
+		RevocationEndpointAuthMethodsSupported:    []string{"client_secret_post"},
+		IntrospectionEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post"},
+		CodeChallengeMethodsSupported:             codeChallengeMethods,
+	}
+}
+
 // WebfingerGet handles the GET for a webfinger resource. Most commonly, it will be used for returning account lookups.
 func (p *Processor) WebfingerGet(ctx context.Context, requestedUsername string) (*apimodel.WellKnownResponse, gtserror.WithCode) {
 	// Get the local account the request is referring to.
@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+	"errors"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+)
+
+// AdminsGet returns the admins of the given group,
+// if requester is also an admin of the group.
+func (p *Processor) AdminsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	group, errWithCode := p.getAdministeredGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiGroupAdmins(ctx, group)
+}
+
+// AdminAdd makes the local account with the given ID an admin
+// of the given group, if requester is an admin of the group.
+func (p *Processor) AdminAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	accountID string,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	group, errWithCode := p.getAdministeredGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := gtserror.Newf("account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if account.IsRemote() ||
+		account.IsGroup() ||
+		account.IsInstance() ||
+		account.IsSuspended() {
+		const text = "only local user accounts can be group admins"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if err := p.state.DB.PutGroupAdmin(ctx, &gtsmodel.GroupAdmin{
+		ID:             id.NewULID(),
+		GroupAccountID: group.ID,
+		GroupAccount:   group,
+		AccountID:      account.ID,
+		Account:        account,
+	}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+		err := gtserror.Newf("db error putting group admin: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiGroupAdmins(ctx, group)
+}
+
+// AdminRemove removes the local account with the given ID from
+// the admins of the given group, if requester is an admin of the
+// group. The last remaining admin of a group cannot be removed.
+func (p *Processor) AdminRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	accountID string,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	group, errWithCode := p.getAdministeredGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	groupAdmins, err := p.state.DB.GetGroupAdmins(ctx, group.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting group admins: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var groupAdmin *gtsmodel.GroupAdmin
+	for _, ga := range groupAdmins {
+		if ga.AccountID == accountID {
+			groupAdmin = ga
+			break
+		}
+	}
+
+	if groupAdmin == nil {
+		err := gtserror.Newf("account %s is not an admin of group %s", accountID, group.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if len(groupAdmins) == 1 {
+		const text = "cannot remove the last admin of a group"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if err := p.state.DB.DeleteGroupAdminByID(ctx, groupAdmin.ID); err != nil {
+		err := gtserror.Newf("db error deleting group admin: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiGroupAdmins(ctx, group)
+}
+
+// apiGroupAdmins returns the API
+// accounts of the given group's admins.
+func (p *Processor) apiGroupAdmins(
+	ctx context.Context,
+	group *gtsmodel.Account,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	groupAdmins, err := p.state.DB.GetGroupAdmins(ctx, group.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting group admins: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := make([]*apimodel.Account, 0, len(groupAdmins))
+	for _, groupAdmin := range groupAdmins {
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, groupAdmin.Account)
+		if err != nil {
+			err := gtserror.Newf("error converting group admin to api account: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
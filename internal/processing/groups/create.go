@@ -0,0 +1,83 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// Create creates a new local Group actor with the given
+// parameters, making requester the first admin of the group.
+// These params should have already been validated by the time
+// they reach this function.
+func (p *Processor) Create(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.GroupCreateRequest,
+) (*apimodel.Account, gtserror.WithCode) {
+	usernameAvailable, err := p.state.DB.IsUsernameAvailable(ctx, form.Username)
+	if err != nil {
+		err := gtserror.Newf("db error checking username availability: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if !usernameAvailable {
+		err := fmt.Errorf("username %s is not available", form.Username)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	// Format the group description.
+	note := p.formatter.FromPlain(ctx,
+		p.parseMention,
+		requester.ID,
+		"",
+		form.Note,
+	)
+
+	group, err := p.state.DB.NewGroup(ctx, gtsmodel.NewGroup{
+		Username:    form.Username,
+		Admin:       requester,
+		DisplayName: form.DisplayName,
+		Note:        note.HTML,
+		NoteRaw:     form.Note,
+	})
+	if err != nil {
+		err := gtserror.Newf("db error creating group: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiGroup(ctx, group)
+}
+
+// apiGroup converts the given group to its API model representation.
+func (p *Processor) apiGroup(
+	ctx context.Context,
+	group *gtsmodel.Account,
+) (*apimodel.Account, gtserror.WithCode) {
+	apiGroup, err := p.converter.AccountToAPIAccountPublic(ctx, group)
+	if err != nil {
+		err := gtserror.Newf("error converting group to api account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiGroup, nil
+}
@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+	"errors"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// GetAll returns all local groups administered by requester.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	groupAdmins, err := p.state.DB.GetGroupAdminsByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting administered groups: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiGroups := make([]*apimodel.Account, 0, len(groupAdmins))
+	for _, groupAdmin := range groupAdmins {
+		apiGroup, errWithCode := p.apiGroup(ctx, groupAdmin.GroupAccount)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiGroups = append(apiGroups, apiGroup)
+	}
+
+	return apiGroups, nil
+}
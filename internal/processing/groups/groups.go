@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+	"errors"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/account"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/status"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+)
+
+// Processor wraps functionality for creating and
+// moderating local Group actors in response to API
+// requests from the local accounts that administer them.
+type Processor struct {
+	state        *state.State
+	converter    *typeutils.Converter
+	formatter    *text.Formatter
+	parseMention gtsmodel.ParseMentionFunc
+
+	// Groups act through the same account and
+	// status logic as any other local account,
+	// eg., blocking banned members, unboosting.
+	account *account.Processor
+	status  *status.Processor
+}
+
+// New returns a new groups processor.
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+	parseMention gtsmodel.ParseMentionFunc,
+	account *account.Processor,
+	status *status.Processor,
+) Processor {
+	return Processor{
+		state:        state,
+		converter:    converter,
+		formatter:    text.NewFormatter(state.DB),
+		parseMention: parseMention,
+		account:      account,
+		status:       status,
+	}
+}
+
+// getAdministeredGroup gets the local group with the given
+// ID, checking that requester is an admin of the group.
+func (p *Processor) getAdministeredGroup(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+) (*gtsmodel.Account, gtserror.WithCode) {
+	group, err := p.state.DB.GetAccountByID(ctx, groupID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting group %s: %w", groupID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if group == nil || group.IsRemote() || !group.IsGroup() {
+		err := gtserror.Newf("local group %s not found", groupID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	isAdmin, err := p.state.DB.IsGroupAdmin(ctx, group.ID, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("db error checking group admin: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !isAdmin {
+		const text = "you are not an admin of this group"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	return group, nil
+}
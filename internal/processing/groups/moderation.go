@@ -0,0 +1,145 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+	"errors"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// StatusRemove removes the status with the given ID from the
+// given group, by undoing the group's Announce of the status,
+// if requester is an admin of the group.
+func (p *Processor) StatusRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	statusID string,
+) gtserror.WithCode {
+	group, errWithCode := p.getAdministeredGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), statusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting status %s: %w", statusID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil {
+		err := gtserror.Newf("status %s not found", statusID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	if status.BoostOfID != "" {
+		// Unwrap boost to
+		// the boosted status.
+		statusID = status.BoostOfID
+	}
+
+	// Check the group actually announced the status.
+	boost, err := p.state.DB.GetStatusBoost(
+		gtscontext.SetBarebones(ctx),
+		statusID,
+		group.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting group boost: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if boost == nil {
+		err := gtserror.Newf("status %s not posted to group %s", statusID, group.ID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	// Unboost the status as the group.
+	_, errWithCode = p.status.BoostRemove(ctx, group, nil, statusID)
+	return errWithCode
+}
+
+// BansGet returns a page of accounts banned
+// from the given group, if requester is an
+// admin of the group.
+func (p *Processor) BansGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	group, errWithCode := p.getAdministeredGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Bans are blocks by the group.
+	return p.account.BlocksGet(ctx, group, page)
+}
+
+// BanCreate bans the account with the given ID from the given
+// group, if requester is an admin of the group. The group blocks
+// the banned account, which removes the account's membership
+// and prevents it from following the group again.
+func (p *Processor) BanCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	accountID string,
+) (*apimodel.Relationship, gtserror.WithCode) {
+	group, errWithCode := p.getAdministeredGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	isAdmin, err := p.state.DB.IsGroupAdmin(ctx, group.ID, accountID)
+	if err != nil {
+		err := gtserror.Newf("db error checking group admin: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if isAdmin {
+		const text = "cannot ban an admin of the group"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return p.account.BlockCreate(ctx, group, accountID)
+}
+
+// BanRemove lifts the ban of the account with the given ID
+// from the given group, if requester is an admin of the group.
+func (p *Processor) BanRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	accountID string,
+) (*apimodel.Relationship, gtserror.WithCode) {
+	group, errWithCode := p.getAdministeredGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.account.BlockRemove(ctx, group, accountID)
+}
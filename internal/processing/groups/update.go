@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+)
+
+// Update updates the profile of the given group with the
+// given parameters, if requester is an admin of the group.
+// These params should have already been validated by the
+// time they reach this function.
+func (p *Processor) Update(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	form *apimodel.GroupUpdateRequest,
+) (*apimodel.Account, gtserror.WithCode) {
+	group, errWithCode := p.getAdministeredGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var columns []string
+
+	if form.DisplayName != nil {
+		group.DisplayName = *form.DisplayName
+		if group.DisplayName == "" {
+			group.DisplayName = group.Username
+		}
+		columns = append(columns, "display_name")
+	}
+
+	if form.Note != nil {
+		note := p.formatter.FromPlain(ctx,
+			p.parseMention,
+			group.ID,
+			"",
+			*form.Note,
+		)
+		group.Note = note.HTML
+		group.NoteRaw = *form.Note
+		columns = append(columns, "note", "note_raw")
+	}
+
+	if len(columns) == 0 {
+		// Nothing to do.
+		return p.apiGroup(ctx, group)
+	}
+
+	if err := p.state.DB.UpdateAccount(ctx, group, columns...); err != nil {
+		err := gtserror.Newf("db error updating group: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Federate the profile update; group
+	// profiles are sent like any other.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       group,
+		Origin:         group,
+	})
+
+	return p.apiGroup(ctx, group)
+}
@@ -105,11 +105,20 @@ func (p *Processor) InstancePeersGet(ctx context.Context, includeSuspended bool,
 				d = obfuscate(d)
 			}
 
-			domains = append(domains, &apimodel.Domain{
-				Domain:      d,
-				SuspendedAt: util.FormatISO8601(domainBlock.CreatedAt),
-				Comment:     &domainBlock.PublicComment,
-			})
+			domain := &apimodel.Domain{
+				Domain:  d,
+				Comment: &domainBlock.PublicComment,
+			}
+
+			// Limited domains are silenced
+			// rather than fully suspended.
+			if domainBlock.IsLimit() {
+				domain.SilencedAt = util.FormatISO8601(domainBlock.CreatedAt)
+			} else {
+				domain.SuspendedAt = util.FormatISO8601(domainBlock.CreatedAt)
+			}
+
+			domains = append(domains, domain)
 		}
 	}
 
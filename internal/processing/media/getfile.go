@@ -49,7 +49,7 @@ func (p *Processor) GetFile(
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
-	// Parse media type (emoji, header, avatar, attachment).
+	// Parse media type (emoji, header, avatar, attachment, card).
 	mediaType, err := parseType(form.MediaType)
 	if err != nil {
 		err := gtserror.Newf("media type %s not valid", form.MediaType)
@@ -113,6 +113,13 @@ func (p *Processor) GetFile(
 			mediaID,
 		)
 
+	case media.TypeCard:
+		return p.getPreviewCardContent(ctx,
+			acctID,
+			mediaSize,
+			mediaID,
+		)
+
 	default:
 		err := gtserror.Newf("media type %s not recognized", mediaType)
 		return nil, gtserror.NewErrorNotFound(err)
@@ -282,6 +289,46 @@ func (p *Processor) getEmojiContent(
 	}
 }
 
+func (p *Processor) getPreviewCardContent(
+	ctx context.Context,
+	acctID string,
+	sizeStr media.Size,
+	cardID string,
+) (
+	*apimodel.Content,
+	gtserror.WithCode,
+) {
+	// Preview card images are
+	// only stored as thumbnails.
+	if sizeStr != media.SizeSmall {
+		const text = "invalid preview card image size"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Get preview card with given ID from the database.
+	card, err := p.state.DB.GetPreviewCardByID(ctx, cardID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting preview card %s: %w", cardID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Ensure the card image is cached locally,
+	// and stored under the passed account ID.
+	if card == nil || !*card.Cached ||
+		!strings.HasPrefix(card.ImagePath, acctID+"/") {
+		const text = "preview card image not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return p.getContent(ctx,
+		card.ImagePath,
+		&apimodel.Content{
+			ContentType:   card.ImageContentType,
+			ContentLength: int64(card.ImageFileSize),
+		},
+	)
+}
+
 // getContent performs the final file fetching of
 // stored content at path in storage. This is
 // populated in the apimodel.Content{} and returned.
@@ -365,6 +412,8 @@ func parseType(s string) (media.Type, error) {
 		return media.TypeAvatar, nil
 	case string(media.TypeEmoji):
 		return media.TypeEmoji, nil
+	case string(media.TypeCard):
+		return media.TypeCard, nil
 	}
 	return "", fmt.Errorf("%s not a recognized media.Type", s)
 }
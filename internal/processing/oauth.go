@@ -19,9 +19,13 @@
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 	"code.superseriousbusiness.org/oauth2/v4"
 )
 
@@ -53,3 +57,92 @@ func (p *Processor) OAuthRevokeAccessToken(
 		accessToken,
 	)
 }
+
+// OAuthIntrospectToken returns RFC 7662 introspection
+// information about the given access token, to the client
+// authenticated with the given client ID and secret.
+func (p *Processor) OAuthIntrospectToken(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	accessToken string,
+) (*apimodel.OAuthTokenIntrospection, gtserror.WithCode) {
+	token, errWithCode := p.oauthServer.IntrospectAccessToken(
+		ctx,
+		clientID,
+		clientSecret,
+		accessToken,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if token == nil {
+		// Token not active,
+		// return nothing else.
+		return &apimodel.OAuthTokenIntrospection{
+			Active: false,
+		}, nil
+	}
+
+	// Get the stored token for accurate
+	// creation and expiry times, as these
+	// are relative to "now" in token info.
+	dbToken, err := p.state.DB.GetTokenByAccess(ctx, oauth.HashToken(accessToken))
+	if err != nil {
+		err := gtserror.Newf("db error getting token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	introspection := &apimodel.OAuthTokenIntrospection{
+		Active:    true,
+		Scope:     token.GetScope(),
+		ClientID:  token.GetClientID(),
+		TokenType: "Bearer",
+		Iat:       dbToken.AccessCreateAt.Unix(),
+	}
+
+	if !dbToken.AccessExpiresAt.IsZero() {
+		introspection.Exp = dbToken.AccessExpiresAt.Unix()
+	}
+
+	if userID := token.GetUserID(); userID != "" {
+		// User-level token, include
+		// details of the user's account.
+		user, err := p.state.DB.GetUserByID(ctx, userID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting user %s: %w", userID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if user == nil ||
+			user.ConfirmedAt.IsZero() ||
+			!*user.Approved ||
+			*user.Disabled {
+			// User since deleted or otherwise
+			// can't use the token, so not active.
+			return &apimodel.OAuthTokenIntrospection{
+				Active: false,
+			}, nil
+		}
+
+		account, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting account %s: %w", user.AccountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if account == nil || account.IsSuspended() {
+			// Account gone or suspended,
+			// so token is not active.
+			return &apimodel.OAuthTokenIntrospection{
+				Active: false,
+			}, nil
+		}
+
+		introspection.Username = account.Username
+		introspection.Sub = account.ID
+	}
+
+	return introspection, nil
+}
@@ -29,12 +29,14 @@
 	"code.superseriousbusiness.org/gotosocial/internal/processing/account"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/admin"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/advancedmigrations"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/announcements"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/application"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/common"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/conversations"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/fedi"
 	filtersv1 "code.superseriousbusiness.org/gotosocial/internal/processing/filters/v1"
 	filtersv2 "code.superseriousbusiness.org/gotosocial/internal/processing/filters/v2"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/groups"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/interactionrequests"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/list"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/markers"
@@ -45,8 +47,10 @@
 	"code.superseriousbusiness.org/gotosocial/internal/processing/search"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/status"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/stream"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/suggestions"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/tags"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/timeline"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/trends"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/user"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/workers"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
@@ -82,11 +86,13 @@ type Processor struct {
 	account             account.Processor
 	admin               admin.Processor
 	advancedmigrations  advancedmigrations.Processor
+	announcements       announcements.Processor
 	application         application.Processor
 	conversations       conversations.Processor
 	fedi                fedi.Processor
 	filtersv1           filtersv1.Processor
 	filtersv2           filtersv2.Processor
+	groups              groups.Processor
 	interactionRequests interactionrequests.Processor
 	list                list.Processor
 	markers             markers.Processor
@@ -97,8 +103,10 @@ type Processor struct {
 	search              search.Processor
 	status              status.Processor
 	stream              stream.Processor
+	suggestions         suggestions.Processor
 	tags                tags.Processor
 	timeline            timeline.Processor
+	trends              trends.Processor
 	user                user.Processor
 	workers             workers.Processor
 }
@@ -115,6 +123,10 @@ func (p *Processor) AdvancedMigrations() *advancedmigrations.Processor {
 	return &p.advancedmigrations
 }
 
+func (p *Processor) Announcements() *announcements.Processor {
+	return &p.announcements
+}
+
 func (p *Processor) Application() *application.Processor {
 	return &p.application
 }
@@ -135,6 +147,10 @@ func (p *Processor) FiltersV2() *filtersv2.Processor {
 	return &p.filtersv2
 }
 
+func (p *Processor) Groups() *groups.Processor {
+	return &p.groups
+}
+
 func (p *Processor) InteractionRequests() *interactionrequests.Processor {
 	return &p.interactionRequests
 }
@@ -175,6 +191,10 @@ func (p *Processor) Stream() *stream.Processor {
 	return &p.stream
 }
 
+func (p *Processor) Suggestions() *suggestions.Processor {
+	return &p.suggestions
+}
+
 func (p *Processor) Tags() *tags.Processor {
 	return &p.tags
 }
@@ -183,6 +203,10 @@ func (p *Processor) Timeline() *timeline.Processor {
 	return &p.timeline
 }
 
+func (p *Processor) Trends() *trends.Processor {
+	return &p.trends
+}
+
 func (p *Processor) User() *user.Processor {
 	return &p.user
 }
@@ -219,14 +243,15 @@ func NewProcessor(
 	// Start with sub processors that will
 	// be required by the workers processor.
 	common := common.New(state, mediaManager, converter, federator, visFilter)
-	processor.account = account.New(&common, state, converter, mediaManager, federator, visFilter, parseMentionFunc)
+	processor.account = account.New(&common, state, converter, mediaManager, federator, visFilter, parseMentionFunc, emailSender)
 	processor.media = media.New(&common, state, converter, federator, mediaManager, federator.TransportController())
 	processor.stream = stream.New(state, oauthServer)
 
 	// Instantiate the rest of the sub
 	// processors + pin them to this struct.
-	processor.account = account.New(&common, state, converter, mediaManager, federator, visFilter, parseMentionFunc)
+	processor.account = account.New(&common, state, converter, mediaManager, federator, visFilter, parseMentionFunc, emailSender)
 	processor.admin = admin.New(&common, state, cleaner, subscriptions, federator, converter, mediaManager, federator.TransportController(), emailSender)
+	processor.announcements = announcements.New(state, converter, &processor.stream, parseMentionFunc)
 	processor.application = application.New(state, converter)
 	processor.conversations = conversations.New(state, converter, visFilter)
 	processor.fedi = fedi.New(state, &common, converter, federator, visFilter)
@@ -238,10 +263,13 @@ func NewProcessor(
 	processor.polls = polls.New(&common, state, converter)
 	processor.push = push.New(state, converter)
 	processor.report = report.New(state, converter)
+	processor.suggestions = suggestions.New(state, converter)
 	processor.tags = tags.New(state, converter)
 	processor.timeline = timeline.New(state, converter, visFilter)
+	processor.trends = trends.New(state, converter, visFilter)
 	processor.search = search.New(state, federator, converter, visFilter)
 	processor.status = status.New(state, &common, &processor.polls, &processor.interactionRequests, federator, converter, visFilter, intFilter, parseMentionFunc)
+	processor.groups = groups.New(state, converter, parseMentionFunc, &processor.account, &processor.status)
 	processor.user = user.New(state, converter, oauthServer, emailSender)
 
 	// The advanced migrations processor sequences advanced migrations from all other processors.
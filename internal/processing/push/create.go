@@ -19,11 +19,14 @@
 
 import (
 	"context"
+	"errors"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
 )
 
@@ -46,11 +49,25 @@ func (p *Processor) CreateOrReplace(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Keep a recoverable copy of the access token
+	// to include in notification payloads, as
+	// the token itself is only stored hashed.
+	sealedAccessToken, err := oauth.SealToken(accessToken)
+	if errors.Is(err, oauth.ErrNoTokenSecret) {
+		// No secret to seal it with, so don't
+		// keep it; payloads just won't include it.
+		log.Warnf(ctx, "not storing access token for Web Push subscription of token ID %s: %v", tokenID, err)
+	} else if err != nil {
+		err := gtserror.Newf("couldn't seal access token for token ID %s: %w", tokenID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	// Insert a new one.
 	subscription := &gtsmodel.WebPushSubscription{
 		ID:                id.NewULID(),
 		AccountID:         accountID,
 		TokenID:           tokenID,
+		SealedAccessToken: sealedAccessToken,
 		Endpoint:          request.Subscription.Endpoint,
 		Auth:              request.Subscription.Keys.Auth,
 		P256dh:            request.Subscription.Keys.P256dh,
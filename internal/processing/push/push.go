@@ -23,6 +23,7 @@
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
 	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
 )
@@ -42,7 +43,7 @@ func New(state *state.State, converter *typeutils.Converter) Processor {
 // getTokenID returns the token ID for a given access token.
 // Since all push API calls require authentication, this should always be available.
 func (p *Processor) getTokenID(ctx context.Context, accessToken string) (string, gtserror.WithCode) {
-	token, err := p.state.DB.GetTokenByAccess(ctx, accessToken)
+	token, err := p.state.DB.GetTokenByAccess(ctx, oauth.HashToken(accessToken))
 	if err != nil {
 		err := gtserror.Newf("couldn't find token ID for access token: %w", err)
 		return "", gtserror.NewErrorInternalError(err)
@@ -80,6 +81,9 @@ func alertsToNotificationFlags(alerts *apimodel.WebPushSubscriptionAlerts) gtsmo
 	n.Set(gtsmodel.NotificationPendingFave, alerts.PendingFavourite)
 	n.Set(gtsmodel.NotificationPendingReply, alerts.PendingReply)
 	n.Set(gtsmodel.NotificationPendingReblog, alerts.PendingReblog)
+	n.Set(gtsmodel.NotificationQuote, alerts.Quote)
+	n.Set(gtsmodel.NotificationPendingQuote, alerts.PendingQuote)
+	n.Set(gtsmodel.NotificationReaction, alerts.Reaction)
 
 	return n
 }
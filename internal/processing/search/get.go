@@ -119,21 +119,6 @@ func (p *Processor) Get(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
-		return p.packageSearchResult(
-			ctx,
-			account,
-			nil, nil, nil, // No results.
-			req.APIv1,
-			includeInstanceAccounts,
-			includeBlockedAccounts,
-		)
-	}
-
 	var (
 		foundStatuses = make([]*gtsmodel.Status, 0, limit)
 		foundAccounts = make([]*gtsmodel.Account, 0, limit)
@@ -221,6 +206,20 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
+		if offset > 0 {
+			// A URI can only match one account
+			// or status, so there's no further
+			// pages of results beyond the first.
+			return p.packageSearchResult(
+				ctx,
+				account,
+				nil, nil, nil, // No results.
+				req.APIv1,
+				includeInstanceAccounts,
+				includeBlockedAccounts,
+			)
+		}
+
 		if err := p.byURI(
 			ctx,
 			account,
@@ -301,6 +300,20 @@ func (p *Processor) Get(
 	// have 'mastodon' in the domain, and therefore in
 	// the username, making the search results useless.
 	includeInstanceAccounts = false
+
+	// Parse any search operators out of
+	// the query, bailing early if invalid.
+	parsed, errWithCode := p.parseQuery(ctx, account, query)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if fromAccountID != "" {
+		// Account specified by caller in request
+		// params takes precedence over any 'from:'.
+		parsed.statusParams.AccountIDs = []string{fromAccountID}
+	}
+
 	if err := p.byText(
 		ctx,
 		account,
@@ -308,10 +321,9 @@ func (p *Processor) Get(
 		minID,
 		limit,
 		offset,
-		query,
+		parsed,
 		queryType,
 		following,
-		fromAccountID,
 		appendAccount,
 		appendStatus,
 	); err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -372,6 +384,13 @@ func (p *Processor) accountsByUsernameDomain(
 		)
 	}
 
+	if offset > 0 {
+		// Domain and username were both set, so
+		// this can only match one account; there
+		// are no further pages beyond the first.
+		return nil
+	}
+
 	// Domain and username were both set.
 	// Caller is likely trying to search for an exact
 	// match, from either a remote instance or local.
@@ -739,7 +758,7 @@ func (p *Processor) hashtag(
 }
 
 // byText searches in the database for accounts and/or
-// statuses containing the given query string, using
+// statuses matching the given parsed query, using
 // the provided parameters.
 //
 // If queryType is any (empty string), both accounts
@@ -752,10 +771,9 @@ func (p *Processor) byText(
 	minID string,
 	limit int,
 	offset int,
-	query string,
+	parsed *parsedQuery,
 	queryType string,
 	following bool,
-	fromAccountID string,
 	appendAccount func(*gtsmodel.Account),
 	appendStatus func(*gtsmodel.Status),
 ) error {
@@ -767,7 +785,10 @@ func (p *Processor) byText(
 		minID = ""
 	}
 
-	if includeAccounts(queryType) {
+	// Operators only apply to statuses,
+	// so only search accounts if there's
+	// some text left over to search by.
+	if includeAccounts(queryType) && parsed.text != "" {
 		// Search for accounts using the given text.
 		if err := p.accountsByText(ctx,
 			requestingAccount.ID,
@@ -775,7 +796,7 @@ func (p *Processor) byText(
 			minID,
 			limit,
 			offset,
-			query,
+			parsed.text,
 			following,
 			appendAccount,
 		); err != nil {
@@ -784,15 +805,14 @@ func (p *Processor) byText(
 	}
 
 	if includeStatuses(queryType) {
-		// Search for statuses using the given text.
+		// Search for statuses using the given params.
 		if err := p.statusesByText(ctx,
 			requestingAccount.ID,
 			maxID,
 			minID,
 			limit,
 			offset,
-			query,
-			fromAccountID,
+			&parsed.statusParams,
 			appendStatus,
 		); err != nil {
 			return err
@@ -831,7 +851,7 @@ func (p *Processor) accountsByText(
 }
 
 // statusesByText searches in the database for limit
-// number of statuses using the given query text.
+// number of statuses using the given search params.
 func (p *Processor) statusesByText(
 	ctx context.Context,
 	requestingAccountID string,
@@ -839,33 +859,20 @@ func (p *Processor) statusesByText(
 	minID string,
 	limit int,
 	offset int,
-	query string,
-	fromAccountID string,
+	params *db.StatusSearchParams,
 	appendStatus func(*gtsmodel.Status),
 ) error {
-	parsed, err := p.parseQuery(ctx, query)
-	if err != nil {
-		return err
-	}
-	query = parsed.query
-	// If the owning account for statuses was not provided as the account_id query parameter,
-	// it may still have been provided as a search operator in the query string.
-	if fromAccountID == "" {
-		fromAccountID = parsed.fromAccountID
-	}
-
 	statuses, err := p.state.DB.SearchForStatuses(
 		ctx,
 		requestingAccountID,
-		query,
-		fromAccountID,
+		params,
 		maxID,
 		minID,
 		limit,
 		offset,
 	)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return gtserror.Newf("error checking database for statuses using text %s: %w", query, err)
+		return gtserror.Newf("error checking database for statuses: %w", err)
 	}
 
 	for _, status := range statuses {
@@ -874,60 +881,3 @@ func (p *Processor) statusesByText(
 
 	return nil
 }
-
-// parsedQuery represents the results of parsing the search operator terms within a query.
-type parsedQuery struct {
-	// query is the original search query text with operator terms removed.
-	query string
-	// fromAccountID is the account from a successfully resolved `from:` operator, if present.
-	fromAccountID string
-}
-
-// parseQuery parses query text and handles any search operator terms present.
-func (p *Processor) parseQuery(ctx context.Context, query string) (parsed parsedQuery, err error) {
-	queryPartSeparator := " "
-	queryParts := strings.Split(query, queryPartSeparator)
-	nonOperatorQueryParts := make([]string, 0, len(queryParts))
-	for _, queryPart := range queryParts {
-		if arg, hasPrefix := strings.CutPrefix(queryPart, "from:"); hasPrefix {
-			parsed.fromAccountID, err = p.parseFromOperatorArg(ctx, arg)
-			if err != nil {
-				return
-			}
-		} else {
-			nonOperatorQueryParts = append(nonOperatorQueryParts, queryPart)
-		}
-	}
-	parsed.query = strings.Join(nonOperatorQueryParts, queryPartSeparator)
-	return
-}
-
-// parseFromOperatorArg attempts to parse the from: operator's argument as an account name,
-// and returns the account ID if possible. Allows specifying an account name with or without a leading @.
-func (p *Processor) parseFromOperatorArg(ctx context.Context, namestring string) (string, error) {
-	if namestring == "" {
-		return "", gtserror.New(
-			"the 'from:' search operator requires an account name, but it wasn't provided",
-		)
-	}
-	if namestring[0] != '@' {
-		namestring = "@" + namestring
-	}
-
-	username, domain, err := util.ExtractNamestringParts(namestring)
-	if err != nil {
-		return "", gtserror.Newf(
-			"the 'from:' search operator couldn't parse its argument as an account name: %w",
-			err,
-		)
-	}
-	account, err := p.state.DB.GetAccountByUsernameDomain(gtscontext.SetBarebones(ctx), username, domain)
-	if err != nil {
-		return "", gtserror.Newf(
-			"the 'from:' search operator couldn't find the requested account name: %w",
-			err,
-		)
-	}
-
-	return account.ID, nil
-}
@@ -0,0 +1,393 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+const (
+	operatorFrom     = "from"
+	operatorHas      = "has"
+	operatorIs       = "is"
+	operatorIn       = "in"
+	operatorLanguage = "language"
+	operatorBefore   = "before"
+	operatorAfter    = "after"
+	operatorDuring   = "during"
+
+	// Date format accepted by
+	// before:, after: and during:.
+	operatorDateFormat = "2006-01-02"
+)
+
+// queryClause is a single clause of a tokenized
+// search query. This is either a text term or
+// "quoted phrase", or an operator:value pair,
+// either of which may be negated with a leading '-'.
+type queryClause struct {
+	// negated is true if the
+	// clause was prefixed by '-'.
+	negated bool
+
+	// operator is the lowercased name of
+	// the operator, or empty for text clauses.
+	operator string
+
+	// value is the text term or phrase,
+	// or the value given to operator.
+	value string
+}
+
+// parsedQuery is the result of compiling
+// a tokenized search query for the database.
+type parsedQuery struct {
+	// text contains the non-negated text
+	// clauses of the query, space-separated,
+	// suitable for searching accounts with.
+	text string
+
+	// statusParams contains all clauses of
+	// the query, for searching statuses with.
+	statusParams db.StatusSearchParams
+}
+
+// tokenizeQuery splits the given query on whitespace into
+// clauses, respecting double-quoted phrases and values.
+//
+// An error is returned for an operator without a value.
+func tokenizeQuery(query string) ([]queryClause, error) {
+	var (
+		clauses []queryClause
+		rs      = []rune(query)
+		i       = 0
+	)
+
+	for i < len(rs) {
+		// Skip any whitespace
+		// between clauses.
+		if unicode.IsSpace(rs[i]) {
+			i++
+			continue
+		}
+
+		var clause queryClause
+
+		// Check for negation; a lone '-'
+		// is just treated as text though.
+		if rs[i] == '-' && i+1 < len(rs) && !unicode.IsSpace(rs[i+1]) {
+			clause.negated = true
+			i++
+		}
+
+		// Check for an operator, ie.,
+		// a run of letters then ':'.
+		j := i
+		for j < len(rs) && isOperatorRune(rs[j]) {
+			j++
+		}
+		if j > i && j < len(rs) && rs[j] == ':' {
+			clause.operator = strings.ToLower(string(rs[i:j]))
+			i = j + 1
+		}
+
+		// Read value, either up to a closing
+		// quote if it's quoted, or whitespace.
+		if i < len(rs) && rs[i] == '"' {
+			i++
+			j = i
+			for j < len(rs) && rs[j] != '"' {
+				j++
+			}
+			clause.value = string(rs[i:j])
+			i = j + 1
+		} else {
+			j = i
+			for j < len(rs) && !unicode.IsSpace(rs[j]) {
+				j++
+			}
+			clause.value = string(rs[i:j])
+			i = j
+		}
+
+		clause.value = strings.TrimSpace(clause.value)
+		if clause.value == "" {
+			if clause.operator != "" {
+				return nil, fmt.Errorf("search operator '%s:' requires a value", clause.operator)
+			}
+
+			// Just
+			// empty
+			// quotes.
+			continue
+		}
+
+		clauses = append(clauses, clause)
+	}
+
+	return clauses, nil
+}
+
+// isOperatorRune returns true if r
+// may be part of an operator name.
+func isOperatorRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// parseQuery tokenizes the given search query text, and
+// compiles the resulting clauses into a parsedQuery.
+//
+// A 422 error is returned if the query uses an unknown
+// operator, or an operator with an invalid value.
+func (p *Processor) parseQuery(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	query string,
+) (*parsedQuery, gtserror.WithCode) {
+	clauses, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	var (
+		parsed = new(parsedQuery)
+		params = &parsed.statusParams
+		text   = make([]string, 0, len(clauses))
+	)
+
+	for _, clause := range clauses {
+		if errWithCode := p.applyClause(ctx, requester, params, clause); errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if clause.operator == "" && !clause.negated {
+			text = append(text, clause.value)
+		}
+	}
+
+	parsed.text = strings.Join(text, " ")
+	return parsed, nil
+}
+
+// applyClause applies the given query
+// clause to the status search params.
+func (p *Processor) applyClause(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	params *db.StatusSearchParams,
+	clause queryClause,
+) gtserror.WithCode {
+	var err error
+
+	switch clause.operator {
+
+	// Text term or phrase.
+	case "":
+		if clause.negated {
+			params.ExcludeTerms = append(params.ExcludeTerms, clause.value)
+		} else {
+			params.Terms = append(params.Terms, clause.value)
+		}
+
+	// Statuses by account.
+	case operatorFrom:
+		accountID, errWithCode := p.parseFromValue(ctx, requester, clause.value)
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		if clause.negated {
+			params.ExcludeAccountIDs = append(params.ExcludeAccountIDs, accountID)
+		} else {
+			params.AccountIDs = append(params.AccountIDs, accountID)
+		}
+
+	// Statuses with or without something.
+	case operatorHas:
+		want := !clause.negated
+		switch strings.ToLower(clause.value) {
+		case "media":
+			params.HasMedia = &want
+		case "poll":
+			params.HasPoll = &want
+		case "link":
+			params.HasLink = &want
+		default:
+			err = fmt.Errorf(
+				"search operator 'has:' value %s was not recognized, valid options are ['media', 'poll', 'link']",
+				clause.value,
+			)
+		}
+
+	// Statuses that are or aren't something.
+	case operatorIs:
+		want := !clause.negated
+		switch strings.ToLower(clause.value) {
+		case "reply":
+			params.IsReply = &want
+		case "sensitive":
+			params.IsSensitive = &want
+		default:
+			err = fmt.Errorf(
+				"search operator 'is:' value %s was not recognized, valid options are ['reply', 'sensitive']",
+				clause.value,
+			)
+		}
+
+	// Statuses in a wider scope.
+	case operatorIn:
+		if clause.negated {
+			err = errors.New("search operator 'in:' cannot be negated")
+			break
+		}
+
+		switch strings.ToLower(clause.value) {
+		case "library":
+			params.InLibrary = true
+		default:
+			err = fmt.Errorf(
+				"search operator 'in:' value %s was not recognized, valid options are ['library']",
+				clause.value,
+			)
+		}
+
+	// Statuses in a language.
+	case operatorLanguage:
+		lang, langErr := validate.Language(clause.value)
+		if langErr != nil {
+			err = fmt.Errorf("search operator 'language:' value %s was not a valid language", clause.value)
+			break
+		}
+
+		if clause.negated {
+			params.ExcludeLanguages = append(params.ExcludeLanguages, lang)
+		} else {
+			params.Languages = append(params.Languages, lang)
+		}
+
+	// Statuses within a date range.
+	case operatorBefore, operatorAfter, operatorDuring:
+		if clause.negated {
+			err = fmt.Errorf("search operator '%s:' cannot be negated", clause.operator)
+			break
+		}
+
+		day, parseErr := time.Parse(operatorDateFormat, clause.value)
+		if parseErr != nil {
+			err = fmt.Errorf(
+				"search operator '%s:' value %s was not a valid date, expected format YYYY-MM-DD",
+				clause.operator, clause.value,
+			)
+			break
+		}
+
+		// Dates are taken to be whole
+		// days in UTC, so after: and
+		// during: include the whole day.
+		nextDay := day.AddDate(0, 0, 1)
+		switch clause.operator {
+		case operatorBefore:
+			params.Before = earliest(params.Before, day)
+		case operatorAfter:
+			params.After = latest(params.After, nextDay)
+		case operatorDuring:
+			params.After = latest(params.After, day)
+			params.Before = earliest(params.Before, nextDay)
+		}
+
+	default:
+		err = fmt.Errorf(
+			"search operator '%s:' was not recognized, valid options are ['%s:', '%s:', '%s:', '%s:', '%s:', '%s:', '%s:', '%s:']",
+			clause.operator,
+			operatorFrom, operatorHas, operatorIs, operatorIn,
+			operatorLanguage, operatorBefore, operatorAfter, operatorDuring,
+		)
+	}
+
+	if err != nil {
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return nil
+}
+
+// parseFromValue parses the from: operator's value as an account name
+// with or without a leading @, or "me" for requester, returning the ID
+// of the corresponding account.
+func (p *Processor) parseFromValue(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	namestring string,
+) (string, gtserror.WithCode) {
+	if strings.EqualFold(namestring, "me") {
+		return requester.ID, nil
+	}
+
+	if namestring[0] != '@' {
+		namestring = "@" + namestring
+	}
+
+	username, domain, err := util.ExtractNamestringParts(namestring)
+	if err != nil {
+		err := fmt.Errorf("search operator 'from:' couldn't parse %s as an account name", namestring)
+		return "", gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	account, err := p.state.DB.GetAccountByUsernameDomain(gtscontext.SetBarebones(ctx), username, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", namestring, err)
+		return "", gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := fmt.Errorf("search operator 'from:' couldn't find account %s", namestring)
+		return "", gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	return account.ID, nil
+}
+
+// earliest returns the earlier of
+// t and u, ignoring t if it's zero.
+func earliest(t time.Time, u time.Time) time.Time {
+	if t.IsZero() || u.Before(t) {
+		return u
+	}
+	return t
+}
+
+// latest returns the later of
+// t and u, ignoring t if it's zero.
+func latest(t time.Time, u time.Time) time.Time {
+	if t.IsZero() || u.After(t) {
+		return u
+	}
+	return t
+}
@@ -73,6 +73,19 @@ func (p *Processor) packageAccounts(
 			continue
 		}
 
+		// Check if searchee is silenced to searcher.
+		silenced, err := p.visFilter.AccountSilenced(ctx, requestingAccount, account)
+		if err != nil {
+			err = gtserror.Newf("error checking silence of searched account %s for searching account %s: %w", account.ID, requestingAccount.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if silenced {
+			// Silenced accounts are only
+			// shown to their followers.
+			continue
+		}
+
 		var apiAccount *apimodel.Account
 		if blocked {
 			apiAccount, err = p.converter.AccountToAPIAccountBlocked(ctx, account)
@@ -114,6 +127,18 @@ func (p *Processor) packageStatuses(
 			continue
 		}
 
+		// Ensure result status author isn't silenced to requester.
+		silenced, err := p.visFilter.StatusAuthorSilenced(ctx, requestingAccount, status)
+		if err != nil {
+			err = gtserror.Newf("error checking silence of status %s author for account %s: %w", status.ID, requestingAccount.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if silenced {
+			log.Debugf(ctx, "status %s author is silenced to account %s, skipping this result", status.ID, requestingAccount.ID)
+			continue
+		}
+
 		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requestingAccount, statusfilter.FilterContextNone, nil, nil)
 		if err != nil {
 			log.Debugf(ctx, "skipping status %s because it couldn't be converted to its api representation: %s", status.ID, err)
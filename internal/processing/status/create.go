@@ -19,14 +19,11 @@
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
-	"code.superseriousbusiness.org/gotosocial/internal/db"
-	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
@@ -108,11 +105,11 @@ func (p *Processor) Create(
 	if form.ScheduledAt != nil {
 		scheduledAt := *form.ScheduledAt
 
-		// Statuses may only be scheduled
-		// a minimum time into the future.
+		// Statuses scheduled into the future
+		// must go via ScheduledStatusesCreate.
 		if now.Before(scheduledAt) {
-			const errText = "scheduled statuses are not yet supported"
-			return nil, gtserror.NewErrorNotImplemented(gtserror.New(errText), errText)
+			const errText = "statuses with a future scheduled_at must be scheduled, not created"
+			return nil, gtserror.NewErrorUnprocessableEntity(gtserror.New(errText), errText)
 		}
 
 		// If not scheduled into the future, this status is being backfilled.
@@ -141,7 +138,7 @@ func (p *Processor) Create(
 		createdAt = scheduledAt
 
 		// Generate an appropriate, (and unique!), ID for the creation time.
-		if statusID, err = p.backfilledStatusID(ctx, createdAt); err != nil {
+		if statusID, err = p.c.BackfilledStatusID(ctx, createdAt); err != nil {
 			return nil, gtserror.NewErrorInternalError(err)
 		}
 	}
@@ -186,7 +183,8 @@ func (p *Processor) Create(
 
 		// Assume not pending approval; this may
 		// change when permissivity is checked.
-		PendingApproval: util.Ptr(false),
+		PendingApproval:      util.Ptr(false),
+		QuotePendingApproval: util.Ptr(false),
 	}
 
 	// Only store ContentWarningText if the parsed
@@ -230,9 +228,21 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
-	if status.ContentWarning != "" && len(status.AttachmentIDs) > 0 {
-		// If a content-warning is set, and
-		// the status contains media, always
+	// Check + attach quoted status.
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuotedStatusID,
+		backfill,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if (status.ContentWarning != "" || requester.IsSensitized()) &&
+		len(status.AttachmentIDs) > 0 {
+		// If a content-warning is set, or the
+		// account has been sensitized by an admin,
+		// and the status contains media, always
 		// set the status sensitive flag.
 		status.Sensitive = util.Ptr(true)
 	}
@@ -312,42 +322,6 @@ func (p *Processor) Create(
 	return p.c.GetAPIStatus(ctx, requester, status)
 }
 
-// backfilledStatusID tries to find an unused ULID for a backfilled status.
-func (p *Processor) backfilledStatusID(ctx context.Context, createdAt time.Time) (string, error) {
-
-	// Any fetching of statuses here is
-	// only to check availability of ID,
-	// no need for any attached models.
-	ctx = gtscontext.SetBarebones(ctx)
-
-	// backfilledStatusIDRetries should
-	// be more than enough attempts.
-	const backfilledStatusIDRetries = 100
-	for try := 0; try < backfilledStatusIDRetries; try++ {
-		var err error
-
-		// Generate a ULID based on the backfilled
-		// status's original creation time.
-		statusID := id.NewULIDFromTime(createdAt)
-
-		// Check for an existing status with that ID.
-		status, err := p.state.DB.GetStatusByID(ctx, statusID)
-		if err != nil && !errors.Is(err, db.ErrNoEntries) {
-			return "", gtserror.Newf("DB error checking if a status ID was in use: %w", err)
-		}
-
-		if status == nil {
-			// We found a free ID!
-			return statusID, nil
-		}
-
-		// That status ID is
-		// in use. Try again.
-	}
-
-	return "", gtserror.Newf("failed to find an unused ID after %d tries", backfilledStatusIDRetries)
-}
-
 func (p *Processor) processInReplyTo(
 	ctx context.Context,
 	requester *gtsmodel.Account,
@@ -444,6 +418,103 @@ func (p *Processor) processInReplyTo(
 	return nil
 }
 
+func (p *Processor) processQuote(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+	quotedStatusID string,
+	backfill bool,
+) gtserror.WithCode {
+	if quotedStatusID == "" {
+		// Not a quote.
+		// Nothing to do.
+		return nil
+	}
+
+	// Fetch target quoted status (checking visibility).
+	quoteOf, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		quotedStatusID,
+		nil,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// If this is a boost, unwrap it to get source status.
+	quoteOf, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		quoteOf,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Ensure valid quote target for requester.
+	policyResult, err := p.intFilter.StatusQuotable(ctx,
+		requester,
+		quoteOf,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is quotable: %w", quoteOf.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if policyResult.Forbidden() {
+		const errText = "you do not have permission to quote this status"
+		err := gtserror.New(errText)
+		return gtserror.NewErrorForbidden(err, errText)
+	}
+
+	// When backfilling, only self-quotes are allowed.
+	if backfill && requester.ID != quoteOf.AccountID {
+		const errText = "quotes of others can't be backfilled"
+		err := gtserror.New(errText)
+		return gtserror.NewErrorForbidden(err, errText)
+	}
+
+	// Derive quotePendingApproval status.
+	var quotePendingApproval bool
+	switch {
+	case policyResult.WithApproval():
+		// We're allowed to do
+		// this pending approval.
+		quotePendingApproval = true
+
+	case policyResult.MatchedOnCollection():
+		// We're permitted to do this, but since
+		// we matched due to presence in a followers
+		// or following collection, we should mark
+		// as pending approval and wait until we can
+		// prove it's been Accepted by the target.
+		quotePendingApproval = true
+
+		if *quoteOf.Local {
+			// If the target is local we don't need
+			// to wait for an Accept from remote,
+			// we can just preapprove it and have
+			// the processor create the Accept.
+			status.QuotePreApproved = true
+		}
+
+	case policyResult.Permitted():
+		// We're permitted to do this
+		// based on another kind of match.
+		quotePendingApproval = false
+	}
+
+	status.QuotePendingApproval = &quotePendingApproval
+
+	// Set status fields from quoteOf.
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+	status.QuoteOfURI = quoteOf.URI
+	status.QuoteOfAccountID = quoteOf.AccountID
+	status.QuoteOfAccount = quoteOf.Account
+
+	return nil
+}
+
 func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
 	// Status takes the thread ID of
 	// whatever it replies to, if set.
@@ -19,6 +19,7 @@
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
@@ -241,6 +242,65 @@ func (suite *StatusCreateTestSuite) TestProcessNoContentTypeUsesDefault() {
 	suite.Equal(apimodel.StatusContentTypeDefault, apiStatus.ContentType)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessQuote() {
+	ctx := context.Background()
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoted := suite.testStatuses["local_account_2_status_1"]
+
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:         "look at this!",
+		QuotedStatusID: quoted.ID,
+		Visibility:     apimodel.VisibilityPublic,
+		LocalOnly:      util.Ptr(false),
+		Language:       "en",
+		ContentType:    apimodel.StatusContentTypePlain,
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+
+	// Status is public, so anyone
+	// can quote it without approval.
+	suite.NotNil(apiStatus.Quote)
+	suite.Equal("accepted", apiStatus.Quote.State)
+	suite.Equal(quoted.ID, apiStatus.Quote.QuotedStatus.ID)
+
+	dbStatus, err := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(quoted.ID, dbStatus.QuoteOfID)
+	suite.Equal(quoted.URI, dbStatus.QuoteOfURI)
+	suite.Equal(quoted.AccountID, dbStatus.QuoteOfAccountID)
+	suite.False(*dbStatus.QuotePendingApproval)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteNotPermitted() {
+	ctx := context.Background()
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Followers-only status, which by
+	// default only the author can quote.
+	quoted := suite.testStatuses["local_account_2_status_7"]
+
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:         "look at this!",
+		QuotedStatusID: quoted.ID,
+		Visibility:     apimodel.VisibilityPublic,
+		LocalOnly:      util.Ptr(false),
+		Language:       "en",
+		ContentType:    apimodel.StatusContentTypePlain,
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.Equal("Forbidden: you do not have permission to quote this status", errWithCode.Safe())
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
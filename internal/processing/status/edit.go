@@ -84,6 +84,12 @@ func (p *Processor) Edit(
 		return nil, errWithCode
 	}
 
+	if requester.IsSensitized() && len(form.MediaIDs) > 0 {
+		// Media posted by an account that's been
+		// sensitized by an admin is always sensitive.
+		form.Sensitive = true
+	}
+
 	// Process incoming content type.
 	contentType := processContentType(form.ContentType, status, requester.Settings.StatusContentType)
 
@@ -0,0 +1,267 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package status
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+func (p *Processor) getReactableStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+) (*gtsmodel.Status, gtserror.WithCode) {
+	// Get target status and ensure it's not a boost.
+	target, errWithCode := p.c.GetVisibleTargetStatus(
+		ctx,
+		requester,
+		targetID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.c.UnwrapIfBoost(
+		ctx,
+		requester,
+		target,
+	)
+}
+
+// ReactionAdd adds a reaction with the given name (either a unicode
+// emoji or the shortcode of a local custom emoji) by the requester
+// to the status with given ID. Adding a reaction that already exists
+// is a no-op.
+func (p *Processor) ReactionAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetStatusID string,
+	name string,
+) (*apimodel.Status, gtserror.WithCode) {
+	status, errWithCode := p.getReactableStatus(ctx, requester, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Allow shortcodes to be given
+	// with or without the colons.
+	name = strings.Trim(name, ":")
+
+	existing, err := p.state.DB.GetStatusReaction(ctx,
+		requester.ID,
+		status.ID,
+		name,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		// Already reacted,
+		// nothing to do.
+		return p.c.GetAPIStatus(ctx, requester, status)
+	}
+
+	// Reactions are gated by the
+	// same policy rules as faves.
+	policyResult, err := p.intFilter.StatusLikeable(ctx,
+		requester,
+		status,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is likeable: %w", status.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policyResult.Forbidden() {
+		const errText = "you do not have permission to react to this status"
+		err := gtserror.New(errText)
+		return nil, gtserror.NewErrorForbidden(err, errText)
+	}
+
+	if policyResult.WithApproval() {
+		// Unlike faves, reactions can't
+		// be pending approval, so don't
+		// allow this if not permitted.
+		const errText = "reacting to this status requires approval, which is not supported for reactions"
+		err := gtserror.New(errText)
+		return nil, gtserror.NewErrorForbidden(err, errText)
+	}
+
+	reactionID := id.NewULID()
+	reaction := &gtsmodel.StatusReaction{
+		ID:              reactionID,
+		AccountID:       requester.ID,
+		Account:         requester,
+		TargetAccountID: status.AccountID,
+		TargetAccount:   status.Account,
+		StatusID:        status.ID,
+		Status:          status,
+		Name:            name,
+		URI:             uris.GenerateURIForLike(requester.Username, reactionID),
+	}
+
+	if validate.EmojiShortcode(name) == nil {
+		// Name looks like a shortcode,
+		// ensure it's a usable local emoji.
+		emoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, name, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if emoji == nil || *emoji.Disabled {
+			const text = "name is not a recognized emoji"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+	} else if err := validate.UnicodeEmoji(name); err != nil {
+		const text = "name is not a recognized emoji"
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	}
+
+	if err := p.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		err := gtserror.Newf("db error putting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process new reaction side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Origin:         requester,
+		Target:         status.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// ReactionRemove removes the reaction with the given name by the
+// requester from the status with given ID. Removing a reaction
+// that doesn't exist is a no-op.
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetStatusID string,
+	name string,
+) (*apimodel.Status, gtserror.WithCode) {
+	status, errWithCode := p.getReactableStatus(ctx, requester, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	reaction, err := p.state.DB.GetStatusReaction(ctx,
+		requester.ID,
+		status.ID,
+		strings.Trim(name, ":"),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if reaction == nil {
+		// Not reacted,
+		// nothing to do.
+		return p.c.GetAPIStatus(ctx, requester, status)
+	}
+
+	if err := p.state.DB.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		err := gtserror.Newf("db error deleting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process remove reaction side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityUndo,
+		GTSModel:       reaction,
+		Origin:         requester,
+		Target:         status.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// ReactionsGet returns the reactions to the given status, grouped
+// by emoji, and filtered according to blocks. If name is set, then
+// only reactions with that name will be returned.
+func (p *Processor) ReactionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetStatusID string,
+	name string,
+) ([]*apimodel.StatusReaction, gtserror.WithCode) {
+	status, errWithCode := p.getReactableStatus(ctx, requester, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	reactions, err := p.state.DB.GetStatusReactions(ctx, status.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting reactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	name = strings.Trim(name, ":")
+
+	// Only include reactions with the given name (if
+	// set), by accounts the requester can see.
+	visible := make([]*gtsmodel.StatusReaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		if name != "" && reaction.Name != name {
+			continue
+		}
+
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, reaction.AccountID)
+		if err != nil {
+			err := gtserror.Newf("error checking blocks: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			continue
+		}
+
+		visible = append(visible, reaction)
+	}
+
+	apiReactions, err := p.converter.StatusReactionsToAPIStatusReactions(ctx, visible, requester)
+	if err != nil {
+		err := gtserror.Newf("error converting reactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReactions, nil
+}
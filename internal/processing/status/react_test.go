@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusReactTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusReactTestSuite) TestReactAddRemove() {
+	ctx := context.Background()
+
+	reactingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	// react with a unicode emoji
+	_, errWithCode := suite.status.ReactionAdd(ctx, reactingAccount, targetStatus.ID, "🐸")
+	suite.NoError(errWithCode)
+
+	// react with a local custom emoji, with colons
+	_, errWithCode = suite.status.ReactionAdd(ctx, reactingAccount, targetStatus.ID, ":rainbow:")
+	suite.NoError(errWithCode)
+
+	// react again with the same emoji, should be a no-op
+	_, errWithCode = suite.status.ReactionAdd(ctx, reactingAccount, targetStatus.ID, "🐸")
+	suite.NoError(errWithCode)
+
+	reactions, errWithCode := suite.status.ReactionsGet(ctx, reactingAccount, targetStatus.ID, "")
+	suite.NoError(errWithCode)
+	if suite.Len(reactions, 2) {
+		suite.Equal("🐸", reactions[0].Name)
+		suite.Equal(1, reactions[0].Count)
+		suite.True(reactions[0].Me)
+		suite.Empty(reactions[0].URL)
+		suite.Equal("rainbow", reactions[1].Name)
+		suite.NotEmpty(reactions[1].URL)
+	}
+
+	// only get reactions with one emoji
+	reactions, errWithCode = suite.status.ReactionsGet(ctx, reactingAccount, targetStatus.ID, "rainbow")
+	suite.NoError(errWithCode)
+	suite.Len(reactions, 1)
+
+	// remove unicode emoji reaction
+	_, errWithCode = suite.status.ReactionRemove(ctx, reactingAccount, targetStatus.ID, "🐸")
+	suite.NoError(errWithCode)
+
+	reactions, errWithCode = suite.status.ReactionsGet(ctx, reactingAccount, targetStatus.ID, "")
+	suite.NoError(errWithCode)
+	if suite.Len(reactions, 1) {
+		suite.Equal("rainbow", reactions[0].Name)
+	}
+}
+
+func (suite *StatusReactTestSuite) TestReactNotEmoji() {
+	ctx := context.Background()
+
+	reactingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	for _, name := range []string{
+		"not an emoji",   // just text
+		":not_an_emoji:", // unknown shortcode
+		"🐸 frog",         // emoji with text
+		"yell",           // remote emoji
+	} {
+		_, errWithCode := suite.status.ReactionAdd(ctx, reactingAccount, targetStatus.ID, name)
+		if suite.NotNil(errWithCode, name) {
+			suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code(), name)
+		}
+	}
+}
+
+func TestStatusReactTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactTestSuite))
+}
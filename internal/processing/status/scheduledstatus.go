@@ -0,0 +1,664 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+// minScheduledStatusDelay is the minimum amount
+// of time into the future that a status may be
+// scheduled for, as per the Mastodon API.
+const minScheduledStatusDelay = 5 * time.Minute
+
+const (
+	// scheduledStatusMaxAttempts is the number
+	// of times publishing a scheduled status is
+	// attempted before it's given up on.
+	scheduledStatusMaxAttempts = 5
+
+	// scheduledStatusRetryBackoff is the delay
+	// before retrying publishing a scheduled
+	// status, doubled with each failed attempt.
+	scheduledStatusRetryBackoff = time.Minute
+)
+
+// ScheduledStatusesCreate validates the given status creation form and,
+// if OK, stores it as a scheduled status to be published at form.ScheduledAt.
+func (p *Processor) ScheduledStatusesCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.StatusCreateRequest,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	if form.ScheduledAt == nil {
+		const text = "scheduled_at not set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+	scheduledAt := *form.ScheduledAt
+
+	// Ensure scheduled time is valid and
+	// the account hasn't hit any limits.
+	if errWithCode := p.validateScheduledAt(ctx,
+		requester.ID,
+		scheduledAt,
+		nil,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Validate incoming form status content.
+	if errWithCode := validateStatusContent(
+		form.Status,
+		form.SpoilerText,
+		form.MediaIDs,
+		form.Poll,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Ensure account populated; we'll need their settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	// Generate new ID for scheduled status.
+	scheduledStatusID := id.NewULID()
+
+	// Check incoming status attachments are
+	// usable, this also checks media ownership.
+	media, errWithCode := p.processMedia(ctx,
+		requester.ID,
+		scheduledStatusID,
+		form.MediaIDs,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Validate + normalize language, if given,
+	// else this is set when status is published.
+	language := form.Language
+	if language != "" {
+		var err error
+		language, err = validate.Language(language)
+		if err != nil {
+			text := fmt.Sprintf("invalid language tag: %v", err)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	// Determine visibility using a placeholder status,
+	// this also sets form.Visibility to the determined
+	// value, which the interaction policy relies on.
+	var placeholder gtsmodel.Status
+	processVisibility(form, requester.Settings.Privacy, &placeholder)
+
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:               scheduledStatusID,
+		AccountID:        requester.ID,
+		Account:          requester,
+		ScheduledAt:      scheduledAt,
+		Text:             form.Status,
+		MediaIDs:         form.MediaIDs,
+		MediaAttachments: media,
+		Sensitive:        &form.Sensitive,
+		SpoilerText:      form.SpoilerText,
+		Visibility:       placeholder.Visibility,
+		InReplyToID:      form.InReplyToID,
+		QuotedStatusID:   form.QuotedStatusID,
+		Language:         language,
+		ApplicationID:    application.ID,
+		Application:      application,
+		LocalOnly:        util.Ptr(!*placeholder.Federated),
+		ContentType: processContentType(
+			form.ContentType,
+			nil,
+			requester.Settings.StatusContentType,
+		),
+	}
+
+	// Only store an interaction policy if explicitly set,
+	// otherwise the account default at time of publishing
+	// will be used, as with any other status creation.
+	if form.InteractionPolicy != nil {
+		policy, err := typeutils.APIInteractionPolicyToInteractionPolicy(
+			form.InteractionPolicy,
+			form.Visibility,
+		)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		scheduledStatus.InteractionPolicy = policy
+	}
+
+	if form.Poll != nil {
+		scheduledStatus.Poll = &gtsmodel.ScheduledStatusPoll{
+			Options:    form.Poll.Options,
+			ExpiresIn:  form.Poll.ExpiresIn,
+			Multiple:   form.Poll.Multiple,
+			HideTotals: form.Poll.HideTotals,
+		}
+	}
+
+	// Insert this newly prepared scheduled status into the database.
+	if err := p.state.DB.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+		err := gtserror.Newf("error inserting scheduled status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Schedule the status for publication.
+	if err := p.ScheduledStatusesSchedulePublication(ctx,
+		scheduledStatus,
+	); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduledStatus)
+}
+
+// ScheduledStatusesGetPage returns a page of
+// scheduled statuses owned by the requester.
+func (p *Processor) ScheduledStatusesGetPage(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	scheduledStatuses, err := p.state.DB.GetScheduledStatusesForAcct(ctx,
+		requester.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(scheduledStatuses)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = scheduledStatuses[count-1].ID
+		hi = scheduledStatuses[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, scheduledStatus := range scheduledStatuses {
+		apiScheduledStatus, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduledStatus)
+		if err != nil {
+			log.Errorf(ctx, "error converting scheduled status to api scheduled status: %v", err)
+			continue
+		}
+
+		// Append scheduled status to return items.
+		items = append(items, apiScheduledStatus)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/scheduled_statuses",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ScheduledStatusesGetOne returns the scheduled status
+// with the given ID, if it's owned by the requester.
+func (p *Processor) ScheduledStatusesGetOne(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiScheduledStatus(ctx, scheduledStatus)
+}
+
+// ScheduledStatusesUpdate changes the publication time
+// of the scheduled status with the given ID, if it's owned
+// by the requester, rescheduling it for publication.
+func (p *Processor) ScheduledStatusesUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+	scheduledAt *time.Time,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, requester, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if scheduledAt == nil {
+		// Nothing to change.
+		return p.apiScheduledStatus(ctx, scheduledStatus)
+	}
+
+	// Ensure new scheduled time is valid and
+	// the account hasn't hit any daily limits.
+	if errWithCode := p.validateScheduledAt(ctx,
+		requester.ID,
+		*scheduledAt,
+		scheduledStatus,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Update the scheduled status in the database,
+	// giving any failed publishing a fresh start.
+	scheduledStatus.ScheduledAt = *scheduledAt
+	scheduledStatus.PublishAttempts = 0
+	scheduledStatus.PublishFailedAt = time.Time{}
+	if err := p.state.DB.UpdateScheduledStatus(ctx,
+		scheduledStatus,
+		"scheduled_at",
+		"publish_attempts",
+		"publish_failed_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating scheduled status %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Cancel the existing publication task,
+	// and schedule for the new publication time.
+	p.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+	if err := p.ScheduledStatusesSchedulePublication(ctx,
+		scheduledStatus,
+	); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiScheduledStatus(ctx, scheduledStatus)
+}
+
+// ScheduledStatusesDelete cancels and deletes the scheduled
+// status with the given ID, if it's owned by the requester.
+func (p *Processor) ScheduledStatusesDelete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) gtserror.WithCode {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, requester, id)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Cancel the publication task.
+	p.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+
+	if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduledStatus.ID); err != nil {
+		err := gtserror.Newf("db error deleting scheduled status %s: %w", id, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// ScheduledStatusesScheduleAll schedules publication of all
+// pending scheduled statuses in the database. This should
+// be called once on startup, after the scheduler has started.
+func (p *Processor) ScheduledStatusesScheduleAll(ctx context.Context) error {
+	// Fetch all pending scheduled statuses from the database (barebones models are enough).
+	scheduledStatuses, err := p.state.DB.GetAllScheduledStatuses(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled statuses from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, scheduledStatus := range scheduledStatuses {
+		if scheduledStatus.PublishFailed() {
+			// Given up on, leave it
+			// for the user to reschedule.
+			continue
+		}
+
+		// Schedule each of the statuses and catch any errors.
+		if err := p.ScheduledStatusesSchedulePublication(ctx, scheduledStatus); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// ScheduledStatusesSchedulePublication adds the
+// given scheduled status to the scheduler, to be
+// published at its set scheduled time. Statuses
+// that were due while the instance was down will
+// be published as soon as the scheduler allows.
+func (p *Processor) ScheduledStatusesSchedulePublication(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+) error {
+	// Add the given scheduled status to the scheduler.
+	ok := p.state.Workers.Scheduler.AddOnce(
+		scheduledStatus.ID,
+		scheduledStatus.ScheduledAt,
+		p.onPublication(scheduledStatus.ID),
+	)
+
+	if !ok {
+		// Failed to add the status to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding scheduled status %s to scheduler", scheduledStatus.ID)
+	}
+
+	atStr := scheduledStatus.ScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled status publication for %s at '%s'", scheduledStatus.ID, atStr)
+	return nil
+}
+
+// onPublication returns a callback function to be used by
+// the scheduler when the given scheduled status comes due.
+func (p *Processor) onPublication(scheduledStatusID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of scheduled status from database.
+		scheduledStatus, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledStatusID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "error getting scheduled status %s from db: %v", scheduledStatusID, err)
+			}
+			return
+		}
+
+		if _, errWithCode := p.publishScheduledStatus(ctx, scheduledStatus); errWithCode != nil {
+			log.Errorf(ctx, "error publishing scheduled status %s: %v", scheduledStatusID, errWithCode)
+			p.retryPublication(ctx, scheduledStatus, errWithCode)
+		}
+	}
+}
+
+// retryPublication records a failed attempt to publish the
+// given scheduled status, and reschedules it with backoff.
+// If the failure was caused by the status itself (rather than
+// eg., the database), or it's been attempted too many times,
+// it's marked as failed instead, and left for the user to
+// reschedule or delete.
+func (p *Processor) retryPublication(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+	errWithCode gtserror.WithCode,
+) {
+	scheduledStatus.PublishAttempts++
+
+	giveUp := errWithCode.Code() < http.StatusInternalServerError ||
+		scheduledStatus.PublishAttempts >= scheduledStatusMaxAttempts
+	if giveUp {
+		scheduledStatus.PublishFailedAt = time.Now()
+	}
+
+	if err := p.state.DB.UpdateScheduledStatus(ctx,
+		scheduledStatus,
+		"publish_attempts",
+		"publish_failed_at",
+	); err != nil {
+		log.Errorf(ctx, "db error updating scheduled status %s: %v", scheduledStatus.ID, err)
+		return
+	}
+
+	// Remove the spent publication task,
+	// so the ID can be scheduled again.
+	p.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+
+	if giveUp {
+		log.Warnf(ctx,
+			"giving up publishing scheduled status %s after %d attempt(s)",
+			scheduledStatus.ID, scheduledStatus.PublishAttempts,
+		)
+		return
+	}
+
+	backoff := scheduledStatusRetryBackoff << (scheduledStatus.PublishAttempts - 1)
+	if !p.state.Workers.Scheduler.AddOnce(
+		scheduledStatus.ID,
+		time.Now().Add(backoff),
+		p.onPublication(scheduledStatus.ID),
+	) {
+		log.Errorf(ctx, "failed rescheduling scheduled status %s", scheduledStatus.ID)
+		return
+	}
+
+	log.Infof(ctx, "retrying publication of scheduled status %s in %s", scheduledStatus.ID, backoff)
+}
+
+// publishScheduledStatus creates a new status using the stored parameters
+// of the given scheduled status, and then removes it from the database. If
+// publishing fails, the scheduled status is left in place for the user.
+func (p *Processor) publishScheduledStatus(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+) (*apimodel.Status, gtserror.WithCode) {
+	account := scheduledStatus.Account
+	if account == nil {
+		err := gtserror.Newf("scheduled status %s account not populated", scheduledStatus.ID)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account.IsSuspended() || account.IsMoving() {
+		// Account can no longer post.
+		err := gtserror.Newf("account %s is suspended or moving", account.ID)
+		return nil, gtserror.NewErrorForbidden(err)
+	}
+
+	application := scheduledStatus.Application
+	if application == nil {
+		// The application was probably deleted
+		// since scheduling, use an empty one.
+		application = new(gtsmodel.Application)
+	}
+
+	// Rebuild the status creation form.
+	form := &apimodel.StatusCreateRequest{
+		Status:         scheduledStatus.Text,
+		MediaIDs:       scheduledStatus.MediaIDs,
+		InReplyToID:    scheduledStatus.InReplyToID,
+		QuotedStatusID: scheduledStatus.QuotedStatusID,
+		Sensitive:      util.PtrOrZero(scheduledStatus.Sensitive),
+		SpoilerText:    scheduledStatus.SpoilerText,
+		Visibility:     typeutils.VisToAPIVis(scheduledStatus.Visibility),
+		LocalOnly:      scheduledStatus.LocalOnly,
+		Language:       scheduledStatus.Language,
+		ContentType:    typeutils.ContentTypeToAPIContentType(scheduledStatus.ContentType),
+	}
+
+	if scheduledStatus.Visibility == gtsmodel.VisibilityMutualsOnly {
+		// VisToAPIVis converts mutuals-only to
+		// private for client compatibility, so
+		// set the intended visibility directly.
+		form.Visibility = apimodel.VisibilityMutualsOnly
+	}
+
+	if poll := scheduledStatus.Poll; poll != nil {
+		form.Poll = &apimodel.PollRequest{
+			Options:    poll.Options,
+			ExpiresIn:  poll.ExpiresIn,
+			Multiple:   poll.Multiple,
+			HideTotals: poll.HideTotals,
+		}
+	}
+
+	if policy := scheduledStatus.InteractionPolicy; policy != nil {
+		var err error
+		form.InteractionPolicy, err = p.converter.InteractionPolicyToAPIInteractionPolicy(ctx,
+			policy,
+			nil,
+			nil,
+		)
+		if err != nil {
+			err := gtserror.Newf("error converting interaction policy: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if policy.CanQuote.IsEmpty() {
+			// Policy was stored without quote
+			// rules, so leave them unset on the
+			// form to use the visibility default.
+			form.InteractionPolicy.CanQuote = apimodel.PolicyRules{}
+		}
+	}
+
+	// Detach media from the scheduled status,
+	// so that they can be used by the status.
+	if err := p.state.DB.UpdateScheduledStatusMedia(ctx,
+		"",
+		scheduledStatus.MediaIDs,
+	); err != nil {
+		err := gtserror.Newf("db error detaching scheduled status media: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiStatus, errWithCode := p.Create(ctx, account, application, form)
+	if errWithCode != nil {
+		// Publishing failed, so keep the scheduled
+		// status around, with its media reattached.
+		if err := p.state.DB.UpdateScheduledStatusMedia(ctx,
+			scheduledStatus.ID,
+			scheduledStatus.MediaIDs,
+		); err != nil {
+			log.Errorf(ctx, "db error reattaching scheduled status media: %v", err)
+		}
+		return nil, errWithCode
+	}
+
+	// Only now that the status is
+	// published, delete the scheduled one.
+	if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduledStatus.ID); err != nil {
+		log.Errorf(ctx, "db error deleting scheduled status: %v", err)
+	}
+
+	return apiStatus, nil
+}
+
+// getOwnScheduledStatus fetches the scheduled
+// status with the given ID, returning not found
+// if it doesn't exist or isn't owned by requester.
+func (p *Processor) getOwnScheduledStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, err := p.state.DB.GetScheduledStatusByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled status %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if scheduledStatus == nil || scheduledStatus.AccountID != requester.ID {
+		const text = "scheduled status not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return scheduledStatus, nil
+}
+
+// validateScheduledAt checks that the given time is far
+// enough into the future, and that scheduling a status
+// for that time wouldn't take account over its limits.
+// If rescheduling an existing scheduled status, it should
+// be passed as existing so it's excluded from the limits.
+func (p *Processor) validateScheduledAt(
+	ctx context.Context,
+	accountID string,
+	scheduledAt time.Time,
+	existing *gtsmodel.ScheduledStatus,
+) gtserror.WithCode {
+	if time.Until(scheduledAt) < minScheduledStatusDelay {
+		const text = "scheduled_at must be at least 5 minutes in the future"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Rescheduling an existing status
+	// doesn't change the total count.
+	if existing == nil {
+		total, err := p.state.DB.CountScheduledStatusesForAcct(ctx, accountID, time.Time{})
+		if err != nil {
+			err := gtserror.Newf("db error counting scheduled statuses: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if max := config.GetScheduledStatusesMaxTotal(); total >= max {
+			text := fmt.Sprintf("total scheduled statuses limit reached (%d)", max)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	daily, err := p.state.DB.CountScheduledStatusesForAcct(ctx, accountID, scheduledAt)
+	if err != nil {
+		err := gtserror.Newf("db error counting scheduled statuses: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil && sameUTCDay(existing.ScheduledAt, scheduledAt) {
+		// Don't count the existing
+		// status against its own day.
+		daily--
+	}
+
+	if max := config.GetScheduledStatusesMaxDaily(); daily >= max {
+		text := fmt.Sprintf("daily scheduled statuses limit reached (%d)", max)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return nil
+}
+
+// sameUTCDay returns whether the two given
+// times fall within the same day in UTC.
+func sameUTCDay(t1, t2 time.Time) bool {
+	y1, m1, d1 := t1.UTC().Date()
+	y2, m2, d2 := t2.UTC().Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+func (p *Processor) apiScheduledStatus(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	apiScheduledStatus, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduledStatus)
+	if err != nil {
+		err := gtserror.Newf("error converting scheduled status to api scheduled status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiScheduledStatus, nil
+}
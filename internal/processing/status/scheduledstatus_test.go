@@ -0,0 +1,313 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScheduledStatusTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) SetupTest() {
+	suite.StatusStandardTestSuite.SetupTest()
+
+	// The previous test's teardown can race with
+	// the scheduler starting up again in setup, so
+	// make sure it's running before we schedule.
+	_ = suite.state.Workers.Scheduler.Start()
+}
+
+func (suite *ScheduledStatusTestSuite) scheduleForm(scheduledAt time.Time, mediaIDs ...string) *apimodel.StatusCreateRequest {
+	return &apimodel.StatusCreateRequest{
+		Status:      "this is a status from the future",
+		MediaIDs:    mediaIDs,
+		SpoilerText: "spoopy",
+		Visibility:  apimodel.VisibilityUnlisted,
+		LocalOnly:   util.Ptr(true),
+		ScheduledAt: &scheduledAt,
+		Language:    "en",
+		ContentType: apimodel.StatusContentTypeMarkdown,
+	}
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusesCreate() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	media := suite.testAttachments["local_account_1_unattached_1"]
+	scheduledAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	apiScheduledStatus, errWithCode := suite.status.ScheduledStatusesCreate(ctx,
+		account,
+		application,
+		suite.scheduleForm(scheduledAt, media.ID),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(util.FormatISO8601(scheduledAt), apiScheduledStatus.ScheduledAt)
+	suite.Equal("this is a status from the future", apiScheduledStatus.Params.Text)
+	suite.Equal("spoopy", apiScheduledStatus.Params.SpoilerText)
+	suite.Equal(apimodel.VisibilityUnlisted, apiScheduledStatus.Params.Visibility)
+	suite.True(apiScheduledStatus.Params.LocalOnly)
+	suite.Equal(apimodel.StatusContentTypeMarkdown, apiScheduledStatus.Params.ContentType)
+	suite.Equal(application.ID, apiScheduledStatus.Params.ApplicationID)
+	suite.Equal([]string{media.ID}, apiScheduledStatus.Params.MediaIDs)
+	suite.Len(apiScheduledStatus.MediaAttachments, 1)
+
+	// Media can't be used by another status in the meantime.
+	_, errWithCode = suite.status.Create(ctx, account, application, &apimodel.StatusCreateRequest{
+		Status:   "sneaky",
+		MediaIDs: []string{media.ID},
+	})
+	suite.EqualError(errWithCode, "media already attached to status: "+media.ID)
+
+	// Scheduled status should be listed.
+	resp, errWithCode := suite.status.ScheduledStatusesGetPage(ctx, account, &paging.Page{Limit: 10})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(resp.Items, 1)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusesCreateTooSoon() {
+	ctx := context.Background()
+
+	_, errWithCode := suite.status.ScheduledStatusesCreate(ctx,
+		suite.testAccounts["local_account_1"],
+		suite.testApplications["application_1"],
+		suite.scheduleForm(time.Now().Add(time.Minute)),
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.EqualError(errWithCode, "scheduled_at must be at least 5 minutes in the future")
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusesCreateDailyLimit() {
+	ctx := context.Background()
+
+	config.SetScheduledStatusesMaxDaily(1)
+	defer config.SetScheduledStatusesMaxDaily(25)
+
+	account := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	scheduledAt := time.Now().Add(time.Hour)
+
+	_, errWithCode := suite.status.ScheduledStatusesCreate(ctx, account, application, suite.scheduleForm(scheduledAt))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.status.ScheduledStatusesCreate(ctx, account, application, suite.scheduleForm(scheduledAt))
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.EqualError(errWithCode, "daily scheduled statuses limit reached (1)")
+
+	// Scheduling on another day should be fine.
+	_, errWithCode = suite.status.ScheduledStatusesCreate(ctx, account, application, suite.scheduleForm(scheduledAt.Add(48*time.Hour)))
+	suite.NoError(errWithCode)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusesUpdateDelete() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	media := suite.testAttachments["local_account_1_unattached_1"]
+
+	apiScheduledStatus, errWithCode := suite.status.ScheduledStatusesCreate(ctx,
+		account,
+		application,
+		suite.scheduleForm(time.Now().Add(time.Hour), media.ID),
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Another account shouldn't be able to see or change it.
+	_, errWithCode = suite.status.ScheduledStatusesGetOne(ctx, suite.testAccounts["local_account_2"], apiScheduledStatus.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	newScheduledAt := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	apiScheduledStatus, errWithCode = suite.status.ScheduledStatusesUpdate(ctx, account, apiScheduledStatus.ID, &newScheduledAt)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(util.FormatISO8601(newScheduledAt), apiScheduledStatus.ScheduledAt)
+
+	errWithCode = suite.status.ScheduledStatusesDelete(ctx, account, apiScheduledStatus.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, err := suite.db.GetScheduledStatusByID(ctx, apiScheduledStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Media should be free to use again.
+	dbMedia, err := suite.db.GetAttachmentByID(ctx, media.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbMedia.ScheduledStatusID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusPublish() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+	media := suite.testAttachments["local_account_1_unattached_1"]
+
+	// Put a scheduled status in the database that's
+	// already due, eg., because the instance was down.
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:            id.NewULID(),
+		AccountID:     account.ID,
+		ScheduledAt:   time.Now().Add(-time.Minute),
+		Text:          "better late than never",
+		MediaIDs:      []string{media.ID},
+		Sensitive:     util.Ptr(false),
+		Visibility:    gtsmodel.VisibilityPublic,
+		LocalOnly:     util.Ptr(false),
+		ContentType:   gtsmodel.StatusContentTypePlain,
+		ApplicationID: suite.testApplications["application_1"].ID,
+	}
+	if err := suite.db.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Schedule all, this should publish it right away.
+	if err := suite.status.ScheduledStatusesScheduleAll(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var status *gtsmodel.Status
+	if !testrig.WaitFor(func() bool {
+		dbMedia, err := suite.db.GetAttachmentByID(ctx, media.ID)
+		if err != nil || dbMedia.StatusID == "" {
+			return false
+		}
+
+		status, err = suite.db.GetStatusByID(ctx, dbMedia.StatusID)
+		return err == nil
+	}) {
+		suite.FailNow("timed out waiting for scheduled status to be published")
+	}
+
+	suite.Equal("better late than never", status.Text)
+	suite.Equal(account.ID, status.AccountID)
+	suite.Equal(gtsmodel.VisibilityPublic, status.Visibility)
+	suite.Equal([]string{media.ID}, status.AttachmentIDs)
+
+	// Scheduled status should now be gone.
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+		return errors.Is(err, db.ErrNoEntries)
+	}) {
+		suite.FailNow("timed out waiting for scheduled status to be deleted")
+	}
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusPublishFailed() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+
+	// Put a scheduled status in the database that's
+	// already due, replying to a status that's gone.
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:            id.NewULID(),
+		AccountID:     account.ID,
+		ScheduledAt:   time.Now().Add(-time.Minute),
+		Text:          "replying to nothing",
+		Sensitive:     util.Ptr(false),
+		Visibility:    gtsmodel.VisibilityPublic,
+		InReplyToID:   "01JYBQ4Q9J2S3PQ1X2H4W0XZ9D",
+		LocalOnly:     util.Ptr(false),
+		ContentType:   gtsmodel.StatusContentTypePlain,
+		ApplicationID: suite.testApplications["application_1"].ID,
+	}
+	if err := suite.db.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Schedule all, this should try to publish
+	// it right away, and give up as it can't be.
+	if err := suite.status.ScheduledStatusesScheduleAll(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		dbScheduledStatus, err := suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+		if err != nil {
+			return false
+		}
+		scheduledStatus = dbScheduledStatus
+		return scheduledStatus.PublishFailed()
+	}) {
+		suite.FailNow("timed out waiting for scheduled status to fail")
+	}
+	suite.Equal(1, scheduledStatus.PublishAttempts)
+
+	// Failed scheduled status shouldn't
+	// be scheduled again on startup.
+	suite.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+	if err := suite.status.ScheduledStatusesScheduleAll(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(suite.state.Workers.Scheduler.Cancel(scheduledStatus.ID))
+
+	// Rescheduling should give it a fresh start.
+	scheduledAt := time.Now().Add(time.Hour)
+	apiScheduledStatus, errWithCode := suite.status.ScheduledStatusesUpdate(ctx,
+		account,
+		scheduledStatus.ID,
+		&scheduledAt,
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(scheduledStatus.ID, apiScheduledStatus.ID)
+
+	scheduledStatus, err := suite.db.GetScheduledStatusByID(ctx, scheduledStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(scheduledStatus.PublishFailed())
+	suite.Zero(scheduledStatus.PublishAttempts)
+	suite.True(suite.state.Workers.Scheduler.Cancel(scheduledStatus.ID))
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
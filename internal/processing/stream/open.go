@@ -19,11 +19,14 @@
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/stream"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
 	"codeberg.org/gruf/go-kv"
 )
 
@@ -34,5 +37,53 @@ func (p *Processor) Open(ctx context.Context, account *gtsmodel.Account, streamT
 		{"streamType", streamType},
 	}...)
 	l.Debug("received open stream request")
+
+	streamType, errWithCode := NormalizeStreamType(streamType)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	return p.streams.Open(account.ID, streamType), nil
 }
+
+// Resume is like Open, but will also replay recent messages for the given
+// account + stream type that came after the message with given lastEventID.
+func (p *Processor) Resume(ctx context.Context, account *gtsmodel.Account, streamType string, lastEventID string) (*stream.Stream, gtserror.WithCode) {
+	l := log.WithContext(ctx).WithFields(kv.Fields{
+		{"account", account.ID},
+		{"streamType", streamType},
+		{"lastEventID", lastEventID},
+	}...)
+	l.Debug("received resume stream request")
+
+	streamType, errWithCode := NormalizeStreamType(streamType)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.streams.Resume(account.ID, lastEventID, streamType), nil
+}
+
+// NormalizeStreamType normalizes the tag name of the given
+// hashtag stream type (eg., `hashtag:Example`), so that it
+// matches the name of the tag as stored in the database.
+// Other stream types are returned unchanged.
+func NormalizeStreamType(streamType string) (string, gtserror.WithCode) {
+	var prefix string
+	switch {
+	case strings.HasPrefix(streamType, stream.TimelineHashtagLocal+":"):
+		prefix = stream.TimelineHashtagLocal + ":"
+	case strings.HasPrefix(streamType, stream.TimelineHashtag+":"):
+		prefix = stream.TimelineHashtag + ":"
+	default:
+		return streamType, nil
+	}
+
+	tagName, ok := text.NormalizeHashtag(streamType[len(prefix):])
+	if !ok {
+		const text = "tag name was not valid"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return prefix + strings.ToLower(tagName), nil
+}
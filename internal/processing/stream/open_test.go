@@ -21,6 +21,7 @@
 	"context"
 	"testing"
 
+	"code.superseriousbusiness.org/gotosocial/internal/processing/stream"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -35,6 +36,33 @@ func (suite *OpenStreamTestSuite) TestOpenStream() {
 	suite.NoError(errWithCode)
 }
 
+func (suite *OpenStreamTestSuite) TestOpenHashtagStream() {
+	account := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.streamProcessor.Open(context.Background(), account, "hashtag:local:#WeLcOmE")
+	suite.NoError(errWithCode)
+}
+
+func (suite *OpenStreamTestSuite) TestNormalizeStreamType() {
+	for in, expect := range map[string]string{
+		"user":                            "user",
+		"list:01H3YF48G8B7KTPQFS8D2QBVG8": "list:01H3YF48G8B7KTPQFS8D2QBVG8",
+		"hashtag:Welcome":                 "hashtag:welcome",
+		"hashtag:local:#WeLcOmE":          "hashtag:local:welcome",
+	} {
+		streamType, errWithCode := stream.NormalizeStreamType(in)
+		suite.NoError(errWithCode)
+		suite.Equal(expect, streamType)
+	}
+}
+
+func (suite *OpenStreamTestSuite) TestOpenHashtagStreamInvalidTag() {
+	account := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.streamProcessor.Open(context.Background(), account, "hashtag:not a tag")
+	suite.Error(errWithCode)
+}
+
 func TestOpenStreamTestSuite(t *testing.T) {
 	suite.Run(t, &OpenStreamTestSuite{})
 }
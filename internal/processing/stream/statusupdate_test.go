@@ -154,6 +154,13 @@ func (suite *StatusUpdateTestSuite) TestStreamNotification() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, dst.String())
@@ -36,3 +36,9 @@ func New(state *state.State, oauthServer oauth.Server) Processor {
 		streams:     stream.Streams{},
 	}
 }
+
+// Subscribers returns the IDs of all accounts with
+// open streams matching any of the given stream types.
+func (p *Processor) Subscribers(streamTypes ...string) []string {
+	return p.streams.Subscribers(streamTypes...)
+}
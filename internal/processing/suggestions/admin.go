@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+)
+
+// StaffPicksGet returns all accounts picked
+// by admins, in ascending order of picking.
+func (p *Processor) StaffPicksGet(ctx context.Context) ([]*apimodel.Account, gtserror.WithCode) {
+	picks, err := p.state.DB.GetStaffPicks(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting staff picks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := make([]*apimodel.Account, 0, len(picks))
+	for _, pick := range picks {
+		apiAccount, errWithCode := p.apiAccount(ctx, pick.Account)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
+
+// StaffPickCreate picks the account with the given ID to be
+// suggested to local accounts, on behalf of the given admin.
+func (p *Processor) StaffPickCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("account %s not found", accountID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account.IsSuspended() {
+		err := fmt.Errorf("account %s is suspended", account.ID)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Ensure account isn't already picked.
+	existing, err := p.state.DB.GetStaffPickByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		err := fmt.Errorf("account %s is already a staff pick", account.ID)
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	if err := p.state.DB.PutStaffPick(ctx, &gtsmodel.StaffPick{
+		ID:                 id.NewULID(),
+		AccountID:          account.ID,
+		Account:            account,
+		CreatedByAccountID: adminAcct.ID,
+	}); err != nil {
+		err := gtserror.Newf("db error putting staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiAccount(ctx, account)
+}
+
+// StaffPickDelete stops the account with the given ID from
+// being suggested to local accounts as a staff pick.
+func (p *Processor) StaffPickDelete(
+	ctx context.Context,
+	accountID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	pick, err := p.state.DB.GetStaffPickByAccountID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("account %s is not a staff pick", accountID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteStaffPickByAccountID(ctx, pick.AccountID); err != nil {
+		err := gtserror.Newf("db error deleting staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiAccount(ctx, pick.Account)
+}
+
+// apiAccount converts the given picked
+// account to its API representation.
+func (p *Processor) apiAccount(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (*apimodel.Account, gtserror.WithCode) {
+	apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+	if err != nil {
+		err := gtserror.Newf("error converting account %s to API representation: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiAccount, nil
+}
@@ -0,0 +1,213 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+const (
+	sourceFeatured         = "featured"
+	sourceFriendsOfFriends = "friends_of_friends"
+)
+
+// candidate is an account
+// that may be suggested.
+type candidate struct {
+	accountID string
+	sources   []string
+}
+
+// Get returns up to limit accounts suggested for the requester to
+// follow: first any accounts picked by admins, then accounts followed
+// by accounts that the requester follows, most followed first.
+func (p *Processor) Get(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+) ([]*apimodel.Suggestion, gtserror.WithCode) {
+	picks, err := p.state.DB.GetStaffPicks(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting staff picks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Gather candidates from both sources,
+	// merging sources of accounts in both.
+	candidates := make([]*candidate, 0, len(picks))
+	for _, pick := range picks {
+		candidates = append(candidates, &candidate{
+			accountID: pick.AccountID,
+			sources:   []string{sourceFeatured},
+		})
+	}
+
+	for _, s := range p.state.Caches.Suggestions.Get(requester.ID) {
+		i := slices.IndexFunc(candidates, func(c *candidate) bool {
+			return c.accountID == s.TargetAccountID
+		})
+		if i != -1 {
+			candidates[i].sources = append(candidates[i].sources, sourceFriendsOfFriends)
+			continue
+		}
+
+		candidates = append(candidates, &candidate{
+			accountID: s.TargetAccountID,
+			sources:   []string{sourceFriendsOfFriends},
+		})
+	}
+
+	dismissed, err := p.state.DB.GetSuggestionDismissedIDs(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting dismissed suggestions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	suggestions := make([]*apimodel.Suggestion, 0, limit)
+	for _, c := range candidates {
+		if len(suggestions) == limit {
+			break
+		}
+
+		if slices.Contains(dismissed, c.accountID) {
+			continue
+		}
+
+		account, errWithCode := p.suggestable(ctx, requester, c.accountID)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if account == nil {
+			continue
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting account %s to api representation: %v", account.ID, err)
+			continue
+		}
+
+		source := "global"
+		if c.sources[0] == sourceFeatured {
+			source = "staff"
+		}
+
+		suggestions = append(suggestions, &apimodel.Suggestion{
+			Source:  source,
+			Sources: c.sources,
+			Account: apiAccount,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// suggestable returns the account with given ID if it may be
+// suggested to the requester, or nil if it may not be: if it
+// isn't discoverable or is suspended, or if the requester
+// already follows, has requested to follow, has muted, or
+// has a block in place with the account.
+func (p *Processor) suggestable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	accountID string,
+) (*gtsmodel.Account, gtserror.WithCode) {
+	if accountID == requester.ID {
+		return nil, nil
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Account deleted
+			// since refresh.
+			return nil, nil
+		}
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !*account.Discoverable || account.IsSuspended() {
+		return nil, nil
+	}
+
+	for _, check := range []struct {
+		name string
+		f    func(context.Context, string, string) (bool, error)
+	}{
+		{"follow", p.state.DB.IsFollowing},
+		{"follow request", p.state.DB.IsFollowRequested},
+		{"mute", p.state.DB.IsMuted},
+		{"block", p.state.DB.IsEitherBlocked},
+	} {
+		exists, err := check.f(ctx, requester.ID, account.ID)
+		if err != nil {
+			err := gtserror.Newf("db error checking %s of account %s: %w", check.name, account.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if exists {
+			return nil, nil
+		}
+	}
+
+	return account, nil
+}
+
+// Dismiss stops the account with the given
+// ID from being suggested to the requester.
+func (p *Processor) Dismiss(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetAccountID string,
+) gtserror.WithCode {
+	targetAccount, err := p.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		targetAccountID,
+	)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("account %s not found", targetAccountID)
+			return gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting account %s: %w", targetAccountID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.PutSuggestionDismissal(ctx, &gtsmodel.SuggestionDismissal{
+		ID:              id.NewULID(),
+		AccountID:       requester.ID,
+		TargetAccountID: targetAccount.ID,
+	}); err != nil {
+		err := gtserror.Newf("db error putting suggestion dismissal: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,385 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	statusfilter "code.superseriousbusiness.org/gotosocial/internal/filter/status"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// AdminTagsGet returns all currently trending tags,
+// whether reviewed or not, most trending first,
+// starting from given offset and up to given limit.
+func (p *Processor) AdminTagsGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrendsTag, gtserror.WithCode) {
+	trends := page(p.state.Caches.Trends.Get(gtsmodel.TrendTypeTag), limit, offset)
+	apiTags := make([]*apimodel.AdminTrendsTag, 0, len(trends))
+
+	for _, trend := range trends {
+		tag, err := p.state.DB.GetTag(ctx, trend.Target)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error getting tag %s: %w", trend.Target, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			continue
+		}
+
+		review, errWithCode := p.getReview(ctx, trend.Type, trend.Target)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiTag, errWithCode := p.adminAPITag(ctx, tag, trend, review)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// AdminStatusesGet returns all currently trending statuses,
+// whether reviewed or not, most trending first, starting
+// from given offset and up to given limit.
+func (p *Processor) AdminStatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrendsStatus, gtserror.WithCode) {
+	trends := page(p.state.Caches.Trends.Get(gtsmodel.TrendTypeStatus), limit, offset)
+	apiStatuses := make([]*apimodel.AdminTrendsStatus, 0, len(trends))
+
+	for _, trend := range trends {
+		status, err := p.state.DB.GetStatusByID(ctx, trend.Target)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error getting status %s: %w", trend.Target, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			continue
+		}
+
+		review, errWithCode := p.getReview(ctx, trend.Type, trend.Target)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiStatus, errWithCode := p.adminAPIStatus(ctx, requester, status, review)
+		if errWithCode != nil {
+			log.Debugf(ctx, "skipping status %s: %v", status.ID, errWithCode)
+			continue
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return apiStatuses, nil
+}
+
+// AdminLinksGet returns all currently trending links,
+// whether reviewed or not, most trending first,
+// starting from given offset and up to given limit.
+func (p *Processor) AdminLinksGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrendsLink, gtserror.WithCode) {
+	trends := page(p.state.Caches.Trends.Get(gtsmodel.TrendTypeLink), limit, offset)
+	apiLinks := make([]*apimodel.AdminTrendsLink, 0, len(trends))
+
+	for _, trend := range trends {
+		review, errWithCode := p.getReview(ctx, trend.Type, trend.Target)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		if review == nil {
+			// Links can only be reviewed
+			// by review ID, so skip any
+			// not yet queued for review.
+			continue
+		}
+
+		apiLinks = append(apiLinks, adminAPILink(trend, review))
+	}
+
+	return apiLinks, nil
+}
+
+// AdminTagReview approves or rejects the tag with the
+// given ID for display in trends, on behalf of the given
+// admin account, and returns the updated tag.
+func (p *Processor) AdminTagReview(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	tagID string,
+	approve bool,
+) (*apimodel.AdminTrendsTag, gtserror.WithCode) {
+	tag, err := p.state.DB.GetTag(ctx, tagID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("tag %s not found", tagID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting tag %s: %w", tagID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	review, errWithCode := p.review(ctx, adminAcct, gtsmodel.TrendTypeTag, tag.ID, approve)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	trend := p.cachedTrend(gtsmodel.TrendTypeTag, tag.ID)
+	return p.adminAPITag(ctx, tag, trend, review)
+}
+
+// AdminStatusReview approves or rejects the status with
+// the given ID for display in trends, on behalf of the
+// given admin account, and returns the updated status.
+func (p *Processor) AdminStatusReview(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	statusID string,
+	approve bool,
+) (*apimodel.AdminTrendsStatus, gtserror.WithCode) {
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("status %s not found", statusID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting status %s: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	review, errWithCode := p.review(ctx, adminAcct, gtsmodel.TrendTypeStatus, status.ID, approve)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.adminAPIStatus(ctx, adminAcct, status, review)
+}
+
+// AdminLinkReview approves or rejects the link with
+// the given trend review ID for display in trends,
+// on behalf of the given admin account, and returns
+// the updated link.
+func (p *Processor) AdminLinkReview(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	reviewID string,
+	approve bool,
+) (*apimodel.AdminTrendsLink, gtserror.WithCode) {
+	review, err := p.state.DB.GetTrendReviewByID(ctx, reviewID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting trend review %s: %w", reviewID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if review == nil || review.Type != gtsmodel.TrendTypeLink {
+		err := gtserror.Newf("link trend review %s not found", reviewID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	review, errWithCode := p.review(ctx, adminAcct, review.Type, review.Target, approve)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	trend := p.cachedTrend(gtsmodel.TrendTypeLink, review.Target)
+	return adminAPILink(trend, review), nil
+}
+
+// getReview gets the trend review for the given
+// type and target, returning nil if there is none.
+func (p *Processor) getReview(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	target string,
+) (*gtsmodel.TrendReview, gtserror.WithCode) {
+	review, err := p.state.DB.GetTrendReview(ctx, trendType, target)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting trend review: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return review, nil
+}
+
+// review approves or rejects the given trend
+// target, creating a review if necessary.
+func (p *Processor) review(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	target string,
+	approve bool,
+) (*gtsmodel.TrendReview, gtserror.WithCode) {
+	review, errWithCode := p.getReview(ctx, trendType, target)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	state := gtsmodel.TrendReviewStateRejected
+	if approve {
+		state = gtsmodel.TrendReviewStateApproved
+	}
+
+	if review == nil {
+		// Not yet queued, review
+		// ahead of it trending.
+		review = &gtsmodel.TrendReview{
+			ID:                  id.NewULID(),
+			Type:                trendType,
+			Target:              target,
+			State:               state,
+			ReviewedByAccountID: adminAcct.ID,
+			ReviewedAt:          time.Now(),
+		}
+
+		if err := p.state.DB.PutTrendReview(ctx, review); err != nil {
+			err := gtserror.Newf("db error putting trend review: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		return review, nil
+	}
+
+	review.State = state
+	review.ReviewedByAccountID = adminAcct.ID
+	review.ReviewedAt = time.Now()
+
+	if err := p.state.DB.UpdateTrendReview(
+		ctx,
+		review,
+		"state",
+		"reviewed_by_account_id",
+		"reviewed_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating trend review: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return review, nil
+}
+
+// cachedTrend returns the currently cached trend for
+// given type and target, or a trend with no history
+// if the target isn't currently trending.
+func (p *Processor) cachedTrend(trendType gtsmodel.TrendType, target string) *cache.Trend {
+	for _, trend := range p.state.Caches.Trends.Get(trendType) {
+		if trend.Target == target {
+			return trend
+		}
+	}
+
+	return &cache.Trend{
+		Type:   trendType,
+		Target: target,
+	}
+}
+
+// adminAPITag converts the given trending
+// tag to its admin API representation.
+func (p *Processor) adminAPITag(
+	ctx context.Context,
+	tag *gtsmodel.Tag,
+	trend *cache.Trend,
+	review *gtsmodel.TrendReview,
+) (*apimodel.AdminTrendsTag, gtserror.WithCode) {
+	apiTag, err := p.converter.TagToAPITag(ctx, tag, false, nil)
+	if err != nil {
+		err := gtserror.Newf("error converting tag %s to API representation: %w", tag.Name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AdminTrendsTag{
+		ID:             tag.ID,
+		Name:           apiTag.Name,
+		URL:            apiTag.URL,
+		History:        apiHistory(trend.History),
+		Trendable:      review != nil && review.IsApproved() && tagTrendable(tag),
+		Usable:         *tag.Useable,
+		RequiresReview: review == nil || review.IsPending(),
+	}, nil
+}
+
+// adminAPIStatus converts the given trending
+// status to its admin API representation.
+func (p *Processor) adminAPIStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+	review *gtsmodel.TrendReview,
+) (*apimodel.AdminTrendsStatus, gtserror.WithCode) {
+	apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester, statusfilter.FilterContextNone, nil, nil)
+	if err != nil {
+		err := gtserror.Newf("error converting status %s to API representation: %w", status.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AdminTrendsStatus{
+		Status:         apiStatus,
+		Trendable:      review != nil && review.IsApproved(),
+		RequiresReview: review == nil || review.IsPending(),
+	}, nil
+}
+
+// adminAPILink converts the given trending
+// link to its admin API representation.
+func adminAPILink(trend *cache.Trend, review *gtsmodel.TrendReview) *apimodel.AdminTrendsLink {
+	return &apimodel.AdminTrendsLink{
+		ID:             review.ID,
+		TrendsLink:     apiLink(trend),
+		Trendable:      review.IsApproved(),
+		RequiresReview: review.IsPending(),
+	}
+}
+
+// page returns the slice of given trends at
+// given offset, and up to given limit in length.
+func page(trends []*cache.Trend, limit int, offset int) []*cache.Trend {
+	if offset >= len(trends) {
+		return nil
+	}
+
+	trends = trends[offset:]
+	if len(trends) > limit {
+		trends = trends[:limit]
+	}
+
+	return trends
+}
@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	statusfilter "code.superseriousbusiness.org/gotosocial/internal/filter/status"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// TagsGet returns approved trending tags, most trending
+// first, starting from given offset and up to given limit.
+func (p *Processor) TagsGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.Tag, gtserror.WithCode) {
+	apiTags := make([]*apimodel.Tag, 0, limit)
+	if !config.GetInstanceTrendsEnabled() {
+		return apiTags, nil
+	}
+
+	for _, trend := range p.state.Caches.Trends.Get(gtsmodel.TrendTypeTag) {
+		if len(apiTags) == limit+offset {
+			break
+		}
+
+		approved, err := p.approved(ctx, trend)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !approved {
+			continue
+		}
+
+		tag, err := p.state.DB.GetTag(ctx, trend.Target)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error getting tag %s: %w", trend.Target, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			// Tag deleted
+			// since refresh.
+			continue
+		}
+
+		if !tagTrendable(tag) {
+			continue
+		}
+
+		apiTag, err := p.converter.TagToAPITag(ctx, tag, false, nil)
+		if err != nil {
+			err := gtserror.Newf("error converting tag %s to API representation: %w", tag.Name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		history := apiHistory(trend.History)
+		apiTag.History = &history
+		apiTags = append(apiTags, &apiTag)
+	}
+
+	return pageAPI(apiTags, offset), nil
+}
+
+// StatusesGet returns approved trending statuses visible to the
+// requester (which may be nil), most trending first, starting
+// from given offset and up to given limit.
+func (p *Processor) StatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.Status, gtserror.WithCode) {
+	apiStatuses := make([]*apimodel.Status, 0, limit)
+	if !config.GetInstanceTrendsEnabled() {
+		return apiStatuses, nil
+	}
+
+	for _, trend := range p.state.Caches.Trends.Get(gtsmodel.TrendTypeStatus) {
+		if len(apiStatuses) == limit+offset {
+			break
+		}
+
+		approved, err := p.approved(ctx, trend)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !approved {
+			continue
+		}
+
+		status, err := p.state.DB.GetStatusByID(ctx, trend.Target)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error getting status %s: %w", trend.Target, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			// Status deleted
+			// since refresh.
+			continue
+		}
+
+		visible, err := p.visFilter.StatusVisible(ctx, requester, status)
+		if err != nil {
+			err := gtserror.Newf("error checking visibility of status %s: %w", status.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !visible {
+			continue
+		}
+
+		silenced, err := p.visFilter.StatusAuthorSilenced(ctx, requester, status)
+		if err != nil {
+			err := gtserror.Newf("error checking silence of status %s author: %w", status.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if silenced {
+			continue
+		}
+
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester, statusfilter.FilterContextNone, nil, nil)
+		if err != nil {
+			log.Debugf(ctx, "skipping status %s because it couldn't be converted to its api representation: %s", status.ID, err)
+			continue
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return pageAPI(apiStatuses, offset), nil
+}
+
+// LinksGet returns approved trending links, most trending
+// first, starting from given offset and up to given limit.
+func (p *Processor) LinksGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.TrendsLink, gtserror.WithCode) {
+	apiLinks := make([]*apimodel.TrendsLink, 0, limit)
+	if !config.GetInstanceTrendsEnabled() {
+		return apiLinks, nil
+	}
+
+	for _, trend := range p.state.Caches.Trends.Get(gtsmodel.TrendTypeLink) {
+		if len(apiLinks) == limit+offset {
+			break
+		}
+
+		approved, err := p.approved(ctx, trend)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !approved {
+			continue
+		}
+
+		apiLink := apiLink(trend)
+		apiLinks = append(apiLinks, &apiLink)
+	}
+
+	return pageAPI(apiLinks, offset), nil
+}
+
+// pageAPI drops the first offset entries of
+// given slice, returning an empty slice if
+// offset is beyond the length of the slice.
+func pageAPI[T any](s []T, offset int) []T {
+	if offset >= len(s) {
+		return s[:0]
+	}
+	return s[offset:]
+}
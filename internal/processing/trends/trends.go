@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/filter/visibility"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	state     *state.State
+	converter *typeutils.Converter
+	visFilter *visibility.Filter
+}
+
+func New(state *state.State, converter *typeutils.Converter, visFilter *visibility.Filter) Processor {
+	return Processor{
+		state:     state,
+		converter: converter,
+		visFilter: visFilter,
+	}
+}
+
+// approved returns whether the given trend
+// has been approved by an admin for display.
+func (p *Processor) approved(ctx context.Context, trend *cache.Trend) (bool, error) {
+	review, err := p.state.DB.GetTrendReview(ctx, trend.Type, trend.Target)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Not yet
+			// queued.
+			return false, nil
+		}
+		return false, gtserror.Newf("db error getting trend review: %w", err)
+	}
+
+	return review.IsApproved(), nil
+}
+
+// apiHistory converts the given trend
+// history to its API representation.
+func apiHistory(history []cache.TrendHistory) []apimodel.History {
+	apiHistory := make([]apimodel.History, 0, len(history))
+	for _, h := range history {
+		apiHistory = append(apiHistory, apimodel.History{
+			Day:      strconv.FormatInt(h.Day.Unix(), 10),
+			Uses:     strconv.Itoa(h.Uses),
+			Accounts: strconv.Itoa(h.Accounts),
+		})
+	}
+	return apiHistory
+}
+
+// apiLink converts the given link trend
+// to its API representation.
+func apiLink(trend *cache.Trend) apimodel.TrendsLink {
+	return apimodel.TrendsLink{
+		URL:     trend.Target,
+		Title:   trend.Target,
+		Type:    "link",
+		History: apiHistory(trend.History),
+	}
+}
+
+// tagTrendable returns whether the given tag may
+// be shown in trends, which isn't the case if an
+// admin has marked it as not useable or listable.
+func tagTrendable(tag *gtsmodel.Tag) bool {
+	return *tag.Useable && *tag.Listable
+}
@@ -19,6 +19,7 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -27,8 +28,12 @@
 	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/messages"
 	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"code.superseriousbusiness.org/oauth2/v4"
 )
 
@@ -47,8 +52,24 @@ func (p *Processor) Create(
 	var (
 		usersPerDay = config.GetAccountsRegistrationDailyLimit()
 		regBacklog  = config.GetAccountsRegistrationBacklogLimit()
+		invite      *gtsmodel.Invite
 	)
 
+	if form.InviteCode != "" {
+		// Signing up with an invite,
+		// make sure it can be used.
+		var errWithCode gtserror.WithCode
+		invite, errWithCode = p.InviteGetForSignup(ctx, form.InviteCode)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		// Invited sign ups are
+		// exempt from the limits.
+		usersPerDay = 0
+		regBacklog = 0
+	}
+
 	// If usersPerDay limit is in place,
 	// ensure no more than usersPerDay
 	// have registered in the last 24h.
@@ -118,7 +139,7 @@ func (p *Processor) Create(
 		}
 	}
 
-	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
+	newSignup := gtsmodel.NewSignup{
 		Username: form.Username,
 		Email:    form.Email,
 		Password: form.Password,
@@ -126,8 +147,36 @@ func (p *Processor) Create(
 		SignUpIP: form.IP,
 		Locale:   form.Locale,
 		AppID:    app.ID,
-	})
+	}
+
+	if invite != nil {
+		// Use up the invite only now that we
+		// know the sign up is otherwise fine.
+		ok, err := p.state.DB.UseInvite(ctx, invite)
+		if err != nil {
+			err := fmt.Errorf("db error using invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		if !ok {
+			// Someone beat us
+			// to the last use.
+			const text = "invite is no longer valid"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		newSignup.InviteID = invite.ID
+	}
+
+	user, err := p.state.DB.NewSignup(ctx, newSignup)
 	if err != nil {
+		if invite != nil {
+			// Sign up failed, so give back the
+			// use of the invite, to allow retry.
+			if err := p.state.DB.UnuseInvite(ctx, invite); err != nil {
+				log.Errorf(ctx, "db error giving back invite use: %v", err)
+			}
+		}
+
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -144,9 +193,52 @@ func (p *Processor) Create(
 		Origin:         user.Account,
 	})
 
+	if invite != nil && *invite.Autofollow {
+		// Have the new account follow the inviter.
+		if err := p.inviteFollow(ctx, user.Account, invite.User.Account); err != nil {
+			log.Errorf(ctx, "error following inviter: %v", err)
+		}
+	}
+
 	return user, nil
 }
 
+// inviteFollow creates a follow request from the new account
+// to the account that invited it. Side effects are handled as
+// for any other follow request, so if the inviter's account is
+// unlocked the request is accepted straight away.
+func (p *Processor) inviteFollow(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	inviter *gtsmodel.Account,
+) error {
+	followID := id.NewULID()
+	fr := &gtsmodel.FollowRequest{
+		ID:              followID,
+		URI:             uris.GenerateURIForFollow(account.Username, followID),
+		AccountID:       account.ID,
+		Account:         account,
+		TargetAccountID: inviter.ID,
+		TargetAccount:   inviter,
+		ShowReblogs:     util.Ptr(true),
+		Notify:          util.Ptr(false),
+	}
+
+	if err := p.state.DB.PutFollowRequest(ctx, fr); err != nil {
+		return gtserror.Newf("db error putting follow request: %w", err)
+	}
+
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       fr,
+		Origin:         account,
+		Target:         inviter,
+	})
+
+	return nil
+}
+
 // TokenForNewUser generates an OAuth Bearer token
 // for a new user (with account) created by Create().
 func (p *Processor) TokenForNewUser(
@@ -0,0 +1,209 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// canInvite returns whether the given
+// user is allowed to create invites.
+func canInvite(user *gtsmodel.User) bool {
+	return *user.Admin || config.GetAccountsAllowUserInvites()
+}
+
+// InvitesGet returns a page of invites
+// created by the given user, newest first.
+func (p *Processor) InvitesGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	invites, err := p.state.DB.GetInvites(
+		gtscontext.SetBarebones(ctx),
+		user.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for empty response.
+	count := len(invites)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := invites[count-1].ID
+	hi := invites[0].ID
+
+	items := make([]interface{}, 0, count)
+	for _, invite := range invites {
+		apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite, false)
+		if err != nil {
+			log.Errorf(ctx, "error converting invite to api invite: %v", err)
+			continue
+		}
+		items = append(items, apiInvite)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/user/invites",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// InviteCreate creates a new invite for the given user,
+// using the parameters in the given form. Only admins
+// may create invites, unless user invites are allowed.
+func (p *Processor) InviteCreate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	form *apimodel.InviteCreateRequest,
+) (*apimodel.Invite, gtserror.WithCode) {
+	if !canInvite(user) {
+		const text = "creating invites is not allowed on this instance"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	if form.MaxUses < 0 {
+		const text = "max_uses must not be negative"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.ExpiresIn < 0 {
+		const text = "expires_in must not be negative"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:         id.NewULID(),
+		Code:       util.MustGenerateSecret(),
+		UserID:     user.ID,
+		User:       user,
+		MaxUses:    form.MaxUses,
+		Autofollow: util.Ptr(form.Autofollow),
+	}
+
+	if form.ExpiresIn > 0 {
+		expiresIn := time.Duration(form.ExpiresIn) * time.Second
+		invite.ExpiresAt = time.Now().Add(expiresIn)
+	}
+
+	if err := p.state.DB.PutInvite(ctx, invite); err != nil {
+		err := gtserror.Newf("db error putting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite, false)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to api invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
+
+// InviteRevoke revokes the invite with the given
+// ID, provided it was created by the given user.
+func (p *Processor) InviteRevoke(
+	ctx context.Context,
+	user *gtsmodel.User,
+	id string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil || invite.UserID != user.ID {
+		const text = "invite not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if !invite.IsRevoked() {
+		invite.RevokedAt = time.Now()
+		if err := p.state.DB.UpdateInvite(ctx, invite, "revoked_at"); err != nil {
+			err := gtserror.Newf("db error updating invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite, false)
+	if err != nil {
+		err := gtserror.Newf("error converting invite to api invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
+
+// InviteGetForSignup returns the invite with the given
+// code, with its creator populated, if it can currently
+// be used to sign up. Otherwise an error is returned.
+func (p *Processor) InviteGetForSignup(
+	ctx context.Context,
+	code string,
+) (*gtsmodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByCode(ctx, code)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		// Not a 404 as this is used for
+		// validating sign up requests.
+		const text = "invite not found"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// An invite stops working when it's revoked, expired or
+	// used up, when its creator can no longer sign in, or when
+	// its creator is no longer allowed to create invites.
+	inviter := invite.User
+	if !invite.IsValid() ||
+		*inviter.Disabled ||
+		inviter.Account == nil ||
+		inviter.Account.IsSuspended() ||
+		!canInvite(inviter) {
+		const text = "invite is no longer valid"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return invite, nil
+}
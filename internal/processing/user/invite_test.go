@@ -0,0 +1,152 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type InviteTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *InviteTestSuite) signupForm(username string, inviteCode string) *apimodel.AccountCreateRequest {
+	return &apimodel.AccountCreateRequest{
+		Username:   username,
+		Email:      username + "@example.org",
+		Password:   "a long enough password for this endpoint",
+		Agreement:  true,
+		Locale:     "en-us",
+		InviteCode: inviteCode,
+		IP:         net.ParseIP("192.0.2.128"),
+	}
+}
+
+func (suite *InviteTestSuite) TestInviteCreateNotAllowed() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	_, errWithCode := suite.user.InviteCreate(ctx, user, &apimodel.InviteCreateRequest{})
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Allowed once user invites are enabled.
+	config.SetAccountsAllowUserInvites(true)
+	invite, errWithCode := suite.user.InviteCreate(ctx, user, &apimodel.InviteCreateRequest{})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(invite.Valid)
+	suite.Nil(invite.MaxUses)
+	suite.Nil(invite.ExpiresAt)
+	suite.Equal("http://localhost:8080/signup?invite="+invite.Code, invite.URL)
+
+	// And stops working when they're disabled again.
+	config.SetAccountsAllowUserInvites(false)
+	_, errWithCode = suite.user.InviteGetForSignup(ctx, invite.Code)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *InviteTestSuite) TestCreateWithInvite() {
+	ctx := context.Background()
+	admin := suite.testUsers["admin_account"]
+
+	// Registration closed, and no room for
+	// any more sign ups without an invite.
+	config.SetAccountsRegistrationOpen(false)
+	config.SetAccountsRegistrationBacklogLimit(1)
+	config.SetAccountsRegistrationDailyLimit(1)
+
+	invite, errWithCode := suite.user.InviteCreate(ctx, admin, &apimodel.InviteCreateRequest{
+		MaxUses:    1,
+		ExpiresIn:  3600,
+		Autofollow: true,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(util.Ptr(1), invite.MaxUses)
+	suite.NotNil(invite.ExpiresAt)
+
+	_, errWithCode = suite.user.Create(ctx, nil, suite.signupForm("uninvited", ""))
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	user, errWithCode := suite.user.Create(ctx, nil, suite.signupForm("invited", invite.Code))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(invite.ID, user.InviteID)
+	suite.False(*user.Approved)
+
+	// New account should have
+	// requested to follow inviter.
+	followReq, err := suite.db.GetFollowRequest(ctx, user.AccountID, admin.AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotNil(followReq)
+
+	// Invite is now used up.
+	_, errWithCode = suite.user.Create(ctx, nil, suite.signupForm("invited_again", invite.Code))
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: invite is no longer valid", errWithCode.Safe())
+}
+
+func (suite *InviteTestSuite) TestCreateWithUnknownInvite() {
+	ctx := context.Background()
+
+	_, errWithCode := suite.user.Create(ctx, nil, suite.signupForm("invited", "not a real code"))
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: invite not found", errWithCode.Safe())
+}
+
+func (suite *InviteTestSuite) TestInviteRevoke() {
+	ctx := context.Background()
+	admin := suite.testUsers["admin_account"]
+	user := suite.testUsers["local_account_1"]
+
+	invite, errWithCode := suite.user.InviteCreate(ctx, admin, &apimodel.InviteCreateRequest{})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Can't revoke someone else's invite.
+	_, errWithCode = suite.user.InviteRevoke(ctx, user, invite.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	revoked, errWithCode := suite.user.InviteRevoke(ctx, admin, invite.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(revoked.Valid)
+	suite.NotNil(revoked.RevokedAt)
+
+	_, errWithCode = suite.user.InviteGetForSignup(ctx, invite.Code)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, &InviteTestSuite{})
+}
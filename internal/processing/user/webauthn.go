@@ -0,0 +1,387 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/internal/webauthn"
+	"codeberg.org/gruf/go-byteutil"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// maxWebAuthnCredentials is the maximum
+	// number of WebAuthn credentials per user.
+	maxWebAuthnCredentials = 32
+
+	// maxWebAuthnNameLength is the maximum
+	// length of a WebAuthn credential name.
+	maxWebAuthnNameLength = 64
+)
+
+// relyingParty returns the WebAuthn relying
+// party details for this instance. Credentials
+// are scoped to the host, without any port.
+func relyingParty() *webauthn.RelyingParty {
+	host := config.GetHost()
+	rpID := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		rpID = h
+	}
+
+	return &webauthn.RelyingParty{
+		ID:     rpID,
+		Name:   host,
+		Origin: config.GetProtocol() + "://" + host,
+	}
+}
+
+// WebAuthnCredentialsGet returns all WebAuthn
+// credentials registered by the given user.
+func (p *Processor) WebAuthnCredentialsGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+) ([]*apimodel.WebAuthnCredential, gtserror.WithCode) {
+	creds, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting webauthn credentials: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiCreds := make([]*apimodel.WebAuthnCredential, 0, len(creds))
+	for _, cred := range creds {
+		apiCreds = append(apiCreds, p.converter.WebAuthnCredentialToAPIWebAuthnCredential(cred))
+	}
+
+	return apiCreds, nil
+}
+
+// WebAuthnRegisterOptions returns options for registering a new
+// WebAuthn credential for the given user, to be passed to
+// navigator.credentials.create(). The challenge in the options
+// is stored until the registration is completed or times out.
+func (p *Processor) WebAuthnRegisterOptions(
+	ctx context.Context,
+	user *gtsmodel.User,
+	passwordless bool,
+) (*webauthn.CreationOptions, gtserror.WithCode) {
+	creds, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting webauthn credentials: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(creds) >= maxWebAuthnCredentials {
+		const errText = "maximum number of security keys reached; remove one first then try again"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(errText), errText)
+	}
+
+	// Exclude existing credentials so that the
+	// same authenticator isn't registered twice.
+	exclude := credentialDescriptors(creds)
+
+	// Generate + store a new challenge for this
+	// user, replacing any previous pending one.
+	challenge := webauthn.NewChallenge()
+	p.state.Caches.WebAuthnChallenges.Set(user.ID, challenge)
+
+	return relyingParty().CreationOptions(
+		challenge,
+		webauthn.UserEntity{
+			// The user handle, must not contain
+			// personally identifying information.
+			ID:          webauthn.EncodeToString(byteutil.S2B(user.ID)),
+			Name:        user.Email,
+			DisplayName: user.Email,
+		},
+		exclude,
+		passwordless,
+	), nil
+}
+
+// WebAuthnRegister verifies and stores a new WebAuthn credential
+// for the given user, created by navigator.credentials.create()
+// with options previously returned from WebAuthnRegisterOptions.
+func (p *Processor) WebAuthnRegister(
+	ctx context.Context,
+	user *gtsmodel.User,
+	form *apimodel.WebAuthnRegisterRequest,
+) (*apimodel.WebAuthnCredential, gtserror.WithCode) {
+	name := strings.TrimSpace(form.Name)
+	if name == "" {
+		const errText = "name cannot be empty"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	if len([]rune(name)) > maxWebAuthnNameLength {
+		const errText = "name must be no more than 64 characters"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	// Challenges are one-use only, so
+	// remove from the cache immediately.
+	challenge, ok := p.state.Caches.WebAuthnChallenges.Get(user.ID)
+	if !ok {
+		const errText = "no pending security key registration; request registration options first"
+		return nil, gtserror.NewErrorForbidden(errors.New(errText), errText)
+	}
+	p.state.Caches.WebAuthnChallenges.Invalidate(user.ID)
+
+	resp := new(webauthn.RegistrationResponse)
+	if err := json.Unmarshal(byteutil.S2B(form.Credential), resp); err != nil {
+		const errText = "credential was not valid json"
+		return nil, gtserror.NewErrorBadRequest(err, errText)
+	}
+
+	cred, err := relyingParty().VerifyRegistration(
+		resp,
+		challenge,
+		form.Passwordless,
+	)
+	if err != nil {
+		err := gtserror.Newf("error verifying webauthn registration: %w", err)
+		const errText = "security key registration could not be verified, try again"
+		return nil, gtserror.NewErrorForbidden(err, errText)
+	}
+
+	credentialID := webauthn.EncodeToString(cred.ID)
+
+	// Ensure credential isn't already in use by anyone.
+	existing, err := p.state.DB.GetWebAuthnCredentialByCredentialID(ctx, credentialID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		const errText = "this security key is already registered"
+		return nil, gtserror.NewErrorConflict(errors.New(errText), errText)
+	}
+
+	dbCred := &gtsmodel.WebAuthnCredential{
+		ID:           id.NewULID(),
+		UserID:       user.ID,
+		Name:         name,
+		CredentialID: credentialID,
+		PublicKey:    cred.PublicKey,
+		SignCount:    cred.SignCount,
+		Transports:   cred.Transports,
+		Passwordless: util.Ptr(form.Passwordless),
+	}
+
+	if len(cred.AAGUID) != 0 {
+		dbCred.AAGUID = webauthn.EncodeToString(cred.AAGUID)
+	}
+
+	if err := p.state.DB.PutWebAuthnCredential(ctx, dbCred); err != nil {
+		err := gtserror.Newf("db error putting webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.WebAuthnCredentialToAPIWebAuthnCredential(dbCred), nil
+}
+
+// WebAuthnCredentialDelete deletes the WebAuthn credential with
+// the given ID registered by the given user. User's current
+// password must be provided for verification purposes.
+func (p *Processor) WebAuthnCredentialDelete(
+	ctx context.Context,
+	user *gtsmodel.User,
+	credID string,
+	password string,
+) gtserror.WithCode {
+	cred, err := p.state.DB.GetWebAuthnCredentialByID(ctx, credID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting webauthn credential: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if cred == nil || cred.UserID != user.ID {
+		err := gtserror.Newf("webauthn credential %s not found for user %s", credID, user.ID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	// Ensure provided password is correct.
+	if err := bcrypt.CompareHashAndPassword(
+		byteutil.S2B(user.EncryptedPassword),
+		byteutil.S2B(password),
+	); err != nil {
+		const errText = "incorrect password"
+		return gtserror.NewErrorUnauthorized(errors.New(errText), errText)
+	}
+
+	if err := p.state.DB.DeleteWebAuthnCredentialByID(ctx, cred.ID); err != nil {
+		err := gtserror.Newf("db error deleting webauthn credential: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// WebAuthnLoginOptions returns options for signing in with a WebAuthn
+// credential, to be passed to navigator.credentials.get(), along with
+// the challenge in those options. The caller is responsible for keeping
+// the challenge until the sign in is completed with WebAuthnLogin.
+//
+// If user is set, the options allow only that user's credentials, for
+// use as a second factor. If user is nil, any passkey can be used, for
+// passwordless sign in.
+func (p *Processor) WebAuthnLoginOptions(
+	ctx context.Context,
+	user *gtsmodel.User,
+) (*webauthn.RequestOptions, string, gtserror.WithCode) {
+	var allow []webauthn.CredentialDescriptor
+	if user != nil {
+		creds, err := p.state.DB.GetWebAuthnCredentialsByUserID(ctx, user.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting webauthn credentials: %w", err)
+			return nil, "", gtserror.NewErrorInternalError(err)
+		}
+
+		if len(creds) == 0 {
+			const errText = "no security keys registered"
+			return nil, "", gtserror.NewErrorUnprocessableEntity(errors.New(errText), errText)
+		}
+
+		allow = credentialDescriptors(creds)
+	}
+
+	challenge := webauthn.NewChallenge()
+	return relyingParty().RequestOptions(challenge, allow), challenge, nil
+}
+
+// WebAuthnLogin verifies the given JSON encoded WebAuthn assertion,
+// created by navigator.credentials.get() with options previously
+// returned by WebAuthnLoginOptions, returning the user signing in.
+//
+// If user is set, the assertion must be from one of their credentials,
+// as a second factor. If user is nil, the assertion must be from a
+// passkey with user verification, for passwordless sign in.
+func (p *Processor) WebAuthnLogin(
+	ctx context.Context,
+	user *gtsmodel.User,
+	challenge string,
+	assertion string,
+) (*gtsmodel.User, gtserror.WithCode) {
+	resp := new(webauthn.AssertionResponse)
+	if err := json.Unmarshal(byteutil.S2B(assertion), resp); err != nil {
+		const errText = "security key response was not valid json"
+		return nil, gtserror.NewErrorBadRequest(err, errText)
+	}
+
+	const errText = "security key could not be verified, try again"
+
+	// Normalize the credential ID
+	// to how we store it in the db.
+	rawID, err := webauthn.DecodeString(resp.RawID)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, errText)
+	}
+	credentialID := webauthn.EncodeToString(rawID)
+
+	cred, err := p.state.DB.GetWebAuthnCredentialByCredentialID(ctx, credentialID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if cred == nil {
+		err := gtserror.Newf("webauthn credential %s not found", credentialID)
+		return nil, gtserror.NewErrorUnauthorized(err, errText)
+	}
+
+	passwordless := (user == nil)
+	if passwordless && !*cred.Passwordless {
+		err := gtserror.Newf("webauthn credential %s not usable for passwordless sign in", cred.ID)
+		return nil, gtserror.NewErrorUnauthorized(err, errText)
+	}
+
+	if !passwordless && cred.UserID != user.ID {
+		err := gtserror.Newf("webauthn credential %s not owned by user %s", cred.ID, user.ID)
+		return nil, gtserror.NewErrorUnauthorized(err, errText)
+	}
+
+	result, err := relyingParty().VerifyAssertion(
+		resp,
+		challenge,
+		cred.PublicKey,
+		cred.SignCount,
+		passwordless,
+	)
+	if err != nil {
+		err := gtserror.Newf("error verifying webauthn assertion for credential %s: %w", cred.ID, err)
+		return nil, gtserror.NewErrorUnauthorized(err, errText)
+	}
+
+	if passwordless {
+		// Passkeys must return the user handle
+		// they were registered with; check it.
+		if string(result.UserHandle) != cred.UserID {
+			err := gtserror.Newf("webauthn credential %s user handle mismatch", cred.ID)
+			return nil, gtserror.NewErrorUnauthorized(err, errText)
+		}
+
+		user, err = p.state.DB.GetUserByID(ctx, cred.UserID)
+		if err != nil {
+			err := gtserror.Newf("db error getting user %s: %w", cred.UserID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Store updated counter + usage time.
+	cred.SignCount = result.SignCount
+	cred.LastUsedAt = time.Now()
+	if err := p.state.DB.UpdateWebAuthnCredential(
+		ctx,
+		cred,
+		"sign_count",
+		"last_used_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating webauthn credential: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
+
+// credentialDescriptors converts the given credentials
+// into descriptors, for allowing or excluding them.
+func credentialDescriptors(creds []*gtsmodel.WebAuthnCredential) []webauthn.CredentialDescriptor {
+	descs := make([]webauthn.CredentialDescriptor, len(creds))
+	for i, cred := range creds {
+		descs[i] = webauthn.CredentialDescriptor{
+			Type:       "public-key",
+			ID:         cred.CredentialID,
+			Transports: cred.Transports,
+		}
+	}
+	return descs
+}
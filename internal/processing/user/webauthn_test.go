@@ -0,0 +1,195 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+// origin of the test
+// instance, from config.
+const testOrigin = "http://localhost:8080"
+
+type WebAuthnTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *WebAuthnTestSuite) register(
+	authenticator *testrig.WebAuthnAuthenticator,
+	passwordless bool,
+) *apimodel.WebAuthnCredential {
+	var (
+		ctx  = context.Background()
+		user = suite.testUsers["local_account_1"]
+	)
+
+	options, errWithCode := suite.user.WebAuthnRegisterOptions(ctx, user, passwordless)
+	suite.NoError(errWithCode)
+	suite.Equal("localhost", options.RP.ID)
+
+	cred, errWithCode := suite.user.WebAuthnRegister(ctx, user, &apimodel.WebAuthnRegisterRequest{
+		Name:         "yubikey",
+		Passwordless: passwordless,
+		Credential:   authenticator.Create(options, testOrigin),
+	})
+	suite.NoError(errWithCode)
+	return cred
+}
+
+func (suite *WebAuthnTestSuite) TestRegisterAndLogin() {
+	var (
+		ctx           = context.Background()
+		user          = suite.testUsers["local_account_1"]
+		authenticator = testrig.NewWebAuthnAuthenticator()
+	)
+
+	cred := suite.register(authenticator, false)
+	suite.Equal("yubikey", cred.Name)
+	suite.False(cred.Passwordless)
+	suite.Equal([]string{"usb"}, cred.Transports)
+
+	creds, errWithCode := suite.user.WebAuthnCredentialsGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Len(creds, 1)
+
+	// Sign in using key as second factor.
+	options, challenge, errWithCode := suite.user.WebAuthnLoginOptions(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Len(options.AllowCredentials, 1)
+
+	assertion := authenticator.Get(options, testOrigin)
+	signedIn, errWithCode := suite.user.WebAuthnLogin(ctx, user, challenge, assertion)
+	suite.NoError(errWithCode)
+	suite.Equal(user.ID, signedIn.ID)
+
+	// Replaying the same assertion must fail,
+	// as the signature counter hasn't increased.
+	_, errWithCode = suite.user.WebAuthnLogin(ctx, user, challenge, assertion)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Key isn't a passkey, so it can't
+	// be used for passwordless sign in.
+	options, challenge, errWithCode = suite.user.WebAuthnLoginOptions(ctx, nil)
+	suite.NoError(errWithCode)
+	suite.Empty(options.AllowCredentials)
+
+	_, errWithCode = suite.user.WebAuthnLogin(ctx, nil, challenge, authenticator.Get(options, testOrigin))
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+}
+
+func (suite *WebAuthnTestSuite) TestPasswordlessLogin() {
+	var (
+		ctx           = context.Background()
+		user          = suite.testUsers["local_account_1"]
+		authenticator = testrig.NewWebAuthnAuthenticator()
+	)
+
+	cred := suite.register(authenticator, true)
+	suite.True(cred.Passwordless)
+
+	options, challenge, errWithCode := suite.user.WebAuthnLoginOptions(ctx, nil)
+	suite.NoError(errWithCode)
+
+	signedIn, errWithCode := suite.user.WebAuthnLogin(ctx, nil, challenge, authenticator.Get(options, testOrigin))
+	suite.NoError(errWithCode)
+	suite.Equal(user.ID, signedIn.ID)
+}
+
+func (suite *WebAuthnTestSuite) TestLoginWrongUser() {
+	var (
+		ctx           = context.Background()
+		otherUser     = suite.testUsers["local_account_2"]
+		authenticator = testrig.NewWebAuthnAuthenticator()
+	)
+
+	suite.register(authenticator, false)
+
+	// Other user has no keys registered.
+	_, _, errWithCode := suite.user.WebAuthnLoginOptions(ctx, otherUser)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Key of local_account_1 can't be
+	// used as local_account_2's second factor.
+	options, challenge, errWithCode := suite.user.WebAuthnLoginOptions(ctx, nil)
+	suite.NoError(errWithCode)
+
+	_, errWithCode = suite.user.WebAuthnLogin(ctx, otherUser, challenge, authenticator.Get(options, testOrigin))
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+}
+
+func (suite *WebAuthnTestSuite) TestRegisterNoChallenge() {
+	var (
+		ctx           = context.Background()
+		user          = suite.testUsers["local_account_1"]
+		authenticator = testrig.NewWebAuthnAuthenticator()
+	)
+
+	options, errWithCode := suite.user.WebAuthnRegisterOptions(ctx, user, false)
+	suite.NoError(errWithCode)
+	credential := authenticator.Create(options, testOrigin)
+
+	_, errWithCode = suite.user.WebAuthnRegister(ctx, user, &apimodel.WebAuthnRegisterRequest{
+		Name:       "yubikey",
+		Credential: credential,
+	})
+	suite.NoError(errWithCode)
+
+	// Challenge has been used,
+	// so can't register again.
+	_, errWithCode = suite.user.WebAuthnRegister(ctx, user, &apimodel.WebAuthnRegisterRequest{
+		Name:       "yubikey",
+		Credential: credential,
+	})
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *WebAuthnTestSuite) TestDelete() {
+	var (
+		ctx           = context.Background()
+		user          = suite.testUsers["local_account_1"]
+		authenticator = testrig.NewWebAuthnAuthenticator()
+	)
+
+	cred := suite.register(authenticator, false)
+
+	errWithCode := suite.user.WebAuthnCredentialDelete(ctx, user, cred.ID, "wrong password")
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	errWithCode = suite.user.WebAuthnCredentialDelete(ctx, user, cred.ID, "password")
+	suite.NoError(errWithCode)
+
+	creds, errWithCode := suite.user.WebAuthnCredentialsGet(ctx, user)
+	suite.NoError(errWithCode)
+	suite.Empty(creds)
+}
+
+func TestWebAuthnTestSuite(t *testing.T) {
+	suite.Run(t, new(WebAuthnTestSuite))
+}
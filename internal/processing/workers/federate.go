@@ -186,7 +186,9 @@ func (f *federate) CreateStatus(ctx context.Context, status *gtsmodel.Status) er
 	if _, err := f.FederatingActor().Send(ctx, outboxIRI, create); err != nil {
 		return gtserror.Newf("error sending Create activity via outbox %s: %w", outboxIRI, err)
 	}
-	return nil
+
+	// Public statuses also go to any relays.
+	return f.deliverToRelays(ctx, status, create)
 }
 
 func (f *federate) CreatePollVote(ctx context.Context, poll *gtsmodel.Poll, vote *gtsmodel.PollVote) error {
@@ -265,7 +267,9 @@ func (f *federate) DeleteStatus(ctx context.Context, status *gtsmodel.Status) er
 		)
 	}
 
-	return nil
+	// Deletes of public statuses also go to any
+	// relays, so they can pass the Delete along.
+	return f.deliverToRelays(ctx, status, delete)
 }
 
 func (f *federate) UpdateStatus(ctx context.Context, status *gtsmodel.Status) error {
@@ -472,6 +476,64 @@ func (f *federate) UndoLike(ctx context.Context, fave *gtsmodel.StatusFave) erro
 	return nil
 }
 
+func (f *federate) UndoEmojiReact(ctx context.Context, reaction *gtsmodel.StatusReaction) error {
+	// Populate model.
+	if err := f.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction: %w", err)
+	}
+
+	// Do nothing if both accounts are local.
+	if reaction.Account.IsLocal() &&
+		reaction.TargetAccount.IsLocal() {
+		return nil
+	}
+
+	// Parse relevant URI(s).
+	outboxIRI, err := parseURI(reaction.Account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	targetAccountIRI, err := parseURI(reaction.TargetAccount.URI)
+	if err != nil {
+		return err
+	}
+
+	// Recreate the ActivityStreams EmojiReact.
+	react, err := f.converter.StatusReactionToASEmojiReact(ctx, reaction)
+	if err != nil {
+		return gtserror.Newf("error converting reaction to AS: %w", err)
+	}
+
+	// Create a new Undo with the
+	// same Actor as the EmojiReact.
+	undo := streams.NewActivityStreamsUndo()
+	undo.SetActivityStreamsActor(react.GetActivityStreamsActor())
+
+	// Set the whole recreated EmojiReact
+	// as the 'object' property of the Undo.
+	undoObject := streams.NewActivityStreamsObjectProperty()
+	undoObject.AppendActivityStreamsLike(react)
+	undo.SetActivityStreamsObject(undoObject)
+
+	// Address the Undo To the target account.
+	undoTo := streams.NewActivityStreamsToProperty()
+	undoTo.AppendIRI(targetAccountIRI)
+	undo.SetActivityStreamsTo(undoTo)
+
+	// Send the Undo via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(
+		ctx, outboxIRI, undo,
+	); err != nil {
+		return gtserror.Newf(
+			"error sending activity %T via outbox %s: %w",
+			undo, outboxIRI, err,
+		)
+	}
+
+	return nil
+}
+
 func (f *federate) UndoAnnounce(ctx context.Context, boost *gtsmodel.Status) error {
 	// Populate model.
 	if err := f.state.DB.PopulateStatus(ctx, boost); err != nil {
@@ -748,6 +810,45 @@ func (f *federate) Like(ctx context.Context, fave *gtsmodel.StatusFave) error {
 	return nil
 }
 
+// EmojiReact sends the given emoji reaction out
+// to the account owning the reacted-to status.
+func (f *federate) EmojiReact(ctx context.Context, reaction *gtsmodel.StatusReaction) error {
+	// Populate model.
+	if err := f.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction: %w", err)
+	}
+
+	// Do nothing if both accounts are local.
+	if reaction.Account.IsLocal() &&
+		reaction.TargetAccount.IsLocal() {
+		return nil
+	}
+
+	// Parse relevant URI(s).
+	outboxIRI, err := parseURI(reaction.Account.OutboxURI)
+	if err != nil {
+		return err
+	}
+
+	// Create the ActivityStreams EmojiReact.
+	react, err := f.converter.StatusReactionToASEmojiReact(ctx, reaction)
+	if err != nil {
+		return gtserror.Newf("error converting reaction to AS EmojiReact: %w", err)
+	}
+
+	// Send the EmojiReact via the Actor's outbox.
+	if _, err := f.FederatingActor().Send(
+		ctx, outboxIRI, react,
+	); err != nil {
+		return gtserror.Newf(
+			"error sending activity %T via outbox %s: %w",
+			react, outboxIRI, err,
+		)
+	}
+
+	return nil
+}
+
 // Announce sends the given boost out to relevant
 // recipients with the Outbox of the status creator.
 //
@@ -859,6 +960,66 @@ func (f *federate) deliverToInboxOnly(
 	return nil
 }
 
+// deliverToRelays delivers the given activity
+// concerning a public local status to the inbox
+// of each relay that has accepted our subscription,
+// on behalf of the status author.
+func (f *federate) deliverToRelays(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	t vocab.Type,
+) error {
+	if status.Visibility != gtsmodel.VisibilityPublic {
+		// Only public statuses
+		// are sent to relays.
+		return nil
+	}
+
+	relays, err := f.state.DB.GetAcceptedRelays(ctx)
+	if err != nil {
+		return gtserror.Newf("db error getting relays: %w", err)
+	}
+
+	if len(relays) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	inboxes := make([]*url.URL, 0, len(relays))
+	for _, relay := range relays {
+		inbox, err := parseURI(relay.InboxURI)
+		if err != nil {
+			return err
+		}
+		inboxes = append(inboxes, inbox)
+	}
+
+	tsport, err := f.TransportController().NewTransportForUsername(
+		ctx,
+		status.Account.Username,
+	)
+	if err != nil {
+		return gtserror.Newf(
+			"error getting transport to deliver activity %T to relays: %w",
+			t, err,
+		)
+	}
+
+	m, err := ap.Serialize(t)
+	if err != nil {
+		return err
+	}
+
+	if err := tsport.BatchDeliver(ctx, m, inboxes); err != nil {
+		return gtserror.Newf(
+			"error delivering activity %T to relays: %w",
+			t, err,
+		)
+	}
+
+	return nil
+}
+
 func (f *federate) UpdateAccount(ctx context.Context, account *gtsmodel.Account) error {
 	// Populate model.
 	if err := f.state.DB.PopulateAccount(ctx, account); err != nil {
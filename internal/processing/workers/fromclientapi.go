@@ -99,6 +99,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.CreateLike(ctx, cMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.CreateEmojiReact(ctx, cMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.CreateAnnounce(ctx, cMsg)
@@ -152,6 +156,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// ACCEPT BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.AcceptAnnounce(ctx, cMsg)
+
+		// ACCEPT QUOTE
+		case ap.ActivityQuoteRequest:
+			return p.clientAPI.AcceptQuote(ctx, cMsg)
 		}
 
 	// REJECT SOMETHING
@@ -177,6 +185,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// REJECT BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.RejectAnnounce(ctx, cMsg)
+
+		// REJECT QUOTE
+		case ap.ActivityQuoteRequest:
+			return p.clientAPI.RejectQuote(ctx, cMsg)
 		}
 
 	// UNDO SOMETHING
@@ -195,6 +207,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.UndoFave(ctx, cMsg)
 
+		// UNDO EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.UndoEmojiReact(ctx, cMsg)
+
 		// UNDO ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.UndoAnnounce(ctx, cMsg)
@@ -241,6 +257,11 @@ func (p *clientAPI) CreateUser(ctx context.Context, cMsg *messages.FromClientAPI
 		return gtserror.Newf("%T not parseable as *gtsmodel.User", cMsg.GTSModel)
 	}
 
+	// Make the new account searchable.
+	if err := p.state.DB.IndexAccount(ctx, cMsg.Origin); err != nil {
+		log.Errorf(ctx, "error indexing new account: %v", err)
+	}
+
 	// Notify mods of the new signup.
 	if err := p.surface.notifySignup(ctx, newUser); err != nil {
 		log.Errorf(ctx, "error notifying mods of new sign-up: %v", err)
@@ -262,6 +283,7 @@ func (p *clientAPI) CreateUser(ctx context.Context, cMsg *messages.FromClientAPI
 func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	var status *gtsmodel.Status
 	var backfill bool
+	var federate bool
 
 	// Check passed client message model type.
 	switch model := cMsg.GTSModel.(type) {
@@ -270,10 +292,50 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 	case *gtsmodel.BackfillStatus:
 		status = model.Status
 		backfill = true
+		federate = model.Federate
 	default:
 		return gtserror.Newf("%T not parseable as *gtsmodel.Status or *gtsmodel.BackfillStatus", cMsg.GTSModel)
 	}
 
+	// Make the new status searchable.
+	if err := p.state.DB.IndexStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error indexing status: %v", err)
+	}
+
+	// Generate a preview card for
+	// the first link in the status.
+	p.federate.RefreshStatusPreviewCardAsync(ctx, status)
+
+	// Handle the quote (if any) separately from
+	// the reply, as each requires its own approval.
+	// The quoting status is published either way,
+	// only the quote itself awaits approval.
+	quotePendingApproval := util.PtrOrZero(status.QuotePendingApproval)
+
+	switch {
+	case quotePendingApproval && !status.QuotePreApproved:
+		// Quote requires approval, notify
+		// the account being interacted
+		// with if it's local: they can
+		// approve or deny the quote later.
+		if err := p.utils.requestQuote(ctx, status); err != nil {
+			return gtserror.Newf("error pending quote: %w", err)
+		}
+
+	case quotePendingApproval && status.QuotePreApproved:
+		// Quote of one of our statuses that matched
+		// on a following/followers collection.
+		// Do the Accept immediately.
+		approval, err := p.utils.preApproveQuote(ctx, status)
+		if err != nil {
+			return gtserror.Newf("error pre-approving quote: %w", err)
+		}
+
+		if err := p.federate.AcceptInteraction(ctx, approval); err != nil {
+			return gtserror.Newf("error federating pre-approval of quote: %w", err)
+		}
+	}
+
 	// If pending approval is true then status must
 	// reply to a status (either one of ours or a
 	// remote) that requires approval for the reply.
@@ -366,6 +428,17 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		if err := p.federate.CreateStatus(ctx, status); err != nil {
 			log.Errorf(ctx, "error federating status: %v", err)
 		}
+
+		if err := p.utils.groupAnnounce(ctx, status); err != nil {
+			log.Errorf(ctx, "error announcing status to groups: %v", err)
+		}
+	} else if federate {
+
+		// Backfilled statuses aren't timelined,
+		// but may still be explicitly federated.
+		if err := p.federate.CreateStatus(ctx, status); err != nil {
+			log.Errorf(ctx, "error federating backfilled status: %v", err)
+		}
 	}
 
 	if status.InReplyToID != "" {
@@ -717,6 +790,15 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 		return gtserror.Newf("cannot cast %T -> *gtsmodel.Status", cMsg.GTSModel)
 	}
 
+	// Update the status' searchable text.
+	if err := p.state.DB.IndexStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error indexing status: %v", err)
+	}
+
+	// Refresh the preview card,
+	// in case links have changed.
+	p.federate.RefreshStatusPreviewCardAsync(ctx, status)
+
 	// Federate the updated status changes out remotely.
 	if err := p.federate.UpdateStatus(ctx, status); err != nil {
 		log.Errorf(ctx, "error federating status update: %v", err)
@@ -767,6 +849,11 @@ func (p *clientAPI) UpdateAccount(ctx context.Context, cMsg *messages.FromClient
 		return gtserror.Newf("cannot cast %T -> *gtsmodel.Account", cMsg.GTSModel)
 	}
 
+	// Update the account's searchable text.
+	if err := p.state.DB.IndexAccount(ctx, account); err != nil {
+		log.Errorf(ctx, "error indexing account: %v", err)
+	}
+
 	if err := p.federate.UpdateAccount(ctx, account); err != nil {
 		log.Errorf(ctx, "error federating account update: %v", err)
 	}
@@ -914,6 +1001,41 @@ func (p *clientAPI) UndoFave(ctx context.Context, cMsg *messages.FromClientAPI)
 	return nil
 }
 
+func (p *clientAPI) CreateEmojiReact(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	// Ensure reaction populated.
+	if err := p.state.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating status reaction: %w", err)
+	}
+
+	if err := p.surface.notifyReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error notifying reaction: %v", err)
+	}
+
+	if err := p.federate.EmojiReact(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating reaction: %v", err)
+	}
+
+	return nil
+}
+
+func (p *clientAPI) UndoEmojiReact(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	if err := p.federate.UndoEmojiReact(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating reaction undo: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) UndoAnnounce(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -960,8 +1082,7 @@ func (p *clientAPI) DeleteStatus(ctx context.Context, cMsg *messages.FromClientA
 
 	// Drop any outgoing queued AP requests about / targeting
 	// this status, (stops queued likes, boosts, creates etc).
-	p.state.Workers.Delivery.Queue.Delete("ObjectID", status.URI)
-	p.state.Workers.Delivery.Queue.Delete("TargetID", status.URI)
+	p.state.Workers.Delivery.Delete(ctx, status.URI)
 
 	// Drop any incoming queued client messages about / targeting
 	// status, (stops processing of local origin data for status).
@@ -1030,9 +1151,7 @@ func (p *clientAPI) DeleteAccountOrUser(ctx context.Context, cMsg *messages.From
 
 	// Drop any outgoing queued AP requests to / from / targeting
 	// this account, (stops queued likes, boosts, creates etc).
-	p.state.Workers.Delivery.Queue.Delete("ActorID", account.URI)
-	p.state.Workers.Delivery.Queue.Delete("ObjectID", account.URI)
-	p.state.Workers.Delivery.Queue.Delete("TargetID", account.URI)
+	p.state.Workers.Delivery.Delete(ctx, account.URI)
 
 	// Drop any incoming queued client messages to / from this
 	// account, (stops processing of local origin data for acccount).
@@ -1053,6 +1172,11 @@ func (p *clientAPI) DeleteAccountOrUser(ctx context.Context, cMsg *messages.From
 		log.Errorf(ctx, "error deleting account: %v", err)
 	}
 
+	// Remove the account from search.
+	if err := p.state.DB.DeindexAccount(ctx, account.ID); err != nil {
+		log.Errorf(ctx, "error deindexing account: %v", err)
+	}
+
 	return nil
 }
 
@@ -1264,6 +1388,29 @@ func (p *clientAPI) AcceptAnnounce(ctx context.Context, cMsg *messages.FromClien
 	return nil
 }
 
+func (p *clientAPI) AcceptQuote(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", cMsg.GTSModel)
+	}
+
+	// Notify the quote (distinct from the notif for the pending quote).
+	if err := p.surface.notifyQuote(ctx, req.Quote); err != nil {
+		log.Errorf(ctx, "error notifying quote: %v", err)
+	}
+
+	// Send out the Accept.
+	if err := p.federate.AcceptInteraction(ctx, req); err != nil {
+		log.Errorf(ctx, "error federating approval of quote: %v", err)
+	}
+
+	// Quote state changed on the quoting status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, req.Quote.ID)
+
+	return nil
+}
+
 func (p *clientAPI) RejectLike(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
 	if !ok {
@@ -1383,3 +1530,40 @@ func (p *clientAPI) RejectAnnounce(ctx context.Context, cMsg *messages.FromClien
 
 	return nil
 }
+
+func (p *clientAPI) RejectQuote(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", cMsg.GTSModel)
+	}
+
+	// At this point the InteractionRequest should already
+	// be in the database, we just need to do side effects.
+
+	// Send out the Reject.
+	if err := p.federate.RejectInteraction(ctx, req); err != nil {
+		log.Errorf(ctx, "error federating rejection of quote: %v", err)
+	}
+
+	// Get the rejected quote.
+	quote, err := p.state.DB.GetStatusByURI(
+		gtscontext.SetBarebones(ctx),
+		req.ObjectURI(),
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting rejected quote: %w", err)
+	}
+
+	// Unlike a rejected reply, the quoting status
+	// itself is left intact; we just detach it
+	// from the status it was trying to quote.
+	if err := p.utils.detachQuote(ctx, quote); err != nil {
+		log.Errorf(ctx, "error detaching quote: %v", err)
+	}
+
+	// Quote state changed on the quoting status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, quote.ID)
+
+	return nil
+}
@@ -1338,6 +1338,108 @@ func (suite *FromClientAPITestSuite) TestProcessCreateStatusWithFollowedHashtag(
 	suite.checkNotWebPushed(testStructs.WebPushSender, receivingAccount.ID)
 }
 
+// A public status with a hashtag should be streamed to local users
+// with the matching hashtag and public streams open, but not to users
+// who have the author blocked.
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusStreamsToHashtagAndPublic() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx              = context.Background()
+		postingAccount   = suite.testAccounts["admin_account"]
+		receivingAccount = suite.testAccounts["local_account_2"]
+		blockingAccount  = suite.testAccounts["local_account_1"]
+		testTag          = suite.testTags["welcome"]
+
+		// postingAccount posts a new public status using testTag.
+		status = suite.newStatus(
+			ctx,
+			testStructs.State,
+			postingAccount,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+			nil,
+			false,
+			[]string{testTag.ID},
+		)
+	)
+
+	openStream := func(account *gtsmodel.Account, streamType string) *stream.Stream {
+		str, errWithCode := testStructs.Processor.Stream().Open(ctx, account, streamType)
+		if errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		return str
+	}
+
+	var (
+		publicStream       = openStream(receivingAccount, stream.TimelinePublic)
+		localStream        = openStream(receivingAccount, stream.TimelineLocal)
+		remoteStream       = openStream(receivingAccount, stream.TimelineRemote)
+		hashtagStream      = openStream(receivingAccount, stream.TimelineHashtag+":"+testTag.Name)
+		hashtagLocalStream = openStream(receivingAccount, stream.TimelineHashtagLocal+":"+testTag.Name)
+		otherTagStream     = openStream(receivingAccount, stream.TimelineHashtag+":somethingelse")
+		blockedTagStream   = openStream(blockingAccount, stream.TimelineHashtag+":"+testTag.Name)
+	)
+
+	// Setup: blockingAccount blocks postingAccount.
+	if err := testStructs.State.DB.PutBlock(ctx, &gtsmodel.Block{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/users/the_mighty_zork/blocks/" + id.NewULID(),
+		AccountID:       blockingAccount.ID,
+		TargetAccountID: postingAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the new status.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			Origin:         postingAccount,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Status should be in the
+	// public, local and hashtag streams.
+	for _, str := range []*stream.Stream{
+		publicStream,
+		localStream,
+		hashtagStream,
+		hashtagLocalStream,
+	} {
+		suite.checkStreamed(
+			str,
+			true,
+			"",
+			stream.EventTypeUpdate,
+		)
+	}
+
+	// Status should not be in the remote stream, in
+	// a stream for another tag, or streamed to the
+	// account that has the author blocked.
+	for _, str := range []*stream.Stream{
+		remoteStream,
+		otherTagStream,
+		blockedTagStream,
+	} {
+		suite.checkStreamed(
+			str,
+			false,
+			"",
+			"",
+		)
+	}
+}
+
 // A public status with a hashtag followed by a local user who does not otherwise follow the author
 // should not end up in the tag-following user's home timeline
 // if the user has the author blocked.
@@ -2212,6 +2314,87 @@ func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	}
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusMentioningGroup() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx             = context.Background()
+		groupAdmin      = suite.testAccounts["admin_account"]
+		member          = suite.testAccounts["local_account_1"]
+		nonMember       = suite.testAccounts["local_account_2"]
+		group, groupErr = testStructs.State.DB.NewGroup(ctx, gtsmodel.NewGroup{
+			Username: "knitting",
+			Admin:    groupAdmin,
+		})
+	)
+	if groupErr != nil {
+		suite.FailNow(groupErr.Error())
+	}
+
+	// Member joins group by following it.
+	if err := testStructs.State.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		URI:             member.URI + "/follow/" + id.NewULID(),
+		AccountID:       member.ID,
+		TargetAccountID: group.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, test := range []struct {
+		account   *gtsmodel.Account
+		announced bool
+	}{
+		{account: member, announced: true},
+		{account: nonMember, announced: false},
+	} {
+		// Account posts a status mentioning the group.
+		status := suite.newStatus(
+			ctx,
+			testStructs.State,
+			test.account,
+			gtsmodel.VisibilityPublic,
+			nil,
+			nil,
+			[]*gtsmodel.Account{group},
+			false,
+			nil,
+		)
+
+		// Process the new status.
+		if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+			ctx,
+			&messages.FromClientAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityCreate,
+				GTSModel:       status,
+				Origin:         test.account,
+			},
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		// Group should have announced the status
+		// only if the author is a member of the group.
+		boost, err := testStructs.State.DB.GetStatusBoost(ctx, status.ID, group.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			suite.FailNow(err.Error())
+		}
+
+		if !test.announced {
+			suite.Nil(boost)
+			continue
+		}
+
+		if suite.NotNil(boost) {
+			suite.Equal(group.ID, boost.AccountID)
+			suite.Equal(status.ID, boost.BoostOfID)
+			suite.False(*boost.PendingApproval)
+		}
+	}
+}
+
 func TestFromClientAPITestSuite(t *testing.T) {
 	suite.Run(t, &FromClientAPITestSuite{})
 }
@@ -34,6 +34,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
 	"code.superseriousbusiness.org/gotosocial/internal/messages"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/account"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/common"
@@ -96,6 +97,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		case ap.ActivityLike:
 			return p.fediAPI.CreateLike(ctx, fMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.fediAPI.CreateEmojiReact(ctx, fMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.fediAPI.CreateAnnounce(ctx, fMsg)
@@ -150,6 +155,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		case ap.ActivityAnnounce:
 			return p.fediAPI.AcceptAnnounce(ctx, fMsg)
 
+		// ACCEPT (pending) QUOTE
+		case ap.ActivityQuoteRequest:
+			return p.fediAPI.AcceptQuote(ctx, fMsg)
+
 		// ACCEPT (remote) REPLY or ANNOUNCE
 		case ap.ObjectUnknown:
 			return p.fediAPI.AcceptRemoteStatus(ctx, fMsg)
@@ -170,6 +179,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		// REJECT BOOST
 		case ap.ActivityAnnounce:
 			return p.fediAPI.RejectAnnounce(ctx, fMsg)
+
+		// REJECT QUOTE
+		case ap.ActivityQuoteRequest:
+			return p.fediAPI.RejectQuote(ctx, fMsg)
 		}
 
 	// DELETE SOMETHING
@@ -267,6 +280,45 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		return nil
 	}
 
+	// Make the new status searchable.
+	if err := p.state.DB.IndexStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error indexing status: %v", err)
+	}
+
+	// Generate a preview card for
+	// the first link in the status.
+	p.federate.RefreshStatusPreviewCardAsync(ctx, status)
+
+	// Handle the quote (if any) separately from
+	// the reply, as each requires its own approval.
+	// The quoting status is published either way,
+	// only the quote itself awaits approval.
+	quotePendingApproval := util.PtrOrZero(status.QuotePendingApproval)
+
+	switch {
+	case quotePendingApproval && !status.QuotePreApproved:
+		// Quote requires approval, notify
+		// the account being interacted
+		// with if it's local: they can
+		// approve or deny the quote later.
+		if err := p.utils.requestQuote(ctx, status); err != nil {
+			return gtserror.Newf("error pending quote: %w", err)
+		}
+
+	case quotePendingApproval && status.QuotePreApproved:
+		// Quote of one of our statuses that matched
+		// on a following/followers collection.
+		// Do the Accept immediately.
+		approval, err := p.utils.preApproveQuote(ctx, status)
+		if err != nil {
+			return gtserror.Newf("error pre-approving quote: %w", err)
+		}
+
+		if err := p.federate.AcceptInteraction(ctx, approval); err != nil {
+			return gtserror.Newf("error federating pre-approval of quote: %w", err)
+		}
+	}
+
 	// If pending approval is true then
 	// status must reply to a LOCAL status
 	// that requires approval for the reply.
@@ -333,8 +385,10 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		// Don't return, just continue as normal.
 	}
 
-	// Update stats for the remote account.
-	if err := p.utils.incrementStatusesCount(ctx, fMsg.Requesting, status); err != nil {
+	// Update stats for the remote account. Use the
+	// status author rather than the requester, as the
+	// status may have been forwarded or relayed to us.
+	if err := p.utils.incrementStatusesCount(ctx, status.Account, status); err != nil {
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
@@ -342,6 +396,10 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	if err := p.utils.groupAnnounce(ctx, status); err != nil {
+		log.Errorf(ctx, "error announcing status to groups: %v", err)
+	}
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status; uncache the
 		// prepared version from all timelines. The status dereferencer
@@ -578,6 +636,52 @@ func (p *fediAPI) CreateLike(ctx context.Context, fMsg *messages.FromFediAPI) er
 	return nil
 }
 
+func (p *fediAPI) CreateEmojiReact(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	reaction, ok := fMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", fMsg.GTSModel)
+	}
+
+	if placeholder := reaction.Emoji; placeholder != nil && placeholder.ID == "" {
+		// Custom emoji reaction, fetch the emoji
+		// itself (or our existing copy of it).
+		emoji, err := p.federate.GetEmoji(ctx,
+			placeholder.Shortcode,
+			placeholder.Domain,
+			placeholder.ImageRemoteURL,
+			media.AdditionalEmojiInfo{
+				URI:                  &placeholder.URI,
+				ImageRemoteURL:       &placeholder.ImageRemoteURL,
+				ImageStaticRemoteURL: &placeholder.ImageStaticRemoteURL,
+			},
+			false,
+		)
+		if err != nil && emoji == nil {
+			return gtserror.Newf("error fetching reaction emoji %s: %w", placeholder.ImageRemoteURL, err)
+		}
+
+		reaction.Emoji = emoji
+		reaction.EmojiID = emoji.ID
+	}
+
+	// Store the new reaction.
+	reaction.ID = id.NewULID()
+	if err := p.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Reaction was stored in the
+			// meantime, no side effects.
+			return nil
+		}
+		return gtserror.Newf("db error inserting reaction %s: %w", reaction.URI, err)
+	}
+
+	if err := p.surface.notifyReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error notifying reaction: %v", err)
+	}
+
+	return nil
+}
+
 func (p *fediAPI) CreateAnnounce(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	boost, ok := fMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -958,6 +1062,27 @@ func (p *fediAPI) AcceptAnnounce(ctx context.Context, fMsg *messages.FromFediAPI
 	return nil
 }
 
+func (p *fediAPI) AcceptQuote(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	quote, ok := fMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Status", fMsg.GTSModel)
+	}
+
+	// Send out an Update of the quote so that
+	// remotes pick up the new approval, if it's ours.
+	if quote.IsLocal() {
+		if err := p.federate.UpdateStatus(ctx, quote); err != nil {
+			log.Errorf(ctx, "error federating quote update: %v", err)
+		}
+	}
+
+	// Quote state changed on the quoting status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, quote.ID)
+
+	return nil
+}
+
 func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	// Cast the existing Status model attached to msg.
 	existing, ok := fMsg.GTSModel.(*gtsmodel.Status)
@@ -989,6 +1114,15 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error refreshing status: %v", err)
 	}
 
+	// Update the status' searchable text.
+	if err := p.state.DB.IndexStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error indexing status: %v", err)
+	}
+
+	// Refresh the preview card,
+	// in case links have changed.
+	p.federate.RefreshStatusPreviewCardAsync(ctx, status)
+
 	if status.Poll != nil && status.Poll.Closing {
 
 		// If the latest status has a newly closed poll, at least compared
@@ -1044,8 +1178,7 @@ func (p *fediAPI) DeleteStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 
 	// Drop any outgoing queued AP requests about / targeting
 	// this status, (stops queued likes, boosts, creates etc).
-	p.state.Workers.Delivery.Queue.Delete("ObjectID", status.URI)
-	p.state.Workers.Delivery.Queue.Delete("TargetID", status.URI)
+	p.state.Workers.Delivery.Delete(ctx, status.URI)
 
 	// Drop any incoming queued client messages about / targeting
 	// status, (stops processing of local origin data for status).
@@ -1096,8 +1229,7 @@ func (p *fediAPI) DeleteAccount(ctx context.Context, fMsg *messages.FromFediAPI)
 
 	// Drop any outgoing queued AP requests to / from / targeting
 	// this account, (stops queued likes, boosts, creates etc).
-	p.state.Workers.Delivery.Queue.Delete("ObjectID", account.URI)
-	p.state.Workers.Delivery.Queue.Delete("TargetID", account.URI)
+	p.state.Workers.Delivery.Delete(ctx, account.URI)
 
 	// Drop any incoming queued client messages to / from this
 	// account, (stops processing of local origin data for acccount).
@@ -1114,6 +1246,11 @@ func (p *fediAPI) DeleteAccount(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error deleting account: %v", err)
 	}
 
+	// Remove the account from search.
+	if err := p.state.DB.DeindexAccount(ctx, account.ID); err != nil {
+		log.Errorf(ctx, "error deindexing account: %v", err)
+	}
+
 	return nil
 }
 
@@ -1227,6 +1364,37 @@ func (p *fediAPI) RejectAnnounce(ctx context.Context, fMsg *messages.FromFediAPI
 	return nil
 }
 
+func (p *fediAPI) RejectQuote(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	req, ok := fMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", fMsg.GTSModel)
+	}
+
+	// At this point the InteractionRequest should already
+	// be in the database, we just need to do side effects.
+
+	// Get the rejected quote.
+	quote, err := p.state.DB.GetStatusByURI(
+		gtscontext.SetBarebones(ctx),
+		req.ObjectURI(),
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting rejected quote: %w", err)
+	}
+
+	// Detach the quoting status from the
+	// status it was trying to quote.
+	if err := p.utils.detachQuote(ctx, quote); err != nil {
+		log.Errorf(ctx, "error detaching quote: %v", err)
+	}
+
+	// Quote state changed on the quoting status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(ctx, quote.ID)
+
+	return nil
+}
+
 func (p *fediAPI) UndoAnnounce(
 	ctx context.Context,
 	fMsg *messages.FromFediAPI,
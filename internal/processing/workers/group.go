@@ -0,0 +1,223 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// groupAnnounce re-announces the given status to the members
+// of any local groups that it's addressed to, as per FEP-1b12.
+//
+// A status is addressed to a group if it mentions the group,
+// or if it replies to a status that the group has announced,
+// so that whole threads are shared with group members.
+func (u *utils) groupAnnounce(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) error {
+	if status.BoostOfID != "" {
+		// Boosts are never
+		// posts to a group.
+		return nil
+	}
+
+	if status.Visibility != gtsmodel.VisibilityPublic &&
+		status.Visibility != gtsmodel.VisibilityUnlocked {
+		// Only public and unlisted
+		// statuses can be announced.
+		return nil
+	}
+
+	groups, err := u.statusGroups(ctx, status)
+	if err != nil {
+		return err
+	}
+
+	var errs gtserror.MultiError
+	for _, group := range groups {
+		if err := u.groupAnnounceStatus(ctx, group, status); err != nil {
+			errs.Appendf("error announcing status via group %s: %w", group.URI, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// statusGroups returns the local groups that
+// the given status is addressed to, if any.
+func (u *utils) statusGroups(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) ([]*gtsmodel.Account, error) {
+	// Ensure status mentions are populated.
+	if err := u.state.DB.PopulateStatus(ctx, status); err != nil {
+		log.Errorf(ctx, "error(s) populating status, will continue: %v", err)
+	}
+
+	var groups []*gtsmodel.Account
+	addGroup := func(account *gtsmodel.Account) {
+		if account == nil ||
+			account.IsRemote() ||
+			!account.IsGroup() ||
+			account.IsSuspended() {
+			return
+		}
+
+		if slices.ContainsFunc(groups, func(group *gtsmodel.Account) bool {
+			return group.ID == account.ID
+		}) {
+			return
+		}
+
+		groups = append(groups, account)
+	}
+
+	// Groups mentioned by the status.
+	for _, mention := range status.Mentions {
+		addGroup(mention.TargetAccount)
+	}
+
+	if status.InReplyToID != "" {
+		// Groups that announced the
+		// status this one replies to.
+		boosts, err := u.state.DB.GetStatusBoosts(
+			gtscontext.SetBarebones(ctx),
+			status.InReplyToID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting boosts of %s: %w", status.InReplyToID, err)
+		}
+
+		for _, boost := range boosts {
+			account, err := u.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				boost.AccountID,
+			)
+			if err != nil {
+				return nil, gtserror.Newf("db error getting boost account %s: %w", boost.AccountID, err)
+			}
+			addGroup(account)
+		}
+	}
+
+	return groups, nil
+}
+
+// groupAnnounceStatus creates an Announce of the given
+// status by the given local group, if the status author
+// is a member of the group and the status can be boosted.
+func (u *utils) groupAnnounceStatus(
+	ctx context.Context,
+	group *gtsmodel.Account,
+	status *gtsmodel.Status,
+) error {
+	if status.AccountID == group.ID {
+		// Can't post
+		// to yourself.
+		return nil
+	}
+
+	// Only members of the group, ie., accounts
+	// following it, can post to the group. Banned
+	// members are blocked by the group, which also
+	// removes their follow, so they're caught here.
+	member, err := u.state.DB.IsFollowing(ctx,
+		status.AccountID,
+		group.ID,
+	)
+	if err != nil {
+		return gtserror.Newf("db error checking group membership: %w", err)
+	}
+
+	if !member {
+		log.Debugf(ctx, "%s is not a member of group %s", status.AccountURI, group.URI)
+		return nil
+	}
+
+	// Check if group already announced this.
+	existing, err := u.state.DB.GetStatusBoost(
+		gtscontext.SetBarebones(ctx),
+		status.ID,
+		group.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error checking existing boost: %w", err)
+	}
+
+	if existing != nil {
+		// Already
+		// announced.
+		return nil
+	}
+
+	// Ensure status can be boosted by the group. Statuses
+	// requiring approval are skipped, as there's nobody to
+	// wait for approval on behalf of the group.
+	policyResult, err := u.intFilter.StatusBoostable(ctx,
+		group,
+		status,
+	)
+	if err != nil {
+		return gtserror.Newf("error seeing if status %s is boostable: %w", status.URI, err)
+	}
+
+	if !policyResult.Permitted() || policyResult.MatchedOnCollection() {
+		log.Debugf(ctx, "status %s not boostable by group %s", status.URI, group.URI)
+		return nil
+	}
+
+	boost, err := u.converter.StatusToBoost(ctx,
+		status,
+		group,
+		"", // no application
+	)
+	if err != nil {
+		return gtserror.Newf("error wrapping status in boost: %w", err)
+	}
+	boost.PendingApproval = util.Ptr(false)
+
+	// Store the new boost.
+	if err := u.state.DB.PutStatus(ctx, boost); err != nil {
+		return gtserror.Newf("db error putting boost: %w", err)
+	}
+
+	// Process boost side effects as though the group
+	// boosted the status through the client API, which
+	// timelines the boost for members, and federates it.
+	u.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityAnnounce,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       boost,
+		Origin:         group,
+		Target:         status.Account,
+	})
+
+	return nil
+}
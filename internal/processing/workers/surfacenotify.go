@@ -356,6 +356,59 @@ func (s *Surface) notifyableFave(
 	return true, nil
 }
 
+// notifyReaction notifies the target of the given
+// emoji reaction that their status has been reacted to.
+func (s *Surface) notifyReaction(
+	ctx context.Context,
+	reaction *gtsmodel.StatusReaction,
+) error {
+	if reaction.TargetAccountID == reaction.AccountID {
+		// Self-reaction, nothing to do.
+		return nil
+	}
+
+	// Beforehand, ensure the passed reaction is fully populated.
+	if err := s.State.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction %s: %w", reaction.ID, err)
+	}
+
+	if reaction.TargetAccount.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return nil
+	}
+
+	// Ensure reactee hasn't
+	// muted the thread.
+	muted, err := s.State.DB.IsThreadMutedByAccount(
+		ctx,
+		reaction.Status.ThreadID,
+		reaction.TargetAccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status thread mute %s: %w", reaction.StatusID, err)
+	}
+
+	if muted {
+		// Reactee doesn't want
+		// notifs for this thread.
+		return nil
+	}
+
+	// notify status author
+	// of reaction by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationReaction,
+		reaction.TargetAccount,
+		reaction.Account,
+		reaction.StatusID,
+	); err != nil {
+		return gtserror.Newf("error notifying status author %s: %w", reaction.TargetAccountID, err)
+	}
+
+	return nil
+}
+
 // notifyAnnounce notifies the status boost target
 // account that their status has been boosted.
 func (s *Surface) notifyAnnounce(
@@ -466,6 +519,123 @@ func (s *Surface) notifyableAnnounce(
 	return true, nil
 }
 
+// notifyQuote notifies the quoted status
+// account that their status has been quoted.
+func (s *Surface) notifyQuote(
+	ctx context.Context,
+	quote *gtsmodel.Status,
+) error {
+	notifyable, err := s.notifyableQuote(ctx, quote)
+	if err != nil {
+		return err
+	}
+
+	if !notifyable {
+		// Nothing to do.
+		return nil
+	}
+
+	// notify status author
+	// of quote by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationQuote,
+		quote.QuoteOfAccount,
+		quote.Account,
+		quote.ID,
+	); err != nil {
+		return gtserror.Newf("error notifying quote target %s: %w", quote.QuoteOfAccountID, err)
+	}
+
+	return nil
+}
+
+// notifyPendingQuote notifies the quoted status
+// account that their status has been quoted,
+// and that the quote requires approval.
+func (s *Surface) notifyPendingQuote(
+	ctx context.Context,
+	quote *gtsmodel.Status,
+) error {
+	notifyable, err := s.notifyableQuote(ctx, quote)
+	if err != nil {
+		return err
+	}
+
+	if !notifyable {
+		// Nothing to do.
+		return nil
+	}
+
+	// notify status author
+	// of quote by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationPendingQuote,
+		quote.QuoteOfAccount,
+		quote.Account,
+		quote.ID,
+	); err != nil {
+		return gtserror.Newf("error notifying quote target %s: %w", quote.QuoteOfAccountID, err)
+	}
+
+	return nil
+}
+
+// notifyableQuote checks that the given
+// quote should be notified, taking account
+// of localness of receiving account, and mutes.
+func (s *Surface) notifyableQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) (bool, error) {
+	if status.QuoteOfID == "" {
+		// Not a (resolved)
+		// quote, nothing to do.
+		return false, nil
+	}
+
+	if status.QuoteOfAccountID == status.AccountID {
+		// Self-quote, nothing to do.
+		return false, nil
+	}
+
+	// Beforehand, ensure the passed status is fully populated.
+	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
+		return false, gtserror.Newf("error populating status %s: %w", status.ID, err)
+	}
+
+	if status.QuoteOf == nil || status.QuoteOfAccount == nil {
+		// Quoted status
+		// was deleted.
+		return false, nil
+	}
+
+	if status.QuoteOfAccount.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return false, nil
+	}
+
+	// Ensure quotee hasn't
+	// muted the thread.
+	muted, err := s.State.DB.IsThreadMutedByAccount(
+		ctx,
+		status.QuoteOf.ThreadID,
+		status.QuoteOfAccountID,
+	)
+
+	if err != nil {
+		return false, gtserror.Newf("error checking status thread mute %s: %w", status.QuoteOfID, err)
+	}
+
+	if muted {
+		// Quotee doesn't want
+		// notifs for this thread.
+		return false, nil
+	}
+
+	return true, nil
+}
+
 func (s *Surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status) error {
 	// Beforehand, ensure the passed status is fully populated.
 	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
@@ -595,6 +765,36 @@ func (s *Surface) Notify(
 		return nil
 	}
 
+	if targetAccount.IsGroup() {
+		// Local groups have no
+		// user to notify.
+		return nil
+	}
+
+	switch notificationType {
+	case gtsmodel.NotificationPoll,
+		gtsmodel.NotificationAdminSignup,
+		gtsmodel.NotificationAdminReport:
+		// Always deliver these, as they're
+		// not something origin is pushing
+		// onto the target of their own accord.
+
+	default:
+		// Only followers of a silenced origin account
+		// (or of an account on a limited domain) should
+		// receive notifications from that account.
+		silenced, err := s.VisFilter.AccountSilenced(ctx, targetAccount, originAccount)
+		if err != nil {
+			return gtserror.Newf("error checking if origin account silenced: %w", err)
+		}
+
+		if silenced {
+			// Don't
+			// notify.
+			return nil
+		}
+	}
+
 	// We're doing state-y stuff so get a
 	// lock on this combo of notif params.
 	lockURI := getNotifyLockURI(
@@ -113,6 +113,74 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	}
 }
 
+func (suite *SurfaceNotifyTestSuite) TestNotifyFromLimitedDomain() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	surface := &workers.Surface{
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		VisFilter:     visibility.NewFilter(testStructs.State),
+		EmailSender:   testStructs.EmailSender,
+		WebPushSender: testStructs.WebPushSender,
+		Conversations: testStructs.Processor.Conversations(),
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_2"]
+		originAccount = suite.testAccounts["remote_account_1"]
+	)
+
+	// Limit the origin account's domain.
+	if err := testStructs.State.DB.PutDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 "01JSZ1WK3VHRD2W7ADR6G5M0DA",
+		Domain:             originAccount.Domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Severity:           gtsmodel.DomainBlockSeverityLimit,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	notify := func() bool {
+		if err := surface.Notify(ctx,
+			gtsmodel.NotificationFollow,
+			targetAccount,
+			originAccount,
+			"",
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		_, err := testStructs.State.DB.GetNotification(
+			gtscontext.SetBarebones(ctx),
+			gtsmodel.NotificationFollow,
+			targetAccount.ID,
+			originAccount.ID,
+			"",
+		)
+		return err == nil
+	}
+
+	// Target doesn't follow origin,
+	// so notif should be dropped.
+	suite.False(notify())
+
+	// Make target follow origin.
+	if err := testStructs.State.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01JSZ1YBG8QK5W8FZ8MBC0H4KJ",
+		URI:             "http://localhost:8080/users/1happyturtle/follow/01JSZ1YBG8QK5W8FZ8MBC0H4KJ",
+		AccountID:       targetAccount.ID,
+		TargetAccountID: originAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now the notif should go through.
+	suite.True(notify())
+}
+
 func TestSurfaceNotifyTestSuite(t *testing.T) {
 	suite.Run(t, new(SurfaceNotifyTestSuite))
 }
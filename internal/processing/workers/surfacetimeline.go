@@ -21,6 +21,7 @@
 	"context"
 	"errors"
 
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	statusfilter "code.superseriousbusiness.org/gotosocial/internal/filter/status"
 	"code.superseriousbusiness.org/gotosocial/internal/filter/usermute"
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
@@ -71,11 +72,25 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
 	}
 
+	// Stream the status to any open public and hashtag streams.
+	if err := s.streamStatusToPublicStreams(ctx, status, s.Stream.Update); err != nil {
+		return gtserror.Newf("error streaming status %s to public streams: %w", status.ID, err)
+	}
+
 	// Notify each local account that's mentioned by this status.
 	if err := s.notifyMentions(ctx, status); err != nil {
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
 	}
 
+	// Notify local author of the quoted status, if this
+	// status is an approved quote. Pending quotes are
+	// notified when the quote interaction is requested.
+	if status.QuoteApproved() {
+		if err := s.notifyQuote(ctx, status); err != nil {
+			return gtserror.Newf("error notifying quote for status %s: %w", status.ID, err)
+		}
+	}
+
 	// Update any conversations containing this status, and send conversation notifications.
 	notifications, err := s.Conversations.UpdateConversationsForStatus(ctx, status)
 	if err != nil {
@@ -550,6 +565,148 @@ func (s *Surface) tagFollowersForStatus(
 	return visibleTagFollowerAccounts, errs.Combine()
 }
 
+// publicStreamTypes returns the public timeline
+// stream types and hashtag stream types that the
+// given status should be streamed to, if any.
+func publicStreamTypes(status *gtsmodel.Status) (public []string, hashtag []string) {
+	if status.BoostOfID != "" ||
+		status.Visibility != gtsmodel.VisibilityPublic {
+		// Only original public statuses
+		// are streamed to public streams.
+		return nil, nil
+	}
+
+	hasMedia := len(status.AttachmentIDs) > 0
+
+	public = append(public, stream.TimelinePublic)
+	if hasMedia {
+		public = append(public, stream.TimelinePublicMedia)
+	}
+
+	if status.IsLocal() {
+		public = append(public, stream.TimelineLocal)
+		if hasMedia {
+			public = append(public, stream.TimelineLocalMedia)
+		}
+	} else {
+		public = append(public, stream.TimelineRemote)
+		if hasMedia {
+			public = append(public, stream.TimelineRemoteMedia)
+		}
+	}
+
+	for _, tag := range status.Tags {
+		if !*tag.Useable || !*tag.Listable {
+			continue
+		}
+
+		// Hashtag streams are keyed
+		// by stream type + tag name.
+		hashtag = append(hashtag, stream.TimelineHashtag+":"+tag.Name)
+		if status.IsLocal() {
+			hashtag = append(hashtag, stream.TimelineHashtagLocal+":"+tag.Name)
+		}
+	}
+
+	return public, hashtag
+}
+
+// streamStatusToPublicStreams streams the given status, using the given
+// stream function, to each local account with open public timeline or
+// hashtag streams that the status belongs in. Visibility is checked per
+// subscribed account, and statuses by accounts that the subscriber has
+// blocked or muted (or that match the subscriber's filters) are skipped.
+func (s *Surface) streamStatusToPublicStreams(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	streamFn func(context.Context, *gtsmodel.Account, *apimodel.Status, string),
+) error {
+	publicTypes, hashtagTypes := publicStreamTypes(status)
+	if len(publicTypes) == 0 && len(hashtagTypes) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Get IDs of accounts with
+	// open streams of these types.
+	accountIDs := s.Stream.Subscribers(
+		append(publicTypes, hashtagTypes...)...,
+	)
+	if len(accountIDs) == 0 {
+		// Nobody
+		// listening.
+		return nil
+	}
+
+	accounts, err := s.State.DB.GetAccountsByIDs(ctx, accountIDs)
+	if err != nil {
+		return gtserror.Newf("db error getting stream subscriber accounts: %w", err)
+	}
+
+	var errs gtserror.MultiError
+	for _, account := range accounts {
+		// Check which of the stream
+		// types the status is visible on.
+		var streamTypes []string
+
+		if len(publicTypes) > 0 {
+			timelineable, err := s.VisFilter.StatusPublicTimelineable(ctx, account, status)
+			if err != nil {
+				errs.Appendf("error checking public timelineability for account %s: %w", account.ID, err)
+				continue
+			}
+			if timelineable {
+				streamTypes = append(streamTypes, publicTypes...)
+			}
+		}
+
+		if len(hashtagTypes) > 0 {
+			timelineable, err := s.VisFilter.StatusTagTimelineable(ctx, account, status)
+			if err != nil {
+				errs.Appendf("error checking tag timelineability for account %s: %w", account.ID, err)
+				continue
+			}
+			if timelineable {
+				streamTypes = append(streamTypes, hashtagTypes...)
+			}
+		}
+
+		if len(streamTypes) == 0 {
+			// Not visible
+			// to account.
+			continue
+		}
+
+		filters, mutes, err := s.getFiltersAndMutes(ctx, account.ID)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		// Convert status to frontend model, hiding
+		// statuses by muted accounts or filtered.
+		apiStatus, err := s.Converter.StatusToAPIStatus(ctx,
+			status,
+			account,
+			statusfilter.FilterContextPublic,
+			filters,
+			mutes,
+		)
+		if err != nil {
+			if !errors.Is(err, statusfilter.ErrHideStatus) {
+				errs.Appendf("error converting status for account %s: %w", account.ID, err)
+			}
+			continue
+		}
+
+		for _, streamType := range streamTypes {
+			streamFn(ctx, account, apiStatus, streamType)
+		}
+	}
+
+	return errs.Combine()
+}
+
 // deleteStatusFromTimelines completely removes the given status from all timelines.
 // It will also stream deletion of the status to all open streams.
 func (s *Surface) deleteStatusFromTimelines(ctx context.Context, statusID string) error {
@@ -620,6 +777,11 @@ func (s *Surface) timelineStatusUpdate(ctx context.Context, status *gtsmodel.Sta
 		return gtserror.Newf("error timelining status %s for tag followers: %w", status.ID, err)
 	}
 
+	// Push updated status to any open public and hashtag streams.
+	if err := s.streamStatusToPublicStreams(ctx, status, s.Stream.StatusUpdate); err != nil {
+		return gtserror.Newf("error streaming status %s to public streams: %w", status.ID, err)
+	}
+
 	return nil
 }
 
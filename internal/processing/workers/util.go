@@ -20,9 +20,11 @@
 import (
 	"context"
 	"errors"
+	"time"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/filter/interaction"
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
@@ -31,6 +33,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/processing/media"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
 	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
 	"code.superseriousbusiness.org/gotosocial/internal/util"
 )
 
@@ -42,6 +45,7 @@ type utils struct {
 	account   *account.Processor
 	surface   *Surface
 	converter *typeutils.Converter
+	intFilter *interaction.Filter
 }
 
 // wipeStatus encapsulates common logic used to
@@ -142,6 +146,11 @@ func (u *utils) wipeStatus(
 		errs.Appendf("error deleting status faves: %w", err)
 	}
 
+	// Delete all reactions to this status.
+	if err := u.state.DB.DeleteStatusReactionsForStatus(ctx, status.ID); err != nil {
+		errs.Appendf("error deleting status reactions: %w", err)
+	}
+
 	if id := status.PollID; id != "" {
 		// Delete this poll by ID from the database.
 		if err := u.state.DB.DeletePollByID(ctx, id); err != nil {
@@ -187,6 +196,11 @@ func (u *utils) wipeStatus(
 		errs.Appendf("error deleting status from conversations: %w", err)
 	}
 
+	// Remove this status from the search index.
+	if err := u.state.DB.DeindexStatus(ctx, status.ID); err != nil {
+		errs.Appendf("error deindexing status: %w", err)
+	}
+
 	// Finally delete the status itself.
 	if err := u.state.DB.DeleteStatusByID(ctx, status.ID); err != nil {
 		errs.Appendf("error deleting status: %w", err)
@@ -658,3 +672,115 @@ func (u *utils) requestAnnounce(
 
 	return nil
 }
+
+// requestQuote stores an interaction request
+// for the given quote, and notifies the interactee.
+func (u *utils) requestQuote(
+	ctx context.Context,
+	quote *gtsmodel.Status,
+) error {
+	// Only create interaction request if
+	// status quotes a local status.
+	if quote.QuoteOf == nil ||
+		!quote.QuoteOf.IsLocal() {
+		return nil
+	}
+
+	// Lock on the interaction URI.
+	reqURI := quote.QuoteRequestURI()
+	unlock := u.state.ProcessingLocks.Lock(reqURI)
+	defer unlock()
+
+	// Ensure no req with this URI exists already.
+	req, err := u.state.DB.GetInteractionRequestByInteractionURI(ctx, reqURI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error checking for existing interaction request: %w", err)
+	}
+
+	if req != nil {
+		// Interaction req already exists,
+		// no need to do anything else.
+		return nil
+	}
+
+	// Create + store interaction request.
+	req = typeutils.StatusQuoteToInteractionRequest(quote)
+	if err := u.state.DB.PutInteractionRequest(ctx, req); err != nil {
+		return gtserror.Newf("db error storing interaction request: %w", err)
+	}
+
+	// Notify *local* account of pending quote.
+	if err := u.surface.notifyPendingQuote(ctx, quote); err != nil {
+		return gtserror.Newf("error notifying pending quote: %w", err)
+	}
+
+	return nil
+}
+
+// preApproveQuote stores an already-accepted interaction
+// request for the given quote of a local status, and marks
+// the quote as approved, returning the stored request so
+// that the caller can send out the Accept.
+func (u *utils) preApproveQuote(
+	ctx context.Context,
+	quote *gtsmodel.Status,
+) (*gtsmodel.InteractionRequest, error) {
+	// Ensure quoted status and account are populated.
+	if err := u.state.DB.PopulateStatus(ctx, quote); err != nil {
+		return nil, gtserror.Newf("error populating status %s: %w", quote.ID, err)
+	}
+
+	if quote.QuoteOfAccount == nil {
+		return nil, gtserror.Newf("quoted account %s not found", quote.QuoteOfAccountID)
+	}
+
+	// Store an already-accepted interaction request.
+	approval := typeutils.StatusQuoteToInteractionRequest(quote)
+	approval.URI = uris.GenerateURIForAccept(quote.QuoteOfAccount.Username, approval.ID)
+	approval.AcceptedAt = time.Now()
+	if err := u.state.DB.PutInteractionRequest(ctx, approval); err != nil {
+		return nil, gtserror.Newf("db error putting pre-approved interaction request: %w", err)
+	}
+
+	// Mark the quote as now approved.
+	quote.QuotePendingApproval = util.Ptr(false)
+	quote.QuotePreApproved = false
+	quote.QuoteApprovedByURI = approval.URI
+	if err := u.state.DB.UpdateStatus(
+		ctx,
+		quote,
+		"quote_pending_approval",
+		"quote_approved_by_uri",
+	); err != nil {
+		return nil, gtserror.Newf("db error updating status: %w", err)
+	}
+
+	return approval, nil
+}
+
+// detachQuote clears the quote fields on the given
+// status, eg., after the quote has been rejected by
+// the author of the quoted status. The status itself
+// is otherwise left untouched.
+func (u *utils) detachQuote(ctx context.Context, status *gtsmodel.Status) error {
+	status.QuoteOfID = ""
+	status.QuoteOfURI = ""
+	status.QuoteOfAccountID = ""
+	status.QuoteOf = nil
+	status.QuoteOfAccount = nil
+	status.QuotePendingApproval = util.Ptr(false)
+	status.QuoteApprovedByURI = ""
+	if err := u.state.DB.UpdateStatus(
+		ctx,
+		status,
+		"quote_of_id",
+		"quote_of_uri",
+		"quote_of_account_id",
+		"quote_pending_approval",
+		"quote_approved_by_uri",
+	); err != nil {
+		return gtserror.Newf("db error updating status: %w", err)
+	}
+
+	return nil
+}
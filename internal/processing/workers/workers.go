@@ -20,6 +20,7 @@
 import (
 	"code.superseriousbusiness.org/gotosocial/internal/email"
 	"code.superseriousbusiness.org/gotosocial/internal/federation"
+	"code.superseriousbusiness.org/gotosocial/internal/filter/interaction"
 	"code.superseriousbusiness.org/gotosocial/internal/filter/visibility"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/account"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/common"
@@ -78,6 +79,7 @@ func New(
 		account:   account,
 		surface:   surface,
 		converter: converter,
+		intFilter: interaction.NewFilter(state),
 	}
 
 	return Processor{
@@ -22,6 +22,7 @@
 	"net/http"
 	"time"
 
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
 	"github.com/gin-gonic/gin"
 )
 
@@ -33,7 +34,8 @@ type timeoutHandler struct {
 
 // ServeHTTP wraps the embedded Gin engine's ServeHTTP
 // function with an injected context which times out
-// non-upgraded inbound requests after 10 minutes.
+// non-upgraded, non-event-stream inbound requests
+// after 10 minutes.
 func (th timeoutHandler) ServeHTTP(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -45,6 +47,13 @@ func (th timeoutHandler) ServeHTTP(
 		return
 	}
 
+	if apiutil.EventStreamRequest(r) {
+		// Server-sent events streams
+		// are long-lived by design.
+		th.Engine.ServeHTTP(w, r)
+		return
+	}
+
 	// Create timeout ctx.
 	toCtx, cancelCtx := context.WithTimeout(
 		r.Context(),
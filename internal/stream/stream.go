@@ -21,8 +21,12 @@
 	"context"
 	"maps"
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/id"
 )
 
 const (
@@ -50,6 +54,18 @@
 	// EventTypeConversation -- a user
 	// should be shown an updated conversation.
 	EventTypeConversation = "conversation"
+
+	// EventTypeAnnouncement -- an announcement
+	// has been published or updated by an admin.
+	EventTypeAnnouncement = "announcement"
+
+	// EventTypeAnnouncementReaction -- reactions
+	// to an announcement have been changed.
+	EventTypeAnnouncementReaction = "announcement.reaction"
+
+	// EventTypeAnnouncementDelete -- an announcement
+	// has been deleted or unpublished by an admin.
+	EventTypeAnnouncementDelete = "announcement.delete"
 )
 
 const (
@@ -58,11 +74,40 @@
 	// server. Analogous to the local timeline.
 	TimelineLocal = "public:local"
 
+	// TimelineLocalMedia:
+	// All public posts originating from
+	// this server, with media attached.
+	TimelineLocalMedia = "public:local:media"
+
 	// TimelinePublic:
 	// All public posts known to the server.
 	// Analogous to the federated timeline.
 	TimelinePublic = "public"
 
+	// TimelinePublicMedia:
+	// All public posts known to the
+	// server, with media attached.
+	TimelinePublicMedia = "public:media"
+
+	// TimelineRemote:
+	// All public posts originating
+	// from other servers.
+	TimelineRemote = "public:remote"
+
+	// TimelineRemoteMedia:
+	// All public posts originating from
+	// other servers, with media attached.
+	TimelineRemoteMedia = "public:remote:media"
+
+	// TimelineHashtag:
+	// All public posts using a specific hashtag.
+	TimelineHashtag = "hashtag"
+
+	// TimelineHashtagLocal:
+	// All public posts originating from this
+	// server that use a specific hashtag.
+	TimelineHashtagLocal = "hashtag:local"
+
 	// TimelineHome:
 	// Events related to the current user, such
 	// as home feed updates and notifications.
@@ -86,19 +131,68 @@
 // to, useful for sending out status deletes.
 var AllStatusTimelines = []string{
 	TimelineLocal,
+	TimelineLocalMedia,
 	TimelinePublic,
+	TimelinePublicMedia,
+	TimelineRemote,
+	TimelineRemoteMedia,
 	TimelineHome,
 	TimelineDirect,
 	TimelineList,
+	TimelineHashtag,
+	TimelineHashtagLocal,
 }
 
+// isParameterized returns whether the given stream type
+// is only subscribed to along with a parameter, ie., as
+// `list:<list_id>` or `hashtag:<tag_name>`.
+func isParameterized(streamType string) bool {
+	switch streamType {
+	case TimelineList,
+		TimelineHashtag,
+		TimelineHashtagLocal:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	// msgChSize is the size of
+	// each stream's message buffer.
+	msgChSize = 50 // TODO: make configurable
+
+	// replaySize is the maximum number of
+	// recent messages kept per account for
+	// replay to resuming streams. This must
+	// not exceed msgChSize, so that replayed
+	// messages can be queued without blocking.
+	replaySize = msgChSize
+
+	// replayMaxAge is the maximum age of
+	// messages kept per account for replay,
+	// and also the time that an account's
+	// replay buffer is kept around for after
+	// its last open stream has been closed.
+	replayMaxAge = 5 * time.Minute
+)
+
 type Streams struct {
 	streams map[string][]*Stream
+	replays map[string]*replayBuffer
 	mutex   sync.Mutex
 }
 
 // Open will open open a new Stream for given account ID and stream types, the given context will be passed to Stream.
 func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
+	return s.Resume(accountID, "", streamTypes...)
+}
+
+// Resume is like Open, but additionally queues any recently posted messages
+// matching the given stream types that came after the message with the given
+// ID, allowing clients to resume a stream without missing messages in between
+// (eg., using Last-Event-ID). If lastID is not known, nothing will be replayed.
+func (s *Streams) Resume(accountID string, lastID string, streamTypes ...string) *Stream {
 	if len(streamTypes) == 0 {
 		panic("no stream types given")
 	}
@@ -106,7 +200,7 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	// Prep new Stream.
 	str := new(Stream)
 	str.done = make(chan struct{})
-	str.msgCh = make(chan Message, 50) // TODO: make configurable
+	str.msgCh = make(chan Message, msgChSize)
 	for _, streamType := range streamTypes {
 		str.Subscribe(streamType)
 	}
@@ -122,6 +216,35 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 		s.streams = make(map[string][]*Stream)
 	}
 
+	if s.replays == nil {
+		// Replay-map needs allocating.
+		s.replays = make(map[string]*replayBuffer)
+	}
+
+	// Drop old replay buffers for
+	// accounts without open streams.
+	s.pruneReplays(time.Now())
+
+	// Get replay buffer for account,
+	// allocating if not yet present.
+	replay := s.replays[accountID]
+	if replay == nil {
+		replay = new(replayBuffer)
+		s.replays[accountID] = replay
+	}
+
+	if lastID != "" {
+		// Queue any messages missed since lastID.
+		// These are guaranteed to fit in msgCh,
+		// and as we hold the lock nothing else
+		// can be posted to the stream meanwhile.
+		for _, msg := range replay.since(lastID) {
+			if stype := str.getStreamType(msg.Stream...); stype != "" {
+				str.msgCh <- msg.only(stype)
+			}
+		}
+	}
+
 	// Add new stream for account.
 	strs := s.streams[accountID]
 	strs = append(strs, str)
@@ -137,6 +260,11 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 			return s == str // remove 'str' ptr
 		})
 		s.streams[accountID] = strs
+		if len(strs) == 0 {
+			// Mark when account's last stream
+			// was closed, for replay pruning.
+			replay.closed = time.Now()
+		}
 		s.mutex.Unlock()
 	}
 
@@ -146,6 +274,19 @@ func (s *Streams) Open(accountID string, streamTypes ...string) *Stream {
 	return str
 }
 
+// pruneReplays drops replay buffers of accounts without open
+// streams, whose last stream was closed over replayMaxAge ago.
+// Must be called with the lock held.
+func (s *Streams) pruneReplays(now time.Time) {
+	for accountID, replay := range s.replays {
+		if len(s.streams[accountID]) == 0 &&
+			now.Sub(replay.closed) > replayMaxAge {
+			delete(s.replays, accountID)
+			delete(s.streams, accountID)
+		}
+	}
+}
+
 // Post will post the given message to all streams of given account ID matching type.
 func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool {
 	var deferred []func() bool
@@ -153,6 +294,15 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 	// Acquire lock.
 	s.mutex.Lock()
 
+	// Give message an ID
+	// for stream resumption.
+	msg.ID = id.NewULID()
+
+	if replay := s.replays[accountID]; replay != nil {
+		// Keep message for replay.
+		replay.add(msg)
+	}
+
 	// Iterate all streams stored for account.
 	for _, str := range s.streams[accountID] {
 
@@ -166,11 +316,7 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 
 			// Use a message copy to *only*
 			// include the supported stream.
-			msgCopy := Message{
-				Stream:  []string{stype},
-				Event:   msg.Event,
-				Payload: msg.Payload,
-			}
+			msgCopy := msg.only(stype)
 
 			// Send message to supported stream
 			// DEFERRED (i.e. OUTSIDE OF MAIN MUTEX).
@@ -196,6 +342,28 @@ func (s *Streams) Post(ctx context.Context, accountID string, msg Message) bool
 	return ok
 }
 
+// Subscribers returns the IDs of all accounts with
+// open streams matching any of the given stream types.
+func (s *Streams) Subscribers(streamTypes ...string) []string {
+	var accountIDs []string
+
+	// Acquire lock.
+	s.mutex.Lock()
+
+	for accountID, strs := range s.streams {
+		if slices.ContainsFunc(strs, func(str *Stream) bool {
+			return str.getStreamType(streamTypes...) != ""
+		}) {
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+
+	// Done with lock.
+	s.mutex.Unlock()
+
+	return accountIDs
+}
+
 // PostAll will post the given message to all streams with matching types.
 func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 	var deferred []func() bool
@@ -203,6 +371,16 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 	// Acquire lock.
 	s.mutex.Lock()
 
+	// Give message an ID
+	// for stream resumption.
+	msg.ID = id.NewULID()
+
+	// Keep message for
+	// replay to all accounts.
+	for _, replay := range s.replays {
+		replay.add(msg)
+	}
+
 	// Iterate ALL stored streams.
 	for _, strs := range s.streams {
 		for _, str := range strs {
@@ -217,11 +395,7 @@ func (s *Streams) PostAll(ctx context.Context, msg Message) bool {
 
 				// Use a message copy to *only*
 				// include the supported stream.
-				msgCopy := Message{
-					Stream:  []string{stype},
-					Event:   msg.Event,
-					Payload: msg.Payload,
-				}
+				msgCopy := msg.only(stype)
 
 				// Send message to supported stream
 				// DEFERRED (i.e. OUTSIDE OF MAIN MUTEX).
@@ -291,12 +465,27 @@ func (s *Stream) Unsubscribe(streamType string) {
 }
 
 // getStreamType returns the first stream type in given list that stream supports.
+//
+// A parameterized stream type without its parameter (eg., `hashtag`)
+// matches any subscription to that type (eg., `hashtag:example`),
+// which allows for posting deletes to all streams of that type.
 func (s *Stream) getStreamType(streamTypes ...string) string {
 	if ptr := s.types.Load(); ptr != nil {
 		for _, streamType := range streamTypes {
 			if _, ok := (*ptr)[streamType]; ok {
 				return streamType
 			}
+
+			if !isParameterized(streamType) {
+				continue
+			}
+
+			prefix := streamType + ":"
+			for subscribed := range *ptr {
+				if strings.HasPrefix(subscribed, prefix) {
+					return subscribed
+				}
+			}
 		}
 	}
 	return ""
@@ -375,6 +564,11 @@ func (s *Stream) cas(fn func(map[string]struct{}) bool) {
 // one streamed message.
 type Message struct {
 
+	// ID of the message, set on posting. Only
+	// used for resuming streams (not included
+	// in the JSON representation of a message).
+	ID string `json:"-"`
+
 	// All the stream types this
 	// message should be delivered to.
 	Stream []string `json:"stream"`
@@ -387,3 +581,64 @@ type Message struct {
 	// update or notification, this will be a JSON string.
 	Payload string `json:"payload"`
 }
+
+// only returns a copy of the message
+// addressed only to given stream type.
+func (m Message) only(streamType string) Message {
+	return Message{
+		ID:      m.ID,
+		Stream:  []string{streamType},
+		Event:   m.Event,
+		Payload: m.Payload,
+	}
+}
+
+// replayBuffer is a short ring buffer of
+// recently posted messages for an account.
+type replayBuffer struct {
+	msgs   []replayMsg
+	closed time.Time
+}
+
+type replayMsg struct {
+	msg    Message
+	posted time.Time
+}
+
+// add will add given message to the buffer,
+// dropping the oldest message if full.
+func (b *replayBuffer) add(msg Message) {
+	if len(b.msgs) == replaySize {
+		copy(b.msgs, b.msgs[1:])
+		b.msgs = b.msgs[:len(b.msgs)-1]
+	}
+	b.msgs = append(b.msgs, replayMsg{
+		msg:    msg,
+		posted: time.Now(),
+	})
+}
+
+// since returns buffered messages posted after
+// the message with given ID, up to replayMaxAge
+// old. Returns nothing if the ID is not found.
+func (b *replayBuffer) since(lastID string) []Message {
+	i := slices.IndexFunc(b.msgs, func(m replayMsg) bool {
+		return m.msg.ID == lastID
+	})
+	if i < 0 {
+		return nil
+	}
+
+	var (
+		cutoff = time.Now().Add(-replayMaxAge)
+		msgs   []Message
+	)
+
+	for _, m := range b.msgs[i+1:] {
+		if m.posted.After(cutoff) {
+			msgs = append(msgs, m.msg)
+		}
+	}
+
+	return msgs
+}
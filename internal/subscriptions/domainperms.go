@@ -396,6 +396,19 @@ func (s *Subscriptions) processDomainPermission(
 		return !existing, nil
 	}
 
+	// Severity and media policy only apply to
+	// blocks, defaulting to a plain suspension.
+	var (
+		severity       = gtsmodel.DomainBlockSeveritySuspend
+		rejectMedia    = util.Ptr(false)
+		sensitizeMedia = util.Ptr(false)
+	)
+	if wantedBlock, ok := wantedPerm.(*gtsmodel.DomainBlock); ok {
+		severity = cmp.Or(wantedBlock.Severity, severity)
+		rejectMedia = cmp.Or(wantedBlock.RejectMedia, rejectMedia)
+		sensitizeMedia = cmp.Or(wantedBlock.SensitizeMedia, sensitizeMedia)
+	}
+
 	// Handle perm creation differently depending
 	// on whether or not a perm already existed.
 	switch {
@@ -414,6 +427,9 @@ func (s *Subscriptions) processDomainPermission(
 				PublicComment:      wantedPerm.GetPublicComment(),
 				Obfuscate:          wantedPerm.GetObfuscate(),
 				SubscriptionID:     permSub.ID,
+				Severity:           severity,
+				RejectMedia:        rejectMedia,
+				SensitizeMedia:     sensitizeMedia,
 			},
 		)
 
@@ -437,6 +453,9 @@ func (s *Subscriptions) processDomainPermission(
 				PublicComment:      wantedPerm.GetPublicComment(),
 				Obfuscate:          wantedPerm.GetObfuscate(),
 				SubscriptionID:     permSub.ID,
+				Severity:           severity,
+				RejectMedia:        rejectMedia,
+				SensitizeMedia:     sensitizeMedia,
 			}
 			insertF = func() error { return s.state.DB.PutDomainBlock(ctx, domainBlock) }
 
@@ -447,6 +466,9 @@ func (s *Subscriptions) processDomainPermission(
 				Type:           gtsmodel.AdminActionSuspend,
 				AccountID:      permSub.CreatedByAccountID,
 			}
+			if domainBlock.IsLimit() {
+				action.Type = gtsmodel.AdminActionSilence
+			}
 			actionF = s.state.AdminActions.DomainBlockF(action.ID, domainBlock)
 
 		} else {
@@ -505,6 +527,9 @@ func (s *Subscriptions) processDomainPermission(
 			wantedPerm.GetObfuscate(),
 			permSub.URI,
 			wantedPerm.GetPublicComment(),
+			severity,
+			rejectMedia,
+			sensitizeMedia,
 		)
 
 	case existingPerm.GetSubscriptionID() != permSub.ID:
@@ -522,6 +547,9 @@ func (s *Subscriptions) processDomainPermission(
 			wantedPerm.GetObfuscate(),
 			permSub.URI,
 			wantedPerm.GetPublicComment(),
+			severity,
+			rejectMedia,
+			sensitizeMedia,
 		)
 
 	default:
@@ -529,8 +557,23 @@ func (s *Subscriptions) processDomainPermission(
 		//
 		// TODO: update public/private comment
 		// from latest version if it's changed.
-		l.Debug("permission already exists and is managed by this subscription, skipping")
-		return false, nil
+		domainBlock, ok := existingPerm.(*gtsmodel.DomainBlock)
+		if !ok || !blockPolicyChanged(domainBlock, severity, rejectMedia, sensitizeMedia) {
+			l.Debug("permission already exists and is managed by this subscription, skipping")
+			return false, nil
+		}
+
+		// Severity or media policy has changed
+		// in the list since we last fetched it.
+		l.Debug("updating severity and media policy of permission")
+		return false, s.updateBlockPolicy(
+			ctx,
+			domainBlock,
+			permSub,
+			severity,
+			rejectMedia,
+			sensitizeMedia,
+		)
 	}
 
 	if err != nil && !errors.Is(err, db.ErrAlreadyExists) {
@@ -558,6 +601,7 @@ func permsFromCSV(
 	var (
 		domainI        *int
 		severityI      *int
+		rejectMediaI   *int
 		publicCommentI *int
 		obfuscateI     *int
 	)
@@ -586,6 +630,14 @@ func permsFromCSV(
 			}
 			severityI = &i
 
+		case columnHeader == "reject_media":
+			if rejectMediaI != nil {
+				body.Close()
+				err := gtserror.NewfAt(3, "duplicate reject_media column header in csv: %+v", columnHeaders)
+				return nil, err
+			}
+			rejectMediaI = &i
+
 		case columnHeader == "public_comment" || columnHeader == "comment":
 			if publicCommentI != nil {
 				body.Close()
@@ -639,15 +691,26 @@ func permsFromCSV(
 			continue
 		}
 
-		// Skip records that specify severity
-		// that's not "suspend" (we don't support
-		// "silence" or "limit" or whatever yet).
+		// Parse severity if set, treating
+		// missing severity as "suspend".
+		severity := gtsmodel.DomainBlockSeveritySuspend
 		if severityI != nil {
-			severity := record[*severityI]
-			if severity != "suspend" {
-				l.Warnf("skipping non-suspend record: %+v", record)
-				continue
-			}
+			severity = gtsmodel.ParseDomainBlockSeverity(record[*severityI])
+		}
+
+		switch {
+		case severity == gtsmodel.DomainBlockSeverityUnknown:
+			// Eg., "noop" records,
+			// nothing to do for these.
+			l.Warnf("skipping unsupported severity record: %+v", record)
+			continue
+
+		case permType == gtsmodel.DomainPermissionAllow &&
+			severity != gtsmodel.DomainBlockSeveritySuspend:
+			// Only full entries
+			// make sense as allows.
+			l.Warnf("skipping non-suspend record: %+v", record)
+			continue
 		}
 
 		// Normalize + validate domain.
@@ -663,7 +726,23 @@ func permsFromCSV(
 		var perm gtsmodel.DomainPermission
 		switch permType {
 		case gtsmodel.DomainPermissionBlock:
-			perm = &gtsmodel.DomainBlock{Domain: domain}
+			block := &gtsmodel.DomainBlock{
+				Domain:   domain,
+				Severity: severity,
+			}
+
+			// Media rejection only
+			// makes sense for limits.
+			if rejectMediaI != nil && block.IsLimit() {
+				rejectMedia, err := strconv.ParseBool(record[*rejectMediaI])
+				if err != nil {
+					l.Warnf("couldn't parse reject_media field of record: %+v", record)
+					continue
+				}
+				block.RejectMedia = &rejectMedia
+			}
+
+			perm = block
 		case gtsmodel.DomainPermissionAllow:
 			perm = &gtsmodel.DomainAllow{Domain: domain}
 		}
@@ -736,7 +815,22 @@ func permsFromJSON(
 		var perm gtsmodel.DomainPermission
 		switch permType {
 		case gtsmodel.DomainPermissionBlock:
-			perm = &gtsmodel.DomainBlock{Domain: domain}
+			severity := gtsmodel.DomainBlockSeveritySuspend
+			if apiPerm.Severity != "" {
+				severity = gtsmodel.ParseDomainBlockSeverity(apiPerm.Severity)
+			}
+
+			if severity == gtsmodel.DomainBlockSeverityUnknown {
+				l.Warnf("skipping unsupported severity %s for domain %s", apiPerm.Severity, domain)
+				continue
+			}
+
+			perm = &gtsmodel.DomainBlock{
+				Domain:         domain,
+				Severity:       severity,
+				RejectMedia:    apiPerm.RejectMedia,
+				SensitizeMedia: apiPerm.SensitizeMedia,
+			}
 		case gtsmodel.DomainPermissionAllow:
 			perm = &gtsmodel.DomainAllow{Domain: domain}
 		}
@@ -796,6 +890,7 @@ func permsFromPlain(
 			perm = &gtsmodel.DomainBlock{
 				Domain:    domain,
 				Obfuscate: util.Ptr(false),
+				Severity:  gtsmodel.DomainBlockSeveritySuspend,
 			}
 		case gtsmodel.DomainPermissionAllow:
 			perm = &gtsmodel.DomainAllow{
@@ -869,6 +964,9 @@ func (s *Subscriptions) adoptPerm(
 	obfuscate *bool,
 	privateComment string,
 	publicComment string,
+	severity gtsmodel.DomainBlockSeverity,
+	rejectMedia *bool,
+	sensitizeMedia *bool,
 ) error {
 	// Set to our sub ID + this subs's
 	// account as we're managing it now.
@@ -885,13 +983,112 @@ func (s *Subscriptions) adoptPerm(
 	perm.SetObfuscate(cmp.Or(obfuscate, util.Ptr(false)))
 
 	// Update the perm in the db.
-	var err error
 	switch p := perm.(type) {
 	case *gtsmodel.DomainBlock:
-		err = s.state.DB.UpdateDomainBlock(ctx, p)
+		// Blocks also take on the list's severity
+		// and media policy, processing side effects
+		// if the severity differs from what it was.
+		severityChanged := p.Severity != severity
+		p.Severity = severity
+		p.RejectMedia = rejectMedia
+		p.SensitizeMedia = sensitizeMedia
+
+		if err := s.state.DB.UpdateDomainBlock(ctx, p); err != nil {
+			return err
+		}
+
+		if severityChanged {
+			s.blockSeverityChanged(ctx, permSub, p)
+		}
+
+		return nil
+
 	case *gtsmodel.DomainAllow:
-		err = s.state.DB.UpdateDomainAllow(ctx, p)
+		return s.state.DB.UpdateDomainAllow(ctx, p)
 	}
 
-	return err
+	return nil
+}
+
+// blockPolicyChanged returns whether the severity or media
+// policy of the given domain block differ from those given.
+func blockPolicyChanged(
+	domainBlock *gtsmodel.DomainBlock,
+	severity gtsmodel.DomainBlockSeverity,
+	rejectMedia *bool,
+	sensitizeMedia *bool,
+) bool {
+	return domainBlock.Severity != severity ||
+		util.PtrOrZero(domainBlock.RejectMedia) != util.PtrOrZero(rejectMedia) ||
+		util.PtrOrZero(domainBlock.SensitizeMedia) != util.PtrOrZero(sensitizeMedia)
+}
+
+// updateBlockPolicy updates the severity and media policy of
+// the given domain block, managed by the given subscription,
+// processing side effects if the severity has changed.
+func (s *Subscriptions) updateBlockPolicy(
+	ctx context.Context,
+	domainBlock *gtsmodel.DomainBlock,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	severity gtsmodel.DomainBlockSeverity,
+	rejectMedia *bool,
+	sensitizeMedia *bool,
+) error {
+	severityChanged := domainBlock.Severity != severity
+	domainBlock.Severity = severity
+	domainBlock.RejectMedia = rejectMedia
+	domainBlock.SensitizeMedia = sensitizeMedia
+
+	if err := s.state.DB.UpdateDomainBlock(ctx, domainBlock,
+		"severity",
+		"reject_media",
+		"sensitize_media",
+	); err != nil {
+		return err
+	}
+
+	if severityChanged {
+		s.blockSeverityChanged(ctx, permSub, domainBlock)
+	}
+
+	return nil
+}
+
+// blockSeverityChanged runs an admin action to process
+// side effects of the given domain block's severity
+// having been changed by the given subscription.
+//
+// Errors are logged rather than returned, as
+// they're not db errors to abort processing on.
+func (s *Subscriptions) blockSeverityChanged(
+	ctx context.Context,
+	permSub *gtsmodel.DomainPermissionSubscription,
+	domainBlock *gtsmodel.DomainBlock,
+) {
+	action := &gtsmodel.AdminAction{
+		ID:             id.NewULID(),
+		TargetCategory: gtsmodel.AdminActionCategoryDomain,
+		TargetID:       domainBlock.Domain,
+		AccountID:      permSub.CreatedByAccountID,
+		Text:           domainBlock.PrivateComment,
+	}
+
+	var actionF admin.ActionF
+	if domainBlock.IsLimit() {
+		// Downgraded from suspend to limit,
+		// process this as though the domain
+		// was unblocked, so that accounts
+		// suspended by the block are restored.
+		action.Type = gtsmodel.AdminActionUnsuspend
+		actionF = s.state.AdminActions.DomainUnblockF(action.ID, domainBlock)
+	} else {
+		// Upgraded from limit to suspend,
+		// process side effects of the block.
+		action.Type = gtsmodel.AdminActionSuspend
+		actionF = s.state.AdminActions.DomainBlockF(action.ID, domainBlock)
+	}
+
+	if errWithCode := s.state.AdminActions.Run(ctx, action, actionF); errWithCode != nil {
+		log.Errorf(ctx, "error processing severity change of domain block %s: %v", domainBlock.ID, errWithCode)
+	}
 }
@@ -129,6 +129,83 @@ func (suite *SubscriptionsTestSuite) TestDomainBlocksCSV() {
 	suite.WithinDuration(time.Now(), permSub.SuccessfullyFetchedAt, 1*time.Minute)
 }
 
+func (suite *SubscriptionsTestSuite) TestDomainBlocksCSVSeverities() {
+	var (
+		ctx           = context.Background()
+		testStructs   = testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+		testAccount   = suite.testAccounts["admin_account"]
+		subscriptions = subscriptions.New(
+			testStructs.State,
+			testStructs.TransportController,
+			testStructs.TypeConverter,
+		)
+
+		// Create a subscription for a CSV list of
+		// baddies with a mix of different severities.
+		testSubscription = &gtsmodel.DomainPermissionSubscription{
+			ID:                 "01JGE681TQSBPAV59GZXPKE62H",
+			Priority:           255,
+			Title:              "whatever!",
+			PermissionType:     gtsmodel.DomainPermissionBlock,
+			AsDraft:            util.Ptr(false),
+			AdoptOrphans:       util.Ptr(true),
+			CreatedByAccountID: testAccount.ID,
+			CreatedByAccount:   testAccount,
+			URI:                "https://lists.example.org/baddies_severities.csv",
+			ContentType:        gtsmodel.DomainPermSubContentTypeCSV,
+		}
+	)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	// Store test subscription.
+	if err := testStructs.State.DB.PutDomainPermissionSubscription(
+		ctx, testSubscription,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process all subscriptions.
+	subscriptions.ProcessDomainPermissionSubscriptions(ctx, testSubscription.PermissionType)
+
+	// Wait for the suspend and limit blocks.
+	blocks := make(map[string]*gtsmodel.DomainBlock, 2)
+	for _, domain := range []string{
+		"bumfaces.net",
+		"peepee.poopoo",
+	} {
+		if !testrig.WaitFor(func() bool {
+			block, err := testStructs.State.DB.GetDomainBlock(ctx, domain)
+			blocks[domain] = block
+			return err == nil
+		}) {
+			suite.FailNowf("", "timed out waiting for domain %s", domain)
+		}
+	}
+
+	// "suspend" should be a suspension.
+	suite.Equal(gtsmodel.DomainBlockSeveritySuspend, blocks["bumfaces.net"].Severity)
+	suite.False(*blocks["bumfaces.net"].RejectMedia)
+
+	// "silence" should be a limit, with media rejected.
+	suite.Equal(gtsmodel.DomainBlockSeverityLimit, blocks["peepee.poopoo"].Severity)
+	suite.True(*blocks["peepee.poopoo"].RejectMedia)
+	suite.False(*blocks["peepee.poopoo"].SensitizeMedia)
+
+	// "noop" should have been skipped.
+	_, err := testStructs.State.DB.GetDomainBlock(ctx, "nothanks.com")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Limited domain shouldn't be blocked.
+	config.SetInstanceFederationMode(config.InstanceFederationModeBlocklist)
+	blocked, err := testStructs.State.DB.IsDomainBlocked(ctx, "peepee.poopoo")
+	suite.NoError(err)
+	suite.False(blocked)
+
+	limited, err := testStructs.State.DB.IsDomainLimited(ctx, "peepee.poopoo")
+	suite.NoError(err)
+	suite.True(limited)
+}
+
 func (suite *SubscriptionsTestSuite) TestDomainBlocksJSON() {
 	var (
 		ctx           = context.Background()
@@ -815,6 +892,117 @@ func (suite *SubscriptionsTestSuite) TestAdoption() {
 	suite.Equal(testSubscription.ID, existingBlock3.SubscriptionID)
 }
 
+func (suite *SubscriptionsTestSuite) TestSeverityChanges() {
+	var (
+		ctx           = context.Background()
+		testStructs   = testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+		testAccount   = suite.testAccounts["admin_account"]
+		subscriptions = subscriptions.New(
+			testStructs.State,
+			testStructs.TransportController,
+			testStructs.TypeConverter,
+		)
+
+		// A subscription for a CSV list of baddies
+		// with different severities, adopting orphans.
+		testSubscription = &gtsmodel.DomainPermissionSubscription{
+			ID:                 "01JGE681TQSBPAV59GZXPKE62H",
+			Priority:           255,
+			Title:              "whatever!",
+			PermissionType:     gtsmodel.DomainPermissionBlock,
+			AsDraft:            util.Ptr(false),
+			AdoptOrphans:       util.Ptr(true),
+			CreatedByAccountID: testAccount.ID,
+			CreatedByAccount:   testAccount,
+			URI:                "https://lists.example.org/baddies_severities.csv",
+			ContentType:        gtsmodel.DomainPermSubContentTypeCSV,
+		}
+
+		// Orphan limit which the list
+		// has as a full suspension.
+		existingBlock1 = &gtsmodel.DomainBlock{
+			ID:                 "01JHX2V5WN250TKB6FQ1M3QE1H",
+			Domain:             "bumfaces.net",
+			CreatedByAccount:   testAccount,
+			CreatedByAccountID: testAccount.ID,
+			Severity:           gtsmodel.DomainBlockSeverityLimit,
+		}
+
+		// Suspension managed by testSubscription
+		// which the list has since changed to a
+		// limit, with media rejected.
+		existingBlock2 = &gtsmodel.DomainBlock{
+			ID:                 "01JHX3EZAYG3KKC56C1YTKBRK7",
+			Domain:             "peepee.poopoo",
+			CreatedByAccount:   testAccount,
+			CreatedByAccountID: testAccount.ID,
+			SubscriptionID:     testSubscription.ID,
+			Severity:           gtsmodel.DomainBlockSeveritySuspend,
+		}
+	)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	// Store test subscription.
+	if err := testStructs.State.DB.PutDomainPermissionSubscription(
+		ctx, testSubscription,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Store the existing blocks.
+	for _, block := range []*gtsmodel.DomainBlock{
+		existingBlock1,
+		existingBlock2,
+	} {
+		if err := testStructs.State.DB.PutDomainBlock(
+			ctx, block,
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Process all subscriptions.
+	subscriptions.ProcessDomainPermissionSubscriptions(ctx, testSubscription.PermissionType)
+
+	var err error
+
+	// existingBlock1 should now be adopted
+	// by testSubscription, as a suspension.
+	if existingBlock1, err = testStructs.State.DB.GetDomainBlockByID(
+		ctx, existingBlock1.ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testSubscription.ID, existingBlock1.SubscriptionID)
+	suite.Equal(gtsmodel.DomainBlockSeveritySuspend, existingBlock1.Severity)
+
+	// existingBlock2 should now be a
+	// limit, with media rejected.
+	if existingBlock2, err = testStructs.State.DB.GetDomainBlockByID(
+		ctx, existingBlock2.ID,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.DomainBlockSeverityLimit, existingBlock2.Severity)
+	suite.True(*existingBlock2.RejectMedia)
+	suite.False(*existingBlock2.SensitizeMedia)
+
+	// Suspended domain should now be
+	// blocked, and limited one not.
+	config.SetInstanceFederationMode(config.InstanceFederationModeBlocklist)
+	blocked, err := testStructs.State.DB.IsDomainBlocked(ctx, "bumfaces.net")
+	suite.NoError(err)
+	suite.True(blocked)
+
+	blocked, err = testStructs.State.DB.IsDomainBlocked(ctx, "peepee.poopoo")
+	suite.NoError(err)
+	suite.False(blocked)
+
+	limited, err := testStructs.State.DB.IsDomainLimited(ctx, "peepee.poopoo")
+	suite.NoError(err)
+	suite.True(limited)
+}
+
 func (suite *SubscriptionsTestSuite) TestDomainAllowsAndBlocks() {
 	var (
 		ctx           = context.Background()
@@ -0,0 +1,129 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+)
+
+const (
+	// How often suggestions are recalculated.
+	refreshEvery = time.Hour
+
+	// Maximum number of suggestions
+	// to keep for each account.
+	maxSuggestions = 80
+)
+
+// Suggestions calculates which accounts to suggest to each
+// local account, based on the local social graph, and stores
+// them in the suggestions cache.
+//
+// Accounts are suggested when they're followed by accounts
+// that the local account follows (friends of friends), and
+// are ranked by how many of those accounts follow them.
+type Suggestions struct {
+	state *state.State
+}
+
+func New(state *state.State) *Suggestions {
+	return &Suggestions{state: state}
+}
+
+// ScheduleJobs schedules suggestions to
+// be recalculated regularly, starting now.
+func (s *Suggestions) ScheduleJobs() error {
+	fn := func(ctx context.Context, start time.Time) {
+		log.Debug(ctx, "starting suggestions refresh")
+		if err := s.Refresh(ctx); err != nil {
+			log.Errorf(ctx, "error refreshing suggestions: %v", err)
+			return
+		}
+		log.Debugf(ctx, "finished suggestions refresh after %s", time.Since(start))
+	}
+
+	log.Infof(nil, "scheduling suggestions refresh to run every %s", refreshEvery)
+
+	if !s.state.Workers.Scheduler.AddRecurring(
+		"@suggestionsrefresh",
+		time.Now(),
+		refreshEvery,
+		fn,
+	) {
+		panic("failed to schedule @suggestionsrefresh")
+	}
+
+	return nil
+}
+
+// Refresh recalculates friends-of-friends
+// suggestions for all local accounts,
+// storing them in the suggestions cache.
+func (s *Suggestions) Refresh(ctx context.Context) error {
+	fofs, err := s.state.DB.GetFriendsOfFriends(ctx)
+	if err != nil {
+		return gtserror.Newf("db error getting friends of friends: %w", err)
+	}
+
+	suggestions := make(map[string][]*cache.Suggestion)
+	for _, fof := range fofs {
+		suggestions[fof.AccountID] = append(
+			suggestions[fof.AccountID],
+			&cache.Suggestion{
+				TargetAccountID: fof.TargetAccountID,
+				Mutuals:         fof.Mutuals,
+			},
+		)
+	}
+
+	for accountID, accountSuggestions := range suggestions {
+		suggestions[accountID] = topSuggestions(accountSuggestions)
+	}
+
+	s.state.Caches.Suggestions.Set(suggestions)
+	return nil
+}
+
+// topSuggestions sorts given suggestions by number of
+// mutuals, highest first, and truncates them to
+// maxSuggestions in length.
+func topSuggestions(suggestions []*cache.Suggestion) []*cache.Suggestion {
+	slices.SortFunc(suggestions, func(a, b *cache.Suggestion) int {
+		if c := cmp.Compare(b.Mutuals, a.Mutuals); c != 0 {
+			return c
+		}
+
+		// Break ties
+		// predictably.
+		return cmp.Compare(a.TargetAccountID, b.TargetAccountID)
+	})
+
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+
+	return suggestions
+}
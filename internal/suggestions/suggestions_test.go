@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions_test
+
+import (
+	"context"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/suggestions"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuggestionsTestSuite struct {
+	suite.Suite
+
+	db          db.DB
+	state       state.State
+	suggestions *suggestions.Suggestions
+
+	testAccounts map[string]*gtsmodel.Account
+}
+
+func (suite *SuggestionsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.suggestions = suggestions.New(&suite.state)
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+func (suite *SuggestionsTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StopWorkers(&suite.state)
+}
+
+func (suite *SuggestionsTestSuite) TestRefresh() {
+	ctx := context.Background()
+	adminAccount := suite.testAccounts["admin_account"]
+
+	if err := suite.suggestions.Refresh(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// local_account_2 follows local_account_1,
+	// who follows admin_account, so admin_account
+	// should be suggested to local_account_2.
+	suggested := suite.state.Caches.Suggestions.Get(suite.testAccounts["local_account_2"].ID)
+	if suite.Len(suggested, 1) {
+		suite.Equal(adminAccount.ID, suggested[0].TargetAccountID)
+		suite.Equal(1, suggested[0].Mutuals)
+	}
+
+	// admin_account follows local_account_1, who
+	// follows local_account_2, but local_account_2
+	// isn't discoverable so shouldn't be suggested.
+	suite.Empty(suite.state.Caches.Suggestions.Get(adminAccount.ID))
+
+	// local_account_1 already follows everyone.
+	suite.Empty(suite.state.Caches.Suggestions.Get(suite.testAccounts["local_account_1"].ID))
+}
+
+func (suite *SuggestionsTestSuite) TestRefreshDismissed() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_2"]
+
+	if err := suite.db.PutSuggestionDismissal(ctx, &gtsmodel.SuggestionDismissal{
+		ID:              id.NewULID(),
+		AccountID:       account.ID,
+		TargetAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.suggestions.Refresh(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(suite.state.Caches.Suggestions.Get(account.ID))
+}
+
+func (suite *SuggestionsTestSuite) TestRefreshBlocked() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_2"]
+	adminAccount := suite.testAccounts["admin_account"]
+
+	blockID := id.NewULID()
+	if err := suite.db.PutBlock(ctx, &gtsmodel.Block{
+		ID:              blockID,
+		URI:             adminAccount.URI + "/blocks/" + blockID,
+		AccountID:       adminAccount.ID,
+		TargetAccountID: account.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.suggestions.Refresh(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Empty(suite.state.Caches.Suggestions.Get(account.ID))
+}
+
+func TestSuggestionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SuggestionsTestSuite))
+}
@@ -0,0 +1,77 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// FindLinks returns the href of each http(s) link
+// in the given HTML, in the order they appear.
+// Links rendered for mentions and hashtags
+// (ie., anchors with class "mention") are skipped.
+func FindLinks(in string) []string {
+	var (
+		links []string
+		tkn   = html.NewTokenizer(strings.NewReader(in))
+	)
+
+	for {
+		switch tkn.Next() {
+		case html.ErrorToken:
+			// EOF or malformed
+			// input, we're done.
+			return links
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tkn.TagName()
+			if string(name) != "a" || !hasAttr {
+				continue
+			}
+
+			var href, class string
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = tkn.TagAttr()
+				switch string(key) {
+				case "href":
+					href = string(val)
+				case "class":
+					class = string(val)
+				}
+			}
+
+			if slices.Contains(strings.Fields(class), "mention") {
+				// Mention or
+				// hashtag link.
+				continue
+			}
+
+			lower := strings.ToLower(href)
+			if !strings.HasPrefix(lower, "https://") &&
+				!strings.HasPrefix(lower, "http://") {
+				continue
+			}
+
+			links = append(links, href)
+		}
+	}
+}
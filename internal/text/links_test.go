@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text_test
+
+import (
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"github.com/stretchr/testify/suite"
+)
+
+type LinksTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LinksTestSuite) TestNoLinks() {
+	suite.Empty(text.FindLinks(simpleExpected))
+}
+
+func (suite *LinksTestSuite) TestMentionsAndHashtags() {
+	suite.Empty(text.FindLinks(moreComplexExpected))
+}
+
+func (suite *LinksTestSuite) TestLinks() {
+	suite.Equal(
+		[]string{"https://example.org/s%C3%B6me_url"},
+		text.FindLinks(withUTF8LinkExpected),
+	)
+}
+
+func (suite *LinksTestSuite) TestNonHTTPLink() {
+	suite.Equal(
+		[]string{"http://example.org"},
+		text.FindLinks(`<p><a href="mailto:someone@example.org">mail</a> <a href="http://example.org">web</a></p>`),
+	)
+}
+
+func TestLinksTestSuite(t *testing.T) {
+	suite.Run(t, new(LinksTestSuite))
+}
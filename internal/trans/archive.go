@@ -0,0 +1,351 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+const (
+	// Names of / prefixes for members of an archive.
+	archiveManifest   = "manifest.json"
+	archiveTrailer    = "trailer.json"
+	archiveDBPrefix   = "db/"
+	archiveKeysPrefix = "keys/"
+	archiveBlobPrefix = "storage/"
+
+	// PAX header record containing
+	// sha256 checksum of a member.
+	archiveChecksumKey = "GOTOSOCIAL.sha256"
+
+	// Max number of entries (or
+	// keys) written per db member.
+	archiveChunkSize = 1000
+)
+
+// archiveTable is a database table
+// included in a full archive export.
+type archiveTable struct {
+	name  string
+	model interface{}
+}
+
+// noExport returns true for tables that
+// are no longer exported, but may still
+// be present in older archives.
+func (t archiveTable) noExport() bool {
+	_, ok := archiveNoExportTables[t.name]
+	return ok
+}
+
+// archiveNoExportTables contains names of tables
+// in archiveTables that are skipped on export.
+var archiveNoExportTables = map[string]struct{}{
+	"router_sessions": {},
+}
+
+// archiveTables contains every table included in a full archive
+// export, in the order they're written. Migration bookkeeping and
+// search indices are excluded, as these are recreated on import.
+//
+// Archives must never contain usable credentials, so router sessions
+// (the keys used to sign session cookies) are not exported; new keys
+// will be generated after import. OAuth tokens are only ever stored
+// as hashes, and any password reset tokens and identity provider
+// refresh tokens are scrubbed on export.
+var archiveTables = []archiveTable{
+	{"instances", &gtsmodel.Instance{}},
+	{"accounts", &gtsmodel.Account{}},
+	{"account_settings", &gtsmodel.AccountSettings{}},
+	{"account_archives", &gtsmodel.AccountArchive{}},
+	{"account_stats", &gtsmodel.AccountStats{}},
+	{"account_notes", &gtsmodel.AccountNote{}},
+	{"account_to_emojis", &gtsmodel.AccountToEmoji{}},
+	{"users", &gtsmodel.User{}},
+	{"denied_users", &gtsmodel.DeniedUser{}},
+	{"applications", &gtsmodel.Application{}},
+	{"tokens", &gtsmodel.Token{}},
+	{"router_sessions", &gtsmodel.RouterSession{}},
+	{"vapid_key_pairs", &gtsmodel.VAPIDKeyPair{}},
+	{"web_push_subscriptions", &gtsmodel.WebPushSubscription{}},
+	{"web_authn_credentials", &gtsmodel.WebAuthnCredential{}},
+	{"external_identities", &gtsmodel.ExternalIdentity{}},
+	{"invites", &gtsmodel.Invite{}},
+	{"domain_blocks", &gtsmodel.DomainBlock{}},
+	{"domain_allows", &gtsmodel.DomainAllow{}},
+	{"domain_permission_drafts", &gtsmodel.DomainPermissionDraft{}},
+	{"domain_permission_excludes", &gtsmodel.DomainPermissionExclude{}},
+	{"domain_permission_subscriptions", &gtsmodel.DomainPermissionSubscription{}},
+	{"email_domain_blocks", &gtsmodel.EmailDomainBlock{}},
+	{"header_filter_allows", &gtsmodel.HeaderFilterAllow{}},
+	{"header_filter_blocks", &gtsmodel.HeaderFilterBlock{}},
+	{"admin_actions", &gtsmodel.AdminAction{}},
+	{"relays", &gtsmodel.Relay{}},
+	{"rules", &gtsmodel.Rule{}},
+	{"reports", &gtsmodel.Report{}},
+	{"announcements", &gtsmodel.Announcement{}},
+	{"announcement_reads", &gtsmodel.AnnouncementRead{}},
+	{"announcement_reactions", &gtsmodel.AnnouncementReaction{}},
+	{"emoji_categories", &gtsmodel.EmojiCategory{}},
+	{"emojis", &gtsmodel.Emoji{}},
+	{"follows", &gtsmodel.Follow{}},
+	{"follow_requests", &gtsmodel.FollowRequest{}},
+	{"blocks", &gtsmodel.Block{}},
+	{"user_mutes", &gtsmodel.UserMute{}},
+	{"moves", &gtsmodel.Move{}},
+	{"outbox_imports", &gtsmodel.OutboxImport{}},
+	{"tags", &gtsmodel.Tag{}},
+	{"followed_tags", &gtsmodel.FollowedTag{}},
+	{"statuses", &gtsmodel.Status{}},
+	{"status_to_emojis", &gtsmodel.StatusToEmoji{}},
+	{"status_to_tags", &gtsmodel.StatusToTag{}},
+	{"status_edits", &gtsmodel.StatusEdit{}},
+	{"status_faves", &gtsmodel.StatusFave{}},
+	{"status_reactions", &gtsmodel.StatusReaction{}},
+	{"status_bookmarks", &gtsmodel.StatusBookmark{}},
+	{"sin_bin_statuses", &gtsmodel.SinBinStatus{}},
+	{"scheduled_statuses", &gtsmodel.ScheduledStatus{}},
+	{"interaction_requests", &gtsmodel.InteractionRequest{}},
+	{"media_attachments", &gtsmodel.MediaAttachment{}},
+	{"mentions", &gtsmodel.Mention{}},
+	{"polls", &gtsmodel.Poll{}},
+	{"poll_votes", &gtsmodel.PollVote{}},
+	{"preview_cards", &gtsmodel.PreviewCard{}},
+	{"threads", &gtsmodel.Thread{}},
+	{"thread_to_statuses", &gtsmodel.ThreadToStatus{}},
+	{"thread_mutes", &gtsmodel.ThreadMute{}},
+	{"conversations", &gtsmodel.Conversation{}},
+	{"conversation_to_statuses", &gtsmodel.ConversationToStatus{}},
+	{"lists", &gtsmodel.List{}},
+	{"list_entries", &gtsmodel.ListEntry{}},
+	{"pending_list_entries", &gtsmodel.PendingListEntry{}},
+	{"filters", &gtsmodel.Filter{}},
+	{"filter_keywords", &gtsmodel.FilterKeyword{}},
+	{"filter_statuses", &gtsmodel.FilterStatus{}},
+	{"markers", &gtsmodel.Marker{}},
+	{"notifications", &gtsmodel.Notification{}},
+	{"tombstones", &gtsmodel.Tombstone{}},
+	{"trend_reviews", &gtsmodel.TrendReview{}},
+	{"staff_picks", &gtsmodel.StaffPick{}},
+	{"suggestion_dismissals", &gtsmodel.SuggestionDismissal{}},
+	{"worker_tasks", &gtsmodel.WorkerTask{}},
+}
+
+// archiveTableModel returns a newly allocated model
+// for the archive table with the given name, if any.
+func archiveTableModel(name string) (interface{}, bool) {
+	for _, t := range archiveTables {
+		if t.name == name {
+			typ := reflect.TypeOf(t.model).Elem()
+			return reflect.New(typ).Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// archiveBlobKeys returns the storage keys of
+// any cached files that given entry refers to.
+func archiveBlobKeys(entry interface{}) []string {
+	switch e := entry.(type) {
+	case *gtsmodel.MediaAttachment:
+		if !util.PtrOrZero(e.Cached) {
+			return nil
+		}
+		keys := []string{e.File.Path}
+		if e.Thumbnail.Path != "" {
+			keys = append(keys, e.Thumbnail.Path)
+		}
+		return keys
+
+	case *gtsmodel.Emoji:
+		if !util.PtrOrZero(e.Cached) {
+			return nil
+		}
+		return []string{e.ImagePath, e.ImageStaticPath}
+
+	case *gtsmodel.PreviewCard:
+		if !util.PtrOrZero(e.Cached) || e.ImagePath == "" {
+			return nil
+		}
+		return []string{e.ImagePath}
+
+	case *gtsmodel.AccountArchive:
+		if e.Path == "" {
+			return nil
+		}
+		return []string{e.Path}
+
+	default:
+		return nil
+	}
+}
+
+// archiveScrub clears any secrets from given
+// entry that must not be written to an archive.
+func archiveScrub(entry interface{}) {
+	switch e := entry.(type) {
+	case *gtsmodel.User:
+		e.ResetPasswordToken = ""
+	case *gtsmodel.ExternalIdentity:
+		e.SealedRefreshToken = ""
+	case *gtsmodel.WebPushSubscription:
+		e.SealedAccessToken = ""
+	}
+}
+
+// archiveWriter wraps a tar.Writer to write
+// archive members alongside their checksums.
+type archiveWriter struct {
+	tw *tar.Writer
+	gz *gzip.Writer
+}
+
+func newArchiveWriter(w io.Writer, compress bool) *archiveWriter {
+	var aw archiveWriter
+	if compress {
+		aw.gz = gzip.NewWriter(w)
+		w = aw.gz
+	}
+	aw.tw = tar.NewWriter(w)
+	return &aw
+}
+
+// writeMember writes the given data to the
+// archive as a member with the given name.
+func (aw *archiveWriter) writeMember(name string, data []byte) error {
+	sum := sha256.Sum256(data)
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     int64(len(data)),
+		Mode:     0o600,
+		ModTime:  time.Now(),
+		Format:   tar.FormatPAX,
+		PAXRecords: map[string]string{
+			archiveChecksumKey: hex.EncodeToString(sum[:]),
+		},
+	}
+
+	if err := aw.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("error writing header for %s: %w", name, err)
+	}
+
+	if _, err := aw.tw.Write(data); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// close flushes and closes the archive,
+// but not the underlying io.Writer.
+func (aw *archiveWriter) close() error {
+	if err := aw.tw.Close(); err != nil {
+		return err
+	}
+	if aw.gz != nil {
+		return aw.gz.Close()
+	}
+	return nil
+}
+
+// archiveReader wraps a tar.Reader to read
+// archive members, verifying their checksums.
+type archiveReader struct {
+	tr *tar.Reader
+}
+
+// readMember reads the next member from the archive, returning
+// an error if its contents don't match its recorded checksum.
+// At the end of the archive, io.EOF is returned.
+func (ar *archiveReader) readMember() (string, []byte, error) {
+	hdr, err := ar.tr.Next()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if hdr.Typeflag != tar.TypeReg {
+		return "", nil, fmt.Errorf("unexpected member type for %s", hdr.Name)
+	}
+
+	checksum, ok := hdr.PAXRecords[archiveChecksumKey]
+	if !ok {
+		return "", nil, fmt.Errorf("no checksum recorded for %s", hdr.Name)
+	}
+
+	data, err := io.ReadAll(ar.tr)
+	if err != nil {
+		return "", nil, fmt.Errorf("error reading %s: %w", hdr.Name, err)
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != checksum {
+		return "", nil, fmt.Errorf("checksum mismatch for %s", hdr.Name)
+	}
+
+	return hdr.Name, data, nil
+}
+
+// openArchive checks whether the given reader contains a (possibly
+// gzipped) archive, rather than a newline-separated JSON export file.
+// If so, an archiveReader is returned. Otherwise the returned reader
+// should be used to read the file, as some of it may have been buffered.
+func openArchive(r io.Reader) (*archiveReader, io.Reader, error) {
+	br := bufio.NewReader(r)
+
+	// Check for gzip magic bytes.
+	magic, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		// Only archives are ever gzipped.
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error opening gzip reader: %w", err)
+		}
+		return &archiveReader{tr: tar.NewReader(gz)}, nil, nil
+	}
+
+	// Check for the tar "ustar"
+	// magic at offset 257 in header.
+	hdr, err := br.Peek(263)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, err
+	}
+
+	if len(hdr) == 263 && string(hdr[257:262]) == "ustar" {
+		return &archiveReader{tr: tar.NewReader(br)}, nil, nil
+	}
+
+	return nil, br, nil
+}
@@ -0,0 +1,251 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	"code.superseriousbusiness.org/gotosocial/internal/trans"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type ArchiveTestSuite struct {
+	TransTestSuite
+}
+
+// newTarget returns a new, empty
+// database and storage to import into.
+func (suite *ArchiveTestSuite) newTarget() (db.DB, *storage.Driver) {
+	var state state.State
+	return testrig.NewTestDB(&state), testrig.NewInMemoryStorage()
+}
+
+// export exports an archive from
+// the suite db to a temporary file.
+func (suite *ArchiveTestSuite) export(name string, since time.Time) string {
+	path := filepath.Join(suite.T().TempDir(), name)
+	exporter := trans.NewExporter(suite.db, suite.storage)
+	if err := exporter.Export(context.Background(), path, since); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return path
+}
+
+// encodeAll JSON encodes each entry of the given slice pointer,
+// so entries can be compared regardless of time locations.
+func (suite *ArchiveTestSuite) encodeAll(slice interface{}) []string {
+	v := reflect.ValueOf(slice).Elem()
+	encoded := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		b, err := json.Marshal(v.Index(i).Interface())
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		encoded = append(encoded, string(b))
+	}
+	return encoded
+}
+
+func (suite *ArchiveTestSuite) TestExportImport() {
+	ctx := context.Background()
+	path := suite.export("backup.tar.gz", time.Time{})
+
+	newDB, newStorage := suite.newTarget()
+	importer := trans.NewImporter(newDB, newStorage)
+	if err := importer.Import(ctx, path); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Every entry of these types should have made it across.
+	for _, models := range []struct{ before, after interface{} }{
+		{&[]*gtsmodel.Status{}, &[]*gtsmodel.Status{}},
+		{&[]*gtsmodel.MediaAttachment{}, &[]*gtsmodel.MediaAttachment{}},
+		{&[]*gtsmodel.Emoji{}, &[]*gtsmodel.Emoji{}},
+		{&[]*gtsmodel.List{}, &[]*gtsmodel.List{}},
+		{&[]*gtsmodel.Filter{}, &[]*gtsmodel.Filter{}},
+		{&[]*gtsmodel.StatusBookmark{}, &[]*gtsmodel.StatusBookmark{}},
+		{&[]*gtsmodel.Poll{}, &[]*gtsmodel.Poll{}},
+		{&[]*gtsmodel.Token{}, &[]*gtsmodel.Token{}},
+		{&[]*gtsmodel.AccountSettings{}, &[]*gtsmodel.AccountSettings{}},
+		{&[]*gtsmodel.StatusToTag{}, &[]*gtsmodel.StatusToTag{}},
+	} {
+		suite.NoError(suite.db.GetAll(ctx, models.before))
+		suite.NoError(newDB.GetAll(ctx, models.after))
+		before := suite.encodeAll(models.before)
+		after := suite.encodeAll(models.after)
+		suite.NotEmpty(before)
+		suite.ElementsMatch(before, after)
+	}
+
+	// Interaction policy should be intact.
+	statusBefore, err := suite.db.GetStatusByID(ctx, "01F8MHAMCHF6Y650WCRSCP4WMY")
+	suite.NoError(err)
+	statusAfter, err := newDB.GetStatusByID(ctx, statusBefore.ID)
+	suite.NoError(err)
+	suite.Equal(statusBefore.Content, statusAfter.Content)
+	suite.Equal(statusBefore.InteractionPolicy, statusAfter.InteractionPolicy)
+
+	// Keys should be intact.
+	accountBefore := suite.testAccounts["local_account_1"]
+	accountAfter, err := newDB.GetAccountByID(ctx, accountBefore.ID)
+	suite.NoError(err)
+	suite.True(accountBefore.PrivateKey.Equal(accountAfter.PrivateKey))
+
+	// Sealed Web Push access tokens should have been scrubbed.
+	subscriptions := []*gtsmodel.WebPushSubscription{}
+	suite.NoError(newDB.GetAll(ctx, &subscriptions))
+	suite.NotEmpty(subscriptions)
+	for _, subscription := range subscriptions {
+		suite.Empty(subscription.SealedAccessToken)
+	}
+
+	// Media files should have been restored.
+	attachment := testrig.NewTestAttachments()["admin_account_status_1_attachment_1"]
+	for _, key := range []string{attachment.File.Path, attachment.Thumbnail.Path} {
+		before, err := suite.storage.Get(ctx, key)
+		suite.NoError(err)
+		after, err := newStorage.Get(ctx, key)
+		suite.NoError(err)
+		suite.Equal(before, after)
+	}
+}
+
+func (suite *ArchiveTestSuite) TestExportImportIncremental() {
+	ctx := context.Background()
+
+	// Restore a full backup first.
+	newDB, newStorage := suite.newTarget()
+	importer := trans.NewImporter(newDB, newStorage)
+	if err := importer.Import(ctx, suite.export("full.tar", time.Time{})); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Update a status after the full backup.
+	since := time.Now()
+	status := &gtsmodel.Status{
+		ID:       "01F8MHAMCHF6Y650WCRSCP4WMY",
+		Content:  "updated since the full backup",
+		EditedAt: since.Add(time.Second),
+	}
+	if err := suite.db.UpdateByID(ctx, status, status.ID, "content", "edited_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Delete a status and its tag after the full backup.
+	deletedID := "01F8MH75CBF9JFX4ZAD54N0W0R"
+	if err := suite.db.DeleteByID(ctx, deletedID, &gtsmodel.Status{}); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := suite.db.DeleteWhere(ctx, []db.Where{{Key: "status_id", Value: deletedID}}, &gtsmodel.StatusToTag{}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Restoring an incremental backup on top
+	// should only bring the update and deletions.
+	if err := importer.Import(ctx, suite.export("incremental.tar", since)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusAfter, err := newDB.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+	suite.Equal(status.Content, statusAfter.Content)
+
+	_, err = newDB.GetStatusByID(ctx, deletedID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Entries without timestamps are always
+	// included, but shouldn't be duplicated,
+	// and deleted ones should be pruned.
+	before := []*gtsmodel.StatusToTag{}
+	suite.NoError(suite.db.GetAll(ctx, &before))
+	after := []*gtsmodel.StatusToTag{}
+	suite.NoError(newDB.GetAll(ctx, &after))
+	suite.Len(after, len(before))
+}
+
+func (suite *ArchiveTestSuite) TestVerifyCorrupt() {
+	ctx := context.Background()
+	path := suite.export("backup.tar", time.Time{})
+
+	importer := trans.NewImporter(suite.newTarget())
+	suite.NoError(importer.Verify(ctx, path))
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Flip a byte within the content of the first status.
+	i := bytes.Index(b, []byte("db/statuses/000001.jsonl"))
+	if i < 0 {
+		suite.FailNow("statuses not found in archive")
+	}
+	i += bytes.Index(b[i:], []byte(`"Content":"`))
+	b[i+len(`"Content":"`)] ^= 0x01
+	suite.NoError(os.WriteFile(path, b, 0o600))
+
+	err = importer.Verify(ctx, path)
+	suite.ErrorContains(err, "checksum mismatch for db/statuses/000001.jsonl")
+
+	// Nothing should be imported from a corrupt archive.
+	newDB, newStorage := suite.newTarget()
+	err = trans.NewImporter(newDB, newStorage).Import(ctx, path)
+	suite.ErrorContains(err, "checksum mismatch")
+
+	accounts := []*gtsmodel.Account{}
+	suite.NoError(newDB.GetAll(ctx, &accounts))
+	suite.Empty(accounts)
+}
+
+func (suite *ArchiveTestSuite) TestVerifyTruncated() {
+	ctx := context.Background()
+	path := suite.export("backup.tar", time.Time{})
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Drop the trailer and end of archive marker (2x512 byte blocks),
+	// leaving the rest of the archive readable, as though the export
+	// was interrupted partway through.
+	trailer := bytes.Index(b, []byte(`{"entries":`))
+	if trailer < 0 {
+		suite.FailNow("trailer not found in archive")
+	}
+	suite.NoError(os.WriteFile(path, b[:trailer-1024], 0o600))
+
+	importer := trans.NewImporter(suite.newTarget())
+	suite.Error(importer.Verify(ctx, path))
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, &ArchiveTestSuite{})
+}
@@ -214,6 +214,7 @@ func (e *exporter) exportUsers(ctx context.Context, file *os.File) ([]*transmode
 
 	for _, u := range users {
 		u.Type = transmodel.TransUser
+		u.ResetPasswordToken = ""
 		if err := e.simpleEncode(ctx, file, u, u.ID); err != nil {
 			return nil, fmt.Errorf("exportUsers: error encoding user: %s", err)
 		}
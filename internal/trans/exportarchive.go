@@ -0,0 +1,254 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	transmodel "code.superseriousbusiness.org/gotosocial/internal/trans/model"
+)
+
+func (e *exporter) Export(ctx context.Context, path string, since time.Time) error {
+	if path == "" {
+		return errors.New("Export: path empty")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("Export: couldn't export to %s: %s", path, err)
+	}
+
+	aw := newArchiveWriter(file, strings.HasSuffix(path, ".gz"))
+
+	manifest := transmodel.Manifest{
+		Version:       transmodel.ArchiveVersion,
+		CreatedAt:     time.Now(),
+		Host:          config.GetHost(),
+		AccountDomain: config.GetAccountDomain(),
+		Software:      config.GetSoftwareVersion(),
+	}
+	if !since.IsZero() {
+		manifest.Since = &since
+	}
+
+	if err := writeJSONMember(aw, archiveManifest, &manifest); err != nil {
+		return fmt.Errorf("Export: error writing manifest: %s", err)
+	}
+
+	trailer := transmodel.Trailer{
+		Entries: make(map[string]int, len(archiveTables)),
+	}
+
+	for _, table := range archiveTables {
+		if table.noExport() {
+			continue
+		}
+
+		entries, blobs, err := e.exportTable(ctx, aw, table, since)
+		if err != nil {
+			return fmt.Errorf("Export: error exporting %s: %s", table.name, err)
+		}
+
+		log.Infof(ctx, "exported %d %s entries and %d files", entries, table.name, blobs)
+		trailer.Entries[table.name] = entries
+		trailer.Blobs += blobs
+
+		if since.IsZero() {
+			continue
+		}
+
+		// Incremental archives list the key of every
+		// entry in the table, so that entries deleted
+		// since the last archive are pruned on import.
+		keys, err := e.exportTableKeys(ctx, aw, table)
+		if err != nil {
+			return fmt.Errorf("Export: error exporting %s keys: %s", table.name, err)
+		}
+
+		log.Infof(ctx, "exported %d %s keys", keys, table.name)
+		if trailer.Keys == nil {
+			trailer.Keys = make(map[string]int, len(archiveTables))
+		}
+		trailer.Keys[table.name] = keys
+	}
+
+	if err := writeJSONMember(aw, archiveTrailer, &trailer); err != nil {
+		return fmt.Errorf("Export: error writing trailer: %s", err)
+	}
+
+	if err := aw.close(); err != nil {
+		return fmt.Errorf("Export: error closing archive: %s", err)
+	}
+
+	return neatClose(file)
+}
+
+// exportTable writes all entries from the given table to the archive,
+// in members of up to archiveChunkSize entries, each followed by the
+// files in storage referred to by its entries. Returns the number of
+// entries and files written.
+func (e *exporter) exportTable(
+	ctx context.Context,
+	aw *archiveWriter,
+	table archiveTable,
+	since time.Time,
+) (entries int, blobs int, err error) {
+	var (
+		buf   bytes.Buffer
+		enc   = json.NewEncoder(&buf)
+		keys  []string
+		chunk int
+		count int
+	)
+
+	flush := func() error {
+		if count == 0 {
+			return nil
+		}
+
+		chunk++
+		name := fmt.Sprintf("%s%s/%06d.jsonl", archiveDBPrefix, table.name, chunk)
+		if err := aw.writeMember(name, buf.Bytes()); err != nil {
+			return err
+		}
+		buf.Reset()
+		count = 0
+
+		for _, key := range keys {
+			data, err := e.storage.Get(ctx, key)
+			if err != nil {
+				if storage.IsNotFound(err) {
+					log.Warnf(ctx, "file %s not found in storage, skipping it", key)
+					continue
+				}
+				return fmt.Errorf("error getting file %s from storage: %w", key, err)
+			}
+
+			if err := aw.writeMember(archiveBlobPrefix+key, data); err != nil {
+				return err
+			}
+			blobs++
+		}
+		keys = keys[:0]
+
+		return nil
+	}
+
+	err = e.db.StreamTable(ctx, table.model, since, func(entry interface{}) error {
+		archiveScrub(entry)
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("error encoding entry: %w", err)
+		}
+		entries++
+		count++
+
+		// Note any files in storage this entry
+		// refers to, so they follow its member.
+		keys = append(keys, archiveBlobKeys(entry)...)
+
+		if count >= archiveChunkSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := flush(); err != nil {
+		return 0, 0, err
+	}
+
+	return entries, blobs, nil
+}
+
+// exportTableKeys writes the key of every entry in the given
+// table to the archive, one JSON array of key values per line,
+// in members of up to archiveChunkSize keys. Returns the number
+// of keys written.
+func (e *exporter) exportTableKeys(
+	ctx context.Context,
+	aw *archiveWriter,
+	table archiveTable,
+) (int, error) {
+	var (
+		buf   bytes.Buffer
+		enc   = json.NewEncoder(&buf)
+		chunk int
+		count int
+		keys  int
+	)
+
+	flush := func() error {
+		if count == 0 {
+			return nil
+		}
+
+		chunk++
+		name := fmt.Sprintf("%s%s/%06d.jsonl", archiveKeysPrefix, table.name, chunk)
+		if err := aw.writeMember(name, buf.Bytes()); err != nil {
+			return err
+		}
+		buf.Reset()
+		count = 0
+
+		return nil
+	}
+
+	err := e.db.StreamTableKeys(ctx, table.model, func(key []interface{}) error {
+		if err := enc.Encode(key); err != nil {
+			return fmt.Errorf("error encoding key: %w", err)
+		}
+		keys++
+		count++
+
+		if count >= archiveChunkSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := flush(); err != nil {
+		return 0, err
+	}
+
+	return keys, nil
+}
+
+// writeJSONMember writes the JSON encoding
+// of v to the archive under the given name.
+func writeJSONMember(aw *archiveWriter, name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return aw.writeMember(name, data)
+}
@@ -19,24 +19,39 @@
 
 import (
 	"context"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
 )
 
 // Exporter wraps functionality for exporting entries from the database to a file.
 type Exporter interface {
+	// ExportMinimal exports only the bare minimum entries needed to
+	// restore an instance without breaking federation, as newline
+	// separated JSON objects written to the file at the given path.
 	ExportMinimal(ctx context.Context, path string) error
+
+	// Export exports every database entry, and every file in storage
+	// referred to by them, as an archive written to the file at the
+	// given path. If the path ends in ".gz" the archive is gzipped.
+	//
+	// If since is not zero, only entries created or updated at or
+	// after since are exported, for use as an incremental backup.
+	Export(ctx context.Context, path string, since time.Time) error
 }
 
 type exporter struct {
 	db         db.DB
+	storage    *storage.Driver
 	writtenIDs map[string]bool
 }
 
-// NewExporter returns a new Exporter that will use the given db.
-func NewExporter(db db.DB) Exporter {
+// NewExporter returns a new Exporter that will use the given db and storage.
+func NewExporter(db db.DB, storage *storage.Driver) Exporter {
 	return &exporter{
 		db:         db,
+		storage:    storage,
 		writtenIDs: make(map[string]bool),
 	}
 }
@@ -32,15 +32,34 @@
 
 func (i *importer) Import(ctx context.Context, path string) error {
 	if path == "" {
-		return errors.New("Export: path empty")
+		return errors.New("Import: path empty")
 	}
 
 	file, err := os.Open(path)
 	if err != nil {
-		return fmt.Errorf("Import: couldn't export to %s: %s", path, err)
+		return fmt.Errorf("Import: couldn't import from %s: %s", path, err)
 	}
 
-	decoder := json.NewDecoder(file)
+	ar, r, err := openArchive(file)
+	if err != nil {
+		return fmt.Errorf("Import: error opening %s: %s", path, err)
+	}
+
+	if ar != nil {
+		// This is a full archive, so verify it
+		// completely before importing anything.
+		if err := i.readArchive(ctx, ar, false); err != nil {
+			return fmt.Errorf("Import: error verifying archive: %s", err)
+		}
+
+		if err := neatClose(file); err != nil {
+			return fmt.Errorf("Import: %s", err)
+		}
+
+		return i.importArchive(ctx, path)
+	}
+
+	decoder := json.NewDecoder(r)
 	decoder.UseNumber()
 
 	for {
@@ -0,0 +1,341 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	transmodel "code.superseriousbusiness.org/gotosocial/internal/trans/model"
+)
+
+func (i *importer) Verify(ctx context.Context, path string) error {
+	if path == "" {
+		return errors.New("Verify: path empty")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("Verify: couldn't open %s: %s", path, err)
+	}
+
+	ar, _, err := openArchive(file)
+	if err != nil {
+		return fmt.Errorf("Verify: error opening %s: %s", path, err)
+	}
+
+	if ar == nil {
+		return fmt.Errorf("Verify: %s is not an archive", path)
+	}
+
+	if err := i.readArchive(ctx, ar, false); err != nil {
+		return fmt.Errorf("Verify: %s", err)
+	}
+
+	log.Infof(ctx, "verified archive %s", path)
+	return neatClose(file)
+}
+
+// importArchive restores every entry and
+// file from the (verified) archive at path.
+func (i *importer) importArchive(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("importArchive: couldn't open %s: %s", path, err)
+	}
+
+	ar, _, err := openArchive(file)
+	if err != nil {
+		return fmt.Errorf("importArchive: error opening %s: %s", path, err)
+	}
+
+	if err := i.readArchive(ctx, ar, true); err != nil {
+		return fmt.Errorf("importArchive: %s", err)
+	}
+
+	return neatClose(file)
+}
+
+// readArchive reads every member of the given archive, checking
+// each against its checksum, and checking the archive is complete.
+// If restore is set, entries and files are restored as they're read.
+func (i *importer) readArchive(ctx context.Context, ar *archiveReader, restore bool) error {
+	name, data, err := ar.readMember()
+	if err != nil {
+		return fmt.Errorf("error reading manifest: %w", err)
+	}
+
+	if name != archiveManifest {
+		return fmt.Errorf("expected %s as first member, got %s", archiveManifest, name)
+	}
+
+	var manifest transmodel.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("error decoding manifest: %w", err)
+	}
+
+	if manifest.Version > transmodel.ArchiveVersion {
+		return fmt.Errorf("archive version %d is newer than supported version %d", manifest.Version, transmodel.ArchiveVersion)
+	}
+
+	if host := config.GetHost(); manifest.Host != host {
+		return fmt.Errorf("archive was exported from host %s, but this instance's host is %s", manifest.Host, host)
+	}
+
+	var (
+		entries = make(map[string]int)
+		keys    = make(map[string]int)
+		blobs   int
+
+		// Keys of entries to keep in each
+		// table, only collected on restore.
+		keep = make(map[string]map[string]struct{})
+	)
+
+	for {
+		name, data, err := ar.readMember()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("archive is truncated: no trailer found")
+			}
+			return err
+		}
+
+		switch {
+		case name == archiveTrailer:
+			trailer, err := checkTrailer(ar, data, entries, keys, blobs)
+			if err != nil {
+				return err
+			}
+
+			if !restore {
+				return nil
+			}
+			log.Infof(ctx, "restored %d files", blobs)
+
+			// Now the archive is known to be complete, prune
+			// entries of tables with keys listed that are no
+			// longer present, ie., were deleted since export.
+			for table := range trailer.Keys {
+				n, err := i.pruneEntries(ctx, table, keep[table])
+				if err != nil {
+					return fmt.Errorf("error pruning %s: %w", table, err)
+				}
+				log.Infof(ctx, "pruned %d deleted %s entries", n, table)
+			}
+			return nil
+
+		case strings.HasPrefix(name, archiveDBPrefix):
+			table := path.Dir(strings.TrimPrefix(name, archiveDBPrefix))
+			n, err := i.readEntries(ctx, table, data, restore)
+			if err != nil {
+				return fmt.Errorf("error reading %s: %w", name, err)
+			}
+
+			if restore {
+				log.Infof(ctx, "restored %d %s entries", n, table)
+			}
+			entries[table] += n
+
+		case strings.HasPrefix(name, archiveKeysPrefix):
+			table := path.Dir(strings.TrimPrefix(name, archiveKeysPrefix))
+			if _, ok := archiveTableModel(table); !ok {
+				return fmt.Errorf("error reading %s: unknown table %s", name, table)
+			}
+
+			lines := bytes.Split(bytes.TrimSuffix(data, []byte{'\n'}), []byte{'\n'})
+			if restore {
+				if keep[table] == nil {
+					keep[table] = make(map[string]struct{})
+				}
+				for _, line := range lines {
+					keep[table][string(line)] = struct{}{}
+				}
+			}
+			keys[table] += len(lines)
+
+		case strings.HasPrefix(name, archiveBlobPrefix):
+			if restore {
+				key := strings.TrimPrefix(name, archiveBlobPrefix)
+				if err := i.restoreBlob(ctx, key, data); err != nil {
+					return fmt.Errorf("error restoring file %s: %w", key, err)
+				}
+			}
+			blobs++
+
+		default:
+			return fmt.Errorf("unexpected archive member %s", name)
+		}
+	}
+}
+
+// readEntries decodes newline separated entries from the given
+// data into models for the given table, restoring them if set.
+// Returns the number of entries read.
+func (i *importer) readEntries(ctx context.Context, table string, data []byte, restore bool) (int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	var n int
+	for {
+		entry, ok := archiveTableModel(table)
+		if !ok {
+			return n, fmt.Errorf("unknown table %s", table)
+		}
+
+		if err := dec.Decode(entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, fmt.Errorf("error decoding entry: %w", err)
+		}
+		n++
+
+		if !restore {
+			continue
+		}
+
+		if err := i.db.RestoreEntry(ctx, entry); err != nil {
+			return n, fmt.Errorf("error restoring entry: %w", err)
+		}
+
+		// Search indices aren't part of the
+		// archive, so rebuild them as we go.
+		var err error
+		switch e := entry.(type) {
+		case *gtsmodel.Account:
+			err = i.db.IndexAccount(ctx, e)
+		case *gtsmodel.Status:
+			err = i.db.IndexStatus(ctx, e)
+		}
+		if err != nil {
+			return n, fmt.Errorf("error indexing entry: %w", err)
+		}
+	}
+}
+
+// pruneEntries deletes every entry in the given table whose
+// key isn't in keep, returning the number of entries deleted.
+func (i *importer) pruneEntries(ctx context.Context, table string, keep map[string]struct{}) (int, error) {
+	model, ok := archiveTableModel(table)
+	if !ok {
+		return 0, fmt.Errorf("unknown table %s", table)
+	}
+
+	// Gather stale keys first, rather than
+	// deleting while their rows are open.
+	var stale [][]interface{}
+	if err := i.db.StreamTableKeys(ctx, model, func(key []interface{}) error {
+		b, err := json.Marshal(key)
+		if err != nil {
+			return fmt.Errorf("error encoding key: %w", err)
+		}
+
+		if _, ok := keep[string(b)]; !ok {
+			stale = append(stale, key)
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	for _, key := range stale {
+		if err := i.db.DeleteEntryByKey(ctx, model, key); err != nil {
+			return 0, fmt.Errorf("error deleting entry: %w", err)
+		}
+	}
+
+	return len(stale), nil
+}
+
+// restoreBlob puts the given file data in
+// storage, overwriting any existing file.
+func (i *importer) restoreBlob(ctx context.Context, key string, data []byte) error {
+	_, err := i.storage.Put(ctx, key, data)
+	if err == nil || !storage.IsAlreadyExist(err) {
+		return err
+	}
+
+	if err := i.storage.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	_, err = i.storage.Put(ctx, key, data)
+	return err
+}
+
+// checkTrailer checks the given trailer data against the number of entries,
+// keys, and files read from the archive, and that it's the last member.
+func checkTrailer(
+	ar *archiveReader,
+	data []byte,
+	entries map[string]int,
+	keys map[string]int,
+	blobs int,
+) (*transmodel.Trailer, error) {
+	var trailer transmodel.Trailer
+	if err := json.Unmarshal(data, &trailer); err != nil {
+		return nil, fmt.Errorf("error decoding trailer: %w", err)
+	}
+
+	if err := checkTrailerCounts("entries", trailer.Entries, entries); err != nil {
+		return nil, err
+	}
+
+	if err := checkTrailerCounts("keys", trailer.Keys, keys); err != nil {
+		return nil, err
+	}
+
+	if trailer.Blobs != blobs {
+		return nil, fmt.Errorf("expected %d files, read %d", trailer.Blobs, blobs)
+	}
+
+	if _, _, err := ar.readMember(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected members after trailer")
+	}
+
+	return &trailer, nil
+}
+
+// checkTrailerCounts checks the per-table counts of what
+// was read from the archive against those in the trailer.
+func checkTrailerCounts(what string, expect map[string]int, read map[string]int) error {
+	for table, n := range expect {
+		if read[table] != n {
+			return fmt.Errorf("expected %d %s %s, read %d", n, table, what, read[table])
+		}
+	}
+
+	for table, n := range read {
+		if _, ok := expect[table]; !ok {
+			return fmt.Errorf("read %d %s %s not recorded in trailer", n, table, what)
+		}
+	}
+
+	return nil
+}
@@ -21,20 +21,34 @@
 	"context"
 
 	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
 )
 
 // Importer wraps functionality for importing entries from a file into the database.
 type Importer interface {
+	// Import imports the file at the given path, which may be either
+	// an archive written by Exporter.Export, or a file of newline
+	// separated JSON objects written by Exporter.ExportMinimal.
+	//
+	// Archives are verified in full before anything is imported,
+	// and entries already in the database are overwritten.
 	Import(ctx context.Context, path string) error
+
+	// Verify checks that the archive at the given path is complete,
+	// and that every member in it matches its recorded checksum,
+	// without importing anything.
+	Verify(ctx context.Context, path string) error
 }
 
 type importer struct {
-	db db.DB
+	db      db.DB
+	storage *storage.Driver
 }
 
-// NewImporter returns a new Importer interface that uses the given db.
-func NewImporter(db db.DB) Importer {
+// NewImporter returns a new Importer interface that uses the given db and storage.
+func NewImporter(db db.DB, storage *storage.Driver) Importer {
 	return &importer{
-		db: db,
+		db:      db,
+		storage: storage,
 	}
 }
@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import "time"
+
+// ArchiveVersion is the version of the archive format
+// written by the exporter. Importers refuse archives
+// with a version newer than the one they understand.
+const ArchiveVersion = 2
+
+// Manifest describes an exported archive,
+// and is always the first member of one.
+type Manifest struct {
+	Version       int        `json:"version"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	Since         *time.Time `json:"since,omitempty"`
+	Host          string     `json:"host"`
+	AccountDomain string     `json:"accountDomain"`
+	Software      string     `json:"software,omitempty"`
+}
+
+// Trailer is always the last member of an exported archive,
+// and records what it contains so truncation can be detected.
+//
+// Keys is only set for incremental archives, and records the
+// number of keys listed for each table: every entry present
+// at export time, so entries deleted since can be pruned.
+type Trailer struct {
+	Entries map[string]int `json:"entries"`
+	Keys    map[string]int `json:"keys,omitempty"`
+	Blobs   int            `json:"blobs"`
+}
@@ -21,6 +21,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/testrig"
 	"github.com/stretchr/testify/suite"
 )
@@ -28,6 +29,7 @@
 type TransTestSuite struct {
 	suite.Suite
 	db           db.DB
+	storage      *storage.Driver
 	testAccounts map[string]*gtsmodel.Account
 }
 
@@ -42,8 +44,12 @@ func (suite *TransTestSuite) SetupTest() {
 
 	suite.db = testrig.NewTestDB(&state)
 	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.storage = testrig.NewInMemoryStorage()
+	testrig.StandardStorageSetup(suite.storage, "../../testrig/media")
 }
 
 func (suite *TransTestSuite) TearDownTest() {
 	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
 }
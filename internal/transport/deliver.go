@@ -81,7 +81,7 @@ func (t *transport) BatchDeliver(ctx context.Context, obj map[string]interface{}
 	}
 
 	// Push prepared request list to the delivery queue.
-	t.controller.state.Workers.Delivery.Queue.Push(reqs...)
+	t.controller.state.Workers.Delivery.Push(ctx, reqs...)
 
 	// Return combined err.
 	return errs.Combine()
@@ -112,7 +112,7 @@ func (t *transport) Deliver(ctx context.Context, obj map[string]interface{}, to
 	}
 
 	// Push prepared request to the delivery queue.
-	t.controller.state.Workers.Delivery.Queue.Push(req)
+	t.controller.state.Workers.Delivery.Push(ctx, req)
 
 	return nil
 }
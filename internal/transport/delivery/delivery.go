@@ -24,6 +24,7 @@
 	"net/http"
 	"time"
 
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/httpclient"
 )
 
@@ -33,6 +34,11 @@
 // be indexed (and so, dropped from queue)
 // by any of these possible ID IRIs.
 type Delivery struct {
+	// ID is the ID of the persisted
+	// gtsmodel.QueuedDelivery{} for
+	// this delivery, if persisted.
+	ID string
+
 	// ActorID contains the ActivityPub
 	// actor ID IRI (if any) of the activity
 	// being sent out by this request.
@@ -55,6 +61,7 @@ type Delivery struct {
 
 	// internal fields.
 	next time.Time
+	busy bool
 }
 
 // delivery is an internal type
@@ -149,6 +156,41 @@ func (dlv *Delivery) Deserialize(data []byte) error {
 	return nil
 }
 
+// Model returns the serialized delivery as a
+// gtsmodel.QueuedDelivery{} for persisting,
+// with the given ID as the database ID.
+func (dlv *Delivery) Model(id string) (*gtsmodel.QueuedDelivery, error) {
+	data, err := dlv.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return &gtsmodel.QueuedDelivery{
+		ID:            id,
+		Domain:        dlv.Request.URL.Host,
+		ActorID:       dlv.ActorID,
+		ObjectID:      dlv.ObjectID,
+		TargetID:      dlv.TargetID,
+		Data:          data,
+		Attempts:      dlv.Request.Attempts(),
+		NextAttemptAt: dlv.next,
+	}, nil
+}
+
+// Restore will deserialize a previously persisted
+// gtsmodel.QueuedDelivery{}, restoring its attempt
+// and backoff state. As with Deserialize, this will
+// leave the delivery still requiring signing setup.
+func (dlv *Delivery) Restore(queued *gtsmodel.QueuedDelivery) error {
+	if err := dlv.Deserialize(queued.Data); err != nil {
+		return err
+	}
+	dlv.ID = queued.ID
+	dlv.Request.SetAttempts(queued.Attempts)
+	dlv.next = queued.NextAttemptAt
+	return nil
+}
+
 // backoff returns a valid (>= 0) backoff duration.
 func (dlv *Delivery) backoff() time.Duration {
 	if dlv.next.IsZero() {
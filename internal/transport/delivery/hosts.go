@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// breakerThreshold is the number of consecutive
+	// failed deliveries to a host, after which its
+	// circuit is opened and no further deliveries
+	// will be attempted until breaker cooldown.
+	breakerThreshold = 5
+
+	// breakerBaseCooldown is the time that a host's
+	// circuit is initially opened for, doubling on
+	// each further failure, up to breakerMaxCooldown.
+	breakerBaseCooldown = time.Minute
+
+	// breakerMaxCooldown is the maximum
+	// time a host's circuit is opened for.
+	breakerMaxCooldown = 6 * time.Hour
+
+	// busyHostDelay is the time deliveries are
+	// deferred for when their target host is
+	// already at its limit of in-flight requests.
+	busyHostDelay = time.Second
+)
+
+// HostState contains the current delivery
+// state of one delivery target host.
+type HostState struct {
+	// Host is the target host.
+	Host string
+
+	// InFlight is the number of
+	// deliveries currently being
+	// made to this host.
+	InFlight int
+
+	// Failures is the number of consecutive
+	// failed deliveries to this host.
+	Failures int
+
+	// OpenUntil is the time until which the
+	// circuit for this host is open, i.e.
+	// no deliveries will be attempted to it.
+	// Zero if the circuit is closed.
+	OpenUntil time.Time
+}
+
+// hosts tracks per-host delivery state, shared between delivery
+// workers. This provides fairness between target hosts, by limiting
+// the number of in-flight deliveries to any one host, and circuit
+// breaking for hosts that are consistently failing, so that dead
+// instances can't tie up workers at the expense of everyone else.
+type hosts struct {
+	// maximum in-flight
+	// deliveries per host.
+	maxInFlight int
+
+	states map[string]*HostState
+	notify chan struct{}
+	mutex  sync.Mutex
+}
+
+// acquire checks whether a delivery to the given host may be attempted
+// now, in which case in-flight count for the host is incremented and true
+// returned. Else returns false, the time after which to retry, and a
+// channel closed on next release of any host (as a busy host may then
+// be available again).
+func (h *hosts) acquire(host string, now time.Time) (time.Time, <-chan struct{}, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.states == nil {
+		h.states = make(map[string]*HostState)
+	}
+
+	state := h.states[host]
+	if state == nil {
+		state = &HostState{Host: host}
+		h.states[host] = state
+	}
+
+	if now.Before(state.OpenUntil) {
+		// Circuit is open.
+		return state.OpenUntil, nil, false
+	}
+
+	if (!state.OpenUntil.IsZero() && state.InFlight > 0) ||
+		(h.maxInFlight > 0 && state.InFlight >= h.maxInFlight) {
+		// Either circuit is half-open, so only one trial
+		// delivery at a time is allowed through, or host
+		// already has its fair share of in-flight deliveries.
+		if h.notify == nil {
+			h.notify = make(chan struct{})
+		}
+		return now.Add(busyHostDelay), h.notify, false
+	}
+
+	state.InFlight++
+	return time.Time{}, nil, true
+}
+
+// release marks an in-flight delivery to the given host as finished,
+// updating the host's circuit breaker according to whether it failed.
+func (h *hosts) release(host string, failed bool, now time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	state := h.states[host]
+	if state == nil {
+		return
+	}
+
+	state.InFlight--
+
+	if h.notify != nil {
+		// Wake any deferred.
+		close(h.notify)
+		h.notify = nil
+	}
+
+	if !failed {
+		// Host is alive, close circuit. We drop the
+		// host state entirely once it's idle, so the
+		// map only ever contains busy / failing hosts.
+		state.Failures = 0
+		state.OpenUntil = time.Time{}
+		if state.InFlight <= 0 {
+			delete(h.states, host)
+		}
+		return
+	}
+
+	state.Failures++
+	if state.Failures < breakerThreshold {
+		return
+	}
+
+	// Open the circuit, backing off
+	// exponentially on continued failure.
+	cooldown := breakerBaseCooldown
+	for i := breakerThreshold; i < state.Failures &&
+		cooldown < breakerMaxCooldown; i++ {
+		cooldown *= 2
+	}
+	cooldown = min(cooldown, breakerMaxCooldown)
+	state.OpenUntil = now.Add(cooldown)
+}
+
+// snapshot returns a copy of all currently tracked host states.
+func (h *hosts) snapshot() []HostState {
+	h.mutex.Lock()
+	states := make([]HostState, 0, len(h.states))
+	for _, state := range h.states {
+		states = append(states, *state)
+	}
+	h.mutex.Unlock()
+
+	slices.SortFunc(states, func(a, b HostState) int {
+		return strings.Compare(a.Host, b.Host)
+	})
+
+	return states
+}
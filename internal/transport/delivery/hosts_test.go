@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHostsFairness(t *testing.T) {
+	h := hosts{maxInFlight: 2}
+	now := time.Now()
+
+	// Up to max in-flight deliveries to one host.
+	for i := 0; i < 2; i++ {
+		if _, _, ok := h.acquire("busy.example.org", now); !ok {
+			t.Fatalf("expected acquire %d to succeed", i)
+		}
+	}
+
+	// Further deliveries to that host are deferred.
+	next, _, ok := h.acquire("busy.example.org", now)
+	if ok {
+		t.Fatal("expected acquire over max in-flight to fail")
+	}
+	if !next.Equal(now.Add(busyHostDelay)) {
+		t.Fatalf("unexpected retry time %s", next)
+	}
+
+	// Other hosts are unaffected.
+	if _, _, ok := h.acquire("other.example.org", now); !ok {
+		t.Fatal("expected acquire for other host to succeed")
+	}
+
+	// Once released, host may be delivered to again.
+	h.release("busy.example.org", false, now)
+	if _, _, ok := h.acquire("busy.example.org", now); !ok {
+		t.Fatal("expected acquire after release to succeed")
+	}
+}
+
+func TestHostsCircuitBreaker(t *testing.T) {
+	h := hosts{maxInFlight: 1}
+	now := time.Now()
+	const host = "dead.example.org"
+
+	// Fail deliveries up to threshold.
+	for i := 0; i < breakerThreshold; i++ {
+		if _, _, ok := h.acquire(host, now); !ok {
+			t.Fatalf("expected acquire %d to succeed", i)
+		}
+		h.release(host, true, now)
+	}
+
+	// Circuit should now be open.
+	next, _, ok := h.acquire(host, now)
+	if ok {
+		t.Fatal("expected acquire with open circuit to fail")
+	}
+	if !next.Equal(now.Add(breakerBaseCooldown)) {
+		t.Fatalf("unexpected retry time %s", next)
+	}
+
+	states := h.snapshot()
+	if len(states) != 1 || states[0].Failures != breakerThreshold {
+		t.Fatalf("unexpected host states %+v", states)
+	}
+
+	// After cooldown, a trial delivery is let through,
+	// failure of which re-opens circuit for longer.
+	now = next
+	if _, _, ok := h.acquire(host, now); !ok {
+		t.Fatal("expected trial acquire to succeed")
+	}
+	h.release(host, true, now)
+	next, _, ok = h.acquire(host, now)
+	if ok {
+		t.Fatal("expected acquire with re-opened circuit to fail")
+	}
+	if !next.Equal(now.Add(2 * breakerBaseCooldown)) {
+		t.Fatalf("unexpected retry time %s", next)
+	}
+
+	// Successful trial delivery closes circuit.
+	now = next
+	if _, _, ok := h.acquire(host, now); !ok {
+		t.Fatal("expected trial acquire to succeed")
+	}
+	h.release(host, false, now)
+	if states := h.snapshot(); len(states) != 0 {
+		t.Fatalf("unexpected host states %+v", states)
+	}
+}
@@ -23,8 +23,11 @@
 	"slices"
 	"time"
 
+	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/httpclient"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/queue"
 	"code.superseriousbusiness.org/gotosocial/internal/util"
@@ -44,8 +47,16 @@ type WorkerPool struct {
 	// passed to each of delivery pool Worker{}s.
 	Queue queue.StructQueue[*Delivery]
 
+	// DB is the database that deliveries pushed via
+	// Push() are persisted to before being queued,
+	// and removed from once delivered (or dropped).
+	// If nil, deliveries are only queued in memory.
+	DB db.Delivery
+
 	// internal fields.
 	workers []*Worker
+	hosts   hosts
+	initID  string
 }
 
 // Init will initialize the Worker{} pool
@@ -60,6 +71,72 @@ func (p *WorkerPool) Init(client *httpclient.Client) {
 			{Fields: "TargetID", Multiple: true},
 		},
 	})
+
+	// Generate the lowest possible ULID for the current
+	// time, so any generated after are guaranteed higher.
+	p.initID = id.NewULIDFromTime(time.Now())[:10] + id.Lowest[10:]
+}
+
+// InitID returns an ID generated on Init(). All deliveries
+// persisted by this pool will have IDs greater than this,
+// so persisted deliveries with lower IDs are those left
+// over from a previous run (eg., before a crash), which
+// need recovering.
+func (p *WorkerPool) InitID() string {
+	return p.initID
+}
+
+// Push will persist the given deliveries to the database
+// (if set) ahead of pushing them to the delivery queue.
+func (p *WorkerPool) Push(ctx context.Context, dlvs ...*Delivery) {
+	if p.DB != nil && len(dlvs) > 0 {
+		queued := make([]*gtsmodel.QueuedDelivery, 0, len(dlvs))
+
+		for _, dlv := range dlvs {
+			// Serialize for persisting.
+			model, err := dlv.Model(id.NewULID())
+			if err != nil {
+				log.Errorf(ctx, "error serializing delivery: %v", err)
+				continue
+			}
+
+			dlv.ID = model.ID
+			queued = append(queued, model)
+		}
+
+		if err := p.DB.PutQueuedDeliveries(ctx, queued); err != nil {
+			log.Errorf(ctx, "error persisting deliveries: %v", err)
+
+			// Not persisted, so unset IDs; they
+			// can still be persisted on shutdown.
+			for _, dlv := range dlvs {
+				dlv.ID = ""
+			}
+		}
+	}
+
+	p.Queue.Push(dlvs...)
+}
+
+// Delete will drop all queued deliveries with actor,
+// object or target ID matching given IRI, including
+// from the database (if set).
+func (p *WorkerPool) Delete(ctx context.Context, iri string) {
+	p.Queue.Delete("ActorID", iri)
+	p.Queue.Delete("ObjectID", iri)
+	p.Queue.Delete("TargetID", iri)
+
+	if p.DB != nil {
+		if err := p.DB.DeleteQueuedDeliveriesByIRI(ctx, iri); err != nil {
+			log.Errorf(ctx, "error deleting persisted deliveries: %v", err)
+		}
+	}
+}
+
+// Hosts returns the current delivery state of all target hosts
+// that currently have deliveries in-flight or that are failing.
+func (p *WorkerPool) Hosts() []HostState {
+	return p.hosts.snapshot()
 }
 
 // Start will attempt to start 'n' Worker{}s.
@@ -71,6 +148,12 @@ func (p *WorkerPool) Start(n int) {
 		return
 	}
 
+	// Limit the number of workers that can
+	// be delivering to any one host at once
+	// to half, so that one slow or unreachable
+	// host can't hold up deliveries elsewhere.
+	p.hosts.maxInFlight = max(1, n/2)
+
 	// Allocate new workers slice.
 	p.workers = make([]*Worker, n)
 	for i := range p.workers {
@@ -79,6 +162,8 @@ func (p *WorkerPool) Start(n int) {
 		p.workers[i] = new(Worker)
 		p.workers[i].Client = p.Client
 		p.workers[i].Queue = &p.Queue
+		p.workers[i].DB = p.DB
+		p.workers[i].hosts = &p.hosts
 
 		// Attempt to start worker.
 		// Return bool not useful
@@ -124,9 +209,16 @@ type Worker struct {
 	// that delivery worker will feed from.
 	Queue *queue.StructQueue[*Delivery]
 
+	// DB is the database of persisted
+	// deliveries to keep up-to-date
+	// with delivery state, if set.
+	DB db.Delivery
+
 	// internal fields.
-	backlog []*Delivery
-	service runners.Service
+	backlog  []*Delivery
+	hosts    *hosts
+	released <-chan struct{}
+	service  runners.Service
 }
 
 // Start will attempt to start the Worker{}.
@@ -196,33 +288,78 @@ func (w *Worker) process(ctx context.Context) bool {
 				backoff.Stop()
 				continue loop
 
+			case <-w.released:
+				// A host was released, re-add
+				// this to backlog and retry any
+				// deferred due to busy hosts.
+				w.released = nil
+				w.pushBacklog(dlv)
+				for _, dlv := range w.backlog {
+					if dlv.busy {
+						dlv.next = time.Time{}
+						dlv.busy = false
+					}
+				}
+				backoff.Stop()
+				continue loop
+
 			case <-backoff.C:
 				// success!
 			}
 		}
 
+		// Check whether the target host is available
+		// for delivery, i.e. its circuit isn't open and
+		// it isn't at its limit of in-flight deliveries.
+		host := dlv.Request.URL.Host
+		if w.hosts != nil {
+			next, released, ok := w.hosts.acquire(host, time.Now())
+			if !ok {
+				// Defer delivery (without
+				// counting as an attempt).
+				if released != nil {
+					w.released = released
+					dlv.busy = true
+				}
+				dlv.next = next
+				w.pushBacklog(dlv)
+				continue loop
+			}
+		}
+
 		// Attempt delivery of AP request.
 		rsp, retry, err := w.Client.DoOnce(
 			dlv.Request,
 		)
 
+		// Check if our own context was cancelled.
+		//
+		// Note we specifically check against
+		// context.Canceled here as it will
+		// be faster than the mutex lock of
+		// ctx.Err(), so gives an initial
+		// faster check in the if-clause.
+		cancelled := errors.Is(err, context.Canceled) &&
+			ctx.Err() != nil
+
+		if w.hosts != nil {
+			// Release host, marking any failure
+			// (not caused by us) against host.
+			failed := (err != nil && !cancelled)
+			w.hosts.release(host, failed, time.Now())
+		}
+
 		switch {
 		case err == nil:
 			// Ensure body closed.
 			_ = rsp.Body.Close()
+			w.done(dlv)
 			continue loop
 
-		case errors.Is(err, context.Canceled) &&
-			ctx.Err() != nil:
+		case cancelled:
 			// In the case of our own context
 			// being cancelled, push delivery
 			// back onto queue for persisting.
-			//
-			// Note we specifically check against
-			// context.Canceled here as it will
-			// be faster than the mutex lock of
-			// ctx.Err(), so gives an initial
-			// faster check in the if-clause.
 			w.Queue.Push(dlv)
 			continue loop
 
@@ -230,6 +367,7 @@ func (w *Worker) process(ctx context.Context) bool {
 			// Drop deliveries when no
 			// retry requested, or they
 			// reached max (either).
+			w.done(dlv)
 			continue loop
 		}
 
@@ -237,11 +375,56 @@ func (w *Worker) process(ctx context.Context) bool {
 		backoff := dlv.Request.BackOff()
 		dlv.next = time.Now().Add(backoff)
 
+		// Persist attempt state.
+		w.update(dlv)
+
 		// Push to backlog.
 		w.pushBacklog(dlv)
 	}
 }
 
+// done removes the given delivery from the
+// database once delivered (or dropped), if
+// it was persisted.
+func (w *Worker) done(dlv *Delivery) {
+	if w.DB == nil || dlv.ID == "" {
+		return
+	}
+
+	// Use a background context, as
+	// worker context may be cancelled.
+	ctx := context.Background()
+
+	if err := w.DB.DeleteQueuedDeliveryByID(ctx, dlv.ID); err != nil {
+		log.Errorf(ctx, "error deleting delivery %s: %v", dlv.ID, err)
+	}
+}
+
+// update persists the attempt and backoff state of the
+// given delivery to the database, if it was persisted,
+// so that backoff is respected across restarts.
+func (w *Worker) update(dlv *Delivery) {
+	if w.DB == nil || dlv.ID == "" {
+		return
+	}
+
+	// Use a background context, as
+	// worker context may be cancelled.
+	ctx := context.Background()
+
+	if err := w.DB.UpdateQueuedDelivery(ctx,
+		&gtsmodel.QueuedDelivery{
+			ID:            dlv.ID,
+			Attempts:      dlv.Request.Attempts(),
+			NextAttemptAt: dlv.next,
+		},
+		"attempts",
+		"next_attempt_at",
+	); err != nil {
+		log.Errorf(ctx, "error updating delivery %s: %v", dlv.ID, err)
+	}
+}
+
 // next gets the next available delivery, blocking until available if necessary.
 func (w *Worker) next(ctx context.Context) (*Delivery, bool) {
 	// Try a fast-pop of queued
@@ -301,14 +484,6 @@ func (w *Worker) pushBacklog(dlv *Delivery) {
 // to when is the first requiring re-attempt.
 func sortDeliveries(d []*Delivery) {
 	slices.SortFunc(d, func(a, b *Delivery) int {
-		const k = +1
-		switch {
-		case a.next.Before(b.next):
-			return +k
-		case b.next.Before(a.next):
-			return -k
-		default:
-			return 0
-		}
+		return a.next.Compare(b.next)
 	})
 }
@@ -115,8 +115,8 @@ func (suite *DereferenceTestSuite) TestDerefLocalStatus() {
 	defer resp.Body.Close()
 
 	suite.Equal(http.StatusOK, resp.StatusCode)
-	suite.EqualValues(1502, resp.ContentLength)
-	suite.Equal("1502", resp.Header.Get("Content-Length"))
+	suite.EqualValues(1595, resp.ContentLength)
+	suite.Equal("1595", resp.Header.Get("Content-Length"))
 	suite.Equal(apiutil.AppActivityLDJSON, resp.Header.Get("Content-Type"))
 
 	b, err := io.ReadAll(resp.Body)
@@ -158,6 +158,12 @@ func (suite *DereferenceTestSuite) TestDerefLocalStatus() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
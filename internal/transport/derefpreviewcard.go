@@ -0,0 +1,486 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"codeberg.org/gruf/go-bytesize"
+	"codeberg.org/gruf/go-iotools"
+	"github.com/temoto/robotstxt"
+	"golang.org/x/net/html"
+)
+
+// maxPreviewCardSize is the maximum size of
+// HTML page or oEmbed document we'll read
+// when generating a preview card for a link.
+const maxPreviewCardSize = int64(1 * bytesize.MiB)
+
+func (t *transport) DereferencePreviewCard(ctx context.Context, iri *url.URL) (*gtsmodel.PreviewCard, error) {
+	// Try to fetch robots.txt to check
+	// if we're allowed to fetch the page.
+	robotsTxt, err := t.DereferenceRobots(ctx, iri.Scheme, iri.Host)
+	if err != nil {
+		log.Debugf(ctx, "couldn't fetch robots.txt from %s: %v", iri.Host, err)
+	}
+
+	// Fetch the linked page and parse
+	// any metadata from the <head>.
+	meta, err := t.dereferencePreviewCardPage(ctx, iri, robotsTxt)
+	if err != nil {
+		return nil, err
+	}
+
+	card := &gtsmodel.PreviewCard{
+		URL:          iri.String(),
+		Type:         gtsmodel.ParsePreviewCardType(meta.ogType),
+		Title:        meta.title,
+		Description:  meta.description,
+		AuthorName:   meta.author,
+		ProviderName: meta.siteName,
+	}
+
+	// Resolve the preview image
+	// URL relative to the page.
+	if meta.image != "" {
+		if image, err := iri.Parse(meta.image); err == nil &&
+			(image.Scheme == "https" || image.Scheme == "http") {
+			card.ImageRemoteURL = image.String()
+		}
+	}
+
+	if meta.oEmbed == "" {
+		// No oEmbed data, just
+		// use what we have.
+		return card, nil
+	}
+
+	oEmbedIRI, err := iri.Parse(meta.oEmbed)
+	if err != nil || (oEmbedIRI.Scheme != "https" && oEmbedIRI.Scheme != "http") {
+		log.Debugf(ctx, "invalid oEmbed url %s: %v", meta.oEmbed, err)
+		return card, nil
+	}
+
+	if oEmbedIRI.Host != iri.Host {
+		// oEmbed endpoint lives on a different
+		// host, so fetch its own robots.txt.
+		robotsTxt, err = t.DereferenceRobots(ctx, oEmbedIRI.Scheme, oEmbedIRI.Host)
+		if err != nil {
+			log.Debugf(ctx, "couldn't fetch robots.txt from %s: %v", oEmbedIRI.Host, err)
+		}
+	}
+
+	// Fetch oEmbed data for the page. Failure
+	// here isn't fatal, we can still use the
+	// page metadata that we already parsed.
+	oEmbed, err := t.dereferenceOEmbed(ctx, oEmbedIRI, robotsTxt)
+	if err != nil {
+		log.Debugf(ctx, "couldn't fetch oEmbed from %s: %v", oEmbedIRI, err)
+		return card, nil
+	}
+
+	// oEmbed data takes
+	// precedence over meta.
+	if oEmbed.Title != "" {
+		card.Title = oEmbed.Title
+	}
+	if oEmbed.AuthorName != "" {
+		card.AuthorName = oEmbed.AuthorName
+	}
+	if oEmbed.AuthorURL != "" {
+		card.AuthorURL = oEmbed.AuthorURL
+	}
+	if oEmbed.ProviderName != "" {
+		card.ProviderName = oEmbed.ProviderName
+	}
+	if oEmbed.ProviderURL != "" {
+		card.ProviderURL = oEmbed.ProviderURL
+	}
+	if oEmbed.ThumbnailURL != "" {
+		card.ImageRemoteURL = oEmbed.ThumbnailURL
+	}
+	card.Width = oEmbed.Width
+	card.Height = oEmbed.Height
+
+	switch oEmbed.Type {
+	case "photo":
+		if oEmbed.URL != "" {
+			card.Type = gtsmodel.PreviewCardTypePhoto
+			card.EmbedURL = oEmbed.URL
+		}
+
+	case "video", "rich":
+		// Only accept embed HTML if it's a
+		// single https iframe, anything
+		// else is too risky to pass on.
+		if iframe := sanitizeOEmbedHTML(oEmbed.HTML, card.Width, card.Height); iframe != "" {
+			card.Type = gtsmodel.ParsePreviewCardType(oEmbed.Type)
+			card.HTML = iframe
+		}
+	}
+
+	return card, nil
+}
+
+// previewCardMeta contains metadata
+// parsed from the <head> of a page.
+type previewCardMeta struct {
+	title       string
+	description string
+	author      string
+	siteName    string
+	ogType      string
+	image       string
+	oEmbed      string
+}
+
+func (t *transport) dereferencePreviewCardPage(
+	ctx context.Context,
+	iri *url.URL,
+	robotsTxt *robotstxt.RobotsData,
+) (*previewCardMeta, error) {
+	rsp, err := t.getPreviewCardResource(ctx, iri, robotsTxt, apiutil.TextHTML)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	// Ensure that the incoming request content-type is expected.
+	if ct := rsp.Header.Get("Content-Type"); !apiutil.TextHTMLContentType(ct) {
+		err := gtserror.Newf("non text/html response: %s", ct)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	return parsePreviewCardMeta(rsp.Body), nil
+}
+
+// oEmbedResponse models the fields of
+// an oEmbed response that we care about.
+//
+// See: https://oembed.com/#section2.3
+type oEmbedResponse struct {
+	Type         string      `json:"type"`
+	Title        string      `json:"title"`
+	AuthorName   string      `json:"author_name"`
+	AuthorURL    string      `json:"author_url"`
+	ProviderName string      `json:"provider_name"`
+	ProviderURL  string      `json:"provider_url"`
+	ThumbnailURL string      `json:"thumbnail_url"`
+	URL          string      `json:"url"`
+	HTML         string      `json:"html"`
+	Width        json.Number `json:"width"`
+	Height       json.Number `json:"height"`
+}
+
+func (t *transport) dereferenceOEmbed(
+	ctx context.Context,
+	iri *url.URL,
+	robotsTxt *robotstxt.RobotsData,
+) (*oEmbedData, error) {
+	rsp, err := t.getPreviewCardResource(ctx, iri, robotsTxt, apiutil.AppJSON)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	// Ensure that the incoming request content-type is expected.
+	if ct := rsp.Header.Get("Content-Type"); !apiutil.JSONContentType(ct) {
+		err := gtserror.Newf("non json response type: %s", ct)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	var oEmbed oEmbedResponse
+	if err := json.NewDecoder(rsp.Body).Decode(&oEmbed); err != nil {
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	return &oEmbedData{
+		oEmbedResponse: oEmbed,
+		Width:          parseDimension(oEmbed.Width),
+		Height:         parseDimension(oEmbed.Height),
+	}, nil
+}
+
+// oEmbedData wraps an oEmbed response
+// with width and height parsed to ints,
+// since some providers give these as
+// strings and others as numbers.
+type oEmbedData struct {
+	oEmbedResponse
+	Width  int
+	Height int
+}
+
+// getPreviewCardResource performs a size-limited
+// GET request of given IRI, accepting given content
+// type, after checking the path is allowed by robots.
+func (t *transport) getPreviewCardResource(
+	ctx context.Context,
+	iri *url.URL,
+	robotsTxt *robotstxt.RobotsData,
+	accept string,
+) (*http.Response, error) {
+	if robotsTxt != nil && !robotsTxt.TestAgent(iri.RequestURI(), t.controller.userAgent) {
+		err := gtserror.Newf("can't fetch %s: robots.txt disallows it", iri)
+		return nil, gtserror.SetNotPermitted(err)
+	}
+
+	// Build IRI just once
+	iriStr := iri.String()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", iriStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", accept)
+
+	rsp, err := t.GET(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure a non-error status response.
+	if rsp.StatusCode != http.StatusOK {
+		err := gtserror.NewFromResponse(rsp)
+		_ = rsp.Body.Close() // close early.
+		return nil, err
+	}
+
+	// Check body claims to be within size limit.
+	if rsp.ContentLength > maxPreviewCardSize {
+		_ = rsp.Body.Close()                    // close early.
+		sz := bytesize.Size(maxPreviewCardSize) //nolint:gosec
+		return nil, gtserror.Newf("response body exceeds max size %s", sz)
+	}
+
+	// Update response body with maximum size.
+	rsp.Body, _, _ = iotools.UpdateReadCloserLimit(rsp.Body, maxPreviewCardSize)
+
+	return rsp, nil
+}
+
+// parsePreviewCardMeta parses OpenGraph, Twitter card and
+// standard HTML metadata from the <head> of given HTML page,
+// along with the location of any JSON oEmbed document.
+func parsePreviewCardMeta(r io.Reader) *previewCardMeta {
+	var (
+		meta    previewCardMeta
+		twitter previewCardMeta
+		plain   previewCardMeta
+		inTitle bool
+		tkn     = html.NewTokenizer(r)
+	)
+
+	for {
+		switch tkn.Next() {
+		case html.ErrorToken:
+			// EOF or malformed
+			// input, we're done.
+			return mergePreviewCardMeta(&meta, &twitter, &plain)
+
+		case html.TextToken:
+			if inTitle && plain.title == "" {
+				plain.title = strings.TrimSpace(string(tkn.Text()))
+			}
+
+		case html.EndTagToken:
+			name, _ := tkn.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				// All the metadata
+				// we need is in head.
+				return mergePreviewCardMeta(&meta, &twitter, &plain)
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tkn.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = true
+				continue
+			case "body":
+				// Past the head.
+				return mergePreviewCardMeta(&meta, &twitter, &plain)
+			case "meta", "link":
+			default:
+				continue
+			}
+
+			attrs := make(map[string]string, 4)
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = tkn.TagAttr()
+				attrs[string(key)] = string(val)
+			}
+
+			if string(name) == "link" {
+				if strings.EqualFold(attrs["rel"], "alternate") &&
+					strings.EqualFold(attrs["type"], "application/json+oembed") {
+					meta.oEmbed = attrs["href"]
+				}
+				continue
+			}
+
+			content := strings.TrimSpace(attrs["content"])
+			if content == "" {
+				continue
+			}
+
+			// OpenGraph uses "property", while
+			// Twitter cards and others use "name".
+			key := attrs["property"]
+			if key == "" {
+				key = attrs["name"]
+			}
+
+			switch strings.ToLower(key) {
+			case "og:title":
+				meta.title = content
+			case "og:description":
+				meta.description = content
+			case "og:site_name":
+				meta.siteName = content
+			case "og:type":
+				meta.ogType = content
+			case "og:image", "og:image:url", "og:image:secure_url":
+				if meta.image == "" {
+					meta.image = content
+				}
+			case "article:author":
+				meta.author = content
+			case "twitter:title":
+				twitter.title = content
+			case "twitter:description":
+				twitter.description = content
+			case "twitter:image", "twitter:image:src":
+				twitter.image = content
+			case "twitter:creator":
+				twitter.author = content
+			case "twitter:site":
+				twitter.siteName = content
+			case "description":
+				plain.description = content
+			case "author":
+				plain.author = content
+			}
+		}
+	}
+}
+
+// mergePreviewCardMeta fills any missing metadata fields
+// in OpenGraph meta from Twitter card metadata, and then
+// from the standard HTML metadata, in that order.
+func mergePreviewCardMeta(meta, twitter, plain *previewCardMeta) *previewCardMeta {
+	for _, fallback := range []*previewCardMeta{twitter, plain} {
+		if meta.title == "" {
+			meta.title = fallback.title
+		}
+		if meta.description == "" {
+			meta.description = fallback.description
+		}
+		if meta.author == "" {
+			meta.author = fallback.author
+		}
+		if meta.siteName == "" {
+			meta.siteName = fallback.siteName
+		}
+		if meta.image == "" {
+			meta.image = fallback.image
+		}
+	}
+
+	// We only distinguish between link and video
+	// types from OpenGraph, as that's all Mastodon
+	// clients expect when there's no oEmbed data.
+	if !strings.HasPrefix(meta.ogType, "video") {
+		meta.ogType = "link"
+	} else {
+		meta.ogType = "video"
+	}
+
+	return meta
+}
+
+// sanitizeOEmbedHTML returns a freshly rendered iframe
+// using only the https src of the first iframe in given
+// oEmbed HTML, or an empty string if there isn't one.
+func sanitizeOEmbedHTML(in string, width int, height int) string {
+	tkn := html.NewTokenizer(strings.NewReader(in))
+	for {
+		switch tkn.Next() {
+		case html.ErrorToken:
+			return ""
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tkn.TagName()
+			if string(name) != "iframe" {
+				continue
+			}
+
+			var src string
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = tkn.TagAttr()
+				if string(key) == "src" {
+					src = string(val)
+				}
+			}
+
+			srcURL, err := url.Parse(src)
+			if err != nil || srcURL.Scheme != "https" || srcURL.Host == "" {
+				return ""
+			}
+
+			var b strings.Builder
+			b.WriteString(`<iframe src="`)
+			b.WriteString(html.EscapeString(srcURL.String()))
+			b.WriteString(`"`)
+			if width > 0 {
+				b.WriteString(` width="` + strconv.Itoa(width) + `"`)
+			}
+			if height > 0 {
+				b.WriteString(` height="` + strconv.Itoa(height) + `"`)
+			}
+			b.WriteString(` frameborder="0" allowfullscreen="true"></iframe>`)
+			return b.String()
+		}
+	}
+}
+
+// parseDimension parses an oEmbed width or
+// height, returning 0 if it's missing or invalid.
+func parseDimension(n json.Number) int {
+	f, err := n.Float64()
+	if err != nil || f < 0 || f > 1<<16 {
+		return 0
+	}
+	return int(f)
+}
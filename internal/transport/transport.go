@@ -78,6 +78,11 @@ type Transport interface {
 	// DereferenceInstance dereferences remote instance information, first by checking /api/v1/instance, and then by checking /.well-known/nodeinfo.
 	DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error)
 
+	// DereferencePreviewCard fetches the web page at the given IRI, honouring robots.txt, and
+	// generates a preview card from its OpenGraph, Twitter card and oEmbed metadata. The
+	// returned card has no ID set, and its preview image (if any) has not been fetched.
+	DereferencePreviewCard(ctx context.Context, iri *url.URL) (*gtsmodel.PreviewCard, error)
+
 	// DereferenceDomainPermissions dereferences the
 	// permissions list present at the given permSub's URI.
 	//
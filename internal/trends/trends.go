@@ -0,0 +1,415 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+)
+
+const (
+	// How often trends are recalculated.
+	refreshEvery = 10 * time.Minute
+
+	// Length of the sliding window
+	// in which recent uses and
+	// interactions are counted.
+	window = 24 * time.Hour
+
+	// Number of days of history kept for tags
+	// and links, including the current day. All
+	// but the current day make up the baseline
+	// against which recent uses are compared.
+	historyDays = 7
+
+	// Statuses older than this can't trend.
+	statusMaxAge = 48 * time.Hour
+
+	// Minimum number of distinct accounts
+	// that must interact with a status
+	// in the window for it to trend.
+	statusMinAccounts = 2
+
+	// Maximum number of each
+	// type of trend to keep.
+	maxTrends = 100
+)
+
+// Trends calculates which tags, statuses, and links are
+// trending on this instance, based on public activity by
+// discoverable accounts, and stores them in the trends cache.
+//
+// Tags and links trend when more distinct accounts use
+// them within the last day than would be expected from
+// the preceding days. Statuses trend based on how many
+// distinct accounts interacted with them in the last day,
+// decaying with status age.
+type Trends struct {
+	state *state.State
+
+	// Counts of tag and link
+	// uses on each past day,
+	// which won't change again.
+	daysMu sync.Mutex
+	days   map[dayKey][]*db.TrendUses
+}
+
+// dayKey keys counts of
+// uses of tags or links
+// on one particular day.
+type dayKey struct {
+	trendType gtsmodel.TrendType
+	day       time.Time
+}
+
+// countFunc counts uses of tags or
+// links between since and until.
+type countFunc func(ctx context.Context, since time.Time, until time.Time) ([]*db.TrendUses, error)
+
+func New(state *state.State) *Trends {
+	return &Trends{
+		state: state,
+		days:  make(map[dayKey][]*db.TrendUses),
+	}
+}
+
+// ScheduleJobs schedules trends to be
+// recalculated regularly, starting now.
+func (t *Trends) ScheduleJobs() error {
+	if !config.GetInstanceTrendsEnabled() {
+		log.Info(nil, "trends disabled, not scheduling trends refresh")
+		return nil
+	}
+
+	fn := func(ctx context.Context, start time.Time) {
+		log.Debug(ctx, "starting trends refresh")
+		if err := t.Refresh(ctx, start); err != nil {
+			log.Errorf(ctx, "error refreshing trends: %v", err)
+			return
+		}
+		log.Debugf(ctx, "finished trends refresh after %s", time.Since(start))
+	}
+
+	log.Infof(nil, "scheduling trends refresh to run every %s", refreshEvery)
+
+	if !t.state.Workers.Scheduler.AddRecurring(
+		"@trendsrefresh",
+		time.Now(),
+		refreshEvery,
+		fn,
+	) {
+		panic("failed to schedule @trendsrefresh")
+	}
+
+	return nil
+}
+
+// Refresh recalculates trends as of now, storing them
+// in the trends cache. Anything found to be trending for
+// the first time is added to the admin review queue.
+func (t *Trends) Refresh(ctx context.Context, now time.Time) error {
+	tags, err := t.usesTrends(ctx, gtsmodel.TrendTypeTag, now, t.state.DB.CountTagUses)
+	if err != nil {
+		return gtserror.Newf("error calculating trending tags: %w", err)
+	}
+
+	statuses, err := t.statusTrends(ctx, now)
+	if err != nil {
+		return gtserror.Newf("error calculating trending statuses: %w", err)
+	}
+
+	links, err := t.usesTrends(ctx, gtsmodel.TrendTypeLink, now, t.state.DB.CountLinkUses)
+	if err != nil {
+		return gtserror.Newf("error calculating trending links: %w", err)
+	}
+
+	for _, trends := range [][]*cache.Trend{tags, statuses, links} {
+		if err := t.enqueueReviews(ctx, trends); err != nil {
+			return err
+		}
+	}
+
+	t.state.Caches.Trends.Set(gtsmodel.TrendTypeTag, tags)
+	t.state.Caches.Trends.Set(gtsmodel.TrendTypeStatus, statuses)
+	t.state.Caches.Trends.Set(gtsmodel.TrendTypeLink, links)
+
+	return nil
+}
+
+// usesTrends calculates trending tags or links, by comparing
+// the number of distinct accounts using each in the window to
+// the average number per day in the preceding days.
+func (t *Trends) usesTrends(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	now time.Time,
+	count countFunc,
+) ([]*cache.Trend, error) {
+	// Count uses in the sliding window.
+	recent, err := count(ctx, now.Add(-window), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(recent) == 0 {
+		// Nothing
+		// going on.
+		return nil, nil
+	}
+
+	// Count uses on each day of
+	// history, most recent first.
+	today := now.UTC().Truncate(24 * time.Hour)
+	days := make([]map[string]*db.TrendUses, historyDays)
+	for i := range days {
+		uses, err := t.dayUses(ctx, trendType, today.AddDate(0, 0, -i), now, count)
+		if err != nil {
+			return nil, err
+		}
+
+		days[i] = make(map[string]*db.TrendUses, len(uses))
+		for _, u := range uses {
+			days[i][u.Target] = u
+		}
+	}
+
+	trends := make([]*cache.Trend, 0, len(recent))
+	for _, u := range recent {
+		// Expected accounts per day is the average
+		// over the days before today, at least 1 so
+		// that one account alone can't cause a trend.
+		var expected float64
+		for _, day := range days[1:] {
+			if du, ok := day[u.Target]; ok {
+				expected += float64(du.Accounts)
+			}
+		}
+		expected = max(1, expected/float64(historyDays-1))
+
+		observed := float64(u.Accounts)
+		if observed <= expected {
+			// Nothing out
+			// of the ordinary.
+			continue
+		}
+
+		history := make([]cache.TrendHistory, historyDays)
+		for i, day := range days {
+			history[i].Day = today.AddDate(0, 0, -i)
+			if du, ok := day[u.Target]; ok {
+				history[i].Uses = du.Uses
+				history[i].Accounts = du.Accounts
+			}
+		}
+
+		trends = append(trends, &cache.Trend{
+			Type:    trendType,
+			Target:  u.Target,
+			Score:   math.Pow(observed-expected, 2) / expected,
+			History: history,
+		})
+	}
+
+	return topTrends(trends), nil
+}
+
+// dayUses returns counts of uses of tags or links
+// on the given day, up until now. Counts for days
+// before today are cached, as they won't change.
+func (t *Trends) dayUses(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	day time.Time,
+	now time.Time,
+	count countFunc,
+) ([]*db.TrendUses, error) {
+	end := day.Add(24 * time.Hour)
+	if end.After(now) {
+		// Day still in progress,
+		// count uses so far.
+		return count(ctx, day, now)
+	}
+
+	t.daysMu.Lock()
+	defer t.daysMu.Unlock()
+
+	key := dayKey{trendType, day}
+	if uses, ok := t.days[key]; ok {
+		return uses, nil
+	}
+
+	uses, err := count(ctx, day, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop days that have
+	// passed out of history.
+	oldest := now.UTC().Truncate(24*time.Hour).AddDate(0, 0, -historyDays)
+	for k := range t.days {
+		if !k.day.After(oldest) {
+			delete(t.days, k)
+		}
+	}
+
+	t.days[key] = uses
+	return uses, nil
+}
+
+// statusTrends calculates trending statuses, scoring
+// each by the number of distinct accounts interacting
+// with it in the window, decaying with its age.
+func (t *Trends) statusTrends(ctx context.Context, now time.Time) ([]*cache.Trend, error) {
+	interactions, err := t.state.DB.CountStatusInteractions(ctx, now.Add(-window))
+	if err != nil {
+		return nil, err
+	}
+
+	trends := make([]*cache.Trend, 0, len(interactions))
+	for _, u := range interactions {
+		if u.Accounts < statusMinAccounts {
+			continue
+		}
+
+		status, err := t.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), u.Target)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting status %s: %v", u.Target, err)
+			}
+			continue
+		}
+
+		trendable, err := t.statusTrendable(ctx, status, now)
+		if err != nil {
+			log.Errorf(ctx, "error checking trendability of status %s: %v", u.Target, err)
+			continue
+		}
+
+		if !trendable {
+			continue
+		}
+
+		age := now.Sub(status.CreatedAt).Hours()
+		trends = append(trends, &cache.Trend{
+			Type:   gtsmodel.TrendTypeStatus,
+			Target: status.ID,
+			Score:  float64(u.Accounts) / math.Pow(max(0, age)+2, 1.5),
+		})
+	}
+
+	return topTrends(trends), nil
+}
+
+// statusTrendable returns whether the given status may trend:
+// it must be a recent, public, non-sensitive original post,
+// by a discoverable, unsilenced account from an instance that isn't limited.
+func (t *Trends) statusTrendable(ctx context.Context, status *gtsmodel.Status, now time.Time) (bool, error) {
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" ||
+		status.InReplyToURI != "" ||
+		*status.Sensitive ||
+		status.ContentWarning != "" ||
+		now.Sub(status.CreatedAt) > statusMaxAge {
+		return false, nil
+	}
+
+	account, err := t.state.DB.GetAccountByID(gtscontext.SetBarebones(ctx), status.AccountID)
+	if err != nil {
+		return false, gtserror.Newf("db error getting status author: %w", err)
+	}
+
+	if !*account.Discoverable || account.IsSuspended() || account.IsSilenced() {
+		return false, nil
+	}
+
+	if account.IsRemote() {
+		limited, err := t.state.DB.IsDomainLimited(ctx, account.Domain)
+		if err != nil {
+			return false, gtserror.Newf("db error checking domain limit: %w", err)
+		}
+
+		if limited {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// enqueueReviews creates pending reviews for any of the
+// given trends that haven't yet been seen by an admin.
+func (t *Trends) enqueueReviews(ctx context.Context, trends []*cache.Trend) error {
+	for _, trend := range trends {
+		_, err := t.state.DB.GetTrendReview(ctx, trend.Type, trend.Target)
+		if err == nil {
+			// Already queued
+			// or reviewed.
+			continue
+		}
+
+		if !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting trend review: %w", err)
+		}
+
+		if err := t.state.DB.PutTrendReview(ctx, &gtsmodel.TrendReview{
+			ID:     id.NewULID(),
+			Type:   trend.Type,
+			Target: trend.Target,
+			State:  gtsmodel.TrendReviewStatePending,
+		}); err != nil {
+			return gtserror.Newf("db error putting trend review: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// topTrends sorts given trends by score, highest
+// first, and truncates them to maxTrends in length.
+func topTrends(trends []*cache.Trend) []*cache.Trend {
+	slices.SortFunc(trends, func(a, b *cache.Trend) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+
+		// Break ties
+		// predictably.
+		return cmp.Compare(a.Target, b.Target)
+	})
+
+	if len(trends) > maxTrends {
+		trends = trends[:maxTrends]
+	}
+
+	return trends
+}
@@ -0,0 +1,308 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/trends"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrendsTestSuite struct {
+	suite.Suite
+
+	db     db.DB
+	state  state.State
+	trends *trends.Trends
+
+	testAccounts map[string]*gtsmodel.Account
+	testTags     map[string]*gtsmodel.Tag
+}
+
+func (suite *TrendsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	suite.db = testrig.NewTestDB(&suite.state)
+	suite.state.DB = suite.db
+	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.trends = trends.New(&suite.state)
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testTags = testrig.NewTestTags()
+}
+
+func (suite *TrendsTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+	testrig.StopWorkers(&suite.state)
+}
+
+// putStatus puts a new public status by the given account,
+// created a minute ago, with given content and tags.
+func (suite *TrendsTestSuite) putStatus(account *gtsmodel.Account, content string, tags ...*gtsmodel.Tag) *gtsmodel.Status {
+	statusID := id.NewULID()
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		CreatedAt:           time.Now().Add(-time.Minute),
+		URI:                 account.URI + "/statuses/" + statusID,
+		URL:                 account.URL + "/statuses/" + statusID,
+		Content:             content,
+		ContentType:         gtsmodel.StatusContentTypePlain,
+		Local:               util.Ptr(account.IsLocal()),
+		AccountURI:          account.URI,
+		AccountID:           account.ID,
+		Visibility:          gtsmodel.VisibilityPublic,
+		Sensitive:           util.Ptr(false),
+		Federated:           util.Ptr(true),
+		ActivityStreamsType: ap.ObjectNote,
+	}
+
+	for _, tag := range tags {
+		status.TagIDs = append(status.TagIDs, tag.ID)
+	}
+
+	if err := suite.db.PutStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+// putFave puts a new fave of the given status by the given account.
+func (suite *TrendsTestSuite) putFave(account *gtsmodel.Account, status *gtsmodel.Status) {
+	faveID := id.NewULID()
+	if err := suite.db.PutStatusFave(context.Background(), &gtsmodel.StatusFave{
+		ID:              faveID,
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		URI:             account.URI + "/faves/" + faveID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// trend returns the cached trend of given type
+// and target, or nil if it isn't trending.
+func (suite *TrendsTestSuite) trend(trendType gtsmodel.TrendType, target string) *cache.Trend {
+	for _, trend := range suite.state.Caches.Trends.Get(trendType) {
+		if trend.Target == target {
+			return trend
+		}
+	}
+	return nil
+}
+
+func (suite *TrendsTestSuite) TestRefreshTags() {
+	ctx := context.Background()
+	tag := suite.testTags["Hashtag"]
+
+	// Used by three discoverable accounts.
+	suite.putStatus(suite.testAccounts["admin_account"], "hello #hashtag", tag)
+	suite.putStatus(suite.testAccounts["local_account_1"], "hi #hashtag", tag)
+	suite.putStatus(suite.testAccounts["local_account_1"], "hi again #hashtag", tag)
+	suite.putStatus(suite.testAccounts["remote_account_1"], "hey #hashtag", tag)
+
+	// Not counted, as account isn't discoverable.
+	suite.putStatus(suite.testAccounts["local_account_2"], "yo #hashtag", tag)
+
+	// Used by one account only.
+	suite.putStatus(suite.testAccounts["local_account_1"], "#welcome", suite.testTags["welcome"])
+
+	if err := suite.trends.Refresh(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	trend := suite.trend(gtsmodel.TrendTypeTag, tag.ID)
+	if suite.NotNil(trend) {
+		suite.Greater(trend.Score, float64(0))
+		suite.Len(trend.History, 7)
+
+		// Tag might have been used either today or yesterday.
+		var uses, accounts int
+		for _, h := range trend.History[:2] {
+			uses += h.Uses
+			accounts += h.Accounts
+		}
+		suite.Equal(4, uses)
+		suite.Equal(3, accounts)
+	}
+
+	suite.Nil(suite.trend(gtsmodel.TrendTypeTag, suite.testTags["welcome"].ID))
+
+	// Trending tag should be queued for review.
+	review, err := suite.db.GetTrendReview(ctx, gtsmodel.TrendTypeTag, tag.ID)
+	if suite.NoError(err) {
+		suite.True(review.IsPending())
+	}
+}
+
+func (suite *TrendsTestSuite) TestRefreshLinks() {
+	ctx := context.Background()
+	link := "https://example.org/some/article"
+	content := `<p>look <a href="` + link + `" rel="nofollow noreferrer noopener" target="_blank">here</a></p>`
+
+	suite.putStatus(suite.testAccounts["admin_account"], content)
+	suite.putStatus(suite.testAccounts["local_account_1"], content)
+	suite.putStatus(suite.testAccounts["remote_account_2"], content)
+
+	if err := suite.trends.Refresh(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	trend := suite.trend(gtsmodel.TrendTypeLink, link)
+	if suite.NotNil(trend) {
+		suite.Greater(trend.Score, float64(0))
+	}
+
+	review, err := suite.db.GetTrendReview(ctx, gtsmodel.TrendTypeLink, link)
+	if suite.NoError(err) {
+		suite.True(review.IsPending())
+	}
+}
+
+func (suite *TrendsTestSuite) TestRefreshStatuses() {
+	ctx := context.Background()
+
+	// Faved by two different accounts.
+	trending := suite.putStatus(suite.testAccounts["local_account_1"], "trending")
+	suite.putFave(suite.testAccounts["admin_account"], trending)
+	suite.putFave(suite.testAccounts["remote_account_1"], trending)
+
+	// Faved by two different accounts,
+	// but author isn't discoverable.
+	undiscoverable := suite.putStatus(suite.testAccounts["local_account_2"], "undiscoverable")
+	suite.putFave(suite.testAccounts["admin_account"], undiscoverable)
+	suite.putFave(suite.testAccounts["remote_account_1"], undiscoverable)
+
+	// Faved by only one account.
+	lonely := suite.putStatus(suite.testAccounts["admin_account"], "lonely")
+	suite.putFave(suite.testAccounts["local_account_1"], lonely)
+
+	if err := suite.trends.Refresh(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotNil(suite.trend(gtsmodel.TrendTypeStatus, trending.ID))
+	suite.Nil(suite.trend(gtsmodel.TrendTypeStatus, undiscoverable.ID))
+	suite.Nil(suite.trend(gtsmodel.TrendTypeStatus, lonely.ID))
+}
+
+func (suite *TrendsTestSuite) TestRefreshKeepsReview() {
+	ctx := context.Background()
+	tag := suite.testTags["Hashtag"]
+
+	// Tag was rejected before it trended.
+	if err := suite.db.PutTrendReview(ctx, &gtsmodel.TrendReview{
+		ID:                  id.NewULID(),
+		Type:                gtsmodel.TrendTypeTag,
+		Target:              tag.ID,
+		State:               gtsmodel.TrendReviewStateRejected,
+		ReviewedByAccountID: suite.testAccounts["admin_account"].ID,
+		ReviewedAt:          time.Now(),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.putStatus(suite.testAccounts["admin_account"], "#hashtag", tag)
+	suite.putStatus(suite.testAccounts["local_account_1"], "#hashtag", tag)
+
+	if err := suite.trends.Refresh(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Tag is still trending, but stays rejected.
+	suite.NotNil(suite.trend(gtsmodel.TrendTypeTag, tag.ID))
+	review, err := suite.db.GetTrendReview(ctx, gtsmodel.TrendTypeTag, tag.ID)
+	if suite.NoError(err) {
+		suite.Equal(gtsmodel.TrendReviewStateRejected, review.State)
+	}
+}
+
+func (suite *TrendsTestSuite) TestRefreshExcludesSilencedAndLimited() {
+	ctx := context.Background()
+	tag := suite.testTags["Hashtag"]
+
+	// Silence local_account_1.
+	silenced := suite.testAccounts["local_account_1"]
+	silenced.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, silenced, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Limit remote_account_1's domain.
+	if err := suite.db.PutDomainBlock(ctx, &gtsmodel.DomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		Severity:           gtsmodel.DomainBlockSeverityLimit,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.putStatus(suite.testAccounts["admin_account"], "hello #hashtag", tag)
+	suite.putStatus(suite.testAccounts["remote_account_2"], "hey #hashtag", tag)
+	suite.putStatus(silenced, "hi #hashtag", tag)
+	suite.putStatus(suite.testAccounts["remote_account_1"], "yo #hashtag", tag)
+
+	// Only uses by the unsilenced,
+	// unlimited accounts are counted.
+	uses, err := suite.db.CountTagUses(ctx, time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	var found bool
+	for _, u := range uses {
+		if u.Target == tag.ID {
+			found = true
+			suite.Equal(2, u.Uses)
+			suite.Equal(2, u.Accounts)
+		}
+	}
+	suite.True(found)
+
+	// Faved by two different accounts,
+	// but author has been silenced.
+	status := suite.putStatus(silenced, "silenced")
+	suite.putFave(suite.testAccounts["admin_account"], status)
+	suite.putFave(suite.testAccounts["remote_account_2"], status)
+
+	if err := suite.trends.Refresh(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Nil(suite.trend(gtsmodel.TrendTypeStatus, status.ID))
+}
+
+func TestTrendsTestSuite(t *testing.T) {
+	suite.Run(t, new(TrendsTestSuite))
+}
@@ -22,6 +22,7 @@
 	"context"
 	"errors"
 	"net/url"
+	"strings"
 	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
@@ -32,6 +33,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/uris"
 	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
 	"github.com/miekg/dns"
 )
 
@@ -415,6 +417,35 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteOfURI
+	// status.QuoteOfID
+	// status.QuoteOf
+	// status.QuoteOfAccountID
+	// status.QuoteOfAccount
+	//
+	// Status that this status quotes, if applicable.
+	// As with inReplyTo, if we don't have this status
+	// in the database we just set the URI for now.
+	if quoteOfURI := ap.ExtractQuoteURI(statusable); quoteOfURI != nil {
+		status.QuoteOfURI = quoteOfURI.String()
+
+		// Check if we already have the quoted status.
+		quoteOf, err := c.state.DB.GetStatusByURI(ctx, status.QuoteOfURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quote %s from db: %w", status.QuoteOfURI, err)
+			return nil, err
+		}
+
+		if quoteOf != nil {
+			// We have it in the DB! Set
+			// appropriate fields here and now.
+			status.QuoteOfID = quoteOf.ID
+			status.QuoteOf = quoteOf
+			status.QuoteOfAccountID = quoteOf.AccountID
+			status.QuoteOfAccount = quoteOf.Account
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
@@ -448,6 +479,7 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// Assume not pending approval; this may
 	// change when permissivity is checked.
 	status.PendingApproval = util.Ptr(false)
+	status.QuotePendingApproval = util.Ptr(false)
 
 	// status.Sensitive
 	sensitive := ap.ExtractSensitive(statusable)
@@ -555,6 +587,75 @@ func (c *Converter) ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gt
 	}, nil
 }
 
+// ASEmojiReactToStatusReaction converts a remote activity streams emoji
+// reaction (ie., a 'like' with content) into a gts model status reaction.
+//
+// If the reaction is a custom emoji, the returned reaction's Emoji will
+// be a placeholder with no ID, which must be dereferenced by the caller.
+func (c *Converter) ASEmojiReactToStatusReaction(ctx context.Context, reactable ap.EmojiReactable) (*gtsmodel.StatusReaction, error) {
+	uriObj := ap.GetJSONLDId(reactable)
+	if uriObj == nil {
+		err := gtserror.New("unusable iri property")
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Stringify uri obj.
+	uri := uriObj.String()
+
+	origin, err := c.getASActorAccount(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := c.getASObjectStatus(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &gtsmodel.StatusReaction{
+		AccountID:       origin.ID,
+		Account:         origin,
+		TargetAccountID: target.AccountID,
+		TargetAccount:   target.Account,
+		StatusID:        target.ID,
+		Status:          target,
+		URI:             uri,
+	}
+
+	content := strings.TrimSpace(ap.ExtractContent(reactable).Content)
+	shortcode, isShortcode := strings.CutPrefix(content, ":")
+	shortcode, isShortcode = strings.CutSuffix(shortcode, ":")
+
+	if !isShortcode {
+		// Should be a plain unicode emoji.
+		if err := validate.UnicodeEmoji(content); err != nil {
+			return nil, gtserror.SetMalformed(err)
+		}
+
+		reaction.Name = content
+		return reaction, nil
+	}
+
+	// Custom emoji, look for
+	// it in the reaction tags.
+	emojis, err := ap.ExtractEmojis(reactable, origin.Domain)
+	if err != nil {
+		err := gtserror.Newf("error extracting emojis for %s: %w", uri, err)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	for _, emoji := range emojis {
+		if emoji.Shortcode == shortcode {
+			reaction.Name = shortcode + "@" + emoji.Domain
+			reaction.Emoji = emoji
+			return reaction, nil
+		}
+	}
+
+	err = gtserror.Newf("no emoji tag for reaction %s on %s", content, uri)
+	return nil, gtserror.SetMalformed(err)
+}
+
 // ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 func (c *Converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error) {
 	uriObj := ap.GetJSONLDId(blockable)
@@ -626,8 +727,9 @@ func (c *Converter) ASAnnounceToStatus(
 	boost.URI = uri
 	isNew = true
 
-	// Get the URI of the boosted status.
-	boostOf := ap.GetObjectIRIs(announceable)
+	// Get the URI of the boosted status,
+	// unwrapping any announced Create.
+	boostOf := ap.ExtractAnnouncedIRIs(announceable)
 	if len(boostOf) == 0 {
 		err := gtserror.Newf("unusable object property iri for %s", uri)
 		return nil, isNew, gtserror.SetMalformed(err)
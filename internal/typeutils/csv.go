@@ -20,6 +20,7 @@
 import (
 	"cmp"
 	"context"
+	"net/url"
 	"slices"
 	"strconv"
 
@@ -66,6 +67,14 @@ func (c *Converter) AccountToExportStats(
 		)
 	}
 
+	bookmarksCount, err := c.state.DB.CountStatusBookmarks(ctx, a.ID)
+	if err != nil {
+		return nil, gtserror.Newf(
+			"error counting bookmarks for account %s: %w",
+			a.ID, err,
+		)
+	}
+
 	return &apimodel.AccountExportStats{
 		FollowersCount: *a.Stats.FollowersCount,
 		FollowingCount: *a.Stats.FollowingCount,
@@ -73,6 +82,7 @@ func (c *Converter) AccountToExportStats(
 		ListsCount:     listsCount,
 		BlocksCount:    blockingCount,
 		MutesCount:     mutingCount,
+		BookmarksCount: bookmarksCount,
 	}, nil
 }
 
@@ -383,6 +393,29 @@ func(a *gtsmodel.UserMute, b *gtsmodel.UserMute) int {
 	return records, nil
 }
 
+// BookmarksToCSV converts a slice of bookmarks into
+// a slice of CSV-compatible bookmarks records.
+//
+// Each bookmark should be populated.
+func (c *Converter) BookmarksToCSV(
+	ctx context.Context,
+	bookmarks []*gtsmodel.StatusBookmark,
+) ([][]string, error) {
+	// NOTE: Mastodon-compatible bookmarks
+	// CSV doesn't use column headers.
+	records := make([][]string, 0, len(bookmarks))
+
+	// For each item, add a record.
+	for _, bookmark := range bookmarks {
+		records = append(records, []string{
+			// Status URI: eg., https://example.org/users/someone/statuses/01J...
+			bookmark.Status.URI,
+		})
+	}
+
+	return records, nil
+}
+
 // CSVToFollowing converts a slice of CSV records
 // to a slice of barebones *gtsmodel.Follow's,
 // ready for further processing.
@@ -646,3 +679,120 @@ func (c *Converter) CSVToMutes(
 
 	return mutes, nil
 }
+
+// CSVToListEntries converts a slice of CSV records
+// to a slice of barebones *gtsmodel.ListEntry's,
+// ready for further processing.
+//
+// Only List.Title, Follow.TargetAccount.Username,
+// and Follow.TargetAccount.Domain will be set on
+// each ListEntry.
+func (c *Converter) CSVToListEntries(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.ListEntry, error) {
+	// We need to know our own domain for this.
+	// Try account domain, fall back to host.
+	var (
+		thisHost          = config.GetHost()
+		thisAccountDomain = config.GetAccountDomain()
+		entries           = make([]*gtsmodel.ListEntry, 0, len(records))
+	)
+
+	for _, record := range records {
+		if len(record) != 2 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "List title"
+		title := record[0]
+		if title == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "Account address"
+		namestring := record[1]
+		if namestring == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// Prepend with "@"
+		// if not included.
+		if namestring[0] != '@' {
+			namestring = "@" + namestring
+		}
+
+		username, domain, err := util.ExtractNamestringParts(namestring)
+		if err != nil {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		if domain == thisHost || domain == thisAccountDomain {
+			// Clear the domain,
+			// since it's ours.
+			domain = ""
+		}
+
+		// Looks good, whack it in the slice.
+		entries = append(entries, &gtsmodel.ListEntry{
+			List: &gtsmodel.List{
+				Title: title,
+			},
+			Follow: &gtsmodel.Follow{
+				TargetAccount: &gtsmodel.Account{
+					Username: username,
+					Domain:   domain,
+				},
+			},
+		})
+	}
+
+	return entries, nil
+}
+
+// CSVToBookmarks converts a slice of CSV records
+// to a slice of barebones *gtsmodel.StatusBookmark's,
+// ready for further processing.
+//
+// Only Status.URI will be set on each StatusBookmark.
+func (c *Converter) CSVToBookmarks(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.StatusBookmark, error) {
+	bookmarks := make([]*gtsmodel.StatusBookmark, 0, len(records))
+
+	for _, record := range records {
+		if len(record) != 1 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "Status URI"
+		uri, err := url.Parse(record[0])
+		if err != nil ||
+			(uri.Scheme != "https" && uri.Scheme != "http") ||
+			uri.Host == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// Looks good, whack it in the slice.
+		bookmarks = append(bookmarks, &gtsmodel.StatusBookmark{
+			Status: &gtsmodel.Status{
+				URI: uri.String(),
+			},
+		})
+	}
+
+	return bookmarks, nil
+}
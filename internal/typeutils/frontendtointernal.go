@@ -176,11 +176,24 @@ func APIInteractionPolicyToInteractionPolicy(
 		return nil, err
 	}
 
+	canQuoteAlways, err := convertURIs(p.CanQuote.Always)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_quote.always: %w", v, err)
+		return nil, err
+	}
+
+	canQuoteWithApproval, err := convertURIs(p.CanQuote.WithApproval)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_quote.with_approval: %w", v, err)
+		return nil, err
+	}
+
 	// Normalize URIs.
 	//
 	// 1. Ensure canLikeAlways, canReplyAlways,
-	//    and canAnnounceAlways include self
-	//    (either explicitly or within public).
+	//    canAnnounceAlways, and canQuoteAlways
+	//    include self (either explicitly or
+	//    within public).
 
 	// ensureIncludesSelf adds the "author" PolicyValue
 	// to given slice of PolicyValues, if not already
@@ -211,6 +224,17 @@ func(uri gtsmodel.PolicyValue) bool {
 	canReplyAlways = ensureIncludesSelf(canReplyAlways)
 	canAnnounceAlways = ensureIncludesSelf(canAnnounceAlways)
 
+	if p.CanQuote.Always == nil && p.CanQuote.WithApproval == nil {
+		// Quote rules weren't provided at all (eg.,
+		// by a client that doesn't know about them),
+		// so use the default for this visibility.
+		canQuote := gtsmodel.DefaultInteractionPolicyFor(visibility).CanQuote
+		canQuoteAlways = slices.Clone(canQuote.Always)
+		canQuoteWithApproval = slices.Clone(canQuote.WithApproval)
+	} else {
+		canQuoteAlways = ensureIncludesSelf(canQuoteAlways)
+	}
+
 	// 2. Ensure canReplyAlways includes mentioned
 	//    accounts (either explicitly or within public).
 	if !slices.ContainsFunc(
@@ -239,6 +263,10 @@ func(uri gtsmodel.PolicyValue) bool {
 			Always:       canAnnounceAlways,
 			WithApproval: canAnnounceWithApproval,
 		},
+		CanQuote: gtsmodel.PolicyRules{
+			Always:       canQuoteAlways,
+			WithApproval: canQuoteWithApproval,
+		},
 	}, nil
 }
 
@@ -151,6 +151,26 @@ func StatusToInteractionRequest(status *gtsmodel.Status) *gtsmodel.InteractionRe
 	}
 }
 
+// StatusQuoteToInteractionRequest returns an interaction
+// request for the given status quoting the status at QuoteOf.
+func StatusQuoteToInteractionRequest(status *gtsmodel.Status) *gtsmodel.InteractionRequest {
+	reqID := id.NewULIDFromTime(status.CreatedAt)
+
+	return &gtsmodel.InteractionRequest{
+		ID:                   reqID,
+		CreatedAt:            status.CreatedAt,
+		StatusID:             status.QuoteOfID,
+		Status:               status.QuoteOf,
+		TargetAccountID:      status.QuoteOfAccountID,
+		TargetAccount:        status.QuoteOfAccount,
+		InteractingAccountID: status.AccountID,
+		InteractingAccount:   status.Account,
+		InteractionURI:       status.QuoteRequestURI(),
+		InteractionType:      gtsmodel.InteractionQuote,
+		Quote:                status,
+	}
+}
+
 func StatusFaveToInteractionRequest(fave *gtsmodel.StatusFave) *gtsmodel.InteractionRequest {
 	reqID := id.NewULIDFromTime(fave.CreatedAt)
 
@@ -24,6 +24,7 @@
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	"code.superseriousbusiness.org/activity/pub"
@@ -597,6 +598,15 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 		}
 		tagProp.AppendTootHashtag(asHashtag)
 	}
+
+	// tag -- quote
+	if s.QuoteOfURI != "" {
+		asQuoteLink, err := quoteToASLink(s.QuoteOfURI)
+		if err != nil {
+			return nil, gtserror.Newf("error converting quote to AS link: %w", err)
+		}
+		tagProp.AppendActivityStreamsLink(asQuoteLink)
+	}
 	status.SetActivityStreamsTag(tagProp)
 
 	// parse out some URIs we need here
@@ -658,6 +668,24 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 			ccProp.AppendIRI(iri)
 		}
 	}
+
+	// If this public or unlisted status quotes a status
+	// by another account, cc the quoted account so they
+	// receive the status (and can approve the quote).
+	if (s.Visibility == gtsmodel.VisibilityPublic ||
+		s.Visibility == gtsmodel.VisibilityUnlocked) &&
+		s.QuoteOfAccount != nil &&
+		s.QuoteOfAccountID != s.AccountID &&
+		!slices.ContainsFunc(mentions, func(m *gtsmodel.Mention) bool {
+			return m.TargetAccountID == s.QuoteOfAccountID
+		}) {
+		iri, err := url.Parse(s.QuoteOfAccount.URI)
+		if err != nil {
+			return nil, gtserror.Newf("error parsing uri %s: %w", s.QuoteOfAccount.URI, err)
+		}
+		ccProp.AppendIRI(iri)
+	}
+
 	status.SetActivityStreamsTo(toProp)
 	status.SetActivityStreamsCc(ccProp)
 
@@ -1005,6 +1033,92 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// RelayToASFollow converts a relay into an activity streams Follow
+// of that relay by our instance actor. For Mastodon-style relays
+// the object of the Follow is the public collection, for LitePub
+// relays it's the relay actor.
+func (c *Converter) RelayToASFollow(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsFollow, error) {
+	instanceAcct, err := c.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	actorIRI, err := url.Parse(instanceAcct.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing instance account uri: %w", err)
+	}
+
+	followIRI, err := url.Parse(r.FollowURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing follow uri: %w", err)
+	}
+
+	objectIRI := ap.PublicURI()
+	if r.Type == gtsmodel.RelayTypeLitePub {
+		objectIRI, err = url.Parse(r.ActorURI)
+		if err != nil {
+			return nil, gtserror.Newf("error parsing relay actor uri: %w", err)
+		}
+	}
+
+	follow := streams.NewActivityStreamsFollow()
+
+	// Set the id.
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(followIRI)
+	follow.SetJSONLDId(idProp)
+
+	// Set the actor.
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorIRI)
+	follow.SetActivityStreamsActor(actorProp)
+
+	// Set the object.
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(objectIRI)
+	follow.SetActivityStreamsObject(objectProp)
+
+	// Address To the object.
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(objectIRI)
+	follow.SetActivityStreamsTo(toProp)
+
+	return follow, nil
+}
+
+// RelayToASUndo converts a relay into an activity streams Undo
+// of the current Follow of that relay by our instance actor.
+func (c *Converter) RelayToASUndo(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsUndo, error) {
+	follow, err := c.RelayToASFollow(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	undoIRI, err := url.Parse(r.FollowURI + "/undo")
+	if err != nil {
+		return nil, gtserror.Newf("error parsing undo uri: %w", err)
+	}
+
+	undo := streams.NewActivityStreamsUndo()
+
+	// Set the id.
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(undoIRI)
+	undo.SetJSONLDId(idProp)
+
+	// Same actor + addressing as the Follow.
+	undo.SetActivityStreamsActor(follow.GetActivityStreamsActor())
+	undo.SetActivityStreamsTo(follow.GetActivityStreamsTo())
+
+	// Embed the whole Follow as object,
+	// as most relays need it to be there.
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendActivityStreamsFollow(follow)
+	undo.SetActivityStreamsObject(objectProp)
+
+	return undo, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
@@ -1257,6 +1371,65 @@ func (c *Converter) FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab
 	return like, nil
 }
 
+// StatusReactionToASEmojiReact converts a gts model status reaction into
+// an activityStreams LIKE with content, suitable for federation. It will
+// be serialized as an EmojiReact, see ap.NormalizeOutgoingEmojiReact.
+func (c *Converter) StatusReactionToASEmojiReact(ctx context.Context, r *gtsmodel.StatusReaction) (vocab.ActivityStreamsLike, error) {
+	// Ensure reaction fully populated.
+	if err := c.state.DB.PopulateStatusReaction(ctx, r); err != nil {
+		return nil, gtserror.Newf("error populating status reaction: %w", err)
+	}
+
+	like := streams.NewActivityStreamsLike()
+
+	// Set actor to the reacting account's URI.
+	actorIRI, err := url.Parse(r.Account.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.Account.URI, err)
+	}
+	ap.AppendActorIRIs(like, actorIRI)
+
+	// Set ID to the reaction's URI.
+	idIRI, err := url.Parse(r.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.URI, err)
+	}
+	ap.SetJSONLDId(like, idIRI)
+
+	// Set object to the target status's URI.
+	statusIRI, err := url.Parse(r.Status.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.Status.URI, err)
+	}
+	ap.AppendObjectIRIs(like, statusIRI)
+
+	// Address to the target account's URI.
+	toIRI, err := url.Parse(r.TargetAccount.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing uri %s: %w", r.TargetAccount.URI, err)
+	}
+	ap.AppendTo(like, toIRI)
+
+	// Set content to the emoji itself.
+	contentProp := streams.NewActivityStreamsContentProperty()
+	contentProp.AppendXMLSchemaString(r.Content())
+	like.SetActivityStreamsContent(contentProp)
+
+	if r.Emoji != nil {
+		// Custom emoji, include in tags.
+		asEmoji, err := c.EmojiToAS(ctx, r.Emoji)
+		if err != nil {
+			return nil, gtserror.Newf("error converting emoji to AS emoji: %w", err)
+		}
+
+		tagProp := streams.NewActivityStreamsTagProperty()
+		tagProp.AppendTootEmoji(asEmoji)
+		like.SetActivityStreamsTag(tagProp)
+	}
+
+	return like, nil
+}
+
 // BoostToAS converts a gts model boost into an activityStreams ANNOUNCE, suitable for federation
 func (c *Converter) BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error) {
 	// the boosted status is probably pinned to the boostWrapperStatus but double check to make sure
@@ -2007,9 +2180,80 @@ func (c *Converter) InteractionPolicyToASInteractionPolicy(
 	canAnnounceProp.AppendGoToSocialCanAnnounce(canAnnounce)
 	policy.SetGoToSocialCanAnnounce(canAnnounceProp)
 
+	/*
+		CAN QUOTE
+	*/
+
+	// Policies stored before quotes were supported
+	// have no canQuote rules; fall back to defaults.
+	canQuoteRules := interactionPolicy.CanQuote
+	if canQuoteRules.IsEmpty() && status != nil {
+		canQuoteRules = gtsmodel.DefaultInteractionPolicyFor(status.Visibility).CanQuote
+	}
+
+	// Build canQuote
+	canQuote := streams.NewGoToSocialCanQuote()
+
+	// Build canQuote.always
+	canQuoteAlwaysProp := streams.NewGoToSocialAlwaysProperty()
+	if err := populateValuesForProp(
+		canQuoteAlwaysProp,
+		status,
+		canQuoteRules.Always,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canQuote.always: %w", err)
+	}
+
+	// Set canQuote.always
+	canQuote.SetGoToSocialAlways(canQuoteAlwaysProp)
+
+	// Build canQuote.approvalRequired
+	canQuoteApprovalRequiredProp := streams.NewGoToSocialApprovalRequiredProperty()
+	if err := populateValuesForProp(
+		canQuoteApprovalRequiredProp,
+		status,
+		canQuoteRules.WithApproval,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canQuote.approvalRequired: %w", err)
+	}
+
+	// Set canQuote.approvalRequired.
+	canQuote.SetGoToSocialApprovalRequired(canQuoteApprovalRequiredProp)
+
+	// Set canQuote on the policy.
+	canQuoteProp := streams.NewGoToSocialCanQuoteProperty()
+	canQuoteProp.AppendGoToSocialCanQuote(canQuote)
+	policy.SetGoToSocialCanQuote(canQuoteProp)
+
 	return policy, nil
 }
 
+// quoteToASLink returns a FEP-e232 object link
+// to the given quoted status URI, suitable for
+// including in the tag property of a status.
+func quoteToASLink(quoteOfURI string) (vocab.ActivityStreamsLink, error) {
+	href, err := url.Parse(quoteOfURI)
+	if err != nil {
+		return nil, err
+	}
+
+	link := streams.NewActivityStreamsLink()
+
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(href)
+	link.SetActivityStreamsHref(hrefProp)
+
+	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
+	mediaTypeProp.Set(`application/ld+json; profile="https://www.w3.org/ns/activitystreams"`)
+	link.SetActivityStreamsMediaType(mediaTypeProp)
+
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("RE: " + quoteOfURI)
+	link.SetActivityStreamsName(nameProp)
+
+	return link, nil
+}
+
 // InteractionReqToASAccept converts a *gtsmodel.InteractionRequest
 // to an ActivityStreams Accept, addressed to the interacting account.
 func (c *Converter) InteractionReqToASAccept(
@@ -2028,7 +2272,7 @@ func (c *Converter) InteractionReqToASAccept(
 		return nil, gtserror.Newf("invalid account uri: %w", err)
 	}
 
-	objectIRI, err := url.Parse(req.InteractionURI)
+	objectIRI, err := url.Parse(req.ObjectURI())
 	if err != nil {
 		return nil, gtserror.Newf("invalid object uri: %w", err)
 	}
@@ -2087,6 +2331,10 @@ func (c *Converter) InteractionReqToASAccept(
 	case gtsmodel.InteractionAnnounce:
 		// Accept of announce gets cc'd.
 		cc = true
+
+	case gtsmodel.InteractionQuote:
+		// Accept of quote gets cc'd.
+		cc = true
 	}
 
 	if cc {
@@ -2124,7 +2372,7 @@ func (c *Converter) InteractionReqToASReject(
 		return nil, gtserror.Newf("invalid account uri: %w", err)
 	}
 
-	objectIRI, err := url.Parse(req.InteractionURI)
+	objectIRI, err := url.Parse(req.ObjectURI())
 	if err != nil {
 		return nil, gtserror.Newf("invalid object uri: %w", err)
 	}
@@ -2183,6 +2431,10 @@ func (c *Converter) InteractionReqToASReject(
 	case gtsmodel.InteractionAnnounce:
 		// Reject of announce gets cc'd.
 		cc = true
+
+	case gtsmodel.InteractionQuote:
+		// Reject of quote gets cc'd.
+		cc = true
 	}
 
 	if cc {
@@ -548,6 +548,12 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -630,6 +636,12 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -730,6 +742,12 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -823,6 +841,12 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
       ],
       "approvalRequired": []
     },
+    "canQuote": {
+      "always": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ],
+      "approvalRequired": []
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
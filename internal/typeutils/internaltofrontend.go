@@ -23,6 +23,7 @@
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
 	"slices"
 	"strconv"
 	"strings"
@@ -106,6 +107,102 @@ func (c *Converter) UserToAPIUser(ctx context.Context, u *gtsmodel.User) *apimod
 	return user
 }
 
+// WebAuthnCredentialToAPIWebAuthnCredential converts a gts
+// model WebAuthn credential into its api (frontend) representation.
+func (c *Converter) WebAuthnCredentialToAPIWebAuthnCredential(cred *gtsmodel.WebAuthnCredential) *apimodel.WebAuthnCredential {
+	apiCred := &apimodel.WebAuthnCredential{
+		ID:           cred.ID,
+		Name:         cred.Name,
+		CreatedAt:    util.FormatISO8601(cred.CreatedAt),
+		Passwordless: util.PtrOrZero(cred.Passwordless),
+		Transports:   cred.Transports,
+	}
+
+	if !cred.LastUsedAt.IsZero() {
+		apiCred.LastUsedAt = util.FormatISO8601(cred.LastUsedAt)
+	}
+
+	if apiCred.Transports == nil {
+		apiCred.Transports = []string{}
+	}
+
+	return apiCred
+}
+
+// ExternalIdentityToAdminAPIExternalIdentity converts a gts model
+// external identity into its admin api (frontend) representation.
+func (c *Converter) ExternalIdentityToAdminAPIExternalIdentity(identity *gtsmodel.ExternalIdentity) *apimodel.AdminExternalIdentity {
+	apiIdentity := &apimodel.AdminExternalIdentity{
+		ID:           identity.ID,
+		Provider:     identity.Provider,
+		Subject:      identity.Subject,
+		Email:        identity.Email,
+		Groups:       identity.Groups,
+		CreatedAt:    util.FormatISO8601(identity.CreatedAt),
+		LastLoginAt:  util.FormatISO8601(identity.LastLoginAt),
+		LastSyncedAt: util.FormatISO8601(identity.LastSyncedAt),
+	}
+
+	if apiIdentity.Groups == nil {
+		apiIdentity.Groups = []string{}
+	}
+
+	return apiIdentity
+}
+
+// InviteToAPIInvite converts a gts model invite into its api
+// (frontend) representation. If forAdmin is true, the account
+// that created the invite will be included in the result.
+func (c *Converter) InviteToAPIInvite(ctx context.Context, invite *gtsmodel.Invite, forAdmin bool) (*apimodel.Invite, error) {
+	apiInvite := &apimodel.Invite{
+		ID:         invite.ID,
+		Code:       invite.Code,
+		URL:        config.GetProtocol() + "://" + config.GetHost() + "/signup?invite=" + url.QueryEscape(invite.Code),
+		CreatedAt:  util.FormatISO8601(invite.CreatedAt),
+		Uses:       invite.Uses,
+		Autofollow: util.PtrOrZero(invite.Autofollow),
+		Valid:      invite.IsValid(),
+	}
+
+	if !invite.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(invite.ExpiresAt)
+		apiInvite.ExpiresAt = &expiresAt
+	}
+
+	if invite.MaxUses != 0 {
+		maxUses := invite.MaxUses
+		apiInvite.MaxUses = &maxUses
+	}
+
+	if invite.IsRevoked() {
+		revokedAt := util.FormatISO8601(invite.RevokedAt)
+		apiInvite.RevokedAt = &revokedAt
+	}
+
+	if forAdmin {
+		if err := c.state.DB.PopulateInvite(ctx, invite); err != nil {
+			return nil, gtserror.Newf("error populating invite: %w", err)
+		}
+
+		account := invite.User.Account
+		if account == nil {
+			var err error
+			account, err = c.state.DB.GetAccountByID(ctx, invite.User.AccountID)
+			if err != nil {
+				return nil, gtserror.Newf("error getting invite account: %w", err)
+			}
+		}
+
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting invite account: %w", err)
+		}
+		apiInvite.Account = apiAccount
+	}
+
+	return apiInvite, nil
+}
+
 // AccountToAPIAccountSensitive takes a db model application as a param, and returns a populated apitype application, or an error
 // if something goes wrong. The returned application should be ready to serialize on an API level, and may have sensitive fields
 // (such as client id and client secret), so serve it only to an authorized user who should have permission to see it.
@@ -343,14 +440,16 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	} else {
 		// This is a local account, try to
 		// fetch more info. Skip for instance
-		// accounts since they have no user.
+		// and group accounts as they have no user.
 		if !a.IsInstance() {
-			user, err := c.state.DB.GetUserByAccountID(ctx, a.ID)
-			if err != nil {
-				return nil, gtserror.Newf("error getting user from database for account id %s: %w", a.ID, err)
-			}
-			if role := c.UserToAPIAccountDisplayRole(user); role != nil {
-				roles = append(roles, *role)
+			if !a.IsGroup() {
+				user, err := c.state.DB.GetUserByAccountID(ctx, a.ID)
+				if err != nil {
+					return nil, gtserror.Newf("error getting user from database for account id %s: %w", a.ID, err)
+				}
+				if role := c.UserToAPIAccountDisplayRole(user); role != nil {
+					roles = append(roles, *role)
+				}
 			}
 
 			enableRSS = *a.Settings.EnableRSS
@@ -401,7 +500,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		EnableRSS:         enableRSS,
 		HideCollections:   hideCollections,
 		Roles:             roles,
-		Group:             false,
+		Group:             a.IsGroup(),
 	}
 
 	// Bodge default avatar + header in,
@@ -503,8 +602,8 @@ func (c *Converter) AccountToAPIAccountBlocked(ctx context.Context, a *gtsmodel.
 	} else {
 		// This is a local account, try to
 		// fetch more info. Skip for instance
-		// accounts since they have no user.
-		if !a.IsInstance() {
+		// and group accounts as they have no user.
+		if !a.IsInstance() && !a.IsGroup() {
 			user, err := c.state.DB.GetUserByAccountID(ctx, a.ID)
 			if err != nil {
 				return nil, gtserror.Newf("error getting user from database for account id %s: %w", a.ID, err)
@@ -554,6 +653,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = *c.APIAccountDisplayRoleToAPIAccountRoleSensitive(nil)
 		createdByApplicationID string
+		invitedByAccountID     string
 	)
 
 	if err := c.state.DB.PopulateAccount(ctx, a); err != nil {
@@ -569,9 +669,9 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		}
 
 		domain = &d
-	} else if !a.IsInstance() {
-		// This is a local, non-instance
-		// acct; we can fetch more info.
+	} else if !a.IsInstance() && !a.IsGroup() {
+		// This is a local, non-instance,
+		// non-group acct; we can fetch more info.
 		user, err := c.state.DB.GetUserByAccountID(ctx, a.ID)
 		if err != nil {
 			return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting user from database for account id %s: %w", a.ID, err)
@@ -600,6 +700,19 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			// Signed up with an invite,
+			// so look up who created it.
+			invite, err := c.state.DB.GetInviteByID(ctx, user.InviteID)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting invite %s from database: %w", user.InviteID, err)
+			}
+
+			if invite != nil && invite.User != nil {
+				invitedByAccountID = invite.User.AccountID
+			}
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -621,11 +734,12 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Confirmed:              confirmed,
 		Approved:               approved,
 		Disabled:               disabled,
-		Silenced:               !a.SilencedAt.IsZero(),
+		Sensitized:             a.IsSensitized(),
+		Silenced:               a.IsSilenced(),
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		InvitedByAccountID:     invitedByAccountID,
 	}, nil
 }
 
@@ -822,12 +936,12 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 	return apimodel.Tag{
 		Name: strings.ToLower(t.Name),
 		URL:  uris.URIForTag(t.Name),
-		History: func() *[]any {
+		History: func() *[]apimodel.History {
 			if !stubHistory {
 				return nil
 			}
 
-			h := make([]any, 0)
+			h := make([]apimodel.History, 0)
 			return &h
 		}(),
 		Following: following,
@@ -1126,6 +1240,18 @@ func filterAppliesInContext(filter *gtsmodel.Filter, filterContext statusfilter.
 func (c *Converter) StatusToWebStatus(
 	ctx context.Context,
 	s *gtsmodel.Status,
+) (*apimodel.WebStatus, error) {
+	return c.statusToWebStatus(ctx, s, true)
+}
+
+// statusToWebStatus is the package-internal implementation
+// of StatusToWebStatus that lets the caller choose whether
+// to include the web version of the quoted status (if any),
+// to prevent recursing through chains of quotes.
+func (c *Converter) statusToWebStatus(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	withQuote bool,
 ) (*apimodel.WebStatus, error) {
 	apiStatus, err := c.statusToFrontend(ctx, s,
 		nil,                            // No authed requester.
@@ -1286,9 +1412,96 @@ func (c *Converter) StatusToWebStatus(
 		}
 	}
 
+	// Include quoted status if it's
+	// accepted and visible via the web.
+	if withQuote &&
+		apiStatus.Quote != nil &&
+		apiStatus.Quote.QuotedStatus != nil &&
+		s.QuoteOf != nil {
+		webStatus.QuotedStatus, err = c.statusToWebStatus(ctx, s.QuoteOf, false)
+		if err != nil {
+			return nil, gtserror.Newf("error converting quoted status: %w", err)
+		}
+	}
+
+	// Include emoji reactions, but not
+	// for quoted statuses, to keep the
+	// rendered quote nice and compact.
+	if withQuote {
+		reactions, err := c.state.DB.GetStatusReactions(ctx, s.ID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting reactions: %w", err)
+		}
+
+		webStatus.Reactions, err = c.StatusReactionsToAPIStatusReactions(ctx, reactions, nil)
+		if err != nil {
+			return nil, gtserror.Newf("error converting reactions: %w", err)
+		}
+	}
+
 	return webStatus, nil
 }
 
+// StatusReactionsToAPIStatusReactions groups the given reactions to a
+// status by emoji, in order of first use. If requester is set, it's used
+// to mark groups including their own reaction as 'me', and the accounts
+// that reacted are included in each group. Callers should filter out any
+// reactions by accounts that the requester shouldn't see beforehand.
+func (c *Converter) StatusReactionsToAPIStatusReactions(
+	ctx context.Context,
+	reactions []*gtsmodel.StatusReaction,
+	requester *gtsmodel.Account,
+) ([]*apimodel.StatusReaction, error) {
+	apiReactions := make([]*apimodel.StatusReaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		i := slices.IndexFunc(apiReactions, func(r *apimodel.StatusReaction) bool {
+			return r.Name == reaction.Name
+		})
+
+		if i == -1 {
+			// First reaction
+			// with this name.
+			r := &apimodel.StatusReaction{
+				Name:     reaction.Name,
+				Accounts: []*apimodel.Account{},
+			}
+			if reaction.Emoji != nil {
+				r.URL = reaction.Emoji.ImageURL
+				r.StaticURL = reaction.Emoji.ImageStaticURL
+			}
+
+			apiReactions = append(apiReactions, r)
+			i = len(apiReactions) - 1
+		}
+
+		apiReactions[i].Count++
+		if requester == nil {
+			// No need for
+			// more details.
+			continue
+		}
+
+		if reaction.AccountID == requester.ID {
+			apiReactions[i].Me = true
+		}
+
+		if reaction.Account == nil {
+			// Account isn't set for some reason, just skip.
+			log.Warnf(ctx, "reaction %s had no associated account", reaction.ID)
+			continue
+		}
+
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, reaction.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account %s: %w", reaction.AccountID, err)
+		}
+
+		apiReactions[i].Accounts = append(apiReactions[i].Accounts, apiAccount)
+	}
+
+	return apiReactions, nil
+}
+
 // statusToFrontend is a package internal function for
 // parsing a status into its initial frontend representation.
 //
@@ -1341,11 +1554,110 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Reblogged = apiStatus.Reblog.Reblogged
 		apiStatus.Pinned = apiStatus.Reblog.Pinned
 		apiStatus.Filtered = apiStatus.Reblog.Filtered
+
+		// Set quote of the boosted status, if any.
+		reblog.Quote, err = c.statusQuoteToAPIQuote(ctx,
+			status.BoostOf,
+			requestingAccount,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error converting boosted status quote: %w", err)
+		}
+	}
+
+	// Set quote of the status, if any.
+	apiStatus.Quote, err = c.statusQuoteToAPIQuote(ctx,
+		status,
+		requestingAccount,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status quote: %w", err)
 	}
 
 	return apiStatus, nil
 }
 
+// statusQuoteToAPIQuote converts the quote of the given
+// status (if any) to its frontend representation, returning
+// nil if status is not a quote. The quoted status itself is
+// only included if the quote was accepted and the quoted
+// status is visible to requester, and it is converted
+// without its own quote, to prevent recursion.
+//
+// Requesting account can be nil.
+func (c *Converter) statusQuoteToAPIQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+) (*apimodel.Quote, error) {
+	switch {
+	case !status.IsQuote():
+		// Not a quote,
+		// nothing to do.
+		return nil, nil
+
+	case status.QuoteOfID == "":
+		// Quoted status was never
+		// resolved, or has since
+		// been deleted.
+		return &apimodel.Quote{State: "deleted"}, nil
+
+	case !status.QuoteApproved():
+		// Quote still awaiting
+		// approval from author
+		// of quoted status.
+		return &apimodel.Quote{State: "pending"}, nil
+	}
+
+	quoteOf := status.QuoteOf
+	if quoteOf == nil {
+		// Try to get quoted status from the db.
+		var err error
+		quoteOf, err = c.state.DB.GetStatusByID(ctx, status.QuoteOfID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting quoted status: %w", err)
+		}
+
+		if quoteOf == nil {
+			// Quoted status
+			// has been deleted.
+			return &apimodel.Quote{State: "deleted"}, nil
+		}
+	}
+
+	visible, err := c.visFilter.StatusVisible(ctx, requestingAccount, quoteOf)
+	if err != nil {
+		return nil, gtserror.Newf("error checking quoted status visibility: %w", err)
+	}
+
+	if !visible {
+		// Requester can't
+		// see quoted status.
+		return &apimodel.Quote{State: "unauthorized"}, nil
+	}
+
+	apiQuoted, err := c.baseStatusToFrontend(ctx,
+		quoteOf,
+		requestingAccount,
+		statusfilter.FilterContextNone,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error converting quoted status: %w", err)
+	}
+
+	apiQuoted.Account, err = c.AccountToAPIAccountPublic(ctx, quoteOf.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting quoted status acct: %w", err)
+	}
+
+	return &apimodel.Quote{
+		State:        "accepted",
+		QuotedStatus: apiQuoted,
+	}, nil
+}
+
 // baseStatusToFrontend performs the main logic
 // of statusToFrontend() without handling of boost
 // logic, to prevent *possible* recursion issues.
@@ -1433,7 +1745,7 @@ func (c *Converter) baseStatusToFrontend(
 		CreatedAt:          util.FormatISO8601(s.CreatedAt),
 		InReplyToID:        nil, // Set below.
 		InReplyToAccountID: nil, // Set below.
-		Sensitive:          *s.Sensitive,
+		Sensitive:          *s.Sensitive || (s.Account.IsSensitized() && len(s.AttachmentIDs) > 0),
 		Visibility:         VisToAPIVis(s.Visibility),
 		LocalOnly:          s.IsLocalOnly(),
 		Language:           nil, // Set below.
@@ -1450,7 +1762,7 @@ func (c *Converter) baseStatusToFrontend(
 		Mentions:           apiMentions,
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
-		Card:               nil, // TODO: implement cards
+		Card:               nil, // Set below.
 		Text:               s.Text,
 		ContentType:        ContentTypeToAPIContentType(s.ContentType),
 		InteractionPolicy:  *apiInteractionPolicy,
@@ -1506,6 +1818,10 @@ func (c *Converter) baseStatusToFrontend(
 		}
 	}
 
+	if s.PreviewCard != nil {
+		apiStatus.Card = c.PreviewCardToAPICard(s.PreviewCard)
+	}
+
 	// Status interactions.
 	//
 	if s.BoostOf != nil { //nolint
@@ -1747,6 +2063,19 @@ func InstanceRuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.AdminInstanceRule {
 	}
 }
 
+// RelayToAdminAPIRelay converts a relay into its api equivalent for serving at /api/v1/admin/relays/:id
+func RelayToAdminAPIRelay(r *gtsmodel.Relay) *apimodel.AdminRelay {
+	return &apimodel.AdminRelay{
+		ID:        r.ID,
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+		UpdatedAt: util.FormatISO8601(r.UpdatedAt),
+		Type:      r.Type.String(),
+		InboxURL:  r.InboxURI,
+		ActorURL:  r.ActorURI,
+		State:     r.State.String(),
+	}
+}
+
 // InstanceToAPIV1Instance converts a gts instance into its api equivalent for serving at /api/v1/instance
 func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV1, error) {
 	domain := i.Domain
@@ -1768,8 +2097,8 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Version:              config.GetSoftwareVersion(),
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
-		ApprovalRequired:     true,                               // approval always required
-		InvitesEnabled:       false,                              // todo: not supported yet
+		ApprovalRequired:     true, // approval always required
+		InvitesEnabled:       config.GetAccountsAllowUserInvites(),
 		MaxTootChars:         uint(config.GetStatusesMaxChars()), // #nosec G115 -- Already validated.
 		Rules:                InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
@@ -2109,13 +2438,37 @@ func (c *Converter) NotificationToAPINotification(
 		apiStatus = apiStatus.Reblog.Status
 	}
 
-	return &apimodel.Notification{
+	apiNotif := &apimodel.Notification{
 		ID:        n.ID,
 		Type:      n.NotificationType.String(),
 		CreatedAt: util.FormatISO8601(n.CreatedAt),
 		Account:   apiAccount,
 		Status:    apiStatus,
-	}, nil
+	}
+
+	if n.NotificationType == gtsmodel.NotificationReaction {
+		// Include the emoji of the most recent
+		// reaction by origin account to the status.
+		reactions, err := c.state.DB.GetStatusReactions(ctx, n.StatusID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting reactions for status %s: %w", n.StatusID, err)
+		}
+
+		for i := len(reactions) - 1; i >= 0; i-- {
+			reaction := reactions[i]
+			if reaction.AccountID != n.OriginAccountID {
+				continue
+			}
+
+			apiNotif.Emoji = reaction.Content()
+			if reaction.Emoji != nil {
+				apiNotif.EmojiURL = reaction.Emoji.ImageURL
+			}
+			break
+		}
+	}
+
+	return apiNotif, nil
 }
 
 // ConversationToAPIConversation converts a conversation into its API representation.
@@ -2232,12 +2585,45 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 		},
 	}
 
+	// Get block severity + media
+	// policy from block or block draft.
+	var (
+		severity       gtsmodel.DomainBlockSeverity
+		rejectMedia    *bool
+		sensitizeMedia *bool
+	)
+	switch dp := d.(type) {
+	case *gtsmodel.DomainBlock:
+		severity = dp.Severity
+		rejectMedia = dp.RejectMedia
+		sensitizeMedia = dp.SensitizeMedia
+	case *gtsmodel.DomainPermissionDraft:
+		if dp.PermissionType == gtsmodel.DomainPermissionBlock {
+			severity = dp.Severity
+			rejectMedia = dp.RejectMedia
+			sensitizeMedia = dp.SensitizeMedia
+		}
+	}
+
 	// If we're exporting, provide
-	// only bare minimum detail.
+	// only bare minimum detail,
+	// plus severity for limits
+	// so they can be reimported.
 	if export {
+		if severity == gtsmodel.DomainBlockSeverityLimit {
+			domainPerm.Severity = severity.String()
+			domainPerm.RejectMedia = rejectMedia
+			domainPerm.SensitizeMedia = sensitizeMedia
+		}
 		return domainPerm, nil
 	}
 
+	if severity != gtsmodel.DomainBlockSeverityUnknown {
+		domainPerm.Severity = severity.String()
+		domainPerm.RejectMedia = rejectMedia
+		domainPerm.SensitizeMedia = sensitizeMedia
+	}
+
 	domainPerm.ID = d.GetID()
 	domainPerm.Obfuscate = d.GetObfuscate()
 	domainPerm.PrivateComment = util.Ptr(d.GetPrivateComment())
@@ -2609,6 +2995,34 @@ func (c *Converter) PollToAPIPoll(ctx context.Context, requester *gtsmodel.Accou
 	}, nil
 }
 
+// PreviewCardToAPICard converts a gts model preview card into its api (frontend) representation.
+func (c *Converter) PreviewCardToAPICard(card *gtsmodel.PreviewCard) *apimodel.Card {
+	apiCard := &apimodel.Card{
+		URL:          card.URL,
+		Title:        card.Title,
+		Description:  card.Description,
+		Type:         card.Type.String(),
+		AuthorName:   card.AuthorName,
+		AuthorURL:    card.AuthorURL,
+		ProviderName: card.ProviderName,
+		ProviderURL:  card.ProviderURL,
+		HTML:         card.HTML,
+		Width:        card.Width,
+		Height:       card.Height,
+		EmbedURL:     card.EmbedURL,
+	}
+
+	// Only serve the image if we
+	// have it cached, we never want
+	// clients to fetch it from remote.
+	if util.PtrOrZero(card.Cached) {
+		apiCard.Image = card.ImageURL
+		apiCard.Blurhash = card.Blurhash
+	}
+
+	return apiCard
+}
+
 // convertAttachmentsToAPIAttachments will convert a slice of GTS model attachments to frontend API model attachments, falling back to IDs if no GTS models supplied.
 func (c *Converter) convertAttachmentsToAPIAttachments(ctx context.Context, attachments []*gtsmodel.MediaAttachment, attachmentIDs []string) ([]*apimodel.Attachment, error) {
 	var errs gtserror.MultiError
@@ -2892,6 +3306,20 @@ func (c *Converter) InteractionPolicyToAPIInteractionPolicy(
 		},
 	}
 
+	// Policies created before quote rules
+	// were introduced won't have any, so
+	// fall back to the default for status
+	// visibility, if status is provided.
+	canQuote := policy.CanQuote
+	if canQuote.IsEmpty() && status != nil {
+		canQuote = gtsmodel.DefaultInteractionPolicyFor(status.Visibility).CanQuote
+	}
+
+	apiPolicy.CanQuote = apimodel.PolicyRules{
+		Always:       policyValsToAPIPolicyVals(canQuote.Always),
+		WithApproval: policyValsToAPIPolicyVals(canQuote.WithApproval),
+	}
+
 	if status == nil || requester == nil {
 		// We're done here!
 		return apiPolicy, nil
@@ -2961,6 +3389,26 @@ func (c *Converter) InteractionPolicyToAPIInteractionPolicy(
 		)
 	}
 
+	quotable, err := c.intFilter.StatusQuotable(ctx, requester, status)
+	if err != nil {
+		err := gtserror.Newf("error checking status quotable by requester: %w", err)
+		return nil, err
+	}
+
+	if quotable.Permission == gtsmodel.PolicyPermissionPermitted {
+		// We can do this!
+		apiPolicy.CanQuote.Always = append(
+			apiPolicy.CanQuote.Always,
+			apimodel.PolicyValueMe,
+		)
+	} else if quotable.Permission == gtsmodel.PolicyPermissionWithApproval {
+		// We can do this with approval.
+		apiPolicy.CanQuote.WithApproval = append(
+			apiPolicy.CanQuote.WithApproval,
+			apimodel.PolicyValueMe,
+		)
+	}
+
 	return apiPolicy, nil
 }
 
@@ -3095,6 +3543,24 @@ func (c *Converter) InteractionReqToAPIInteractionReq(
 		}
 	}
 
+	var quote *apimodel.Status
+	if req.InteractionType == gtsmodel.InteractionQuote && req.Quote != nil {
+		quote, err = c.statusToAPIStatus(
+			ctx,
+			req.Quote,
+			requestingAcct,
+			statusfilter.FilterContextNone,
+			nil,  // No filters.
+			nil,  // No mutes.
+			true, // Placehold unknown attachments.
+			false,
+		)
+		if err != nil {
+			err := gtserror.Newf("error converting quote: %w", err)
+			return nil, err
+		}
+	}
+
 	var acceptedAt string
 	if req.IsAccepted() {
 		acceptedAt = util.FormatISO8601(req.AcceptedAt)
@@ -3112,6 +3578,7 @@ func (c *Converter) InteractionReqToAPIInteractionReq(
 		Account:    interactingAcct,
 		Status:     interactedStatus,
 		Reply:      reply,
+		Quote:      quote,
 		AcceptedAt: acceptedAt,
 		RejectedAt: rejectedAt,
 		URI:        req.URI,
@@ -3159,6 +3626,9 @@ func (c *Converter) WebPushSubscriptionToAPIWebPushSubscription(
 			PendingFavourite: subscription.NotificationFlags.Get(gtsmodel.NotificationPendingFave),
 			PendingReply:     subscription.NotificationFlags.Get(gtsmodel.NotificationPendingReply),
 			PendingReblog:    subscription.NotificationFlags.Get(gtsmodel.NotificationPendingReblog),
+			Quote:            subscription.NotificationFlags.Get(gtsmodel.NotificationQuote),
+			PendingQuote:     subscription.NotificationFlags.Get(gtsmodel.NotificationPendingQuote),
+			Reaction:         subscription.NotificationFlags.Get(gtsmodel.NotificationReaction),
 		},
 		Policy:   webPushNotificationPolicyToAPIWebPushNotificationPolicy(subscription.Policy),
 		Standard: true,
@@ -3200,3 +3670,225 @@ func (c *Converter) TokenToAPITokenInfo(
 		Application: apiApplication,
 	}, nil
 }
+
+// ScheduledStatusToAPIScheduledStatus converts a scheduled
+// status into its API model representation, fetching and
+// converting any attached media that's not yet populated.
+func (c *Converter) ScheduledStatusToAPIScheduledStatus(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, error) {
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(
+		ctx,
+		scheduledStatus.MediaAttachments,
+		scheduledStatus.MediaIDs,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error converting scheduled status attachments: %v", err)
+	}
+
+	var apiPoll *apimodel.ScheduledStatusParamsPoll
+	if scheduledStatus.Poll != nil {
+		apiPoll = &apimodel.ScheduledStatusParamsPoll{
+			Options:    scheduledStatus.Poll.Options,
+			ExpiresIn:  scheduledStatus.Poll.ExpiresIn,
+			Multiple:   scheduledStatus.Poll.Multiple,
+			HideTotals: scheduledStatus.Poll.HideTotals,
+		}
+	}
+
+	var apiPolicy *apimodel.InteractionPolicy
+	if scheduledStatus.InteractionPolicy != nil {
+		apiPolicy, err = c.InteractionPolicyToAPIInteractionPolicy(
+			ctx,
+			scheduledStatus.InteractionPolicy,
+			nil,
+			nil,
+		)
+		if err != nil {
+			err := gtserror.Newf("error converting interaction policy: %w", err)
+			return nil, err
+		}
+	}
+
+	mediaIDs := scheduledStatus.MediaIDs
+	if mediaIDs == nil {
+		// Serialize as empty array.
+		mediaIDs = []string{}
+	}
+
+	return &apimodel.ScheduledStatus{
+		ID:          scheduledStatus.ID,
+		ScheduledAt: util.FormatISO8601(scheduledStatus.ScheduledAt),
+		Params: &apimodel.StatusParams{
+			Text:              scheduledStatus.Text,
+			Poll:              apiPoll,
+			MediaIDs:          mediaIDs,
+			Sensitive:         util.PtrOrZero(scheduledStatus.Sensitive),
+			SpoilerText:       scheduledStatus.SpoilerText,
+			Visibility:        VisToAPIVis(scheduledStatus.Visibility),
+			InReplyToID:       scheduledStatus.InReplyToID,
+			QuotedStatusID:    scheduledStatus.QuotedStatusID,
+			Language:          scheduledStatus.Language,
+			ApplicationID:     scheduledStatus.ApplicationID,
+			LocalOnly:         util.PtrOrZero(scheduledStatus.LocalOnly),
+			ContentType:       ContentTypeToAPIContentType(scheduledStatus.ContentType),
+			InteractionPolicy: apiPolicy,
+		},
+		MediaAttachments: apiAttachments,
+	}, nil
+}
+
+// AnnouncementToAPIAnnouncement converts a gts model announcement
+// into its api (frontend) representation for serialization on the API.
+//
+// If requester is nil, read state and reactions will be given
+// without reference to any particular account, eg., for streaming.
+func (c *Converter) AnnouncementToAPIAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	requester *gtsmodel.Account,
+) (*apimodel.Announcement, error) {
+	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx,
+		announcement.Emojis,
+		announcement.EmojiIDs,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement emojis: %v", err)
+	}
+
+	apiTags, err := c.convertTagsToAPITags(ctx,
+		announcement.Tags,
+		announcement.TagIDs,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement tags: %v", err)
+	}
+
+	var read bool
+	if requester != nil {
+		read, err = c.state.DB.IsAnnouncementRead(ctx,
+			announcement.ID,
+			requester.ID,
+		)
+		if err != nil {
+			err := gtserror.Newf("error checking announcement read: %w", err)
+			return nil, err
+		}
+	}
+
+	reactions, err := c.state.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil {
+		err := gtserror.Newf("error getting announcement reactions: %w", err)
+		return nil, err
+	}
+
+	// Group reactions by name, in order of first use.
+	apiReactions := make([]apimodel.AnnouncementReaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		i := slices.IndexFunc(apiReactions, func(r apimodel.AnnouncementReaction) bool {
+			return r.Name == reaction.Name
+		})
+
+		if i == -1 {
+			// First reaction
+			// with this name.
+			r := apimodel.AnnouncementReaction{Name: reaction.Name}
+			if reaction.Emoji != nil {
+				r.URL = reaction.Emoji.ImageURL
+				r.StaticURL = reaction.Emoji.ImageStaticURL
+			}
+
+			apiReactions = append(apiReactions, r)
+			i = len(apiReactions) - 1
+		}
+
+		apiReactions[i].Count++
+		if requester != nil && reaction.AccountID == requester.ID {
+			apiReactions[i].Me = true
+		}
+	}
+
+	// formatTime formats non-zero times,
+	// leaving unset times as empty strings.
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return util.FormatISO8601(t)
+	}
+
+	return &apimodel.Announcement{
+		ID:          announcement.ID,
+		Content:     announcement.Content,
+		StartsAt:    formatTime(announcement.StartsAt),
+		EndsAt:      formatTime(announcement.EndsAt),
+		AllDay:      *announcement.AllDay,
+		PublishedAt: formatTime(announcement.PublishedAt),
+		UpdatedAt:   util.FormatISO8601(announcement.UpdatedAt),
+		Published:   *announcement.Published,
+		Read:        read,
+		Mentions:    []apimodel.Mention{},
+		Statuses:    []apimodel.Status{},
+		Tags:        apiTags,
+		Emojis:      apiEmojis,
+		Reactions:   apiReactions,
+	}, nil
+}
+
+// AccountArchiveToAPIAccountArchive converts a gts model
+// account archive into its api (frontend) representation.
+func (c *Converter) AccountArchiveToAPIAccountArchive(
+	archive *gtsmodel.AccountArchive,
+) *apimodel.AccountArchive {
+	apiArchive := &apimodel.AccountArchive{
+		ID:        archive.ID,
+		CreatedAt: util.FormatISO8601(archive.CreatedAt),
+		ExpiresAt: util.FormatISO8601(archive.ExpiresAt()),
+	}
+
+	switch {
+	case archive.Pending():
+		apiArchive.State = "pending"
+
+	case archive.Ready():
+		apiArchive.State = "ready"
+		apiArchive.Size = archive.Size
+		apiArchive.URL = config.GetProtocol() + "://" + config.GetHost() +
+			"/api/v1/exports/archive/" + archive.ID
+
+	default:
+		apiArchive.State = "failed"
+	}
+
+	return apiArchive
+}
+
+// OutboxImportToAPIOutboxImport converts a gts model
+// outbox import into its api (frontend) representation.
+func (c *Converter) OutboxImportToAPIOutboxImport(
+	outboxImport *gtsmodel.OutboxImport,
+) *apimodel.OutboxImport {
+	apiImport := &apimodel.OutboxImport{
+		ID:        outboxImport.ID,
+		CreatedAt: util.FormatISO8601(outboxImport.CreatedAt),
+		UpdatedAt: util.FormatISO8601(outboxImport.UpdatedAt),
+		Federate:  *outboxImport.Federate,
+		Total:     outboxImport.Total,
+		Imported:  outboxImport.Imported,
+		Skipped:   outboxImport.Skipped,
+	}
+
+	switch {
+	case *outboxImport.Failed || outboxImport.Stale():
+		apiImport.State = "failed"
+
+	case outboxImport.Finished():
+		apiImport.State = "finished"
+
+	default:
+		apiImport.State = "processing"
+	}
+
+	return apiImport
+}
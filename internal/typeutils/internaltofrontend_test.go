@@ -602,6 +602,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -750,6 +757,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendHTMLContentWarning
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -900,6 +914,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendApplicationDeleted
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1107,6 +1128,13 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1293,6 +1321,13 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   },
@@ -1385,6 +1420,13 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1684,6 +1726,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1760,6 +1809,12 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
         "public"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public"
+      ],
+      "with_approval": []
     }
   },
   "account": {
@@ -2004,6 +2059,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -2100,6 +2162,12 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendPartialInteraction
         "author"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "author"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -2227,6 +2295,13 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIStatusPendingApproval()
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "always": [
+        "public",
+        "me"
+      ],
+      "with_approval": []
     }
   }
 }
@@ -2770,6 +2845,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2817,6 +2893,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2871,12 +2948,13 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "id": "admin",
       "name": "admin",
       "color": "",
-      "permissions": "546033",
+      "permissions": "554225",
       "highlighted": true
     },
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2927,12 +3005,13 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "id": "admin",
       "name": "admin",
       "color": "",
-      "permissions": "546033",
+      "permissions": "554225",
       "highlighted": true
     },
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -3012,6 +3091,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -3072,6 +3152,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -3205,6 +3286,13 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
             "me"
           ],
           "with_approval": []
+        },
+        "can_quote": {
+          "always": [
+            "public",
+            "me"
+          ],
+          "with_approval": []
         }
       }
     }
@@ -3282,6 +3370,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -3329,6 +3418,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": true,
     "account": {
@@ -3372,12 +3462,13 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "id": "admin",
       "name": "admin",
       "color": "",
-      "permissions": "546033",
+      "permissions": "554225",
       "highlighted": true
     },
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -3428,12 +3519,13 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "id": "admin",
       "name": "admin",
       "color": "",
-      "permissions": "546033",
+      "permissions": "554225",
       "highlighted": true
     },
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -3709,6 +3801,13 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   },
@@ -3806,6 +3905,13 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   }
@@ -3966,6 +4072,13 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPISelfConvo() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   }
@@ -4136,6 +4249,13 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "always": [
+          "public",
+          "me"
+        ],
+        "with_approval": []
       }
     }
   }
@@ -21,6 +21,8 @@
 	"errors"
 	"fmt"
 	"net/mail"
+	"strings"
+	"unicode"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
@@ -46,6 +48,8 @@
 	maximumListTitleLength        = 200
 	maximumFilterKeywordLength    = 40
 	maximumFilterTitleLength      = 200
+	maximumAnnouncementLength     = 5000
+	maximumUnicodeEmojiRunes      = 16
 )
 
 // Password returns a helpful error if the given password
@@ -209,6 +213,42 @@ func EmojiShortcode(shortcode string) error {
 	return nil
 }
 
+// UnicodeEmoji checks that the given string looks like a single unicode
+// emoji, including any modifiers, variation selectors, and joiners.
+func UnicodeEmoji(emoji string) error {
+	if emoji == "" {
+		return errors.New("emoji must be provided")
+	}
+
+	runes := []rune(emoji)
+	if len(runes) > maximumUnicodeEmojiRunes {
+		return fmt.Errorf("emoji %s did not pass validation, must be no more than %d codepoints", emoji, maximumUnicodeEmojiRunes)
+	}
+
+	// First rune must be a symbol (pictograph, regional
+	// indicator etc), or a keycap base followed by a keycap.
+	if !unicode.Is(unicode.So, runes[0]) &&
+		(!strings.ContainsRune("#*0123456789", runes[0]) ||
+			!strings.ContainsRune(emoji, '\u20e3')) {
+		return fmt.Errorf("emoji %s did not pass validation, must be a unicode emoji", emoji)
+	}
+
+	for _, r := range runes[1:] {
+		switch {
+		case unicode.Is(unicode.So, r), // pictographs, regional indicators
+			unicode.Is(unicode.Sk, r),      // skin tone modifiers
+			unicode.Is(unicode.Me, r),      // combining enclosing keycap
+			r == '\u200d',                  // zero width joiner
+			r == '\ufe0e' || r == '\ufe0f', // variation selectors
+			r >= 0xe0020 && r <= 0xe007f:   // tag sequences
+			continue
+		}
+		return fmt.Errorf("emoji %s did not pass validation, must be a unicode emoji", emoji)
+	}
+
+	return nil
+}
+
 // EmojiCategory validates the length of the given category string.
 func EmojiCategory(category string) error {
 	if length := len(category); length > maximumEmojiCategoryLength {
@@ -253,6 +293,19 @@ func SiteTerms(t string) error {
 	return nil
 }
 
+// AnnouncementText ensures that the given announcement text is within spec.
+func AnnouncementText(t string) error {
+	if t == "" {
+		return errors.New("announcement text must be provided")
+	}
+
+	if length := len([]rune(t)); length > maximumAnnouncementLength {
+		return fmt.Errorf("announcement text should be no more than %d chars but given text was %d", maximumAnnouncementLength, length)
+	}
+
+	return nil
+}
+
 // ULID returns an error if the passed string is not a valid ULID.
 // The name param is used to form error messages.
 func ULID(i string, name string) error {
@@ -404,7 +457,12 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("form was nil")
 	}
 
-	if !config.GetAccountsRegistrationOpen() {
+	// Invites let people sign up even
+	// when registration is closed. The
+	// invite itself is checked later on.
+	invited := form.InviteCode != ""
+
+	if !config.GetAccountsRegistrationOpen() && !invited {
 		return errors.New("registration is not open for this server")
 	}
 
@@ -385,6 +385,61 @@ type testStruct struct {
 	}
 }
 
+func (suite *ValidationTestSuite) TestValidateUnicodeEmoji() {
+	for _, test := range []struct {
+		emoji string
+		ok    bool
+	}{
+		{
+			emoji: "👍",
+			ok:    true,
+		},
+		{
+			// Skin tone modifier.
+			emoji: "👍🏽",
+			ok:    true,
+		},
+		{
+			// ZWJ sequence.
+			emoji: "🏳️‍🌈",
+			ok:    true,
+		},
+		{
+			// Keycap.
+			emoji: "1️⃣",
+			ok:    true,
+		},
+		{
+			emoji: "",
+			ok:    false,
+		},
+		{
+			emoji: "hello",
+			ok:    false,
+		},
+		{
+			// Keycap base without keycap.
+			emoji: "1",
+			ok:    false,
+		},
+		{
+			emoji: "👍 👍",
+			ok:    false,
+		},
+		{
+			// Too long.
+			emoji: "👍👍👍👍👍👍👍👍👍👍👍👍👍👍👍👍👍",
+			ok:    false,
+		},
+	} {
+		err := validate.UnicodeEmoji(test.emoji)
+		ok := err == nil
+		if !suite.Equal(test.ok, ok) {
+			suite.T().Logf("fail on %s", test.emoji)
+		}
+	}
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
@@ -21,6 +21,7 @@
 	"context"
 	"errors"
 	"net"
+	"net/http"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
@@ -30,6 +31,10 @@
 	"github.com/gin-gonic/gin"
 )
 
+// signupInviteKey is the query key
+// for the code of an invite link.
+const signupInviteKey = "invite"
+
 func (m *Module) signupGETHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -53,14 +58,41 @@ func (m *Module) signupGETHandler(c *gin.Context) {
 		return
 	}
 
+	extra := map[string]any{
+		"reasonRequired":   config.GetAccountsReasonRequired(),
+		"registrationOpen": config.GetAccountsRegistrationOpen(),
+	}
+
+	// If signing up with an invite, show
+	// the form even if registration is
+	// closed, along with who sent it.
+	if code := c.Query(signupInviteKey); code != "" {
+		invite, errWithCode := m.processor.User().InviteGetForSignup(ctx, code)
+		switch {
+		case errWithCode == nil:
+			inviter := invite.User.Account
+			extra["inviteCode"] = invite.Code
+			extra["inviter"] = map[string]string{
+				"username":    inviter.Username,
+				"displayName": inviter.DisplayName,
+				"url":         inviter.URL,
+			}
+			extra["registrationOpen"] = true
+
+		case errWithCode.Code() == http.StatusUnprocessableEntity:
+			extra["inviteInvalid"] = true
+
+		default:
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+	}
+
 	page := apiutil.WebPage{
 		Template: "sign-up.tmpl",
 		Instance: instance,
 		OGMeta:   apiutil.OGBase(instance),
-		Extra: map[string]any{
-			"reasonRequired":   config.GetAccountsReasonRequired(),
-			"registrationOpen": config.GetAccountsRegistrationOpen(),
-		},
+		Extra:    extra,
 	}
 
 	apiutil.TemplateWebPage(c, page)
@@ -0,0 +1,285 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webauthn
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// Attestation statement formats,
+// see: https://www.iana.org/assignments/webauthn/webauthn.xhtml
+const (
+	FormatNone    = "none"
+	FormatPacked  = "packed"
+	FormatFIDOU2F = "fido-u2f"
+)
+
+// oidFIDOGenCEAAGUID is the OID of the X.509 extension
+// in packed attestation certificates holding the AAGUID.
+var oidFIDOGenCEAAGUID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 45724, 1, 1, 4}
+
+// attestationObject models a
+// parsed attestation object.
+type attestationObject struct {
+	format   string
+	attStmt  map[any]any
+	authData []byte
+}
+
+// parseAttestationObject parses the given CBOR encoded
+// attestation object, see: https://www.w3.org/TR/webauthn-2/#sctn-attestation
+func parseAttestationObject(b []byte) (*attestationObject, error) {
+	v, rest, err := decodeCBOR(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation object: %w", err)
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after attestation object")
+	}
+
+	m, ok := v.(map[any]any)
+	if !ok {
+		return nil, errors.New("attestation object is not a map")
+	}
+
+	format, ok := m["fmt"].(string)
+	if !ok {
+		return nil, errors.New("attestation object missing fmt")
+	}
+
+	attStmt, ok := m["attStmt"].(map[any]any)
+	if !ok {
+		return nil, errors.New("attestation object missing attStmt")
+	}
+
+	authData, ok := m["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("attestation object missing authData")
+	}
+
+	return &attestationObject{
+		format:   format,
+		attStmt:  attStmt,
+		authData: authData,
+	}, nil
+}
+
+// verifyAttestation verifies the attestation statement in the
+// given attestation object, using only the data provided in it.
+//
+// Certificate chains are NOT validated against any trust anchors,
+// as that would require fetching authenticator metadata; we only
+// check the statement is self-consistent and correctly signed, so
+// that a malformed or tampered registration is rejected. Formats
+// we don't support are treated as if no attestation was provided,
+// as permitted by step 21 of the registration ceremony.
+func verifyAttestation(
+	attObj *attestationObject,
+	authData *authenticatorData,
+	pubKey *PublicKey,
+	clientDataHash []byte,
+) error {
+	switch attObj.format {
+	case FormatNone:
+		if len(attObj.attStmt) != 0 {
+			return errors.New("none attestation with non-empty statement")
+		}
+		return nil
+
+	case FormatPacked:
+		return verifyPacked(attObj, authData, pubKey, clientDataHash)
+
+	case FormatFIDOU2F:
+		return verifyFIDOU2F(attObj, authData, pubKey, clientDataHash)
+
+	default:
+		return nil
+	}
+}
+
+// verifyPacked verifies a "packed" attestation statement,
+// see: https://www.w3.org/TR/webauthn-2/#sctn-packed-attestation
+func verifyPacked(
+	attObj *attestationObject,
+	authData *authenticatorData,
+	pubKey *PublicKey,
+	clientDataHash []byte,
+) error {
+	alg, ok := cborInt(attObj.attStmt["alg"])
+	if !ok {
+		return errors.New("packed attestation missing alg")
+	}
+
+	sig, ok := attObj.attStmt["sig"].([]byte)
+	if !ok {
+		return errors.New("packed attestation missing sig")
+	}
+
+	signed := concat(attObj.authData, clientDataHash)
+
+	x5c, ok := attObj.attStmt["x5c"]
+	if !ok {
+		// Self attestation, signed with
+		// the credential private key.
+		if alg != pubKey.Alg {
+			return errors.New("packed self attestation algorithm mismatch")
+		}
+
+		if err := pubKey.Verify(signed, sig); err != nil {
+			return fmt.Errorf("error verifying packed self attestation: %w", err)
+		}
+
+		return nil
+	}
+
+	cert, err := parseX5C(x5c)
+	if err != nil {
+		return err
+	}
+
+	if err := verifySignature(cert.PublicKey, alg, signed, sig); err != nil {
+		return fmt.Errorf("error verifying packed attestation: %w", err)
+	}
+
+	// Check attestation certificate requirements,
+	// see: https://www.w3.org/TR/webauthn-2/#sctn-packed-attestation-cert-requirements
+	if cert.Version != 3 {
+		return errors.New("packed attestation certificate must be version 3")
+	}
+
+	if cert.BasicConstraintsValid && cert.IsCA {
+		return errors.New("packed attestation certificate must not be a CA")
+	}
+
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidFIDOGenCEAAGUID) {
+			continue
+		}
+
+		if ext.Critical {
+			return errors.New("packed attestation AAGUID extension must not be critical")
+		}
+
+		var aaguid []byte
+		if _, err := asn1.Unmarshal(ext.Value, &aaguid); err != nil {
+			return fmt.Errorf("invalid packed attestation AAGUID extension: %w", err)
+		}
+
+		if !bytes.Equal(aaguid, authData.aaguid) {
+			return errors.New("packed attestation AAGUID mismatch")
+		}
+	}
+
+	return nil
+}
+
+// verifyFIDOU2F verifies a "fido-u2f" attestation statement,
+// see: https://www.w3.org/TR/webauthn-2/#sctn-fido-u2f-attestation
+func verifyFIDOU2F(
+	attObj *attestationObject,
+	authData *authenticatorData,
+	pubKey *PublicKey,
+	clientDataHash []byte,
+) error {
+	sig, ok := attObj.attStmt["sig"].([]byte)
+	if !ok {
+		return errors.New("fido-u2f attestation missing sig")
+	}
+
+	x5c, ok := attObj.attStmt["x5c"]
+	if !ok {
+		return errors.New("fido-u2f attestation missing x5c")
+	}
+
+	cert, err := parseX5C(x5c)
+	if err != nil {
+		return err
+	}
+
+	certKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok || certKey.Curve != elliptic.P256() {
+		return errors.New("fido-u2f attestation certificate must have P-256 key")
+	}
+
+	credKey, ok := pubKey.Key.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("fido-u2f credential must have P-256 key")
+	}
+
+	// Build the raw ANSI X9.62
+	// form of the credential key.
+	x := credKey.X.FillBytes(make([]byte, 32))
+	y := credKey.Y.FillBytes(make([]byte, 32))
+	rawKey := concat([]byte{0x04}, x, y)
+
+	rpIDHash := authData.rpIDHash
+	signed := concat(
+		[]byte{0x00},
+		rpIDHash,
+		clientDataHash,
+		authData.credentialID,
+		rawKey,
+	)
+
+	if err := verifySignature(certKey, AlgES256, signed, sig); err != nil {
+		return fmt.Errorf("error verifying fido-u2f attestation: %w", err)
+	}
+
+	return nil
+}
+
+// parseX5C parses the leaf attestation
+// certificate from the given x5c array.
+func parseX5C(v any) (*x509.Certificate, error) {
+	x5c, ok := v.([]any)
+	if !ok || len(x5c) == 0 {
+		return nil, errors.New("invalid attestation x5c")
+	}
+
+	der, ok := x5c[0].([]byte)
+	if !ok {
+		return nil, errors.New("invalid attestation x5c")
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+// concat concatenates the given byte slices.
+func concat(bs ...[]byte) []byte {
+	var n int
+	for _, b := range bs {
+		n += len(b)
+	}
+	out := make([]byte, 0, n)
+	for _, b := range bs {
+		out = append(out, b...)
+	}
+	return out
+}
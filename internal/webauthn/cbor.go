@@ -0,0 +1,267 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webauthn
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxCBORDepth is the maximum nesting depth
+// of arrays / maps that will be decoded; the
+// structures used by WebAuthn are very shallow.
+const maxCBORDepth = 16
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+// decodeCBOR decodes the first CBOR data item
+// in b, returning the item and remaining bytes.
+//
+// This is a minimal decoder covering only what is
+// needed to parse WebAuthn attestation objects and
+// COSE keys, ie., definite-length items. Values
+// are decoded to: uint64, int64 (negative integers),
+// []byte, string, []any, map[any]any, bool, float64
+// and nil. Tags are skipped, returning tagged item.
+func decodeCBOR(b []byte) (any, []byte, error) {
+	return decodeCBORItem(b, 0)
+}
+
+func decodeCBORItem(b []byte, depth int) (any, []byte, error) {
+	if depth > maxCBORDepth {
+		return nil, nil, errors.New("cbor: maximum nesting depth exceeded")
+	}
+
+	if len(b) == 0 {
+		return nil, nil, errCBORTruncated
+	}
+
+	major := b[0] >> 5
+	info := b[0] & 0x1f
+	b = b[1:]
+
+	if major == 7 {
+		// Simple values and floats.
+		return decodeCBORSimple(info, b)
+	}
+
+	// Read argument for all other major types.
+	arg, b, err := decodeCBORArg(info, b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+
+	// Unsigned integer.
+	case 0:
+		return arg, b, nil
+
+	// Negative integer.
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, nil, errors.New("cbor: negative integer overflow")
+		}
+		return -1 - int64(arg), b, nil // #nosec G115 -- checked above
+
+	// Byte string.
+	case 2:
+		if uint64(len(b)) < arg {
+			return nil, nil, errCBORTruncated
+		}
+		return b[:arg:arg], b[arg:], nil
+
+	// Text string.
+	case 3:
+		if uint64(len(b)) < arg {
+			return nil, nil, errCBORTruncated
+		}
+		return string(b[:arg]), b[arg:], nil
+
+	// Array.
+	case 4:
+		if uint64(len(b)) < arg {
+			// Each item is at least one byte,
+			// so this can't possibly be valid.
+			return nil, nil, errCBORTruncated
+		}
+		arr := make([]any, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			var item any
+			item, b, err = decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			arr = append(arr, item)
+		}
+		return arr, b, nil
+
+	// Map.
+	case 5:
+		if uint64(len(b)) < arg*2 {
+			// Each key and value is at least one
+			// byte, so this can't possibly be valid.
+			return nil, nil, errCBORTruncated
+		}
+		m := make(map[any]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			var key, value any
+			key, b, err = decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch key.(type) {
+			case uint64, int64, string:
+				// Comparable,
+				// usable key.
+			default:
+				return nil, nil, fmt.Errorf("cbor: unsupported map key type %T", key)
+			}
+			value, b, err = decodeCBORItem(b, depth+1)
+			if err != nil {
+				return nil, nil, err
+			}
+			if _, ok := m[key]; ok {
+				return nil, nil, fmt.Errorf("cbor: duplicate map key %v", key)
+			}
+			m[key] = value
+		}
+		return m, b, nil
+
+	// Tag, just return the tagged item.
+	case 6:
+		return decodeCBORItem(b, depth+1)
+
+	default:
+		panic("unreachable")
+	}
+}
+
+// decodeCBORArg decodes the argument of a data
+// item with the given additional information.
+func decodeCBORArg(info byte, b []byte) (uint64, []byte, error) {
+	switch {
+	case info < 24:
+		return uint64(info), b, nil
+	case info == 24:
+		if len(b) < 1 {
+			return 0, nil, errCBORTruncated
+		}
+		return uint64(b[0]), b[1:], nil
+	case info == 25:
+		if len(b) < 2 {
+			return 0, nil, errCBORTruncated
+		}
+		return uint64(binary.BigEndian.Uint16(b)), b[2:], nil
+	case info == 26:
+		if len(b) < 4 {
+			return 0, nil, errCBORTruncated
+		}
+		return uint64(binary.BigEndian.Uint32(b)), b[4:], nil
+	case info == 27:
+		if len(b) < 8 {
+			return 0, nil, errCBORTruncated
+		}
+		return binary.BigEndian.Uint64(b), b[8:], nil
+	default:
+		return 0, nil, errors.New("cbor: indefinite length items not supported")
+	}
+}
+
+// decodeCBORSimple decodes a major type 7 item
+// with the given additional information.
+func decodeCBORSimple(info byte, b []byte) (any, []byte, error) {
+	switch info {
+	case 20:
+		return false, b, nil
+	case 21:
+		return true, b, nil
+	case 22, 23:
+		// null / undefined.
+		return nil, b, nil
+	case 25:
+		if len(b) < 2 {
+			return nil, nil, errCBORTruncated
+		}
+		return float16ToFloat64(binary.BigEndian.Uint16(b)), b[2:], nil
+	case 26:
+		if len(b) < 4 {
+			return nil, nil, errCBORTruncated
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), b[4:], nil
+	case 27:
+		if len(b) < 8 {
+			return nil, nil, errCBORTruncated
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), b[8:], nil
+	default:
+		return nil, nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+	}
+}
+
+// float16ToFloat64 converts an IEEE 754
+// half-precision float to a float64.
+func float16ToFloat64(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1.0
+	}
+	exp := int((h >> 10) & 0x1f)
+	frac := float64(h & 0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	default:
+		return sign * math.Ldexp(frac+1024, exp-25)
+	}
+}
+
+// cborInt returns the given decoded CBOR
+// value as an int64, if it's an integer.
+func cborInt(v any) (int64, bool) {
+	switch v := v.(type) {
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true // #nosec G115 -- checked above
+	case int64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// cborIntKey returns the value stored under the
+// given integer key in a decoded CBOR map, which
+// may have been decoded as either uint64 or int64.
+func cborIntKey(m map[any]any, key int64) (any, bool) {
+	if key >= 0 {
+		v, ok := m[uint64(key)]
+		return v, ok
+	}
+	v, ok := m[key]
+	return v, ok
+}
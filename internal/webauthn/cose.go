@@ -0,0 +1,241 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers supported for credential keys,
+// see: https://www.iana.org/assignments/cose/cose.xhtml#algorithms
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// SupportedAlgorithms contains the COSE algorithms accepted
+// for new credentials, in order of preference. This is
+// offered to the browser as pubKeyCredParams.
+var SupportedAlgorithms = []int64{
+	AlgES256,
+	AlgEdDSA,
+	AlgRS256,
+}
+
+// COSE key type and parameter labels,
+// see: https://www.rfc-editor.org/rfc/rfc9053
+const (
+	coseKeyKty = 1
+	coseKeyAlg = 3
+
+	coseKtyOKP = 1
+	coseKtyEC2 = 2
+	coseKtyRSA = 3
+
+	coseEC2Crv = -1
+	coseEC2X   = -2
+	coseEC2Y   = -3
+
+	coseRSAN = -1
+	coseRSAE = -2
+
+	coseCrvP256    = 1
+	coseCrvEd25519 = 6
+)
+
+// PublicKey wraps a credential
+// public key parsed from COSE.
+type PublicKey struct {
+	Alg int64
+	Key crypto.PublicKey
+}
+
+// ParsePublicKey parses the given CBOR
+// encoded COSE_Key into a PublicKey.
+func ParsePublicKey(b []byte) (*PublicKey, error) {
+	v, rest, err := decodeCBOR(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("cose: trailing data after key")
+	}
+
+	return parsePublicKey(v)
+}
+
+// parsePublicKey parses the given
+// decoded COSE_Key into a PublicKey.
+func parsePublicKey(v any) (*PublicKey, error) {
+	m, ok := v.(map[any]any)
+	if !ok {
+		return nil, errors.New("cose: key is not a map")
+	}
+
+	kty, ok := coseInt(m, coseKeyKty)
+	if !ok {
+		return nil, errors.New("cose: missing key type")
+	}
+
+	alg, ok := coseInt(m, coseKeyAlg)
+	if !ok {
+		return nil, errors.New("cose: missing key algorithm")
+	}
+
+	switch {
+	case kty == coseKtyEC2 && alg == AlgES256:
+		crv, _ := coseInt(m, coseEC2Crv)
+		if crv != coseCrvP256 {
+			return nil, fmt.Errorf("cose: unsupported EC2 curve %d", crv)
+		}
+
+		x, _ := coseBytes(m, coseEC2X)
+		y, _ := coseBytes(m, coseEC2Y)
+		if len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("cose: invalid EC2 coordinates")
+		}
+
+		// Marshal into uncompressed point form so that
+		// crypto/ecdh can validate the point is on curve.
+		point := make([]byte, 0, 65)
+		point = append(point, 0x04)
+		point = append(point, x...)
+		point = append(point, y...)
+
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("cose: invalid EC2 key: %w", err)
+		}
+
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+
+		return &PublicKey{Alg: alg, Key: key}, nil
+
+	case kty == coseKtyOKP && alg == AlgEdDSA:
+		crv, _ := coseInt(m, coseEC2Crv)
+		if crv != coseCrvEd25519 {
+			return nil, fmt.Errorf("cose: unsupported OKP curve %d", crv)
+		}
+
+		x, _ := coseBytes(m, coseEC2X)
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("cose: invalid OKP key")
+		}
+
+		key := ed25519.PublicKey(x)
+		return &PublicKey{Alg: alg, Key: key}, nil
+
+	case kty == coseKtyRSA && alg == AlgRS256:
+		n, _ := coseBytes(m, coseRSAN)
+		e, _ := coseBytes(m, coseRSAE)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			// Require 2048+ bit modulus.
+			return nil, errors.New("cose: invalid RSA key")
+		}
+
+		var exp int
+		for _, b := range e {
+			exp = exp<<8 | int(b)
+		}
+
+		key := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: exp,
+		}
+
+		return &PublicKey{Alg: alg, Key: key}, nil
+
+	default:
+		return nil, fmt.Errorf("cose: unsupported key type %d / algorithm %d", kty, alg)
+	}
+}
+
+// Verify checks the given signature over data with this public key.
+func (k *PublicKey) Verify(data []byte, sig []byte) error {
+	return verifySignature(k.Key, k.Alg, data, sig)
+}
+
+// verifySignature checks the given signature over
+// data with given public key and COSE algorithm.
+func verifySignature(pub crypto.PublicKey, alg int64, data []byte, sig []byte) error {
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		if alg != AlgES256 {
+			break
+		}
+		sum := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, sum[:], sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+
+	case ed25519.PublicKey:
+		if alg != AlgEdDSA {
+			break
+		}
+		if !ed25519.Verify(key, data, sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+
+	case *rsa.PublicKey:
+		if alg != AlgRS256 {
+			break
+		}
+		sum := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig); err != nil {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported key %T for algorithm %d", pub, alg)
+}
+
+// coseInt fetches integer value at key from COSE map.
+func coseInt(m map[any]any, key int64) (int64, bool) {
+	v, ok := cborIntKey(m, key)
+	if !ok {
+		return 0, false
+	}
+	return cborInt(v)
+}
+
+// coseBytes fetches byte string value at key from COSE map.
+func coseBytes(m map[any]any, key int64) ([]byte, bool) {
+	v, ok := cborIntKey(m, key)
+	if !ok {
+		return nil, false
+	}
+	b, ok := v.([]byte)
+	return b, ok
+}
@@ -0,0 +1,153 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webauthn
+
+// CreationOptions models the JSON form of
+// PublicKeyCredentialCreationOptions, as accepted
+// by PublicKeyCredential.parseCreationOptionsFromJSON().
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameters `json:"pubKeyCredParams"`
+	Timeout                int                    `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions models the JSON form of
+// PublicKeyCredentialRequestOptions, as accepted
+// by PublicKeyCredential.parseRequestOptionsFromJSON().
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int                    `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// RelyingPartyEntity models PublicKeyCredentialRpEntity.
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserEntity models the JSON form of PublicKeyCredentialUserEntity.
+type UserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// CredentialParameters models PublicKeyCredentialParameters.
+type CredentialParameters struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// CredentialDescriptor models the JSON form of PublicKeyCredentialDescriptor.
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// AuthenticatorSelection models AuthenticatorSelectionCriteria.
+type AuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+// CreationOptions returns options for registering a new credential
+// for the given user, with the given challenge. Existing credentials
+// of the user should be given as exclude, to prevent registering the
+// same authenticator twice.
+//
+// If passwordless is set, a discoverable credential (passkey) with
+// user verification is requested, so that it can be used to sign in
+// without a password. Otherwise, a plain second factor is requested.
+func (rp *RelyingParty) CreationOptions(
+	challenge string,
+	user UserEntity,
+	exclude []CredentialDescriptor,
+	passwordless bool,
+) *CreationOptions {
+	params := make([]CredentialParameters, len(SupportedAlgorithms))
+	for i, alg := range SupportedAlgorithms {
+		params[i] = CredentialParameters{Type: "public-key", Alg: alg}
+	}
+
+	selection := AuthenticatorSelection{
+		ResidentKey:      "discouraged",
+		UserVerification: "discouraged",
+	}
+	if passwordless {
+		selection = AuthenticatorSelection{
+			ResidentKey:        "required",
+			RequireResidentKey: true,
+			UserVerification:   "required",
+		}
+	}
+
+	if exclude == nil {
+		exclude = []CredentialDescriptor{}
+	}
+
+	return &CreationOptions{
+		Challenge:              challenge,
+		RP:                     RelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User:                   user,
+		PubKeyCredParams:       params,
+		Timeout:                Timeout,
+		ExcludeCredentials:     exclude,
+		AuthenticatorSelection: selection,
+
+		// We don't ask for attestation as we have no way to
+		// check it against trusted roots offline anyway, and
+		// asking prompts the user in some browsers. Any that's
+		// given regardless will still be verified.
+		Attestation: FormatNone,
+	}
+}
+
+// RequestOptions returns options for asserting an existing
+// credential, with the given challenge. The user's credentials
+// should be given as allow when using them as a second factor.
+//
+// If allow is empty, any discoverable credential (passkey) may be
+// used, and user verification is required, for passwordless login.
+func (rp *RelyingParty) RequestOptions(
+	challenge string,
+	allow []CredentialDescriptor,
+) *RequestOptions {
+	userVerification := "discouraged"
+	if len(allow) == 0 {
+		userVerification = "required"
+		allow = []CredentialDescriptor{}
+	}
+
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          Timeout,
+		RPID:             rp.ID,
+		AllowCredentials: allow,
+		UserVerification: userVerification,
+	}
+}
@@ -0,0 +1,497 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package webauthn implements the relying party side of Web
+// Authentication (WebAuthn) level 2, as needed for registering
+// and verifying security keys and passkeys.
+//
+// Everything here runs offline: attestation statements
+// are verified against the certificates included in the
+// statement itself, and no metadata service is consulted.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Authenticator data flags,
+// see: https://www.w3.org/TR/webauthn-2/#flags
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+	flagExtensions   = 0x80
+)
+
+// Client data types.
+const (
+	clientDataCreate = "webauthn.create"
+	clientDataGet    = "webauthn.get"
+)
+
+// Timeout is the timeout in milliseconds given
+// to the browser for registration and assertion
+// ceremonies, and the lifetime of a challenge.
+const Timeout = 5 * 60 * 1000
+
+// encoding is the base64 encoding used
+// for all binary values exchanged with
+// the browser, matching PublicKeyCredential.toJSON().
+var encoding = base64.RawURLEncoding
+
+// EncodeToString encodes the given
+// bytes as unpadded base64url.
+func EncodeToString(b []byte) string {
+	return encoding.EncodeToString(b)
+}
+
+// DecodeString decodes the given unpadded
+// base64url string, also accepting padding.
+func DecodeString(s string) ([]byte, error) {
+	for len(s)%4 != 0 && s[len(s)-1] == '=' {
+		s = s[:len(s)-1]
+	}
+	return encoding.DecodeString(s)
+}
+
+// NewChallenge returns a new random base64url
+// encoded challenge for use in a ceremony.
+func NewChallenge() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return EncodeToString(b)
+}
+
+// RelyingParty contains the details of the
+// relying party (ie., this instance) that
+// credentials are scoped to and verified against.
+type RelyingParty struct {
+	// ID is the relying party ID, the
+	// effective domain of this instance.
+	ID string
+
+	// Name is a human readable name for
+	// the relying party, shown by browsers.
+	Name string
+
+	// Origin is the expected origin of
+	// client data, eg., "https://example.org".
+	Origin string
+}
+
+// Credential contains the details of a
+// newly registered credential, for storage.
+type Credential struct {
+	// ID is the credential ID.
+	ID []byte
+
+	// PublicKey is the CBOR encoded
+	// COSE_Key of the credential.
+	PublicKey []byte
+
+	// SignCount is the initial
+	// signature counter value.
+	SignCount uint32
+
+	// AAGUID identifies the
+	// authenticator model.
+	AAGUID []byte
+
+	// UserVerified indicates whether
+	// the user was verified (by PIN,
+	// biometrics etc) on registration.
+	UserVerified bool
+
+	// AttestationFormat is the attestation
+	// statement format given by the authenticator.
+	AttestationFormat string
+
+	// Transports are the transports
+	// reported for this credential.
+	Transports []string
+}
+
+// Assertion contains the details
+// of a successfully verified assertion.
+type Assertion struct {
+	// SignCount is the new
+	// signature counter value.
+	SignCount uint32
+
+	// UserVerified indicates whether
+	// the user was verified (by PIN,
+	// biometrics etc) on assertion.
+	UserVerified bool
+
+	// UserHandle is the user handle returned
+	// by the authenticator, if any. Always set
+	// for discoverable credentials (passkeys).
+	UserHandle []byte
+}
+
+// RegistrationResponse models the JSON form of a
+// PublicKeyCredential returned by navigator.credentials.create(),
+// as produced by PublicKeyCredential.toJSON().
+type RegistrationResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string   `json:"clientDataJSON"`
+		AttestationObject string   `json:"attestationObject"`
+		Transports        []string `json:"transports"`
+	} `json:"response"`
+}
+
+// AssertionResponse models the JSON form of a
+// PublicKeyCredential returned by navigator.credentials.get(),
+// as produced by PublicKeyCredential.toJSON().
+type AssertionResponse struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON"`
+		AuthenticatorData string `json:"authenticatorData"`
+		Signature         string `json:"signature"`
+		UserHandle        string `json:"userHandle"`
+	} `json:"response"`
+}
+
+// clientData models the parts of
+// CollectedClientData that we check.
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// authenticatorData models
+// parsed authenticator data.
+type authenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+
+	// Only set when
+	// flagAttested.
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+// VerifyRegistration verifies the given registration response
+// against the challenge issued for it, returning the new credential.
+// If requireUV is set, the user must have been verified by the
+// authenticator, as required for passwordless (passkey) credentials.
+func (rp *RelyingParty) VerifyRegistration(
+	resp *RegistrationResponse,
+	challenge string,
+	requireUV bool,
+) (*Credential, error) {
+	if resp.Type != "public-key" {
+		return nil, fmt.Errorf("invalid credential type %q", resp.Type)
+	}
+
+	clientDataJSON, err := DecodeString(resp.Response.ClientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clientDataJSON: %w", err)
+	}
+
+	if err := rp.verifyClientData(
+		clientDataJSON,
+		clientDataCreate,
+		challenge,
+	); err != nil {
+		return nil, err
+	}
+
+	attObjBytes, err := DecodeString(resp.Response.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestationObject: %w", err)
+	}
+
+	attObj, err := parseAttestationObject(attObjBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	authData, err := parseAuthenticatorData(attObj.authData)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rp.verifyAuthenticatorData(authData, requireUV); err != nil {
+		return nil, err
+	}
+
+	if authData.flags&flagAttested == 0 {
+		return nil, errors.New("authenticator data contains no attested credential")
+	}
+
+	// Check credential ID
+	// matches what was given.
+	rawID, err := DecodeString(resp.RawID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rawId: %w", err)
+	}
+
+	if !bytes.Equal(rawID, authData.credentialID) {
+		return nil, errors.New("credential ID mismatch")
+	}
+
+	// Ensure public key is one we support.
+	pubKey, err := ParsePublicKey(authData.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify attestation statement, if any.
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	if err := verifyAttestation(
+		attObj,
+		authData,
+		pubKey,
+		clientDataHash[:],
+	); err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:                authData.credentialID,
+		PublicKey:         authData.publicKey,
+		SignCount:         authData.signCount,
+		AAGUID:            authData.aaguid,
+		UserVerified:      authData.flags&flagUserVerified != 0,
+		AttestationFormat: attObj.format,
+		Transports:        resp.Response.Transports,
+	}, nil
+}
+
+// VerifyAssertion verifies the given assertion response against
+// the challenge issued for it, and the stored public key and
+// signature counter of the credential it claims to be from.
+// If requireUV is set, the user must have been verified by the
+// authenticator, as required for passwordless (passkey) login.
+func (rp *RelyingParty) VerifyAssertion(
+	resp *AssertionResponse,
+	challenge string,
+	publicKey []byte,
+	signCount uint32,
+	requireUV bool,
+) (*Assertion, error) {
+	if resp.Type != "public-key" {
+		return nil, fmt.Errorf("invalid credential type %q", resp.Type)
+	}
+
+	clientDataJSON, err := DecodeString(resp.Response.ClientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clientDataJSON: %w", err)
+	}
+
+	if err := rp.verifyClientData(
+		clientDataJSON,
+		clientDataGet,
+		challenge,
+	); err != nil {
+		return nil, err
+	}
+
+	authDataBytes, err := DecodeString(resp.Response.AuthenticatorData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authenticatorData: %w", err)
+	}
+
+	authData, err := parseAuthenticatorData(authDataBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rp.verifyAuthenticatorData(authData, requireUV); err != nil {
+		return nil, err
+	}
+
+	sig, err := DecodeString(resp.Response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	pubKey, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Signature is over authenticator
+	// data || sha256(clientDataJSON).
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := concat(authDataBytes, clientDataHash[:])
+
+	if err := pubKey.Verify(signed, sig); err != nil {
+		return nil, fmt.Errorf("error verifying assertion: %w", err)
+	}
+
+	// If either counter is non-zero the new one must
+	// be greater, otherwise the authenticator may have
+	// been cloned. Authenticators that don't support
+	// counters (eg., most synced passkeys) always give 0.
+	if (authData.signCount != 0 || signCount != 0) &&
+		authData.signCount <= signCount {
+		return nil, fmt.Errorf(
+			"signature counter %d not greater than stored %d",
+			authData.signCount, signCount,
+		)
+	}
+
+	var userHandle []byte
+	if resp.Response.UserHandle != "" {
+		userHandle, err = DecodeString(resp.Response.UserHandle)
+		if err != nil {
+			return nil, fmt.Errorf("invalid userHandle: %w", err)
+		}
+	}
+
+	return &Assertion{
+		SignCount:    authData.signCount,
+		UserVerified: authData.flags&flagUserVerified != 0,
+		UserHandle:   userHandle,
+	}, nil
+}
+
+// verifyClientData checks that the given client data JSON
+// is of the given type, for the given challenge and origin.
+func (rp *RelyingParty) verifyClientData(
+	clientDataJSON []byte,
+	typ string,
+	challenge string,
+) error {
+	var cd clientData
+	if err := json.Unmarshal(clientDataJSON, &cd); err != nil {
+		return fmt.Errorf("error parsing client data: %w", err)
+	}
+
+	if cd.Type != typ {
+		return fmt.Errorf("unexpected client data type %q", cd.Type)
+	}
+
+	if challenge == "" || subtle.ConstantTimeCompare(
+		[]byte(cd.Challenge),
+		[]byte(challenge),
+	) != 1 {
+		return errors.New("client data challenge mismatch")
+	}
+
+	if cd.Origin != rp.Origin {
+		return fmt.Errorf("unexpected client data origin %q", cd.Origin)
+	}
+
+	if cd.CrossOrigin {
+		return errors.New("cross origin client data not allowed")
+	}
+
+	return nil
+}
+
+// verifyAuthenticatorData checks the relying party ID
+// hash and user presence / verification flags.
+func (rp *RelyingParty) verifyAuthenticatorData(authData *authenticatorData, requireUV bool) error {
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(authData.rpIDHash, rpIDHash[:]) {
+		return errors.New("relying party ID hash mismatch")
+	}
+
+	if authData.flags&flagUserPresent == 0 {
+		return errors.New("user not present")
+	}
+
+	if requireUV && authData.flags&flagUserVerified == 0 {
+		return errors.New("user not verified")
+	}
+
+	return nil
+}
+
+// parseAuthenticatorData parses the binary authenticator
+// data structure, see: https://www.w3.org/TR/webauthn-2/#sctn-authenticator-data
+func parseAuthenticatorData(b []byte) (*authenticatorData, error) {
+	if len(b) < 37 {
+		return nil, errors.New("authenticator data too short")
+	}
+
+	authData := &authenticatorData{
+		rpIDHash:  b[:32],
+		flags:     b[32],
+		signCount: binary.BigEndian.Uint32(b[33:37]),
+	}
+	b = b[37:]
+
+	if authData.flags&flagAttested != 0 {
+		// aaguid (16) + credentialIdLength (2).
+		if len(b) < 18 {
+			return nil, errors.New("attested credential data too short")
+		}
+
+		authData.aaguid = b[:16]
+		idLen := int(binary.BigEndian.Uint16(b[16:18]))
+		b = b[18:]
+
+		if idLen > 1023 || len(b) < idLen {
+			return nil, errors.New("invalid credential ID length")
+		}
+
+		authData.credentialID = b[:idLen]
+		b = b[idLen:]
+
+		// Credential public key is
+		// a CBOR encoded COSE_Key of
+		// unspecified length, decode
+		// it to find where it ends.
+		_, rest, err := decodeCBOR(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential public key: %w", err)
+		}
+
+		authData.publicKey = b[:len(b)-len(rest)]
+		b = rest
+	}
+
+	if authData.flags&flagExtensions != 0 {
+		// We don't use any extension
+		// outputs, just skip over them.
+		_, rest, err := decodeCBOR(b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extensions: %w", err)
+		}
+		b = rest
+	}
+
+	if len(b) != 0 {
+		return nil, errors.New("trailing data after authenticator data")
+	}
+
+	return authData, nil
+}
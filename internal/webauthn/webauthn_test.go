@@ -0,0 +1,324 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"testing"
+)
+
+var testRP = &RelyingParty{
+	ID:     "example.org",
+	Name:   "example.org",
+	Origin: "https://example.org",
+}
+
+// testAuthenticator is a software authenticator
+// with a single P-256 credential, for testing.
+type testAuthenticator struct {
+	key       *ecdsa.PrivateKey
+	id        []byte
+	signCount uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	return &testAuthenticator{key: key, id: id}
+}
+
+func (a *testAuthenticator) coseKey() []byte {
+	return encodeTestCBOR(map[int64]any{
+		coseKeyKty: int64(coseKtyEC2),
+		coseKeyAlg: AlgES256,
+		coseEC2Crv: int64(coseCrvP256),
+		coseEC2X:   a.key.X.FillBytes(make([]byte, 32)),
+		coseEC2Y:   a.key.Y.FillBytes(make([]byte, 32)),
+	})
+}
+
+func (a *testAuthenticator) authData(rpID string, flags byte, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+
+	b := append([]byte{}, rpIDHash[:]...)
+	b = append(b, flags)
+	b = binary.BigEndian.AppendUint32(b, a.signCount)
+
+	if attested {
+		b = append(b, make([]byte, 16)...) // aaguid
+		b = binary.BigEndian.AppendUint16(b, uint16(len(a.id)))
+		b = append(b, a.id...)
+		b = append(b, a.coseKey()...)
+	}
+
+	return b
+}
+
+func (a *testAuthenticator) sign(t *testing.T, authData []byte, clientDataJSON []byte) []byte {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	sum := sha256.Sum256(concat(authData, clientDataHash[:]))
+	sig, err := ecdsa.SignASN1(rand.Reader, a.key, sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func (a *testAuthenticator) create(t *testing.T, challenge string, flags byte) *RegistrationResponse {
+	clientDataJSON := testClientData(clientDataCreate, challenge, testRP.Origin)
+	authData := a.authData(testRP.ID, flags|flagAttested, true)
+
+	// Packed self attestation.
+	attObj := encodeTestCBOR(map[string]any{
+		"fmt": FormatPacked,
+		"attStmt": map[string]any{
+			"alg": AlgES256,
+			"sig": a.sign(t, authData, clientDataJSON),
+		},
+		"authData": authData,
+	})
+
+	resp := new(RegistrationResponse)
+	resp.ID = EncodeToString(a.id)
+	resp.RawID = resp.ID
+	resp.Type = "public-key"
+	resp.Response.ClientDataJSON = EncodeToString(clientDataJSON)
+	resp.Response.AttestationObject = EncodeToString(attObj)
+	return resp
+}
+
+func (a *testAuthenticator) get(t *testing.T, challenge string, flags byte) *AssertionResponse {
+	a.signCount++
+
+	clientDataJSON := testClientData(clientDataGet, challenge, testRP.Origin)
+	authData := a.authData(testRP.ID, flags, false)
+
+	resp := new(AssertionResponse)
+	resp.ID = EncodeToString(a.id)
+	resp.RawID = resp.ID
+	resp.Type = "public-key"
+	resp.Response.ClientDataJSON = EncodeToString(clientDataJSON)
+	resp.Response.AuthenticatorData = EncodeToString(authData)
+	resp.Response.Signature = EncodeToString(a.sign(t, authData, clientDataJSON))
+	resp.Response.UserHandle = EncodeToString([]byte("01HEXAMPLEUSER"))
+	return resp
+}
+
+func TestRegisterAndAssert(t *testing.T) {
+	auth := newTestAuthenticator(t)
+
+	challenge := NewChallenge()
+	cred, err := testRP.VerifyRegistration(
+		auth.create(t, challenge, flagUserPresent|flagUserVerified),
+		challenge,
+		true,
+	)
+	if err != nil {
+		t.Fatalf("error verifying registration: %v", err)
+	}
+
+	if cred.AttestationFormat != FormatPacked {
+		t.Fatalf("unexpected attestation format %q", cred.AttestationFormat)
+	}
+
+	challenge = NewChallenge()
+	assertion, err := testRP.VerifyAssertion(
+		auth.get(t, challenge, flagUserPresent|flagUserVerified),
+		challenge,
+		cred.PublicKey,
+		cred.SignCount,
+		true,
+	)
+	if err != nil {
+		t.Fatalf("error verifying assertion: %v", err)
+	}
+
+	if assertion.SignCount != 1 {
+		t.Fatalf("unexpected sign count %d", assertion.SignCount)
+	}
+
+	if string(assertion.UserHandle) != "01HEXAMPLEUSER" {
+		t.Fatalf("unexpected user handle %q", assertion.UserHandle)
+	}
+
+	// Replaying the same counter
+	// value should be rejected.
+	auth.signCount--
+	challenge = NewChallenge()
+	if _, err := testRP.VerifyAssertion(
+		auth.get(t, challenge, flagUserPresent),
+		challenge,
+		cred.PublicKey,
+		assertion.SignCount,
+		false,
+	); err == nil {
+		t.Fatal("expected error verifying assertion with stale counter")
+	}
+}
+
+func TestAssertionRejected(t *testing.T) {
+	auth := newTestAuthenticator(t)
+
+	challenge := NewChallenge()
+	cred, err := testRP.VerifyRegistration(
+		auth.create(t, challenge, flagUserPresent),
+		challenge,
+		false,
+	)
+	if err != nil {
+		t.Fatalf("error verifying registration: %v", err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		modify func(*AssertionResponse, *string)
+		uv     bool
+	}{
+		{
+			name: "wrong challenge",
+			modify: func(_ *AssertionResponse, challenge *string) {
+				*challenge = NewChallenge()
+			},
+		},
+		{
+			name: "bad signature",
+			modify: func(resp *AssertionResponse, _ *string) {
+				resp.Response.Signature = EncodeToString([]byte("nope"))
+			},
+		},
+		{
+			name: "wrong origin",
+			modify: func(resp *AssertionResponse, challenge *string) {
+				resp.Response.ClientDataJSON = EncodeToString(testClientData(
+					clientDataGet, *challenge, "https://evil.example.org",
+				))
+			},
+		},
+		{
+			name:   "user not verified",
+			modify: func(*AssertionResponse, *string) {},
+			uv:     true,
+		},
+	} {
+		challenge := NewChallenge()
+		resp := auth.get(t, challenge, flagUserPresent)
+		test.modify(resp, &challenge)
+
+		if _, err := testRP.VerifyAssertion(
+			resp,
+			challenge,
+			cred.PublicKey,
+			cred.SignCount,
+			test.uv,
+		); err == nil {
+			t.Errorf("%s: expected error verifying assertion", test.name)
+		}
+	}
+}
+
+func TestRegistrationWrongRPID(t *testing.T) {
+	auth := newTestAuthenticator(t)
+
+	challenge := NewChallenge()
+	rp := *testRP
+	rp.ID = "other.example.org"
+
+	if _, err := rp.VerifyRegistration(
+		auth.create(t, challenge, flagUserPresent),
+		challenge,
+		false,
+	); err == nil {
+		t.Fatal("expected error verifying registration for wrong rp id")
+	}
+}
+
+func testClientData(typ string, challenge string, origin string) []byte {
+	b, _ := json.Marshal(clientData{
+		Type:      typ,
+		Challenge: challenge,
+		Origin:    origin,
+	})
+	return b
+}
+
+// encodeTestCBOR is a minimal CBOR encoder
+// supporting the types used in these tests.
+func encodeTestCBOR(v any) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n <= 0xff:
+			return []byte{major<<5 | 24, byte(n)}
+		case n <= 0xffff:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+		default:
+			return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+		}
+	}
+
+	switch v := v.(type) {
+	case int64:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b := head(5, uint64(len(v)))
+		for _, k := range keys {
+			b = append(b, encodeTestCBOR(k)...)
+			b = append(b, encodeTestCBOR(v[k])...)
+		}
+		return b
+	case map[int64]any:
+		keys := make([]int64, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		b := head(5, uint64(len(v)))
+		for _, k := range keys {
+			b = append(b, encodeTestCBOR(k)...)
+			b = append(b, encodeTestCBOR(v[k])...)
+		}
+		return b
+	default:
+		panic("unsupported type")
+	}
+}
@@ -20,6 +20,7 @@
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -33,6 +34,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
 	"code.superseriousbusiness.org/gotosocial/internal/text"
 	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
@@ -201,10 +203,16 @@ func (r *realSender) sendToSubscription(
 		responseBodyMaxLen = 1024
 	)
 
-	// Get the associated access token.
-	token, err := r.state.DB.GetTokenByID(ctx, subscription.TokenID)
-	if err != nil {
-		return gtserror.Newf("error getting token %s: %w", subscription.TokenID, err)
+	// Get the associated access token. This
+	// is only stored encrypted, and may be
+	// empty for very old subscriptions, or
+	// if no token secret is configured.
+	accessToken, err := oauth.OpenToken(subscription.SealedAccessToken)
+	if errors.Is(err, oauth.ErrNoTokenSecret) {
+		// Secret since unset, send without.
+		accessToken = ""
+	} else if err != nil {
+		return gtserror.Newf("error opening access token for token %s: %w", subscription.TokenID, err)
 	}
 
 	// Create push notification payload struct.
@@ -215,7 +223,7 @@ func (r *realSender) sendToSubscription(
 		Body:             formatNotificationBody(apiNotification),
 		Icon:             apiNotification.Account.Avatar,
 		PreferredLocale:  targetAccountSettings.Language,
-		AccessToken:      token.Access,
+		AccessToken:      accessToken,
 	}
 
 	// Encode the push notification as JSON.
@@ -337,6 +345,12 @@ func formatNotificationTitle(
 		return fmt.Sprintf("%s submitted a report", displayNameOrAcct)
 	case gtsmodel.NotificationUpdate:
 		return fmt.Sprintf("%s updated their post", displayNameOrAcct)
+	case gtsmodel.NotificationQuote:
+		return fmt.Sprintf("%s quoted your post", displayNameOrAcct)
+	case gtsmodel.NotificationPendingQuote:
+		return fmt.Sprintf("%s quoted your post, which requires your approval", displayNameOrAcct)
+	case gtsmodel.NotificationReaction:
+		return fmt.Sprintf("%s reacted to your post", displayNameOrAcct)
 	default:
 		log.Warnf(ctx, "Unknown notification type: %d", notification.NotificationType)
 		return fmt.Sprintf(
@@ -6,6 +6,7 @@ EXPECT=$(cat << "EOF"
 {
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
+    "accounts-allow-user-invites": true,
     "accounts-custom-css-length": 5000,
     "accounts-reason-required": false,
     "accounts-registration-backlog-limit": 100,
@@ -14,6 +15,9 @@ EXPECT=$(cat << "EOF"
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
     "advanced-header-filter-mode": "block",
+    "advanced-introspection-clients": [
+        "01JXKZ3V0N5QW8H6T2R9B4M7CY"
+    ],
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
         "127.0.0.1/32"
@@ -22,6 +26,7 @@ EXPECT=$(cat << "EOF"
     "advanced-sender-multiplier": -1,
     "advanced-throttling-multiplier": -1,
     "advanced-throttling-retry-after": 10000000000,
+    "advanced-token-hash-secret": "hashbrowns",
     "application-name": "gts",
     "bind-address": "127.0.0.1",
     "cache": {
@@ -29,6 +34,7 @@ EXPECT=$(cat << "EOF"
         "account-note-mem-ratio": 1,
         "account-settings-mem-ratio": 0.1,
         "account-stats-mem-ratio": 2,
+        "announcement-mem-ratio": 0.1,
         "application-mem-ratio": 0.1,
         "block-ids-mem-ratio": 3,
         "block-mem-ratio": 2,
@@ -51,6 +57,7 @@ EXPECT=$(cat << "EOF"
         "in-reply-to-ids-mem-ratio": 3,
         "instance-mem-ratio": 1,
         "interaction-request-mem-ratio": 1,
+        "invite-mem-ratio": 0.5,
         "list-ids-mem-ratio": 2,
         "list-mem-ratio": 1,
         "listed-ids-mem-ratio": 2,
@@ -63,7 +70,10 @@ EXPECT=$(cat << "EOF"
         "poll-mem-ratio": 1,
         "poll-vote-ids-mem-ratio": 2,
         "poll-vote-mem-ratio": 2,
+        "preview-card-mem-ratio": 0.5,
+        "relay-mem-ratio": 0.1,
         "report-mem-ratio": 1,
+        "scheduled-status-mem-ratio": 0.5,
         "sin-bin-status-mem-ratio": 0.5,
         "status-bookmark-ids-mem-ratio": 2,
         "status-bookmark-mem-ratio": 0.5,
@@ -75,6 +85,7 @@ EXPECT=$(cat << "EOF"
         "thread-mute-mem-ratio": 0.2,
         "token-mem-ratio": 0.75,
         "tombstone-mem-ratio": 0.5,
+        "trend-review-mem-ratio": 0.5,
         "user-mem-ratio": 0.25,
         "user-mute-ids-mem-ratio": 3,
         "user-mute-mem-ratio": 2,
@@ -123,6 +134,7 @@ EXPECT=$(cat << "EOF"
     "instance-stats-mode": "baffle",
     "instance-subscriptions-process-every": 86400000000000,
     "instance-subscriptions-process-from": "23:00",
+    "instance-trends-enabled": true,
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -149,6 +161,7 @@ EXPECT=$(cat << "EOF"
     "metrics-auth-password": "",
     "metrics-auth-username": "",
     "metrics-enabled": false,
+    "minimal": false,
     "oidc-admin-groups": [
         "steamy"
     ],
@@ -161,6 +174,10 @@ EXPECT=$(cat << "EOF"
     "oidc-idp-name": "sex-haver",
     "oidc-issuer": "whoknows",
     "oidc-link-existing": true,
+    "oidc-moderator-groups": [
+        "mods"
+    ],
+    "oidc-providers": null,
     "oidc-scopes": [
         "read",
         "write"
@@ -172,6 +189,9 @@ EXPECT=$(cat << "EOF"
     "protocol": "http",
     "remote-only": false,
     "request-id-header": "X-Trace-Id",
+    "scheduled-statuses-max-daily": 25,
+    "scheduled-statuses-max-total": 300,
+    "since": "",
     "smtp-disclose-recipients": true,
     "smtp-from": "queen.rip.in.piss@terfisland.org",
     "smtp-host": "example.com",
@@ -207,6 +227,7 @@ EXPECT=$(cat << "EOF"
         "docker.host.local"
     ],
     "username": "",
+    "verify-only": false,
     "web-asset-base-dir": "/root",
     "web-template-base-dir": "/root"
 }
@@ -254,6 +275,7 @@ GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
 GTS_INSTANCE_STATS_MODE="baffle" \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
+GTS_ACCOUNTS_ALLOW_USER_INVITES=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
 GTS_ACCOUNTS_REGISTRATION_BACKLOG_LIMIT=100 \
 GTS_ACCOUNTS_REGISTRATION_DAILY_LIMIT=50 \
@@ -300,6 +322,7 @@ GTS_OIDC_SCOPES='read,write' \
 GTS_OIDC_LINK_EXISTING=true \
 GTS_OIDC_ALLOWED_GROUPS='sloths' \
 GTS_OIDC_ADMIN_GROUPS='steamy' \
+GTS_OIDC_MODERATOR_GROUPS='mods' \
 GTS_SMTP_HOST='example.com' \
 GTS_SMTP_PORT=4269 \
 GTS_SMTP_USERNAME='sex-haver' \
@@ -318,6 +341,8 @@ GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
 GTS_ADVANCED_HEADER_FILTER_MODE='block' \
+GTS_ADVANCED_TOKEN_HASH_SECRET='hashbrowns' \
+GTS_ADVANCED_INTROSPECTION_CLIENTS='01JXKZ3V0N5QW8H6T2R9B4M7CY' \
 GTS_REQUEST_ID_HEADER='X-Trace-Id' \
 go run ./cmd/gotosocial/... --config-path internal/config/testdata/test.yaml debug config)
 
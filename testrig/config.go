@@ -102,6 +102,7 @@ func testDefaults() config.Configuration {
 		InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm,
 		InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
 		InstanceAllowBackdatingStatuses:   true,
+		InstanceTrendsEnabled:             true,
 
 		AccountsRegistrationOpen:         true,
 		AccountsReasonRequired:           true,
@@ -130,6 +131,8 @@ func testDefaults() config.Configuration {
 		StatusesPollMaxOptions:     6,
 		StatusesPollOptionMaxChars: 50,
 		StatusesMediaMaxFiles:      6,
+		ScheduledStatusesMaxTotal:  300,
+		ScheduledStatusesMaxDaily:  25,
 
 		LetsEncryptEnabled:      false,
 		LetsEncryptPort:         0,
@@ -170,6 +173,7 @@ func testDefaults() config.Configuration {
 		AdvancedRateLimitRequests:    0, // disabled
 		AdvancedThrottlingMultiplier: 0, // disabled
 		AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
+		AdvancedTokenHashSecret:      "testrig-token-hash-secret",
 
 		SoftwareVersion: "0.0.0-testrig",
 
@@ -24,11 +24,13 @@
 	"code.superseriousbusiness.org/gotosocial/internal/db/bundb"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
 )
 
 var testModels = []interface{}{
 	&gtsmodel.Account{},
+	&gtsmodel.AccountArchive{},
 	&gtsmodel.AccountNote{},
 	&gtsmodel.AccountSettings{},
 	&gtsmodel.AccountToEmoji{},
@@ -41,19 +43,23 @@
 	&gtsmodel.FilterStatus{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
+	&gtsmodel.GroupAdmin{},
 	&gtsmodel.InteractionRequest{},
 	&gtsmodel.List{},
 	&gtsmodel.ListEntry{},
+	&gtsmodel.PendingListEntry{},
 	&gtsmodel.Marker{},
 	&gtsmodel.MediaAttachment{},
 	&gtsmodel.Mention{},
 	&gtsmodel.Poll{},
 	&gtsmodel.PollVote{},
+	&gtsmodel.PreviewCard{},
 	&gtsmodel.Status{},
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusEdit{},
 	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusReaction{},
 	&gtsmodel.StatusBookmark{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
@@ -63,16 +69,29 @@
 	&gtsmodel.UserMute{},
 	&gtsmodel.VAPIDKeyPair{},
 	&gtsmodel.WebPushSubscription{},
+	&gtsmodel.WebAuthnCredential{},
+	&gtsmodel.ExternalIdentity{},
+	&gtsmodel.Invite{},
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
 	&gtsmodel.Notification{},
+	&gtsmodel.OutboxImport{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
 	&gtsmodel.EmojiCategory{},
 	&gtsmodel.Tombstone{},
 	&gtsmodel.Report{},
+	&gtsmodel.ScheduledStatus{},
+	&gtsmodel.Announcement{},
+	&gtsmodel.AnnouncementRead{},
+	&gtsmodel.AnnouncementReaction{},
+	&gtsmodel.Relay{},
+	&gtsmodel.TrendReview{},
+	&gtsmodel.StaffPick{},
+	&gtsmodel.SuggestionDismissal{},
 	&gtsmodel.Rule{},
 	&gtsmodel.WorkerTask{},
+	&gtsmodel.QueuedDelivery{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -126,6 +145,11 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 	ctx := context.Background()
 
 	for _, v := range NewTestTokens() {
+		// Tokens are only stored hashed,
+		// test code uses the plaintext.
+		v.Code = oauth.HashToken(v.Code)
+		v.Access = oauth.HashToken(v.Access)
+		v.Refresh = oauth.HashToken(v.Refresh)
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(ctx, err)
 		}
@@ -155,6 +179,18 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestAnnouncements() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(ctx, err)
+		}
+	}
+
+	for _, v := range NewTestRelays() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(ctx, err)
+		}
+	}
+
 	for _, v := range NewTestDomainBlocks() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(ctx, err)
@@ -344,6 +380,18 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 	}
 
 	for _, v := range NewTestWebPushSubscriptions() {
+		// Subscriptions keep an encrypted
+		// copy of their token's plaintext.
+		for _, token := range NewTestTokens() {
+			if token.ID != v.TokenID {
+				continue
+			}
+			sealed, err := oauth.SealToken(token.Access)
+			if err != nil {
+				log.Panic(ctx, err)
+			}
+			v.SealedAccessToken = sealed
+		}
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(nil, err)
 		}
@@ -361,6 +409,22 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	if accounts == nil {
+		accounts = NewTestAccounts()
+	}
+
+	for _, v := range accounts {
+		if err := db.IndexAccount(ctx, v); err != nil {
+			log.Panic(ctx, err)
+		}
+	}
+
+	for _, v := range NewTestStatuses() {
+		if err := db.IndexStatus(ctx, v); err != nil {
+			log.Panic(ctx, err)
+		}
+	}
+
 	if err := db.CreateInstanceAccount(ctx); err != nil {
 		log.Panic(ctx, err)
 	}
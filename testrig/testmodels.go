@@ -3383,6 +3383,61 @@ func NewTestRules() map[string]*gtsmodel.Rule {
 	}
 }
 
+func NewTestAnnouncements() map[string]*gtsmodel.Announcement {
+	return map[string]*gtsmodel.Announcement{
+		"ended_announcement": {
+			ID:          "01JSV4ZK3N6R9D2F5H8J1M4P7S",
+			CreatedAt:   TimeMustParse("2025-03-20T10:00:00Z"),
+			UpdatedAt:   TimeMustParse("2025-03-20T10:00:00Z"),
+			AccountID:   "01F8MH17FWEB39HZJ76B6VXSKF",
+			Text:        "We're moving house, see you on the other side!",
+			Content:     "<p>We're moving house, see you on the other side!</p>",
+			StartsAt:    TimeMustParse("2025-03-29T00:00:00Z"),
+			EndsAt:      TimeMustParse("2025-03-31T00:00:00Z"),
+			AllDay:      util.Ptr(true),
+			Published:   util.Ptr(true),
+			PublishedAt: TimeMustParse("2025-03-20T10:00:00Z"),
+		},
+		"active_announcement": {
+			ID:          "01JSV5B9WZ4F1N0T3M8Y7E2K6Q",
+			CreatedAt:   TimeMustParse("2025-04-20T12:30:00Z"),
+			UpdatedAt:   TimeMustParse("2025-04-20T12:30:00Z"),
+			AccountID:   "01F8MH17FWEB39HZJ76B6VXSKF",
+			Text:        "Scheduled maintenance this weekend, expect some downtime!",
+			Content:     "<p>Scheduled maintenance this weekend, expect some downtime!</p>",
+			StartsAt:    TimeMustParse("2025-04-26T10:00:00Z"),
+			AllDay:      util.Ptr(false),
+			Published:   util.Ptr(true),
+			PublishedAt: TimeMustParse("2025-04-20T12:30:00Z"),
+		},
+		"draft_announcement": {
+			ID:        "01JSV5H2R8M3Q6Z9T1X4C7B0PD",
+			CreatedAt: TimeMustParse("2025-04-21T09:15:00Z"),
+			UpdatedAt: TimeMustParse("2025-04-21T09:15:00Z"),
+			AccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			Text:      "This one isn't ready yet.",
+			Content:   "<p>This one isn't ready yet.</p>",
+			AllDay:    util.Ptr(false),
+			Published: util.Ptr(false),
+		},
+	}
+}
+
+func NewTestRelays() map[string]*gtsmodel.Relay {
+	return map[string]*gtsmodel.Relay{
+		"pending_relay": {
+			ID:                 "01JSYB6Y3W2QK8N5R0T7V4X1ZC",
+			CreatedAt:          TimeMustParse("2025-04-28T15:30:45Z"),
+			UpdatedAt:          TimeMustParse("2025-04-28T15:30:45Z"),
+			CreatedByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			Type:               gtsmodel.RelayTypeMastodon,
+			InboxURI:           "https://relay.fedi.example.org/inbox",
+			FollowURI:          "http://localhost:8080/users/localhost:8080/follow/01JSYB6Y3WBDZ5H2PTA9XQ0M6K",
+			State:              gtsmodel.RelayStatePending,
+		},
+	}
+}
+
 // ActivityWithSignature wraps a pub.Activity along with its signature headers, for testing.
 type ActivityWithSignature struct {
 	Activity        pub.Activity
@@ -619,6 +619,12 @@ func DomainPermissionSubscriptionResponse(req *http.Request) (
 nothanks.com,suspend,false,false,,false`
 		csvRespETag = "\"bigbums6969\""
 
+		csvSeveritiesResp = `#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+bumfaces.net,suspend,false,false,big jerks,false
+peepee.poopoo,silence,true,false,harassment,false
+nothanks.com,noop,true,false,,false`
+		csvSeveritiesRespETag = "\"severe bums\""
+
 		textResp = `bumfaces.net
 peepee.poopoo
 nothanks.com`
@@ -724,6 +730,21 @@ func DomainPermissionSubscriptionResponse(req *http.Request) (
 		}
 		responseContentLength = len(responseBytes)
 
+	case "https://lists.example.org/baddies_severities.csv":
+		extraHeaders = map[string]string{
+			"Last-Modified": lastModified,
+			"ETag":          csvSeveritiesRespETag,
+		}
+		if req.Header.Get("If-None-Match") == csvSeveritiesRespETag {
+			// Cached.
+			responseCode = http.StatusNotModified
+		} else {
+			responseBytes = []byte(csvSeveritiesResp)
+			responseContentType = textCSV
+			responseCode = http.StatusOK
+		}
+		responseContentLength = len(responseBytes)
+
 	case "https://lists.example.org/goodies.csv":
 		extraHeaders = map[string]string{
 			"Last-Modified": lastModified,
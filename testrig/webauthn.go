@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package testrig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+
+	"code.superseriousbusiness.org/gotosocial/internal/webauthn"
+)
+
+// WebAuthnAuthenticator is a software WebAuthn authenticator
+// holding a single ES256 credential, for testing registration
+// and sign in without a real security key. It always reports
+// the user as present and verified.
+type WebAuthnAuthenticator struct {
+	Key        *ecdsa.PrivateKey
+	ID         []byte
+	SignCount  uint32
+	UserHandle []byte
+}
+
+// NewWebAuthnAuthenticator returns a new
+// authenticator with a freshly generated key.
+func NewWebAuthnAuthenticator() *WebAuthnAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+
+	return &WebAuthnAuthenticator{Key: key, ID: id}
+}
+
+// Create returns the JSON form of a new credential
+// created with the given options, as navigator.credentials.create()
+// followed by PublicKeyCredential.toJSON() would in a browser.
+func (a *WebAuthnAuthenticator) Create(options *webauthn.CreationOptions, origin string) string {
+	userHandle, err := webauthn.DecodeString(options.User.ID)
+	if err != nil {
+		panic(err)
+	}
+	a.UserHandle = userHandle
+
+	clientDataJSON := webAuthnClientData("webauthn.create", options.Challenge, origin)
+
+	// Build attested credential data with COSE public key.
+	coseKey := webAuthnCBOR(map[int64]any{
+		1:  int64(2),  // kty: EC2
+		3:  int64(-7), // alg: ES256
+		-1: int64(1),  // crv: P-256
+		-2: a.Key.X.FillBytes(make([]byte, 32)),
+		-3: a.Key.Y.FillBytes(make([]byte, 32)),
+	})
+	authData := a.authData(options.RP.ID, 0x40)
+	authData = append(authData, make([]byte, 16)...) // aaguid
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.ID)))
+	authData = append(authData, a.ID...)
+	authData = append(authData, coseKey...)
+
+	attObj := webAuthnCBOR(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+
+	b, err := json.Marshal(map[string]any{
+		"id":    webauthn.EncodeToString(a.ID),
+		"rawId": webauthn.EncodeToString(a.ID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    webauthn.EncodeToString(clientDataJSON),
+			"attestationObject": webauthn.EncodeToString(attObj),
+			"transports":        []string{"usb"},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// Get returns the JSON form of an assertion made with the given
+// options, as navigator.credentials.get() followed by
+// PublicKeyCredential.toJSON() would in a browser.
+func (a *WebAuthnAuthenticator) Get(options *webauthn.RequestOptions, origin string) string {
+	a.SignCount++
+
+	clientDataJSON := webAuthnClientData("webauthn.get", options.Challenge, origin)
+	authData := a.authData(options.RPID, 0)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authData...), clientDataHash[:]...)
+	sum := sha256.Sum256(signed)
+	sig, err := ecdsa.SignASN1(rand.Reader, a.Key, sum[:])
+	if err != nil {
+		panic(err)
+	}
+
+	b, err := json.Marshal(map[string]any{
+		"id":    webauthn.EncodeToString(a.ID),
+		"rawId": webauthn.EncodeToString(a.ID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    webauthn.EncodeToString(clientDataJSON),
+			"authenticatorData": webauthn.EncodeToString(authData),
+			"signature":         webauthn.EncodeToString(sig),
+			"userHandle":        webauthn.EncodeToString(a.UserHandle),
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// authData returns authenticator data for the given relying party
+// ID, with user present + verified, and any extra flags.
+func (a *WebAuthnAuthenticator) authData(rpID string, flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	authData := append([]byte{}, rpIDHash[:]...)
+	authData = append(authData, 0x01|0x04|flags)
+	return binary.BigEndian.AppendUint32(authData, a.SignCount)
+}
+
+func webAuthnClientData(typ string, challenge string, origin string) []byte {
+	b, err := json.Marshal(map[string]any{
+		"type":      typ,
+		"challenge": challenge,
+		"origin":    origin,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// webAuthnCBOR is a minimal CBOR encoder supporting
+// only what's needed for attestation objects + keys.
+func webAuthnCBOR(v any) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n <= 0xff:
+			return []byte{major<<5 | 24, byte(n)}
+		default:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+		}
+	}
+
+	switch v := v.(type) {
+	case int64:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case map[string]any:
+		b := head(5, uint64(len(v)))
+		for k, val := range v {
+			b = append(b, webAuthnCBOR(k)...)
+			b = append(b, webAuthnCBOR(val)...)
+		}
+		return b
+	case map[int64]any:
+		b := head(5, uint64(len(v)))
+		for k, val := range v {
+			b = append(b, webAuthnCBOR(k)...)
+			b = append(b, webAuthnCBOR(val)...)
+		}
+		return b
+	default:
+		panic("unsupported type")
+	}
+}
@@ -73,6 +73,15 @@ skulk({
 				["babelify", { global: true }]
 			],
 		},
+		webauthn: {
+			entryFile: "webauthn",
+			outputFile: "webauthn.js",
+			preset: ["js"],
+			prodCfg: prodCfg,
+			transform: [
+				["babelify", { global: true }]
+			],
+		},
 		settings: {
 			entryFile: "settings",
 			outputFile: "settings.js",
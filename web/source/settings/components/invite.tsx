@@ -0,0 +1,140 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { useMemo } from "react";
+import { useLocation } from "wouter";
+import MutationButton from "./form/mutation-button";
+import UsernameLozenge from "./username-lozenge";
+import { useBaseUrl } from "../lib/navigation/util";
+import { useRevokeInviteMutation } from "../lib/query/user/invites";
+import { Invite } from "../lib/types/invite";
+
+interface InviteListEntryProps {
+	invite: Invite;
+	/**
+	 * Mutation hook to use for
+	 * revoking the invite.
+	 */
+	useRevokeMutation: typeof useRevokeInviteMutation;
+}
+
+export function InviteListEntry({ invite, useRevokeMutation }: InviteListEntryProps) {
+	const baseUrl = useBaseUrl();
+	const [ location ] = useLocation();
+	const [ revoke, revokeResult ] = useRevokeMutation();
+
+	const created = useMemo(() => {
+		const createdAt = new Date(invite.created_at);
+		return <time dateTime={invite.created_at}>{createdAt.toDateString()}</time>;
+	}, [invite.created_at]);
+
+	const expires = useMemo(() => {
+		if (!invite.expires_at) {
+			return "never";
+		}
+
+		const expiresAt = new Date(invite.expires_at);
+		return <time dateTime={invite.expires_at}>{expiresAt.toLocaleString()}</time>;
+	}, [invite.expires_at]);
+
+	let status = "valid";
+	if (invite.revoked_at) {
+		status = "revoked";
+	} else if (!invite.valid) {
+		status = "expired or used up";
+	}
+
+	return (
+		<span
+			className={`invite entry${invite.valid ? "" : " invalid"}`}
+			aria-label={`Invite ${invite.code}, ${status}`}
+			title={`Invite ${invite.code}, ${status}`}
+		>
+			<dl className="info-list">
+				<div className="info-list-entry">
+					<dt>Link:</dt>
+					<dd className="text-cutoff monospace">{invite.url}</dd>
+				</div>
+				{ invite.account &&
+					<div className="info-list-entry">
+						<dt>Created by:</dt>
+						<dd>
+							<UsernameLozenge
+								account={invite.account.id}
+								linkTo={`~/settings/moderation/accounts/${invite.account.id}`}
+								backLocation={`~${baseUrl}${location}`}
+							/>
+						</dd>
+					</div>
+				}
+				<div className="info-list-entry">
+					<dt>Created:</dt>
+					<dd className="text-cutoff">{created}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Expires:</dt>
+					<dd className="text-cutoff">{expires}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Uses:</dt>
+					<dd className="text-cutoff">
+						{invite.uses}{invite.max_uses ? ` of ${invite.max_uses}` : ""}
+					</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Autofollow:</dt>
+					<dd className="text-cutoff">{invite.autofollow ? "yes" : "no"}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Status:</dt>
+					<dd className="text-cutoff">{status}</dd>
+				</div>
+			</dl>
+			{ invite.valid &&
+				<div className="action-buttons">
+					<button
+						type="button"
+						className="button"
+						onClick={(e) => {
+							e.preventDefault();
+							e.stopPropagation();
+							navigator.clipboard.writeText(invite.url);
+						}}
+					>
+						Copy link
+					</button>
+					<MutationButton
+						label="Revoke invite"
+						title="Revoke invite"
+						type="button"
+						className="button danger"
+						onClick={(e) => {
+							e.preventDefault();
+							e.stopPropagation();
+							revoke(invite.id);
+						}}
+						disabled={false}
+						showError={true}
+						result={revokeResult}
+					/>
+				</div>
+			}
+		</span>
+	);
+}
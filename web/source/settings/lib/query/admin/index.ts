@@ -20,7 +20,14 @@
 import { replaceCacheOnMutation, removeFromCacheOnMutation } from "../query-modifiers";
 import { gtsApi } from "../gts-api";
 import { listToKeyedObject } from "../transforms";
-import { ActionAccountParams, AdminAccount, HandleSignupParams, SearchAccountParams, SearchAccountResp } from "../../types/account";
+import {
+	ActionAccountParams,
+	AdminAccount,
+	AdminExternalIdentity,
+	HandleSignupParams,
+	SearchAccountParams,
+	SearchAccountResp,
+} from "../../types/account";
 import { InstanceRule, MappedRules } from "../../types/rules";
 import parse from "parse-link-header";
 
@@ -46,6 +53,15 @@ const extended = gtsApi.injectEndpoints({
 			],
 		}),
 
+		getAccountExternalIdentities: build.query<AdminExternalIdentity[], string>({
+			query: (id) => ({
+				url: `/api/v1/admin/accounts/${id}/external_identities`
+			}),
+			providesTags: (_result, _error, id) => [
+				{ type: 'Account', id }
+			],
+		}),
+
 		searchAccounts: build.query<SearchAccountResp, SearchAccountParams>({
 			query: (form) => {
 				const params = new(URLSearchParams);
@@ -196,6 +212,7 @@ export const {
 	useUpdateInstanceMutation,
 	useGetAccountQuery,
 	useLazyGetAccountQuery,
+	useGetAccountExternalIdentitiesQuery,
 	useActionAccountMutation,
 	useSearchAccountsQuery,
 	useLazySearchAccountsQuery,
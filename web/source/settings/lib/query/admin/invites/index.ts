@@ -0,0 +1,82 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { gtsApi } from "../../gts-api";
+
+import type {
+	Invite,
+	SearchInvitesParams,
+	SearchInvitesResp,
+} from "../../../types/invite";
+import parse from "parse-link-header";
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		searchAdminInvites: build.query<SearchInvitesResp, SearchInvitesParams>({
+			query: (form) => {
+				const params = new(URLSearchParams);
+				Object.entries(form).forEach(([k, v]) => {
+					if (v !== undefined) {
+						params.append(k, v);
+					}
+				});
+
+				let query = "";
+				if (params.size !== 0) {
+					query = `?${params.toString()}`;
+				}
+
+				return {
+					url: `/api/v1/admin/invites${query}`
+				};
+			},
+			// Headers required for paging.
+			transformResponse: (apiResp: Invite[], meta) => {
+				const invites = apiResp;
+				const linksStr = meta?.response?.headers.get("Link");
+				const links = parse(linksStr);
+				return { invites, links };
+			},
+			providesTags: [{ type: "Invite", id: "TRANSFORMED" }]
+		}),
+
+		adminRevokeInvite: build.mutation<Invite, string>({
+			query: (id) => ({
+				method: "POST",
+				url: `/api/v1/admin/invites/${id}/revoke`,
+			}),
+			invalidatesTags: [{ type: "Invite", id: "TRANSFORMED" }]
+		}),
+	}),
+});
+
+/**
+ * View invites created by users of this instance.
+ */
+const useLazySearchAdminInvitesQuery = extended.useLazySearchAdminInvitesQuery;
+
+/**
+ * Revoke an invite, so that it can no longer be used to sign up.
+ */
+const useAdminRevokeInviteMutation = extended.useAdminRevokeInviteMutation;
+
+export {
+	useLazySearchAdminInvitesQuery,
+	useAdminRevokeInviteMutation,
+};
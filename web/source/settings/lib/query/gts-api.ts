@@ -152,6 +152,10 @@ const gtsBaseQuery: BaseQueryFn<
 					// It's an image,
 					// return the blob.
 					return response.blob();
+				case (accept === "application/zip"):
+					// It's an archive,
+					// return the blob.
+					return response.blob();
 				default:
 					// God knows what it
 					// is, just return text.
@@ -170,6 +174,8 @@ export const gtsApi = createApi({
 		"Emoji",
 		"Report",
 		"Account",
+		"AccountArchive",
+		"OutboxImport",
 		"InstanceRules",
 		"HTTPHeaderAllows",
 		"HTTPHeaderBlocks",
@@ -180,6 +186,8 @@ export const gtsApi = createApi({
 		"DomainPermissionSubscription",
 		"TokenInfo",
 		"User",
+		"WebAuthnCredential",
+		"Invite",
 	],
 	endpoints: (build) => ({
 		instanceV1: build.query<InstanceV1, void>({
@@ -29,6 +29,7 @@ import { RootState } from '../../../redux/store';
 import { Account } from '../../types/account';
 import { OAuthAccessTokenRequestBody } from '../../types/oauth';
 import { App } from '../../types/application';
+import { InstanceV1 } from '../../types/instance';
 
 function getSettingsURL() {
 	/*
@@ -211,6 +212,30 @@ const extended = gtsApi.injectEndpoints({
 				// to get just the access token part.
 				token = token.substring(7);
 
+				// If the instance uses OIDC, sign out through
+				// its sign out endpoint instead. That revokes
+				// all of our tokens, then redirects to the OIDC
+				// provider so we're signed out there too.
+				const instanceResult = await fetchWithBQ({ url: "/api/v1/instance" });
+				const instance = instanceResult.data as InstanceV1 | undefined;
+				if (instance?.configuration?.oidc_enabled) {
+					api.dispatch(oauthRemove());
+
+					const form = document.createElement("form");
+					form.method = "POST";
+					form.action = new URL("/auth/sign_out", loginState.instanceUrl ?? window.location.origin).toString();
+
+					const input = document.createElement("input");
+					input.type = "hidden";
+					input.name = "token";
+					input.value = token;
+					form.appendChild(input);
+
+					document.body.appendChild(form);
+					form.submit();
+					return { data: null };
+				}
+
 				// Try to revoke the token. If we fail, just
 				// log the error and clear our state anyway.
 				const invalidateResult = await fetchWithBQ({
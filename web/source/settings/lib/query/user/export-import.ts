@@ -21,7 +21,7 @@ import fileDownload from "js-file-download";
 
 import { gtsApi } from "../gts-api";
 import { FetchBaseQueryError } from "@reduxjs/toolkit/query";
-import { AccountExportStats } from "../../types/account";
+import { AccountArchive, AccountExportStats, OutboxImport } from "../../types/account";
 
 const extended = gtsApi.injectEndpoints({
 	endpoints: (build) => ({
@@ -126,6 +126,59 @@ const extended = gtsApi.injectEndpoints({
 			}
 		}),
 
+		exportBookmarks: build.mutation<string | null, void>({
+			async queryFn(_arg, _api, _extraOpts, fetchWithBQ) {
+				const csvRes = await fetchWithBQ({
+					url: `/api/v1/exports/bookmarks.csv`,
+					acceptContentType: "text/csv",
+				});
+				if (csvRes.error) {
+					return { error: csvRes.error as FetchBaseQueryError };
+				}
+
+				if (csvRes.meta?.response?.status !== 200) {
+					return { error: csvRes.data };
+				}
+
+				fileDownload(csvRes.data, "bookmarks.csv", "text/csv");
+				return { data: null };
+			}
+		}),
+
+		exportArchives: build.query<AccountArchive[], void>({
+			query: () => ({
+				url: `/api/v1/exports/archive`
+			}),
+			providesTags: ["AccountArchive"],
+		}),
+
+		requestArchive: build.mutation<AccountArchive, void>({
+			query: () => ({
+				method: "POST",
+				url: `/api/v1/exports/archive`,
+			}),
+			invalidatesTags: ["AccountArchive"],
+		}),
+
+		downloadArchive: build.mutation<string | null, string>({
+			async queryFn(id, _api, _extraOpts, fetchWithBQ) {
+				const zipRes = await fetchWithBQ({
+					url: `/api/v1/exports/archive/${id}`,
+					acceptContentType: "application/zip",
+				});
+				if (zipRes.error) {
+					return { error: zipRes.error as FetchBaseQueryError };
+				}
+
+				if (zipRes.meta?.response?.status !== 200) {
+					return { error: zipRes.data };
+				}
+
+				fileDownload(zipRes.data, `archive-${id}.zip`, "application/zip");
+				return { data: null };
+			}
+		}),
+
 		importData: build.mutation({
 			query: (formData) => ({
 				method: "POST",
@@ -134,6 +187,15 @@ const extended = gtsApi.injectEndpoints({
 				body: formData,
 				discardEmpty: true
 			}),
+			invalidatesTags: (_res, _error, formData) =>
+				formData?.type === "outbox" ? ["OutboxImport"] : [],
+		}),
+
+		outboxImports: build.query<OutboxImport[], void>({
+			query: () => ({
+				url: `/api/v1/import/outbox`
+			}),
+			providesTags: ["OutboxImport"],
 		}),
 	})
 });
@@ -145,5 +207,10 @@ export const {
 	useExportListsMutation,
 	useExportBlocksMutation,
 	useExportMutesMutation,
+	useExportBookmarksMutation,
+	useExportArchivesQuery,
+	useRequestArchiveMutation,
+	useDownloadArchiveMutation,
 	useImportDataMutation,
+	useOutboxImportsQuery,
 } = extended;
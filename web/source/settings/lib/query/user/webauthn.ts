@@ -0,0 +1,151 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { gtsApi } from "../gts-api";
+import { FetchBaseQueryError } from "@reduxjs/toolkit/query";
+import { WebAuthnCredential, WebAuthnRegisterParams } from "../../types/user";
+
+function b64urlToBuffer(str: string): ArrayBuffer {
+	const b64 = str.replace(/-/g, "+").replace(/_/g, "/");
+	const padded = b64 + "===".slice((b64.length + 3) % 4);
+	return Uint8Array.from(atob(padded), (c) => c.charCodeAt(0)).buffer;
+}
+
+function bufferToB64url(buf: ArrayBuffer): string {
+	let str = "";
+	new Uint8Array(buf).forEach((b) => {
+		str += String.fromCharCode(b);
+	});
+	return btoa(str).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+}
+
+// Parse JSON creation options, for browsers without
+// PublicKeyCredential.parseCreationOptionsFromJSON.
+function parseCreationOptions(options): PublicKeyCredentialCreationOptions {
+	const pkc = PublicKeyCredential as any;
+	if (typeof pkc.parseCreationOptionsFromJSON === "function") {
+		return pkc.parseCreationOptionsFromJSON(options);
+	}
+
+	return {
+		...options,
+		challenge: b64urlToBuffer(options.challenge),
+		user: { ...options.user, id: b64urlToBuffer(options.user.id) },
+		excludeCredentials: (options.excludeCredentials ?? []).map((cred) => {
+			return { ...cred, id: b64urlToBuffer(cred.id) };
+		}),
+	};
+}
+
+// Serialize a new credential to JSON, for
+// browsers without PublicKeyCredential.toJSON.
+function credentialToJSON(cred: PublicKeyCredential) {
+	const c = cred as any;
+	if (typeof c.toJSON === "function") {
+		return c.toJSON();
+	}
+
+	const resp = cred.response as AuthenticatorAttestationResponse;
+	return {
+		id: cred.id,
+		rawId: bufferToB64url(cred.rawId),
+		type: cred.type,
+		response: {
+			clientDataJSON: bufferToB64url(resp.clientDataJSON),
+			attestationObject: bufferToB64url(resp.attestationObject),
+			transports: resp.getTransports ? resp.getTransports() : [],
+		},
+	};
+}
+
+const extended = gtsApi.injectEndpoints({
+	endpoints: (build) => ({
+		webAuthnCredentials: build.query<WebAuthnCredential[], void>({
+			query: () => ({
+				url: `/api/v1/user/webauthn`,
+			}),
+			providesTags: ["WebAuthnCredential"],
+		}),
+
+		// Register a new credential by fetching creation
+		// options from the server, asking the browser to
+		// create a credential using those options, and
+		// then submitting the result back to the server.
+		webAuthnRegister: build.mutation<WebAuthnCredential, WebAuthnRegisterParams>({
+			async queryFn(formData, _api, _extraOpts, fetchWithBQ) {
+				if (!window.PublicKeyCredential || !navigator.credentials) {
+					return { error: { status: 400, data: { error: "Your browser does not support security keys / passkeys" } } };
+				}
+
+				const optionsRes = await fetchWithBQ({
+					method: "POST",
+					url: `/api/v1/user/webauthn/options`,
+					asForm: true,
+					body: { passwordless: formData.passwordless },
+				});
+				if (optionsRes.error) {
+					return { error: optionsRes.error as FetchBaseQueryError };
+				}
+
+				let cred: PublicKeyCredential;
+				try {
+					cred = await navigator.credentials.create({
+						publicKey: parseCreationOptions(optionsRes.data),
+					}) as PublicKeyCredential;
+				} catch (e) {
+					return { error: { status: 400, data: { error: (e as Error).message } } };
+				}
+
+				const registerRes = await fetchWithBQ({
+					method: "POST",
+					url: `/api/v1/user/webauthn`,
+					asForm: true,
+					body: {
+						name: formData.name,
+						passwordless: formData.passwordless,
+						credential: JSON.stringify(credentialToJSON(cred)),
+					},
+				});
+				if (registerRes.error) {
+					return { error: registerRes.error as FetchBaseQueryError };
+				}
+
+				return { data: registerRes.data as WebAuthnCredential };
+			},
+			invalidatesTags: ["WebAuthnCredential"],
+		}),
+
+		webAuthnDelete: build.mutation<void, { id: string, password: string }>({
+			query: ({ id, password }) => ({
+				method: "POST",
+				url: `/api/v1/user/webauthn/${id}/delete`,
+				asForm: true,
+				body: { password },
+				acceptContentType: "*/*",
+			}),
+			invalidatesTags: ["WebAuthnCredential"],
+		}),
+	})
+});
+
+export const {
+	useWebAuthnCredentialsQuery,
+	useWebAuthnRegisterMutation,
+	useWebAuthnDeleteMutation,
+} = extended;
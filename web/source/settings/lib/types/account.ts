@@ -30,6 +30,7 @@ export interface AdminAccount {
 	ips: [],
 	locale: string,
 	invite_request: string | null,
+	invited_by_account_id?: string,
 	role: any,
 	confirmed: boolean,
 	approved: boolean,
@@ -98,6 +99,17 @@ export interface SearchAccountParams {
 	limit?: number,
 }
 
+export interface AdminExternalIdentity {
+	id: string;
+	provider: string;
+	subject: string;
+	email: string;
+	groups: string[];
+	created_at: string;
+	last_login_at: string;
+	last_synced_at: string;
+}
+
 export interface SearchAccountResp {
 	accounts: AdminAccount[];
 	links: Links | null;
@@ -125,4 +137,25 @@ export interface AccountExportStats {
 	lists_count: number;
 	blocks_count: number;
 	mutes_count: number;
+	bookmarks_count: number;
+}
+
+export interface AccountArchive {
+	id: string;
+	created_at: string;
+	state: "pending" | "ready" | "failed";
+	size?: number;
+	url?: string;
+	expires_at: string;
+}
+
+export interface OutboxImport {
+	id: string;
+	created_at: string;
+	updated_at: string;
+	state: "processing" | "finished" | "failed";
+	federate: boolean;
+	total: number;
+	imported: number;
+	skipped: number;
 }
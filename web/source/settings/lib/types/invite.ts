@@ -0,0 +1,97 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import { Links } from "parse-link-header";
+import { Account } from "./account";
+
+export interface Invite {
+	id: string;
+	code: string;
+	url: string;
+	created_at: string;
+	expires_at: string | null;
+	max_uses: number | null;
+	uses: number;
+	autofollow: boolean;
+	revoked_at: string | null;
+	valid: boolean;
+	/**
+	 * Account that created the invite.
+	 * Only set in admin views.
+	 */
+	account?: Account;
+}
+
+/**
+ * Parameters for POST to /api/v1/user/invites.
+ */
+export interface CreateInviteParams {
+	/**
+	 * How many times the invite can be
+	 * used to sign up. 0 for unlimited.
+	 */
+	max_uses: number;
+	/**
+	 * Seconds from now until the
+	 * invite expires. 0 for never.
+	 */
+	expires_in: number;
+	/**
+	 * Have people who sign up with
+	 * the invite follow the creator.
+	 */
+	autofollow: boolean;
+}
+
+/**
+ * Parameters for GET to /api/v1/user/invites
+ * and /api/v1/admin/invites.
+ */
+export interface SearchInvitesParams {
+	/**
+	 * If set, show only invites created by the given account.
+	 * Only used for /api/v1/admin/invites.
+	 */
+	account_id?: string;
+	/**
+	 * If set, show only items older (ie., lower) than the given ID.
+	 * Item with the given ID will not be included in response.
+	 */
+	max_id?: string;
+	/**
+	 * If set, show only items newer (ie., higher) than the given ID.
+	 * Item with the given ID will not be included in response.
+	 */
+	since_id?: string;
+	/**
+	 * If set, show only items *immediately newer* than the given ID.
+	 * Item with the given ID will not be included in response.
+	 */
+	min_id?: string;
+	/**
+	 * If set, limit returned items to this number.
+	 * Else, fall back to GtS API defaults.
+	 */
+	limit?: number;
+}
+
+export interface SearchInvitesResp {
+	invites: Invite[];
+	links: Links | null;
+}
@@ -33,3 +33,17 @@ export interface User {
 	reset_password_sent_at?: string;
 	two_factor_enabled_at?: string;
 }
+
+export interface WebAuthnCredential {
+	id: string;
+	name: string;
+	created_at: string;
+	last_used_at?: string;
+	passwordless: boolean;
+	transports?: string[];
+}
+
+export interface WebAuthnRegisterParams {
+	name: string;
+	passwordless: boolean;
+}
@@ -0,0 +1,158 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { ReactNode, useEffect, useMemo } from "react";
+import { useLocation, useSearch } from "wouter";
+import { Select, TextInput } from "../../../components/form/inputs";
+import MutationButton from "../../../components/form/mutation-button";
+import { PageableList } from "../../../components/pageable-list";
+import { InviteListEntry } from "../../../components/invite";
+import { useTextInput } from "../../../lib/form";
+import {
+	useAdminRevokeInviteMutation,
+	useLazySearchAdminInvitesQuery,
+} from "../../../lib/query/admin/invites";
+import { Invite } from "../../../lib/types/invite";
+
+export default function InstanceInvites() {
+	return (
+		<div className="invites-view">
+			<div className="form-section-docs">
+				<h1>Invites</h1>
+				<p>
+					On this page you can view invite links created by users of this instance,
+					and revoke invites so that they can no longer be used to sign up.
+					Revoking an invite doesn't affect accounts that already signed up with it.
+					<br/><br/>
+					To create an invite of your own, use the "invites" section of your user settings.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/admin/signups/#sign-up-via-invite"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about invites (opens in a new tab)
+				</a>
+			</div>
+			<InvitesSearchForm />
+		</div>
+	);
+}
+
+function InvitesSearchForm() {
+	const [ location, setLocation ] = useLocation();
+	const search = useSearch();
+	const urlQueryParams = useMemo(() => new URLSearchParams(search), [search]);
+	const [ searchInvites, searchRes ] = useLazySearchAdminInvitesQuery();
+
+	// Populate search form using values from
+	// urlQueryParams, to allow paging.
+	const form = {
+		account_id: useTextInput("account_id", { defaultValue: urlQueryParams.get("account_id") ?? "" }),
+		limit: useTextInput("limit", { defaultValue: urlQueryParams.get("limit") ?? "25" })
+	};
+
+	// On mount, trigger search.
+	useEffect(() => {
+		searchInvites(Object.fromEntries(urlQueryParams), true);
+	}, [urlQueryParams, searchInvites]);
+
+	// Rather than triggering the search directly,
+	// the "submit" button changes the location
+	// based on form field params, and lets the
+	// useEffect hook above actually do the search.
+	function submitQuery(e) {
+		e.preventDefault();
+
+		// Parse query parameters.
+		const entries = Object.entries(form).map(([k, v]) => {
+			// Take only defined form fields.
+			if (v.value === undefined) {
+				return null;
+			} else if (typeof v.value === "string" && v.value.length === 0) {
+				return null;
+			}
+
+			return [[k, v.value.toString()]];
+		}).flatMap(kv => {
+			// Remove any nulls.
+			return kv !== null ? kv : [];
+		});
+
+		const searchParams = new URLSearchParams(entries);
+		setLocation(location + "?" + searchParams.toString());
+	}
+
+	// Function to map an item to a list entry.
+	function itemToEntry(invite: Invite): ReactNode {
+		return (
+			<InviteListEntry
+				key={invite.id}
+				invite={invite}
+				useRevokeMutation={useAdminRevokeInviteMutation}
+			/>
+		);
+	}
+
+	return (
+		<>
+			<form
+				onSubmit={submitQuery}
+				// Prevent password managers
+				// trying to fill in fields.
+				autoComplete="off"
+			>
+				<TextInput
+					field={form.account_id}
+					label="Created by account ID (optional)"
+					placeholder="01FC0SKA48HNSVR6YKZCQGS2V8"
+				/>
+				<Select
+					field={form.limit}
+					label="Items per page"
+					options={
+						<>
+							<option value="25">25</option>
+							<option value="50">50</option>
+							<option value="75">75</option>
+							<option value="100">100</option>
+						</>
+					}
+				></Select>
+				<MutationButton
+					disabled={false}
+					label={"Search"}
+					result={searchRes}
+				/>
+			</form>
+			<PageableList
+				isLoading={searchRes.isLoading}
+				isFetching={searchRes.isFetching}
+				isSuccess={searchRes.isSuccess}
+				items={searchRes.data?.invites}
+				itemToEntry={itemToEntry}
+				isError={searchRes.isError}
+				error={searchRes.error}
+				emptyMessage={<b>No invites found.</b>}
+				prevNextLinks={searchRes.data?.links}
+			/>
+		</>
+	);
+}
@@ -29,6 +29,7 @@ import { useHasPermission, useInstanceDebug } from "../../lib/navigation/util";
  * - /settings/admin/instance/settings
  * - /settings/admin/instance/rules
  * - /settings/admin/instance/rules/:ruleId
+ * - /settings/admin/instance/invites
  * - /settings/admin/emojis
  * - /settings/admin/emojis/local
  * - /settings/admin/emojis/local/:emojiId
@@ -87,6 +88,11 @@ function AdminInstanceMenu() {
 				itemUrl="rules"
 				icon="fa-dot-circle-o"
 			/>
+			<MenuItem
+				name="Invites"
+				itemUrl="invites"
+				icon="fa-envelope-open"
+			/>
 		</MenuItem>
 	);
 }
@@ -24,6 +24,7 @@ import { ErrorBoundary } from "../../lib/navigation/error";
 import InstanceSettings from "./instance/settings";
 import InstanceRules from "./instance/rules";
 import InstanceRuleDetail from "./instance/ruledetail";
+import InstanceInvites from "./instance/invites";
 import Media from "./actions/media";
 import Keys from "./actions/keys";
 import EmojiOverview from "./emoji/local/overview";
@@ -43,6 +44,7 @@ import Caches from "./debug/caches";
  * - /settings/admin/instance/settings
  * - /settings/admin/instance/rules
  * - /settings/admin/instance/rules/:ruleId
+ * - /settings/admin/instance/invites
  * - /settings/admin/emojis
  * - /settings/admin/emojis/local
  * - /settings/admin/emojis/local/:emojiId
@@ -157,6 +159,7 @@ function AdminInstanceRouter() {
 						<Route path="/settings" component={InstanceSettings}/>
 						<Route path="/rules" component={InstanceRules} />
 						<Route path="/rules/:ruleId" component={InstanceRuleDetail} />
+						<Route path="/invites" component={InstanceInvites} />
 						<Route><Redirect to="/settings" /></Route>
 					</Switch>
 				</ErrorBoundary>
@@ -19,14 +19,16 @@
 
 import React from "react";
 
-import { useGetAccountQuery } from "../../../../lib/query/admin";
+import { useGetAccountExternalIdentitiesQuery, useGetAccountQuery } from "../../../../lib/query/admin";
+import { useInstanceV1Query } from "../../../../lib/query/gts-api";
 import FormWithData from "../../../../lib/form/form-with-data";
 import FakeProfile from "../../../../components/profile";
-import { AdminAccount } from "../../../../lib/types/account";
+import { AdminAccount, AdminExternalIdentity } from "../../../../lib/types/account";
 import { AccountActions } from "./actions";
 import { useParams } from "wouter";
 import { useBaseUrl } from "../../../../lib/navigation/util";
 import BackButton from "../../../../components/back-button";
+import UsernameLozenge from "../../../../components/username-lozenge";
 import { UseOurInstanceAccount, yesOrNo } from "../../../../lib/util";
 
 export default function AccountDetail() {
@@ -81,6 +83,7 @@ function AccountDetailForm({ data: adminAcct, backLocation }: AccountDetailFormP
 				// if this is a local account!
 				local && <LocalAccountDetails adminAcct={adminAcct} />
 			}
+			{ local && <ExternalIdentities adminAcct={adminAcct} /> }
 			<AccountActions
 				account={adminAcct}
 				backLocation={backLocation}
@@ -159,6 +162,8 @@ function GeneralAccountDetails({ adminAcct } : { adminAcct: AdminAccount }) {
 }
 
 function LocalAccountDetails({ adminAcct }: { adminAcct: AdminAccount }) {	
+	const baseUrl = useBaseUrl();
+
 	return (
 		<>
 			<h3>Local Account Details</h3>
@@ -191,6 +196,16 @@ function LocalAccountDetails({ adminAcct }: { adminAcct: AdminAccount }) {
 					<dt>Sign-Up Reason</dt>
 					<dd>{adminAcct.invite_request ?? <i>none provided</i>}</dd>
 				</div>
+				{ adminAcct.invited_by_account_id &&
+					<div className="info-list-entry">
+						<dt>Invited By</dt>
+						<dd>
+							<UsernameLozenge
+								account={adminAcct.invited_by_account_id}
+								linkTo={`~${baseUrl}/${adminAcct.invited_by_account_id}`}
+							/>
+						</dd>
+					</div> }
 				{ (adminAcct.ip && adminAcct.ip !== "0.0.0.0") &&
 					<div className="info-list-entry">
 						<dt>Sign-Up IP</dt>
@@ -205,3 +220,65 @@ function LocalAccountDetails({ adminAcct }: { adminAcct: AdminAccount }) {
 		</> 
 	);
 }
+
+function ExternalIdentities({ adminAcct }: { adminAcct: AdminAccount }) {
+	const { data: instance } = useInstanceV1Query();
+	const oidcEnabled = instance?.configuration.oidc_enabled;
+
+	// Only bother fetching identities if
+	// OIDC is in use for this instance.
+	const { data: identities } = useGetAccountExternalIdentitiesQuery(
+		adminAcct.id,
+		{ skip: !oidcEnabled },
+	);
+
+	if (!oidcEnabled || identities === undefined) {
+		return null;
+	}
+
+	return (
+		<>
+			<h3>OIDC Identities</h3>
+			{ identities.length === 0
+				? <p>This account is not linked to any identity at an OIDC provider.</p>
+				: identities.map((identity) => (
+					<ExternalIdentity key={identity.id} identity={identity} />
+				))
+			}
+		</>
+	);
+}
+
+function ExternalIdentity({ identity }: { identity: AdminExternalIdentity }) {
+	const lastLogin = new Date(identity.last_login_at).toDateString();
+	const lastSynced = new Date(identity.last_synced_at).toDateString();
+
+	return (
+		<dl className="info-list">
+			<div className="info-list-entry">
+				<dt>Provider</dt>
+				<dd>{identity.provider}</dd>
+			</div>
+			<div className="info-list-entry">
+				<dt>Subject</dt>
+				<dd>{identity.subject}</dd>
+			</div>
+			<div className="info-list-entry">
+				<dt>Email</dt>
+				<dd>{identity.email || <i>none provided</i>}</dd>
+			</div>
+			<div className="info-list-entry">
+				<dt>Groups</dt>
+				<dd>{identity.groups.length !== 0 ? identity.groups.join(", ") : <i>none</i>}</dd>
+			</div>
+			<div className="info-list-entry">
+				<dt>Last sign-in</dt>
+				<dd><time dateTime={identity.last_login_at}>{lastLogin}</time></dd>
+			</div>
+			<div className="info-list-entry">
+				<dt>Groups last synced</dt>
+				<dd><time dateTime={identity.last_synced_at}>{lastSynced}</time></dd>
+			</div>
+		</dl>
+	);
+}
@@ -21,6 +21,7 @@ import React from "react";
 import EmailChange from "./email";
 import PasswordChange from "./password";
 import TwoFactor from "./twofactor";
+import SecurityKeys from "./securitykeys";
 import { useInstanceV1Query } from "../../../lib/query/gts-api";
 import Loading from "../../../components/loading";
 import { useUserQuery } from "../../../lib/query/user";
@@ -69,6 +70,9 @@ export default function Account() {
 				oidcEnabled={instance.configuration.oidc_enabled}
 				twoFactorEnabledAt={user.two_factor_enabled_at}
 			/>
+			<SecurityKeys
+				oidcEnabled={instance.configuration.oidc_enabled}
+			/>
 		</>
 	);
 }
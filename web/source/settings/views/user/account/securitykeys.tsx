@@ -0,0 +1,226 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React from "react";
+import { Checkbox, Select, TextInput } from "../../../components/form/inputs";
+import MutationButton from "../../../components/form/mutation-button";
+import useFormSubmit from "../../../lib/form/submit";
+import { useBoolInput, useTextInput } from "../../../lib/form";
+import Loading from "../../../components/loading";
+import { Error } from "../../../components/error";
+import {
+	useWebAuthnCredentialsQuery,
+	useWebAuthnDeleteMutation,
+	useWebAuthnRegisterMutation,
+} from "../../../lib/query/user/webauthn";
+import { WebAuthnCredential } from "../../../lib/types/user";
+
+export default function SecurityKeys({ oidcEnabled }: { oidcEnabled?: boolean }) {
+	if (oidcEnabled) {
+		// Can't manage keys if OIDC is in place.
+		return (
+			<form>
+				<SecurityKeysHeader
+					blurb={
+						<p>
+							OIDC is enabled for your instance. To use security keys or passkeys,
+							you must use your instance's OIDC provider instead. Poke your admin
+							for more information.
+						</p>
+					}
+				/>
+			</form>
+		);
+	}
+
+	return <SecurityKeysForms />;
+}
+
+function SecurityKeysForms() {
+	const {
+		data: credentials,
+		isLoading,
+		isFetching,
+		isError,
+		error,
+	} = useWebAuthnCredentialsQuery();
+
+	let content: React.ReactNode;
+	if (isLoading || isFetching) {
+		content = <Loading />;
+	} else if (isError) {
+		content = <Error error={error} />;
+	} else if (!credentials || credentials.length === 0) {
+		content = <b>You haven't added any security keys or passkeys yet.</b>;
+	} else {
+		content = (
+			<ul className="webauthn-credentials">
+				{credentials.map((cred) => <CredentialEntry key={cred.id} cred={cred} />)}
+			</ul>
+		);
+	}
+
+	return (
+		<>
+			<form className="webauthn-register-form" onSubmit={(e) => e.preventDefault()}>
+				<SecurityKeysHeader
+					blurb={
+						<p>
+							Security keys (eg., a Yubikey) and passkeys can be used as a second factor
+							when signing in, as an alternative to a code from an authenticator app.
+							<br/>Passkeys can also be used to sign in without a password.
+						</p>
+					}
+				/>
+				{content}
+			</form>
+			<RegisterForm />
+			{ credentials && credentials.length !== 0 &&
+				// Key on credential IDs to reset
+				// the form when the list changes.
+				<DeleteForm
+					key={credentials.map((cred) => cred.id).join()}
+					credentials={credentials}
+				/>
+			}
+		</>
+	);
+}
+
+function CredentialEntry({ cred }: { cred: WebAuthnCredential }) {
+	const createdAt = new Date(cred.created_at).toDateString();
+	const lastUsedAt = cred.last_used_at
+		? new Date(cred.last_used_at).toDateString()
+		: "never";
+
+	return (
+		<li>
+			<b>{cred.name}</b>{cred.passwordless && " (passkey)"}
+			<br/>Added <time dateTime={cred.created_at}>{createdAt}</time>,
+			last used {cred.last_used_at
+				? <time dateTime={cred.last_used_at}>{lastUsedAt}</time>
+				: lastUsedAt
+			}.
+		</li>
+	);
+}
+
+function RegisterForm() {
+	const form = {
+		name: useTextInput("name"),
+		passwordless: useBoolInput("passwordless", { defaultValue: false }),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useWebAuthnRegisterMutation(), {
+		changedOnly: false,
+		onFinish: (res) => {
+			if (res.error) {
+				return;
+			}
+			form.name.reset();
+			form.passwordless.reset();
+		},
+	});
+
+	return (
+		<form className="webauthn-register-form" onSubmit={submitForm}>
+			<h4>Add security key or passkey</h4>
+			<TextInput
+				name="name"
+				field={form.name}
+				label="Name for this key (eg., 'Yubikey' or 'Phone')"
+				autoComplete="off"
+				maxLength={64}
+				required={true}
+			/>
+			<Checkbox
+				field={form.passwordless}
+				label="Allow this key to be used to sign in without a password (passkey)"
+			/>
+			<MutationButton
+				label="Add key"
+				result={result}
+				disabled={!form.name.value}
+			/>
+		</form>
+	);
+}
+
+function DeleteForm({ credentials }: { credentials: WebAuthnCredential[] }) {
+	const form = {
+		id: useTextInput("id", { defaultValue: credentials[0].id }),
+		password: useTextInput("password"),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useWebAuthnDeleteMutation(), {
+		changedOnly: false,
+		onFinish: (res) => {
+			if (res.error) {
+				return;
+			}
+			form.id.reset();
+			form.password.reset();
+		},
+	});
+
+	return (
+		<form className="webauthn-delete-form" onSubmit={submitForm}>
+			<h4>Remove security key or passkey</h4>
+			<Select
+				field={form.id}
+				label="Key to remove"
+				options={
+					<>
+						{credentials.map((cred) => <option key={cred.id} value={cred.id}>{cred.name}</option>)}
+					</>
+				}
+			/>
+			<TextInput
+				type="password"
+				name="password"
+				field={form.password}
+				label="Current password"
+				autoComplete="current-password"
+			/>
+			<MutationButton
+				label="Remove key"
+				result={result}
+				disabled={!form.password.value}
+				className="danger"
+			/>
+		</form>
+	);
+}
+
+function SecurityKeysHeader({ blurb }: { blurb: React.ReactNode }) {
+	return (
+		<div className="form-section-docs">
+			<h3>Security Keys and Passkeys</h3>
+			{blurb}
+			<a
+				href="https://docs.gotosocial.org/en/latest/user_guide/settings/#security-keys-and-passkeys"
+				target="_blank"
+				className="docslink"
+				rel="noreferrer"
+			>
+				Learn more about this (opens in a new tab)
+			</a>
+		</div>
+	);
+}
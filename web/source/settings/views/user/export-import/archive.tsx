@@ -0,0 +1,132 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React from "react";
+import {
+	useExportArchivesQuery,
+	useRequestArchiveMutation,
+	useDownloadArchiveMutation,
+} from "../../../lib/query/user/export-import";
+import MutationButton from "../../../components/form/mutation-button";
+import useFormSubmit from "../../../lib/form/submit";
+import { useValue } from "../../../lib/form";
+import Loading from "../../../components/loading";
+import { Error } from "../../../components/error";
+import { AccountArchive } from "../../../lib/types/account";
+
+export default function Archive() {
+	const {
+		data: archives,
+		isLoading,
+		isFetching,
+		isError,
+		error,
+	} = useExportArchivesQuery();
+
+	const [requestArchive, requestArchiveResult] = useFormSubmit(
+		// Use a dummy value.
+		{ type: useValue("requestArchive", "requestArchive") },
+		// Mutation we're wrapping.
+		useRequestArchiveMutation(),
+		// Form never changes but
+		// we want to always trigger.
+		{ changedOnly: false },
+	);
+
+	let content: React.JSX.Element;
+	if (isLoading || isFetching) {
+		content = <Loading />;
+	} else if (isError) {
+		content = <Error error={error} />;
+	} else if (!archives || archives.length === 0) {
+		content = <span>You haven't requested any archives yet.</span>;
+	} else {
+		content = (
+			<div className="export-buttons-wrapper">
+				{archives.map((archive) => <ArchiveEntry key={archive.id} archive={archive} />)}
+			</div>
+		);
+	}
+
+	return (
+		<form className="export-data">
+			<div className="form-section-docs">
+				<h3>Account Archive</h3>
+				<p>
+					Request a Mastodon-compatible ZIP archive of your profile, posts,
+					boosts, likes, bookmarks, and media. The archive is prepared in
+					the background, and you'll be sent an email when it's ready.
+					Archives are removed a week after being requested.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#account-archive"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+				Learn more about this section (opens in a new tab)
+				</a>
+			</div>
+
+			{content}
+
+			<MutationButton
+				label="Request archive"
+				type="button"
+				onClick={() => requestArchive()}
+				result={requestArchiveResult}
+				showError={true}
+				disabled={archives?.some((archive) => archive.state !== "failed") ?? true}
+			/>
+		</form>
+	);
+}
+
+function ArchiveEntry({ archive }: { archive: AccountArchive }) {
+	const [downloadArchive, downloadArchiveResult] = useDownloadArchiveMutation();
+	const created = new Date(archive.created_at).toLocaleString();
+
+	let status: string;
+	switch (archive.state) {
+		case "pending":
+			status = "being prepared";
+			break;
+		case "ready":
+			status = `${((archive.size ?? 0) / 1048576).toFixed(1)}MiB, available until ${new Date(archive.expires_at).toLocaleString()}`;
+			break;
+		default:
+			status = "failed";
+	}
+
+	return (
+		<div className="stats-and-button">
+			<span className="text-cutoff">
+				Requested {created} ({status})
+			</span>
+			<MutationButton
+				label="Download archive"
+				type="button"
+				onClick={() => downloadArchive(archive.id)}
+				result={downloadArchiveResult}
+				showError={true}
+				disabled={archive.state !== "ready"}
+			/>
+		</div>
+	);
+}
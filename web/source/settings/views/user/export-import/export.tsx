@@ -24,6 +24,7 @@ import {
 	useExportListsMutation,
 	useExportBlocksMutation,
 	useExportMutesMutation,
+	useExportBookmarksMutation,
 } from "../../../lib/query/user/export-import";
 import MutationButton from "../../../components/form/mutation-button";
 import useFormSubmit from "../../../lib/form/submit";
@@ -81,6 +82,16 @@ export default function Export({ exportStats }: { exportStats: AccountExportStat
 		// we want to always trigger.
 		{ changedOnly: false },
 	);
+
+	const [exportBookmarks, exportBookmarksResult] = useFormSubmit(
+		// Use a dummy value.
+		{ type: useValue("exportBookmarks", "exportBookmarks") },
+		// Mutation we're wrapping.
+		useExportBookmarksMutation(),
+		// Form never changes but
+		// we want to always trigger.
+		{ changedOnly: false },
+	);
 	
 	return (
 		<form className="export-data">
@@ -162,6 +173,19 @@ export default function Export({ exportStats }: { exportStats: AccountExportStat
 						disabled={exportStats.mutes_count === 0}
 					/>
 				</div>
+				<div className="stats-and-button">
+					<span className="text-cutoff">
+						Bookmarked {exportStats.bookmarks_count} post{ exportStats.bookmarks_count !== 1 && "s" }
+					</span>
+					<MutationButton
+						label="Download bookmarks.csv"
+						type="button"
+						onClick={() => exportBookmarks()}
+						result={exportBookmarksResult}
+						showError={true}
+						disabled={exportStats.bookmarks_count === 0}
+					/>
+				</div>
 			</div>
 		</form>
 	);
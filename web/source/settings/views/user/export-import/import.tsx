@@ -18,18 +18,21 @@
 */
 
 import React from "react";
-import { useImportDataMutation } from "../../../lib/query/user/export-import";
+import { useImportDataMutation, useOutboxImportsQuery } from "../../../lib/query/user/export-import";
 import MutationButton from "../../../components/form/mutation-button";
 import useFormSubmit from "../../../lib/form/submit";
-import { useFileInput, useTextInput } from "../../../lib/form";
-import { FileInput, Select } from "../../../components/form/inputs";
+import { useBoolInput, useFileInput, useTextInput } from "../../../lib/form";
+import { Checkbox, FileInput, Select } from "../../../components/form/inputs";
+import { OutboxImport } from "../../../lib/types/account";
 
 export default function Import() {
 	const form = {
 		data: useFileInput("data"),
 		type: useTextInput("type", { defaultValue: "" }),
-		mode: useTextInput("mode", { defaultValue: "" })
+		mode: useTextInput("mode", { defaultValue: "" }),
+		federate: useBoolInput("federate", { defaultValue: false }),
 	};
+	const outbox = form.type.value === "outbox";
 
 	const [submitForm, result] = useFormSubmit(form, useImportDataMutation(), {
 		changedOnly: false,
@@ -37,6 +40,7 @@ export default function Import() {
 			form.data.reset();
 			form.type.reset();
 			form.mode.reset();
+			form.federate.reset();
 		}
 	});
 	
@@ -55,9 +59,9 @@ export default function Import() {
 			</div>
 			
 			<FileInput
-				label="CSV data file"
+				label={outbox ? "Archive ZIP file" : "CSV data file"}
 				field={form.data}
-				accept="text/csv"
+				accept={outbox ? "application/zip" : "text/csv"}
 			/>
 
 			<Select
@@ -69,31 +73,81 @@ export default function Import() {
 						<option value="following">Following list</option>
 						<option value="blocks">Blocked accounts list</option>
 						<option value="mutes">Muted accounts list</option>
+						<option value="lists">Lists</option>
+						<option value="bookmarks">Bookmarks</option>
+						<option value="outbox">Posts (outbox) from an archive</option>
 					</>
 				}>
 			</Select>
 
-			<Select
-				field={form.mode}
-				label="Import mode"
-				options={
-					<>
-						<option value="">- Select import mode -</option>
-						<option value="merge">Merge (recommended): add to existing records</option>
-						<option value="overwrite">Overwrite: replace existing records</option>
-					</>
-				}>
-			</Select>
+			{outbox
+				? <Checkbox
+					label="Federate imported posts to your followers"
+					field={form.federate}
+				/>
+				: <Select
+					field={form.mode}
+					label="Import mode"
+					options={
+						<>
+							<option value="">- Select import mode -</option>
+							<option value="merge">Merge (recommended): add to existing records</option>
+							<option value="overwrite">Overwrite: replace existing records</option>
+						</>
+					}>
+				</Select>
+			}
 
 			<MutationButton
 				disabled={
 					form.data.value === undefined ||
 					!form.type.value ||
-					!form.mode.value
+					(!outbox && !form.mode.value)
 				}
 				label="Import"
 				result={result}
 			/>
+
+			<OutboxImports />
 		</form>
 	);
 }
+
+function OutboxImports() {
+	const { data: outboxImports } = useOutboxImportsQuery();
+	if (!outboxImports || outboxImports.length === 0) {
+		return null;
+	}
+
+	return (
+		<div className="outbox-imports">
+			<h4>Posts imports</h4>
+			{outboxImports.map((outboxImport) =>
+				<OutboxImportEntry key={outboxImport.id} outboxImport={outboxImport} />
+			)}
+		</div>
+	);
+}
+
+function OutboxImportEntry({ outboxImport }: { outboxImport: OutboxImport }) {
+	const created = new Date(outboxImport.created_at).toLocaleString();
+	const { total, imported, skipped } = outboxImport;
+
+	let status: string;
+	switch (outboxImport.state) {
+		case "processing":
+			status = `in progress, ${imported + skipped} of ${total} posts processed`;
+			break;
+		case "finished":
+			status = `finished, ${imported} of ${total} posts imported, ${skipped} skipped`;
+			break;
+		default:
+			status = `failed after importing ${imported} of ${total} posts`;
+	}
+
+	return (
+		<span className="text-cutoff">
+			Started {created} ({status})
+		</span>
+	);
+}
@@ -57,6 +57,9 @@ export default function InteractionRequestsSearchForm() {
 		boosts: useBoolInput("reblogs", {
 			defaultValue: defaultTrue(urlQueryParams.get("reblogs"))
 		}),
+		quotes: useBoolInput("quotes", {
+			defaultValue: defaultTrue(urlQueryParams.get("quotes"))
+		}),
 	};
 
 	// On mount, trigger search.
@@ -126,6 +129,10 @@ export default function InteractionRequestsSearchForm() {
 					label="Include boosts"
 					field={form.boosts}
 				/>
+				<Checkbox
+					label="Include quotes"
+					field={form.quotes}
+				/>
 				<MutationButton
 					disabled={false}
 					label={"Search"}
@@ -172,7 +179,7 @@ function ReqsListEntry({ req, linkTo, backLocation }: ReqsListEntryProps) {
 	}, [req.account, noun]);
 
 	const ourContent = useContent(req.status);
-	const theirContent = useContent(req.reply);
+	const theirContent = useContent(req.reply ?? req.quote);
 
 	const onClick = (e) => {
 		e.preventDefault();
@@ -0,0 +1,165 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { ReactNode, useEffect, useMemo } from "react";
+import { useSearch } from "wouter";
+import { Checkbox, Select } from "../../../components/form/inputs";
+import MutationButton from "../../../components/form/mutation-button";
+import { PageableList } from "../../../components/pageable-list";
+import { InviteListEntry } from "../../../components/invite";
+import useFormSubmit from "../../../lib/form/submit";
+import { useBoolInput, useTextInput } from "../../../lib/form";
+import {
+	useCreateInviteMutation,
+	useLazySearchInvitesQuery,
+	useRevokeInviteMutation,
+} from "../../../lib/query/user/invites";
+import { Invite } from "../../../lib/types/invite";
+
+export default function Invites() {
+	return (
+		<div className="invites-view">
+			<div className="form-section-docs">
+				<h1>Invites</h1>
+				<p>
+					On this page you can create invite links, which let people sign up to this instance
+					even when sign-ups are closed. People who sign up with an invite don't need to wait
+					for an admin to approve their sign-up.
+					<br/><br/>
+					You're responsible for who you invite, so only share invite links with people you trust.
+					You can revoke an invite at any time to stop it being used.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings/#invites"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+					Learn more about invites (opens in a new tab)
+				</a>
+			</div>
+			<CreateInviteForm />
+			<InvitesList />
+		</div>
+	);
+}
+
+function CreateInviteForm() {
+	const form = {
+		max_uses: useTextInput("max_uses", { defaultValue: "1" }),
+		expires_in: useTextInput("expires_in", { defaultValue: "604800" }),
+		autofollow: useBoolInput("autofollow", { defaultValue: false }),
+	};
+
+	const [submitForm, result] = useFormSubmit(form, useCreateInviteMutation(), {
+		changedOnly: false,
+		onFinish: (res) => {
+			if (res.error) {
+				return;
+			}
+			form.max_uses.reset();
+			form.expires_in.reset();
+			form.autofollow.reset();
+		},
+	});
+
+	return (
+		<form onSubmit={submitForm}>
+			<h2>Create invite</h2>
+			<Select
+				field={form.max_uses}
+				label="Max number of uses"
+				options={
+					<>
+						<option value="1">1 use</option>
+						<option value="5">5 uses</option>
+						<option value="10">10 uses</option>
+						<option value="25">25 uses</option>
+						<option value="50">50 uses</option>
+						<option value="100">100 uses</option>
+						<option value="0">No limit</option>
+					</>
+				}
+			/>
+			<Select
+				field={form.expires_in}
+				label="Expire after"
+				options={
+					<>
+						<option value="1800">30 minutes</option>
+						<option value="3600">1 hour</option>
+						<option value="21600">6 hours</option>
+						<option value="43200">12 hours</option>
+						<option value="86400">1 day</option>
+						<option value="604800">1 week</option>
+						<option value="0">Never</option>
+					</>
+				}
+			/>
+			<Checkbox
+				field={form.autofollow}
+				label="Have people who sign up with this invite follow you"
+			/>
+			<MutationButton
+				label="Create invite"
+				result={result}
+				disabled={false}
+			/>
+		</form>
+	);
+}
+
+function InvitesList() {
+	const search = useSearch();
+	const urlQueryParams = useMemo(() => new URLSearchParams(search), [search]);
+	const [ searchInvites, searchRes ] = useLazySearchInvitesQuery();
+
+	// On mount, and when paging, trigger search.
+	useEffect(() => {
+		searchInvites(Object.fromEntries(urlQueryParams), true);
+	}, [urlQueryParams, searchInvites]);
+
+	// Function to map an item to a list entry.
+	function itemToEntry(invite: Invite): ReactNode {
+		return (
+			<InviteListEntry
+				key={invite.id}
+				invite={invite}
+				useRevokeMutation={useRevokeInviteMutation}
+			/>
+		);
+	}
+
+	return (
+		<>
+			<h2>Your invites</h2>
+			<PageableList
+				isLoading={searchRes.isLoading}
+				isFetching={searchRes.isFetching}
+				isSuccess={searchRes.isSuccess}
+				items={searchRes.data?.invites}
+				itemToEntry={itemToEntry}
+				isError={searchRes.isError}
+				error={searchRes.error}
+				emptyMessage={<b>You haven't created any invites yet.</b>}
+				prevNextLinks={searchRes.data?.links}
+			/>
+		</>
+	);
+}
@@ -19,6 +19,8 @@
 
 import { MenuItem } from "../../lib/navigation/menu";
 import React from "react";
+import { useHasPermission } from "../../lib/navigation/util";
+import { useInstanceV1Query } from "../../lib/query/gts-api";
 
 /**
  * - /settings/user/profile
@@ -27,6 +29,12 @@ import React from "react";
  * - /settings/user/migration
  */
 export default function UserMenu() {	
+	// Only admins can create invites,
+	// unless user invites are enabled.
+	const admin = useHasPermission(["admin"]);
+	const { data: instance } = useInstanceV1Query();
+	const canInvite = admin || instance?.invites_enabled;
+
 	return (
 		<MenuItem
 			name="User"
@@ -68,6 +76,13 @@ export default function UserMenu() {
 				itemUrl="tokens"
 				icon="fa-certificate"
 			/>
+			{ canInvite &&
+				<MenuItem
+					name="Invites"
+					itemUrl="invites"
+					icon="fa-envelope-open"
+				/>
+			}
 			<MenuItem
 				name="Applications"
 				itemUrl="applications"
@@ -82,8 +82,11 @@ function InteractionPoliciesForm({ defaultPolicies }: InteractionPoliciesFormPro
 			can_favourite: assemblePolicyEntry("public", "favourite", formPublic),
 			can_reply: assemblePolicyEntry("public", "reply", formPublic),
 			can_reblog: assemblePolicyEntry("public", "reblog", formPublic),
+			// Not (yet) editable here,
+			// so keep the current value.
+			can_quote: defaultPolicies.public.can_quote,
 		};
-	}, [formPublic]);
+	}, [formPublic, defaultPolicies]);
 	
 	// Sub-form for visibility "unlisted".
 	const formUnlisted = useFormForVis(defaultPolicies.unlisted, "unlisted");
@@ -92,8 +95,11 @@ function InteractionPoliciesForm({ defaultPolicies }: InteractionPoliciesFormPro
 			can_favourite: assemblePolicyEntry("unlisted", "favourite", formUnlisted),
 			can_reply: assemblePolicyEntry("unlisted", "reply", formUnlisted),
 			can_reblog: assemblePolicyEntry("unlisted", "reblog", formUnlisted),
+			// Not (yet) editable here,
+			// so keep the current value.
+			can_quote: defaultPolicies.unlisted.can_quote,
 		};
-	}, [formUnlisted]);
+	}, [formUnlisted, defaultPolicies]);
 	
 	// Sub-form for visibility "private".
 	const formPrivate = useFormForVis(defaultPolicies.private, "private");
@@ -102,8 +108,11 @@ function InteractionPoliciesForm({ defaultPolicies }: InteractionPoliciesFormPro
 			can_favourite: assemblePolicyEntry("private", "favourite", formPrivate),
 			can_reply: assemblePolicyEntry("private", "reply", formPrivate),
 			can_reblog: assemblePolicyEntry("private", "reblog", formPrivate),
+			// Not (yet) editable here,
+			// so keep the current value.
+			can_quote: defaultPolicies.private.can_quote,
 		};
-	}, [formPrivate]);
+	}, [formPrivate, defaultPolicies]);
 
 	const selectedVis = useTextInput("selectedVis", { defaultValue: "public" });
 	
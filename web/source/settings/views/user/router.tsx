@@ -33,6 +33,7 @@ import NewApp from "./applications/new";
 import AppDetail from "./applications/detail";
 import { AppTokenCallback } from "./applications/callback";
 import Migration from "./migration";
+import Invites from "./invites";
 
 /**
  * - /settings/user/profile
@@ -41,6 +42,7 @@ import Migration from "./migration";
  * - /settings/user/migration
  * - /settings/user/export-import
  * - /settings/user/tokens
+ * - /settings/user/invites
  * - /settings/user/interaction_requests
  * - /settings/user/applications
  */
@@ -59,6 +61,7 @@ export default function UserRouter() {
 					<Route path="/migration" component={Migration} />
 					<Route path="/export-import" component={ExportImport} />
 					<Route path="/tokens" component={Tokens} />
+					<Route path="/invites" component={Invites} />
 				</Switch>
 				<InteractionRequestsRouter />
 				<ApplicationsRouter />
@@ -0,0 +1,109 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+	WHAT SHOULD GO IN THIS FILE?
+
+	This script is loaded on the sign-in and 2fa pages, deferred + async.
+	It progressively enhances any form with a data-webauthn-options attribute:
+	if the browser supports WebAuthn, the form's button is shown, and clicking
+	it asks the browser for a security key / passkey assertion using the given
+	options, which is then submitted in the form's hidden "credential" input.
+*/
+
+function b64urlToBuffer(str) {
+	const b64 = str.replace(/-/g, "+").replace(/_/g, "/");
+	const padded = b64 + "===".slice((b64.length + 3) % 4);
+	return Uint8Array.from(atob(padded), (c) => c.charCodeAt(0)).buffer;
+}
+
+function bufferToB64url(buf) {
+	const bytes = new Uint8Array(buf);
+	let str = "";
+	bytes.forEach((b) => {
+		str += String.fromCharCode(b);
+	});
+	return btoa(str).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+}
+
+// Parse JSON request options, for browsers
+// without PublicKeyCredential.parseRequestOptionsFromJSON.
+function parseRequestOptions(options) {
+	if (typeof PublicKeyCredential.parseRequestOptionsFromJSON === "function") {
+		return PublicKeyCredential.parseRequestOptionsFromJSON(options);
+	}
+
+	return Object.assign({}, options, {
+		challenge: b64urlToBuffer(options.challenge),
+		allowCredentials: options.allowCredentials.map((cred) => {
+			return Object.assign({}, cred, { id: b64urlToBuffer(cred.id) });
+		}),
+	});
+}
+
+// Serialize an assertion to JSON, for
+// browsers without PublicKeyCredential.toJSON.
+function assertionToJSON(cred) {
+	if (typeof cred.toJSON === "function") {
+		return cred.toJSON();
+	}
+
+	const resp = cred.response;
+	return {
+		id: cred.id,
+		rawId: bufferToB64url(cred.rawId),
+		type: cred.type,
+		response: {
+			clientDataJSON: bufferToB64url(resp.clientDataJSON),
+			authenticatorData: bufferToB64url(resp.authenticatorData),
+			signature: bufferToB64url(resp.signature),
+			userHandle: resp.userHandle ? bufferToB64url(resp.userHandle) : "",
+		},
+	};
+}
+
+Array.from(document.querySelectorAll("form[data-webauthn-options]")).forEach((form) => {
+	if (!window.PublicKeyCredential || !navigator.credentials) {
+		// No WebAuthn support,
+		// leave the form hidden.
+		return;
+	}
+
+	const options = JSON.parse(form.dataset.webauthnOptions);
+	const input = form.querySelector("input[name=credential]");
+	const button = form.querySelector("button");
+	const error = form.querySelector(".webauthn-error");
+
+	button.addEventListener("click", (e) => {
+		e.preventDefault();
+		error.classList.add("hidden");
+
+		navigator.credentials.get({
+			publicKey: parseRequestOptions(options),
+		}).then((cred) => {
+			input.value = JSON.stringify(assertionToJSON(cred));
+			form.submit();
+		}).catch((err) => {
+			error.textContent = err.message;
+			error.classList.remove("hidden");
+		});
+	});
+
+	form.classList.remove("hidden");
+});